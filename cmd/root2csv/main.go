@@ -5,6 +5,8 @@
 // root2csv converts the content of a ROOT TTree to a CSV file.
 //
 //  Usage of root2csv:
+//    -cut string
+//      	selection expression for the entries to convert (e.g. "pt > 20 && abs(eta) < 2.4")
 //    -f string
 //      	path to input ROOT file name
 //    -o string
@@ -17,6 +19,10 @@
 //  - slices/arrays
 //  - C++ objects
 //
+// root2csv only converts the entries passing the (optional) -cut selection.
+// See go-hep.org/x/hep/groot/rtree/rfunc.NewExprFormula for the syntax of
+// selection expressions.
+//
 // Example:
 //  $> root2csv -o out.csv -t tree -f testdata/small-flat-tree.root
 //  $> head out.csv
@@ -53,6 +59,7 @@ func main() {
 	fname := flag.String("f", "", "path to input ROOT file name")
 	oname := flag.String("o", "output.csv", "path to output CSV file name")
 	tname := flag.String("t", "tree", "name of the tree to convert")
+	cut := flag.String("cut", "", "selection expression for the entries to convert (e.g. \"pt > 20 && abs(eta) < 2.4\")")
 
 	flag.Parse()
 
@@ -61,13 +68,13 @@ func main() {
 		log.Fatalf("missing input ROOT filename argument")
 	}
 
-	err := process(*oname, *fname, *tname)
+	err := process(*oname, *fname, *tname, *cut)
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
-func process(oname, fname, tname string) error {
+func process(oname, fname, tname, cut string) error {
 
 	f, err := groot.Open(fname)
 	if err != nil {
@@ -116,20 +123,32 @@ func process(oname, fname, tname string) error {
 	}
 	log.Printf("scanning leaves... [done]")
 
-	sc, err := rtree.NewTreeScannerVars(tree, nt.args...)
+	r, err := rtree.NewReader(tree, nt.args)
 	if err != nil {
-		return fmt.Errorf("could not create tree scanner: %w", err)
+		return fmt.Errorf("could not create tree reader: %w", err)
 	}
-	defer sc.Close()
+	defer r.Close()
 
-	nrows := 0
-	for sc.Next() {
-		err = sc.Scan(nt.vars...)
+	sel := func() float64 { return 1 }
+	if cut != "" {
+		form, err := r.FormulaExpr(cut)
 		if err != nil {
-			return fmt.Errorf("could not scan entry %d: %w", nrows, err)
+			return fmt.Errorf("could not create selection: %w", err)
+		}
+		sel = form.Func().(func() float64)
+	}
+
+	nrows := 0
+	err = r.Read(func(ctx rtree.RCtx) error {
+		if sel() == 0 {
+			return nil
 		}
 		nt.fill()
 		nrows++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not read tree: %w", err)
 	}
 
 	tbl, err := csvutil.Create(oname)
@@ -175,7 +194,6 @@ type ntuple struct {
 	n    int64
 	cols []column
 	args []rtree.ReadVar
-	vars []interface{}
 }
 
 func (nt *ntuple) add(name string, leaf rtree.Leaf) {
@@ -187,7 +205,6 @@ func (nt *ntuple) add(name string, leaf rtree.Leaf) {
 		Leaf:  leaf.Name(),
 		Value: col.data.Addr().Interface(),
 	})
-	nt.vars = append(nt.vars, col.data.Addr().Interface())
 }
 
 func (nt *ntuple) fill() {
@@ -15,6 +15,7 @@ func TestROOT2CSV(t *testing.T) {
 	for _, tc := range []struct {
 		file string
 		tree string
+		cut  string
 		want string
 		skip bool
 	}{
@@ -23,6 +24,12 @@ func TestROOT2CSV(t *testing.T) {
 			tree: "tree",
 			want: "testdata/simple.root.csv",
 		},
+		{
+			file: "../../groot/testdata/simple.root",
+			tree: "tree",
+			cut:  "one > 1 && two < 3",
+			want: "testdata/simple.root-cut.csv",
+		},
 		{
 			file: "../../groot/testdata/leaves.root",
 			tree: "tree",
@@ -52,7 +59,7 @@ func TestROOT2CSV(t *testing.T) {
 			f.Close()
 			defer os.Remove(f.Name())
 
-			err = process(f.Name(), tc.file, tc.tree)
+			err = process(f.Name(), tc.file, tc.tree, tc.cut)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -32,6 +32,7 @@ import (
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rcmd"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/s3"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
 )
 
@@ -8,8 +8,20 @@
 //
 // ex:
 //  $> root-merge -o out.root ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
+//  $> root-merge -o out.root -fast -j 4 -exclude '^/dir-1' ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
+//  $> root-merge -o out.root -max-size 1000000000 ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
 //
 // options:
+//   -exclude string
+//     	regexp of the paths of ROOT objects to exclude from the merge
+//   -fast
+//     	enable fast mode (copy trees' compressed baskets without recompressing them)
+//   -include string
+//     	regexp of the paths of ROOT objects to include in the merge
+//   -j int
+//     	number of concurrent workers (default 1)
+//   -max-size int
+//     	maximum size in bytes of an output ROOT file before rolling over to a new one (out-1.root, ...)
 //   -o string
 //     	path to merged output ROOT file (default "out.root")
 //   -v	enable verbose mode
@@ -24,6 +36,7 @@ import (
 
 	"go-hep.org/x/hep/groot/rcmd"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/s3"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
 )
 
@@ -34,6 +47,11 @@ func main() {
 	var (
 		oname   = flag.String("o", "out.root", "path to merged output ROOT file")
 		verbose = flag.Bool("v", false, "enable verbose mode")
+		workers = flag.Int("j", 1, "number of concurrent workers")
+		incl    = flag.String("include", "", "regexp of the paths of ROOT objects to include in the merge")
+		excl    = flag.String("exclude", "", "regexp of the paths of ROOT objects to exclude from the merge")
+		fast    = flag.Bool("fast", false, "enable fast mode (copy trees' compressed baskets without recompressing them)")
+		maxSize = flag.Int64("max-size", 0, "maximum size in bytes of an output ROOT file before rolling over to a new one (out-1.root, ...)")
 	)
 
 	flag.Usage = func() {
@@ -43,6 +61,8 @@ func main() {
 
 ex:
  $> root-merge -o out.root ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
+ $> root-merge -o out.root -fast -j 4 -exclude '^/dir-1' ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
+ $> root-merge -o out.root -max-size 1000000000 ./testdata/chain.flat.1.root ./testdata/chain.flat.2.root
 
 options:
 `,
@@ -59,7 +79,19 @@ options:
 
 	fnames := flag.Args()
 
-	err := rcmd.Merge(*oname, fnames, *verbose)
+	opts := []rcmd.MergeOption{
+		rcmd.WithMergeWorkers(*workers),
+		rcmd.WithMergeFast(*fast),
+		rcmd.WithMergeMaxSize(*maxSize),
+	}
+	if *incl != "" {
+		opts = append(opts, rcmd.WithMergeInclude(*incl))
+	}
+	if *excl != "" {
+		opts = append(opts, rcmd.WithMergeExclude(*excl))
+	}
+
+	err := rcmd.Merge(*oname, fnames, *verbose, opts...)
 	if err != nil {
 		log.Fatalf("could not merge ROOT files: %+v", err)
 	}
@@ -4,6 +4,11 @@
 
 // root-print prints ROOT files contents to PDF, PNG, ... files.
 //
+// root-print can print histograms (TH1x, TH2x), graphs (TGraph, TGraphErrors,
+// TGraphAsymmErrors), efficiencies (TEfficiency), stacks (THStack),
+// multigraphs (TMultiGraph) and canvases (TCanvas).
+// The primitives of a canvas (and of its sub-pads) are overlaid on a single plot.
+//
 // Examples:
 //
 //  $> root-print -f pdf ./testdata/histos.root
@@ -37,6 +42,7 @@ import (
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/s3"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/hbook/rootcnv"
@@ -171,58 +177,147 @@ func printObject(odir, otyp string, obj root.Object, verbose bool) error {
 		log.Printf("printing %q to %s...", name, oname)
 	}
 
+	switch o := obj.(type) {
+	case rhist.Graph:
+		if name := o.Name(); name != "" {
+			p.Title.Text = name
+		}
+	}
+
+	err := draw(p, obj, 0)
+	if err != nil {
+		return fmt.Errorf("could not draw %q: %w", name, err)
+	}
+
+	p.Add(hplot.NewGrid())
+
+	//	ext := strings.ToLower(stdpath.Ext(oname))
+	//	if len(ext) > 0 {
+	//		ext = ext[1:]
+	//	}
+
+	err = p.Save(20*vg.Centimeter, -1, oname)
+	if err != nil {
+		return fmt.Errorf("could not print %q to %q: %w", name, oname, err)
+	}
+
+	return nil
+}
+
+// draw adds the provided object to the plot.
+// icol is the index of the object when several objects are overlaid
+// on the same plot (e.g. stacks, multigraphs or canvases.)
+func draw(p *hplot.Plot, obj root.Object, icol int) error {
 	switch o := obj.(type) {
 	case rhist.H2:
 		h := rootcnv.H2D(o)
 		p.Add(hplot.NewH2D(h, nil))
 
 	case rhist.H1:
+		col := colors[(icol+2)%len(colors)]
 		h := rootcnv.H1D(o)
 		hh := hplot.NewH1D(h)
-		hh.Color = colors[2]
-		hh.LineStyle.Color = colors[2]
+		hh.Color = col
+		hh.LineStyle.Color = col
 		hh.LineStyle.Width = vg.Points(1.5)
-		hh.Infos.Style = hplot.HInfoSummary
+		if icol == 0 {
+			hh.Infos.Style = hplot.HInfoSummary
+		}
 
 		p.Add(hh)
 
 	case rhist.GraphErrors:
 		h := rootcnv.S2D(o)
-		if name := h.Name(); name != "" {
-			p.Title.Text = name
-		}
 		g := hplot.NewS2D(h, hplot.WithXErrBars(true), hplot.WithYErrBars(true))
-		g.Color = colors[0]
+		g.Color = colors[icol%len(colors)]
 		p.Add(g)
 
 	case rhist.Graph:
 		h := rootcnv.S2D(o)
-		if name := h.Name(); name != "" {
-			p.Title.Text = name
-		}
 		g := hplot.NewS2D(h)
-		g.Color = colors[0]
+		g.Color = colors[icol%len(colors)]
 		p.Add(g)
 
-	default:
-		return fmt.Errorf("unknown type %T for %q", o, name)
-	}
+	case *rhist.Efficiency:
+		g := hplot.NewS2D(o.AsS2D(), hplot.WithXErrBars(true), hplot.WithYErrBars(true))
+		g.Color = colors[icol%len(colors)]
+		p.Add(g)
 
-	p.Add(hplot.NewGrid())
+	case *rhist.HStack:
+		hs := o.Hists()
+		if len(hs) == 0 {
+			return fmt.Errorf("empty THStack")
+		}
+		hists := make([]*hplot.H1D, len(hs))
+		for i, h := range hs {
+			col := colors[(icol+i)%len(colors)]
+			hh := hplot.NewH1D(rootcnv.H1D(h))
+			hh.FillColor = col
+			hh.LineStyle.Color = col
+			hists[i] = hh
+		}
+		p.Add(hplot.NewHStack(hists))
 
-	//	ext := strings.ToLower(stdpath.Ext(oname))
-	//	if len(ext) > 0 {
-	//		ext = ext[1:]
-	//	}
+	case *rhist.MultiGraph:
+		gs := o.Graphs()
+		if len(gs) == 0 {
+			return fmt.Errorf("empty TMultiGraph")
+		}
+		for i, g := range gs {
+			err := draw(p, g, icol+i)
+			if err != nil {
+				return err
+			}
+		}
 
-	err := p.Save(20*vg.Centimeter, -1, oname)
-	if err != nil {
-		return fmt.Errorf("could not print %q to %q: %w", name, oname, err)
+	case *rhist.Canvas:
+		return drawPad(p, &o.Pad)
+
+	case *rhist.Pad:
+		return drawPad(p, o)
+
+	default:
+		return fmt.Errorf("unknown type %T", o)
 	}
 
 	return nil
 }
 
+// drawPad draws the flattened list of primitives of a pad (or canvas)
+// onto the same plot.
+func drawPad(p *hplot.Plot, pad *rhist.Pad) error {
+	prims := flatten(nil, pad)
+	if len(prims) == 0 {
+		return fmt.Errorf("no drawable primitive in %s %q", pad.Class(), pad.Name())
+	}
+	for i, obj := range prims {
+		err := draw(p, obj, i)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flatten returns the list of drawable primitives of the provided pad,
+// recursively descending into sub-pads.
+func flatten(objs []root.Object, pad *rhist.Pad) []root.Object {
+	prims := pad.Primitives()
+	for i := 0; i < prims.Len(); i++ {
+		switch obj := prims.At(i).(type) {
+		case *rhist.Pad:
+			objs = flatten(objs, obj)
+		case *rhist.Canvas:
+			objs = flatten(objs, &obj.Pad)
+		default:
+			if filter(obj) {
+				objs = append(objs, obj)
+			}
+		}
+	}
+	return objs
+}
+
 func filter(obj root.Object) bool {
 	switch obj.(type) {
 	case rhist.Graph, rhist.GraphErrors:
@@ -233,6 +328,12 @@ func filter(obj root.Object) bool {
 
 	case rhist.H1:
 		return true
+
+	case *rhist.Efficiency, *rhist.HStack, *rhist.MultiGraph:
+		return true
+
+	case *rhist.Canvas, *rhist.Pad:
+		return true
 	}
 	return false
 }
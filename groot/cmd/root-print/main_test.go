@@ -11,7 +11,9 @@ import (
 	"testing"
 
 	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hbook/rootcnv"
 	"gonum.org/v1/plot/cmpimg"
@@ -225,3 +227,100 @@ func TestPrint(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintAnalysisObjects(t *testing.T) {
+	dir, err := ioutil.TempDir("", "groot-root-print-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	newH1D := func(name string, ws ...float64) *hbook.H1D {
+		h := hbook.NewH1D(len(ws), 0, float64(len(ws)))
+		h.Annotation()["name"] = name
+		for i, w := range ws {
+			h.Fill(float64(i)+0.5, w)
+		}
+		return h
+	}
+
+	fname := filepath.Join(dir, "ana.root")
+	f, err := groot.Create(fname)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer f.Close()
+
+	eff, err := rhist.NewEfficiencyFrom(
+		newH1D("eff", 1, 4, 6, 9, 3),
+		newH1D("total", 10, 10, 10, 10, 10),
+	)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	var (
+		h1 = rootcnv.FromH1D(newH1D("h1", 1, 2, 3, 4, 5))
+		h2 = rootcnv.FromH1D(newH1D("h2", 5, 4, 3, 2, 1))
+		g1 = rootcnv.FromS2D(hbook.NewS2DFrom([]float64{1, 2, 3}, []float64{1, 4, 9}))
+		g2 = rootcnv.FromS2D(hbook.NewS2DFrom([]float64{1, 2, 3}, []float64{9, 4, 1}))
+	)
+
+	for _, v := range []struct {
+		name string
+		obj  root.Object
+	}{
+		{"eff", eff},
+		{"hs", rhist.NewHStack("hs", "stack", h1, h2)},
+		{"mg", rhist.NewMultiGraph("mg", "multigraph", g1, g2)},
+		{"c1", rhist.NewCanvas("c1", "canvas",
+			h1, rhist.NewPad("c1_1", "pad", g1),
+		)},
+		{"c2", rhist.NewCanvas("c2", "empty canvas")},
+	} {
+		err = f.Put(v.name, v.obj)
+		if err != nil {
+			t.Fatalf("could not write %q: %+v", v.name, err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	odir, err := ioutil.TempDir("", "groot-root-print-out-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(odir)
+
+	const verbose = false
+	err = rootprint(odir, []string{fname + ":c2"}, "png", verbose)
+	if err == nil {
+		t.Fatalf("expected an error printing an empty canvas")
+	}
+
+	err = rootprint(odir, []string{fname + ":(eff|hs|mg|c1)"}, "png", verbose)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	for _, name := range []string{"eff.png", "hs.png", "mg.png", "c1.png"} {
+		got, err := ioutil.ReadFile(filepath.Join(odir, name))
+		if err != nil {
+			t.Fatalf("could not read file %q: %+v", name, err)
+		}
+		want, err := ioutil.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatalf("could not read ref file %q: %+v", name, err)
+		}
+		ok, err := cmpimg.Equal("png", got, want)
+		if err != nil {
+			t.Fatalf("could not compare %q: %+v", name, err)
+		}
+		if !ok {
+			t.Fatalf("file %q does not compare equal", name)
+		}
+	}
+}
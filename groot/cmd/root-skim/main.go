@@ -0,0 +1,129 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// root-skim skims and slims a tree (or a chain of trees) into an output ROOT file.
+//
+// root-skim selects the entries passing a set of cuts, keeps or drops
+// branches by glob patterns and defines new branches from expressions.
+// root-skim reports the cut-flow statistics at the end.
+//
+// Usage: root-skim [options] file1.root [file2.root [...]]
+//
+// ex:
+//  $> root-skim -o out.root -t tree -cut 'Int32 > 10' -cut 'Float64 < 50' ./testdata/small-flat-tree.root
+//  $> root-skim -o out.root -t tree -keep 'Slice*' -drop '*Int64' -define 'F2=Float64*Float64' ./testdata/small-flat-tree.root
+//  $> root-skim -o out.root -t tree -compr zlib:6 -cut 'Sum$(SliceFloat64) > 10' ./testdata/chain.1.root ./testdata/chain.2.root
+//
+// options:
+//   -compr string
+//     	compression algorithm and level of the output tree (none, lz4[:lvl], lzma[:lvl], zlib[:lvl])
+//   -cut value
+//     	selection cut expression (can be repeated)
+//   -define value
+//     	new branch defined as name=expression (can be repeated)
+//   -drop value
+//     	glob pattern of branches to drop (can be repeated)
+//   -keep value
+//     	glob pattern of branches to keep (can be repeated)
+//   -o string
+//     	path to output ROOT file (default "out.root")
+//   -t string
+//     	input tree name to skim (default "tree")
+//   -v	enable verbose mode
+package main // import "go-hep.org/x/hep/groot/cmd/root-skim"
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rcmd"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/s3"
+	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+)
+
+func main() {
+	log.SetPrefix("root-skim: ")
+	log.SetFlags(0)
+
+	var (
+		oname   = flag.String("o", "out.root", "path to output ROOT file")
+		tname   = flag.String("t", "tree", "input tree name to skim")
+		compr   = flag.String("compr", "", "compression algorithm and level of the output tree (none, lz4[:lvl], lzma[:lvl], zlib[:lvl])")
+		verbose = flag.Bool("v", false, "enable verbose mode")
+
+		cuts strs
+		keep strs
+		drop strs
+		defs strs
+	)
+
+	flag.Var(&cuts, "cut", "selection cut expression (can be repeated)")
+	flag.Var(&keep, "keep", "glob pattern of branches to keep (can be repeated)")
+	flag.Var(&drop, "drop", "glob pattern of branches to drop (can be repeated)")
+	flag.Var(&defs, "define", "new branch defined as name=expression (can be repeated)")
+
+	flag.Usage = func() {
+		fmt.Fprintf(
+			os.Stderr,
+			`Usage: root-skim [options] file1.root [file2.root [...]]
+
+ex:
+ $> root-skim -o out.root -t tree -cut 'Int32 > 10' -cut 'Float64 < 50' ./testdata/small-flat-tree.root
+ $> root-skim -o out.root -t tree -keep 'Slice*' -drop '*Int64' -define 'F2=Float64*Float64' ./testdata/small-flat-tree.root
+ $> root-skim -o out.root -t tree -compr zlib:6 -cut 'Sum$(SliceFloat64) > 10' ./testdata/chain.1.root ./testdata/chain.2.root
+
+options:
+`,
+		)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		log.Fatalf("missing input file(s)")
+	}
+
+	var opts []rcmd.SkimOption
+	for _, cut := range cuts {
+		opts = append(opts, rcmd.WithSkimCut(cut))
+	}
+	if len(keep) > 0 {
+		opts = append(opts, rcmd.WithSkimKeep(keep...))
+	}
+	if len(drop) > 0 {
+		opts = append(opts, rcmd.WithSkimDrop(drop...))
+	}
+	for _, def := range defs {
+		i := strings.Index(def, "=")
+		if i <= 0 {
+			log.Fatalf("invalid branch definition %q (want name=expression)", def)
+		}
+		opts = append(opts, rcmd.WithSkimDefine(
+			strings.TrimSpace(def[:i]),
+			strings.TrimSpace(def[i+1:]),
+		))
+	}
+	if *compr != "" {
+		opts = append(opts, rcmd.WithSkimCompression(*compr))
+	}
+
+	err := rcmd.Skim(os.Stdout, *oname, *tname, flag.Args(), *verbose, opts...)
+	if err != nil {
+		log.Fatalf("could not skim ROOT file: %+v", err)
+	}
+}
+
+type strs []string
+
+func (ss *strs) String() string { return strings.Join(*ss, ",") }
+func (ss *strs) Set(v string) error {
+	*ss = append(*ss, v)
+	return nil
+}
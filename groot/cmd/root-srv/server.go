@@ -55,6 +55,18 @@ func newServer(local bool, dir string, mux *http.ServeMux) *server {
 	mux.HandleFunc("/plot-h2", app.srv.PlotH2)
 	mux.HandleFunc("/plot-s2", app.srv.PlotS2)
 	mux.HandleFunc("/plot-branch", app.srv.PlotTree)
+	mux.HandleFunc("/data-h1", app.srv.H1Data)
+	mux.HandleFunc("/data-h2", app.srv.H2Data)
+	mux.HandleFunc("/data-tree", app.srv.TreeData)
+	mux.HandleFunc("/streamer-infos", app.srv.StreamerInfos)
+	mux.HandleFunc("/book-h1", app.srv.BookH1)
+	mux.HandleFunc("/book-h2", app.srv.BookH2)
+	mux.HandleFunc("/book-p1", app.srv.BookP1)
+	mux.HandleFunc("/hists", app.srv.ListHists)
+	mux.HandleFunc("/hist-data", app.srv.HistData)
+	mux.HandleFunc("/divide-hists", app.srv.DivideHists)
+	mux.HandleFunc("/plot-hists", app.srv.PlotHists)
+	mux.HandleFunc("/save-hists", app.srv.SaveHists)
 
 	return app
 }
@@ -0,0 +1,166 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ColumnType describes the on-disk representation of the elements of a column.
+type ColumnType int32
+
+const (
+	ColUnknown ColumnType = iota
+	ColIndex              // offsets into a collection, 32b
+	ColSwitch             // index and tag of a variant, 64b
+	ColByte               // raw bytes
+	ColBit                // booleans, packed 8 per byte
+	ColReal64
+	ColReal32
+	ColReal16
+	ColReal8
+	ColInt64
+	ColInt32
+	ColInt16
+	ColInt8
+)
+
+func (ct ColumnType) String() string {
+	switch ct {
+	case ColUnknown:
+		return "Unknown"
+	case ColIndex:
+		return "Index"
+	case ColSwitch:
+		return "Switch"
+	case ColByte:
+		return "Byte"
+	case ColBit:
+		return "Bit"
+	case ColReal64:
+		return "Real64"
+	case ColReal32:
+		return "Real32"
+	case ColReal16:
+		return "Real16"
+	case ColReal8:
+		return "Real8"
+	case ColInt64:
+		return "Int64"
+	case ColInt32:
+		return "Int32"
+	case ColInt16:
+		return "Int16"
+	case ColInt8:
+		return "Int8"
+	}
+	return fmt.Sprintf("ColumnType(%d)", int32(ct))
+}
+
+// size returns the size in bytes of an unpacked element of that column type.
+func (ct ColumnType) size() int {
+	switch ct {
+	case ColByte, ColBit, ColReal8, ColInt8:
+		return 1
+	case ColReal16, ColInt16:
+		return 2
+	case ColIndex, ColReal32, ColInt32:
+		return 4
+	case ColSwitch, ColReal64, ColInt64:
+		return 8
+	}
+	return 0
+}
+
+// packedSize returns the size in bytes of n packed elements of that column type.
+func (ct ColumnType) packedSize(n int) int {
+	if ct == ColBit {
+		return (n + 7) / 8
+	}
+	return n * ct.size()
+}
+
+// column holds the unpacked elements of a column for a whole cluster.
+type column struct {
+	typ  ColumnType
+	size int
+	data []byte
+}
+
+// readColumnRange reads and unpacks all the pages of a column range.
+func readColumnRange(r io.ReaderAt, typ ColumnType, rng ColumnRange) (*column, error) {
+	col := &column{
+		typ:  typ,
+		size: typ.size(),
+	}
+	if col.size == 0 {
+		return nil, fmt.Errorf("rntup: unsupported column type %v", typ)
+	}
+
+	col.data = make([]byte, 0, int(rng.NElements)*col.size)
+	for i, page := range rng.Pages {
+		n := int(page.NElements)
+		raw, err := readRecord(r, page.Locator.Pos, page.Locator.Bytes, typ.packedSize(n))
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not read page %d: %w", i, err)
+		}
+		col.data = append(col.data, unpackPage(typ, raw, n)...)
+	}
+	return col, nil
+}
+
+func (col *column) len() int { return len(col.data) / col.size }
+
+func (col *column) elem(i uint64) []byte {
+	beg := int(i) * col.size
+	return col.data[beg : beg+col.size]
+}
+
+func (col *column) bool(i uint64) bool   { return col.data[i] != 0 }
+func (col *column) u8(i uint64) uint8    { return col.data[i] }
+func (col *column) u16(i uint64) uint16  { return binary.LittleEndian.Uint16(col.elem(i)) }
+func (col *column) u32(i uint64) uint32  { return binary.LittleEndian.Uint32(col.elem(i)) }
+func (col *column) u64(i uint64) uint64  { return binary.LittleEndian.Uint64(col.elem(i)) }
+func (col *column) f32(i uint64) float32 { return math.Float32frombits(col.u32(i)) }
+func (col *column) f64(i uint64) float64 { return math.Float64frombits(col.u64(i)) }
+
+// index returns the half-open range of elements of the i-th entry of an
+// index column.
+func (col *column) index(i uint64) (beg, end uint64) {
+	if i > 0 {
+		beg = uint64(col.u32(i - 1))
+	}
+	end = uint64(col.u32(i))
+	return beg, end
+}
+
+// unpackPage returns the n unpacked elements of a page.
+func unpackPage(typ ColumnType, raw []byte, n int) []byte {
+	if typ != ColBit {
+		return raw
+	}
+	o := make([]byte, n)
+	for i := range o {
+		o[i] = (raw[i/8] >> uint(i%8)) & 1
+	}
+	return o
+}
+
+// packPage returns the packed representation of the unpacked elements of a page.
+func packPage(typ ColumnType, data []byte) []byte {
+	if typ != ColBit {
+		return data
+	}
+	o := make([]byte, typ.packedSize(len(data)))
+	for i, v := range data {
+		if v != 0 {
+			o[i/8] |= 1 << uint(i%8)
+		}
+	}
+	return o
+}
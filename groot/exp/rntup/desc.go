@@ -0,0 +1,565 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+)
+
+const (
+	frameVersion    = 0 // current version of the RNTuple envelopes
+	frameVersionMin = 0 // minimal version needed to read the RNTuple envelopes
+
+	noParent = ^uint64(0) // identifier of the parent of the top-level field
+)
+
+// Version describes the version of an RNTuple entity.
+type Version struct {
+	Use   uint32 // version used to write the entity
+	Min   uint32 // minimal version needed to read the entity
+	Flags uint64
+}
+
+// Structure describes how the data of a field is laid out in columns.
+type Structure uint32
+
+const (
+	Leaf       Structure = iota // a field with its own columns
+	Collection                  // a field with an index column and a sub-field
+	Record                      // a field made of sub-fields
+	Variant                     // a field holding one of its sub-fields
+	Reference                   // a field referencing another field
+)
+
+func (s Structure) String() string {
+	switch s {
+	case Leaf:
+		return "Leaf"
+	case Collection:
+		return "Collection"
+	case Record:
+		return "Record"
+	case Variant:
+		return "Variant"
+	case Reference:
+		return "Reference"
+	}
+	return fmt.Sprintf("Structure(%d)", uint32(s))
+}
+
+// Field describes a field of an RNTuple.
+type Field struct {
+	ID           uint64
+	Version      Version // version of the field
+	TypeVersion  Version // version of the type of the field
+	Name         string
+	Description  string
+	Type         string // C++ type name of the field
+	NRepetitions uint64 // number of repetitions, for fixed-size arrays
+	Structure    Structure
+	Parent       uint64   // identifier of the parent field
+	Links        []uint64 // identifiers of the sub-fields
+}
+
+// Column describes a column of an RNTuple.
+type Column struct {
+	ID      uint64
+	Version Version
+	Type    ColumnType
+	Sorted  bool
+	Field   uint64 // identifier of the field holding this column
+	Index   uint32 // index of this column within its field
+}
+
+// Locator describes where a record is stored.
+type Locator struct {
+	Pos   int64  // position of the record on file
+	Bytes uint32 // size of the record on file
+	URL   string
+}
+
+// Page describes a page of column elements.
+type Page struct {
+	NElements uint32
+	Locator   Locator
+}
+
+// ColumnRange describes the elements of a column stored in a cluster.
+type ColumnRange struct {
+	Column       uint64 // identifier of the column
+	FirstElement uint64 // index of the first element of the column in the cluster
+	NElements    uint32 // number of elements of the column in the cluster
+	Compression  int64  // compression algorithm and level of the pages
+	Pages        []Page
+}
+
+// Cluster describes a set of consecutive entries of an RNTuple.
+type Cluster struct {
+	ID         uint64
+	Version    Version
+	FirstEntry uint64
+	NEntries   uint64
+	Locator    Locator
+	Columns    []ColumnRange
+}
+
+// Descriptor describes the schema and the on-file layout of an RNTuple.
+type Descriptor struct {
+	Name        string
+	Description string
+	Author      string
+	Custodian   string
+	TimeData    uint64 // time stamp of the data
+	TimeWritten uint64 // time stamp of the write
+	Version     Version
+	UUID        string
+	GroupUUID   string
+
+	Fields   []Field
+	Columns  []Column
+	Clusters []Cluster
+}
+
+// readDescriptor reads the header and footer of the provided RNTuple.
+func readDescriptor(f io.ReaderAt, nt *NTuple) (*Descriptor, error) {
+	var desc Descriptor
+
+	hdr, err := readEnvelope(f, nt.header)
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not read header: %w", err)
+	}
+	err = desc.unmarshalHeader(hdr)
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not decode header: %w", err)
+	}
+
+	ftr, err := readEnvelope(f, nt.footer)
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not read footer: %w", err)
+	}
+	err = desc.unmarshalFooter(ftr)
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not decode footer: %w", err)
+	}
+
+	return &desc, nil
+}
+
+func readEnvelope(r io.ReaderAt, s span) ([]byte, error) {
+	buf, err := readRecord(r, int64(s.seek), s.nbytes, int(s.length))
+	if err != nil {
+		return nil, err
+	}
+	err = checkCRC32(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// readRecord reads the possibly compressed record at pos.
+func readRecord(r io.ReaderAt, pos int64, nbytes uint32, length int) ([]byte, error) {
+	var (
+		buf = make([]byte, length)
+		sr  = io.NewSectionReader(r, pos, int64(nbytes))
+	)
+	if int(nbytes) == length {
+		_, err := io.ReadFull(sr, buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+
+	err := rcompress.Decompress(buf, sr)
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Entries returns the number of entries of the RNTuple.
+func (desc *Descriptor) Entries() int64 {
+	var n int64
+	for _, c := range desc.Clusters {
+		if v := int64(c.FirstEntry + c.NEntries); v > n {
+			n = v
+		}
+	}
+	return n
+}
+
+// Field returns the field with the provided identifier.
+func (desc *Descriptor) Field(id uint64) (Field, bool) {
+	for _, f := range desc.Fields {
+		if f.ID == id {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// TopFields returns the top-level fields of the RNTuple.
+func (desc *Descriptor) TopFields() []Field {
+	root := noParent
+	for _, f := range desc.Fields {
+		if f.Parent == noParent && f.Structure == Record && f.Name == "" {
+			root = f.ID
+			break
+		}
+	}
+	return desc.subFields(root)
+}
+
+// subFields returns the fields whose parent is the provided field.
+func (desc *Descriptor) subFields(parent uint64) []Field {
+	var o []Field
+	if f, ok := desc.Field(parent); ok && len(f.Links) > 0 {
+		for _, id := range f.Links {
+			if sub, ok := desc.Field(id); ok {
+				o = append(o, sub)
+			}
+		}
+		return o
+	}
+	for _, f := range desc.Fields {
+		if f.Parent == parent && f.ID != parent {
+			o = append(o, f)
+		}
+	}
+	return o
+}
+
+// columnsOf returns the columns of the provided field, sorted by index.
+func (desc *Descriptor) columnsOf(field uint64) []Column {
+	var o []Column
+	for _, c := range desc.Columns {
+		if c.Field != field {
+			continue
+		}
+		o = append(o, c)
+	}
+	sort.Slice(o, func(i, j int) bool { return o[i].Index < o[j].Index })
+	return o
+}
+
+func (desc *Descriptor) unmarshalHeader(p []byte) error {
+	r := newRBuffer(p[:len(p)-4])
+
+	_ = r.ReadFrame()
+	_ = r.ReadU64() // feature flags
+	desc.Name = r.ReadString()
+	desc.Description = r.ReadString()
+	desc.Author = r.ReadString()
+	desc.Custodian = r.ReadString()
+	desc.TimeData = r.ReadU64()
+	desc.TimeWritten = r.ReadU64()
+	desc.Version = readVersion(r)
+	desc.UUID = readUUID(r)
+	desc.GroupUUID = readUUID(r)
+
+	n := r.ReadU32()
+	if r.Err() != nil {
+		return r.Err()
+	}
+	desc.Fields = make([]Field, 0, n)
+	for i := 0; i < int(n) && r.Err() == nil; i++ {
+		desc.Fields = append(desc.Fields, readField(r))
+	}
+
+	n = r.ReadU32()
+	if r.Err() != nil {
+		return r.Err()
+	}
+	desc.Columns = make([]Column, 0, n)
+	for i := 0; i < int(n) && r.Err() == nil; i++ {
+		desc.Columns = append(desc.Columns, readColumn(r))
+	}
+
+	return r.Err()
+}
+
+func (desc *Descriptor) marshalHeader() []byte {
+	w := new(wbuffer)
+
+	pos := w.WriteFrame()
+	w.WriteU64(0) // feature flags
+	w.WriteString(desc.Name)
+	w.WriteString(desc.Description)
+	w.WriteString(desc.Author)
+	w.WriteString(desc.Custodian)
+	w.WriteU64(desc.TimeData)
+	w.WriteU64(desc.TimeWritten)
+	writeVersion(w, desc.Version)
+	writeUUID(w, desc.UUID)
+	writeUUID(w, desc.GroupUUID)
+
+	w.WriteU32(uint32(len(desc.Fields)))
+	for _, f := range desc.Fields {
+		writeField(w, f)
+	}
+
+	w.WriteU32(uint32(len(desc.Columns)))
+	for _, c := range desc.Columns {
+		writeColumn(w, c)
+	}
+	w.SetFrameSize(pos)
+	w.WriteCRC32()
+
+	return w.Bytes()
+}
+
+func (desc *Descriptor) unmarshalFooter(p []byte) error {
+	r := newRBuffer(p[:len(p)-4])
+
+	_ = r.ReadFrame()
+	_ = r.ReadU64() // feature flags
+	n := r.ReadU64()
+	if r.Err() != nil {
+		return r.Err()
+	}
+	if n > uint64(len(p)) {
+		return fmt.Errorf("rntup: invalid number of clusters (%d)", n)
+	}
+
+	desc.Clusters = make([]Cluster, 0, n)
+	for i := 0; i < int(n) && r.Err() == nil; i++ {
+		uuid := readUUID(r)
+		if uuid != desc.UUID {
+			return fmt.Errorf("rntup: header and footer UUIDs mismatch (header=%q, footer=%q)", desc.UUID, uuid)
+		}
+		var c Cluster
+		beg := r.Pos()
+		size := r.ReadFrame()
+		c.ID = r.ReadU64()
+		c.Version = readVersion(r)
+		c.FirstEntry = r.ReadU64()
+		c.NEntries = r.ReadU64()
+		c.Locator = readLocator(r)
+		r.skip(beg, size)
+
+		ncols := r.ReadU32()
+		if r.Err() != nil {
+			return r.Err()
+		}
+		c.Columns = make([]ColumnRange, 0, ncols)
+		for j := 0; j < int(ncols) && r.Err() == nil; j++ {
+			var col ColumnRange
+			col.Column = r.ReadU64()
+			col.FirstElement = r.ReadU64()
+			col.NElements = r.ReadU32()
+			col.Compression = r.ReadI64()
+			npages := r.ReadU32()
+			if r.Err() != nil {
+				return r.Err()
+			}
+			col.Pages = make([]Page, 0, npages)
+			for k := 0; k < int(npages) && r.Err() == nil; k++ {
+				var page Page
+				page.NElements = r.ReadU32()
+				page.Locator = readLocator(r)
+				col.Pages = append(col.Pages, page)
+			}
+			c.Columns = append(c.Columns, col)
+		}
+		desc.Clusters = append(desc.Clusters, c)
+	}
+
+	// postscript
+	_ = r.ReadU16() // version
+	_ = r.ReadU16() // minimal version
+	_ = r.ReadU32() // size of the header
+	_ = r.ReadU32() // size of the footer
+
+	return r.Err()
+}
+
+func (desc *Descriptor) marshalFooter(hdrsize uint32) []byte {
+	w := new(wbuffer)
+
+	pos := w.WriteFrame()
+	w.WriteU64(0) // feature flags
+	w.WriteU64(uint64(len(desc.Clusters)))
+	for _, c := range desc.Clusters {
+		writeUUID(w, desc.UUID)
+		beg := w.WriteFrame()
+		w.WriteU64(c.ID)
+		writeVersion(w, c.Version)
+		w.WriteU64(c.FirstEntry)
+		w.WriteU64(c.NEntries)
+		writeLocator(w, c.Locator)
+		w.SetFrameSize(beg)
+
+		w.WriteU32(uint32(len(c.Columns)))
+		for _, col := range c.Columns {
+			w.WriteU64(col.Column)
+			w.WriteU64(col.FirstElement)
+			w.WriteU32(col.NElements)
+			w.WriteI64(col.Compression)
+			w.WriteU32(uint32(len(col.Pages)))
+			for _, page := range col.Pages {
+				w.WriteU32(page.NElements)
+				writeLocator(w, page.Locator)
+			}
+		}
+	}
+
+	// postscript
+	w.WriteU16(frameVersion)
+	w.WriteU16(frameVersionMin)
+	w.WriteU32(hdrsize)
+	w.WriteU32(uint32(w.Pos() + 4 + 4)) // size of the footer, including CRC32
+	w.SetFrameSize(pos)
+	w.WriteCRC32()
+
+	return w.Bytes()
+}
+
+// skip moves the read cursor at the end of the frame started at beg.
+func (r *rbuffer) skip(beg int, size uint32) {
+	if r.err != nil || size == 0 {
+		return
+	}
+	end := beg + int(size)
+	if end < r.c || end > len(r.p) {
+		r.err = fmt.Errorf("rntup: invalid frame size %d at %d", size, beg)
+		return
+	}
+	r.c = end
+}
+
+func readVersion(r *rbuffer) Version {
+	var v Version
+	beg := r.Pos()
+	size := r.ReadFrame()
+	v.Use = r.ReadU32()
+	v.Min = r.ReadU32()
+	v.Flags = r.ReadU64()
+	r.skip(beg, size)
+	return v
+}
+
+func writeVersion(w *wbuffer, v Version) {
+	pos := w.WriteFrame()
+	w.WriteU32(v.Use)
+	w.WriteU32(v.Min)
+	w.WriteU64(v.Flags)
+	w.SetFrameSize(pos)
+}
+
+func readUUID(r *rbuffer) string {
+	beg := r.Pos()
+	size := r.ReadFrame()
+	v := r.ReadString()
+	r.skip(beg, size)
+	return v
+}
+
+func writeUUID(w *wbuffer, v string) {
+	pos := w.WriteFrame()
+	w.WriteString(v)
+	w.SetFrameSize(pos)
+}
+
+func readLocator(r *rbuffer) Locator {
+	var loc Locator
+	loc.Pos = r.ReadI64()
+	loc.Bytes = r.ReadU32()
+	loc.URL = r.ReadString()
+	return loc
+}
+
+func writeLocator(w *wbuffer, loc Locator) {
+	w.WriteI64(loc.Pos)
+	w.WriteU32(loc.Bytes)
+	w.WriteString(loc.URL)
+}
+
+func readField(r *rbuffer) Field {
+	var f Field
+	beg := r.Pos()
+	size := r.ReadFrame()
+	f.ID = r.ReadU64()
+	f.Version = readVersion(r)
+	f.TypeVersion = readVersion(r)
+	f.Name = r.ReadString()
+	f.Description = r.ReadString()
+	f.Type = r.ReadString()
+	f.NRepetitions = r.ReadU64()
+	f.Structure = Structure(r.ReadU32())
+	f.Parent = r.ReadU64()
+	n := r.ReadU32()
+	if r.Err() != nil {
+		return f
+	}
+	if n > 0 {
+		f.Links = make([]uint64, n)
+		for i := range f.Links {
+			f.Links[i] = r.ReadU64()
+		}
+	}
+	r.skip(beg, size)
+	return f
+}
+
+func writeField(w *wbuffer, f Field) {
+	pos := w.WriteFrame()
+	w.WriteU64(f.ID)
+	writeVersion(w, f.Version)
+	writeVersion(w, f.TypeVersion)
+	w.WriteString(f.Name)
+	w.WriteString(f.Description)
+	w.WriteString(f.Type)
+	w.WriteU64(f.NRepetitions)
+	w.WriteU32(uint32(f.Structure))
+	w.WriteU64(f.Parent)
+	w.WriteU32(uint32(len(f.Links)))
+	for _, id := range f.Links {
+		w.WriteU64(id)
+	}
+	w.SetFrameSize(pos)
+}
+
+func readColumn(r *rbuffer) Column {
+	var c Column
+	beg := r.Pos()
+	size := r.ReadFrame()
+	c.ID = r.ReadU64()
+	c.Version = readVersion(r)
+	{
+		beg := r.Pos()
+		size := r.ReadFrame()
+		c.Type = ColumnType(r.ReadI32())
+		c.Sorted = r.ReadI32() != 0
+		r.skip(beg, size)
+	}
+	c.Field = r.ReadU64()
+	c.Index = r.ReadU32()
+	r.skip(beg, size)
+	return c
+}
+
+func writeColumn(w *wbuffer, c Column) {
+	pos := w.WriteFrame()
+	w.WriteU64(c.ID)
+	writeVersion(w, c.Version)
+	{
+		pos := w.WriteFrame()
+		w.WriteI32(int32(c.Type))
+		sorted := int32(0)
+		if c.Sorted {
+			sorted = 1
+		}
+		w.WriteI32(sorted)
+		w.SetFrameSize(pos)
+	}
+	w.WriteU64(c.Field)
+	w.WriteU32(c.Index)
+	w.SetFrameSize(pos)
+}
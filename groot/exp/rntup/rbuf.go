@@ -0,0 +1,165 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// rbuffer reads the little-endian encoded RNTuple envelopes.
+type rbuffer struct {
+	p   []byte
+	c   int
+	err error
+}
+
+func newRBuffer(p []byte) *rbuffer {
+	return &rbuffer{p: p}
+}
+
+func (r *rbuffer) Err() error { return r.err }
+func (r *rbuffer) Pos() int   { return r.c }
+
+func (r *rbuffer) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.c+n > len(r.p) {
+		r.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	p := r.p[r.c : r.c+n]
+	r.c += n
+	return p
+}
+
+func (r *rbuffer) ReadU16() uint16 {
+	p := r.next(2)
+	if p == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(p)
+}
+
+func (r *rbuffer) ReadU32() uint32 {
+	p := r.next(4)
+	if p == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(p)
+}
+
+func (r *rbuffer) ReadU64() uint64 {
+	p := r.next(8)
+	if p == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(p)
+}
+
+func (r *rbuffer) ReadI32() int32 { return int32(r.ReadU32()) }
+func (r *rbuffer) ReadI64() int64 { return int64(r.ReadU64()) }
+
+func (r *rbuffer) ReadString() string {
+	n := r.ReadU32()
+	if r.err != nil {
+		return ""
+	}
+	if n > uint32(len(r.p)-r.c) {
+		r.err = fmt.Errorf("rntup: invalid string length %d", n)
+		return ""
+	}
+	return string(r.next(int(n)))
+}
+
+// ReadFrame reads a frame preamble and returns the size of the frame
+// (preamble included), or an error if the frame requires a newer version
+// of the format than the one this package implements.
+func (r *rbuffer) ReadFrame() uint32 {
+	beg := r.c
+	_ = r.ReadU16() // version
+	min := r.ReadU16()
+	size := r.ReadU32()
+	if r.err != nil {
+		return 0
+	}
+	if min > frameVersion {
+		r.err = fmt.Errorf("rntup: frame at %d requires version %d (max=%d)", beg, min, frameVersion)
+		return 0
+	}
+	return size
+}
+
+// wbuffer writes the little-endian encoded RNTuple envelopes.
+type wbuffer struct {
+	p []byte
+}
+
+func (w *wbuffer) Bytes() []byte { return w.p }
+func (w *wbuffer) Pos() int      { return len(w.p) }
+
+func (w *wbuffer) WriteU16(v uint16) {
+	var p [2]byte
+	binary.LittleEndian.PutUint16(p[:], v)
+	w.p = append(w.p, p[:]...)
+}
+
+func (w *wbuffer) WriteU32(v uint32) {
+	var p [4]byte
+	binary.LittleEndian.PutUint32(p[:], v)
+	w.p = append(w.p, p[:]...)
+}
+
+func (w *wbuffer) WriteU64(v uint64) {
+	var p [8]byte
+	binary.LittleEndian.PutUint64(p[:], v)
+	w.p = append(w.p, p[:]...)
+}
+
+func (w *wbuffer) WriteI32(v int32) { w.WriteU32(uint32(v)) }
+func (w *wbuffer) WriteI64(v int64) { w.WriteU64(uint64(v)) }
+
+func (w *wbuffer) WriteString(v string) {
+	w.WriteU32(uint32(len(v)))
+	w.p = append(w.p, v...)
+}
+
+// WriteFrame writes a frame preamble and returns its position, to be
+// later used with SetFrameSize.
+func (w *wbuffer) WriteFrame() int {
+	pos := w.Pos()
+	w.WriteU16(frameVersion)
+	w.WriteU16(frameVersionMin)
+	w.WriteU32(0)
+	return pos
+}
+
+// SetFrameSize sets the size of the frame started at pos.
+func (w *wbuffer) SetFrameSize(pos int) {
+	binary.LittleEndian.PutUint32(w.p[pos+4:], uint32(w.Pos()-pos))
+}
+
+// WriteCRC32 appends the CRC32 checksum of the whole buffer.
+func (w *wbuffer) WriteCRC32() {
+	w.WriteU32(crc32.ChecksumIEEE(w.p))
+}
+
+func checkCRC32(p []byte) error {
+	if len(p) < 4 {
+		return io.ErrUnexpectedEOF
+	}
+	var (
+		n    = len(p) - 4
+		want = binary.LittleEndian.Uint32(p[n:])
+		got  = crc32.ChecksumIEEE(p[:n])
+	)
+	if got != want {
+		return fmt.Errorf("rntup: invalid CRC32 checksum (got=0x%x, want=0x%x)", got, want)
+	}
+	return nil
+}
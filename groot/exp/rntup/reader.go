@@ -0,0 +1,413 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Reader reads data from an RNTuple.
+type Reader struct {
+	nt   *NTuple
+	desc *Descriptor
+	beg  int64
+	end  int64
+
+	rvars  []ReadVar
+	rfuncs []rfunc
+	dsts   []reflect.Value
+	tops   []int // slots of the first column of each read-var, if any
+
+	ids  []uint64  // identifiers of the columns to load
+	cols []*column // columns of the current cluster, indexed as ids
+}
+
+// rfunc reads the i-th element of a field, relative to the current
+// cluster, into dst.
+type rfunc func(i uint64, dst reflect.Value)
+
+// ReadOption configures how an RNTuple should be traversed.
+type ReadOption func(r *Reader) error
+
+// WithRange specifies the half-open interval [beg, end) of entries
+// an RNTuple reader will read through.
+func WithRange(beg, end int64) ReadOption {
+	return func(r *Reader) error {
+		r.beg = beg
+		r.end = end
+		return nil
+	}
+}
+
+// NewReader creates a new RNTuple Reader from the provided RNTuple and
+// the set of read-variables into which data will be read.
+// If rvars is empty, all the fields of the RNTuple are read.
+func NewReader(nt *NTuple, rvars []ReadVar, opts ...ReadOption) (*Reader, error) {
+	desc, err := nt.Descriptor()
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not create reader: %w", err)
+	}
+
+	r := Reader{
+		nt:   nt,
+		desc: desc,
+		beg:  0,
+		end:  -1,
+	}
+
+	for i, opt := range opts {
+		err := opt(&r)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"rntup: could not set reader option %d: %w",
+				i, err,
+			)
+		}
+	}
+
+	n := desc.Entries()
+	if r.end < 0 {
+		r.end = n
+	}
+
+	switch {
+	case r.beg < 0:
+		return nil, fmt.Errorf("rntup: invalid event reader range [%d, %d) (start=%d < 0)",
+			r.beg, r.end, r.beg,
+		)
+	case r.beg > r.end:
+		return nil, fmt.Errorf("rntup: invalid event reader range [%d, %d) (start=%d > end=%d)",
+			r.beg, r.end, r.beg, r.end,
+		)
+	case r.end > n:
+		return nil, fmt.Errorf("rntup: invalid event reader range [%d, %d) (end=%d > ntuple-entries=%d)",
+			r.beg, r.end, r.end, n,
+		)
+	}
+
+	if len(rvars) == 0 {
+		rvars = NewReadVars(desc)
+	}
+
+	top := make(map[string]Field)
+	for _, f := range desc.TopFields() {
+		top[f.Name] = f
+	}
+
+	for _, rvar := range rvars {
+		f, ok := top[rvar.Name]
+		if !ok {
+			return nil, fmt.Errorf("rntup: could not find field %q", rvar.Name)
+		}
+		rv := reflect.ValueOf(rvar.Value)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return nil, fmt.Errorf("rntup: read-var %q needs a non-nil pointer value (got %T)", rvar.Name, rvar.Value)
+		}
+		fct, err := r.rfuncFor(f, rv.Elem().Type())
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not bind read-var %q: %w", rvar.Name, err)
+		}
+		top := -1
+		if cols := desc.columnsOf(f.ID); len(cols) > 0 {
+			top = r.slot(cols[0].ID)
+		}
+		r.rvars = append(r.rvars, rvar)
+		r.rfuncs = append(r.rfuncs, fct)
+		r.dsts = append(r.dsts, rv.Elem())
+		r.tops = append(r.tops, top)
+	}
+
+	return &r, nil
+}
+
+// Descriptor returns the descriptor of the underlying RNTuple.
+func (r *Reader) Descriptor() *Descriptor { return r.desc }
+
+// ReadVars returns the read-variables bound to this reader.
+func (r *Reader) ReadVars() []ReadVar { return r.rvars }
+
+// Close closes the Reader.
+func (r *Reader) Close() error {
+	r.cols = nil
+	r.rfuncs = nil
+	r.dsts = nil
+	return nil
+}
+
+// RCtx provides an entry-wise local context to the RNTuple Reader.
+type RCtx struct {
+	Entry int64 // Current RNTuple entry.
+}
+
+// Read will read data from the underlying RNTuple over the whole specified range.
+// Read calls the provided user function f for each entry successfully read.
+func (r *Reader) Read(f func(ctx RCtx) error) error {
+	clusters := make([]Cluster, len(r.desc.Clusters))
+	copy(clusters, r.desc.Clusters)
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].FirstEntry < clusters[j].FirstEntry
+	})
+
+	for _, cluster := range clusters {
+		var (
+			cbeg = int64(cluster.FirstEntry)
+			cend = cbeg + int64(cluster.NEntries)
+		)
+		if cend <= r.beg || cbeg >= r.end {
+			continue
+		}
+
+		err := r.load(cluster)
+		if err != nil {
+			return fmt.Errorf("rntup: could not load cluster %d: %w", cluster.ID, err)
+		}
+
+		beg := cbeg
+		if beg < r.beg {
+			beg = r.beg
+		}
+		end := cend
+		if end > r.end {
+			end = r.end
+		}
+
+		for entry := beg; entry < end; entry++ {
+			i := uint64(entry - cbeg)
+			for j, fct := range r.rfuncs {
+				fct(i, r.dsts[j])
+			}
+			err = f(RCtx{Entry: entry})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// load reads all the pages of the needed columns for the provided cluster.
+func (r *Reader) load(cluster Cluster) error {
+	rngs := make(map[uint64]ColumnRange, len(cluster.Columns))
+	for _, rng := range cluster.Columns {
+		rngs[rng.Column] = rng
+	}
+
+	for i, id := range r.ids {
+		var typ ColumnType
+		for _, col := range r.desc.Columns {
+			if col.ID == id {
+				typ = col.Type
+				break
+			}
+		}
+
+		col, err := readColumnRange(r.nt.f, typ, rngs[id])
+		if err != nil {
+			return fmt.Errorf("could not read column %d: %w", id, err)
+		}
+		r.cols[i] = col
+	}
+
+	for i, slot := range r.tops {
+		if slot < 0 {
+			continue
+		}
+		if n := r.cols[slot].len(); uint64(n) < cluster.NEntries {
+			return fmt.Errorf(
+				"field %q holds %d elements (want=%d)",
+				r.rvars[i].Name, n, cluster.NEntries,
+			)
+		}
+	}
+	return nil
+}
+
+// slot returns the index of the provided column in the list of columns
+// to load, registering it if needed.
+func (r *Reader) slot(id uint64) int {
+	for i, v := range r.ids {
+		if v == id {
+			return i
+		}
+	}
+	r.ids = append(r.ids, id)
+	r.cols = append(r.cols, nil)
+	return len(r.ids) - 1
+}
+
+// rfuncFor returns the function reading the elements of the provided
+// field into values of type rt.
+func (r *Reader) rfuncFor(f Field, rt reflect.Type) (rfunc, error) {
+	if f.NRepetitions > 0 {
+		return nil, fmt.Errorf("fixed-size array field %q not supported", f.Name)
+	}
+
+	cols := r.desc.columnsOf(f.ID)
+	switch f.Structure {
+	case Leaf:
+		if rt.Kind() == reflect.String {
+			return r.rfuncString(f, rt, cols)
+		}
+		return r.rfuncLeaf(f, rt, cols)
+
+	case Collection:
+		if rt.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("field %q is a collection, not a %v", f.Name, rt)
+		}
+		subs := r.desc.subFields(f.ID)
+		if len(cols) != 1 || cols[0].Type != ColIndex || len(subs) != 1 {
+			return nil, fmt.Errorf("invalid collection field %q", f.Name)
+		}
+		elt, err := r.rfuncFor(subs[0], rt.Elem())
+		if err != nil {
+			return nil, err
+		}
+		idx := r.slot(cols[0].ID)
+		return func(i uint64, dst reflect.Value) {
+			beg, end := r.cols[idx].index(i)
+			n := int(end - beg)
+			if dst.Cap() < n {
+				dst.Set(reflect.MakeSlice(rt, n, n))
+			}
+			dst.SetLen(n)
+			for j := 0; j < n; j++ {
+				elt(beg+uint64(j), dst.Index(j))
+			}
+		}, nil
+
+	case Record:
+		if rt.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("field %q is a record, not a %v", f.Name, rt)
+		}
+		subs := make(map[string]Field)
+		for _, sub := range r.desc.subFields(f.ID) {
+			subs[sub.Name] = sub
+		}
+		var (
+			idxs  []int
+			fcts  []rfunc
+			nflds = rt.NumField()
+		)
+		for i := 0; i < nflds; i++ {
+			ft := rt.Field(i)
+			if ft.PkgPath != "" {
+				// not exported. ignore.
+				continue
+			}
+			sub, ok := subs[nameOf(ft)]
+			if !ok {
+				return nil, fmt.Errorf("could not find sub-field %q of record %q", nameOf(ft), f.Name)
+			}
+			fct, err := r.rfuncFor(sub, ft.Type)
+			if err != nil {
+				return nil, err
+			}
+			idxs = append(idxs, i)
+			fcts = append(fcts, fct)
+		}
+		return func(i uint64, dst reflect.Value) {
+			for j, fct := range fcts {
+				fct(i, dst.Field(idxs[j]))
+			}
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported structure %v for field %q", f.Structure, f.Name)
+}
+
+func (r *Reader) rfuncString(f Field, rt reflect.Type, cols []Column) (rfunc, error) {
+	if len(cols) != 2 || cols[0].Type != ColIndex || cols[1].Type != ColByte {
+		return nil, fmt.Errorf("invalid string field %q", f.Name)
+	}
+	var (
+		idx   = r.slot(cols[0].ID)
+		chars = r.slot(cols[1].ID)
+	)
+	return func(i uint64, dst reflect.Value) {
+		beg, end := r.cols[idx].index(i)
+		dst.SetString(string(r.cols[chars].data[beg:end]))
+	}, nil
+}
+
+func (r *Reader) rfuncLeaf(f Field, rt reflect.Type, cols []Column) (rfunc, error) {
+	if len(cols) != 1 {
+		return nil, fmt.Errorf("invalid number of columns for field %q (n=%d)", f.Name, len(cols))
+	}
+	var (
+		typ = cols[0].Type
+		idx = r.slot(cols[0].ID)
+	)
+
+	switch kind := rt.Kind(); kind {
+	case reflect.Bool:
+		if typ != ColBit {
+			break
+		}
+		return func(i uint64, dst reflect.Value) {
+			dst.SetBool(r.cols[idx].bool(i))
+		}, nil
+
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case typ == ColInt8 && kind == reflect.Int8:
+			return func(i uint64, dst reflect.Value) {
+				dst.SetInt(int64(int8(r.cols[idx].u8(i))))
+			}, nil
+		case typ == ColInt16 && kind == reflect.Int16:
+			return func(i uint64, dst reflect.Value) {
+				dst.SetInt(int64(int16(r.cols[idx].u16(i))))
+			}, nil
+		case typ == ColInt32 && kind == reflect.Int32:
+			return func(i uint64, dst reflect.Value) {
+				dst.SetInt(int64(int32(r.cols[idx].u32(i))))
+			}, nil
+		case typ == ColInt64 && kind == reflect.Int64:
+			return func(i uint64, dst reflect.Value) {
+				dst.SetInt(int64(r.cols[idx].u64(i)))
+			}, nil
+		}
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch {
+		case (typ == ColInt8 || typ == ColByte) && kind == reflect.Uint8:
+			return func(i uint64, dst reflect.Value) {
+				dst.SetUint(uint64(r.cols[idx].u8(i)))
+			}, nil
+		case typ == ColInt16 && kind == reflect.Uint16:
+			return func(i uint64, dst reflect.Value) {
+				dst.SetUint(uint64(r.cols[idx].u16(i)))
+			}, nil
+		case typ == ColInt32 && kind == reflect.Uint32:
+			return func(i uint64, dst reflect.Value) {
+				dst.SetUint(uint64(r.cols[idx].u32(i)))
+			}, nil
+		case typ == ColInt64 && kind == reflect.Uint64:
+			return func(i uint64, dst reflect.Value) {
+				dst.SetUint(r.cols[idx].u64(i))
+			}, nil
+		}
+
+	case reflect.Float32:
+		if typ != ColReal32 {
+			break
+		}
+		return func(i uint64, dst reflect.Value) {
+			dst.SetFloat(float64(r.cols[idx].f32(i)))
+		}, nil
+
+	case reflect.Float64:
+		if typ != ColReal64 {
+			break
+		}
+		return func(i uint64, dst reflect.Value) {
+			dst.SetFloat(r.cols[idx].f64(i))
+		}, nil
+	}
+
+	return nil, fmt.Errorf("can not read field %q (type=%q, column=%v) into a %v", f.Name, f.Type, typ, rt)
+}
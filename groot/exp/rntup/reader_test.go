@@ -0,0 +1,259 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestDescriptor(t *testing.T) {
+	f, err := riofs.Open("../../testdata/ntpl001_staff.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("Staff")
+	if err != nil {
+		t.Fatalf("could not get ntuple: %+v", err)
+	}
+
+	desc, err := obj.(*NTuple).Descriptor()
+	if err != nil {
+		t.Fatalf("could not read descriptor: %+v", err)
+	}
+
+	if got, want := desc.Name, "Staff"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	if got, want := desc.Entries(), int64(3354); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	if got, want := len(desc.Fields), 12; got != want {
+		t.Fatalf("invalid number of fields: got=%d, want=%d", got, want)
+	}
+
+	if got, want := len(desc.Columns), 13; got != want {
+		t.Fatalf("invalid number of columns: got=%d, want=%d", got, want)
+	}
+
+	if got, want := len(desc.Clusters), 1; got != want {
+		t.Fatalf("invalid number of clusters: got=%d, want=%d", got, want)
+	}
+
+	var names []string
+	for _, f := range desc.TopFields() {
+		names = append(names, f.Name)
+	}
+	want := []string{
+		"Category", "Flag", "Age", "Service", "Children", "Grade",
+		"Step", "Hrweek", "Cost", "Division", "Nation",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("invalid top-level fields:\ngot= %q\nwant=%q", names, want)
+	}
+
+	nation, ok := desc.Field(11)
+	if !ok {
+		t.Fatalf("could not find field 11")
+	}
+	if got, want := nation.Type, "std::string"; got != want {
+		t.Fatalf("invalid type: got=%q, want=%q", got, want)
+	}
+	cols := desc.columnsOf(nation.ID)
+	if got, want := []ColumnType{cols[0].Type, cols[1].Type}, []ColumnType{ColIndex, ColByte}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid columns: got=%v, want=%v", got, want)
+	}
+}
+
+func TestReader(t *testing.T) {
+	f, err := riofs.Open("../../testdata/ntpl001_staff.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("Staff")
+	if err != nil {
+		t.Fatalf("could not get ntuple: %+v", err)
+	}
+
+	type Staff struct {
+		Category int32  `groot:"Category"`
+		Flag     uint32 `groot:"Flag"`
+		Age      int32  `groot:"Age"`
+		Service  int32  `groot:"Service"`
+		Children int32  `groot:"Children"`
+		Grade    int32  `groot:"Grade"`
+		Step     int32  `groot:"Step"`
+		Hrweek   int32  `groot:"Hrweek"`
+		Cost     int32  `groot:"Cost"`
+		Division string `groot:"Division"`
+		Nation   string `groot:"Nation"`
+	}
+
+	var (
+		data  Staff
+		rvars = []ReadVar{
+			{Name: "Category", Value: &data.Category},
+			{Name: "Flag", Value: &data.Flag},
+			{Name: "Age", Value: &data.Age},
+			{Name: "Service", Value: &data.Service},
+			{Name: "Children", Value: &data.Children},
+			{Name: "Grade", Value: &data.Grade},
+			{Name: "Step", Value: &data.Step},
+			{Name: "Hrweek", Value: &data.Hrweek},
+			{Name: "Cost", Value: &data.Cost},
+			{Name: "Division", Value: &data.Division},
+			{Name: "Nation", Value: &data.Nation},
+		}
+	)
+
+	for _, tc := range []struct {
+		beg, end int64
+		want     map[int64]Staff
+		n        int64
+	}{
+		{
+			beg: 0, end: -1, n: 3354,
+			want: map[int64]Staff{
+				0:    {202, 15, 58, 28, 0, 10, 13, 40, 11975, "PS", "DE"},
+				1:    {530, 15, 63, 33, 0, 9, 13, 40, 10228, "EP", "CH"},
+				2:    {316, 15, 56, 31, 2, 9, 13, 40, 10730, "PS", "FR"},
+				3353: {500, 5, 43, 0, 2, 12, 4, 40, 12716, "DG", "ZZ"},
+			},
+		},
+		{
+			beg: 1, end: 3, n: 2,
+			want: map[int64]Staff{
+				1: {530, 15, 63, 33, 0, 9, 13, 40, 10228, "EP", "CH"},
+				2: {316, 15, 56, 31, 2, 9, 13, 40, 10730, "PS", "FR"},
+			},
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			r, err := NewReader(obj.(*NTuple), rvars, WithRange(tc.beg, tc.end))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			n := int64(0)
+			err = r.Read(func(ctx RCtx) error {
+				n++
+				want, ok := tc.want[ctx.Entry]
+				if !ok {
+					return nil
+				}
+				if got := data; got != want {
+					t.Fatalf("invalid entry %d:\ngot= %+v\nwant=%+v", ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read ntuple: %+v", err)
+			}
+
+			if n != tc.n {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", n, tc.n)
+			}
+		})
+	}
+}
+
+func TestReaderAllFields(t *testing.T) {
+	f, err := riofs.Open("../../testdata/ntpl001_staff.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("Staff")
+	if err != nil {
+		t.Fatalf("could not get ntuple: %+v", err)
+	}
+
+	r, err := NewReader(obj.(*NTuple), nil, WithRange(0, 1))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx RCtx) error {
+		got := make(map[string]interface{})
+		for _, rvar := range r.ReadVars() {
+			got[rvar.Name] = rvar.Deref()
+		}
+		want := map[string]interface{}{
+			"Category": int32(202),
+			"Flag":     uint32(15),
+			"Age":      int32(58),
+			"Service":  int32(28),
+			"Children": int32(0),
+			"Grade":    int32(10),
+			"Step":     int32(13),
+			"Hrweek":   int32(40),
+			"Cost":     int32(11975),
+			"Division": "PS",
+			"Nation":   "DE",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid entry:\ngot= %v\nwant=%v", got, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read ntuple: %+v", err)
+	}
+}
+
+func TestReaderInvalid(t *testing.T) {
+	f, err := riofs.Open("../../testdata/ntpl001_staff.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("Staff")
+	if err != nil {
+		t.Fatalf("could not get ntuple: %+v", err)
+	}
+	nt := obj.(*NTuple)
+
+	for _, tc := range []struct {
+		name  string
+		rvars []ReadVar
+		opts  []ReadOption
+	}{
+		{
+			name:  "no-such-field",
+			rvars: []ReadVar{{Name: "NoSuchField", Value: new(int32)}},
+		},
+		{
+			name:  "invalid-type",
+			rvars: []ReadVar{{Name: "Age", Value: new(float64)}},
+		},
+		{
+			name:  "not-a-pointer",
+			rvars: []ReadVar{{Name: "Age", Value: int32(0)}},
+		},
+		{
+			name: "invalid-range",
+			opts: []ReadOption{WithRange(0, 3355)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReader(nt, tc.rvars, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
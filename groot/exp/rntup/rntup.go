@@ -10,8 +10,10 @@ import (
 	"reflect"
 
 	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
 )
 
 type span struct {
@@ -28,6 +30,8 @@ type NTuple struct {
 	footer span
 
 	reserved uint64
+
+	f *riofs.File // underlying file
 }
 
 func (*NTuple) Class() string {
@@ -35,7 +39,18 @@ func (*NTuple) Class() string {
 }
 
 func (*NTuple) RVersion() int16 {
-	return 0 // FIXME(sbinet): generate through gen.rboot
+	return rvers.ROOT_Experimental_RNTuple
+}
+
+// SetFile implements riofs.SetFiler.
+func (nt *NTuple) SetFile(f *riofs.File) { nt.f = f }
+
+// Descriptor reads and returns the descriptor of the RNTuple.
+func (nt *NTuple) Descriptor() (*Descriptor, error) {
+	if nt.f == nil {
+		return nil, fmt.Errorf("rntup: no file attached to RNTuple")
+	}
+	return readDescriptor(nt.f, nt)
 }
 
 func (nt *NTuple) String() string {
@@ -107,4 +122,5 @@ var (
 	_ rbytes.RVersioner  = (*NTuple)(nil)
 	_ rbytes.Marshaler   = (*NTuple)(nil)
 	_ rbytes.Unmarshaler = (*NTuple)(nil)
+	_ riofs.SetFiler     = (*NTuple)(nil)
 )
@@ -18,7 +18,13 @@ func TestNTuple(t *testing.T) {
 		want rtests.ROOTer
 	}{
 		{
-			want: &NTuple{1, 2, span{1, 2, 3}, span{4, 5, 6}, 7},
+			want: &NTuple{
+				rvers:    1,
+				size:     2,
+				header:   span{1, 2, 3},
+				footer:   span{4, 5, 6},
+				reserved: 7,
+			},
 		},
 	} {
 		t.Run("", func(t *testing.T) {
@@ -75,6 +81,7 @@ func TestReadNTuple(t *testing.T) {
 			length: 804,
 		},
 		reserved: 0,
+		f:        f,
 	}
 
 	if got, want := *nt, want; got != want {
@@ -0,0 +1,144 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// ReadVar describes a field to be read out of an RNTuple.
+type ReadVar struct {
+	Name  string      // name of the top-level field to read
+	Value interface{} // pointer to the value to fill
+}
+
+// NewReadVars returns the complete set of ReadVars to read all the data
+// contained in the provided RNTuple descriptor.
+// Fields whose type can not be represented in Go are ignored.
+func NewReadVars(desc *Descriptor) []ReadVar {
+	var vars []ReadVar
+	for _, f := range desc.TopFields() {
+		rt, err := typeOf(desc, f)
+		if err != nil {
+			continue
+		}
+		vars = append(vars, ReadVar{
+			Name:  f.Name,
+			Value: reflect.New(rt).Interface(),
+		})
+	}
+	return vars
+}
+
+// Deref returns the value pointed at by this read-var.
+func (rv ReadVar) Deref() interface{} {
+	return reflect.ValueOf(rv.Value).Elem().Interface()
+}
+
+var (
+	cxx2go = map[string]reflect.Type{
+		"bool":               reflect.TypeOf(false),
+		"char":               reflect.TypeOf(int8(0)),
+		"std::int8_t":        reflect.TypeOf(int8(0)),
+		"std::uint8_t":       reflect.TypeOf(uint8(0)),
+		"unsigned char":      reflect.TypeOf(uint8(0)),
+		"std::int16_t":       reflect.TypeOf(int16(0)),
+		"short":              reflect.TypeOf(int16(0)),
+		"std::uint16_t":      reflect.TypeOf(uint16(0)),
+		"unsigned short":     reflect.TypeOf(uint16(0)),
+		"std::int32_t":       reflect.TypeOf(int32(0)),
+		"int":                reflect.TypeOf(int32(0)),
+		"std::uint32_t":      reflect.TypeOf(uint32(0)),
+		"unsigned int":       reflect.TypeOf(uint32(0)),
+		"std::int64_t":       reflect.TypeOf(int64(0)),
+		"long":               reflect.TypeOf(int64(0)),
+		"long long":          reflect.TypeOf(int64(0)),
+		"std::uint64_t":      reflect.TypeOf(uint64(0)),
+		"unsigned long":      reflect.TypeOf(uint64(0)),
+		"unsigned long long": reflect.TypeOf(uint64(0)),
+		"float":              reflect.TypeOf(float32(0)),
+		"double":             reflect.TypeOf(float64(0)),
+		"std::string":        reflect.TypeOf(""),
+	}
+
+	go2cxx = map[reflect.Kind]string{
+		reflect.Bool:    "bool",
+		reflect.Int8:    "std::int8_t",
+		reflect.Int16:   "std::int16_t",
+		reflect.Int32:   "std::int32_t",
+		reflect.Int64:   "std::int64_t",
+		reflect.Uint8:   "std::uint8_t",
+		reflect.Uint16:  "std::uint16_t",
+		reflect.Uint32:  "std::uint32_t",
+		reflect.Uint64:  "std::uint64_t",
+		reflect.Float32: "float",
+		reflect.Float64: "double",
+		reflect.String:  "std::string",
+	}
+)
+
+// typeOf returns the Go type corresponding to the provided field.
+func typeOf(desc *Descriptor, f Field) (reflect.Type, error) {
+	if f.NRepetitions > 0 {
+		return nil, fmt.Errorf("rntup: fixed-size array field %q not supported", f.Name)
+	}
+
+	switch f.Structure {
+	case Leaf:
+		rt, ok := cxx2go[f.Type]
+		if !ok {
+			return nil, fmt.Errorf("rntup: unsupported type %q for field %q", f.Type, f.Name)
+		}
+		return rt, nil
+
+	case Collection:
+		subs := desc.subFields(f.ID)
+		if len(subs) != 1 {
+			return nil, fmt.Errorf("rntup: invalid number of sub-fields for collection %q (n=%d)", f.Name, len(subs))
+		}
+		elt, err := typeOf(desc, subs[0])
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elt), nil
+
+	case Record:
+		subs := desc.subFields(f.ID)
+		fields := make([]reflect.StructField, len(subs))
+		for i, sub := range subs {
+			rt, err := typeOf(desc, sub)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = reflect.StructField{
+				Name: "F" + strings.Map(goIdent, sub.Name),
+				Type: rt,
+				Tag:  reflect.StructTag(fmt.Sprintf("groot:%q", sub.Name)),
+			}
+		}
+		return reflect.StructOf(fields), nil
+	}
+
+	return nil, fmt.Errorf("rntup: unsupported structure %v for field %q", f.Structure, f.Name)
+}
+
+func goIdent(r rune) rune {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+		return r
+	}
+	return '_'
+}
+
+// nameOf returns the name of the RNTuple field bound to a struct field.
+func nameOf(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("groot")
+	if ok {
+		return tag
+	}
+	return field.Name
+}
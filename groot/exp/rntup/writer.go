@@ -0,0 +1,482 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/internal/rcompress"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+const (
+	defaultClusterSize = 64000 // default number of entries per cluster
+	anchorSize         = 48    // size of the on-disk RNTuple anchor
+)
+
+// WriteVar describes a variable to be written out to an RNTuple.
+//
+// Go builtin types are written as their C++ fixed-size counterparts,
+// string is written as std::string and []T is written as std::vector<T>.
+type WriteVar struct {
+	Name  string      // name of the field
+	Value interface{} // pointer to the value to write
+}
+
+// WriteOption configures how an RNTuple should be created.
+type WriteOption func(opt *wopt) error
+
+type wopt struct {
+	descr    string // description of the RNTuple
+	csize    int    // number of entries per cluster
+	compress int32  // compression algorithm name and compression level
+}
+
+// WithLZ4 configures an RNTuple to use LZ4 as a compression mechanism.
+func WithLZ4(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.LZ4, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithLZMA configures an RNTuple to use LZMA as a compression mechanism.
+func WithLZMA(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.LZMA, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithoutCompression configures an RNTuple to not use any compression mechanism.
+func WithoutCompression() WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = 0
+		return nil
+	}
+}
+
+// WithZlib configures an RNTuple to use zlib as a compression mechanism.
+func WithZlib(level int) WriteOption {
+	return func(opt *wopt) error {
+		opt.compress = rcompress.Settings{Alg: rcompress.ZLIB, Lvl: level}.Compression()
+		return nil
+	}
+}
+
+// WithClusterSize configures the number of entries held by each cluster.
+// if size is <= 0, the default cluster size is used.
+func WithClusterSize(size int) WriteOption {
+	return func(opt *wopt) error {
+		if size <= 0 {
+			size = defaultClusterSize
+		}
+		opt.csize = size
+		return nil
+	}
+}
+
+// WithDescription sets the description of the RNTuple.
+func WithDescription(descr string) WriteOption {
+	return func(opt *wopt) error {
+		opt.descr = descr
+		return nil
+	}
+}
+
+// Writer writes data to an RNTuple.
+type Writer struct {
+	f    *riofs.File
+	dir  riofs.Directory
+	name string
+	cfg  wopt
+
+	desc   Descriptor
+	wvars  []WriteVar
+	wfuncs []wfunc
+	srcs   []reflect.Value
+	cols   []*wcolumn
+
+	hdr     span   // header of the RNTuple
+	entries uint64 // number of entries in the committed clusters
+	nevts   uint64 // number of entries in the current cluster
+
+	closed bool
+}
+
+// wfunc appends the provided value to the columns of a field.
+type wfunc func(src reflect.Value)
+
+// wcolumn holds the unpacked elements of a column for the current cluster.
+type wcolumn struct {
+	typ   ColumnType
+	data  []byte
+	n     uint32 // number of elements in the current cluster
+	first uint64 // number of elements in the committed clusters
+}
+
+func (col *wcolumn) append(p []byte) {
+	col.data = append(col.data, p...)
+	col.n++
+}
+
+// NewWriter creates a new RNTuple with the given name and under the given
+// directory dir, ready to be filled with data.
+func NewWriter(dir riofs.Directory, name string, wvars []WriteVar, opts ...WriteOption) (*Writer, error) {
+	if dir == nil {
+		return nil, fmt.Errorf("rntup: missing parent directory")
+	}
+
+	w := &Writer{
+		f:    fileOf(dir),
+		dir:  dir,
+		name: name,
+	}
+	w.cfg = wopt{
+		csize:    defaultClusterSize,
+		compress: w.f.Compression(),
+	}
+
+	for _, opt := range opts {
+		err := opt(&w.cfg)
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not configure RNTuple writer: %w", err)
+		}
+	}
+
+	w.desc = Descriptor{
+		Name:        name,
+		Description: w.cfg.descr,
+		Author:      "go-hep.org/x/hep/groot",
+	}
+	w.desc.Fields = append(w.desc.Fields, Field{
+		ID:        0,
+		Structure: Record,
+		Parent:    noParent,
+	})
+
+	for _, wvar := range wvars {
+		rv := reflect.ValueOf(wvar.Value)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return nil, fmt.Errorf("rntup: write-var %q needs a non-nil pointer value (got %T)", wvar.Name, wvar.Value)
+		}
+		id, fct, err := w.addField(wvar.Name, rv.Elem().Type(), 0)
+		if err != nil {
+			return nil, fmt.Errorf("rntup: could not create field for write-var %q: %w", wvar.Name, err)
+		}
+		w.desc.Fields[0].Links = append(w.desc.Fields[0].Links, id)
+		w.wvars = append(w.wvars, wvar)
+		w.wfuncs = append(w.wfuncs, fct)
+		w.srcs = append(w.srcs, rv.Elem())
+	}
+
+	err := w.writeHeader()
+	if err != nil {
+		return nil, fmt.Errorf("rntup: could not write RNTuple header: %w", err)
+	}
+
+	return w, nil
+}
+
+// Descriptor returns the descriptor of the RNTuple being written.
+func (w *Writer) Descriptor() *Descriptor { return &w.desc }
+
+// Write appends the current values of the write-variables to the RNTuple.
+func (w *Writer) Write() error {
+	if w.closed {
+		return fmt.Errorf("rntup: RNTuple %q already closed", w.name)
+	}
+
+	for i, fct := range w.wfuncs {
+		fct(w.srcs[i])
+	}
+	w.nevts++
+
+	if w.nevts >= uint64(w.cfg.csize) {
+		return w.Flush()
+	}
+	return nil
+}
+
+// Flush commits the current entries to a new cluster.
+func (w *Writer) Flush() error {
+	if w.nevts == 0 {
+		return nil
+	}
+
+	cluster := Cluster{
+		ID:         uint64(len(w.desc.Clusters)),
+		FirstEntry: w.entries,
+		NEntries:   w.nevts,
+		Locator:    Locator{Pos: -1},
+		Columns:    make([]ColumnRange, len(w.cols)),
+	}
+
+	for i, col := range w.cols {
+		rng := ColumnRange{
+			Column:       uint64(i),
+			FirstElement: col.first,
+			NElements:    col.n,
+			Compression:  int64(w.cfg.compress),
+		}
+		if col.n > 0 {
+			page, err := w.writePage(col)
+			if err != nil {
+				return fmt.Errorf("rntup: could not write page of column %d: %w", i, err)
+			}
+			rng.Pages = []Page{page}
+
+			loc := &cluster.Locator
+			if loc.Pos < 0 || page.Locator.Pos < loc.Pos {
+				loc.Pos = page.Locator.Pos
+			}
+			loc.Bytes += page.Locator.Bytes
+		}
+		cluster.Columns[i] = rng
+
+		col.first += uint64(col.n)
+		col.n = 0
+		col.data = col.data[:0]
+	}
+	if cluster.Locator.Pos < 0 {
+		cluster.Locator.Pos = 0
+	}
+
+	w.desc.Clusters = append(w.desc.Clusters, cluster)
+	w.entries += w.nevts
+	w.nevts = 0
+
+	return nil
+}
+
+// Close commits the pending entries, writes the footer and the anchor of
+// the RNTuple and closes the writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	defer func() {
+		w.closed = true
+	}()
+
+	err := w.Flush()
+	if err != nil {
+		return fmt.Errorf("rntup: could not flush RNTuple %q: %w", w.name, err)
+	}
+
+	ftr, err := w.writeEnvelope(w.desc.marshalFooter(w.hdr.length))
+	if err != nil {
+		return fmt.Errorf("rntup: could not write RNTuple footer: %w", err)
+	}
+
+	nt := &NTuple{
+		size:   anchorSize,
+		header: w.hdr,
+		footer: ftr,
+		f:      w.f,
+	}
+	err = w.dir.Put(w.name, nt)
+	if err != nil {
+		return fmt.Errorf("rntup: could not save RNTuple %q: %w", w.name, err)
+	}
+
+	return nil
+}
+
+func (w *Writer) writeHeader() error {
+	var err error
+	w.hdr, err = w.writeEnvelope(w.desc.marshalHeader())
+	return err
+}
+
+func (w *Writer) writeEnvelope(raw []byte) (span, error) {
+	buf, err := rcompress.Compress(nil, raw, w.cfg.compress)
+	if err != nil {
+		return span{}, err
+	}
+	pos, err := riofs.WriteBlobInternal(w.f, buf, int32(len(raw)))
+	if err != nil {
+		return span{}, err
+	}
+	return span{
+		seek:   uint64(pos),
+		nbytes: uint32(len(buf)),
+		length: uint32(len(raw)),
+	}, nil
+}
+
+func (w *Writer) writePage(col *wcolumn) (Page, error) {
+	raw := packPage(col.typ, col.data)
+	buf, err := rcompress.Compress(nil, raw, w.cfg.compress)
+	if err != nil {
+		return Page{}, err
+	}
+	pos, err := riofs.WriteBlobInternal(w.f, buf, int32(len(raw)))
+	if err != nil {
+		return Page{}, err
+	}
+	return Page{
+		NElements: col.n,
+		Locator: Locator{
+			Pos:   pos,
+			Bytes: uint32(len(buf)),
+		},
+	}, nil
+}
+
+// addColumn adds a new column of the provided type to a field.
+func (w *Writer) addColumn(field uint64, typ ColumnType, idx uint32) *wcolumn {
+	col := &wcolumn{typ: typ}
+	w.desc.Columns = append(w.desc.Columns, Column{
+		ID:     uint64(len(w.cols)),
+		Type:   typ,
+		Sorted: typ == ColIndex,
+		Field:  field,
+		Index:  idx,
+	})
+	w.cols = append(w.cols, col)
+	return col
+}
+
+// addField adds a new field, and its sub-fields, to the RNTuple and
+// returns its identifier and the function appending values to its columns.
+func (w *Writer) addField(name string, rt reflect.Type, parent uint64) (uint64, wfunc, error) {
+	id := uint64(len(w.desc.Fields))
+	w.desc.Fields = append(w.desc.Fields, Field{
+		ID:        id,
+		Name:      name,
+		Structure: Leaf,
+		Parent:    parent,
+	})
+	field := func() *Field { return &w.desc.Fields[id] }
+
+	switch kind := rt.Kind(); kind {
+	case reflect.Bool:
+		field().Type = go2cxx[kind]
+		col := w.addColumn(id, ColBit, 0)
+		return id, func(src reflect.Value) {
+			v := byte(0)
+			if src.Bool() {
+				v = 1
+			}
+			col.append([]byte{v})
+		}, nil
+
+	case reflect.Int8, reflect.Uint8:
+		field().Type = go2cxx[kind]
+		col := w.addColumn(id, ColInt8, 0)
+		return id, func(src reflect.Value) {
+			col.append([]byte{byte(bitsOf(src))})
+		}, nil
+
+	case reflect.Int16, reflect.Uint16:
+		field().Type = go2cxx[kind]
+		col := w.addColumn(id, ColInt16, 0)
+		return id, func(src reflect.Value) {
+			var p [2]byte
+			binary.LittleEndian.PutUint16(p[:], uint16(bitsOf(src)))
+			col.append(p[:])
+		}, nil
+
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		typ := ColInt32
+		if kind == reflect.Float32 {
+			typ = ColReal32
+		}
+		field().Type = go2cxx[kind]
+		col := w.addColumn(id, typ, 0)
+		return id, func(src reflect.Value) {
+			var p [4]byte
+			binary.LittleEndian.PutUint32(p[:], uint32(bitsOf(src)))
+			col.append(p[:])
+		}, nil
+
+	case reflect.Int64, reflect.Uint64, reflect.Float64:
+		typ := ColInt64
+		if kind == reflect.Float64 {
+			typ = ColReal64
+		}
+		field().Type = go2cxx[kind]
+		col := w.addColumn(id, typ, 0)
+		return id, func(src reflect.Value) {
+			var p [8]byte
+			binary.LittleEndian.PutUint64(p[:], bitsOf(src))
+			col.append(p[:])
+		}, nil
+
+	case reflect.String:
+		field().Type = go2cxx[kind]
+		var (
+			idx   = w.addColumn(id, ColIndex, 0)
+			chars = w.addColumn(id, ColByte, 1)
+		)
+		return id, func(src reflect.Value) {
+			str := src.String()
+			chars.data = append(chars.data, str...)
+			chars.n += uint32(len(str))
+			idx.appendIndex(chars.n)
+		}, nil
+
+	case reflect.Slice:
+		field().Structure = Collection
+		idx := w.addColumn(id, ColIndex, 0)
+		sub, elt, err := w.addField("_0", rt.Elem(), id)
+		if err != nil {
+			return 0, nil, fmt.Errorf("could not create field for element of %q: %w", name, err)
+		}
+		field().Links = []uint64{sub}
+		field().Type = "std::vector<" + w.desc.Fields[sub].Type + ">"
+
+		n := uint32(0) // number of elements in the current cluster
+		return id, func(src reflect.Value) {
+			if idx.n == 0 {
+				n = 0 // new cluster
+			}
+			for i := 0; i < src.Len(); i++ {
+				elt(src.Index(i))
+			}
+			n += uint32(src.Len())
+			idx.appendIndex(n)
+		}, nil
+	}
+
+	return 0, nil, fmt.Errorf("unsupported type %v", rt)
+}
+
+func (col *wcolumn) appendIndex(v uint32) {
+	var p [4]byte
+	binary.LittleEndian.PutUint32(p[:], v)
+	col.append(p[:])
+}
+
+// bitsOf returns the bit pattern of a numerical value.
+func bitsOf(v reflect.Value) uint64 {
+	switch v.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(v.Int())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32:
+		return uint64(math.Float32bits(float32(v.Float())))
+	case reflect.Float64:
+		return math.Float64bits(v.Float())
+	}
+	panic(fmt.Errorf("rntup: invalid numerical value kind %v", v.Kind()))
+}
+
+func fileOf(d riofs.Directory) *riofs.File {
+	const max = 1<<31 - 1
+	for i := 0; i < max; i++ {
+		p := d.Parent()
+		if p == nil {
+			return d.(*riofs.File)
+		}
+		d = p
+	}
+	panic("impossible")
+}
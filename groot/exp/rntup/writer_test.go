@@ -0,0 +1,213 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rntup
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestRW(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rntup-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type Data struct {
+		B   bool
+		I8  int8
+		I16 int16
+		I32 int32
+		I64 int64
+		U8  uint8
+		U16 uint16
+		U32 uint32
+		U64 uint64
+		F32 float32
+		F64 float64
+		Str string
+
+		SliF64 []float64
+		SliStr []string
+		SliSli [][]int32
+	}
+
+	wvarsOf := func(d *Data) []WriteVar {
+		return []WriteVar{
+			{Name: "B", Value: &d.B},
+			{Name: "I8", Value: &d.I8},
+			{Name: "I16", Value: &d.I16},
+			{Name: "I32", Value: &d.I32},
+			{Name: "I64", Value: &d.I64},
+			{Name: "U8", Value: &d.U8},
+			{Name: "U16", Value: &d.U16},
+			{Name: "U32", Value: &d.U32},
+			{Name: "U64", Value: &d.U64},
+			{Name: "F32", Value: &d.F32},
+			{Name: "F64", Value: &d.F64},
+			{Name: "Str", Value: &d.Str},
+			{Name: "SliF64", Value: &d.SliF64},
+			{Name: "SliStr", Value: &d.SliStr},
+			{Name: "SliSli", Value: &d.SliSli},
+		}
+	}
+
+	const nevts = 1000
+	gen := func(i int) Data {
+		d := Data{
+			B:   i%3 == 0,
+			I8:  int8(-i),
+			I16: int16(-i),
+			I32: int32(-i),
+			I64: int64(-i),
+			U8:  uint8(i),
+			U16: uint16(i),
+			U32: uint32(i),
+			U64: uint64(i),
+			F32: float32(i),
+			F64: float64(i),
+			Str: fmt.Sprintf("evt-%03d", i),
+		}
+		for j := 0; j < i%5; j++ {
+			d.SliF64 = append(d.SliF64, float64(i+j))
+		}
+		for j := 0; j < i%3; j++ {
+			d.SliStr = append(d.SliStr, fmt.Sprintf("str-%d-%d", i, j))
+		}
+		for j := 0; j < i%4; j++ {
+			var sli []int32
+			for k := 0; k < j; k++ {
+				sli = append(sli, int32(i+j+k))
+			}
+			d.SliSli = append(d.SliSli, sli)
+		}
+		return d
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []WriteOption
+		nclu int
+	}{
+		{name: "default", nclu: 1},
+		{name: "no-compression", opts: []WriteOption{WithoutCompression(), WithClusterSize(300)}, nclu: 4},
+		{name: "zlib", opts: []WriteOption{WithZlib(-1), WithClusterSize(100)}, nclu: 10},
+		{name: "lz4", opts: []WriteOption{WithLZ4(1), WithClusterSize(999)}, nclu: 2},
+		{name: "lzma", opts: []WriteOption{WithLZMA(1), WithDescription("my ntuple")}, nclu: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(tmp, tc.name+".root")
+
+			func() {
+				f, err := riofs.Create(fname)
+				if err != nil {
+					t.Fatalf("could not create file: %+v", err)
+				}
+				defer f.Close()
+
+				var data Data
+				w, err := NewWriter(f, "ntpl", wvarsOf(&data), tc.opts...)
+				if err != nil {
+					t.Fatalf("could not create writer: %+v", err)
+				}
+
+				for i := 0; i < nevts; i++ {
+					data = gen(i)
+					err = w.Write()
+					if err != nil {
+						t.Fatalf("could not write entry %d: %+v", i, err)
+					}
+				}
+
+				err = w.Close()
+				if err != nil {
+					t.Fatalf("could not close writer: %+v", err)
+				}
+
+				err = f.Close()
+				if err != nil {
+					t.Fatalf("could not close file: %+v", err)
+				}
+			}()
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			obj, err := f.Get("ntpl")
+			if err != nil {
+				t.Fatalf("could not get ntuple: %+v", err)
+			}
+			nt := obj.(*NTuple)
+
+			desc, err := nt.Descriptor()
+			if err != nil {
+				t.Fatalf("could not read descriptor: %+v", err)
+			}
+			if got, want := desc.Entries(), int64(nevts); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+			if got, want := len(desc.Clusters), tc.nclu; got != want {
+				t.Fatalf("invalid number of clusters: got=%d, want=%d", got, want)
+			}
+
+			types := make(map[string]string)
+			for _, f := range desc.TopFields() {
+				types[f.Name] = f.Type
+			}
+			for name, want := range map[string]string{
+				"B":      "bool",
+				"I32":    "std::int32_t",
+				"U64":    "std::uint64_t",
+				"F32":    "float",
+				"Str":    "std::string",
+				"SliF64": "std::vector<double>",
+				"SliSli": "std::vector<std::vector<std::int32_t>>",
+			} {
+				if got := types[name]; got != want {
+					t.Fatalf("invalid type for field %q: got=%q, want=%q", name, got, want)
+				}
+			}
+
+			var data Data
+			rvars := make([]ReadVar, 0)
+			for _, wvar := range wvarsOf(&data) {
+				rvars = append(rvars, ReadVar{Name: wvar.Name, Value: wvar.Value})
+			}
+
+			r, err := NewReader(nt, rvars)
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			n := 0
+			err = r.Read(func(ctx RCtx) error {
+				// compare the textual representations, as the reader
+				// reuses the backing arrays of (possibly empty) slices.
+				want := gen(int(ctx.Entry))
+				if fmt.Sprintf("%+v", data) != fmt.Sprintf("%+v", want) {
+					return fmt.Errorf("invalid entry %d:\ngot= %+v\nwant=%+v", ctx.Entry, data, want)
+				}
+				n++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read ntuple: %+v", err)
+			}
+			if n != nevts {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", n, nevts)
+			}
+		})
+	}
+}
@@ -29,10 +29,10 @@ import (
 var (
 	classes = []string{
 		// rbase
-		"TAttAxis", "TAttFill", "TAttLine", "TAttMarker",
+		"TAtt3D", "TAttAxis", "TAttBBox2D", "TAttCanvas", "TAttFill", "TAttLine", "TAttMarker", "TAttPad",
 		"TNamed",
 		"TObject", "TObjString",
-		"TProcessID", "TProcessUUID", "TRef", "TUUID",
+		"TProcessID", "TProcessUUID", "TQObject", "TRef", "TUUID",
 
 		// rcont
 		"TArray", "TArrayC", "TArrayS", "TArrayI", "TArrayL", "TArrayL64", "TArrayF", "TArrayD",
@@ -64,9 +64,21 @@ var (
 
 		// rhist
 		"TAxis",
+		"TCanvas",
+		"TEfficiency",
+		"TF1", "TF1Parameters", "TFormula",
 		"TGraph", "TGraphErrors", "TGraphAsymmErrors",
 		"TH1", "TH1C", "TH1D", "TH1F", "TH1I", "TH1K", "TH1S",
 		"TH2", "TH2C", "TH2D", "TH2F", "TH2I", "TH2Poly", "TH2PolyBin", "TH2S",
+		"TH3", "TH3D", "TH3F", "TH3I",
+		"THStack",
+		"THnBase", "THnSparse", "THnSparseArrayChunk",
+		"THnSparseT<TArrayD>", "THnSparseT<TArrayF>",
+		"TMultiGraph",
+		"TPad",
+		"TProfile", "TProfile2D",
+		"TVirtualPad",
+		"pair<double,double>", // needed by TEfficiency
 
 		// riofs
 		"TDirectory",
@@ -75,7 +87,7 @@ var (
 		"TKey",
 
 		// rntup
-		// "ROOT::Experimental::RNTuple", // FIXME(sbinet): TODO
+		"ROOT::Experimental::RNTuple",
 
 		// rphys
 		"TFeldmanCousins",
@@ -86,7 +98,8 @@ var (
 		"ROOT::TIOFeatures",
 		"TBasket",
 		"TBranch", "TBranchElement", "TBranchRef",
-		"TChain",
+		"TChain", "TChainIndex",
+		"TEntryList", "TEntryListBlock", "TEventList",
 		"TLeaf", "TLeafElement",
 		"TLeafO",
 		"TLeafB", "TLeafS", "TLeafI", "TLeafL",
@@ -95,7 +108,8 @@ var (
 		"TLeafC",
 		"TNtuple",
 		"TRefTable",
-		"TTree",
+		"TTree", "TTreeIndex",
+		"TVirtualIndex",
 	}
 )
 
@@ -223,9 +237,9 @@ func (t Type) GoName() string {
 		namespace = ""
 		name      = t.Name
 	)
-	if strings.HasPrefix(name, "ROOT::Experimental") {
-		namespace = "ROOT_Experimental"
-		name = name[len("ROOT::Experimental"):]
+	if strings.HasPrefix(name, "ROOT::Experimental::") {
+		namespace = "ROOT_Experimental_"
+		name = name[len("ROOT::Experimental::"):]
 	}
 
 	if strings.HasPrefix(name, "ROOT::") {
@@ -236,6 +250,9 @@ func (t Type) GoName() string {
 	if strings.HasPrefix(name, "T") {
 		name = name[1:]
 	}
+
+	// templated classes, e.g. THnSparseT<TArrayD> -> HnSparseT_TArrayD
+	name = strings.NewReplacer("<", "_", ">", "", ",", "_", " ", "").Replace(name)
 	return namespace + name
 }
 
@@ -18,6 +18,7 @@ import (
 func main() {
 	genH1()
 	genH2()
+	genH3()
 }
 
 func genH1() {
@@ -136,6 +137,63 @@ func genH2() {
 	genroot.GoFmt(f)
 }
 
+func genH3() {
+	fname := "./rhist/h3_gen.go"
+	year := genroot.ExtractYear(fname)
+	f, err := os.Create(fname)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	genroot.GenImports(year, "rhist", f,
+		"fmt", "math", "reflect",
+		"",
+		"go-hep.org/x/hep/groot/root",
+		"go-hep.org/x/hep/groot/rcont",
+		"go-hep.org/x/hep/groot/rbytes",
+		"go-hep.org/x/hep/groot/rtypes",
+		"go-hep.org/x/hep/groot/rvers",
+	)
+
+	for i, typ := range []struct {
+		Name string
+		Type string
+		Elem string
+	}{
+		{
+			Name: "H3F",
+			Type: "rcont.ArrayF",
+			Elem: "float32",
+		},
+		{
+			Name: "H3D",
+			Type: "rcont.ArrayD",
+			Elem: "float64",
+		},
+		{
+			Name: "H3I",
+			Type: "rcont.ArrayI",
+			Elem: "int32",
+		},
+	} {
+		if i > 0 {
+			fmt.Fprintf(f, "\n")
+		}
+		tmpl := template.Must(template.New(typ.Name).Parse(h3Tmpl))
+		err = tmpl.Execute(f, typ)
+		if err != nil {
+			log.Fatalf("error executing template for %q: %v\n", typ.Name, err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	genroot.GoFmt(f)
+}
+
 const h1Tmpl = `// {{.Name}} implements ROOT T{{.Name}}
 type {{.Name}} struct {
 	th1
@@ -823,3 +881,192 @@ var (
 	_ rbytes.Unmarshaler = (*{{.Name}})(nil)
 )
 `
+
+const h3Tmpl = `// {{.Name}} implements ROOT T{{.Name}}
+type {{.Name}} struct {
+	th3
+	arr {{.Type}}
+}
+
+func new{{.Name}}() *{{.Name}} {
+	return &{{.Name}}{
+		th3:   *newH3(),
+	}
+}
+
+func (*{{.Name}}) RVersion() int16 {
+	return rvers.{{.Name}}
+}
+
+func (*{{.Name}}) isH3() {}
+
+// Class returns the ROOT class name.
+func (*{{.Name}}) Class() string {
+	return "T{{.Name}}"
+}
+
+func (h *{{.Name}}) Array() {{.Type}} {
+	return h.arr
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *{{.Name}}) Rank() int {
+	return 3
+}
+
+// NbinsX returns the number of bins in X.
+func (h *{{.Name}}) NbinsX() int {
+	return h.th1.xaxis.nbins
+}
+
+// XAxis returns the axis along X.
+func (h *{{.Name}}) XAxis() Axis {
+	return &h.th1.xaxis
+}
+
+// NbinsY returns the number of bins in Y.
+func (h *{{.Name}}) NbinsY() int {
+	return h.th1.yaxis.nbins
+}
+
+// YAxis returns the axis along Y.
+func (h *{{.Name}}) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
+// NbinsZ returns the number of bins in Z.
+func (h *{{.Name}}) NbinsZ() int {
+	return h.th1.zaxis.nbins
+}
+
+// ZAxis returns the axis along Z.
+func (h *{{.Name}}) ZAxis() Axis {
+	return &h.th1.zaxis
+}
+
+// bin returns the regularized bin number given an (x,y,z) bin index triplet.
+func (h *{{.Name}}) bin(ix, iy, iz int) int {
+	nx := h.th1.xaxis.nbins + 1 // overflow bin
+	ny := h.th1.yaxis.nbins + 1 // overflow bin
+	nz := h.th1.zaxis.nbins + 1 // overflow bin
+	switch {
+	case ix < 0:
+		ix = 0
+	case ix > nx:
+		ix = nx
+	}
+	switch {
+	case iy < 0:
+		iy = 0
+	case iy > ny:
+		iy = ny
+	}
+	switch {
+	case iz < 0:
+		iz = 0
+	case iz > nz:
+		iz = nz
+	}
+	return ix + (nx+1)*(iy+(ny+1)*iz)
+}
+
+// BinContent returns the content of the (ix,iy,iz) bin.
+// Bin 0 is the underflow bin, bin N+1 the overflow one.
+func (h *{{.Name}}) BinContent(ix, iy, iz int) float64 {
+	i := h.bin(ix, iy, iz)
+	return float64(h.arr.Data[i])
+}
+
+// BinError returns the error of the (ix,iy,iz) bin.
+// Bin 0 is the underflow bin, bin N+1 the overflow one.
+func (h *{{.Name}}) BinError(ix, iy, iz int) float64 {
+	i := h.bin(ix, iy, iz)
+	if len(h.th1.sumw2.Data) > 0 {
+		return math.Sqrt(float64(h.th1.sumw2.Data[i]))
+	}
+	return math.Sqrt(math.Abs(float64(h.arr.Data[i])))
+}
+
+func (h *{{.Name}}) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(h.RVersion())
+
+	for _, v := range []rbytes.Marshaler{
+		&h.th3,
+		&h.arr,
+	} {
+		if _, err := v.MarshalROOT(w); err != nil {
+			return 0, err
+		}
+	}
+
+	return w.SetByteCount(pos, h.Class())
+}
+
+func (h *{{.Name}}) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(h.Class())
+	if vers < 1 {
+		return fmt.Errorf("rhist: T{{.Name}} version too old (%d<1)", vers)
+	}
+
+	for _, v := range []rbytes.Unmarshaler{
+		&h.th3,
+		&h.arr,
+	} {
+		if err := v.UnmarshalROOT(r); err != nil {
+			return err
+		}
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, h.Class())
+	return r.Err()
+}
+
+func (h *{{.Name}}) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*{{.Name}})
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.{{.Name}} (%T)", src.(root.Named).Name(), src)
+	}
+
+	err := h.th3.add(&hsrc.th3)
+	if err != nil {
+		return err
+	}
+
+	h.th1.addSumw2(
+		&hsrc.th1,
+		func(i int) float64 { return float64(h.arr.Data[i]) },
+		func(i int) float64 { return float64(hsrc.arr.Data[i]) },
+	)
+	for i, v := range hsrc.arr.Data {
+		h.arr.Data[i] += v
+	}
+
+	return nil
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := new{{.Name}}()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("T{{.Name}}", f)
+}
+
+var (
+	_ root.Object        = (*{{.Name}})(nil)
+	_ root.Merger        = (*{{.Name}})(nil)
+	_ root.Named         = (*{{.Name}})(nil)
+	_ H3                 = (*{{.Name}})(nil)
+	_ rbytes.Marshaler   = (*{{.Name}})(nil)
+	_ rbytes.Unmarshaler = (*{{.Name}})(nil)
+)
+`
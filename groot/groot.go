@@ -37,6 +37,11 @@ func Create(name string, opts ...FileOption) (*File, error) {
 	return riofs.Create(name, opts...)
 }
 
+// Update opens the named ROOT file for reading and writing.
+func Update(name string, opts ...FileOption) (*File, error) {
+	return riofs.Update(name, opts...)
+}
+
 type (
 	File       = riofs.File
 	FileOption = riofs.FileOption
@@ -0,0 +1,65 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Att3D implements ROOT TAtt3D.
+// TAtt3D does not carry any data member.
+type Att3D struct{}
+
+func NewAtt3D() *Att3D {
+	return &Att3D{}
+}
+
+func (*Att3D) Class() string {
+	return "TAtt3D"
+}
+
+func (*Att3D) RVersion() int16 {
+	return rvers.Att3D
+}
+
+func (a *Att3D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(a.RVersion())
+	return w.SetByteCount(pos, a.Class())
+}
+
+func (a *Att3D) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	start := r.Pos()
+	/*vers*/ _, pos, bcnt := r.ReadVersion(a.Class())
+	r.CheckByteCount(pos, bcnt, start, a.Class())
+
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewAtt3D()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TAtt3D", f)
+}
+
+var (
+	_ root.Object        = (*Att3D)(nil)
+	_ rbytes.Marshaler   = (*Att3D)(nil)
+	_ rbytes.Unmarshaler = (*Att3D)(nil)
+)
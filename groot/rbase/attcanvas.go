@@ -0,0 +1,92 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// AttCanvas implements ROOT TAttCanvas.
+type AttCanvas struct {
+	XBetween     float32 // x distance between pads
+	YBetween     float32 // y distance between pads
+	TitleFromTop float32 // y distance of global title from top
+	XDate        float32 // x position where to draw the date
+	YDate        float32 // y position where to draw the date
+	ADate        float32 // alignment for the date
+}
+
+func NewAttCanvas() *AttCanvas {
+	return &AttCanvas{
+		XBetween:     2,
+		YBetween:     2,
+		TitleFromTop: 1.2,
+		XDate:        0.2,
+		YDate:        0.3,
+		ADate:        1,
+	}
+}
+
+func (*AttCanvas) Class() string {
+	return "TAttCanvas"
+}
+
+func (*AttCanvas) RVersion() int16 {
+	return rvers.AttCanvas
+}
+
+func (a *AttCanvas) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(a.RVersion())
+	w.WriteF32(a.XBetween)
+	w.WriteF32(a.YBetween)
+	w.WriteF32(a.TitleFromTop)
+	w.WriteF32(a.XDate)
+	w.WriteF32(a.YDate)
+	w.WriteF32(a.ADate)
+
+	return w.SetByteCount(pos, a.Class())
+}
+
+func (a *AttCanvas) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	start := r.Pos()
+	/*vers*/ _, pos, bcnt := r.ReadVersion(a.Class())
+
+	a.XBetween = r.ReadF32()
+	a.YBetween = r.ReadF32()
+	a.TitleFromTop = r.ReadF32()
+	a.XDate = r.ReadF32()
+	a.YDate = r.ReadF32()
+	a.ADate = r.ReadF32()
+	r.CheckByteCount(pos, bcnt, start, a.Class())
+
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewAttCanvas()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TAttCanvas", f)
+}
+
+var (
+	_ root.Object        = (*AttCanvas)(nil)
+	_ rbytes.Marshaler   = (*AttCanvas)(nil)
+	_ rbytes.Unmarshaler = (*AttCanvas)(nil)
+)
@@ -0,0 +1,143 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rbase
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// AttPad implements ROOT TAttPad.
+type AttPad struct {
+	LeftMargin      float32 // left margin
+	RightMargin     float32 // right margin
+	BottomMargin    float32 // bottom margin
+	TopMargin       float32 // top margin
+	XFile           float32 // x position where to draw the file name
+	YFile           float32 // y position where to draw the file name
+	AFile           float32 // alignment for the file name
+	XStat           float32 // x position where to draw the statistics
+	YStat           float32 // y position where to draw the statistics
+	AStat           float32 // alignment for the statistics
+	FrameFillColor  int16   // pad frame fill color
+	FrameLineColor  int16   // pad frame line color
+	FrameFillStyle  int16   // pad frame fill style
+	FrameLineStyle  int16   // pad frame line style
+	FrameLineWidth  int16   // pad frame line width
+	FrameBorderSize int16   // pad frame border size
+	FrameBorderMode int32   // pad frame border mode
+}
+
+func NewAttPad() *AttPad {
+	return &AttPad{
+		LeftMargin:      0.1,
+		RightMargin:     0.1,
+		BottomMargin:    0.1,
+		TopMargin:       0.1,
+		XFile:           0,
+		YFile:           0,
+		AFile:           11,
+		XStat:           0.99,
+		YStat:           0.99,
+		AStat:           0,
+		FrameFillColor:  0,
+		FrameLineColor:  1,
+		FrameFillStyle:  1001,
+		FrameLineStyle:  1,
+		FrameLineWidth:  1,
+		FrameBorderSize: 1,
+		FrameBorderMode: 1,
+	}
+}
+
+func (*AttPad) Class() string {
+	return "TAttPad"
+}
+
+func (*AttPad) RVersion() int16 {
+	return rvers.AttPad
+}
+
+func (a *AttPad) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(a.RVersion())
+	w.WriteF32(a.LeftMargin)
+	w.WriteF32(a.RightMargin)
+	w.WriteF32(a.BottomMargin)
+	w.WriteF32(a.TopMargin)
+	w.WriteF32(a.XFile)
+	w.WriteF32(a.YFile)
+	w.WriteF32(a.AFile)
+	w.WriteF32(a.XStat)
+	w.WriteF32(a.YStat)
+	w.WriteF32(a.AStat)
+	w.WriteI16(a.FrameFillColor)
+	w.WriteI16(a.FrameLineColor)
+	w.WriteI16(a.FrameFillStyle)
+	w.WriteI16(a.FrameLineStyle)
+	w.WriteI16(a.FrameLineWidth)
+	w.WriteI16(a.FrameBorderSize)
+	w.WriteI32(a.FrameBorderMode)
+
+	return w.SetByteCount(pos, a.Class())
+}
+
+func (a *AttPad) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	start := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(a.Class())
+	const minVers = 2
+	if vers < minVers {
+		return fmt.Errorf("rbase: TAttPad version too old (%d<%d)", vers, minVers)
+	}
+
+	a.LeftMargin = r.ReadF32()
+	a.RightMargin = r.ReadF32()
+	a.BottomMargin = r.ReadF32()
+	a.TopMargin = r.ReadF32()
+	a.XFile = r.ReadF32()
+	a.YFile = r.ReadF32()
+	a.AFile = r.ReadF32()
+	a.XStat = r.ReadF32()
+	a.YStat = r.ReadF32()
+	a.AStat = r.ReadF32()
+	if vers > 2 {
+		a.FrameFillColor = r.ReadI16()
+		a.FrameLineColor = r.ReadI16()
+		a.FrameFillStyle = r.ReadI16()
+		a.FrameLineStyle = r.ReadI16()
+		a.FrameLineWidth = r.ReadI16()
+		a.FrameBorderSize = r.ReadI16()
+		a.FrameBorderMode = r.ReadI32()
+	}
+	r.CheckByteCount(pos, bcnt, start, a.Class())
+
+	return r.Err()
+}
+
+func init() {
+	f := func() reflect.Value {
+		o := NewAttPad()
+		return reflect.ValueOf(o)
+	}
+	rtypes.Factory.Add("TAttPad", f)
+}
+
+var (
+	_ root.Object        = (*AttPad)(nil)
+	_ rbytes.Marshaler   = (*AttPad)(nil)
+	_ rbytes.Unmarshaler = (*AttPad)(nil)
+)
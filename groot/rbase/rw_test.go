@@ -82,6 +82,23 @@ func TestWRBuffer(t *testing.T) {
 				obj: Object{ID: 0x0, Bits: 0x3000000},
 			},
 		},
+		{
+			name: "TAttPad",
+			want: NewAttPad(),
+		},
+		{
+			name: "TAttPad",
+			want: &AttPad{
+				LeftMargin: 0.2, RightMargin: 0.05, BottomMargin: 0.15, TopMargin: 0.05,
+				XFile: 1, YFile: 2, AFile: 11, XStat: 0.9, YStat: 0.8, AStat: 1,
+				FrameFillColor: 10, FrameLineColor: 2, FrameFillStyle: 3001,
+				FrameLineStyle: 2, FrameLineWidth: 3, FrameBorderSize: 2, FrameBorderMode: -1,
+			},
+		},
+		{
+			name: "TAttCanvas",
+			want: NewAttCanvas(),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			{
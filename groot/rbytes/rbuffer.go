@@ -641,16 +641,17 @@ func (r *RBuffer) ReadObjectAny() (obj root.Object) {
 		}
 
 		obj = fct().Interface().(root.Object)
-		if err := obj.(Unmarshaler).UnmarshalROOT(r); err != nil {
-			r.err = err
-			return nil
-		}
-
+		// add to refs before reading value, to handle self reference
 		if vers > 0 {
 			r.refs[beg+kMapOffset] = obj
 		} else {
 			r.refs[int64(len(r.refs))+1] = obj
 		}
+
+		if err := obj.(Unmarshaler).UnmarshalROOT(r); err != nil {
+			r.err = err
+			return nil
+		}
 		return obj
 
 	default:
@@ -216,12 +216,15 @@ func (w *WBuffer) WriteClass(beg int64, obj root.Object) (uint32, error) {
 
 	// first time we see this value
 	w.WriteU32(uint32(ref64) | kClassMask)
+
+	// add to refs before writing value, to handle self reference
+	w.refs[obj] = beg + kMapOffset
+
 	if _, err := obj.(Marshaler).MarshalROOT(w); err != nil {
 		w.err = err
 		return 0, w.err
 	}
 
-	w.refs[obj] = beg + kMapOffset
 	bcnt := w.Pos() - start
 	return uint32(bcnt | kByteCountMask), w.err
 }
@@ -83,10 +83,13 @@ func (cmd *dumpCmd) dumpObj(obj root.Object) error {
 	case riofs.Directory:
 		fmt.Fprintf(cmd.w, "\n")
 		err = cmd.dumpDir(obj)
+	case rhist.P1:
+		fmt.Fprintf(cmd.w, "\n")
+		err = cmd.dumpP1(obj)
 	case rhist.H2:
 		fmt.Fprintf(cmd.w, "\n")
 		err = cmd.dumpH2(obj)
-	case rhist.H1: // keep after rhist.H2
+	case rhist.H1: // keep after rhist.P1 and rhist.H2
 		fmt.Fprintf(cmd.w, "\n")
 		err = cmd.dumpH1(obj)
 	case rhist.Graph:
@@ -147,6 +150,11 @@ func (cmd *dumpCmd) dumpH1(h1 rhist.H1) error {
 	return yodacnv.Write(cmd.w, h)
 }
 
+func (cmd *dumpCmd) dumpP1(p1 rhist.P1) error {
+	p := rootcnv.P1D(p1)
+	return yodacnv.Write(cmd.w, p)
+}
+
 func (cmd *dumpCmd) dumpH2(h2 rhist.H2) error {
 	h := rootcnv.H2D(h2)
 	return yodacnv.Write(cmd.w, h)
@@ -5,59 +5,230 @@
 package rcmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	stdpath "path"
+	"regexp"
+	"strings"
+	"sync"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtree"
+	"golang.org/x/sync/errgroup"
 )
 
+// MergeOption configures how root-merge should merge ROOT files.
+type MergeOption func(cmd *mergeCmd) error
+
+// WithMergeWorkers sets the number of goroutines used to merge
+// the objects of an input ROOT file.
+//
+// Histograms and other in-memory objects are merged concurrently.
+// Trees are written to the output ROOT file while being merged:
+// trees are thus merged one at a time.
+func WithMergeWorkers(n int) MergeOption {
+	return func(cmd *mergeCmd) error {
+		if n < 1 {
+			return fmt.Errorf("rcmd: invalid number of workers (%d)", n)
+		}
+		cmd.workers = n
+		return nil
+	}
+}
+
+// WithMergeInclude only selects the ROOT objects whose full path
+// (e.g. "/dir-1/dir-11/tree") matches at least one of the provided
+// regular expressions.
+func WithMergeInclude(exprs ...string) MergeOption {
+	return func(cmd *mergeCmd) error {
+		for _, expr := range exprs {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return fmt.Errorf("rcmd: could not compile include regexp %q: %w", expr, err)
+			}
+			cmd.incl = append(cmd.incl, re)
+		}
+		return nil
+	}
+}
+
+// WithMergeExclude discards the ROOT objects whose full path
+// (e.g. "/dir-1/dir-11/tree") matches any of the provided
+// regular expressions.
+// Excluding a directory excludes all of its content.
+func WithMergeExclude(exprs ...string) MergeOption {
+	return func(cmd *mergeCmd) error {
+		for _, expr := range exprs {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return fmt.Errorf("rcmd: could not compile exclude regexp %q: %w", expr, err)
+			}
+			cmd.excl = append(cmd.excl, re)
+		}
+		return nil
+	}
+}
+
+// WithMergeFast enables the fast merging of trees: compressed baskets
+// are copied as they are stored in the input ROOT files, without being
+// decompressed nor recompressed.
+func WithMergeFast(fast bool) MergeOption {
+	return func(cmd *mergeCmd) error {
+		cmd.fast = fast
+		return nil
+	}
+}
+
+// WithMergeMaxSize sets the maximum size (in bytes) of the output ROOT file.
+//
+// When the output ROOT file grows past that size, it is closed and the
+// content of the remaining input files is merged into a new output file,
+// named after the first one: out.root, out-1.root, out-2.root, ...
+//
+// The size of the output ROOT file is checked after each input ROOT file
+// has been merged.
+// Objects held in memory (e.g. histograms) are only written when the
+// output ROOT file is closed: output files may thus exceed that size.
+func WithMergeMaxSize(n int64) MergeOption {
+	return func(cmd *mergeCmd) error {
+		if n < 0 {
+			return fmt.Errorf("rcmd: invalid maximum output size (%d)", n)
+		}
+		cmd.maxSize = n
+		return nil
+	}
+}
+
 // Merge merges all input fnames ROOT files into the output oname one.
-func Merge(oname string, fnames []string, verbose bool) error {
+func Merge(oname string, fnames []string, verbose bool, opts ...MergeOption) error {
+	cmd := mergeCmd{verbose: verbose, workers: 1}
+	for _, opt := range opts {
+		err := opt(&cmd)
+		if err != nil {
+			return fmt.Errorf("could not configure root-merge: %w", err)
+		}
+	}
+
+	for i := 0; len(fnames) > 0; i++ {
+		name := oname
+		if i > 0 {
+			name = fmt.Sprintf("%s-%d.root", strings.TrimSuffix(oname, ".root"), i)
+		}
+		n, err := cmd.merge(name, fnames)
+		if err != nil {
+			return err
+		}
+		fnames = fnames[n:]
+	}
+
+	return nil
+}
+
+// merge merges the input fnames ROOT files into the output oname one,
+// until the maximum output size is reached.
+// merge returns the number of input files that have been merged.
+func (cmd *mergeCmd) merge(oname string, fnames []string) (int, error) {
 	o, err := groot.Create(oname)
 	if err != nil {
-		return fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
+		return 0, fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
 	}
 	defer o.Close()
 
-	cmd := mergeCmd{verbose: verbose}
+	if cmd.verbose {
+		log.Printf("creating [%s]...", oname)
+	}
+
 	tsks, err := cmd.mergeTasksFrom(o, fnames[0])
 	if err != nil {
-		return fmt.Errorf("could not create merge tasks: %w", err)
+		return 0, fmt.Errorf("could not create merge tasks: %w", err)
 	}
 
+	n := 1
 	for _, fname := range fnames[1:] {
-		err := cmd.process(tsks, fname)
+		full, err := cmd.full(o)
+		if err != nil {
+			return n, fmt.Errorf("could not check size of output ROOT file %q: %w", oname, err)
+		}
+		if full {
+			break
+		}
+
+		err = cmd.process(tsks, fname)
 		if err != nil {
-			return fmt.Errorf("could not process ROOT file %q: %w", fname, err)
+			return n, fmt.Errorf("could not process ROOT file %q: %w", fname, err)
 		}
+		n++
 	}
 
 	for i := range tsks {
 		tsk := &tsks[i]
 		err := tsk.close(o)
 		if err != nil {
-			return fmt.Errorf("could not close task %d (%s): %w", i, tsk.path(), err)
+			return n, fmt.Errorf("could not close task %d (%s): %w", i, tsk.path(), err)
 		}
 	}
 
 	err = o.Close()
 	if err != nil {
-		return fmt.Errorf("could not close output ROOT file %q: %w", oname, err)
+		return n, fmt.Errorf("could not close output ROOT file %q: %w", oname, err)
 	}
 
-	return nil
+	return n, nil
 }
 
 type mergeCmd struct {
 	verbose bool
+	workers int
+	fast    bool
+	maxSize int64
+	incl    []*regexp.Regexp
+	excl    []*regexp.Regexp
+
+	mu sync.Mutex // serializes writes to the output ROOT file
+}
+
+// full returns whether the output ROOT file reached its maximum size.
+func (cmd *mergeCmd) full(o *riofs.File) (bool, error) {
+	if cmd.maxSize <= 0 {
+		return false, nil
+	}
+	fi, err := o.Stat()
+	if err != nil {
+		return false, err
+	}
+	return fi.Size() >= cmd.maxSize, nil
+}
+
+// excluded returns whether the ROOT object with the provided full path
+// matches any of the exclude filters.
+func (cmd *mergeCmd) excluded(name string) bool {
+	for _, re := range cmd.excl {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
 }
 
-func (mergeCmd) acceptObj(obj root.Object) bool {
+// included returns whether the ROOT object with the provided full path
+// matches any of the include filters.
+func (cmd *mergeCmd) included(name string) bool {
+	if len(cmd.incl) == 0 {
+		return true
+	}
+	for _, re := range cmd.incl {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (*mergeCmd) acceptObj(obj root.Object) bool {
 	switch obj.(type) {
 	case rtree.Tree:
 		// need to specially handle rtree.Tree.
@@ -72,7 +243,11 @@ func (mergeCmd) acceptObj(obj root.Object) bool {
 	}
 }
 
-func (cmd mergeCmd) process(tsks []task, fname string) error {
+// process merges the content of the fname ROOT file into the tasks.
+//
+// Objects are first read sequentially from the input ROOT file and then
+// merged concurrently.
+func (cmd *mergeCmd) process(tsks []task, fname string) error {
 	if cmd.verbose {
 		log.Printf("merging [%s]...", fname)
 	}
@@ -83,15 +258,53 @@ func (cmd mergeCmd) process(tsks []task, fname string) error {
 	}
 	defer f.Close()
 
+	objs := make([]root.Object, len(tsks))
 	for i := range tsks {
 		tsk := &tsks[i]
-		err = tsk.merge(f)
+		objs[i], err = riofs.Dir(f).Get(tsk.path())
 		if err != nil {
-			return fmt.Errorf("could not merge task %d (%s) for file %q: %w", i, tsk.path(), fname, err)
+			return fmt.Errorf("could not get task %d (%s) from file %q: %w", i, tsk.path(), fname, err)
 		}
 	}
 
-	return nil
+	if cmd.workers == 1 {
+		for i := range tsks {
+			tsk := &tsks[i]
+			err = tsk.merge(objs[i])
+			if err != nil {
+				return fmt.Errorf("could not merge task %d (%s) for file %q: %w", i, tsk.path(), fname, err)
+			}
+		}
+		return nil
+	}
+
+	grp, ctx := errgroup.WithContext(context.Background())
+	idx := make(chan int)
+	for i := 0; i < cmd.workers; i++ {
+		grp.Go(func() error {
+			for i := range idx {
+				tsk := &tsks[i]
+				err := tsk.merge(objs[i])
+				if err != nil {
+					return fmt.Errorf("could not merge task %d (%s) for file %q: %w", i, tsk.path(), fname, err)
+				}
+			}
+			return nil
+		})
+	}
+
+	go func() {
+		defer close(idx)
+		for i := range tsks {
+			select {
+			case idx <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return grp.Wait()
 }
 
 type task struct {
@@ -100,6 +313,8 @@ type task struct {
 	obj root.Object
 
 	verbose bool
+	fast    bool
+	mu      *sync.Mutex // serializes writes to the output ROOT file
 }
 
 func (cmd *mergeCmd) mergeTasksFrom(o *riofs.File, fname string) ([]task, error) {
@@ -119,7 +334,18 @@ func (cmd *mergeCmd) mergeTasksFrom(o *riofs.File, fname string) ([]task, error)
 			return nil
 		}
 
+		if cmd.excluded(name) {
+			if _, ok := obj.(riofs.Directory); ok {
+				return riofs.SkipDir
+			}
+			return nil
+		}
+
 		if _, ok := obj.(riofs.Directory); ok {
+			if !cmd.included(name) {
+				// directory will be created if any of its content is selected.
+				return nil
+			}
 			_, err := riofs.Dir(o).Mkdir(name)
 			if err != nil {
 				return fmt.Errorf("could not create dir %q in output ROOT file: %w", name, err)
@@ -130,7 +356,7 @@ func (cmd *mergeCmd) mergeTasksFrom(o *riofs.File, fname string) ([]task, error)
 			return nil
 		}
 
-		if !cmd.acceptObj(obj) {
+		if !cmd.included(name) || !cmd.acceptObj(obj) {
 			return nil
 		}
 		if cmd.verbose {
@@ -144,11 +370,10 @@ func (cmd *mergeCmd) mergeTasksFrom(o *riofs.File, fname string) ([]task, error)
 		)
 
 		if dirName != "/" && dirName != "" {
-			obj, err := riofs.Dir(o).Get(dirName)
+			dir, err = riofs.Dir(o).Mkdir(dirName)
 			if err != nil {
-				return fmt.Errorf("could not get dir %q from output ROOT file: %w", dirName, err)
+				return fmt.Errorf("could not create dir %q in output ROOT file: %w", dirName, err)
 			}
-			dir = obj.(riofs.Directory)
 		}
 
 		switch oo := obj.(type) {
@@ -158,18 +383,26 @@ func (cmd *mergeCmd) mergeTasksFrom(o *riofs.File, fname string) ([]task, error)
 				return fmt.Errorf("could not create output ROOT tree %q: %w", name, err)
 			}
 
-			r, err := rtree.NewReader(oo, nil)
-			if err != nil {
-				return fmt.Errorf(
-					"could not create input ROOT tree reader %q: %w",
-					name, err,
-				)
-			}
-			defer r.Close()
-
-			_, err = rtree.Copy(w, r)
-			if err != nil {
-				return fmt.Errorf("could not seed output ROOT tree %q: %w", name, err)
+			switch {
+			case cmd.fast:
+				_, err = rtree.FastCopy(w, oo)
+				if err != nil {
+					return fmt.Errorf("could not seed output ROOT tree %q: %w", name, err)
+				}
+			default:
+				r, err := rtree.NewReader(oo, nil)
+				if err != nil {
+					return fmt.Errorf(
+						"could not create input ROOT tree reader %q: %w",
+						name, err,
+					)
+				}
+				defer r.Close()
+
+				_, err = rtree.Copy(w, r)
+				if err != nil {
+					return fmt.Errorf("could not seed output ROOT tree %q: %w", name, err)
+				}
 			}
 			obj = w
 		}
@@ -179,6 +412,8 @@ func (cmd *mergeCmd) mergeTasksFrom(o *riofs.File, fname string) ([]task, error)
 			key:     objName,
 			obj:     obj,
 			verbose: cmd.verbose,
+			fast:    cmd.fast,
+			mu:      &cmd.mu,
 		})
 		return nil
 	})
@@ -197,14 +432,14 @@ func (tsk *task) path() string {
 	return stdpath.Join(tsk.dir, tsk.key)
 }
 
-func (tsk *task) merge(f *riofs.File) error {
+func (tsk *task) merge(obj root.Object) error {
 	name := tsk.path()
-	obj, err := riofs.Dir(f).Get(name)
-	if err != nil {
-		return fmt.Errorf("could not get %q: %w", name, err)
+	if _, ok := tsk.obj.(rtree.Writer); ok {
+		tsk.mu.Lock()
+		defer tsk.mu.Unlock()
 	}
 
-	err = tsk.mergeObj(tsk.obj, obj)
+	err := tsk.mergeObj(tsk.obj, obj)
 	if err != nil {
 		return fmt.Errorf("could not merge %q: %w", name, err)
 	}
@@ -218,7 +453,7 @@ func (tsk *task) close(f *riofs.File) error {
 	case rtree.Writer:
 		err = obj.Close()
 	default:
-		err = riofs.Dir(f).Put(tsk.path(), tsk.obj)
+		err = riofs.Dir(f).Put(strings.TrimPrefix(tsk.path(), "/"), tsk.obj)
 	}
 
 	if err != nil {
@@ -237,11 +472,16 @@ func (tsk *task) mergeObj(dst, src root.Object) error {
 		return fmt.Errorf("types differ: dst=%T, src=%T", dst, src)
 	}
 
+	if dst, ok := dst.(rtree.Writer); ok && tsk.fast {
+		_, err := rtree.FastCopy(dst, src.(rtree.Tree))
+		return err
+	}
+
 	switch dst := dst.(type) {
-	case rhist.H2:
-		return tsk.mergeH2(dst, src.(rhist.H2))
 	case root.Merger:
 		return dst.ROOTMerge(src)
+	case rhist.H2: // keep after root.Merger
+		return tsk.mergeH2(dst, src.(rhist.H2))
 	default:
 		return fmt.Errorf("could not find suitable merge-API for (dst=%T, src=%T)", dst, src)
 	}
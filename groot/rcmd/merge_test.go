@@ -10,12 +10,15 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"go-hep.org/x/hep/groot"
 	"go-hep.org/x/hep/groot/rcmd"
 	"go-hep.org/x/hep/groot/rhist"
 	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtree"
 	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hbook/rootcnv"
@@ -33,6 +36,7 @@ func TestMerge(t *testing.T) {
 		name   string
 		inputs []funcT
 		output funcT
+		opts   []rcmd.MergeOption
 		panics string
 	}{
 		{
@@ -45,6 +49,18 @@ func TestMerge(t *testing.T) {
 			inputs: []funcT{makeFlatTree(1), makeFlatTree(1)},
 			output: makeFlatTree(2),
 		},
+		{
+			name:   "flat-tree-2-fast",
+			inputs: []funcT{makeFlatTree(1), makeFlatTree(1)},
+			output: makeFlatTree(2),
+			opts:   []rcmd.MergeOption{rcmd.WithMergeFast(true)},
+		},
+		{
+			name:   "flat-tree-3-fast-workers",
+			inputs: []funcT{makeFlatTree(1), makeFlatTree(1), makeFlatTree(1)},
+			output: makeFlatTree(3),
+			opts:   []rcmd.MergeOption{rcmd.WithMergeFast(true), rcmd.WithMergeWorkers(4)},
+		},
 		{
 			name:   "h1f-1",
 			inputs: []funcT{makeH1F(1)},
@@ -65,6 +81,12 @@ func TestMerge(t *testing.T) {
 			inputs: []funcT{makeH1D(1), makeH1D(1)},
 			output: makeH1D(2),
 		},
+		{
+			name:   "h1d-3-workers",
+			inputs: []funcT{makeH1D(1), makeH1D(1), makeH1D(1)},
+			output: makeH1D(3),
+			opts:   []rcmd.MergeOption{rcmd.WithMergeWorkers(4)},
+		},
 		{
 			name:   "h1i-1",
 			inputs: []funcT{makeH1I(1)},
@@ -116,6 +138,12 @@ func TestMerge(t *testing.T) {
 			inputs: []funcT{makeGraphAsymmErr(0, 1), makeGraphAsymmErr(1, 2)},
 			output: makeGraphAsymmErr(0, 2),
 		},
+		{
+			name:   "graph-3-workers",
+			inputs: []funcT{makeGraph(0, 1), makeGraph(1, 2), makeGraph(2, 3)},
+			output: makeGraph(0, 3),
+			opts:   []rcmd.MergeOption{rcmd.WithMergeWorkers(2)},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			var (
@@ -150,7 +178,7 @@ func TestMerge(t *testing.T) {
 				}()
 			}
 
-			err = rcmd.Merge(oname, fnames, verbose)
+			err = rcmd.Merge(oname, fnames, verbose, tc.opts...)
 			if err != nil {
 				t.Fatalf("could not run root-merge: %+v", err)
 			}
@@ -540,3 +568,234 @@ func makeGraphAsymmErr(beg, end int) func(t *testing.T, fname string) error {
 		return nil
 	}
 }
+
+func TestMergeFilters(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-root-merge-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "input.root")
+	func() {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		defer f.Close()
+
+		for _, name := range []string{"dir-1/h1", "dir-1/h2", "dir-2/h1", "h3"} {
+			h := hbook.NewH1D(10, 0, 10)
+			h.Fill(5, 1)
+			err = riofs.Dir(f).Put(name, rootcnv.FromH1D(h))
+			if err != nil {
+				t.Fatalf("could not save %q: %+v", name, err)
+			}
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	for _, tc := range []struct {
+		name string
+		opts []rcmd.MergeOption
+		want []string
+	}{
+		{
+			name: "all",
+			want: []string{"/dir-1", "/dir-1/h1", "/dir-1/h2", "/dir-2", "/dir-2/h1", "/h3"},
+		},
+		{
+			name: "include",
+			opts: []rcmd.MergeOption{rcmd.WithMergeInclude("/h1$")},
+			want: []string{"/dir-1", "/dir-1/h1", "/dir-2", "/dir-2/h1"},
+		},
+		{
+			name: "exclude",
+			opts: []rcmd.MergeOption{rcmd.WithMergeExclude("^/dir-1")},
+			want: []string{"/dir-2", "/dir-2/h1", "/h3"},
+		},
+		{
+			name: "include-exclude",
+			opts: []rcmd.MergeOption{
+				rcmd.WithMergeInclude("^/dir-1", "^/h3$"),
+				rcmd.WithMergeExclude("h2"),
+			},
+			want: []string{"/dir-1", "/dir-1/h1", "/h3"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			err := rcmd.Merge(oname, []string{fname, fname}, false, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not run root-merge: %+v", err)
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			var got []string
+			err = riofs.Walk(f, func(path string, obj root.Object, err error) error {
+				if err != nil {
+					return err
+				}
+				if name := path[len(f.Name()):]; name != "" {
+					got = append(got, name)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not walk output file: %+v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid output content:\ngot= %q\nwant=%q", got, tc.want)
+			}
+		})
+	}
+
+	err = rcmd.Merge(filepath.Join(tmp, "invalid.root"), []string{fname}, false, rcmd.WithMergeInclude("("))
+	if err == nil {
+		t.Fatalf("expected an error for an invalid regexp")
+	}
+}
+
+func TestMergeTreeSchema(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-root-merge-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type DataF64 struct {
+		I32 int32
+		F64 float64
+	}
+	type DataF32 struct {
+		I32 int32
+		F64 float32
+	}
+
+	makeTree := func(fname string, ptr interface{}) {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		defer f.Close()
+
+		w, err := rtree.NewWriter(f, "tree", rtree.WriteVarsFromStruct(ptr))
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		for i := 0; i < 5; i++ {
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	var (
+		f64 = filepath.Join(tmp, "f64.root")
+		f32 = filepath.Join(tmp, "f32.root")
+	)
+	makeTree(f64, new(DataF64))
+	makeTree(f32, new(DataF32))
+
+	for _, tc := range []struct {
+		name string
+		opts []rcmd.MergeOption
+		want string
+	}{
+		{
+			name: "copy",
+			want: `rtree: can not merge tree "tree": branch "F64": leaves differ (dst=F64[1]/float64, src=F64[1]/float32)`,
+		},
+		{
+			name: "fast",
+			opts: []rcmd.MergeOption{rcmd.WithMergeFast(true)},
+			want: `rtree: can not fast-copy tree "tree": branch "F64": leaves differ (dst=F64[1]/float64, src=F64[1]/float32)`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			err := rcmd.Merge(oname, []string{f64, f32}, false, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got := err.Error(); !strings.HasSuffix(got, tc.want) {
+				t.Fatalf("invalid error:\ngot= %s\nwant=...%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeMaxSize(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-root-merge-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var fnames []string
+	for i := 0; i < 3; i++ {
+		fname := filepath.Join(tmp, fmt.Sprintf("input-%d.root", i))
+		err := makeFlatTree(1)(t, fname)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		}
+		fnames = append(fnames, fname)
+	}
+
+	oname := filepath.Join(tmp, "out.root")
+	err = rcmd.Merge(oname, fnames, false, rcmd.WithMergeMaxSize(1))
+	if err != nil {
+		t.Fatalf("could not run root-merge: %+v", err)
+	}
+
+	refname := filepath.Join(tmp, "want.root")
+	err = makeFlatTree(1)(t, refname)
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+
+	want := new(bytes.Buffer)
+	err = rcmd.Dump(want, refname, true, nil)
+	if err != nil {
+		t.Fatalf("could not run root-dump: %+v", err)
+	}
+
+	for _, name := range []string{"out.root", "out-1.root", "out-2.root"} {
+		got := new(bytes.Buffer)
+		err = rcmd.Dump(got, filepath.Join(tmp, name), true, nil)
+		if err != nil {
+			t.Fatalf("could not run root-dump on %q: %+v", name, err)
+		}
+
+		want := strings.Replace(want.String(), refname, filepath.Join(tmp, name), -1)
+		if got := got.String(); got != want {
+			t.Fatalf("invalid root-merge output %q:\ngot:\n%swant:\n%s", name, got, want)
+		}
+	}
+
+	_, err = os.Stat(filepath.Join(tmp, "out-3.root"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("unexpected output file out-3.root (err=%v)", err)
+	}
+}
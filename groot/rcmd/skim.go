@@ -0,0 +1,384 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	stdpath "path"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// SkimOption configures how root-skim should skim and slim a tree.
+type SkimOption func(cmd *skimCmd) error
+
+// WithSkimCut adds a selection cut to the skim.
+// Only the entries satisfying all the cuts are written to the output tree.
+// Cuts are applied in order and are reported in the cut-flow statistics.
+//
+// See rfunc.NewExprFormula for the syntax of expressions.
+func WithSkimCut(expr string) SkimOption {
+	return func(cmd *skimCmd) error {
+		if strings.TrimSpace(expr) == "" {
+			return fmt.Errorf("rcmd: empty cut expression")
+		}
+		cmd.cuts = append(cmd.cuts, expr)
+		return nil
+	}
+}
+
+// WithSkimKeep only keeps the branches whose name matches at least one of
+// the provided glob patterns (see path.Match for the syntax of patterns).
+func WithSkimKeep(globs ...string) SkimOption {
+	return func(cmd *skimCmd) error {
+		for _, glob := range globs {
+			if _, err := stdpath.Match(glob, ""); err != nil {
+				return fmt.Errorf("rcmd: invalid keep pattern %q: %w", glob, err)
+			}
+		}
+		cmd.keep = append(cmd.keep, globs...)
+		return nil
+	}
+}
+
+// WithSkimDrop drops the branches whose name matches any of the provided
+// glob patterns (see path.Match for the syntax of patterns).
+// Drop patterns are applied after keep patterns.
+func WithSkimDrop(globs ...string) SkimOption {
+	return func(cmd *skimCmd) error {
+		for _, glob := range globs {
+			if _, err := stdpath.Match(glob, ""); err != nil {
+				return fmt.Errorf("rcmd: invalid drop pattern %q: %w", glob, err)
+			}
+		}
+		cmd.drop = append(cmd.drop, globs...)
+		return nil
+	}
+}
+
+// WithSkimDefine defines a new float64 branch named name, computed from
+// the provided expression.
+//
+// See rfunc.NewExprFormula for the syntax of expressions.
+func WithSkimDefine(name, expr string) SkimOption {
+	return func(cmd *skimCmd) error {
+		if name == "" {
+			return fmt.Errorf("rcmd: empty branch name for expression %q", expr)
+		}
+		if strings.TrimSpace(expr) == "" {
+			return fmt.Errorf("rcmd: empty expression for branch %q", name)
+		}
+		cmd.defs = append(cmd.defs, skimDef{name: name, expr: expr})
+		return nil
+	}
+}
+
+// WithSkimCompression sets the compression algorithm and level of the
+// output tree, as "<algorithm>[:<level>]".
+// Valid algorithms are "none", "lz4", "lzma" and "zlib".
+//
+// Example:
+//
+//	WithSkimCompression("zlib:6")
+func WithSkimCompression(compr string) SkimOption {
+	return func(cmd *skimCmd) error {
+		opt, err := parseCompression(compr)
+		if err != nil {
+			return fmt.Errorf("rcmd: %w", err)
+		}
+		cmd.wopts = append(cmd.wopts, opt)
+		return nil
+	}
+}
+
+func parseCompression(compr string) (rtree.WriteOption, error) {
+	var (
+		alg = compr
+		lvl = -1
+	)
+	if i := strings.Index(compr, ":"); i >= 0 {
+		alg = compr[:i]
+		v, err := strconv.Atoi(compr[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid compression level in %q: %w", compr, err)
+		}
+		lvl = v
+	}
+
+	switch strings.ToLower(alg) {
+	case "none":
+		return rtree.WithoutCompression(), nil
+	case "lz4":
+		return rtree.WithLZ4(lvl), nil
+	case "lzma":
+		return rtree.WithLZMA(lvl), nil
+	case "zlib":
+		return rtree.WithZlib(lvl), nil
+	default:
+		return nil, fmt.Errorf("invalid compression algorithm %q", alg)
+	}
+}
+
+type skimCmd struct {
+	verbose bool
+	cuts    []string
+	keep    []string
+	drop    []string
+	defs    []skimDef
+	wopts   []rtree.WriteOption
+}
+
+type skimDef struct {
+	name string
+	expr string
+}
+
+// Skim reads the tname tree from the input fnames ROOT files (chained
+// together), selects the entries passing the configured cuts, keeps or
+// drops the configured branches, computes the newly defined branches and
+// writes the result to the oname ROOT file.
+//
+// Skim writes the cut-flow statistics to w.
+func Skim(w io.Writer, oname, tname string, fnames []string, verbose bool, opts ...SkimOption) error {
+	cmd := skimCmd{verbose: verbose}
+	for _, opt := range opts {
+		err := opt(&cmd)
+		if err != nil {
+			return fmt.Errorf("could not configure root-skim: %w", err)
+		}
+	}
+
+	if len(fnames) == 0 {
+		return fmt.Errorf("rcmd: no input ROOT file")
+	}
+
+	tree, closef, err := rtree.ChainOf(tname, fnames...)
+	if err != nil {
+		return fmt.Errorf("could not open input tree %q: %w", tname, err)
+	}
+	defer closef()
+
+	o, err := groot.Create(oname)
+	if err != nil {
+		return fmt.Errorf("could not create output ROOT file %q: %w", oname, err)
+	}
+	defer o.Close()
+
+	var (
+		dirName = stdpath.Dir(tname)
+		objName = stdpath.Base(tname)
+		dir     = riofs.Directory(o)
+	)
+	if dirName != "/" && dirName != "" && dirName != "." {
+		dir, err = riofs.Dir(o).Mkdir(dirName)
+		if err != nil {
+			return fmt.Errorf("could not create output directory %q: %w", dirName, err)
+		}
+	}
+
+	flow, err := cmd.skim(dir, objName, tree)
+	if err != nil {
+		return fmt.Errorf("could not skim tree %q: %w", tname, err)
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("could not close output ROOT file %q: %w", oname, err)
+	}
+
+	err = closef()
+	if err != nil {
+		return fmt.Errorf("could not close input ROOT files: %w", err)
+	}
+
+	flow.print(w)
+	return nil
+}
+
+func (cmd *skimCmd) skim(dir riofs.Directory, name string, tree rtree.Tree) (cutFlow, error) {
+	flow := cutFlow{
+		name: name,
+		cuts: make([]string, len(cmd.cuts)),
+		pass: make([]int64, len(cmd.cuts)),
+	}
+	copy(flow.cuts, cmd.cuts)
+
+	var (
+		rvars = rtree.NewReadVars(tree)
+		wvars = rtree.WriteVarsFromTree(tree)
+	)
+	sel, err := cmd.slim(wvars)
+	if err != nil {
+		return flow, err
+	}
+	{
+		var (
+			rs = make([]rtree.ReadVar, 0, len(sel))
+			ws = make([]rtree.WriteVar, 0, len(sel))
+		)
+		for _, i := range sel {
+			rvar := rvars[i]
+			rvar.Value = wvars[i].Value
+			rs = append(rs, rvar)
+			ws = append(ws, wvars[i])
+		}
+		rvars = rs
+		wvars = ws
+	}
+
+	r, err := rtree.NewReader(tree, rvars)
+	if err != nil {
+		return flow, fmt.Errorf("could not create tree reader: %w", err)
+	}
+	defer r.Close()
+
+	cuts := make([]func() float64, len(cmd.cuts))
+	for i, expr := range cmd.cuts {
+		f, err := r.FormulaExpr(expr)
+		if err != nil {
+			return flow, fmt.Errorf("could not create cut %q: %w", expr, err)
+		}
+		cuts[i] = f.Func().(func() float64)
+	}
+
+	var (
+		defs = make([]func() float64, len(cmd.defs))
+		vals = make([]*float64, len(cmd.defs))
+	)
+	for i, def := range cmd.defs {
+		for _, wvar := range wvars {
+			if wvar.Name == def.name {
+				return flow, fmt.Errorf("could not define branch %q: branch already exists", def.name)
+			}
+		}
+		f, err := r.FormulaExpr(def.expr)
+		if err != nil {
+			return flow, fmt.Errorf("could not define branch %q: %w", def.name, err)
+		}
+		defs[i] = f.Func().(func() float64)
+		vals[i] = new(float64)
+		wvars = append(wvars, rtree.WriteVar{Name: def.name, Value: vals[i]})
+	}
+
+	wopts := append([]rtree.WriteOption{rtree.WithTitle(tree.Title())}, cmd.wopts...)
+	w, err := rtree.NewWriter(dir, name, wvars, wopts...)
+	if err != nil {
+		return flow, fmt.Errorf("could not create tree writer: %w", err)
+	}
+	defer w.Close()
+
+	if cmd.verbose {
+		log.Printf("skimming tree %q (entries=%d)...", name, tree.Entries())
+	}
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		flow.all++
+		for i, cut := range cuts {
+			if cut() == 0 {
+				return nil
+			}
+			flow.pass[i]++
+		}
+
+		for i, def := range defs {
+			*vals[i] = def()
+		}
+
+		_, err := w.Write()
+		if err != nil {
+			return fmt.Errorf("could not write entry %d: %w", ctx.Entry, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return flow, fmt.Errorf("could not read tree: %w", err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return flow, fmt.Errorf("could not close tree writer: %w", err)
+	}
+
+	if cmd.verbose {
+		log.Printf("skimming tree %q (entries=%d)... [ok]", name, tree.Entries())
+	}
+
+	return flow, nil
+}
+
+// slim returns the indices of the write variables selected by the keep
+// and drop patterns.
+// Count variables of selected variables are always selected.
+func (cmd *skimCmd) slim(wvars []rtree.WriteVar) ([]int, error) {
+	match := func(globs []string, name string) bool {
+		for _, glob := range globs {
+			if ok, _ := stdpath.Match(glob, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	sel := make(map[string]bool, len(wvars))
+	for _, wvar := range wvars {
+		sel[wvar.Name] = (len(cmd.keep) == 0 || match(cmd.keep, wvar.Name)) && !match(cmd.drop, wvar.Name)
+	}
+	for _, wvar := range wvars {
+		if sel[wvar.Name] && wvar.Count != "" {
+			sel[wvar.Count] = true
+		}
+	}
+
+	var o []int
+	for i, wvar := range wvars {
+		if sel[wvar.Name] {
+			o = append(o, i)
+		}
+	}
+	if len(o) == 0 {
+		return nil, fmt.Errorf("no branch selected")
+	}
+	return o, nil
+}
+
+// cutFlow holds the number of entries passing each cut.
+type cutFlow struct {
+	name string
+	all  int64
+	cuts []string
+	pass []int64
+}
+
+func (flow cutFlow) print(w io.Writer) {
+	width := len("all entries")
+	for _, cut := range flow.cuts {
+		if len(cut) > width {
+			width = len(cut)
+		}
+	}
+
+	eff := func(n, d int64) float64 {
+		if d == 0 {
+			return 0
+		}
+		return 100 * float64(n) / float64(d)
+	}
+
+	fmt.Fprintf(w, "cut-flow for tree %q:\n", flow.name)
+	fmt.Fprintf(w, "  %-*s %10s %8s %8s\n", width, "cut", "entries", "eff(%)", "cumul(%)")
+	fmt.Fprintf(w, "  %-*s %10d %8.2f %8.2f\n", width, "all entries", flow.all, eff(flow.all, flow.all), eff(flow.all, flow.all))
+	prev := flow.all
+	for i, cut := range flow.cuts {
+		n := flow.pass[i]
+		fmt.Fprintf(w, "  %-*s %10d %8.2f %8.2f\n", width, cut, n, eff(n, prev), eff(n, flow.all))
+		prev = n
+	}
+}
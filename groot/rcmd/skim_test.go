@@ -0,0 +1,239 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rcmd_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+func TestSkim(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-root-skim-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../testdata/small-flat-tree.root"
+
+	for _, tc := range []struct {
+		name     string
+		fnames   []string
+		opts     []rcmd.SkimOption
+		entries  int64
+		branches []string
+		flow     string
+	}{
+		{
+			name:    "cuts",
+			fnames:  []string{fname},
+			opts:    []rcmd.SkimOption{rcmd.WithSkimCut("Int32 > 10"), rcmd.WithSkimCut("Float64 < 50")},
+			entries: 39,
+			branches: []string{
+				"Int32", "Int64", "UInt32", "UInt64", "Float32", "Float64", "Str",
+				"ArrayInt32", "ArrayInt64", "ArrayUInt32", "ArrayUInt64", "ArrayFloat32", "ArrayFloat64",
+				"N",
+				"SliceInt32", "SliceInt64", "SliceUInt32", "SliceUInt64", "SliceFloat32", "SliceFloat64",
+			},
+			flow: `cut-flow for tree "tree":
+  cut             entries   eff(%) cumul(%)
+  all entries         100   100.00   100.00
+  Int32 > 10           89    89.00    89.00
+  Float64 < 50         39    43.82    39.00
+`,
+		},
+		{
+			name:   "chain-slim-define",
+			fnames: []string{fname, fname},
+			opts: []rcmd.SkimOption{
+				rcmd.WithSkimCut("N >= 5"),
+				rcmd.WithSkimKeep("Slice*", "Int32"),
+				rcmd.WithSkimDrop("Slice*Int*", "SliceFloat32"),
+				rcmd.WithSkimDefine("F2", "Float64*Float64"),
+				rcmd.WithSkimCompression("zlib:6"),
+			},
+			entries:  100,
+			branches: []string{"Int32", "N", "SliceFloat64", "F2"},
+			flow: `cut-flow for tree "tree":
+  cut            entries   eff(%) cumul(%)
+  all entries        200   100.00   100.00
+  N >= 5             100    50.00    50.00
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			out := new(bytes.Buffer)
+			err := rcmd.Skim(out, oname, "tree", tc.fnames, true, tc.opts...)
+			if err != nil {
+				t.Fatalf("could not run root-skim: %+v", err)
+			}
+
+			if got, want := out.String(), tc.flow; got != want {
+				t.Fatalf("invalid cut-flow:\ngot:\n%s\nwant:\n%s", got, want)
+			}
+
+			f, err := groot.Open(oname)
+			if err != nil {
+				t.Fatalf("could not open output file: %+v", err)
+			}
+			defer f.Close()
+
+			obj, err := riofs.Dir(f).Get("tree")
+			if err != nil {
+				t.Fatalf("could not get output tree: %+v", err)
+			}
+			tree := obj.(rtree.Tree)
+
+			if got, want := tree.Entries(), tc.entries; got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			var names []string
+			for _, b := range tree.Branches() {
+				names = append(names, b.Name())
+			}
+			if !reflect.DeepEqual(names, tc.branches) {
+				t.Fatalf("invalid branches:\ngot= %q\nwant=%q", names, tc.branches)
+			}
+		})
+	}
+}
+
+func TestSkimDefine(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-root-skim-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	oname := filepath.Join(tmp, "out.root")
+	err = rcmd.Skim(
+		ioutil.Discard, oname, "tree", []string{"../testdata/small-flat-tree.root"}, false,
+		rcmd.WithSkimCut("Int32 % 10 == 0"),
+		rcmd.WithSkimKeep("Int32", "N", "SliceFloat64"),
+		rcmd.WithSkimDefine("Sum", "Sum$(SliceFloat64)"),
+	)
+	if err != nil {
+		t.Fatalf("could not run root-skim: %+v", err)
+	}
+
+	f, err := groot.Open(oname)
+	if err != nil {
+		t.Fatalf("could not open output file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not get output tree: %+v", err)
+	}
+
+	var (
+		i32  int32
+		n    int32
+		sli  []float64
+		sum  float64
+		want = []int32{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}
+	)
+	tree := obj.(rtree.Tree)
+	if got, want := tree.Entries(), int64(len(want)); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	r, err := rtree.NewReader(tree, []rtree.ReadVar{
+		{Name: "Int32", Value: &i32},
+		{Name: "N", Value: &n},
+		{Name: "SliceFloat64", Value: &sli},
+		{Name: "Sum", Value: &sum},
+	})
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		if got, want := i32, want[ctx.Entry]; got != want {
+			t.Fatalf("entry %d: invalid Int32: got=%d, want=%d", ctx.Entry, got, want)
+		}
+		if got, want := int32(len(sli)), n; got != want {
+			t.Fatalf("entry %d: invalid slice length: got=%d, want=%d", ctx.Entry, got, want)
+		}
+		var want float64
+		for _, v := range sli {
+			want += v
+		}
+		if sum != want {
+			t.Fatalf("entry %d: invalid sum: got=%v, want=%v", ctx.Entry, sum, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+}
+
+func TestSkimErrors(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-root-skim-")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const fname = "../testdata/small-flat-tree.root"
+
+	for _, tc := range []struct {
+		name string
+		opts []rcmd.SkimOption
+		err  string
+	}{
+		{
+			name: "invalid-compression",
+			opts: []rcmd.SkimOption{rcmd.WithSkimCompression("gzip:1")},
+			err:  `invalid compression algorithm "gzip"`,
+		},
+		{
+			name: "invalid-pattern",
+			opts: []rcmd.SkimOption{rcmd.WithSkimKeep("[")},
+			err:  `invalid keep pattern "["`,
+		},
+		{
+			name: "no-branch",
+			opts: []rcmd.SkimOption{rcmd.WithSkimKeep("NotThere*")},
+			err:  "no branch selected",
+		},
+		{
+			name: "invalid-cut",
+			opts: []rcmd.SkimOption{rcmd.WithSkimCut("Int32 >")},
+			err:  `could not create cut "Int32 >"`,
+		},
+		{
+			name: "redefine",
+			opts: []rcmd.SkimOption{rcmd.WithSkimDefine("Int32", "2*Int32")},
+			err:  `could not define branch "Int32": branch already exists`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			oname := filepath.Join(tmp, tc.name+".root")
+			err := rcmd.Skim(ioutil.Discard, oname, "tree", []string{fname}, false, tc.opts...)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 )
 
 func init() {
+	StreamerInfos.Add(NewCxxStreamerInfo("TAtt3D", 1, 0x757a, []rbytes.StreamerElement{}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TAttAxis", 4, 0x5c6fff3e, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
 			Name:   *rbase.NewNamed("fNdivisions", "Number of divisions(10000*n3 + 100*n2 + n1)"),
@@ -158,6 +159,87 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TAttBBox2D", 0, 0x2549fc, []rbytes.StreamerElement{}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TAttCanvas", 1, 0xf676633f, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXBetween", "X distance between pads"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYBetween", "Y distance between pads"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTitleFromTop", "Y distance of Global Title from top"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXdate", "X position where to draw the date"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYdate", "X position where to draw the date"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAdate", "Alignment for the date"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TAttFill", 2, 0xffd92a92, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
 			Name:   *rbase.NewNamed("fFillColor", "Fill area color"),
@@ -268,257 +350,217 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TNamed", 1, 0xdfb74a3c, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fName", "object identifier"),
-			Type:   rmeta.TString,
-			Size:   24,
+	StreamerInfos.Add(NewCxxStreamerInfo("TAttPad", 4, 0xa715f011, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLeftMargin", "LeftMargin"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTitle", "object title"),
-			Type:   rmeta.TString,
-			Size:   24,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fRightMargin", "RightMargin"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TObject", 1, 0x901bc02d, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUniqueID", "object unique identifier"),
-			Type:   rmeta.UInt,
+			Name:   *rbase.NewNamed("fBottomMargin", "BottomMargin"),
+			Type:   rmeta.Float,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned int",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBits", "bit field status word"),
-			Type:   rmeta.Bits,
+			Name:   *rbase.NewNamed("fTopMargin", "TopMargin"),
+			Type:   rmeta.Float,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned int",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TObjString", 1, 0x9c8e4800, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fString", "wrapped TString"),
-			Type:   rmeta.TString,
-			Size:   24,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXfile", "X position where to draw the file name"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TProcessID", 1, 0x2b7ef579, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYfile", "Y position where to draw the file name"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TProcessUUID", 1, 0x9af3ae11, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TProcessID", "Process Unique Identifier in time and space"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAfile", "Alignment for the file name"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 729740665, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUUIDs", "Global list of TUUIDs"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXstat", "X position where to draw the statistics"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fActive", "Table of active UUIDs"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYstat", "Y position where to draw the statistics"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TBits*",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TRef", 1, 0x91757901, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAstat", "Alignment for the statistics"),
+			Type:   rmeta.Float,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "float",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TUUID", 1, 0xd24ea15b, []rbytes.StreamerElement{
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTimeLow", "60 bit time, lower 32 bits"),
-			Type:   rmeta.UInt,
-			Size:   4,
+			Name:   *rbase.NewNamed("fFrameFillColor", "Pad frame fill color"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned int",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTimeMid", "middle 16 time bits"),
-			Type:   rmeta.UShort,
+			Name:   *rbase.NewNamed("fFrameLineColor", "Pad frame line color"),
+			Type:   rmeta.Short,
 			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned short",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTimeHiAndVersion", "high 12 time bits + 4 UUID version bits"),
-			Type:   rmeta.UShort,
+			Name:   *rbase.NewNamed("fFrameFillStyle", "Pad frame fill style"),
+			Type:   rmeta.Short,
 			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned short",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClockSeqHiAndReserved", "high 6 clock bits + 2 bits reserved"),
-			Type:   rmeta.UChar,
-			Size:   1,
+			Name:   *rbase.NewNamed("fFrameLineStyle", "Pad frame line style"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned char",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClockSeqLow", "low 8 clock bits"),
-			Type:   rmeta.UChar,
-			Size:   1,
+			Name:   *rbase.NewNamed("fFrameLineWidth", "Pad frame line width"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned char",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNode", "6 node id bytes"),
-			Type:   rmeta.Enum(31),
-			Size:   6,
-			ArrLen: 6,
-			ArrDim: 1,
-			MaxIdx: [5]int32{6, 0, 0, 0, 0},
+			Name:   *rbase.NewNamed("fFrameBorderSize", "Pad frame border size"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned char",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArray", 1, 0x7021b2, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fN", "Number of array elements"),
+			Name:   *rbase.NewNamed("fFrameBorderMode", "Pad frame border mode"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -531,203 +573,161 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayC", 1, 0xae879936, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TNamed", 1, 0xdfb74a3c, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN chars"),
-			Type:   41,
-			Size:   1,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fName", "object identifier"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "char*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 1, "fN", "TArray"),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayS", 1, 0x35c9314, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN shorts"),
-			Type:   42,
-			Size:   2,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTitle", "object title"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short*",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1, "fN", "TArray"),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayI", 1, 0xd9d571c7, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+	StreamerInfos.Add(NewCxxStreamerInfo("TObject", 1, 0x901bc02d, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUniqueID", "object unique identifier"),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN 32 bit integers"),
-			Type:   43,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBits", "bit field status word"),
+			Type:   rmeta.Bits,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int*",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1, "fN", "TArray"),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayL", 1, 0xfcfcb489, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TObjString", 1, 0x9c8e4800, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN longs"),
-			Type:   44,
-			Size:   8,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fString", "wrapped TString"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "long*",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1, "fN", "TArray"),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayL64", 1, 0x18e70794, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TProcessID", 1, 0x2b7ef579, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN long64s"),
-			Type:   56,
-			Size:   8,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "Long64_t*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 1, "fN", "TArray"),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayF", 1, 0x5a0bf6f1, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TProcessUUID", 1, 0x9af3ae11, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Name:   *rbase.NewNamed("TProcessID", "Process Unique Identifier in time and space"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			MaxIdx: [5]int32{0, 729740665, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN floats"),
-			Type:   45,
-			Size:   4,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUUIDs", "Global list of TUUIDs"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "float*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 1, "fN", "TArray"),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TArrayD", 1, 0x7139ef34, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN doubles"),
-			Type:   48,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fActive", "Table of active UUIDs"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "TBits*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1, "fN", "TArray"),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBits", 1, 0xe763c48, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TQObject", 1, 0x42e9c, []rbytes.StreamerElement{}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TRef", 1, 0x91757901, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
@@ -741,8 +741,10 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TUUID", 1, 0xd24ea15b, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbits", "Highest bit set + 1"),
+			Name:   *rbase.NewNamed("fTimeLow", "60 bit time, lower 32 bits"),
 			Type:   rmeta.UInt,
 			Size:   4,
 			ArrLen: 0,
@@ -755,61 +757,74 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytes", "Number of UChars in fAllBits"),
-			Type:   rmeta.UInt,
-			Size:   4,
+			Name:   *rbase.NewNamed("fTimeMid", "middle 16 time bits"),
+			Type:   rmeta.UShort,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned int",
+			EName:  "unsigned short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fAllBits", "[fNbytes] array of UChars"),
-			Type:   51,
-			Size:   1,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTimeHiAndVersion", "high 12 time bits + 4 UUID version bits"),
+			Type:   rmeta.UShort,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned char*",
+			EName:  "unsigned short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1, "fNbytes", "TBits"),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TCollection", 3, 0x57e3cb9c, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClockSeqHiAndReserved", "high 6 clock bits + 2 bits reserved"),
+			Type:   rmeta.UChar,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "unsigned char",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fName", "name of the collection"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClockSeqLow", "low 8 clock bits"),
+			Type:   rmeta.UChar,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "unsigned char",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSize", "number of elements in collection"),
+			Name:   *rbase.NewNamed("fNode", "6 node id bytes"),
+			Type:   rmeta.Enum(31),
+			Size:   6,
+			ArrLen: 6,
+			ArrDim: 1,
+			MaxIdx: [5]int32{6, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "unsigned char",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TArray", 1, 0x7021b2, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fN", "Number of array elements"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -822,218 +837,218 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TClonesArray", 4, 0xb6c0ca63, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayC", 1, 0xae879936, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObjArray", "An array of objects"),
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1449237166, 0, 0, 0},
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TList", 5, 0x69c5c3bb, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TSeqCollection", "Sequenceable collection ABC"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN chars"),
+			Type:   41,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -60015674, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "char*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 0),
+		}.New(), 1, "fN", "TArray"),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("THashList", 0, 0xcc7e49c1, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayS", 1, 0x35c9314, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TList", "Doubly linked list"),
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1774568379, 0, 0, 0},
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TMap", 3, 0xfec4a388, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TCollection", "Collection abstract base class"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1474546588, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 3),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTable", "Hash table used to store TPair's"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN shorts"),
+			Type:   42,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "THashTable*",
+			EName:  "short*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1, "fN", "TArray"),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TObjArray", 3, 0xa99e6552, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayI", 1, 0xd9d571c7, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TSeqCollection", "Sequenceable collection ABC"),
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -60015674, 0, 0, 0},
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 0),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLowerBound", "Lower bound of the array"),
-			Type:   rmeta.Int,
-			Size:   4,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "int",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLast", "Last element in array containing an object"),
-			Type:   rmeta.Int,
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN 32 bit integers"),
+			Type:   43,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "int*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1, "fN", "TArray"),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TRefArray", 1, 0x47f9d0dd, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayL", 1, 0xfcfcb489, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TSeqCollection", "Sequenceable collection ABC"),
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -60015674, 0, 0, 0},
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 0),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fPID", "Pointer to Process Unique Identifier"),
-			Type:   rmeta.ObjectP,
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN longs"),
+			Type:   44,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TProcessID*",
+			EName:  "long*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1, "fN", "TArray"),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayL64", 1, 0x18e70794, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
 		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fUIDs", "[fSize] To store uids of referenced objects"),
-			Type:   53,
-			Size:   4,
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN long64s"),
+			Type:   56,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned int*",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3, "fSize", "TCollection"),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLowerBound", "Lower bound of the array"),
-			Type:   rmeta.Int,
-			Size:   4,
+		}.New(), 1, "fN", "TArray"),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayF", 1, 0x5a0bf6f1, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLast", "Last element in array containing an object"),
-			Type:   rmeta.Int,
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN floats"),
+			Type:   45,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "float*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1, "fN", "TArray"),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TSeqCollection", 0, 0xfc6c3bc6, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TArrayD", 1, 0x7139ef34, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TCollection", "Collection abstract base class"),
+			Name:   *rbase.NewNamed("TArray", "Abstract array base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1474546588, 0, 0, 0},
+			MaxIdx: [5]int32{0, 7348658, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3),
+		}.New(), 1),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fArray", "[fN] Array of fN doubles"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, "fN", "TArray"),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerInfo", 9, 0x90566883, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TBits", 1, 0xe763c48, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TVirtualStreamerInfo", "Abstract Interface describing Streamer information for one class"),
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1154605729, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 6),
+		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCheckSum", "Checksum of original class"),
+			Name:   *rbase.NewNamed("fNbits", "Highest bit set + 1"),
 			Type:   rmeta.UInt,
 			Size:   4,
 			ArrLen: 0,
@@ -1046,61 +1061,61 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClassVersion", "Class version identifier"),
-			Type:   rmeta.Int,
+			Name:   *rbase.NewNamed("fNbytes", "Number of UChars in fAllBits"),
+			Type:   rmeta.UInt,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fElements", "Array of TStreamerElements"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fAllBits", "[fNbytes] array of UChars"),
+			Type:   51,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray*",
+			EName:  "unsigned char*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1, "fNbytes", "TBits"),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerElement", 4, 0xdd0eb253, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TCollection", 3, 0x57e3cb9c, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fType", "element type"),
-			Type:   rmeta.Int,
-			Size:   4,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fName", "name of the collection"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSize", "sizeof element"),
+			Name:   *rbase.NewNamed("fSize", "number of elements in collection"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -1112,75 +1127,109 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fArrayLength", "cumulative size of all array dims"),
-			Type:   rmeta.Int,
-			Size:   4,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TClonesArray", 4, 0xb6c0ca63, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObjArray", "An array of objects"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1449237166, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fArrayDim", "number of array dimensions"),
-			Type:   rmeta.Int,
-			Size:   4,
+		}.New(), 3),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TList", 5, 0x69c5c3bb, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TSeqCollection", "Sequenceable collection ABC"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "int",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaxIndex", "Maximum array index for array dimension \"dim\""),
-			Type:   rmeta.Enum(23),
-			Size:   20,
-			ArrLen: 5,
-			ArrDim: 1,
-			MaxIdx: [5]int32{5, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -60015674, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTypeName", "Data type name of data member"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New(), 0),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("THashList", 0, 0xcc7e49c1, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TList", "Doubly linked list"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1774568379, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 5),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerBase", 3, 0x92715b0, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TMap", 3, 0xfec4a388, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Name:   *rbase.NewNamed("TCollection", "Collection abstract base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1474546588, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBaseVersion", "version number of the base class (used during memberwise streaming)"),
+		}.New(), 3),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTable", "Hash table used to store TPair's"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "THashTable*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TObjArray", 3, 0xa99e6552, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TSeqCollection", "Sequenceable collection ABC"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -60015674, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 0),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLowerBound", "Lower bound of the array"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLast", "Last element in array containing an object"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -1193,91 +1242,158 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerBasicType", 2, 0xf5d5ac4d, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TRefArray", 1, 0x47f9d0dd, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Name:   *rbase.NewNamed("TSeqCollection", "Sequenceable collection ABC"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			MaxIdx: [5]int32{0, -60015674, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
+		}.New(), 0),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPID", "Pointer to Process Unique Identifier"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TProcessID*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fUIDs", "[fSize] To store uids of referenced objects"),
+			Type:   53,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "unsigned int*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3, "fSize", "TCollection"),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLowerBound", "Lower bound of the array"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLast", "Last element in array containing an object"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerBasicPointer", 2, 0x318803b, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TSeqCollection", 0, 0xfc6c3bc6, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Name:   *rbase.NewNamed("TCollection", "Collection abstract base class"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			MaxIdx: [5]int32{0, 1474546588, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
+		}.New(), 3),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerInfo", 9, 0x90566883, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TVirtualStreamerInfo", "Abstract Interface describing Streamer information for one class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1154605729, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 6),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCountVersion", "version number of the class with the counter"),
-			Type:   rmeta.Int,
+			Name:   *rbase.NewNamed("fCheckSum", "Checksum of original class"),
+			Type:   rmeta.UInt,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCountName", "name of data member holding the array count"),
-			Type:   rmeta.TString,
-			Size:   24,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClassVersion", "Class version identifier"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCountClass", "name of the class with the counter"),
-			Type:   rmeta.TString,
-			Size:   24,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fElements", "Array of TStreamerElements"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "TObjArray*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerLoop", 2, 0xf350b8dc, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerElement", 4, 0xdd0eb253, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
+		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCountVersion", "version number of the class with the counter"),
+			Name:   *rbase.NewNamed("fType", "element type"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -1289,21 +1405,60 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCountName", "name of data member holding the array count"),
-			Type:   rmeta.TString,
-			Size:   24,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSize", "sizeof element"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fArrayLength", "cumulative size of all array dims"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fArrayDim", "number of array dimensions"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaxIndex", "Maximum array index for array dimension \"dim\""),
+			Type:   rmeta.Enum(23),
+			Size:   20,
+			ArrLen: 5,
+			ArrDim: 1,
+			MaxIdx: [5]int32{5, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCountClass", "name of the class with the counter"),
+			Name:   *rbase.NewNamed("fTypeName", "Data type name of data member"),
 			Type:   rmeta.TString,
 			Size:   24,
 			ArrLen: 0,
@@ -1316,7 +1471,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerObject", 2, 0x626ac134, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerBase", 3, 0x92715b0, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
 			Type:   rmeta.Base,
@@ -1330,23 +1485,21 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 4),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerObjectPointer", 2, 0x5de72d4b, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBaseVersion", "version number of the base class (used during memberwise streaming)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerObjectAny", 2, 0x8f7ef50, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerBasicType", 2, 0xf5d5ac4d, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
 			Type:   rmeta.Base,
@@ -1361,7 +1514,7 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 4),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerObjectAnyPointer", 1, 0x3600167f, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerBasicPointer", 2, 0x318803b, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
 			Type:   rmeta.Base,
@@ -1375,135 +1528,3452 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 4),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerString", 2, 0xa0aa915e, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCountVersion", "version number of the class with the counter"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCountName", "name of data member holding the array count"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCountClass", "name of the class with the counter"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerLoop", 2, 0xf350b8dc, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCountVersion", "version number of the class with the counter"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCountName", "name of data member holding the array count"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCountClass", "name of the class with the counter"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerObject", 2, 0x626ac134, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerObjectPointer", 2, 0x5de72d4b, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerObjectAny", 2, 0x8f7ef50, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerObjectAnyPointer", 1, 0x3600167f, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerString", 2, 0xa0aa915e, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerSTL", 3, 0x89f908d6, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSTLtype", "type of STL vector"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCtype", "STL contained type"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerSTLstring", 2, 0x262eefa1, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerSTL", "Streamer element of type STL container"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1980167978, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerArtificial", 0, 0x5629bbc9, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TAxis", 10, 0x5a496e70, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttAxis", "Axis attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1550843710, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbins", "Number of bins"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXmin", "low edge of first bin"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXmax", "upper edge of last bin"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXbins", "Bin edges array in X"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFirst", "first bin to display"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLast", "last bin to display"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBits2", "second bit status word"),
+			Type:   rmeta.UShort,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "unsigned short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTimeDisplay", "on/off displaying time values instead of numerics"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTimeFormat", "Date&time format, ex: 09/12/99 12:34:00"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLabels", "List of labels"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "THashList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fModLabs", "List of modified labels"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TCanvas", 8, 0x9546263a, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TPad", "A Graphics pad"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1553368677, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 13),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCatt", "Canvas attributes"),
+			Type:   rmeta.Any,
+			Size:   32,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TAttCanvas",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDISPLAY", "Name of destination screen"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXsizeUser", "User specified size of canvas along X in CM"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYsizeUser", "User specified size of canvas along Y in CM"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXsizeReal", "Current size of canvas along X in CM"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYsizeReal", "Current size of canvas along Y in CM"),
+			Type:   rmeta.Float,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "float",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHighLightColor", "Highlight color of active pad"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDoubleBuffer", "Double buffer flag (0=off, 1=on)"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWindowTopX", "Top X position of window (in pixels)"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWindowTopY", "Top Y position of window (in pixels)"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWindowWidth", "Width of window (including borders, etc.)"),
+			Type:   rmeta.UInt,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "unsigned int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWindowHeight", "Height of window (including menubar, borders, etc.)"),
+			Type:   rmeta.UInt,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "unsigned int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCw", "Width of the canvas along X (pixels)"),
+			Type:   rmeta.UInt,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "unsigned int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCh", "Height of the canvas along Y (pixels)"),
+			Type:   rmeta.UInt,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "unsigned int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fRetained", "Retain structure flag"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TEfficiency", 2, 0x6aec579f, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBeta_alpha", "global parameter for prior beta distribution (default = 1)"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBeta_beta", "global parameter for prior beta distribution (default = 1)"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fBeta_bin_params", "parameter for prior beta distribution different bin by bin"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<pair<double,double> >",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 61),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fConfLevel", "confidence level (default = 0.683, 1 sigma)"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFunctions", "->pointer to list of functions"),
+			Type:   rmeta.Objectp,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPassedHistogram", "histogram for events which passed certain criteria"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStatisticOption", "defines how the confidence intervals are determined"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTotalHistogram", "histogram for total number of events"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fWeight", "weight for all events (default = 1)"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TF1", 12, 0x826a9555, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXmin", "Lower bounds for the range"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXmax", "Upper bounds for the range"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNpar", "Number of parameters"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNdim", "Function dimension"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNpx", "Number of points used for the graphical representation"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fType", ""),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TF1::EFType",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNpfits", "Number of points used in the fit"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNDF", "Number of degrees of freedom in the fit"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fChisquare", "Function fit chisquare"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fParErrors", "Array of errors of the fNpar parameters"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<double>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 8),
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fParMin", "Array of lower limits of the fNpar parameters"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<double>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 8),
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fParMax", "Array of upper limits of the fNpar parameters"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<double>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 8),
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fSave", "Array of fNsave function values"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<double>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 8),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNormalized", "Normalization option (false by default)"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNormIntegral", "Integral of the function before being normalized"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFormula", "Pointer to TFormula in case when user define formula"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TFormula*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAnyPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fParams", "Pointer to Function parameters object (exists only for not-formula functions)"),
+			Type:   rmeta.AnyP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TF1Parameters*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fComposition", "Pointer to composition (NSUM or CONV)"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TF1AbsComposition*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TF1Parameters", 1, 0xd703028e, []rbytes.StreamerElement{
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fParameters", "parameter values"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<double>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 8),
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fParNames", "parameter names"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<string>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 61),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TFormula", 12, 0xd31f034c, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fClingParameters", "! parameter values"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<double>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 8),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAllParametersSetted", "flag to control if all parameters are setted"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fParams", "|| list of parameter names"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "map<TString,int,TFormulaParamOrder>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, 61),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFormula", "string representing the formula expression"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNdim", "Dimension - needed for lambda expressions"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fLinearParts", "vector of linear functions"),
+			Type:   rmeta.Streamer,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "vector<TObject*>",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, 64),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fVectorized", "whether we should use vectorized or regular variables"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TGraph", 4, 0x5f7f465, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNpoints", "Number of points <= fMaxSize"),
+			Type:   rmeta.Counter,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fX", "[fNpoints] array of X points"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, "fNpoints", "TGraph"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fY", "[fNpoints] array of Y points"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, "fNpoints", "TGraph"),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFunctions", "Pointer to list of functions (fits and user)"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHistogram", "Pointer to histogram used for drawing axis"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1F*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TGraphErrors", 3, 0x2a7ce30f, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TGraph", "Graph graphics class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 100136037, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEX", "[fNpoints] array of X errors"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, "fNpoints", "TGraph"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEY", "[fNpoints] array of Y errors"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, "fNpoints", "TGraph"),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TGraphAsymmErrors", 3, 0xcc46af3b, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TGraph", "Graph graphics class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 100136037, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEXlow", "[fNpoints] array of X low errors"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, "fNpoints", "TGraph"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEXhigh", "[fNpoints] array of X high errors"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, "fNpoints", "TGraph"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEYlow", "[fNpoints] array of Y low errors"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, "fNpoints", "TGraph"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEYhigh", "[fNpoints] array of Y high errors"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 4, "fNpoints", "TGraph"),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1", 8, 0x1c3740c4, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNcells", "number of bins(1D), cells (2D) +U/Overflows"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXaxis", "X axis descriptor"),
+			Type:   rmeta.Object,
+			Size:   216,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TAxis",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYaxis", "Y axis descriptor"),
+			Type:   rmeta.Object,
+			Size:   216,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TAxis",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fZaxis", "Z axis descriptor"),
+			Type:   rmeta.Object,
+			Size:   216,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TAxis",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBarOffset", "(1000*offset) for bar charts or legos"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBarWidth", "(1000*width) for bar charts or legos"),
+			Type:   rmeta.Short,
+			Size:   2,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "short",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumw", "Total Sum of weights"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumw2", "Total Sum of squares of weights"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwx", "Total Sum of weight*X"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwx2", "Total Sum of weight*X*X"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNormFactor", "Normalization factor"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fContour", "Array to display contour levels"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSumw2", "Array of sum of squares of weights"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fOption", "histogram options"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFunctions", "->Pointer to list of functions (fits and user)"),
+			Type:   rmeta.Objectp,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBufferSize", "fBuffer size"),
+			Type:   rmeta.Counter,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fBuffer", "[fBufferSize] entry buffer"),
+			Type:   48,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8, "fBufferSize", "TH1"),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinStatErrOpt", "option for bin statistical errors"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1::EBinErrorOpt",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStatOverflows", "per object flag to use under/overflows in statistics"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1::EStatOverflows",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1C", 3, 0x36f6e4ad, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayC", "Array of chars"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1366845130, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1D", 3, 0xf9b1569f, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1899622196, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1F", 3, 0xe2939644, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1I", 3, 0x627564f6, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -640323129, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1K", 2, 0xfd37ced5, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNIn", ""),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH1S", 3, 0x8c4d9dcb, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayS", "Array of shorts"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 56398612, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2", 5, 0x182347f, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fScalefactor", "Scale factor"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwxy", "Total Sum of weight*X*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2C", 4, 0xbd0010fe, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayC", "Array of chars"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1366845130, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2D", 4, 0x7fba82f0, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1899622196, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2F", 4, 0x689cc295, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2I", 4, 0xe87e9147, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -640323129, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2Poly", 3, 0xbc566968, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fOverflow", "Overflow bins"),
+			Type:   rmeta.Enum(28),
+			Size:   72,
+			ArrLen: 9,
+			ArrDim: 1,
+			MaxIdx: [5]int32{9, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCellX", "Number of partition cells in the x-direction of the histogram"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCellY", "Number of partition cells in the y-direction of the histogram"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNCells", "Number of partition cells: fCellX*fCellY"),
+			Type:   rmeta.Counter,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerLoop(Element{
+			Name:   *rbase.NewNamed("fCells", "[fNCells] The array of TLists that store the bins that intersect with each cell. List do not own the contained objects"),
+			Type:   rmeta.StreamLoop,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3, "fNCells", "TH2Poly"),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStepX", "Dimensions of a partition cell"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStepY", "Dimensions of a partition cell"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIsEmpty", "[fNCells] The array that returns true if the cell at the given coordinate is empty"),
+			Type:   58,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3, "fNCells", "TH2Poly"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fCompletelyInside", "[fNCells] The array that returns true if the cell at the given coordinate is completely inside a bin"),
+			Type:   58,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3, "fNCells", "TH2Poly"),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFloat", "When set to kTRUE, allows the histogram to expand if a bin outside the limits is added."),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBins", "List of bins. The list owns the contained objects"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2PolyBin", 1, 0x8fb3de7c, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fChanged", "For the 3D Painter"),
+			Type:   rmeta.Bool,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "bool",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNumber", "Bin number of the bin in TH2Poly"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPoly", "Object holding the polygon definition"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObject*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fArea", "Bin area"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fContent", "Bin content"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXmin", "X minimum value"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYmin", "Y minimum value"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXmax", "X maximum value"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYmax", "Y maximum value"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH2S", 4, 0x1256ca1c, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 5),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayS", "Array of shorts"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 56398612, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH3", 6, 0x8e8a4157, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 8),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAtt3D", "3D attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 30074, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwxy", "Total Sum of weight*X*Y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwz", "Total Sum of weight*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwz2", "Total Sum of weight*Z*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwxz", "Total Sum of weight*X*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwyz", "Total Sum of weight*Y*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH3D", 4, 0x7f83cc71, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH3", "3-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1903541929, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 6),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1899622196, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH3F", 4, 0xadb2efa5, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH3", "3-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1903541929, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 6),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TH3I", 4, 0xf2f9a473, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH3", "3-Dim histogram base class"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1903541929, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 6),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -640323129, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("THStack", 2, 0xaf96e964, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHists", "Pointer to array of TH1"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHistogram", "Pointer to histogram used for drawing axis"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("THnBase", 1, 0x42768e47, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNdimensions", "Number of dimensions"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAxes", "Axes of the histogram"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEntries", "Number of entries, spread over chunks"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumw", "Total sum of weights"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumw2", "Total sum of weights squared; -1 if no errors are calculated"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwx", "Total sum of weight*X for each dimension"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTsumwx2", "Total sum of weight*X*X for each dimension"),
+			Type:   rmeta.Any,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("THnSparse", 3, 0x8d07b0f4, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("THnBase", "Common base for THn and THnSparse"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1115065927, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fChunkSize", "Number of entries for each chunk"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFilledBins", "Number of filled bins"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinContent", "Array of THnSparseArrayChunk"),
+			Type:   rmeta.Object,
+			Size:   64,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TObjArray",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("THnSparseArrayChunk", 1, 0x2f6600b3, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSingleCoordinateSize", "Size of a single bin coordinate"),
+			Type:   rmeta.Int,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCoordinatesSize", "Size of the bin coordinate buffer"),
+			Type:   rmeta.Counter,
+			Size:   4,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "int",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fCoordinates", "[fCoordinatesSize] compact bin coordinate buffer"),
+			Type:   41,
+			Size:   1,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "char*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1, "fCoordinatesSize", "THnSparseArrayChunk"),
+		&StreamerObjectAnyPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fContent", "Bin content"),
+			Type:   rmeta.AnyP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArray*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectAnyPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSumw2", "Bin errors"),
+			Type:   rmeta.AnyP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TArrayD*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("THnSparseT<TArrayD>", 1, 0xb42b28e6, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("THnSparse", "Interfaces of sparse n-dimensional histogram"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1928875788, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("THnSparseT<TArrayF>", 1, 0xb4bd1fd8, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("THnSparse", "Interfaces of sparse n-dimensional histogram"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1928875788, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 3),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TMultiGraph", 2, 0x1423a32a, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fGraphs", "Pointer to list of TGraphs"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFunctions", "Pointer to list of functions (fits and user)"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TList*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fHistogram", "Pointer to histogram used for drawing axis"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TH1F*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting along y"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TPad", 13, 0x5c968665, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TVirtualPad", "Abstract base class for Pads and Canvases"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1786177753, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttBBox2D", "2D bounding box attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 2443772, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 0),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fX1", "X of lower X coordinate"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fY1", "Y of lower Y coordinate"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fX2", "X of upper X coordinate"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fY2", "Y of upper Y coordinate"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXtoAbsPixelk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXtoPixelk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXtoPixel", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYtoAbsPixelk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYtoPixelk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fYtoPixel", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUtoAbsPixelk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUtoPixelk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUtoPixel", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "double",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fVtoAbsPixelk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerSTL", 3, 0x89f908d6, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fVtoPixelk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSTLtype", "type of STL vector"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fVtoPixel", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCtype", "STL contained type"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fAbsPixeltoXk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerSTLstring", 2, 0x262eefa1, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerSTL", "Streamer element of type STL container"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPixeltoXk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1980167978, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TStreamerArtificial", 0, 0x5629bbc9, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TStreamerElement", "Base class for one element (data member) to be Streamed"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPixeltoX", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -586239405, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TAxis", 10, 0x5a496e70, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsPixeltoYk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttAxis", "Axis attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPixeltoYk", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1550843710, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbins", "Number of bins"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fPixeltoY", "Conversion coefficient"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXmin", "low edge of first bin"),
+			Name:   *rbase.NewNamed("fXlowNDC", "X bottom left corner of pad in NDC [0,1]"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -1516,7 +4986,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXmax", "upper edge of last bin"),
+			Name:   *rbase.NewNamed("fYlowNDC", "Y bottom left corner of pad in NDC [0,1]"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -1528,549 +4998,543 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXbins", "Bin edges array in X"),
-			Type:   rmeta.Any,
-			Size:   24,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fXUpNDC", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFirst", "first bin to display"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fYUpNDC", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLast", "last bin to display"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fWNDC", "Width of pad along X in Normalized Coordinates (NDC)"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBits2", "second bit status word"),
-			Type:   rmeta.UShort,
-			Size:   2,
+			Name:   *rbase.NewNamed("fHNDC", "Height of pad along Y in Normalized Coordinates (NDC)"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned short",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTimeDisplay", "on/off displaying time values instead of numerics"),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fAbsXlowNDC", "Absolute X top left corner of pad in NDC [0,1]"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTimeFormat", "Date&time format, ex: 09/12/99 12:34:00"),
-			Type:   rmeta.TString,
-			Size:   24,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsYlowNDC", "Absolute Y top left corner of pad in NDC [0,1]"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLabels", "List of labels"),
-			Type:   rmeta.ObjectP,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsWNDC", "Absolute Width of pad along X in NDC"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "THashList*",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fModLabs", "List of modified labels"),
-			Type:   rmeta.ObjectP,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsHNDC", "Absolute Height of pad along Y in NDC"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TGraph", 4, 0x5f7f465, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUxmin", "Minimum value on the X axis"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUymin", "Minimum value on the Y axis"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUxmax", "Maximum value on the X axis"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUymax", "Maximum value on the Y axis"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNpoints", "Number of points <= fMaxSize"),
-			Type:   rmeta.Counter,
-			Size:   4,
+			Name:   *rbase.NewNamed("fTheta", "theta angle to view as lego/surface"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fX", "[fNpoints] array of X points"),
-			Type:   48,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPhi", "phi angle   to view as lego/surface"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4, "fNpoints", "TGraph"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fY", "[fNpoints] array of Y points"),
-			Type:   48,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAspectRatio", "ratio of w/h in case of fixed ratio"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4, "fNpoints", "TGraph"),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFunctions", "Pointer to list of functions (fits and user)"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNumber", "pad number identifier"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fHistogram", "Pointer to histogram used for drawing axis"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTickx", "Set to 1 if tick marks along X"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TH1F*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting along y"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fTicky", "Set to 1 if tick marks along Y"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting along y"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fLogx", "(=0 if X linear scale, =1 if log scale)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TGraphErrors", 3, 0x2a7ce30f, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TGraph", "Graph graphics class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLogy", "(=0 if Y linear scale, =1 if log scale)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 100136037, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fEX", "[fNpoints] array of X errors"),
-			Type:   48,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLogz", "(=0 if Z linear scale, =1 if log scale)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4, "fNpoints", "TGraph"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fEY", "[fNpoints] array of Y errors"),
-			Type:   48,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPadPaint", "Set to 1 while painting the pad"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4, "fNpoints", "TGraph"),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TGraphAsymmErrors", 3, 0xcc46af3b, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TGraph", "Graph graphics class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCrosshair", "Crosshair type (0 if no crosshair requested)"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 100136037, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fEXlow", "[fNpoints] array of X low errors"),
-			Type:   48,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCrosshairPos", "Position of crosshair"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4, "fNpoints", "TGraph"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fEXhigh", "[fNpoints] array of X high errors"),
-			Type:   48,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBorderSize", "pad bordersize in pixels"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4, "fNpoints", "TGraph"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fEYlow", "[fNpoints] array of Y low errors"),
-			Type:   48,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBorderMode", "Bordermode (-1=down, 0 = no border, 1=up)"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4, "fNpoints", "TGraph"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fEYhigh", "[fNpoints] array of Y high errors"),
-			Type:   48,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fModified", "Set to true when pad is modified"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4, "fNpoints", "TGraph"),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1", 8, 0x1c3740c4, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fGridx", "Set to true if grid along X"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fGridy", "Set to true if grid along Y"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fAbsCoord", "Use absolute coordinates"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttMarker", "Marker attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEditable", "True if canvas is editable"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 689802220, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNcells", "number of bins(1D), cells (2D) +U/Overflows"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fFixedAspectRatio", "True if fixed aspect ratio"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXaxis", "X axis descriptor"),
-			Type:   rmeta.Object,
-			Size:   216,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPrimitives", "->List of primitives (subpads)"),
+			Type:   rmeta.Objectp,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TAxis",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fYaxis", "Y axis descriptor"),
-			Type:   rmeta.Object,
-			Size:   216,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fExecs", "List of commands to be executed when a pad event occurs"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TAxis",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZaxis", "Z axis descriptor"),
-			Type:   rmeta.Object,
-			Size:   216,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fName", "Pad name"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TAxis",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBarOffset", "(1000*offset) for bar charts or legos"),
-			Type:   rmeta.Short,
-			Size:   2,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fTitle", "Pad title"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBarWidth", "(1000*width) for bar charts or legos"),
-			Type:   rmeta.Short,
-			Size:   2,
+			Name:   *rbase.NewNamed("fNumPaletteColor", "Number of objects with an automatic color"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fNextPaletteColor", "Next automatic color"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumw", "Total Sum of weights"),
-			Type:   rmeta.Double,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TProfile", 7, 0xfefb28d1, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH1D", "1-Dim histograms (one double per channel)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -105818465, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumw2", "Total Sum of squares of weights"),
-			Type:   rmeta.Double,
-			Size:   8,
+		}.New(), 3),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinEntries", "number of entries per bin"),
+			Type:   rmeta.Any,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TArrayD",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwx", "Total Sum of weight*X"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fErrorMode", "Option to compute errors"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "EErrorType",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwx2", "Total Sum of weight*X*X"),
+			Name:   *rbase.NewNamed("fYmin", "Lower limit in Y (if set)"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -2083,7 +5547,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum value for plotting"),
+			Name:   *rbase.NewNamed("fYmax", "Upper limit in Y (if set)"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -2096,7 +5560,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMinimum", "Minimum value for plotting"),
+			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -2109,7 +5573,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNormFactor", "Normalization factor"),
+			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
 			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
@@ -2122,7 +5586,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fContour", "Array to display contour levels"),
+			Name:   *rbase.NewNamed("fBinSumw2", "Array of sum of squares of weights per bin"),
 			Type:   rmeta.Any,
 			Size:   24,
 			ArrLen: 0,
@@ -2134,175 +5598,173 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSumw2", "Array of sum of squares of weights"),
-			Type:   rmeta.Any,
-			Size:   24,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TProfile2D", 8, 0x16816230, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TH2D", "2-Dim histograms (one double per channel)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 2142929648, 0, 0, 0},
 			Offset: 0,
-			EName:  "TArrayD",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOption", "histogram options"),
-			Type:   rmeta.TString,
+		}.New(), 4),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinEntries", "Number of entries per bin"),
+			Type:   rmeta.Any,
 			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "TArrayD",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFunctions", "->Pointer to list of functions (fits and user)"),
-			Type:   rmeta.Objectp,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fErrorMode", "Option to compute errors"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "EErrorType",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBufferSize", "fBuffer size"),
-			Type:   rmeta.Counter,
-			Size:   4,
+			Name:   *rbase.NewNamed("fZmin", "Lower limit in Z (if set)"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fBuffer", "[fBufferSize] entry buffer"),
-			Type:   48,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fZmax", "Upper limit in Z (if set)"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double*",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8, "fBufferSize", "TH1"),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBinStatErrOpt", "option for bin statistical errors"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fTsumwz", "Total Sum of weight*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TH1::EBinErrorOpt",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStatOverflows", "per object flag to use under/overflows in statistics"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fTsumwz2", "Total Sum of weight*Z*Z"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TH1::EStatOverflows",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1C", 3, 0x36f6e4ad, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBinSumw2", "Array of sum of squares of weights per bin"),
+			Type:   rmeta.Any,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TArrayD",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
+		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TVirtualPad", 2, 0x6a76e8d9, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayC", "Array of chars"),
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1366845130, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1D", 3, 0xf9b1569f, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TAttLine", "Line attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1811462839, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
+		}.New(), 2),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1899622196, 0, 0, 0},
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1F", 3, 0xe2939644, []rbytes.StreamerElement{
+		}.New(), 2),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TAttPad", "Pad attributes"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1491734511, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
+		}.New(), 4),
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Name:   *rbase.NewNamed("TQObject", "Base class for object communication mechanism"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			MaxIdx: [5]int32{0, 274076, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
@@ -2310,290 +5772,267 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1I", 3, 0x627564f6, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+	StreamerInfos.Add(NewCxxStreamerInfo("pair<double,double>", 1, 0xd7bed2, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("first", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("second", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -640323129, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1K", 2, 0xfd37ced5, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 8),
+	StreamerInfos.Add(NewCxxStreamerInfo("TDirectory", 5, 0x1e9b6f70, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNIn", ""),
-			Type:   rmeta.Int,
-			Size:   4,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMother", "pointer to mother of the directory"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TObject*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH1S", 3, 0x8c4d9dcb, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fList", "List of objects in memory"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayS", "Array of shorts"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUUID", "Unique identifier"),
+			Type:   rmeta.Any,
+			Size:   32,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 56398612, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TUUID",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2", 5, 0x182347f, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TDirectoryFile", 5, 0xbf9f443e, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH1", "1-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TDirectory", ""),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 473383108, 0, 0, 0},
+			MaxIdx: [5]int32{0, 513503088, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 8),
+		}.New(), 5),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fScalefactor", "Scale factor"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fModified", "True if directory has been modified"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwy", "Total Sum of weight*Y"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fWritable", "True if directory is writable"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwy2", "Total Sum of weight*Y*Y"),
-			Type:   rmeta.Double,
-			Size:   8,
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDatimeC", "Date and time when directory is created"),
+			Type:   rmeta.Any,
+			Size:   16,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TDatime",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTsumwxy", "Total Sum of weight*X*Y"),
-			Type:   rmeta.Double,
-			Size:   8,
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDatimeM", "Date and time of last modification"),
+			Type:   rmeta.Any,
+			Size:   16,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TDatime",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2C", 4, 0xbd0010fe, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytesKeys", "Number of bytes for the keys"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayC", "Array of chars"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytesName", "Number of bytes in TNamed at creation time"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1366845130, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2D", 4, 0x7fba82f0, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBufferSize", "Default buffer size to create new TKeys"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayD", "Array of doubles"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekDir", "Location of directory on file"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1899622196, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2F", 4, 0x689cc295, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekParent", "Location of parent directory on file"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayF", "Array of floats"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekKeys", "Location of Keys record on file"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1510733553, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2I", 4, 0xe87e9147, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFile", "Pointer to current file in memory"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TFile*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayI", "Array of ints"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fKeys", "Pointer to keys list in memory"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -640323129, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2Poly", 3, 0xbc566968, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TFile", 8, 0x9468e382, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
+			Name:   *rbase.NewNamed("TDirectoryFile", ""),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1080081346, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
@@ -2601,178 +6040,150 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 5),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOverflow", "Overflow bins"),
-			Type:   rmeta.Enum(28),
-			Size:   72,
-			ArrLen: 9,
-			ArrDim: 1,
-			MaxIdx: [5]int32{9, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "double",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCellX", "Number of partition cells in the x-direction of the histogram"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fSumBuffer", "Sum of buffer sizes of objects written so far"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCellY", "Number of partition cells in the y-direction of the histogram"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fSum2Buffer", "Sum of squares of buffer sizes of objects written so far"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNCells", "Number of partition cells: fCellX*fCellY"),
-			Type:   rmeta.Counter,
-			Size:   4,
+			Name:   *rbase.NewNamed("fBytesWrite", "Number of bytes written to this file"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerLoop(Element{
-			Name:   *rbase.NewNamed("fCells", "[fNCells] The array of TLists that store the bins that intersect with each cell. List do not own the contained objects"),
-			Type:   rmeta.StreamLoop,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBytesRead", "Number of bytes read from this file"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3, "fNCells", "TH2Poly"),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStepX", "Dimensions of a partition cell"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fBytesReadExtra", "Number of extra bytes (overhead) read by the readahead buffer"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStepY", "Dimensions of a partition cell"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fBEGIN", "First used byte in file"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fIsEmpty", "[fNCells] The array that returns true if the cell at the given coordinate is empty"),
-			Type:   58,
-			Size:   1,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "bool*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 3, "fNCells", "TH2Poly"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fCompletelyInside", "[fNCells] The array that returns true if the cell at the given coordinate is completely inside a bin"),
-			Type:   58,
-			Size:   1,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEND", "Last used byte in file"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool*",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3, "fNCells", "TH2Poly"),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFloat", "When set to kTRUE, allows the histogram to expand if a bin outside the limits is added."),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fSeekFree", "Location on disk of free segments structure"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBins", "List of bins. The list owns the contained objects"),
-			Type:   rmeta.ObjectP,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekInfo", "Location on disk of StreamerInfo record"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2PolyBin", 1, 0x8fb3de7c, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fD", "File descriptor"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fChanged", "For the 3D Painter"),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fVersion", "File format version"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNumber", "Bin number of the bin in TH2Poly"),
+			Name:   *rbase.NewNamed("fCompress", "Compression level and algorithm"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -2784,127 +6195,125 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fPoly", "Object holding the polygon definition"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytesFree", "Number of bytes for free segments structure"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObject*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fArea", "Bin area"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fNbytesInfo", "Number of bytes for StreamerInfo record"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fContent", "Bin content"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fWritten", "Number of objects written so far"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXmin", "X minimum value"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fNProcessIDs", "Number of TProcessID written to this file"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fYmin", "Y minimum value"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fReadCalls", "Number of read calls ( not counting the cache calls )"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fXmax", "X maximum value"),
-			Type:   rmeta.Double,
-			Size:   8,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fRealName", "Effective real file name (not original url)"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fYmax", "Y maximum value"),
-			Type:   rmeta.Double,
-			Size:   8,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fOption", "File options"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TH2S", 4, 0x1256ca1c, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TH2", "2-Dim histogram base class"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fUnits", "Number of bytes for file pointers"),
+			Type:   rmeta.Char,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 25310335, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "char",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TArrayS", "Array of shorts"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFree", "Free segments linked list table"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 56398612, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TDirectory", 5, 0x1e9b6f70, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TKey", 4, 0x2d353cd7, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
 			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
@@ -2918,140 +6327,125 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New(), 1),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMother", "pointer to mother of the directory"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fVersion", "Key version identifier"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObject*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fList", "List of objects in memory"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNbytes", "Number of bytes for the object on file"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUUID", "Unique identifier"),
-			Type:   rmeta.Any,
-			Size:   32,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fObjlen", "Length of uncompressed object in bytes"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TUUID",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TDirectoryFile", 5, 0xbf9f443e, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TDirectory", ""),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 513503088, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 5),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fModified", "True if directory has been modified"),
-			Type:   rmeta.Bool,
-			Size:   1,
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fDatime", "Date/Time of insertion in file"),
+			Type:   rmeta.Any,
+			Size:   16,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "TDatime",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fWritable", "True if directory is writable"),
-			Type:   rmeta.Bool,
-			Size:   1,
+			Name:   *rbase.NewNamed("fKeylen", "Number of bytes for the key itself"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDatimeC", "Date and time when directory is created"),
-			Type:   rmeta.Any,
-			Size:   16,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCycle", "Cycle number"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TDatime",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDatimeM", "Date and time of last modification"),
-			Type:   rmeta.Any,
-			Size:   16,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekKey", "Location of object on file"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TDatime",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytesKeys", "Number of bytes for the keys"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fSeekPdir", "Location of parent directory on file"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytesName", "Number of bytes in TNamed at creation time"),
-			Type:   rmeta.Int,
-			Size:   4,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClassName", "Object Class name"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBufferSize", "Default buffer size to create new TKeys"),
+			Name:   *rbase.NewNamed("fLeft", "Number of bytes left in current segment"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3064,269 +6458,271 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekDir", "Location of directory on file"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fBuffer", "Object buffer"),
+			Type:   rmeta.CharStar,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "char*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekParent", "Location of parent directory on file"),
-			Type:   rmeta.Long64,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBufferRef", "Pointer to the TBuffer object"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TBuffer*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("ROOT::Experimental::RNTuple", 1, 0x655b8f56, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekKeys", "Location of Keys record on file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fVersion", ""),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFile", "Pointer to current file in memory"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSize", ""),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TFile*",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fKeys", "Pointer to keys list in memory"),
-			Type:   rmeta.ObjectP,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fSeekHeader", ""),
+			Type:   rmeta.ULong,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "unsigned long",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TFile", 8, 0x9468e382, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TDirectoryFile", ""),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNBytesHeader", ""),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1080081346, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 5),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSumBuffer", "Sum of buffer sizes of objects written so far"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fLenHeader", ""),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSum2Buffer", "Sum of squares of buffer sizes of objects written so far"),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fSeekFooter", ""),
+			Type:   rmeta.ULong,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "unsigned long",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBytesWrite", "Number of bytes written to this file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fNBytesFooter", ""),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBytesRead", "Number of bytes read from this file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fLenFooter", ""),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBytesReadExtra", "Number of extra bytes (overhead) read by the readahead buffer"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fReserved", ""),
+			Type:   rmeta.ULong,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "unsigned long",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBEGIN", "First used byte in file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TFeldmanCousins", 1, 0xebbf41df, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEND", "Last used byte in file"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fCL", "confidence level as a fraction [e.g. 90% = 0.9]"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekFree", "Location on disk of free segments structure"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fUpperLimit", "the calculated upper limit"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekInfo", "Location on disk of StreamerInfo record"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fLowerLimit", "the calculated lower limit"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fD", "File descriptor"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fNobserved", "input number of observed events"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fVersion", "File format version"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fNbackground", "input number of background events"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCompress", "Compression level and algorithm"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fMuMin", "minimum value of signal to use in calculating the tables"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytesFree", "Number of bytes for free segments structure"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fMuMax", "maximum value of signal to use in calculating the tables"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytesInfo", "Number of bytes for StreamerInfo record"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fMuStep", "the step in signal to use when generating tables"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fWritten", "Number of objects written so far"),
+			Name:   *rbase.NewNamed("fNMuStep", "= (int)(fMuStep)"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3339,7 +6735,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNProcessIDs", "Number of TProcessID written to this file"),
+			Name:   *rbase.NewNamed("fNMax", "= (int)(fMuMax)"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3352,7 +6748,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fReadCalls", "Number of read calls ( not counting the cache calls )"),
+			Name:   *rbase.NewNamed("fQUICK", "take a short cut to speed up the process of generating a"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3364,67 +6760,56 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fRealName", "Effective real file name (not original url)"),
-			Type:   rmeta.TString,
-			Size:   24,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TLorentzVector", 4, 0xe3dec1a1, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOption", "File options"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New(), 1),
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fP", "3 vector component"),
+			Type:   rmeta.Object,
+			Size:   40,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "TVector3",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUnits", "Number of bytes for file pointers"),
-			Type:   rmeta.Char,
-			Size:   1,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "char",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFree", "Free segments linked list table"),
-			Type:   rmeta.ObjectP,
+			Name:   *rbase.NewNamed("fE", "time or energy of (x,y,z,t) or (px,py,pz,e)"),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TKey", 4, 0x2d353cd7, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TVector2", 3, 0x89b7f4, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
@@ -3432,124 +6817,117 @@ func init() {
 			Factor: 0.000000,
 		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fVersion", "Key version identifier"),
-			Type:   rmeta.Int,
-			Size:   4,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "int",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbytes", "Number of bytes for the object on file"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fX", "components of the vector"),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fObjlen", "Length of uncompressed object in bytes"),
-			Type:   rmeta.Int,
-			Size:   4,
+			Name:   *rbase.NewNamed("fY", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fDatime", "Date/Time of insertion in file"),
-			Type:   rmeta.Any,
-			Size:   16,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TVector3", 3, 0xabb6be1e, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
-			EName:  "TDatime",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fKeylen", "Number of bytes for the key itself"),
-			Type:   rmeta.Short,
-			Size:   2,
+			Name:   *rbase.NewNamed("fX", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCycle", "Cycle number"),
-			Type:   rmeta.Short,
-			Size:   2,
+			Name:   *rbase.NewNamed("fY", ""),
+			Type:   rmeta.Double,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekKey", "Location of object on file"),
-			Type:   rmeta.Long64,
+			Name:   *rbase.NewNamed("fZ", ""),
+			Type:   rmeta.Double,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "double",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("ROOT::TIOFeatures", 1, 0x1aa12f10, []rbytes.StreamerElement{
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSeekPdir", "Location of parent directory on file"),
-			Type:   rmeta.Long64,
-			Size:   8,
+			Name:   *rbase.NewNamed("fIOBits", ""),
+			Type:   rmeta.UChar,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "unsigned char",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClassName", "Object Class name"),
-			Type:   rmeta.TString,
-			Size:   24,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TBasket", 3, 0xbbf717e7, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TKey", "Header description of a logical record on file."),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 758463703, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 4),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLeft", "Number of bytes left in current segment"),
+			Name:   *rbase.NewNamed("fBufferSize", "fBuffer length in bytes"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3562,152 +6940,126 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBuffer", "Object buffer"),
-			Type:   rmeta.CharStar,
-			Size:   8,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "char*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBufferRef", "Pointer to the TBuffer object"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "TBuffer*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TFeldmanCousins", 1, 0xebbf41df, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
+			Name:   *rbase.NewNamed("fNevBufSize", "Length in Int_t of fEntryOffset OR fixed length of each entry if fEntryOffset is null!"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCL", "confidence level as a fraction [e.g. 90% = 0.9]"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fNevBuf", "Number of entries in basket"),
+			Type:   rmeta.Counter,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fUpperLimit", "the calculated upper limit"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fLast", "Pointer to last used byte in basket"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLowerLimit", "the calculated lower limit"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fHeaderOnly", "True when only the basket header must be read/written"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNobserved", "input number of observed events"),
-			Type:   rmeta.Double,
-			Size:   8,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fEntryOffset", "[fNevBuf] Offset of entries in fBuffer(TKey); generated at runtime.  Special value"),
+			Type:   43,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNbackground", "input number of background events"),
-			Type:   rmeta.Double,
+		}.New(), 3, "fNevBuf", "TBasket"),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranch", "Pointer to the basket support branch"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TBranch*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMuMin", "minimum value of signal to use in calculating the tables"),
-			Type:   rmeta.Double,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TBranch", 13, 0x10978aac, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMuMax", "maximum value of signal to use in calculating the tables"),
-			Type:   rmeta.Double,
-			Size:   8,
+		}.New(), 1),
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 2),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMuStep", "the step in signal to use when generating tables"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fCompress", "Compression level and algorithm"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNMuStep", "= (int)(fMuStep)"),
+			Name:   *rbase.NewNamed("fBasketSize", "Initial Size of  Basket Buffer"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3720,7 +7072,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNMax", "= (int)(fMuMax)"),
+			Name:   *rbase.NewNamed("fEntryOffsetLen", "Initial Length of fEntryOffset table in the basket buffers"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3733,7 +7085,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fQUICK", "take a short cut to speed up the process of generating a"),
+			Name:   *rbase.NewNamed("fWriteBasket", "Last basket number written"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -3745,293 +7097,296 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TLorentzVector", 4, 0xe3dec1a1, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEntryNumber", "Current entry number (last one filled in this branch)"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fP", "3 vector component"),
-			Type:   rmeta.Object,
-			Size:   40,
+		}.New()},
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fIOFeatures", "IO features for newly-created baskets."),
+			Type:   rmeta.Any,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TVector3",
+			EName:  "ROOT::TIOFeatures",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fE", "time or energy of (x,y,z,t) or (px,py,pz,e)"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fOffset", "Offset of this branch"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TVector2", 3, 0x89b7f4, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMaxBaskets", "Maximum number of Baskets so far"),
+			Type:   rmeta.Counter,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fX", "components of the vector"),
-			Type:   rmeta.Double,
-			Size:   8,
+			Name:   *rbase.NewNamed("fSplitLevel", "Branch split level"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fY", ""),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TVector3", 3, 0xabb6be1e, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFirstEntry", "Number of the first entry in this branch"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fX", ""),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fTotBytes", "Total number of bytes in all leaves before compression"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fY", ""),
-			Type:   rmeta.Double,
+			Name:   *rbase.NewNamed("fZipBytes", "Total number of bytes in all leaves after compression"),
+			Type:   rmeta.Long64,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZ", ""),
-			Type:   rmeta.Double,
-			Size:   8,
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranches", "-> List of Branches of this branch"),
+			Type:   rmeta.Object,
+			Size:   64,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "double",
+			EName:  "TObjArray",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("ROOT::TIOFeatures", 1, 0x1aa12f10, []rbytes.StreamerElement{
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIOBits", ""),
-			Type:   rmeta.UChar,
-			Size:   1,
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLeaves", "-> List of leaves of this branch"),
+			Type:   rmeta.Object,
+			Size:   64,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned char",
+			EName:  "TObjArray",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBasket", 3, 0xbbf717e7, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TKey", "Header description of a logical record on file."),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerObject{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBaskets", "-> List of baskets of this branch"),
+			Type:   rmeta.Object,
+			Size:   64,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 758463703, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "TObjArray",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 4),
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBufferSize", "fBuffer length in bytes"),
-			Type:   rmeta.Int,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fBasketBytes", "[fMaxBaskets] Length of baskets on file"),
+			Type:   43,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "int*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNevBufSize", "Length in Int_t of fEntryOffset OR fixed length of each entry if fEntryOffset is null!"),
-			Type:   rmeta.Int,
-			Size:   4,
+		}.New(), 13, "fMaxBaskets", "TBranch"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fBasketEntry", "[fMaxBaskets] Table of first entry in each basket"),
+			Type:   56,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "Long64_t*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 13, "fMaxBaskets", "TBranch"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fBasketSeek", "[fMaxBaskets] Addresses of baskets on file"),
+			Type:   56,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNevBuf", "Number of entries in basket"),
-			Type:   rmeta.Counter,
-			Size:   4,
+		}.New(), 13, "fMaxBaskets", "TBranch"),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFileName", "Name of file where buffers are stored (\"\" if in same file as Tree header)"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLast", "Pointer to last used byte in basket"),
-			Type:   rmeta.Int,
-			Size:   4,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TBranchElement", 10, 0xe74f5e63, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fHeaderOnly", "True when only the basket header must be read/written"),
-			Type:   rmeta.Bool,
-			Size:   1,
+		}.New(), 13),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClassName", "Class name of referenced object"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "bool",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fEntryOffset", "[fNevBuf] Offset of entries in fBuffer(TKey); generated at runtime.  Special value"),
-			Type:   43,
-			Size:   4,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fParentName", "Name of parent class"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int*",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 3, "fNevBuf", "TBasket"),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranch", "Pointer to the basket support branch"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClonesName", "Name of class in TClonesArray (if any)"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TBranch*",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBranch", 13, 0x10978aac, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fCheckSum", "CheckSum of class"),
+			Type:   rmeta.UInt,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "unsigned int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 1),
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TAttFill", "Fill area attributes"),
-			Type:   rmeta.Base,
-			Size:   0,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fClassVersion", "Version number of class"),
+			Type:   rmeta.Short,
+			Size:   2,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, -2545006, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "short",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 2),
+		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCompress", "Compression level and algorithm"),
+			Name:   *rbase.NewNamed("fID", "element serial number in fInfo"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4044,7 +7399,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBasketSize", "Initial Size of  Basket Buffer"),
+			Name:   *rbase.NewNamed("fType", "branch type"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4057,7 +7412,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntryOffsetLen", "Initial Length of fEntryOffset table in the basket buffers"),
+			Name:   *rbase.NewNamed("fStreamerType", "branch streamer type"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4070,7 +7425,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fWriteBasket", "Last basket number written"),
+			Name:   *rbase.NewNamed("fMaximum", "Maximum entries for a TClonesArray or variable array"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4082,47 +7437,77 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntryNumber", "Current entry number (last one filled in this branch)"),
-			Type:   rmeta.Long64,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranchCount", "pointer to primary branchcount branch"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TBranchElement*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectAny{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fIOFeatures", "IO features for newly-created baskets."),
-			Type:   rmeta.Any,
-			Size:   1,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBranchCount2", "pointer to secondary branchcount branch"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "ROOT::TIOFeatures",
+			EName:  "TBranchElement*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fOffset", "Offset of this branch"),
-			Type:   rmeta.Int,
-			Size:   4,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TBranchRef", 1, 0x2360b3fd, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 13),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fRefTable", "pointer to the TRefTable"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "TRefTable*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TChain", 5, 0x3a9ec568, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TTree", "Tree descriptor (the main ROOT I/O class)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 1919213695, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 20),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaxBaskets", "Maximum number of Baskets so far"),
+			Name:   *rbase.NewNamed("fTreeOffsetLen", "Current size of fTreeOffset array"),
 			Type:   rmeta.Counter,
 			Size:   4,
 			ArrLen: 0,
@@ -4135,7 +7520,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fSplitLevel", "Branch split level"),
+			Name:   *rbase.NewNamed("fNtrees", "Number of trees"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4147,179 +7532,181 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fEntries", "Number of entries"),
-			Type:   rmeta.Long64,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fTreeOffset", "[fTreeOffsetLen] Array of variables"),
+			Type:   56,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFirstEntry", "Number of the first entry in this branch"),
-			Type:   rmeta.Long64,
+		}.New(), 5, "fTreeOffsetLen", "TChain"),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fFiles", "-> List of file names containing the trees (TChainElement, owned)"),
+			Type:   rmeta.Objectp,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TObjArray*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTotBytes", "Total number of bytes in all leaves before compression"),
-			Type:   rmeta.Long64,
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fStatus", "-> List of active/inactive branches (TChainElement, owned)"),
+			Type:   rmeta.Objectp,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fZipBytes", "Total number of bytes in all leaves after compression"),
-			Type:   rmeta.Long64,
-			Size:   8,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TChainIndex", 1, 0x72c957c0, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TVirtualIndex", "Abstract interface for Tree Index"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1103679883, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranches", "-> List of Branches of this branch"),
-			Type:   rmeta.Object,
-			Size:   64,
+		}.New(), 1),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMajorName", "Index major name"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fLeaves", "-> List of leaves of this branch"),
-			Type:   rmeta.Object,
-			Size:   64,
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinorName", "Index minor name"),
+			Type:   rmeta.TString,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "TString",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObject{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBaskets", "-> List of baskets of this branch"),
-			Type:   rmeta.Object,
-			Size:   64,
+		NewCxxStreamerSTL(Element{
+			Name:   *rbase.NewNamed("fEntries", "descriptions of indices of trees in the chain."),
+			Type:   rmeta.Streamer,
+			Size:   24,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray",
+			EName:  "vector<TChainIndex::TChainIndexEntry>",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fBasketBytes", "[fMaxBaskets] Length of baskets on file"),
-			Type:   43,
-			Size:   4,
+		}.New(), 1, 61),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TEntryList", 2, 0x60b0310b, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
-			EName:  "int*",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13, "fMaxBaskets", "TBranch"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fBasketEntry", "[fMaxBaskets] Table of first entry in each basket"),
-			Type:   56,
+		}.New(), 1),
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fLists", "a list of underlying entry lists for each tree of a chain"),
+			Type:   rmeta.ObjectP,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t*",
+			EName:  "TList*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13, "fMaxBaskets", "TBranch"),
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fBasketSeek", "[fMaxBaskets] Addresses of baskets on file"),
-			Type:   56,
-			Size:   8,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fNBlocks", "number of TEntryListBlocks"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "Long64_t*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13, "fMaxBaskets", "TBranch"),
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFileName", "Name of file where buffers are stored (\"\" if in same file as Tree header)"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New()},
+		&StreamerObjectPointer{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fBlocks", "blocks with indices of passing events (TEntryListBlocks)"),
+			Type:   rmeta.ObjectP,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "TObjArray*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBranchElement", 10, 0xe74f5e63, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
-			Type:   rmeta.Base,
-			Size:   0,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fN", "number of entries in the list"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "BASE",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13),
-		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClassName", "Class name of referenced object"),
-			Type:   rmeta.TString,
-			Size:   24,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fEntriesToProcess", "used on proof to set the number of entries to process in a packet"),
+			Type:   rmeta.Long64,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TString",
+			EName:  "Long64_t",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fParentName", "Name of parent class"),
+			Name:   *rbase.NewNamed("fTreeName", "name of the tree"),
 			Type:   rmeta.TString,
 			Size:   24,
 			ArrLen: 0,
@@ -4332,7 +7719,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerString{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClonesName", "Name of class in TClonesArray (if any)"),
+			Name:   *rbase.NewNamed("fFileName", "name of the file, where the tree is"),
 			Type:   rmeta.TString,
 			Size:   24,
 			ArrLen: 0,
@@ -4345,33 +7732,35 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fCheckSum", "CheckSum of class"),
-			Type:   rmeta.UInt,
-			Size:   4,
+			Name:   *rbase.NewNamed("fReapply", "If true, TTree::Draw will 'reapply' the original cut"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "unsigned int",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fClassVersion", "Version number of class"),
-			Type:   rmeta.Short,
-			Size:   2,
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TEntryListBlock", 1, 0x8632a4d5, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TObject", "Basic ROOT object"),
+			Type:   rmeta.Base,
+			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			MaxIdx: [5]int32{0, -1877229523, 0, 0, 0},
 			Offset: 0,
-			EName:  "short",
+			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fID", "element serial number in fInfo"),
+			Name:   *rbase.NewNamed("fNPassed", "number of entries in the entry list (if fPassing=0 - number of entries not in the entry list"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4384,7 +7773,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fType", "branch type"),
+			Name:   *rbase.NewNamed("fN", "size of fIndices for I/O  =fNPassed for list, fBlockSize for bits"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4396,21 +7785,21 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStreamerType", "branch streamer type"),
-			Type:   rmeta.Int,
-			Size:   4,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIndices", "[fN]"),
+			Type:   52,
+			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "int",
+			EName:  "unsigned short*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 1, "fN", "TEntryListBlock"),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fMaximum", "Maximum entries for a TClonesArray or variable array"),
+			Name:   *rbase.NewNamed("fType", "0 - bits, 1 - list"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4422,78 +7811,50 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranchCount", "pointer to primary branchcount branch"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "TBranchElement*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fBranchCount2", "pointer to secondary branchcount branch"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fPassing", "1 - stores entries that belong to the list"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TBranchElement*",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
 	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TBranchRef", 1, 0x2360b3fd, []rbytes.StreamerElement{
+	StreamerInfos.Add(NewCxxStreamerInfo("TEventList", 4, 0x19cd1f4a, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TBranch", "Branch descriptor"),
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
 			Type:   rmeta.Base,
 			Size:   0,
 			ArrLen: 0,
 			ArrDim: 0,
-			MaxIdx: [5]int32{0, 278366892, 0, 0, 0},
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
 			Offset: 0,
 			EName:  "BASE",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New(), 13),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fRefTable", "pointer to the TRefTable"),
-			Type:   rmeta.ObjectP,
-			Size:   8,
+		}.New(), 1),
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fN", "Number of elements in the list"),
+			Type:   rmeta.Int,
+			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TRefTable*",
+			EName:  "int",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-	}))
-	StreamerInfos.Add(NewCxxStreamerInfo("TChain", 5, 0x3a9ec568, []rbytes.StreamerElement{
-		NewStreamerBase(Element{
-			Name:   *rbase.NewNamed("TTree", "Tree descriptor (the main ROOT I/O class)"),
-			Type:   rmeta.Base,
-			Size:   0,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 1919213695, 0, 0, 0},
-			Offset: 0,
-			EName:  "BASE",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 20),
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fTreeOffsetLen", "Current size of fTreeOffset array"),
-			Type:   rmeta.Counter,
+			Name:   *rbase.NewNamed("fSize", "Size of array"),
+			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
 			ArrDim: 0,
@@ -4505,7 +7866,7 @@ func init() {
 			Factor: 0.000000,
 		}.New()},
 		&StreamerBasicType{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fNtrees", "Number of trees"),
+			Name:   *rbase.NewNamed("fDelta", "Increment size"),
 			Type:   rmeta.Int,
 			Size:   4,
 			ArrLen: 0,
@@ -4517,45 +7878,32 @@ func init() {
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		NewStreamerBasicPointer(Element{
-			Name:   *rbase.NewNamed("fTreeOffset", "[fTreeOffsetLen] Array of variables"),
-			Type:   56,
-			Size:   8,
-			ArrLen: 0,
-			ArrDim: 0,
-			MaxIdx: [5]int32{0, 0, 0, 0, 0},
-			Offset: 0,
-			EName:  "Long64_t*",
-			XMin:   0.000000,
-			XMax:   0.000000,
-			Factor: 0.000000,
-		}.New(), 5, "fTreeOffsetLen", "TChain"),
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fFiles", "-> List of file names containing the trees (TChainElement, owned)"),
-			Type:   rmeta.Objectp,
-			Size:   8,
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fReapply", "If true, TTree::Draw will 'reapply' the original cut"),
+			Type:   rmeta.Bool,
+			Size:   1,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TObjArray*",
+			EName:  "bool",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
 		}.New()},
-		&StreamerObjectPointer{StreamerElement: Element{
-			Name:   *rbase.NewNamed("fStatus", "-> List of active/inactive branches (TChainElement, owned)"),
-			Type:   rmeta.Objectp,
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fList", "[fN]Array of elements"),
+			Type:   56,
 			Size:   8,
 			ArrLen: 0,
 			ArrDim: 0,
 			MaxIdx: [5]int32{0, 0, 0, 0, 0},
 			Offset: 0,
-			EName:  "TList*",
+			EName:  "Long64_t*",
 			XMin:   0.000000,
 			XMax:   0.000000,
 			Factor: 0.000000,
-		}.New()},
+		}.New(), 4, "fN", "TEventList"),
 	}))
 	StreamerInfos.Add(NewCxxStreamerInfo("TLeaf", 2, 0x6d1e8152, []rbytes.StreamerElement{
 		NewStreamerBase(Element{
@@ -5628,4 +8976,112 @@ func init() {
 		}.New()},
 	}))
 
+	StreamerInfos.Add(NewCxxStreamerInfo("TTreeIndex", 2, 0xad181745, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TVirtualIndex", "Abstract interface for Tree Index"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -1103679883, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMajorName", "Index major name"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerString{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fMinorName", "Index minor name"),
+			Type:   rmeta.TString,
+			Size:   24,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "TString",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:   *rbase.NewNamed("fN", "Number of entries"),
+			Type:   rmeta.Long64,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIndexValues", "[fN] Sorted index values, higher 64bits store major, lower store minor"),
+			Type:   56,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2, "fN", "TTreeIndex"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIndexValuesMinor", "[fN] Sorted index values"),
+			Type:   56,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2, "fN", "TTreeIndex"),
+		NewStreamerBasicPointer(Element{
+			Name:   *rbase.NewNamed("fIndex", "[fN] Index of sorted values"),
+			Type:   56,
+			Size:   8,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, 0, 0, 0, 0},
+			Offset: 0,
+			EName:  "Long64_t*",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 2, "fN", "TTreeIndex"),
+	}))
+	StreamerInfos.Add(NewCxxStreamerInfo("TVirtualIndex", 1, 0xbe372e75, []rbytes.StreamerElement{
+		NewStreamerBase(Element{
+			Name:   *rbase.NewNamed("TNamed", "The basis for a named object (name, title)"),
+			Type:   rmeta.Base,
+			Size:   0,
+			ArrLen: 0,
+			ArrDim: 0,
+			MaxIdx: [5]int32{0, -541636036, 0, 0, 0},
+			Offset: 0,
+			EName:  "BASE",
+			XMin:   0.000000,
+			XMax:   0.000000,
+			Factor: 0.000000,
+		}.New(), 1),
+	}))
 }
@@ -11,6 +11,7 @@ import (
 	"go/types"
 	"log"
 	"reflect"
+	"strings"
 
 	"go-hep.org/x/hep/groot/rmeta"
 	"golang.org/x/tools/go/packages"
@@ -265,8 +266,21 @@ func (g *genStreamer) genStreamerType(t types.Type, n string) {
 			)
 		}
 	case *types.Slice:
-		// FIXME(sbinet): collect+visit element type.
-		g.printf("rdict.NewStreamerSTL(%q, rmeta.STLvector, rmeta.%v),\n", n, gotype2RMeta(ut.Elem()))
+		if _, ok := ut.Elem().Underlying().(*types.Basic); ok {
+			g.printf("rdict.NewStreamerSTL(%q, rmeta.STLvector, rmeta.%v),\n", n, gotype2RMeta(ut.Elem()))
+			break
+		}
+		g.genStreamerSTL(t, n, "rmeta.STLvector")
+
+	case *types.Map:
+		g.genStreamerSTL(t, n, "rmeta.STLmap")
+
+	case *types.Pointer:
+		g.imps["go-hep.org/x/hep/groot/rbase"]++
+		g.printf(
+			"&rdict.StreamerObjectAnyPointer{StreamerElement:rdict.Element{\nName: *rbase.NewNamed(%[1]q, %[2]q),\nType: rmeta.AnyP,\nSize: %[4]d,\nEName:%[3]q,\n}.New()},\n",
+			n, "", g.cxxTypeName(t), g.gosizes.Sizeof(ut),
+		)
 
 	case *types.Struct:
 		g.imps["go-hep.org/x/hep/groot/rbase"]++
@@ -281,38 +295,48 @@ func (g *genStreamer) genStreamerType(t types.Type, n string) {
 	}
 }
 
+// genStreamerSTL generates the streamer element for the Go slice or map
+// named n, as a STL container of kind stl.
+func (g *genStreamer) genStreamerSTL(t types.Type, n, stl string) {
+	g.imps["go-hep.org/x/hep/groot/rbase"]++
+	g.printf(
+		"rdict.NewCxxStreamerSTL(rdict.Element{\nName: *rbase.NewNamed(%[1]q, %[2]q),\nType: rmeta.Streamer,\nSize: %[4]d,\nEName:%[3]q,\n}.New(), %[5]s, rmeta.Object),\n",
+		n, "", g.cxxTypeName(t), g.gosizes.Sizeof(t.Underlying()), stl,
+	)
+}
+
 func (g *genStreamer) wt(t types.Type, n, meth, arr string) {
 	ut := t.Underlying()
 	switch ut := ut.(type) {
 	case *types.Basic:
 		switch kind := ut.Kind(); kind {
 		case types.Bool:
-			g.printf("w.Write%sBool(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sBool(%s%s)\n", meth, n, arr)
 		case types.Uint8:
-			g.printf("w.Write%sU8(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sU8(%s%s)\n", meth, n, arr)
 		case types.Uint16:
-			g.printf("w.Write%sU16(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sU16(%s%s)\n", meth, n, arr)
 		case types.Uint32:
-			g.printf("w.Write%sU32(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sU32(%s%s)\n", meth, n, arr)
 		case types.Uint64:
-			g.printf("w.Write%sU64(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sU64(%s%s)\n", meth, n, arr)
 		case types.Int8:
-			g.printf("w.Write%sI8(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sI8(%s%s)\n", meth, n, arr)
 		case types.Int16:
-			g.printf("w.Write%sI16(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sI16(%s%s)\n", meth, n, arr)
 		case types.Int32:
-			g.printf("w.Write%sI32(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sI32(%s%s)\n", meth, n, arr)
 		case types.Int64:
-			g.printf("w.Write%sI64(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sI64(%s%s)\n", meth, n, arr)
 		case types.Float32:
-			g.printf("w.Write%sF32(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sF32(%s%s)\n", meth, n, arr)
 		case types.Float64:
-			g.printf("w.Write%sF64(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sF64(%s%s)\n", meth, n, arr)
 
 		case types.Uint:
-			g.printf("w.Write%sU64(uint64(o.%s%s))\n", meth, n, arr)
+			g.printf("w.Write%sU64(uint64(%s%s))\n", meth, n, arr)
 		case types.Int:
-			g.printf("w.Write%sI64(int64(o.%s%s))\n", meth, n, arr)
+			g.printf("w.Write%sI64(int64(%s%s))\n", meth, n, arr)
 
 		case types.Complex64:
 			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
@@ -320,14 +344,14 @@ func (g *genStreamer) wt(t types.Type, n, meth, arr string) {
 			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
 
 		case types.String:
-			g.printf("w.Write%sString(o.%s%s)\n", meth, n, arr)
+			g.printf("w.Write%sString(%s%s)\n", meth, n, arr)
 
 		default:
 			log.Fatalf("unhandled type: %v (underlying: %v)\n", t, ut)
 		}
 
 	case *types.Struct:
-		g.printf("o.%s.MarshalROOT(w)\n", n)
+		g.printf("%s.MarshalROOT(w)\n", n)
 
 	default:
 		log.Fatalf("unhandled marshal type: %v (underlying %v)", t, ut)
@@ -485,32 +509,32 @@ func (g *genStreamer) genMarshalType(t types.Type, n string) {
 	case *types.Basic:
 		switch kind := ut.Kind(); kind {
 		case types.Bool:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Uint8:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Uint16:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Uint32:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Uint64:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Int8:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Int16:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Int32:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Int64:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Float32:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Float64:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 
 		case types.Uint:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 		case types.Int:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 
 		case types.Complex64:
 			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
@@ -518,7 +542,7 @@ func (g *genStreamer) genMarshalType(t types.Type, n string) {
 			log.Fatalf("unhandled type: %v (underlying %v)\n", t, ut) // FIXME(sbinet)
 
 		case types.String:
-			g.wt(ut, n, "", "")
+			g.wt(ut, "o."+n, "", "")
 
 		default:
 			log.Fatalf("unhandled type: %v (underlying: %v)\n", t, ut)
@@ -527,24 +551,137 @@ func (g *genStreamer) genMarshalType(t types.Type, n string) {
 	case *types.Array:
 		switch ut.Elem().Underlying().(type) {
 		case *types.Basic:
-			g.wt(ut.Elem(), n, "FastArray", "[:]")
+			g.wt(ut.Elem(), "o."+n, "FastArray", "[:]")
 		default:
 			g.printf("for i := range o.%s {\n", n)
-			g.wt(ut.Elem(), n+"[i]", "", "")
+			g.wt(ut.Elem(), "o."+n+"[i]", "", "")
 			g.printf("}\n")
 		}
 
-	case *types.Slice:
-		g.wt(ut.Elem(), n, "FastArray", "")
+	case *types.Slice, *types.Map:
+		g.genMarshalSTL(t, "o."+n, true, 0)
 
 	case *types.Struct:
 		g.printf("o.%s.MarshalROOT(w)\n", n)
 
+	case *types.Pointer:
+		g.printf("w.WriteObjectAny(o.%s)\n", n)
+
 	default:
 		log.Fatalf("gen-marshal-type: unhandled type: %v (underlying: %v)\n", t, ut)
 	}
 }
 
+// genMarshalSTL generates code writing the Go slice or map expr as a
+// std::vector or a std::map.
+// Containers nested inside other containers are written without a header.
+func (g *genStreamer) genMarshalSTL(t types.Type, expr string, header bool, depth int) {
+	if header {
+		g.imps["go-hep.org/x/hep/groot/rvers"]++
+		g.printf("{\n")
+		g.printf("pos := w.WriteVersion(rvers.StreamerInfo)\n")
+	}
+
+	switch ut := t.Underlying().(type) {
+	case *types.Slice:
+		g.printf("w.WriteI32(int32(len(%s)))\n", expr)
+		if _, ok := ut.Elem().Underlying().(*types.Basic); ok {
+			g.wt(ut.Elem(), expr, "FastArray", "")
+			break
+		}
+		i := fmt.Sprintf("i%d", depth)
+		g.printf("for %s := range %s {\n", i, expr)
+		g.genMarshalElem(ut.Elem(), expr+"["+i+"]", depth+1)
+		g.printf("}\n")
+
+	case *types.Map:
+		var (
+			keys = fmt.Sprintf("keys%d", depth)
+			k    = fmt.Sprintf("k%d", depth)
+			v    = fmt.Sprintf("v%d", depth)
+		)
+		g.printf("w.WriteI32(int32(len(%s)))\n", expr)
+		g.printf("%s := make([]%s, 0, len(%s))\n", keys, g.typeName(ut.Key()), expr)
+		g.printf("for %s := range %s {\n", k, expr)
+		g.printf("%s = append(%s, %s)\n", keys, keys, k)
+		g.printf("}\n")
+		if kt, ok := ut.Key().Underlying().(*types.Basic); ok && kt.Info()&types.IsOrdered != 0 {
+			g.imps["sort"]++
+			g.printf("sort.Slice(%[1]s, func(i, j int) bool { return %[1]s[i] < %[1]s[j] })\n", keys)
+		}
+		g.printf("for _, %s := range %s {\n", k, keys)
+		g.printf("%s := %s[%s]\n", v, expr, k)
+		g.genMarshalElem(ut.Key(), k, depth+1)
+		g.genMarshalElem(ut.Elem(), v, depth+1)
+		g.printf("}\n")
+
+	default:
+		log.Fatalf("gen-marshal-stl: unhandled type: %v (underlying: %v)\n", t, ut)
+	}
+
+	if header {
+		g.printf("if _, err := w.SetByteCount(pos, %q); err != nil {\n", g.cxxTypeName(t))
+		g.printf("return 0, err\n")
+		g.printf("}\n")
+		g.printf("}\n")
+	}
+}
+
+// genMarshalElem generates code writing the element expr of a slice or map.
+func (g *genStreamer) genMarshalElem(t types.Type, expr string, depth int) {
+	switch ut := t.Underlying().(type) {
+	case *types.Basic:
+		g.wt(t, expr, "", "")
+	case *types.Struct:
+		g.printf("%s.MarshalROOT(w)\n", expr)
+	case *types.Slice, *types.Map:
+		g.genMarshalSTL(t, expr, false, depth)
+	case *types.Pointer:
+		g.printf("w.WriteObjectAny(%s)\n", expr)
+	default:
+		log.Fatalf("gen-marshal-elem: unhandled type: %v (underlying: %v)\n", t, ut)
+	}
+}
+
+// typeName returns the name of the provided type, as seen from the package
+// being generated.
+func (g *genStreamer) typeName(t types.Type) string {
+	return types.TypeString(t, types.RelativeTo(g.pkg))
+}
+
+// cxxTypeName returns the C++ type name corresponding to the provided Go type.
+func (g *genStreamer) cxxTypeName(t types.Type) string {
+	tmpl := func(name string, args ...types.Type) string {
+		names := make([]string, len(args))
+		for i, arg := range args {
+			names[i] = g.cxxTypeName(arg)
+		}
+		str := name + "<" + strings.Join(names, ",")
+		if strings.HasSuffix(str, ">") {
+			str += " "
+		}
+		return str + ">"
+	}
+
+	switch ut := t.Underlying().(type) {
+	case *types.Basic:
+		if ut.Kind() == types.String {
+			return "string"
+		}
+		return rmeta.GoType2Cxx[ut.Name()]
+	case *types.Slice:
+		return tmpl("vector", ut.Elem())
+	case *types.Map:
+		return tmpl("map", ut.Key(), ut.Elem())
+	case *types.Pointer:
+		return g.cxxTypeName(ut.Elem()) + "*"
+	case *types.Struct:
+		return GoName2Cxx(t.String())
+	}
+	log.Fatalf("gen-streamer: unhandled type: %v (underlying %v)", t, t.Underlying())
+	panic("unreachable")
+}
+
 // Generate implements rdict.Generator
 func (g *genStreamer) Format() ([]byte, error) {
 	buf := new(bytes.Buffer)
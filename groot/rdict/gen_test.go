@@ -5,6 +5,11 @@
 package rdict
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -789,3 +794,132 @@ func TestGenCxxStreamerInfo(t *testing.T) {
 	}
 
 }
+
+func TestGenGoTypeSTL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "groot-rdict-gen-type-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	g, err := NewGenGoType("main", StreamerInfos, false)
+	if err != nil {
+		t.Fatalf("could not create generator: %+v", err)
+	}
+	for _, name := range []string{testP3SI.Name(), testPairSI.Name(), testEventSI.Name(), testMbrWiseSI.Name()} {
+		err = g.Generate(name)
+		if err != nil {
+			t.Fatalf("could not generate type %q: %+v", name, err)
+		}
+	}
+	src, err := g.Format()
+	if err != nil {
+		t.Fatalf("could not format generated code: %+v", err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "types.go"), src, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(`package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rdict"
+)
+
+func main() {
+	p3 := func(i int) rdict__test__P3 {
+		return rdict__test__P3{
+			Px:   int32(i),
+			Py:   float64(i) + 0.5,
+			Name: string(rune('a' + i)),
+			Vs:   []float64{float64(i), float64(2 * i)},
+		}
+	}
+	ptr := func(i int) *rdict__test__P3 {
+		v := p3(i)
+		return &v
+	}
+
+	want := &rdict__test__Event{
+		N:            2,
+		Arr:          []float64{1, 2},
+		Loop:         []rdict__test__P3{p3(1), p3(2)},
+		VecVecI32:    [][]int32{{1}, {}, {2, 3}},
+		VecP3:        []rdict__test__P3{p3(3)},
+		VecPtrP3:     []*rdict__test__P3{ptr(4), ptr(5)},
+		MapI32Str:    map[int32]string{1: "one", 2: "two"},
+		MapStrVecF64: map[string][]float64{"a": {1}, "b": {2, 3}},
+		SetI32:       map[int32]struct{}{1: {}, 4: {}, 9: {}},
+		Bits:         [8]bool{true, false, true},
+		Pair:         pair_int_double_{first: 42, second: 66.6},
+		Ptr:          ptr(6),
+		Ref:          ptr(7),
+	}
+
+	w := rbytes.NewWBuffer(nil, nil, 0, nil)
+	_, err := want.MarshalROOT(w)
+	if err != nil {
+		log.Fatalf("could not marshal: %+v", err)
+	}
+
+	var got rdict__test__Event
+	r := rbytes.NewRBuffer(w.Bytes(), nil, 0, rdict.StreamerInfos)
+	err = got.UnmarshalROOT(r)
+	if err != nil {
+		log.Fatalf("could not unmarshal: %+v", err)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		log.Fatalf("invalid round-trip:\ngot= %#v\nwant=%#v", &got, want)
+	}
+
+	raw, err := ioutil.ReadFile(os.Args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var mbr rdict__test__MbrWise
+	r = rbytes.NewRBuffer(raw, nil, 0, rdict.StreamerInfos)
+	err = mbr.UnmarshalROOT(r)
+	if err != nil {
+		log.Fatalf("could not unmarshal member-wise data: %+v", err)
+	}
+	if want := (rdict__test__MbrWise{
+		VecP3: []rdict__test__P3{
+			{Px: 1, Py: 1.5, Name: "a", Vs: []float64{1}},
+			{Px: 2, Py: 2.5, Name: "b", Vs: []float64{2, 3}},
+		},
+		Map: map[string]int32{"one": 1, "two": 2},
+	}); !reflect.DeepEqual(mbr, want) {
+		log.Fatalf("invalid member-wise data:\ngot= %#v\nwant=%#v", mbr, want)
+	}
+}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "mbrwise.raw"), testMbrWiseData(t), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	cmd := exec.Command("go", "run",
+		filepath.Join(dir, "main.go"), filepath.Join(dir, "types.go"),
+		filepath.Join(dir, "mbrwise.raw"),
+	)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err = cmd.Run()
+	if err != nil {
+		t.Fatalf("could not run generated code:\n%s\nerr: %+v", out.String(), err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"log"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -96,11 +97,7 @@ func (g *genGoType) genType(si rbytes.StreamerInfo) error {
 		g.printf("// %s has been automatically generated.\n", name)
 		g.printf("// %s\n", title)
 	}
-	goname := name
-	goname = strings.Replace(goname, "::", "__", -1) // handle namespaces
-	goname = strings.Replace(goname, "<", "_", -1)   // handle C++ templates
-	goname = strings.Replace(goname, ">", "_", -1)   // handle C++ templates
-	goname = strings.Replace(goname, ",", "_", -1)   // handle C++ templates
+	goname := goName(name)
 	g.printf("type %s struct{\n", goname)
 	for i, se := range si.Elements() {
 		g.genField(si, i, se)
@@ -194,13 +191,8 @@ func (g *genGoType) genField(si rbytes.StreamerInfo, i int, se rbytes.StreamerEl
 		g.printf(docFmt, se.Name(), "string", g.stag(i, se), doc)
 
 	case *StreamerSTL:
-		switch se.STLType() {
-		case rmeta.STLvector, rmeta.STLmap:
-			tname := g.typename(se)
-			g.printf(docFmt, se.Name(), tname, g.stag(i, se), doc)
-		default:
-			panic(fmt.Errorf("STL-type not implemented %#v", se))
-		}
+		tname := g.typename(se)
+		g.printf(docFmt, se.Name(), tname, g.stag(i, se), doc)
 	default:
 		g.printf("\t%s\t%s // %T -- %s\n", se.Name(), g.typename(se), se, doc)
 	}
@@ -249,12 +241,7 @@ func (g *genGoType) typename(se rbytes.StreamerElement) string {
 		if !ok {
 			panic(fmt.Errorf("gen-type: unknown C++ builtin %q", tname))
 		}
-		switch {
-		case strings.HasPrefix(se.Title(), "["):
-			return g.cxx2go(t.Name(), qualSlice)
-		default:
-			return g.cxx2go(t.Name(), qualStar)
-		}
+		return g.cxx2go(t.Name(), qualSlice)
 
 	case *StreamerBasicType:
 		switch se.Type() {
@@ -272,10 +259,8 @@ func (g *genGoType) typename(se rbytes.StreamerElement) string {
 		return t.Name()
 
 	case *StreamerLoop:
-		if strings.HasSuffix(tname, "*") {
-			tname = tname[:len(tname)-1]
-		}
-		return "[]" + g.cxx2go(tname, qualNone)
+		tname = tname[:len(tname)-1] // drop last '*'
+		return "[]" + g.gotypeOf(tname)
 
 	case *StreamerObject:
 		return g.cxx2go(tname, qualNone)
@@ -316,36 +301,13 @@ func (g *genGoType) typename(se rbytes.StreamerElement) string {
 				return "[]uint32"
 			case rmeta.Uint64:
 				return "[]uint64"
-			case rmeta.Float32, rmeta.Float16:
+			case rmeta.Float32:
 				return "[]float32"
-			case rmeta.Float64, rmeta.Double32:
+			case rmeta.Float64:
 				return "[]float64"
-			case rmeta.Object:
-				etn := se.ElemTypeName()
-				switch etn[0] {
-				case "string":
-					return "[]string"
-				default:
-					etname := g.cxx2go(etn[0], qualNone)
-					return "[]" + etname
-				}
-			default:
-				panic(fmt.Errorf("invalid stl-vector element type: %v -- %#v", se.ContainedType(), se))
-			}
-		case rmeta.STLmap:
-			types := rmeta.CxxTemplateArgsOf(se.TypeName())
-			if len(types) != 2 {
-				panic(fmt.Errorf(
-					"invalid stl-map: got %d template arguments instead of 2 for type %q",
-					len(types), se.TypeName(),
-				))
 			}
-			k := g.cxx2go(types[0], qualNone)
-			v := g.cxx2go(types[1], qualNone)
-			return "map[" + k + "]" + v
-		default:
-			panic(fmt.Errorf("STL-type not implemented %#v", se))
 		}
+		return g.gotypeOf(se.TypeName())
 	}
 	return tname
 }
@@ -400,8 +362,62 @@ func (g *genGoType) cxx2go(name string, qual qualKind) string {
 		return name
 	}
 	name = f(name)
-	name = strings.Replace(name, "::", "__", -1) // handle namespaces
-	return prefix + name
+	return prefix + goName(name)
+}
+
+// gotypeOf returns the Go type name corresponding to the provided C++ type
+// name, including (nested) STL containers.
+func (g *genGoType) gotypeOf(name string) string {
+	name = strings.Replace(strings.TrimSpace(name), "std::", "", -1)
+	if strings.HasSuffix(name, "*") {
+		return "*" + g.gotypeOf(name[:len(name)-1])
+	}
+	switch name {
+	case "string", "TString":
+		return "string"
+	}
+	if t, ok := rmeta.CxxBuiltins[name]; ok {
+		if t.PkgPath() != "" {
+			g.imps[t.PkgPath()] = 1
+			return filepath.Base(t.PkgPath()) + "." + t.Name()
+		}
+		return t.Name()
+	}
+
+	switch stlTypeOf(name) {
+	case rmeta.STLvector, rmeta.STLlist, rmeta.STLdeque, rmeta.STLmultiset:
+		args := rmeta.CxxTemplateArgsOf(name)
+		return "[]" + g.gotypeOf(args[0])
+	case rmeta.STLset, rmeta.STLunorderedset:
+		args := rmeta.CxxTemplateArgsOf(name)
+		return "map[" + g.gotypeOf(args[0]) + "]struct{}"
+	case rmeta.STLmap, rmeta.STLunorderedmap:
+		args := rmeta.CxxTemplateArgsOf(name)
+		if len(args) != 2 {
+			panic(fmt.Errorf(
+				"invalid stl-map: got %d template arguments instead of 2 for type %q",
+				len(args), name,
+			))
+		}
+		return "map[" + g.gotypeOf(args[0]) + "]" + g.gotypeOf(args[1])
+	case rmeta.STLbitset:
+		args := rmeta.CxxTemplateArgsOf(name)
+		return "[" + args[0] + "]bool"
+	case rmeta.NotSTL:
+		return g.cxx2go(name, qualNone)
+	}
+	panic(fmt.Errorf("gen-type: STL-type not implemented %q", name))
+}
+
+// goName returns a valid Go identifier from the provided C++ class name.
+func goName(name string) string {
+	return strings.NewReplacer(
+		"::", "__", // handle namespaces
+		"<", "_", // handle C++ templates
+		">", "_",
+		",", "_",
+		" ", "",
+	).Replace(name)
 }
 
 func (g *genGoType) genMarshal(si rbytes.StreamerInfo) {
@@ -430,42 +446,36 @@ func (g *genGoType) genMarshalField(si rbytes.StreamerInfo, i int, se rbytes.Str
 		g.printf("o.%s.MarshalROOT(w)\n", fmt.Sprintf("base%d", i))
 
 	case *StreamerBasicPointer:
-		title := se.Title()
-		switch {
-		case strings.HasPrefix(title, "["):
-			n := title[strings.Index(title, "[")+1 : strings.Index(title, "]")]
-			g.printf("w.WriteI8(1) // is-array\n")
-			wfunc := ""
-			switch se.Type() {
-			case rmeta.OffsetP + rmeta.Bool:
-				wfunc = "WriteFastArrayBool"
-			case rmeta.OffsetP + rmeta.Int8:
-				wfunc = "WriteFastArrayI8"
-			case rmeta.OffsetP + rmeta.Int16:
-				wfunc = "WriteFastArrayI16"
-			case rmeta.OffsetP + rmeta.Int32:
-				wfunc = "WriteFastArrayI32"
-			case rmeta.OffsetP + rmeta.Int64, rmeta.OffsetP + rmeta.Long64:
-				wfunc = "WriteFastArrayI64"
-			case rmeta.OffsetP + rmeta.Uint8:
-				wfunc = "WriteFastArrayU8"
-			case rmeta.OffsetP + rmeta.Uint16:
-				wfunc = "WriteFastArrayU16"
-			case rmeta.OffsetP + rmeta.Uint32:
-				wfunc = "WriteFastArrayU32"
-			case rmeta.OffsetP + rmeta.Uint64:
-				wfunc = "WriteFastArrayU64"
-			case rmeta.OffsetP + rmeta.Float32:
-				wfunc = "WriteFastArrayF32"
-			case rmeta.OffsetP + rmeta.Float64:
-				wfunc = "WriteFastArrayF64"
-			default:
-				panic(fmt.Errorf("invalid element type: %v", se.Type()))
-			}
-			g.printf("w.%s(o.%s[:o.%s])\n", wfunc, se.Name(), n)
+		n := se.CountName()
+		g.printf("w.WriteI8(1) // is-array\n")
+		wfunc := ""
+		switch se.Type() {
+		case rmeta.OffsetP + rmeta.Bool:
+			wfunc = "WriteFastArrayBool"
+		case rmeta.OffsetP + rmeta.Int8:
+			wfunc = "WriteFastArrayI8"
+		case rmeta.OffsetP + rmeta.Int16:
+			wfunc = "WriteFastArrayI16"
+		case rmeta.OffsetP + rmeta.Int32:
+			wfunc = "WriteFastArrayI32"
+		case rmeta.OffsetP + rmeta.Int64, rmeta.OffsetP + rmeta.Long64:
+			wfunc = "WriteFastArrayI64"
+		case rmeta.OffsetP + rmeta.Uint8:
+			wfunc = "WriteFastArrayU8"
+		case rmeta.OffsetP + rmeta.Uint16:
+			wfunc = "WriteFastArrayU16"
+		case rmeta.OffsetP + rmeta.Uint32:
+			wfunc = "WriteFastArrayU32"
+		case rmeta.OffsetP + rmeta.Uint64:
+			wfunc = "WriteFastArrayU64"
+		case rmeta.OffsetP + rmeta.Float32:
+			wfunc = "WriteFastArrayF32"
+		case rmeta.OffsetP + rmeta.Float64:
+			wfunc = "WriteFastArrayF64"
 		default:
-			panic("not implemented")
+			panic(fmt.Errorf("invalid element type: %v", se.Type()))
 		}
+		g.printf("w.%s(o.%s[:o.%s])\n", wfunc, se.Name(), n)
 
 	case *StreamerBasicType:
 		switch se.ArrayLen() {
@@ -551,8 +561,17 @@ func (g *genGoType) genMarshalField(si rbytes.StreamerInfo, i int, se rbytes.Str
 		}
 
 	case *StreamerLoop:
-		// FIXME(sbinet): implement. handle mbr-wise
-		g.printf("panic(\"o.%s: not implemented (TStreamerLoop)\")\n", se.Name())
+		g.imps["go-hep.org/x/hep/groot/rvers"] = 1
+		g.printf("{\n")
+		g.printf("pos := w.WriteVersion(rvers.StreamerInfo)\n")
+		g.printf("for i := range o.%s[:o.%s] {\n", se.Name(), se.CountName())
+		g.genMarshalElem(fmt.Sprintf("o.%s[i]", se.Name()), strings.TrimSuffix(se.TypeName(), "*"), 1)
+		g.printf("}\n")
+		g.printf("if _, err := w.SetByteCount(pos, %q); err != nil {\n", se.TypeName())
+		g.printf("w.SetErr(err)\n")
+		g.printf("return 0, w.Err()\n")
+		g.printf("}\n")
+		g.printf("}\n")
 
 	case *StreamerObject:
 		// FIXME(sbinet): check semantics
@@ -577,12 +596,20 @@ func (g *genGoType) genMarshalField(si rbytes.StreamerInfo, i int, se rbytes.Str
 		}
 
 	case *StreamerObjectAnyPointer:
-		// FIXME(sbinet): check semantics
-		g.printf("w.WriteObjectAny(o.%s) // obj-any-ptr\n", se.Name())
+		switch se.Type() {
+		case rmeta.Anyp:
+			g.genMarshalPtrInPlace(se)
+		default:
+			g.printf("w.WriteObjectAny(o.%s) // obj-any-ptr\n", se.Name())
+		}
 
 	case *StreamerObjectPointer:
-		// FIXME(sbinet): check semantics
-		g.printf("w.WriteObjectAny(o.%s) // obj-ptr \n", se.Name())
+		switch se.Type() {
+		case rmeta.Objectp:
+			g.genMarshalPtrInPlace(se)
+		default:
+			g.printf("w.WriteObjectAny(o.%s) // obj-ptr \n", se.Name())
+		}
 
 	case *StreamerString:
 		g.printf("w.WriteString(o.%s)\n", se.Name())
@@ -622,11 +649,11 @@ func (g *genGoType) genMarshalField(si rbytes.StreamerInfo, i int, se rbytes.Str
 				switch etn[0] {
 				case "string":
 					wfunc = "WriteFastArrayString"
-				default:
-					panic(fmt.Errorf("invalid stl-vector element type: %v", se.ContainedType()))
 				}
-			default:
-				panic(fmt.Errorf("invalid stl-vector element type: %v", se.ContainedType()))
+			}
+			if wfunc == "" {
+				g.genMarshalSTL("o."+se.Name(), se.TypeName(), true, 0)
+				break
 			}
 			g.imps["go-hep.org/x/hep/groot/rvers"] = 1
 			g.printf("{\n")
@@ -640,7 +667,7 @@ func (g *genGoType) genMarshalField(si rbytes.StreamerInfo, i int, se rbytes.Str
 			g.printf("}\n")
 
 		default:
-			panic(fmt.Errorf("STL-type not implemented %#v", se))
+			g.genMarshalSTL("o."+se.Name(), se.TypeName(), true, 0)
 		}
 
 	default:
@@ -656,11 +683,23 @@ func (o *%[1]s) UnmarshalROOT(r *rbytes.RBuffer) error {
 	}
 	
 	start := r.Pos()
-	/*vers*/ _, pos, bcnt := r.ReadVersion(o.Class())
+	vers, pos, bcnt := r.ReadVersion(o.Class())
+	if vers != o.RVersion() {
+		// schema evolution.
+		if err := %[2]sReadEvolved(r, o, o.Class(), vers); err != nil {
+			return err
+		}
+		r.CheckByteCount(pos, bcnt, start, o.Class())
+		return r.Err()
+	}
 
 `,
 		g.cxx2go(si.Name(), qualNone),
+		g.rdict,
 	)
+	if g.rdict != "" {
+		g.imps["go-hep.org/x/hep/groot/rdict"] = 1
+	}
 
 	for i, se := range si.Elements() {
 		g.genUnmarshalField(si, i, se)
@@ -675,55 +714,49 @@ func (g *genGoType) genUnmarshalField(si rbytes.StreamerInfo, i int, se rbytes.S
 		g.printf("o.%s.UnmarshalROOT(r)\n", fmt.Sprintf("base%d", i))
 
 	case *StreamerBasicPointer:
-		title := se.Title()
-		switch {
-		case strings.HasPrefix(title, "["):
-			n := title[strings.Index(title, "[")+1 : strings.Index(title, "]")]
-			g.printf("_ = r.ReadI8() // is-array\n")
-			rfunc := ""
-			rsize := ""
-			switch se.Type() {
-			case rmeta.OffsetP + rmeta.Bool:
-				rfunc = "ReadArrayBool"
-				rsize = "ResizeBool"
-			case rmeta.OffsetP + rmeta.Int8:
-				rfunc = "ReadArrayI8"
-				rsize = "ResizeI8"
-			case rmeta.OffsetP + rmeta.Int16:
-				rfunc = "ReadArrayI16"
-				rsize = "ResizeI16"
-			case rmeta.OffsetP + rmeta.Int32:
-				rfunc = "ReadArrayI32"
-				rsize = "ResizeI32"
-			case rmeta.OffsetP + rmeta.Int64, rmeta.OffsetP + rmeta.Long64:
-				rfunc = "ReadArrayI64"
-				rsize = "ResizeI64"
-			case rmeta.OffsetP + rmeta.Uint8:
-				rfunc = "ReadArrayU8"
-				rsize = "ResizeU8"
-			case rmeta.OffsetP + rmeta.Uint16:
-				rfunc = "ReadArrayU16"
-				rsize = "ResizeU16"
-			case rmeta.OffsetP + rmeta.Uint32:
-				rfunc = "ReadArrayU32"
-				rsize = "ResizeU32"
-			case rmeta.OffsetP + rmeta.Uint64:
-				rfunc = "ReadArrayU64"
-				rsize = "ResizeU64"
-			case rmeta.OffsetP + rmeta.Float32:
-				rfunc = "ReadArrayF32"
-				rsize = "ResizeF32"
-			case rmeta.OffsetP + rmeta.Float64:
-				rfunc = "ReadArrayF64"
-				rsize = "ResizeF64"
-			default:
-				panic(fmt.Errorf("invalid element type: %v", se.Type()))
-			}
-			g.printf("o.%s = rbytes.%s(nil, int(o.%s))\n", se.Name(), rsize, n)
-			g.printf("r.%s(o.%s)\n", rfunc, se.Name())
+		n := se.CountName()
+		g.printf("_ = r.ReadI8() // is-array\n")
+		rfunc := ""
+		rsize := ""
+		switch se.Type() {
+		case rmeta.OffsetP + rmeta.Bool:
+			rfunc = "ReadArrayBool"
+			rsize = "ResizeBool"
+		case rmeta.OffsetP + rmeta.Int8:
+			rfunc = "ReadArrayI8"
+			rsize = "ResizeI8"
+		case rmeta.OffsetP + rmeta.Int16:
+			rfunc = "ReadArrayI16"
+			rsize = "ResizeI16"
+		case rmeta.OffsetP + rmeta.Int32:
+			rfunc = "ReadArrayI32"
+			rsize = "ResizeI32"
+		case rmeta.OffsetP + rmeta.Int64, rmeta.OffsetP + rmeta.Long64:
+			rfunc = "ReadArrayI64"
+			rsize = "ResizeI64"
+		case rmeta.OffsetP + rmeta.Uint8:
+			rfunc = "ReadArrayU8"
+			rsize = "ResizeU8"
+		case rmeta.OffsetP + rmeta.Uint16:
+			rfunc = "ReadArrayU16"
+			rsize = "ResizeU16"
+		case rmeta.OffsetP + rmeta.Uint32:
+			rfunc = "ReadArrayU32"
+			rsize = "ResizeU32"
+		case rmeta.OffsetP + rmeta.Uint64:
+			rfunc = "ReadArrayU64"
+			rsize = "ResizeU64"
+		case rmeta.OffsetP + rmeta.Float32:
+			rfunc = "ReadArrayF32"
+			rsize = "ResizeF32"
+		case rmeta.OffsetP + rmeta.Float64:
+			rfunc = "ReadArrayF64"
+			rsize = "ResizeF64"
 		default:
-			panic("not implemented")
+			panic(fmt.Errorf("invalid element type: %v", se.Type()))
 		}
+		g.printf("o.%s = rbytes.%s(nil, int(o.%s))\n", se.Name(), rsize, n)
+		g.printf("r.%s(o.%s)\n", rfunc, se.Name())
 
 	case *StreamerBasicType:
 		switch se.ArrayLen() {
@@ -808,8 +841,14 @@ func (g *genGoType) genUnmarshalField(si rbytes.StreamerInfo, i int, se rbytes.S
 		}
 
 	case *StreamerLoop:
-		// FIXME(sbinet): implement. handle mbr-wise
-		g.printf("panic(\"o.%s: not implemented (TStreamerLoop)\")\n", se.Name())
+		g.printf("{\n")
+		g.printf("_, pos, bcnt := r.ReadVersion(%q)\n", se.TypeName())
+		g.printf("o.%s = make(%s, o.%s)\n", se.Name(), g.typename(se), se.CountName())
+		g.printf("for i := range o.%s {\n", se.Name())
+		g.genUnmarshalElem(fmt.Sprintf("o.%s[i]", se.Name()), strings.TrimSuffix(se.TypeName(), "*"), 1)
+		g.printf("}\n")
+		g.printf("r.CheckByteCount(pos, bcnt, start, %q)\n", se.TypeName())
+		g.printf("}\n")
 
 	case *StreamerObject:
 		// FIXME(sbinet): check semantics
@@ -834,7 +873,10 @@ func (g *genGoType) genUnmarshalField(si rbytes.StreamerInfo, i int, se rbytes.S
 		}
 
 	case *StreamerObjectAnyPointer:
-		// FIXME(sbinet): check semantics
+		if se.Type() == rmeta.Anyp {
+			g.genUnmarshalPtrInPlace(se)
+			break
+		}
 		g.printf("{\n")
 		g.printf("o.%s = nil\n", se.Name())
 		g.printf("if oo := r.ReadObjectAny(); oo != nil {  // obj-any-ptr\n")
@@ -842,7 +884,10 @@ func (g *genGoType) genUnmarshalField(si rbytes.StreamerInfo, i int, se rbytes.S
 		g.printf("}\n}\n")
 
 	case *StreamerObjectPointer:
-		// FIXME(sbinet): check semantics
+		if se.Type() == rmeta.Objectp {
+			g.genUnmarshalPtrInPlace(se)
+			break
+		}
 		g.printf("{\n")
 		g.printf("o.%s = nil\n", se.Name())
 		g.printf("if oo := r.ReadObjectAny(); oo != nil {  // obj-ptr\n")
@@ -900,11 +945,11 @@ func (g *genGoType) genUnmarshalField(si rbytes.StreamerInfo, i int, se rbytes.S
 				case "string":
 					rfunc = "ReadArrayString"
 					rsize = "ResizeStr"
-				default:
-					panic(fmt.Errorf("invalid stl-vector element type: %v", se.ContainedType()))
 				}
-			default:
-				panic(fmt.Errorf("invalid stl-vector element type: %v", se.ContainedType()))
+			}
+			if rfunc == "" {
+				g.genUnmarshalSTL("o."+se.Name(), se.TypeName(), true, 0)
+				break
 			}
 			g.imps["fmt"] = 1
 			g.imps["go-hep.org/x/hep/groot/rvers"] = 1
@@ -920,7 +965,7 @@ func (g *genGoType) genUnmarshalField(si rbytes.StreamerInfo, i int, se rbytes.S
 			g.printf("}\n")
 
 		default:
-			panic(fmt.Errorf("STL-type not implemented %#v", se))
+			g.genUnmarshalSTL("o."+se.Name(), se.TypeName(), true, 0)
 		}
 
 	default:
@@ -928,6 +973,396 @@ func (g *genGoType) genUnmarshalField(si rbytes.StreamerInfo, i int, se rbytes.S
 	}
 }
 
+// genMarshalPtrInPlace generates code streaming, in-place, a non-nullable
+// pointer to an object (annotated with "->").
+func (g *genGoType) genMarshalPtrInPlace(se rbytes.StreamerElement) {
+	g.printf("if o.%s == nil {\n", se.Name())
+	g.printf("var v %s\n", g.cxx2go(strings.TrimSuffix(se.TypeName(), "*"), qualNone))
+	g.printf("v.MarshalROOT(w) // obj-ptr\n")
+	g.printf("} else {\n")
+	g.printf("o.%s.MarshalROOT(w) // obj-ptr\n", se.Name())
+	g.printf("}\n")
+}
+
+// genUnmarshalPtrInPlace generates code reading, in-place, a non-nullable
+// pointer to an object (annotated with "->").
+func (g *genGoType) genUnmarshalPtrInPlace(se rbytes.StreamerElement) {
+	g.printf("if o.%s == nil {\n", se.Name())
+	g.printf("o.%s = new(%s)\n", se.Name(), g.cxx2go(strings.TrimSuffix(se.TypeName(), "*"), qualNone))
+	g.printf("}\n")
+	g.printf("o.%s.UnmarshalROOT(r) // obj-ptr\n", se.Name())
+}
+
+// genMarshalSTL generates code writing the STL container expr, of C++ type
+// tname.
+// Containers nested inside other containers are written without a header.
+func (g *genGoType) genMarshalSTL(expr, tname string, header bool, depth int) {
+	var (
+		cxx  = strings.Replace(strings.TrimSpace(tname), "std::", "", -1)
+		args = rmeta.CxxTemplateArgsOf(cxx)
+	)
+
+	if header {
+		g.imps["go-hep.org/x/hep/groot/rvers"] = 1
+		g.printf("{\n")
+		g.printf("pos := w.WriteVersion(rvers.StreamerInfo)\n")
+	}
+
+	switch stlTypeOf(cxx) {
+	case rmeta.STLvector, rmeta.STLlist, rmeta.STLdeque, rmeta.STLmultiset:
+		g.printf("w.WriteI32(int32(len(%s)))\n", expr)
+		if sfx, arg, ok := g.builtinOf(args[0]); ok {
+			if arg != "" {
+				arg = ", " + arg
+			}
+			g.printf("w.WriteFastArray%s(%s%s)\n", sfx, expr, arg)
+			break
+		}
+		i := fmt.Sprintf("i%d", depth)
+		g.printf("for %s := range %s {\n", i, expr)
+		g.genMarshalElem(expr+"["+i+"]", args[0], depth+1)
+		g.printf("}\n")
+
+	case rmeta.STLbitset:
+		g.printf("w.WriteI32(%s)\n", args[0])
+		g.printf("w.WriteFastArrayBool(%s[:])\n", expr)
+
+	case rmeta.STLset, rmeta.STLunorderedset:
+		k := fmt.Sprintf("k%d", depth)
+		g.printf("w.WriteI32(int32(len(%s)))\n", expr)
+		g.printf("for _, %s := range %s {\n", k, g.genSortedKeys(expr, args[0], depth))
+		g.genMarshalElem(k, args[0], depth+1)
+		g.printf("}\n")
+
+	case rmeta.STLmap, rmeta.STLunorderedmap:
+		var (
+			k = fmt.Sprintf("k%d", depth)
+			v = fmt.Sprintf("v%d", depth)
+		)
+		g.printf("w.WriteI32(int32(len(%s)))\n", expr)
+		g.printf("for _, %s := range %s {\n", k, g.genSortedKeys(expr, args[0], depth))
+		g.printf("%s := %s[%s]\n", v, expr, k)
+		g.genMarshalElem(k, args[0], depth+1)
+		g.genMarshalElem(v, args[1], depth+1)
+		g.printf("}\n")
+
+	default:
+		panic(fmt.Errorf("gen-type: STL-type not implemented %q", tname))
+	}
+
+	if header {
+		g.printf("if _, err := w.SetByteCount(pos, %q); err != nil {\n", tname)
+		g.printf("w.SetErr(err)\n")
+		g.printf("return 0, w.Err()\n")
+		g.printf("}\n")
+		g.printf("}\n")
+	}
+}
+
+// genSortedKeys generates code collecting the keys of the map expr, sorted
+// when the keys are ordered, so streaming a map is reproducible.
+// genSortedKeys returns the name of the collected keys.
+func (g *genGoType) genSortedKeys(expr, kname string, depth int) string {
+	var (
+		keys = fmt.Sprintf("keys%d", depth)
+		k    = fmt.Sprintf("k%d", depth)
+	)
+	g.printf("%s := make([]%s, 0, len(%s))\n", keys, g.gotypeOf(kname), expr)
+	g.printf("for %s := range %s {\n", k, expr)
+	g.printf("%s = append(%s, %s)\n", keys, keys, k)
+	g.printf("}\n")
+	if sfx, _, ok := g.builtinOf(kname); ok && sfx != "Bool" {
+		g.imps["sort"] = 1
+		g.printf("sort.Slice(%[1]s, func(i, j int) bool { return %[1]s[i] < %[1]s[j] })\n", keys)
+	}
+	return keys
+}
+
+// genMarshalElem generates code writing the element expr, of C++ type tname,
+// of a STL container or of a variable-length array of objects.
+func (g *genGoType) genMarshalElem(expr, tname string, depth int) {
+	cxx := strings.Replace(strings.TrimSpace(tname), "std::", "", -1)
+	switch {
+	case strings.HasSuffix(cxx, "*"):
+		g.printf("w.WriteObjectAny(%s)\n", expr)
+	case stlTypeOf(cxx) != rmeta.NotSTL:
+		g.genMarshalSTL(expr, cxx, false, depth)
+	default:
+		sfx, arg, ok := g.builtinOf(cxx)
+		if !ok {
+			g.printf("%s.MarshalROOT(w)\n", expr)
+			return
+		}
+		if arg != "" {
+			arg = ", " + arg
+		}
+		g.printf("w.Write%s(%s%s)\n", sfx, expr, arg)
+	}
+}
+
+// genUnmarshalSTL generates code reading the STL container expr, of C++ type
+// tname.
+// Containers nested inside other containers are read without a header.
+// Top-level containers of objects may have been written member-wise.
+func (g *genGoType) genUnmarshalSTL(expr, tname string, header bool, depth int) {
+	var (
+		cxx     = strings.Replace(strings.TrimSpace(tname), "std::", "", -1)
+		args    = rmeta.CxxTemplateArgsOf(cxx)
+		kind    = stlTypeOf(cxx)
+		n       = fmt.Sprintf("n%d", depth)
+		i       = fmt.Sprintf("i%d", depth)
+		mbrwise = false
+	)
+
+	switch kind {
+	case rmeta.STLvector, rmeta.STLlist, rmeta.STLdeque, rmeta.STLmultiset:
+		mbrwise = header && g.isClass(args[0])
+	case rmeta.STLmap, rmeta.STLunorderedmap:
+		mbrwise = header
+	}
+
+	if header {
+		g.printf("{\n")
+		switch {
+		case mbrwise:
+			g.printf("vers, pos, bcnt := r.ReadVersion(%q)\n", tname)
+			g.printf("mbrwise := vers&rbytes.StreamedMemberWise != 0\n")
+			g.printf("if mbrwise {\n")
+			g.printf("if v := r.ReadI16(); v <= 0 {\n")
+			g.printf("_ = r.ReadU32() // checksum\n")
+			g.printf("}\n")
+			g.printf("}\n")
+		default:
+			g.printf("_, pos, bcnt := r.ReadVersion(%q)\n", tname)
+		}
+	}
+	g.printf("%s := int(r.ReadI32())\n", n)
+
+	switch kind {
+	case rmeta.STLvector, rmeta.STLlist, rmeta.STLdeque, rmeta.STLmultiset:
+		g.printf("%s = make(%s, %s)\n", expr, g.gotypeOf(cxx), n)
+		if sfx, arg, ok := g.builtinOf(args[0]); ok {
+			if arg != "" {
+				arg = ", " + arg
+			}
+			g.printf("r.ReadArray%s(%s%s)\n", sfx, expr, arg)
+			break
+		}
+		if mbrwise {
+			g.printf("if mbrwise {\n")
+			g.genUnmarshalMemberWise(expr, args[0], depth)
+			g.printf("} else {\n")
+		}
+		g.printf("for %s := range %s {\n", i, expr)
+		g.genUnmarshalElem(expr+"["+i+"]", args[0], depth+1)
+		g.printf("}\n")
+		if mbrwise {
+			g.printf("}\n")
+		}
+
+	case rmeta.STLbitset:
+		g.imps["fmt"] = 1
+		g.printf("if %s != len(%s) {\n", n, expr)
+		g.printf("r.SetErr(fmt.Errorf(\"rbytes: invalid size for \\\"%s\\\". got=%%v, want=%%v\", %s, len(%s)))\n", tname, n, expr)
+		g.printf("return r.Err()\n")
+		g.printf("}\n")
+		g.printf("r.ReadArrayBool(%s[:])\n", expr)
+
+	case rmeta.STLset, rmeta.STLunorderedset:
+		k := fmt.Sprintf("k%d", depth)
+		g.printf("%s = make(%s, %s)\n", expr, g.gotypeOf(cxx), n)
+		g.printf("for %s := 0; %s < %s; %s++ {\n", i, i, n, i)
+		g.printf("var %s %s\n", k, g.gotypeOf(args[0]))
+		g.genUnmarshalElem(k, args[0], depth+1)
+		g.printf("%s[%s] = struct{}{}\n", expr, k)
+		g.printf("}\n")
+
+	case rmeta.STLmap, rmeta.STLunorderedmap:
+		var (
+			k    = fmt.Sprintf("k%d", depth)
+			v    = fmt.Sprintf("v%d", depth)
+			keys = fmt.Sprintf("keys%d", depth)
+			vals = fmt.Sprintf("vals%d", depth)
+		)
+		g.printf("%s = make(%s, %s)\n", expr, g.gotypeOf(cxx), n)
+		if mbrwise {
+			g.printf("if mbrwise {\n")
+			g.printf("%s := make([]%s, %s)\n", keys, g.gotypeOf(args[0]), n)
+			g.printf("%s := make([]%s, %s)\n", vals, g.gotypeOf(args[1]), n)
+			g.genUnmarshalMembers(keys, args[0], n, depth)
+			g.genUnmarshalMembers(vals, args[1], n, depth)
+			g.printf("for %s := range %s {\n", i, keys)
+			g.printf("%s[%s[%s]] = %s[%s]\n", expr, keys, i, vals, i)
+			g.printf("}\n")
+			g.printf("} else {\n")
+		}
+		g.printf("for %s := 0; %s < %s; %s++ {\n", i, i, n, i)
+		g.printf("var %s %s\n", k, g.gotypeOf(args[0]))
+		g.printf("var %s %s\n", v, g.gotypeOf(args[1]))
+		g.genUnmarshalElem(k, args[0], depth+1)
+		g.genUnmarshalElem(v, args[1], depth+1)
+		g.printf("%s[%s] = %s\n", expr, k, v)
+		g.printf("}\n")
+		if mbrwise {
+			g.printf("}\n")
+		}
+
+	default:
+		panic(fmt.Errorf("gen-type: STL-type not implemented %q", tname))
+	}
+
+	if header {
+		g.printf("r.CheckByteCount(pos, bcnt, start, %q)\n", tname)
+		g.printf("}\n")
+	}
+}
+
+// genUnmarshalMemberWise generates code reading, member-wise, the slice expr
+// of values of the named class: the first member of all the values, then the
+// second member of all the values, etc...
+// Members streamed with a custom streamer (std::string and STL containers of
+// builtins) share a single header for all the values.
+func (g *genGoType) genUnmarshalMemberWise(expr, class string, depth int) {
+	var si rbytes.StreamerInfo
+	if g.ctx != nil {
+		si, _ = g.ctx.StreamerInfo(class, -1)
+	}
+	if si == nil {
+		g.imps["fmt"] = 1
+		g.printf("r.SetErr(fmt.Errorf(\"rbytes: member-wise streaming of \\\"%s\\\" not supported\"))\n", class)
+		g.printf("return r.Err()\n")
+		return
+	}
+
+	for j, se := range si.Elements() {
+		if se.Type() != rmeta.Streamer {
+			g.printf("for i := range %s {\n", expr)
+			g.printf("o := &%s[i]\n", expr)
+			g.genUnmarshalField(si, j, se)
+			g.printf("}\n")
+			continue
+		}
+		g.printf("{\n")
+		g.printf("beg := r.Pos()\n")
+		g.printf("_, pos, bcnt := r.ReadVersion(%q)\n", se.TypeName())
+		g.printf("for i := range %s {\n", expr)
+		g.printf("o := &%s[i]\n", expr)
+		switch se := se.(type) {
+		case *StreamerSTLstring:
+			g.printf("o.%s = r.ReadString()\n", se.Name())
+		case *StreamerSTL:
+			g.genUnmarshalSTL("o."+se.Name(), se.TypeName(), false, depth+1)
+		default:
+			g.genUnmarshalField(si, j, se)
+		}
+		g.printf("}\n")
+		g.printf("r.CheckByteCount(pos, bcnt, beg, %q)\n", se.TypeName())
+		g.printf("}\n")
+	}
+}
+
+// genUnmarshalMembers generates code reading a block of members of
+// std::pair<K,V> values, written member-wise, into the slice expr.
+// Strings are read as a std::string collection, with a header.
+func (g *genGoType) genUnmarshalMembers(expr, tname, n string, depth int) {
+	var (
+		i     = fmt.Sprintf("i%d", depth)
+		isStr = g.gotypeOf(tname) == "string"
+	)
+	if isStr {
+		g.printf("if %s > 0 {\n", n)
+		g.printf("beg := r.Pos()\n")
+		g.printf("_, pos, bcnt := r.ReadVersion(\"string\")\n")
+	}
+	g.printf("for %s := range %s {\n", i, expr)
+	g.genUnmarshalElem(expr+"["+i+"]", tname, depth+1)
+	g.printf("}\n")
+	if isStr {
+		g.printf("r.CheckByteCount(pos, bcnt, beg, \"string\")\n")
+		g.printf("}\n")
+	}
+}
+
+// genUnmarshalElem generates code reading the element expr, of C++ type tname,
+// of a STL container or of a variable-length array of objects.
+func (g *genGoType) genUnmarshalElem(expr, tname string, depth int) {
+	cxx := strings.Replace(strings.TrimSpace(tname), "std::", "", -1)
+	switch {
+	case strings.HasSuffix(cxx, "*"):
+		g.printf("%s = nil\n", expr)
+		g.printf("if oo := r.ReadObjectAny(); oo != nil {\n")
+		g.printf("%s = oo.(%s)\n", expr, g.gotypeOf(cxx))
+		g.printf("}\n")
+	case stlTypeOf(cxx) != rmeta.NotSTL:
+		g.genUnmarshalSTL(expr, cxx, false, depth)
+	default:
+		sfx, arg, ok := g.builtinOf(cxx)
+		if !ok {
+			g.printf("%s.UnmarshalROOT(r)\n", expr)
+			return
+		}
+		g.printf("%s = r.Read%s(%s)\n", expr, sfx, arg)
+	}
+}
+
+// builtinOf returns the suffix of the rbytes methods streaming values of the
+// named C++ builtin type, together with the extra argument these methods
+// may need.
+func (g *genGoType) builtinOf(tname string) (sfx, arg string, ok bool) {
+	if tname == "string" || tname == "TString" {
+		return "String", "", true
+	}
+	t, ok := rmeta.CxxBuiltins[tname]
+	if !ok {
+		return "", "", false
+	}
+	switch t.Name() {
+	case "Float16":
+		g.imps["go-hep.org/x/hep/groot/root"] = 1
+		return "F16", "nil", true
+	case "Double32":
+		g.imps["go-hep.org/x/hep/groot/root"] = 1
+		return "D32", "nil", true
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "Bool", "", true
+	case reflect.Int8:
+		return "I8", "", true
+	case reflect.Int16:
+		return "I16", "", true
+	case reflect.Int32:
+		return "I32", "", true
+	case reflect.Int64:
+		return "I64", "", true
+	case reflect.Uint8:
+		return "U8", "", true
+	case reflect.Uint16:
+		return "U16", "", true
+	case reflect.Uint32:
+		return "U32", "", true
+	case reflect.Uint64:
+		return "U64", "", true
+	case reflect.Float32:
+		return "F32", "", true
+	case reflect.Float64:
+		return "F64", "", true
+	case reflect.String:
+		return "String", "", true
+	}
+	return "", "", false
+}
+
+// isClass returns whether the named C++ type is a class (and not a builtin,
+// a pointer or a STL container.)
+func (g *genGoType) isClass(tname string) bool {
+	tname = strings.Replace(strings.TrimSpace(tname), "std::", "", -1)
+	if _, _, ok := g.builtinOf(tname); ok {
+		return false
+	}
+	return !strings.HasSuffix(tname, "*") && stlTypeOf(tname) == rmeta.NotSTL
+}
+
 func (g *genGoType) genStreamerInfo(si rbytes.StreamerInfo) {
 	g.printf(`func init() {
 		// Streamer for %[1]s.
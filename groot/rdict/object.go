@@ -7,12 +7,15 @@ package rdict
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/rmeta"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
 )
 
 var (
@@ -29,8 +32,11 @@ func ObjectFrom(si rbytes.StreamerInfo, sictx rbytes.StreamerInfoContext) *Objec
 	return newObjectFrom(si, sictx)
 }
 
-type rfunc func(recv interface{}, r *rbytes.RBuffer) error
-type wfunc func(recv interface{}, w *rbytes.WBuffer) (int, error)
+// rfunc reads a value from the provided buffer into rv.
+type rfunc func(r *rbytes.RBuffer, rv reflect.Value) error
+
+// wfunc writes the provided value rv into the buffer.
+type wfunc func(w *rbytes.WBuffer, rv reflect.Value) error
 
 // Object wraps a type created from a Streamer and implements the
 // following interfaces:
@@ -45,8 +51,8 @@ type Object struct {
 	rvers int16
 	class string
 
-	rfuncs  []rfunc
-	marshal wfunc
+	rfuncs []rfunc
+	wfuncs []wfunc
 }
 
 func (obj *Object) Class() string {
@@ -74,35 +80,25 @@ func (obj *Object) UnmarshalROOT(r *rbytes.RBuffer) error {
 		return r.Err()
 	}
 
-	beg := r.Pos()
-	vers, pos, bcnt := r.ReadVersion(obj.Class())
-	if vers != obj.rvers {
-		r.SetErr(fmt.Errorf("rdict: inconsistent ROOT version (got=%d, want=%d)", vers, obj.rvers))
-		return r.Err()
-	}
-
-	rv := reflect.Indirect(reflect.ValueOf(obj.v))
-	for i, rfunc := range obj.rfuncs {
-		rf := rv.Field(i)
-		switch rf.Kind() {
-		case reflect.Array:
-			rf = rf.Slice(0, rf.Len())
-		default:
-			rf = rf.Addr()
-		}
-		recv := rf.Interface()
-		err := rfunc(recv, r)
-		if err != nil {
-			return err
-		}
+	if v, ok := obj.v.(rbytes.Unmarshaler); ok {
+		return v.UnmarshalROOT(r)
 	}
 
-	r.CheckByteCount(pos, bcnt, beg, obj.Class())
-	return r.Err()
+	rv := reflect.ValueOf(obj.v).Elem()
+	return readObject(r, rv, obj.class, obj.rvers, obj.rfuncs)
 }
 
 func (obj *Object) MarshalROOT(w *rbytes.WBuffer) (int, error) {
-	return obj.marshal(obj.v, w)
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	if v, ok := obj.v.(rbytes.Marshaler); ok {
+		return v.MarshalROOT(w)
+	}
+
+	rv := reflect.ValueOf(obj.v).Elem()
+	return writeObject(w, rv, obj.class, obj.rvers, obj.wfuncs)
 }
 
 func newObjectFrom(si rbytes.StreamerInfo, sictx rbytes.StreamerInfoContext) *Object {
@@ -114,17 +110,62 @@ func newObjectFrom(si rbytes.StreamerInfo, sictx rbytes.StreamerInfoContext) *Ob
 		rvers: int16(si.ClassVersion()),
 		class: si.Name(),
 	}
-	obj.rfuncs = genRStreamerFromSI(sictx, si, recv)
+	if _, ok := obj.v.(rbytes.Unmarshaler); !ok {
+		obj.rfuncs = genRStreamerFromSI(sictx, si)
+		obj.wfuncs = genWStreamerFromSI(sictx, si)
+	}
 	return obj
 }
 
+// readObject reads the version header and the members of an object of the
+// named class into rv.
+func readObject(r *rbytes.RBuffer, rv reflect.Value, class string, vers int16, funcs []rfunc) error {
+	beg := r.Pos()
+	v, pos, bcnt := r.ReadVersion(class)
+	if v != vers {
+		err := readEvolved(r, rv, class, v)
+		if err != nil {
+			return err
+		}
+		r.CheckByteCount(pos, bcnt, beg, class)
+		return r.Err()
+	}
+
+	for _, rfunc := range funcs {
+		err := rfunc(r, rv)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, class)
+	return r.Err()
+}
+
+// writeObject writes the version header and the members of an object of
+// the named class from rv.
+func writeObject(w *rbytes.WBuffer, rv reflect.Value, class string, vers int16, funcs []wfunc) (int, error) {
+	pos := w.WriteVersion(vers)
+	for _, wfunc := range funcs {
+		err := wfunc(w, rv)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return w.SetByteCount(pos, class)
+}
+
 func genTypeFromSI(sictx rbytes.StreamerInfoContext, si rbytes.StreamerInfo) reflect.Type {
 	if n := si.Name(); rtypes.Factory.HasKey(n) {
 		fct := rtypes.Factory.Get(n)
 		v := fct()
 		return v.Type().Elem()
 	}
+	return genStructFromSI(sictx, si)
+}
 
+// genStructFromSI returns a struct type with one field per element of si.
+func genStructFromSI(sictx rbytes.StreamerInfoContext, si rbytes.StreamerInfo) reflect.Type {
 	var fields = make([]reflect.StructField, 0, len(si.Elements()))
 	for _, se := range si.Elements() {
 		rt := genTypeFromSE(sictx, se)
@@ -143,22 +184,15 @@ func genTypeFromSI(sictx rbytes.StreamerInfoContext, si rbytes.StreamerInfo) ref
 }
 
 func genTypeFromSE(sictx rbytes.StreamerInfoContext, se rbytes.StreamerElement) reflect.Type {
-	if n := se.TypeName(); rtypes.Factory.HasKey(n) {
-		fct := rtypes.Factory.Get(se.TypeName())
-		v := fct()
-		return v.Elem().Type()
-	}
-
 	switch se := se.(type) {
 	default:
 		panic(fmt.Errorf("rdict: unknown streamer element: %#v (%T)", se, se))
 	case *StreamerBase:
-		si, err := sictx.StreamerInfo(se.Name(), -1)
-		if err != nil {
-			panic(err)
-		}
-		return genTypeFromSI(sictx, si)
+		return genTypeFromClass(sictx, se.Name())
 	case *StreamerBasicType:
+		if se.Type() == rmeta.Counter && se.Size() == 8 {
+			return gotypes[reflect.Int64]
+		}
 		return genType(sictx, se.Type(), se.ArrayLen())
 	case *StreamerString:
 		return genType(sictx, se.Type(), se.ArrayLen())
@@ -166,530 +200,1107 @@ func genTypeFromSE(sictx rbytes.StreamerInfoContext, se rbytes.StreamerElement)
 		return genType(sictx, se.Type(), -1)
 	case *StreamerSTLstring:
 		return gotypes[reflect.String]
-	case *StreamerObject:
-		si, err := sictx.StreamerInfo(se.TypeName(), -1)
-		if err != nil {
-			panic(err)
+	case *StreamerObject, *StreamerObjectAny:
+		rt := genTypeFromClass(sictx, se.TypeName())
+		if n := se.ArrayLen(); n > 0 {
+			rt = reflect.ArrayOf(n, rt)
 		}
-		return genTypeFromSI(sictx, si)
-	case *StreamerObjectAny:
-		si, err := sictx.StreamerInfo(se.TypeName(), -1)
-		if err != nil {
-			panic(err)
+		return rt
+	case *StreamerObjectPointer, *StreamerObjectAnyPointer:
+		name := strings.TrimSuffix(se.TypeName(), "*")
+		return reflect.PtrTo(genTypeFromClass(sictx, name))
+	case *StreamerLoop:
+		name := strings.TrimSuffix(se.TypeName(), "*")
+		return reflect.SliceOf(genTypeFromCxx(sictx, name))
+	case *StreamerSTL:
+		return genTypeFromCxx(sictx, se.TypeName())
+	}
+}
+
+// genTypeFromClass returns the Go type corresponding to the named C++ class.
+func genTypeFromClass(sictx rbytes.StreamerInfoContext, name string) reflect.Type {
+	if rtypes.Factory.HasKey(name) {
+		return rtypes.Factory.Get(name)().Type().Elem()
+	}
+	return genTypeFromSI(sictx, streamerOf(sictx, name))
+}
+
+// genTypeFromCxx returns the Go type corresponding to the named C++ type.
+// Sequence containers (std::vector, std::list, std::deque and std::multiset)
+// are mapped to slices, std::map<K,V> and std::unordered_map<K,V> to map[K]V,
+// std::set<T> and std::unordered_set<T> to map[T]struct{} and std::bitset<N>
+// to [N]bool.
+func genTypeFromCxx(sictx rbytes.StreamerInfoContext, name string) reflect.Type {
+	name = strings.Replace(strings.TrimSpace(name), "std::", "", -1)
+	if strings.HasSuffix(name, "*") {
+		return reflect.PtrTo(genTypeFromCxx(sictx, name[:len(name)-1]))
+	}
+	switch name {
+	case "string", "TString":
+		return gotypes[reflect.String]
+	}
+	if rt, ok := rmeta.CxxBuiltins[name]; ok {
+		return rt
+	}
+
+	switch stlTypeOf(name) {
+	case rmeta.STLvector, rmeta.STLlist, rmeta.STLdeque, rmeta.STLmultiset:
+		args := rmeta.CxxTemplateArgsOf(name)
+		return reflect.SliceOf(genTypeFromCxx(sictx, args[0]))
+	case rmeta.STLset, rmeta.STLunorderedset:
+		args := rmeta.CxxTemplateArgsOf(name)
+		return reflect.MapOf(genTypeFromCxx(sictx, args[0]), emptyType)
+	case rmeta.STLmap, rmeta.STLunorderedmap:
+		args := rmeta.CxxTemplateArgsOf(name)
+		if len(args) < 2 {
+			panic(fmt.Errorf(
+				"rdict: invalid std::map: got %d template arguments, want=2, for %q",
+				len(args), name,
+			))
 		}
-		return genTypeFromSI(sictx, si)
-	case *StreamerObjectPointer:
-		name := se.TypeName()
-		name = name[:len(name)-1] // drop final '*'
-		si, err := sictx.StreamerInfo(name, -1)
+		return reflect.MapOf(genTypeFromCxx(sictx, args[0]), genTypeFromCxx(sictx, args[1]))
+	case rmeta.STLbitset:
+		args := rmeta.CxxTemplateArgsOf(name)
+		n, err := strconv.Atoi(args[0])
 		if err != nil {
-			panic(err)
-		}
-		return reflect.PtrTo(genTypeFromSI(sictx, si))
-	case *StreamerSTL:
-		switch se.STLType() {
-		case rmeta.STLvector:
-			return genType(sictx, se.ContainedType(), -1)
-		case rmeta.STLmap:
-			types := rmeta.CxxTemplateArgsOf(se.TypeName())
-			if len(types) != 2 {
-				panic(fmt.Errorf(
-					"invalid std::map: got %d template arguments, want=2, for %q",
-					len(types), se.TypeName(),
-				))
-			}
-			var (
-				key reflect.Type
-				val reflect.Type
-			)
-			switch v, ok := rmeta.CxxBuiltins[types[0]]; {
-			case ok:
-				key = v
-			default:
-				sikey, err := sictx.StreamerInfo(types[0], -1)
-				if err != nil {
-					panic(fmt.Errorf(
-						"could not find key type %q for std::map %q: %w", types[0], se.TypeName(), err,
-					))
-				}
-				key = genTypeFromSI(sictx, sikey)
-			}
-			switch v, ok := rmeta.CxxBuiltins[types[1]]; {
-			case ok:
-				val = v
-			default:
-				sival, err := sictx.StreamerInfo(types[1], -1)
-				if err != nil {
-					panic(fmt.Errorf(
-						"could not find val type %q for std::map %q: %w", types[1], se.TypeName(), err,
-					))
-				}
-				val = genTypeFromSI(sictx, sival)
-			}
-			return reflect.MapOf(key, val)
+			panic(fmt.Errorf("rdict: invalid std::bitset size for %q: %w", name, err))
 		}
-		panic(fmt.Errorf("rdict: STL container not implemented: %#v", se))
+		return reflect.ArrayOf(n, gotypes[reflect.Bool])
+	case rmeta.NotSTL:
+		return genTypeFromClass(sictx, name)
 	}
+	panic(fmt.Errorf("rdict: STL container not implemented: %q", name))
 }
 
-func genRStreamerFromSI(sictx rbytes.StreamerInfoContext, si rbytes.StreamerInfo, recv reflect.Value) []rfunc {
-	if _, ok := recv.Interface().(rbytes.Unmarshaler); ok {
-		var funcs []rfunc
-		funcs = append(funcs, func(recv interface{}, r *rbytes.RBuffer) error {
-			return recv.(rbytes.Unmarshaler).UnmarshalROOT(r)
-		})
-		return funcs
+// stlTypeOf returns the kind of STL container named by the provided C++
+// type name.
+func stlTypeOf(name string) rmeta.ESTLType {
+	name = strings.Replace(strings.TrimSpace(name), "std::", "", -1)
+	i := strings.Index(name, "<")
+	if i < 0 || !strings.HasSuffix(name, ">") {
+		return rmeta.NotSTL
+	}
+	switch name[:i] {
+	case "vector":
+		return rmeta.STLvector
+	case "list":
+		return rmeta.STLlist
+	case "deque":
+		return rmeta.STLdeque
+	case "map":
+		return rmeta.STLmap
+	case "multimap":
+		return rmeta.STLmultimap
+	case "set":
+		return rmeta.STLset
+	case "multiset":
+		return rmeta.STLmultiset
+	case "bitset":
+		return rmeta.STLbitset
+	case "unordered_set":
+		return rmeta.STLunorderedset
+	case "unordered_multiset":
+		return rmeta.STLunorderedmultiset
+	case "unordered_map":
+		return rmeta.STLunorderedmap
+	case "unordered_multimap":
+		return rmeta.STLunorderedmultimap
 	}
+	return rmeta.NotSTL
+}
 
-	var funcs = make([]rfunc, 0, len(si.Elements()))
+// streamerOf returns the latest streamer for the named class.
+func streamerOf(sictx rbytes.StreamerInfoContext, name string) rbytes.StreamerInfo {
+	si, err := sictx.StreamerInfo(name, -1)
+	if err != nil {
+		panic(fmt.Errorf("rdict: could not find streamer for %q: %w", name, err))
+	}
+	return si
+}
 
+// counterOf returns the index of the element of si holding the size of
+// a variable-length array.
+func counterOf(si rbytes.StreamerInfo, name string) int {
+	for i, se := range si.Elements() {
+		if se.Name() == name {
+			return i
+		}
+	}
+	panic(fmt.Errorf("rdict: could not find counter %q in %q", name, si.Name()))
+}
+
+func genRStreamerFromSI(sictx rbytes.StreamerInfoContext, si rbytes.StreamerInfo) []rfunc {
+	var funcs = make([]rfunc, 0, len(si.Elements()))
 	for i, se := range si.Elements() {
-		sub := reflect.Indirect(recv).Field(i).Addr()
-		rfunc := genRStreamerFromSE(sictx, se, sub)
-		funcs = append(funcs, rfunc)
+		funcs = append(funcs, genRStreamerFromSE(sictx, si, i, se))
 	}
 	return funcs
 }
 
-func genRStreamerFromSE(sictx rbytes.StreamerInfoContext, se rbytes.StreamerElement, recv reflect.Value) rfunc {
-	if _, ok := recv.Interface().(rbytes.Unmarshaler); ok {
-		return func(recv interface{}, r *rbytes.RBuffer) error {
-			return recv.(rbytes.Unmarshaler).UnmarshalROOT(r)
+// genRStreamerFromSE returns the function reading the i-th element of si
+// into the struct value it is given.
+func genRStreamerFromSE(sictx rbytes.StreamerInfoContext, si rbytes.StreamerInfo, i int, se rbytes.StreamerElement) rfunc {
+	field := func(rfunc rfunc) rfunc {
+		return func(r *rbytes.RBuffer, recv reflect.Value) error {
+			return rfunc(r, recv.Field(i))
 		}
 	}
 
 	switch se := se.(type) {
 	default:
 		panic(fmt.Errorf("rdict: unknown read-streamer element: %#v (%T)", se, se))
+
 	case *StreamerBase:
-		typename := se.Name()
-		si, err := sictx.StreamerInfo(typename, -1)
-		if err != nil {
-			panic(err)
+		return field(robjectOf(sictx, se.Name()))
+
+	case *StreamerBasicType, *StreamerString:
+		return field(rbasicOf(se))
+
+	case *StreamerSTLstring:
+		return field(func(r *rbytes.RBuffer, rv reflect.Value) error {
+			rv.SetString(r.ReadSTLString())
+			return r.Err()
+		})
+
+	case *StreamerBasicPointer:
+		n := counterOf(si, se.CountName())
+		return func(r *rbytes.RBuffer, recv reflect.Value) error {
+			_ = r.ReadI8() // is-array
+			var (
+				rv = recv.Field(i)
+				sz = int(recv.Field(n).Int())
+			)
+			rv.Set(reflect.MakeSlice(rv.Type(), sz, sz))
+			readArray(r, rv, se)
+			return r.Err()
 		}
-		typevers := int16(si.ClassVersion())
-		fs := genRStreamerFromSI(sictx, si, recv)
-		return func(recv interface{}, r *rbytes.RBuffer) error {
-			rv := reflect.Indirect(reflect.ValueOf(recv))
-			beg := r.Pos()
-			vers, pos, bcnt := r.ReadVersion(typename)
-			if vers != typevers {
-				r.SetErr(fmt.Errorf("rdict: inconsistent ROOT version type=%q (got=%d, want=%d)", typename, vers, typevers))
-				return r.Err()
-			}
 
-			for i, ff := range fs {
-				rf := rv.Field(i)
-				switch rf.Kind() {
-				case reflect.Array:
-					rf = rf.Slice(0, rf.Len())
-				default:
-					rf = rf.Addr()
+	case *StreamerObject, *StreamerObjectAny:
+		return field(rarrayOf(se.ArrayLen(), robjectOf(sictx, se.TypeName())))
+
+	case *StreamerObjectPointer, *StreamerObjectAnyPointer:
+		switch se.Type() {
+		case rmeta.Objectp, rmeta.Anyp:
+			// non-nullable pointers (annotated with "->") are streamed in-place.
+			rfunc := robjectOf(sictx, strings.TrimSuffix(se.TypeName(), "*"))
+			return field(func(r *rbytes.RBuffer, rv reflect.Value) error {
+				if rv.IsNil() {
+					rv.Set(reflect.New(rv.Type().Elem()))
 				}
-				err := ff(rf.Interface(), r)
+				return rfunc(r, rv.Elem())
+			})
+		default:
+			return field(rpointer)
+		}
+
+	case *StreamerLoop:
+		var (
+			n     = counterOf(si, se.CountName())
+			tname = strings.TrimSuffix(se.TypeName(), "*")
+			efunc = relemOf(sictx, genTypeFromCxx(sictx, tname), tname)
+		)
+		return func(r *rbytes.RBuffer, recv reflect.Value) error {
+			beg := r.Pos()
+			_, pos, bcnt := r.ReadVersion("")
+			var (
+				rv = recv.Field(i)
+				sz = int(recv.Field(n).Int())
+			)
+			rv.Set(reflect.MakeSlice(rv.Type(), sz, sz))
+			for j := 0; j < sz; j++ {
+				err := efunc(r, rv.Index(j))
 				if err != nil {
 					return err
 				}
 			}
-
-			r.CheckByteCount(pos, bcnt, beg, typename)
+			r.CheckByteCount(pos, bcnt, beg, se.TypeName())
 			return r.Err()
 		}
-	case *StreamerBasicType:
-		return genRStreamer(sictx, se.Type(), se.ArrayLen(), recv)
-	case *StreamerString, *StreamerSTLstring:
-		return genRStreamer(sictx, se.Type(), se.ArrayLen(), recv)
-	case *StreamerBasicPointer:
-		return genRStreamer(sictx, se.Type(), -1, recv)
 
-	case *StreamerObjectAny:
-		typename := se.TypeName()
-		si, err := sictx.StreamerInfo(typename, -1)
-		if err != nil {
-			panic(err)
+	case *StreamerSTL:
+		rt := genTypeFromCxx(sictx, se.TypeName())
+		return field(rstlOf(sictx, rt, se.TypeName(), true))
+	}
+}
+
+// rbasicOf returns the function reading the builtin value, or fixed-size
+// array of builtin values, described by the provided streamer element.
+func rbasicOf(se rbytes.StreamerElement) rfunc {
+	if se.ArrayLen() > 0 {
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			readArray(r, rv.Slice(0, rv.Len()), se)
+			return r.Err()
 		}
-		typevers := int16(si.ClassVersion())
-		fs := genRStreamerFromSI(sictx, si, recv)
-		return func(recv interface{}, r *rbytes.RBuffer) error {
-			rv := reflect.Indirect(reflect.ValueOf(recv))
-			beg := r.Pos()
-			vers, pos, bcnt := r.ReadVersion(typename)
-			if vers != typevers {
-				r.SetErr(fmt.Errorf("rdict: inconsistent ROOT version type=%q (got=%d, want=%d)", typename, vers, typevers))
-				return r.Err()
+	}
+	return func(r *rbytes.RBuffer, rv reflect.Value) error {
+		readValue(r, rv, se)
+		return r.Err()
+	}
+}
+
+// rarrayOf returns the function reading n values with rfunc into a
+// fixed-size array, or rfunc itself when n is zero.
+func rarrayOf(n int, rfunc rfunc) rfunc {
+	if n == 0 {
+		return rfunc
+	}
+	return func(r *rbytes.RBuffer, rv reflect.Value) error {
+		for i := 0; i < rv.Len(); i++ {
+			err := rfunc(r, rv.Index(i))
+			if err != nil {
+				return err
 			}
+		}
+		return r.Err()
+	}
+}
 
-			for i, ff := range fs {
-				rf := rv.Field(i)
-				switch rf.Kind() {
-				case reflect.Array:
-					rf = rf.Slice(0, rf.Len())
-				default:
-					rf = rf.Addr()
-				}
-				err := ff(rf.Interface(), r)
+// robjectOf returns the function reading values of the named class.
+func robjectOf(sictx rbytes.StreamerInfoContext, class string) rfunc {
+	if rtypes.Factory.HasKey(class) {
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			return rv.Addr().Interface().(rbytes.Unmarshaler).UnmarshalROOT(r)
+		}
+	}
+
+	var (
+		si    = streamerOf(sictx, class)
+		vers  = int16(si.ClassVersion())
+		funcs = genRStreamerFromSI(sictx, si)
+	)
+	return func(r *rbytes.RBuffer, rv reflect.Value) error {
+		return readObject(r, rv, class, vers, funcs)
+	}
+}
+
+// rpointer reads a pointer to an object, streamed together with its class.
+func rpointer(r *rbytes.RBuffer, rv reflect.Value) error {
+	obj := r.ReadObjectAny()
+	if r.Err() != nil {
+		return r.Err()
+	}
+	if obj == nil {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	v := reflect.ValueOf(obj)
+	if o, ok := obj.(*Object); ok {
+		v = reflect.ValueOf(o.v)
+	}
+	if !v.Type().AssignableTo(rv.Type()) {
+		r.SetErr(fmt.Errorf("rdict: could not assign value of type %v to %v", v.Type(), rv.Type()))
+		return r.Err()
+	}
+	rv.Set(v)
+	return nil
+}
+
+// rstlOf returns the function reading STL containers named typename into
+// values of type rt.
+// Containers nested into other containers are read without a header.
+//
+// Containers of objects and std::map<K,V> are read object-wise or
+// member-wise, as indicated by their header.
+func rstlOf(sictx rbytes.StreamerInfoContext, rt reflect.Type, typename string, header bool) rfunc {
+	args := rmeta.CxxTemplateArgsOf(strings.Replace(typename, "std::", "", -1))
+
+	readHeader := func(r *rbytes.RBuffer) (vers int16, start int64, pos, bcnt int32) {
+		if !header {
+			return 0, 0, 0, 0
+		}
+		start = r.Pos()
+		vers, pos, bcnt = r.ReadVersion(typename)
+		return vers, start, pos, bcnt
+	}
+	checkHeader := func(r *rbytes.RBuffer, start int64, pos, bcnt int32) error {
+		if header {
+			r.CheckByteCount(pos, bcnt, start, typename)
+		}
+		return r.Err()
+	}
+	mbrwiseHeader := func(r *rbytes.RBuffer, vers int16) bool {
+		if vers&rbytes.StreamedMemberWise == 0 {
+			return false
+		}
+		// version of the contained class, followed by its checksum.
+		if v := r.ReadI16(); v <= 0 {
+			_ = r.ReadU32()
+		}
+		return true
+	}
+
+	switch {
+	case rt.Kind() == reflect.Slice:
+		var (
+			et    = rt.Elem()
+			efunc = relemOf(sictx, et, args[0])
+			mfunc func(r *rbytes.RBuffer, sli reflect.Value) error
+		)
+		if et.Kind() == reflect.Struct && !rtypes.Factory.HasKey(args[0]) {
+			mfunc = rmemberwiseOf(sictx, args[0])
+		}
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			vers, start, pos, bcnt := readHeader(r)
+			mbrwise := mbrwiseHeader(r, vers)
+			n := int(r.ReadI32())
+			rv.Set(reflect.MakeSlice(rt, n, n))
+			switch {
+			case mbrwise && mfunc == nil:
+				r.SetErr(fmt.Errorf("rdict: member-wise streaming of %q not supported", typename))
+				return r.Err()
+			case mbrwise:
+				err := mfunc(r, rv)
 				if err != nil {
 					return err
 				}
+			case isBuiltin(et):
+				readArray(r, rv, nil)
+			default:
+				for i := 0; i < n; i++ {
+					err := efunc(r, rv.Index(i))
+					if err != nil {
+						return err
+					}
+				}
 			}
-
-			r.CheckByteCount(pos, bcnt, beg, typename)
-			return r.Err()
+			return checkHeader(r, start, pos, bcnt)
 		}
 
-	case *StreamerObject:
-		typename := se.TypeName()
-		si, err := sictx.StreamerInfo(typename, -1)
-		if err != nil {
-			panic(err)
-		}
-		typevers := int16(si.ClassVersion())
-		fs := genRStreamerFromSI(sictx, si, recv)
-		return func(recv interface{}, r *rbytes.RBuffer) error {
-			rv := reflect.Indirect(reflect.ValueOf(recv))
-			beg := r.Pos()
-			vers, pos, bcnt := r.ReadVersion(typename)
-			if vers != typevers {
-				r.SetErr(fmt.Errorf("rdict: inconsistent ROOT version type=%q (got=%d, want=%d)", typename, vers, typevers))
+	case rt.Kind() == reflect.Array:
+		// std::bitset<N>
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			_, start, pos, bcnt := readHeader(r)
+			n := int(r.ReadI32())
+			if n != rv.Len() {
+				r.SetErr(fmt.Errorf("rdict: invalid %q size (got=%d, want=%d)", typename, n, rv.Len()))
 				return r.Err()
 			}
+			for i := 0; i < n; i++ {
+				rv.Index(i).SetBool(r.ReadBool())
+			}
+			return checkHeader(r, start, pos, bcnt)
+		}
 
-			for i, ff := range fs {
-				rf := rv.Field(i)
-				switch rf.Kind() {
-				case reflect.Array:
-					rf = rf.Slice(0, rf.Len())
-				default:
-					rf = rf.Addr()
-				}
-				err := ff(rf.Interface(), r)
+	case rt.Kind() == reflect.Map && rt.Elem() == emptyType:
+		var (
+			kfunc = relemOf(sictx, rt.Key(), args[0])
+			empty = reflect.New(rt.Elem()).Elem()
+		)
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			_, start, pos, bcnt := readHeader(r)
+			n := int(r.ReadI32())
+			rv.Set(reflect.MakeMapWithSize(rt, n))
+			for i := 0; i < n; i++ {
+				key := reflect.New(rt.Key()).Elem()
+				err := kfunc(r, key)
 				if err != nil {
 					return err
 				}
+				rv.SetMapIndex(key, empty)
 			}
-
-			r.CheckByteCount(pos, bcnt, beg, typename)
-			return r.Err()
+			return checkHeader(r, start, pos, bcnt)
 		}
 
-	case *StreamerObjectPointer:
-		// FIXME(sbinet): a TObject* or MyClass*, in C++/ROOT speak, usually means
-		// (or implies that) we are dealing with some amount of polymorphism.
-		// In Go this should be translated into some kind of interface.
-		typename := se.TypeName()
-		typename = typename[:len(typename)-1] // drop '*' suffix
-		si, err := sictx.StreamerInfo(typename, -1)
-		if err != nil {
-			panic(err)
-		}
-		typevers := int16(si.ClassVersion())
-		fs := genRStreamerFromSI(sictx, si, recv)
-		return func(recv interface{}, r *rbytes.RBuffer) error {
-			rv := reflect.Indirect(reflect.ValueOf(recv))
-			beg := r.Pos()
-			vers, pos, bcnt := r.ReadVersion(typename)
-			if vers != typevers {
-				r.SetErr(fmt.Errorf("rdict: inconsistent ROOT version type=%q (got=%d, want=%d)", typename, vers, typevers))
-				return r.Err()
+	case rt.Kind() == reflect.Map:
+		var (
+			kt    = reflect.SliceOf(rt.Key())
+			vt    = reflect.SliceOf(rt.Elem())
+			kfunc = relemOf(sictx, rt.Key(), args[0])
+			vfunc = relemOf(sictx, rt.Elem(), args[1])
+		)
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			vers, start, pos, bcnt := readHeader(r)
+			mbrwise := mbrwiseHeader(r, vers)
+			n := int(r.ReadI32())
+			keys := reflect.MakeSlice(kt, n, n)
+			vals := reflect.MakeSlice(vt, n, n)
+			switch {
+			case mbrwise:
+				err := readMembers(r, keys, kfunc)
+				if err != nil {
+					return err
+				}
+				err = readMembers(r, vals, vfunc)
+				if err != nil {
+					return err
+				}
+			default:
+				for i := 0; i < n; i++ {
+					err := kfunc(r, keys.Index(i))
+					if err != nil {
+						return err
+					}
+					err = vfunc(r, vals.Index(i))
+					if err != nil {
+						return err
+					}
+				}
+			}
+			rv.Set(reflect.MakeMapWithSize(rt, n))
+			for i := 0; i < n; i++ {
+				rv.SetMapIndex(keys.Index(i), vals.Index(i))
 			}
+			return checkHeader(r, start, pos, bcnt)
+		}
+	}
+
+	panic(fmt.Errorf("rdict: invalid STL container %q for type %v", typename, rt))
+}
 
-			for i, ff := range fs {
-				rf := rv.Field(i)
-				switch rf.Kind() {
-				case reflect.Array:
-					rf = rf.Slice(0, rf.Len())
-				default:
-					rf = rf.Addr()
+// rmemberwiseOf returns the function reading, member-wise, values of the
+// named class into the provided slice: the first member of all the values,
+// then the second member of all the values, etc...
+//
+// Members streamed with a custom streamer (std::string and STL containers of
+// builtins) share a single header for all the values.
+func rmemberwiseOf(sictx rbytes.StreamerInfoContext, class string) func(r *rbytes.RBuffer, sli reflect.Value) error {
+	var (
+		si    = streamerOf(sictx, class)
+		funcs = make([]func(r *rbytes.RBuffer, sli reflect.Value) error, len(si.Elements()))
+	)
+	for i, se := range si.Elements() {
+		var (
+			i     = i
+			tname = se.TypeName()
+			rfunc = genRStreamerFromSE(sictx, si, i, se)
+		)
+		if se.Type() == rmeta.Streamer {
+			switch se := se.(type) {
+			case *StreamerSTLstring:
+				rfunc = func(r *rbytes.RBuffer, recv reflect.Value) error {
+					recv.Field(i).SetString(r.ReadString())
+					return r.Err()
+				}
+			case *StreamerSTL:
+				rt := genTypeFromCxx(sictx, se.TypeName())
+				rstl := rstlOf(sictx, rt, se.TypeName(), false)
+				rfunc = func(r *rbytes.RBuffer, recv reflect.Value) error {
+					return rstl(r, recv.Field(i))
+				}
+			}
+			funcs[i] = func(r *rbytes.RBuffer, sli reflect.Value) error {
+				beg := r.Pos()
+				_, pos, bcnt := r.ReadVersion(tname)
+				for j := 0; j < sli.Len(); j++ {
+					err := rfunc(r, sli.Index(j))
+					if err != nil {
+						return err
+					}
 				}
-				err := ff(rf.Interface(), r)
+				r.CheckByteCount(pos, bcnt, beg, tname)
+				return r.Err()
+			}
+			continue
+		}
+		funcs[i] = func(r *rbytes.RBuffer, sli reflect.Value) error {
+			for j := 0; j < sli.Len(); j++ {
+				err := rfunc(r, sli.Index(j))
 				if err != nil {
 					return err
 				}
 			}
-
-			r.CheckByteCount(pos, bcnt, beg, typename)
 			return r.Err()
 		}
 	}
+
+	return func(r *rbytes.RBuffer, sli reflect.Value) error {
+		for _, f := range funcs {
+			err := f(r, sli)
+			if err != nil {
+				return err
+			}
+		}
+		return r.Err()
+	}
 }
 
-func genType(sictx rbytes.StreamerInfoContext, enum rmeta.Enum, n int) reflect.Type {
-	switch enum {
-	case rmeta.Bool:
-		return gotypes[reflect.Bool]
-	case rmeta.Uint8:
-		return gotypes[reflect.Uint8]
-	case rmeta.Uint16:
-		return gotypes[reflect.Uint16]
-	case rmeta.Uint32, rmeta.Bits:
-		return gotypes[reflect.Uint32]
-	case rmeta.Uint64:
-		return gotypes[reflect.Uint64]
-	case rmeta.Int8:
-		return gotypes[reflect.Int8]
-	case rmeta.Int16:
-		return gotypes[reflect.Int16]
-	case rmeta.Int32:
-		return gotypes[reflect.Int32]
-	case rmeta.Int64:
-		return gotypes[reflect.Int64]
-	case rmeta.Float32:
-		return gotypes[reflect.Float32]
-	case rmeta.Float64:
-		return gotypes[reflect.Float64]
-	case rmeta.TString, rmeta.STLstring:
-		return gotypes[reflect.String]
+// readMembers reads a block of members of std::pair<K,V> values into the
+// provided slice.
+// Strings are read as a std::string collection, with a header.
+func readMembers(r *rbytes.RBuffer, sli reflect.Value, efunc rfunc) error {
+	if sli.Len() == 0 {
+		return r.Err()
+	}
+	var (
+		start int64
+		pos   int32
+		bcnt  int32
+		isStr = sli.Type().Elem().Kind() == reflect.String
+	)
+	if isStr {
+		start = r.Pos()
+		_, pos, bcnt = r.ReadVersion("string")
+	}
+	for i := 0; i < sli.Len(); i++ {
+		err := efunc(r, sli.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+	if isStr {
+		r.CheckByteCount(pos, bcnt, start, "string")
+	}
+	return r.Err()
+}
 
-	case rmeta.Counter:
-		return gotypes[reflect.Int]
+// relemOf returns the function reading elements of STL containers (or of
+// variable-length arrays of objects), named ename, into values of type et.
+func relemOf(sictx rbytes.StreamerInfoContext, et reflect.Type, ename string) rfunc {
+	ename = strings.TrimSpace(ename)
+	switch et.Kind() {
+	case reflect.Ptr:
+		return rpointer
 
-	case rmeta.OffsetL + rmeta.Bool:
-		return reflect.ArrayOf(n, gotypes[reflect.Bool])
-	case rmeta.OffsetL + rmeta.Uint8:
-		return reflect.ArrayOf(n, gotypes[reflect.Uint8])
-	case rmeta.OffsetL + rmeta.Uint16:
-		return reflect.ArrayOf(n, gotypes[reflect.Uint16])
-	case rmeta.OffsetL + rmeta.Uint32:
-		return reflect.ArrayOf(n, gotypes[reflect.Uint32])
-	case rmeta.OffsetL + rmeta.Uint64:
-		return reflect.ArrayOf(n, gotypes[reflect.Uint64])
-	case rmeta.OffsetL + rmeta.Int8:
-		return reflect.ArrayOf(n, gotypes[reflect.Int8])
-	case rmeta.OffsetL + rmeta.Int16:
-		return reflect.ArrayOf(n, gotypes[reflect.Int16])
-	case rmeta.OffsetL + rmeta.Int32:
-		return reflect.ArrayOf(n, gotypes[reflect.Int32])
-	case rmeta.OffsetL + rmeta.Int64:
-		return reflect.ArrayOf(n, gotypes[reflect.Int64])
-	case rmeta.OffsetL + rmeta.Float32:
-		return reflect.ArrayOf(n, gotypes[reflect.Float32])
-	case rmeta.OffsetL + rmeta.Float64:
-		return reflect.ArrayOf(n, gotypes[reflect.Float64])
-	case rmeta.OffsetL + rmeta.TString, rmeta.OffsetL + rmeta.STLstring:
-		return reflect.ArrayOf(n, gotypes[reflect.String])
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return rstlOf(sictx, et, ename, false)
 
-	case rmeta.OffsetP + rmeta.Bool:
-		return reflect.SliceOf(gotypes[reflect.Bool])
-	case rmeta.OffsetP + rmeta.Uint8:
-		return reflect.SliceOf(gotypes[reflect.Uint8])
-	case rmeta.OffsetP + rmeta.Uint16:
-		return reflect.SliceOf(gotypes[reflect.Uint16])
-	case rmeta.OffsetP + rmeta.Uint32:
-		return reflect.SliceOf(gotypes[reflect.Uint32])
-	case rmeta.OffsetP + rmeta.Uint64:
-		return reflect.SliceOf(gotypes[reflect.Uint64])
-	case rmeta.OffsetP + rmeta.Int8:
-		return reflect.SliceOf(gotypes[reflect.Int8])
-	case rmeta.OffsetP + rmeta.Int16:
-		return reflect.SliceOf(gotypes[reflect.Int16])
-	case rmeta.OffsetP + rmeta.Int32:
-		return reflect.SliceOf(gotypes[reflect.Int32])
-	case rmeta.OffsetP + rmeta.Int64:
-		return reflect.SliceOf(gotypes[reflect.Int64])
-	case rmeta.OffsetP + rmeta.Float32:
-		return reflect.SliceOf(gotypes[reflect.Float32])
-	case rmeta.OffsetP + rmeta.Float64:
-		return reflect.SliceOf(gotypes[reflect.Float64])
+	case reflect.Struct:
+		return robjectOf(sictx, strings.Replace(ename, "std::", "", -1))
+	}
 
+	return func(r *rbytes.RBuffer, rv reflect.Value) error {
+		readValue(r, rv, nil)
+		return r.Err()
 	}
-	panic(fmt.Errorf("rmeta=%d (%v) not implemented (n=%v)", enum, enum, n))
 }
 
-func genRStreamer(sictx rbytes.StreamerInfoContext, enum rmeta.Enum, n int, recv reflect.Value) rfunc {
-	switch enum {
-	case rmeta.Bool:
-		return readBool
-	case rmeta.Uint8:
-		return readU8
-	case rmeta.Uint16:
-		return readU16
-	case rmeta.Uint32, rmeta.Bits:
-		return readU32
-	case rmeta.Uint64:
-		return readU64
-	case rmeta.Int8:
-		return readI8
-	case rmeta.Int16:
-		return readI16
-	case rmeta.Int32:
-		return readI32
-	case rmeta.Int64:
-		return readI64
-	case rmeta.Float32:
-		return readF32
-	case rmeta.Float64:
-		return readF64
-	case rmeta.TString, rmeta.STLstring:
-		return readStr
-
-	case rmeta.Counter:
-		return readInt
-
-	case rmeta.OffsetL + rmeta.Bool:
-		return readBools
-	case rmeta.OffsetL + rmeta.Uint8:
-		return readU8s
-	case rmeta.OffsetL + rmeta.Uint16:
-		return readU16s
-	case rmeta.OffsetL + rmeta.Uint32:
-		return readU32s
-	case rmeta.OffsetL + rmeta.Uint64:
-		return readU64s
-	case rmeta.OffsetL + rmeta.Int8:
-		return readI8s
-	case rmeta.OffsetL + rmeta.Int16:
-		return readI16s
-	case rmeta.OffsetL + rmeta.Int32:
-		return readI32s
-	case rmeta.OffsetL + rmeta.Int64:
-		return readI64s
-	case rmeta.OffsetL + rmeta.Float32:
-		return readF32s
-	case rmeta.OffsetL + rmeta.Float64:
-		return readF64s
-	case rmeta.OffsetL + rmeta.TString, rmeta.OffsetL + rmeta.STLstring:
-		return readStrs
+// readValue reads a builtin value into rv.
+// The streamer element, when provided, describes how Float16_t and
+// Double32_t values have been compressed.
+func readValue(r *rbytes.RBuffer, rv reflect.Value, se rbytes.StreamerElement) {
+	switch rv.Type() {
+	case float16Type:
+		rv.SetFloat(float64(r.ReadF16(se)))
+		return
+	case double32Type:
+		rv.SetFloat(float64(r.ReadD32(se)))
+		return
+	}
 
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(r.ReadBool())
+	case reflect.Int8:
+		rv.SetInt(int64(r.ReadI8()))
+	case reflect.Int16:
+		rv.SetInt(int64(r.ReadI16()))
+	case reflect.Int32:
+		rv.SetInt(int64(r.ReadI32()))
+	case reflect.Int64:
+		rv.SetInt(r.ReadI64())
+	case reflect.Uint8:
+		rv.SetUint(uint64(r.ReadU8()))
+	case reflect.Uint16:
+		rv.SetUint(uint64(r.ReadU16()))
+	case reflect.Uint32:
+		rv.SetUint(uint64(r.ReadU32()))
+	case reflect.Uint64:
+		rv.SetUint(r.ReadU64())
+	case reflect.Float32:
+		rv.SetFloat(float64(r.ReadF32()))
+	case reflect.Float64:
+		rv.SetFloat(r.ReadF64())
+	case reflect.String:
+		rv.SetString(r.ReadString())
+	default:
+		panic(fmt.Errorf("rdict: invalid builtin type %v", rv.Type()))
 	}
-	panic(fmt.Errorf("rdict: gen-rstreamer not implemented for rmeta=%v,n=%d", enum, n))
 }
 
-func readBool(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*bool)) = r.ReadBool()
-	return r.Err()
+// readArray reads a slice of builtin values.
+func readArray(r *rbytes.RBuffer, sli reflect.Value, se rbytes.StreamerElement) {
+	switch sli := sli.Interface().(type) {
+	case []bool:
+		r.ReadArrayBool(sli)
+	case []int8:
+		r.ReadArrayI8(sli)
+	case []int16:
+		r.ReadArrayI16(sli)
+	case []int32:
+		r.ReadArrayI32(sli)
+	case []int64:
+		r.ReadArrayI64(sli)
+	case []uint8:
+		r.ReadArrayU8(sli)
+	case []uint16:
+		r.ReadArrayU16(sli)
+	case []uint32:
+		r.ReadArrayU32(sli)
+	case []uint64:
+		r.ReadArrayU64(sli)
+	case []float32:
+		r.ReadArrayF32(sli)
+	case []float64:
+		r.ReadArrayF64(sli)
+	case []root.Float16:
+		r.ReadArrayF16(sli, se)
+	case []root.Double32:
+		r.ReadArrayD32(sli, se)
+	case []string:
+		r.ReadArrayString(sli)
+	default:
+		rv := reflect.ValueOf(sli)
+		for i := 0; i < rv.Len(); i++ {
+			readValue(r, rv.Index(i), se)
+		}
+	}
 }
 
-func readU8(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*uint8)) = r.ReadU8()
-	return r.Err()
+func genWStreamerFromSI(sictx rbytes.StreamerInfoContext, si rbytes.StreamerInfo) []wfunc {
+	var funcs = make([]wfunc, 0, len(si.Elements()))
+	for i, se := range si.Elements() {
+		funcs = append(funcs, genWStreamerFromSE(sictx, si, i, se))
+	}
+	return funcs
 }
 
-func readU16(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*uint16)) = r.ReadU16()
-	return r.Err()
-}
+// genWStreamerFromSE returns the function writing the i-th element of si
+// from the struct value it is given.
+func genWStreamerFromSE(sictx rbytes.StreamerInfoContext, si rbytes.StreamerInfo, i int, se rbytes.StreamerElement) wfunc {
+	field := func(wfunc wfunc) wfunc {
+		return func(w *rbytes.WBuffer, recv reflect.Value) error {
+			return wfunc(w, recv.Field(i))
+		}
+	}
 
-func readU32(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*uint32)) = r.ReadU32()
-	return r.Err()
-}
+	switch se := se.(type) {
+	default:
+		panic(fmt.Errorf("rdict: unknown write-streamer element: %#v (%T)", se, se))
 
-func readU64(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*uint64)) = r.ReadU64()
-	return r.Err()
-}
+	case *StreamerBase:
+		return field(wobjectOf(sictx, se.Name()))
 
-func readI8(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*int8)) = r.ReadI8()
-	return r.Err()
-}
+	case *StreamerBasicType, *StreamerString:
+		return field(wbasicOf(se))
 
-func readI16(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*int16)) = r.ReadI16()
-	return r.Err()
-}
+	case *StreamerSTLstring:
+		return field(func(w *rbytes.WBuffer, rv reflect.Value) error {
+			w.WriteSTLString(rv.String())
+			return w.Err()
+		})
 
-func readI32(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*int32)) = r.ReadI32()
-	return r.Err()
-}
+	case *StreamerBasicPointer:
+		n := counterOf(si, se.CountName())
+		return func(w *rbytes.WBuffer, recv reflect.Value) error {
+			var (
+				rv = recv.Field(i)
+				sz = int(recv.Field(n).Int())
+			)
+			if sz > rv.Len() {
+				return fmt.Errorf(
+					"rdict: invalid length for %s.%s (got=%d, want=%d)",
+					si.Name(), se.Name(), rv.Len(), sz,
+				)
+			}
+			w.WriteI8(1) // is-array
+			writeArray(w, rv.Slice(0, sz), se)
+			return w.Err()
+		}
 
-func readI64(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*int64)) = r.ReadI64()
-	return r.Err()
-}
+	case *StreamerObject, *StreamerObjectAny:
+		return field(warrayOf(se.ArrayLen(), wobjectOf(sictx, se.TypeName())))
+
+	case *StreamerObjectPointer, *StreamerObjectAnyPointer:
+		tname := strings.TrimSuffix(se.TypeName(), "*")
+		switch se.Type() {
+		case rmeta.Objectp, rmeta.Anyp:
+			// non-nullable pointers (annotated with "->") are streamed in-place.
+			wfunc := wobjectOf(sictx, tname)
+			return field(func(w *rbytes.WBuffer, rv reflect.Value) error {
+				if rv.IsNil() {
+					return wfunc(w, reflect.New(rv.Type().Elem()).Elem())
+				}
+				return wfunc(w, rv.Elem())
+			})
+		default:
+			return field(wpointerOf(sictx, tname))
+		}
 
-func readF32(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*float32)) = r.ReadF32()
-	return r.Err()
-}
+	case *StreamerLoop:
+		var (
+			n     = counterOf(si, se.CountName())
+			tname = strings.TrimSuffix(se.TypeName(), "*")
+			efunc = welemOf(sictx, genTypeFromCxx(sictx, tname), tname)
+		)
+		return func(w *rbytes.WBuffer, recv reflect.Value) error {
+			var (
+				rv = recv.Field(i)
+				sz = int(recv.Field(n).Int())
+			)
+			if sz > rv.Len() {
+				return fmt.Errorf(
+					"rdict: invalid length for %s.%s (got=%d, want=%d)",
+					si.Name(), se.Name(), rv.Len(), sz,
+				)
+			}
+			pos := w.WriteVersion(rvers.StreamerInfo)
+			for j := 0; j < sz; j++ {
+				err := efunc(w, rv.Index(j))
+				if err != nil {
+					return err
+				}
+			}
+			_, err := w.SetByteCount(pos, se.TypeName())
+			return err
+		}
 
-func readF64(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*float64)) = r.ReadF64()
-	return r.Err()
+	case *StreamerSTL:
+		rt := genTypeFromCxx(sictx, se.TypeName())
+		return field(wstlOf(sictx, rt, se.TypeName(), true))
+	}
 }
 
-func readStr(recv interface{}, r *rbytes.RBuffer) error {
-	*(recv.(*string)) = r.ReadString()
-	return r.Err()
+// wbasicOf returns the function writing the builtin value, or fixed-size
+// array of builtin values, described by the provided streamer element.
+func wbasicOf(se rbytes.StreamerElement) wfunc {
+	if se.ArrayLen() > 0 {
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			writeArray(w, rv.Slice(0, rv.Len()), se)
+			return w.Err()
+		}
+	}
+	return func(w *rbytes.WBuffer, rv reflect.Value) error {
+		writeValue(w, rv, se)
+		return w.Err()
+	}
 }
 
-func readInt(recv interface{}, r *rbytes.RBuffer) error {
-	panic("not implemented")
-	//	*(recv.(*int)) = int(r.ReadI64())
-	//	return r.Err()
+// warrayOf returns the function writing the n values of a fixed-size array
+// with wfunc, or wfunc itself when n is zero.
+func warrayOf(n int, wfunc wfunc) wfunc {
+	if n == 0 {
+		return wfunc
+	}
+	return func(w *rbytes.WBuffer, rv reflect.Value) error {
+		for i := 0; i < rv.Len(); i++ {
+			err := wfunc(w, rv.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		return w.Err()
+	}
 }
 
-func readBools(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]bool)
-	r.ReadArrayBool(slice)
-	return r.Err()
-}
+// wobjectOf returns the function writing values of the named class.
+func wobjectOf(sictx rbytes.StreamerInfoContext, class string) wfunc {
+	if rtypes.Factory.HasKey(class) {
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			if !rv.CanAddr() {
+				v := reflect.New(rv.Type()).Elem()
+				v.Set(rv)
+				rv = v
+			}
+			_, err := rv.Addr().Interface().(rbytes.Marshaler).MarshalROOT(w)
+			return err
+		}
+	}
 
-func readU8s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]uint8)
-	r.ReadArrayU8(slice)
-	return r.Err()
+	var (
+		si    = streamerOf(sictx, class)
+		vers  = int16(si.ClassVersion())
+		funcs = genWStreamerFromSI(sictx, si)
+	)
+	return func(w *rbytes.WBuffer, rv reflect.Value) error {
+		_, err := writeObject(w, rv, class, vers, funcs)
+		return err
+	}
 }
 
-func readU16s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]uint16)
-	r.ReadArrayU16(slice)
-	return r.Err()
-}
+// wpointerOf returns the function writing pointers to values of the named
+// class, together with their class.
+func wpointerOf(sictx rbytes.StreamerInfoContext, class string) wfunc {
+	if rtypes.Factory.HasKey(class) {
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			if rv.IsNil() {
+				return w.WriteObjectAny(nil)
+			}
+			return w.WriteObjectAny(rv.Interface().(root.Object))
+		}
+	}
 
-func readU32s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]uint32)
-	r.ReadArrayU32(slice)
-	return r.Err()
+	var (
+		si     = streamerOf(sictx, class)
+		vers   = int16(si.ClassVersion())
+		rfuncs = genRStreamerFromSI(sictx, si)
+		wfuncs = genWStreamerFromSI(sictx, si)
+	)
+	return func(w *rbytes.WBuffer, rv reflect.Value) error {
+		if rv.IsNil() {
+			return w.WriteObjectAny(nil)
+		}
+		obj := &Object{
+			v:      rv.Interface(),
+			si:     si,
+			rvers:  vers,
+			class:  class,
+			rfuncs: rfuncs,
+			wfuncs: wfuncs,
+		}
+		return w.WriteObjectAny(obj)
+	}
 }
 
-func readU64s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]uint64)
-	r.ReadArrayU64(slice)
-	return r.Err()
-}
+// wstlOf returns the function writing values of type rt as STL containers
+// named typename.
+// Containers nested into other containers are written without a header.
+// Containers are always written object-wise.
+func wstlOf(sictx rbytes.StreamerInfoContext, rt reflect.Type, typename string, header bool) wfunc {
+	args := rmeta.CxxTemplateArgsOf(strings.Replace(typename, "std::", "", -1))
 
-func readI8s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]int8)
-	r.ReadArrayI8(slice)
-	return r.Err()
+	writeHeader := func(w *rbytes.WBuffer) int64 {
+		if !header {
+			return 0
+		}
+		return w.WriteVersion(rvers.StreamerInfo)
+	}
+	setByteCount := func(w *rbytes.WBuffer, pos int64) error {
+		if !header {
+			return w.Err()
+		}
+		_, err := w.SetByteCount(pos, typename)
+		return err
+	}
+
+	switch {
+	case rt.Kind() == reflect.Slice:
+		var (
+			et    = rt.Elem()
+			efunc = welemOf(sictx, et, args[0])
+		)
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			pos := writeHeader(w)
+			w.WriteI32(int32(rv.Len()))
+			switch {
+			case isBuiltin(et):
+				writeArray(w, rv, nil)
+			default:
+				for i := 0; i < rv.Len(); i++ {
+					err := efunc(w, rv.Index(i))
+					if err != nil {
+						return err
+					}
+				}
+			}
+			return setByteCount(w, pos)
+		}
+
+	case rt.Kind() == reflect.Array:
+		// std::bitset<N>
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			pos := writeHeader(w)
+			w.WriteI32(int32(rv.Len()))
+			for i := 0; i < rv.Len(); i++ {
+				w.WriteBool(rv.Index(i).Bool())
+			}
+			return setByteCount(w, pos)
+		}
+
+	case rt.Kind() == reflect.Map && rt.Elem() == emptyType:
+		kfunc := welemOf(sictx, rt.Key(), args[0])
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			pos := writeHeader(w)
+			keys := sortedKeys(rv)
+			w.WriteI32(int32(len(keys)))
+			for _, key := range keys {
+				err := kfunc(w, key)
+				if err != nil {
+					return err
+				}
+			}
+			return setByteCount(w, pos)
+		}
+
+	case rt.Kind() == reflect.Map:
+		var (
+			kfunc = welemOf(sictx, rt.Key(), args[0])
+			vfunc = welemOf(sictx, rt.Elem(), args[1])
+		)
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			pos := writeHeader(w)
+			keys := sortedKeys(rv)
+			w.WriteI32(int32(len(keys)))
+			for _, key := range keys {
+				err := kfunc(w, key)
+				if err != nil {
+					return err
+				}
+				err = vfunc(w, rv.MapIndex(key))
+				if err != nil {
+					return err
+				}
+			}
+			return setByteCount(w, pos)
+		}
+	}
+
+	panic(fmt.Errorf("rdict: invalid STL container %q for type %v", typename, rt))
 }
 
-func readI16s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]int16)
-	r.ReadArrayI16(slice)
-	return r.Err()
+// welemOf returns the function writing elements of STL containers (or of
+// variable-length arrays of objects), named ename, from values of type et.
+func welemOf(sictx rbytes.StreamerInfoContext, et reflect.Type, ename string) wfunc {
+	ename = strings.Replace(strings.TrimSpace(ename), "std::", "", -1)
+	switch et.Kind() {
+	case reflect.Ptr:
+		return wpointerOf(sictx, strings.TrimSpace(strings.TrimSuffix(ename, "*")))
+
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return wstlOf(sictx, et, ename, false)
+
+	case reflect.Struct:
+		return wobjectOf(sictx, ename)
+	}
+
+	return func(w *rbytes.WBuffer, rv reflect.Value) error {
+		writeValue(w, rv, nil)
+		return w.Err()
+	}
 }
 
-func readI32s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]int32)
-	r.ReadArrayI32(slice)
-	return r.Err()
+// sortedKeys returns the keys of the provided map, sorted in increasing
+// order as in a std::map or a std::set.
+func sortedKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		ki, kj := keys[i], keys[j]
+		switch ki.Kind() {
+		case reflect.Bool:
+			return !ki.Bool() && kj.Bool()
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return ki.Int() < kj.Int()
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return ki.Uint() < kj.Uint()
+		case reflect.Float32, reflect.Float64:
+			return ki.Float() < kj.Float()
+		case reflect.String:
+			return ki.String() < kj.String()
+		}
+		panic(fmt.Errorf("rdict: invalid map key type %v", ki.Type()))
+	})
+	return keys
 }
 
-func readI64s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]int64)
-	r.ReadArrayI64(slice)
-	return r.Err()
+// writeValue writes the builtin value rv.
+// The streamer element, when provided, describes how Float16_t and
+// Double32_t values should be compressed.
+func writeValue(w *rbytes.WBuffer, rv reflect.Value, se rbytes.StreamerElement) {
+	switch rv.Type() {
+	case float16Type:
+		w.WriteF16(root.Float16(rv.Float()), se)
+		return
+	case double32Type:
+		w.WriteD32(root.Double32(rv.Float()), se)
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		w.WriteBool(rv.Bool())
+	case reflect.Int8:
+		w.WriteI8(int8(rv.Int()))
+	case reflect.Int16:
+		w.WriteI16(int16(rv.Int()))
+	case reflect.Int32:
+		w.WriteI32(int32(rv.Int()))
+	case reflect.Int64:
+		w.WriteI64(rv.Int())
+	case reflect.Uint8:
+		w.WriteU8(uint8(rv.Uint()))
+	case reflect.Uint16:
+		w.WriteU16(uint16(rv.Uint()))
+	case reflect.Uint32:
+		w.WriteU32(uint32(rv.Uint()))
+	case reflect.Uint64:
+		w.WriteU64(rv.Uint())
+	case reflect.Float32:
+		w.WriteF32(float32(rv.Float()))
+	case reflect.Float64:
+		w.WriteF64(rv.Float())
+	case reflect.String:
+		w.WriteString(rv.String())
+	default:
+		panic(fmt.Errorf("rdict: invalid builtin type %v", rv.Type()))
+	}
 }
 
-func readF32s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]float32)
-	r.ReadArrayF32(slice)
-	return r.Err()
+// writeArray writes a slice of builtin values.
+func writeArray(w *rbytes.WBuffer, sli reflect.Value, se rbytes.StreamerElement) {
+	switch sli := sli.Interface().(type) {
+	case []bool:
+		w.WriteFastArrayBool(sli)
+	case []int8:
+		w.WriteFastArrayI8(sli)
+	case []int16:
+		w.WriteFastArrayI16(sli)
+	case []int32:
+		w.WriteFastArrayI32(sli)
+	case []int64:
+		w.WriteFastArrayI64(sli)
+	case []uint8:
+		w.WriteFastArrayU8(sli)
+	case []uint16:
+		w.WriteFastArrayU16(sli)
+	case []uint32:
+		w.WriteFastArrayU32(sli)
+	case []uint64:
+		w.WriteFastArrayU64(sli)
+	case []float32:
+		w.WriteFastArrayF32(sli)
+	case []float64:
+		w.WriteFastArrayF64(sli)
+	case []root.Float16:
+		w.WriteFastArrayF16(sli, se)
+	case []root.Double32:
+		w.WriteFastArrayD32(sli, se)
+	case []string:
+		w.WriteFastArrayString(sli)
+	default:
+		rv := reflect.ValueOf(sli)
+		for i := 0; i < rv.Len(); i++ {
+			writeValue(w, rv.Index(i), se)
+		}
+	}
 }
 
-func readF64s(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]float64)
-	r.ReadArrayF64(slice)
-	return r.Err()
+// isBuiltin returns whether values of the provided type are streamed as
+// C++ builtins.
+func isBuiltin(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return true
+	}
+	return false
 }
 
-func readStrs(recv interface{}, r *rbytes.RBuffer) error {
-	slice := recv.([]string)
-	r.ReadArrayString(slice)
-	return r.Err()
+func genType(sictx rbytes.StreamerInfoContext, enum rmeta.Enum, n int) reflect.Type {
+	switch enum {
+	case rmeta.OffsetL + rmeta.TString, rmeta.OffsetL + rmeta.STLstring:
+		return reflect.ArrayOf(n, gotypes[reflect.String])
+	case rmeta.TString, rmeta.STLstring:
+		return gotypes[reflect.String]
+	case rmeta.Counter:
+		return gotypes[reflect.Int32]
+	case rmeta.Bits:
+		return gotypes[reflect.Uint32]
+	}
+
+	switch {
+	case enum > rmeta.OffsetL && enum < rmeta.OffsetP:
+		return reflect.ArrayOf(n, genType(sictx, enum-rmeta.OffsetL, -1))
+	case enum > rmeta.OffsetP && enum < rmeta.OffsetP+rmeta.OffsetL:
+		return reflect.SliceOf(genType(sictx, enum-rmeta.OffsetP, -1))
+	}
+
+	if rt, ok := builtins[enum]; ok {
+		return rt
+	}
+	panic(fmt.Errorf("rmeta=%d (%v) not implemented (n=%v)", enum, enum, n))
 }
 
 var (
@@ -709,6 +1320,29 @@ var (
 		reflect.Float64: reflect.TypeOf(float64(0)),
 		reflect.String:  reflect.TypeOf(""),
 	}
+
+	// builtins maps ROOT builtin enums to their Go type.
+	builtins = map[rmeta.Enum]reflect.Type{
+		rmeta.Bool:     gotypes[reflect.Bool],
+		rmeta.Uint8:    gotypes[reflect.Uint8],
+		rmeta.Uint16:   gotypes[reflect.Uint16],
+		rmeta.Uint32:   gotypes[reflect.Uint32],
+		rmeta.Uint64:   gotypes[reflect.Uint64],
+		rmeta.ULong64:  gotypes[reflect.Uint64],
+		rmeta.Int8:     gotypes[reflect.Int8],
+		rmeta.Int16:    gotypes[reflect.Int16],
+		rmeta.Int32:    gotypes[reflect.Int32],
+		rmeta.Int64:    gotypes[reflect.Int64],
+		rmeta.Long64:   gotypes[reflect.Int64],
+		rmeta.Float32:  gotypes[reflect.Float32],
+		rmeta.Float64:  gotypes[reflect.Float64],
+		rmeta.Float16:  float16Type,
+		rmeta.Double32: double32Type,
+	}
+
+	emptyType    = reflect.TypeOf(struct{}{})
+	float16Type  = reflect.TypeOf(root.Float16(0))
+	double32Type = reflect.TypeOf(root.Double32(0))
 )
 
 var (
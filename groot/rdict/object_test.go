@@ -0,0 +1,343 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdict
+
+import (
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rmeta"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+var (
+	testP3SI = NewCxxStreamerInfo("rdict::test::P3", 1, 0x1, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Px", ""),
+			Type:  rmeta.Int32,
+			Size:  4,
+			EName: "int",
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Py", ""),
+			Type:  rmeta.Float64,
+			Size:  8,
+			EName: "double",
+		}.New()},
+		&StreamerSTLstring{*NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("Name", ""),
+			Type:  rmeta.Streamer,
+			Size:  32,
+			EName: "string",
+		}.New(), rmeta.ESTLType(rmeta.STLstring), rmeta.STLstring)},
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("Vs", ""),
+			Type:  rmeta.Streamer,
+			Size:  24,
+			EName: "vector<double>",
+		}.New(), rmeta.STLvector, rmeta.Double),
+	})
+
+	testPairSI = NewCxxStreamerInfo("pair<int,double>", 1, 0x2, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("first", ""),
+			Type:  rmeta.Int32,
+			Size:  4,
+			EName: "int",
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("second", ""),
+			Type:  rmeta.Float64,
+			Size:  8,
+			EName: "double",
+		}.New()},
+	})
+
+	testEventSI = NewCxxStreamerInfo("rdict::test::Event", 2, 0x3, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("N", ""),
+			Type:  rmeta.Counter,
+			Size:  4,
+			EName: "int",
+		}.New()},
+		NewStreamerBasicPointer(Element{
+			Name:  *rbase.NewNamed("Arr", "[N]"),
+			Type:  rmeta.OffsetP + rmeta.Float64,
+			Size:  8,
+			EName: "double*",
+		}.New(), 2, "N", "rdict::test::Event"),
+		NewStreamerLoop(Element{
+			Name:  *rbase.NewNamed("Loop", "[N]"),
+			Type:  rmeta.StreamLoop,
+			Size:  8,
+			EName: "rdict::test::P3*",
+		}.New(), 2, "N", "rdict::test::Event"),
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("VecVecI32", ""),
+			Type:  rmeta.Streamer,
+			Size:  24,
+			EName: "vector<vector<int> >",
+		}.New(), rmeta.STLvector, rmeta.Object),
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("VecP3", ""),
+			Type:  rmeta.STL,
+			Size:  24,
+			EName: "vector<rdict::test::P3>",
+		}.New(), rmeta.STLvector, rmeta.Object),
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("VecPtrP3", ""),
+			Type:  rmeta.STL,
+			Size:  24,
+			EName: "vector<rdict::test::P3*>",
+		}.New(), rmeta.STLvector, rmeta.Objectp),
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("MapI32Str", ""),
+			Type:  rmeta.STL,
+			Size:  48,
+			EName: "map<int,string>",
+		}.New(), rmeta.STLmap, rmeta.Object),
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("MapStrVecF64", ""),
+			Type:  rmeta.STL,
+			Size:  48,
+			EName: "map<string,vector<double> >",
+		}.New(), rmeta.STLmap, rmeta.Object),
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("SetI32", ""),
+			Type:  rmeta.Streamer,
+			Size:  48,
+			EName: "set<int>",
+		}.New(), rmeta.STLset, rmeta.Int32),
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("Bits", ""),
+			Type:  rmeta.Streamer,
+			Size:  8,
+			EName: "bitset<8>",
+		}.New(), rmeta.STLbitset, rmeta.Bool),
+		&StreamerObjectAny{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Pair", ""),
+			Type:  rmeta.Any,
+			Size:  16,
+			EName: "pair<int,double>",
+		}.New()},
+		&StreamerObjectAnyPointer{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Ptr", ""),
+			Type:  rmeta.AnyP,
+			Size:  8,
+			EName: "rdict::test::P3*",
+		}.New()},
+		&StreamerObjectAnyPointer{StreamerElement: Element{
+			Name:  *rbase.NewNamed("NilPtr", ""),
+			Type:  rmeta.AnyP,
+			Size:  8,
+			EName: "rdict::test::P3*",
+		}.New()},
+		&StreamerObjectAnyPointer{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Ref", "->"),
+			Type:  rmeta.Anyp,
+			Size:  8,
+			EName: "rdict::test::P3*",
+		}.New()},
+	})
+
+	testMbrWiseSI = NewCxxStreamerInfo("rdict::test::MbrWise", 1, 0x4, []rbytes.StreamerElement{
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("VecP3", ""),
+			Type:  rmeta.STL,
+			Size:  24,
+			EName: "vector<rdict::test::P3>",
+		}.New(), rmeta.STLvector, rmeta.Object),
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("Map", ""),
+			Type:  rmeta.STL,
+			Size:  48,
+			EName: "map<string,int>",
+		}.New(), rmeta.STLmap, rmeta.Object),
+	})
+)
+
+func init() {
+	StreamerInfos.Add(testP3SI)
+	StreamerInfos.Add(testPairSI)
+	StreamerInfos.Add(testEventSI)
+	StreamerInfos.Add(testMbrWiseSI)
+}
+
+func TestObjectSTL(t *testing.T) {
+	want := ObjectFrom(testEventSI, StreamerInfos)
+
+	var (
+		rv  = reflect.ValueOf(want.v).Elem()
+		p3t = rv.FieldByName("ROOT_Ref").Type().Elem()
+		set = func(name string, v interface{}) {
+			rv.FieldByName("ROOT_" + name).Set(reflect.ValueOf(v))
+		}
+		newP3 = func(i int) reflect.Value {
+			p3 := reflect.New(p3t).Elem()
+			p3.Field(0).SetInt(int64(i))
+			p3.Field(1).SetFloat(float64(i) + 0.5)
+			p3.Field(2).SetString(string(rune('a' + i)))
+			p3.Field(3).Set(reflect.ValueOf([]float64{float64(i), float64(2 * i)}))
+			return p3
+		}
+		p3s = func(n int) reflect.Value {
+			sli := reflect.MakeSlice(reflect.SliceOf(p3t), n, n)
+			for i := 0; i < n; i++ {
+				sli.Index(i).Set(newP3(i + 1))
+			}
+			return sli
+		}
+	)
+
+	set("N", int32(3))
+	set("Arr", []float64{1, 2, 3})
+	rv.FieldByName("ROOT_Loop").Set(p3s(3))
+	set("VecVecI32", [][]int32{{1}, {}, {2, 3}})
+	rv.FieldByName("ROOT_VecP3").Set(p3s(2))
+	{
+		ptrs := reflect.MakeSlice(reflect.SliceOf(reflect.PtrTo(p3t)), 2, 2)
+		ptrs.Index(0).Set(newP3(4).Addr())
+		ptrs.Index(1).Set(newP3(5).Addr())
+		rv.FieldByName("ROOT_VecPtrP3").Set(ptrs)
+	}
+	set("MapI32Str", map[int32]string{1: "one", 2: "two", 3: "three"})
+	set("MapStrVecF64", map[string][]float64{"a": {1}, "b": {2, 3}, "c": nil})
+	set("SetI32", map[int32]struct{}{1: {}, 4: {}, 9: {}})
+	set("Bits", [8]bool{true, false, true, true})
+	{
+		pair := rv.FieldByName("ROOT_Pair")
+		pair.Field(0).SetInt(42)
+		pair.Field(1).SetFloat(66.6)
+	}
+	rv.FieldByName("ROOT_Ptr").Set(newP3(6).Addr())
+	rv.FieldByName("ROOT_Ref").Set(newP3(7).Addr())
+
+	wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+	_, err := want.MarshalROOT(wbuf)
+	if err != nil {
+		t.Fatalf("could not marshal object: %+v", err)
+	}
+
+	got := ObjectFrom(testEventSI, StreamerInfos)
+	rbuf := rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, StreamerInfos)
+	err = got.UnmarshalROOT(rbuf)
+	if err != nil {
+		t.Fatalf("could not unmarshal object: %+v", err)
+	}
+
+	if got, want := rbuf.Len(), int64(0); got != want {
+		t.Fatalf("invalid number of remaining bytes: got=%d, want=%d", got, want)
+	}
+
+	// empty and nil slices are both read back as empty slices.
+	reflect.ValueOf(want.v).Elem().FieldByName("ROOT_MapStrVecF64").Set(
+		reflect.ValueOf(map[string][]float64{"a": {1}, "b": {2, 3}, "c": {}}),
+	)
+
+	if !reflect.DeepEqual(got.v, want.v) {
+		t.Fatalf("invalid round-trip:\ngot= %+v\nwant=%+v", got.v, want.v)
+	}
+}
+
+func TestObjectSTLMemberWise(t *testing.T) {
+	obj := ObjectFrom(testMbrWiseSI, StreamerInfos)
+	r := rbytes.NewRBuffer(testMbrWiseData(t), nil, 0, StreamerInfos)
+	err := obj.UnmarshalROOT(r)
+	if err != nil {
+		t.Fatalf("could not unmarshal object: %+v", err)
+	}
+
+	var (
+		rv  = reflect.ValueOf(obj.v).Elem()
+		p3s = rv.FieldByName("ROOT_VecP3")
+	)
+	if got, want := p3s.Len(), 2; got != want {
+		t.Fatalf("invalid number of P3s: got=%d, want=%d", got, want)
+	}
+	for i, want := range []struct {
+		px   int32
+		py   float64
+		name string
+		vs   []float64
+	}{
+		{1, 1.5, "a", []float64{1}},
+		{2, 2.5, "b", []float64{2, 3}},
+	} {
+		p3 := p3s.Index(i)
+		if got := int32(p3.Field(0).Int()); got != want.px {
+			t.Fatalf("p3[%d]: invalid Px: got=%v, want=%v", i, got, want.px)
+		}
+		if got := p3.Field(1).Float(); got != want.py {
+			t.Fatalf("p3[%d]: invalid Py: got=%v, want=%v", i, got, want.py)
+		}
+		if got := p3.Field(2).String(); got != want.name {
+			t.Fatalf("p3[%d]: invalid Name: got=%q, want=%q", i, got, want.name)
+		}
+		if got := p3.Field(3).Interface().([]float64); !reflect.DeepEqual(got, want.vs) {
+			t.Fatalf("p3[%d]: invalid Vs: got=%v, want=%v", i, got, want.vs)
+		}
+	}
+
+	got := rv.FieldByName("ROOT_Map").Interface().(map[string]int32)
+	if want := map[string]int32{"one": 1, "two": 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid map: got=%v, want=%v", got, want)
+	}
+}
+
+// testMbrWiseData returns a rdict::test::MbrWise object, with its STL
+// collections of objects streamed member-wise, as ROOT does by default.
+func testMbrWiseData(t *testing.T) []byte {
+	t.Helper()
+
+	w := rbytes.NewWBuffer(nil, nil, 0, nil)
+	beg := w.WriteVersion(1)
+	{
+		pos := w.WriteVersion(rvers.StreamerInfo | rbytes.StreamedMemberWise)
+		w.WriteI16(int16(testP3SI.ClassVersion()))
+		w.WriteI32(2)
+		w.WriteI32(1) // Px
+		w.WriteI32(2)
+		w.WriteF64(1.5) // Py
+		w.WriteF64(2.5)
+		{
+			pos := w.WriteVersion(rvers.StreamerInfo) // Name
+			w.WriteString("a")
+			w.WriteString("b")
+			_, _ = w.SetByteCount(pos, "string")
+		}
+		{
+			pos := w.WriteVersion(rvers.StreamerInfo) // Vs
+			w.WriteI32(1)
+			w.WriteF64(1)
+			w.WriteI32(2)
+			w.WriteF64(2)
+			w.WriteF64(3)
+			_, _ = w.SetByteCount(pos, "vector<double>")
+		}
+		_, _ = w.SetByteCount(pos, "vector<rdict::test::P3>")
+	}
+	{
+		pos := w.WriteVersion(rvers.StreamerInfo | rbytes.StreamedMemberWise)
+		w.WriteI16(0) // pair<string,int> is a foreign class
+		w.WriteU32(0xdeadbeef)
+		w.WriteI32(2)
+		{
+			pos := w.WriteVersion(rvers.StreamerInfo)
+			w.WriteString("one")
+			w.WriteString("two")
+			_, _ = w.SetByteCount(pos, "string")
+		}
+		w.WriteI32(1)
+		w.WriteI32(2)
+		_, _ = w.SetByteCount(pos, "map<string,int>")
+	}
+	_, err := w.SetByteCount(beg, testMbrWiseSI.Name())
+	if err != nil {
+		t.Fatalf("could not create input buffer: %+v", err)
+	}
+	return w.Bytes()
+}
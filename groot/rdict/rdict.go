@@ -846,6 +846,12 @@ func (tss *StreamerSTL) ElemTypeName() []string {
 		return parseStdVector(tss.ename)
 	case rmeta.STLmap:
 		return parseStdMap(tss.ename)
+	case rmeta.STLlist, rmeta.STLdeque, rmeta.STLforwardlist,
+		rmeta.STLset, rmeta.STLmultiset,
+		rmeta.STLunorderedset, rmeta.STLunorderedmultiset,
+		rmeta.STLmultimap, rmeta.STLunorderedmap, rmeta.STLunorderedmultimap,
+		rmeta.STLbitset:
+		return rmeta.CxxTemplateArgsOf(tss.ename)
 	default:
 		panic("not implemented")
 	}
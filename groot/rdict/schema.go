@@ -0,0 +1,460 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdict
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go-hep.org/x/hep/groot/rbytes"
+)
+
+// ReadRule describes how in-memory members of a class should be computed
+// from the on-file members of another version of that class.
+// ReadRule is the equivalent of ROOT's "#pragma read" directive:
+//
+//  #pragma read sourceClass="P3" version="[1-2]" source="float px; float py" \
+//               target="r" code="{ r = sqrt(onfile.px*onfile.px + onfile.py*onfile.py); }"
+//
+// Members listed as targets of a rule are not automatically converted from
+// the on-file members with the same name.
+type ReadRule struct {
+	Class    string   // name of the class the rule applies to.
+	Versions string   // on-file versions the rule applies to (e.g. "[1-3,5,7-]"). Empty means all versions.
+	Source   []string // names of the on-file members needed by the rule.
+	Target   []string // names of the in-memory members set by the rule.
+
+	// Func computes the target members of obj, a pointer to the in-memory
+	// object, from the values of the on-file source members.
+	Func func(onfile map[string]interface{}, obj interface{}) error
+
+	match func(vers int16) bool
+}
+
+// AddReadRule registers a schema evolution rule.
+func AddReadRule(rule ReadRule) error {
+	if rule.Class == "" {
+		return fmt.Errorf("rdict: read rule with no class")
+	}
+	if rule.Func == nil {
+		return fmt.Errorf("rdict: read rule for %q with no function", rule.Class)
+	}
+
+	match, err := parseVersions(rule.Versions)
+	if err != nil {
+		return fmt.Errorf("rdict: invalid read rule for %q: %w", rule.Class, err)
+	}
+	rule.match = match
+
+	readRules.Lock()
+	defer readRules.Unlock()
+	readRules.db[rule.Class] = append(readRules.db[rule.Class], rule)
+	return nil
+}
+
+var readRules = struct {
+	sync.RWMutex
+	db map[string][]ReadRule
+}{
+	db: make(map[string][]ReadRule),
+}
+
+// readRulesFor returns the rules applying to the on-file version vers of
+// the named class.
+func readRulesFor(class string, vers int16) []ReadRule {
+	readRules.RLock()
+	defer readRules.RUnlock()
+
+	var rules []ReadRule
+	for _, rule := range readRules.db[class] {
+		if rule.match(vers) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// parseVersions parses a ROOT versions range, such as "[1-3,5,7-]".
+func parseVersions(str string) (func(vers int16) bool, error) {
+	str = strings.TrimSpace(str)
+	if str == "" || str == "*" {
+		return func(int16) bool { return true }, nil
+	}
+	if !strings.HasPrefix(str, "[") || !strings.HasSuffix(str, "]") {
+		return nil, fmt.Errorf("invalid versions range %q", str)
+	}
+
+	type span struct{ beg, end int16 }
+	var (
+		spans []span
+		atoi  = func(s string, def int16) (int16, error) {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				return def, nil
+			}
+			v, err := strconv.ParseInt(s, 10, 16)
+			return int16(v), err
+		}
+	)
+	for _, tok := range strings.Split(str[1:len(str)-1], ",") {
+		var (
+			beg, end int16
+			err      error
+		)
+		switch i := strings.Index(tok, "-"); {
+		case i < 0:
+			beg, err = atoi(tok, 0)
+			end = beg
+		default:
+			beg, err = atoi(tok[:i], -1<<15)
+			if err == nil {
+				end, err = atoi(tok[i+1:], 1<<15-1)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid versions range %q: %w", str, err)
+		}
+		spans = append(spans, span{beg, end})
+	}
+
+	return func(vers int16) bool {
+		for _, s := range spans {
+			if s.beg <= vers && vers <= s.end {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// ReadEvolved reads the members of an object of the named class, streamed
+// with the on-file version vers, into ptr.
+// The on-file members are matched to the in-memory ones by name, converting
+// numeric types as needed. On-file members that have been removed are
+// skipped, in-memory members that have been added are set to their zero
+// value and registered read rules are applied.
+//
+// ReadEvolved expects the version header of the object to have already been
+// read from the buffer.
+func ReadEvolved(r *rbytes.RBuffer, ptr interface{}, class string, vers int16) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		err := fmt.Errorf("rdict: invalid receiver type %T for class %q", ptr, class)
+		r.SetErr(err)
+		return err
+	}
+	return readEvolved(r, rv.Elem(), class, vers)
+}
+
+func readEvolved(r *rbytes.RBuffer, rv reflect.Value, class string, vers int16) error {
+	evo, err := evolutionOf(r, rv.Type(), class, vers)
+	if err != nil {
+		r.SetErr(err)
+		return err
+	}
+
+	onfile := reflect.New(evo.rt).Elem()
+	for _, rfunc := range evo.rfuncs {
+		err := rfunc(r, onfile)
+		if err != nil {
+			err = fmt.Errorf("rdict: could not read %q (version=%d): %w", class, vers, err)
+			r.SetErr(err)
+			return err
+		}
+	}
+
+	rules := readRulesFor(class, vers)
+	skip := make(map[string]bool)
+	for _, rule := range rules {
+		for _, name := range rule.Target {
+			skip[memberKey(name)] = true
+		}
+	}
+
+	for i, j := range evo.fields {
+		if j == unexported || skip[evo.keys[i]] {
+			continue
+		}
+		dst := rv.Field(i)
+		if j < 0 {
+			dst.Set(reflect.Zero(dst.Type()))
+			continue
+		}
+		err := convertValue(dst, onfile.Field(j))
+		if err != nil {
+			err = fmt.Errorf("rdict: could not convert member %q of %q (version=%d): %w",
+				evo.keys[i], class, vers, err,
+			)
+			r.SetErr(err)
+			return err
+		}
+	}
+
+	for _, rule := range rules {
+		src := make(map[string]interface{}, len(rule.Source))
+		for _, name := range rule.Source {
+			j, ok := evo.index[memberKey(name)]
+			if !ok {
+				err := fmt.Errorf("rdict: read rule for %q (version=%d): no on-file member %q", class, vers, name)
+				r.SetErr(err)
+				return err
+			}
+			src[name] = onfile.Field(j).Interface()
+		}
+		err := rule.Func(src, rv.Addr().Interface())
+		if err != nil {
+			err = fmt.Errorf("rdict: could not apply read rule for %q (version=%d): %w", class, vers, err)
+			r.SetErr(err)
+			return err
+		}
+	}
+
+	return r.Err()
+}
+
+// evolution describes how to read an on-file version of a class into an
+// in-memory type.
+type evolution struct {
+	rt     reflect.Type   // on-file type
+	rfuncs []rfunc        // on-file streamer
+	index  map[string]int // on-file member index, by member key
+	keys   []string       // in-memory member keys
+	fields []int          // on-file member index for each in-memory member, or -1
+}
+
+// unexported marks in-memory members that can not be set.
+const unexported = -2
+
+type evolutionKey struct {
+	class  string
+	vers   int16
+	chksum int
+	rt     reflect.Type
+}
+
+var evolutions = struct {
+	sync.RWMutex
+	db map[evolutionKey]*evolution
+}{
+	db: make(map[evolutionKey]*evolution),
+}
+
+func evolutionOf(r *rbytes.RBuffer, rt reflect.Type, class string, vers int16) (*evolution, error) {
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rdict: invalid in-memory type %v for class %q", rt, class)
+	}
+
+	sictx := onfileContext{r}
+	si, err := sictx.StreamerInfo(class, int(vers))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"rdict: inconsistent ROOT version type=%q (got=%d): %w", class, vers, err,
+		)
+	}
+
+	key := evolutionKey{class: class, vers: vers, chksum: si.CheckSum(), rt: rt}
+	evolutions.RLock()
+	evo, ok := evolutions.db[key]
+	evolutions.RUnlock()
+	if ok {
+		return evo, nil
+	}
+
+	evo = &evolution{
+		rt:     genStructFromSI(sictx, si),
+		rfuncs: genRStreamerFromSI(sictx, si),
+		index:  make(map[string]int, len(si.Elements())),
+		keys:   make([]string, rt.NumField()),
+		fields: make([]int, rt.NumField()),
+	}
+	for i := 0; i < evo.rt.NumField(); i++ {
+		evo.index[fieldKey(evo.rt.Field(i))] = i
+	}
+	for i := range evo.fields {
+		field := rt.Field(i)
+		evo.keys[i] = fieldKey(field)
+		j, ok := evo.index[evo.keys[i]]
+		switch {
+		case field.PkgPath != "":
+			j = unexported
+		case !ok:
+			j = -1
+		}
+		evo.fields[i] = j
+	}
+
+	evolutions.Lock()
+	evolutions.db[key] = evo
+	evolutions.Unlock()
+
+	return evo, nil
+}
+
+// onfileContext looks up streamers from the file being read, and then from
+// the streamers known at runtime.
+type onfileContext struct {
+	r *rbytes.RBuffer
+}
+
+func (ctx onfileContext) StreamerInfo(name string, vers int) (rbytes.StreamerInfo, error) {
+	si, err := ctx.r.StreamerInfo(name, vers)
+	if err == nil && (vers < 0 || si.ClassVersion() == vers) {
+		return si, nil
+	}
+	return StreamerInfos.StreamerInfo(name, vers)
+}
+
+// memberKey returns the key used to match on-file and in-memory members.
+func memberKey(name string) string {
+	return cxxNameSanitizer.Replace(name)
+}
+
+// fieldKey returns the member key of the provided struct field.
+// Fields of types generated from a StreamerInfo are named after their
+// member, other fields are matched through their "groot" struct tag, or
+// their name.
+func fieldKey(field reflect.StructField) string {
+	if strings.HasPrefix(field.Name, "ROOT_") {
+		return field.Name[len("ROOT_"):]
+	}
+	name := field.Tag.Get("groot")
+	if i := strings.IndexAny(name, ",["); i >= 0 {
+		name = name[:i]
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return memberKey(name)
+}
+
+// convertValue sets dst from the on-file value src, converting between
+// numeric types and matching members of structs by name.
+func convertValue(dst, src reflect.Value) error {
+	dt, st := dst.Type(), src.Type()
+	if dt == st {
+		dst.Set(src)
+		return nil
+	}
+
+	switch {
+	case isNumber(dt.Kind()) && isNumber(st.Kind()):
+		dst.Set(src.Convert(dt))
+		return nil
+	case dt.Kind() == reflect.Bool && isNumber(st.Kind()):
+		dst.SetBool(src.Convert(reflect.TypeOf(float64(0))).Float() != 0)
+		return nil
+	case isNumber(dt.Kind()) && st.Kind() == reflect.Bool:
+		v := 0
+		if src.Bool() {
+			v = 1
+		}
+		dst.Set(reflect.ValueOf(v).Convert(dt))
+		return nil
+	case dt.Kind() != st.Kind():
+		return fmt.Errorf("incompatible types (on-file=%v, in-memory=%v)", st, dt)
+	}
+
+	switch dt.Kind() {
+	case reflect.String:
+		dst.SetString(src.String())
+
+	case reflect.Array:
+		for i := 0; i < dst.Len(); i++ {
+			if i >= src.Len() {
+				dst.Index(i).Set(reflect.Zero(dt.Elem()))
+				continue
+			}
+			err := convertValue(dst.Index(i), src.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dt))
+			return nil
+		}
+		dst.Set(reflect.MakeSlice(dt, src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			err := convertValue(dst.Index(i), src.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dt))
+			return nil
+		}
+		dst.Set(reflect.MakeMapWithSize(dt, src.Len()))
+		for _, k := range src.MapKeys() {
+			var (
+				kk = reflect.New(dt.Key()).Elem()
+				vv = reflect.New(dt.Elem()).Elem()
+			)
+			err := convertValue(kk, k)
+			if err != nil {
+				return err
+			}
+			err = convertValue(vv, src.MapIndex(k))
+			if err != nil {
+				return err
+			}
+			dst.SetMapIndex(kk, vv)
+		}
+
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dt))
+			return nil
+		}
+		dst.Set(reflect.New(dt.Elem()))
+		return convertValue(dst.Elem(), src.Elem())
+
+	case reflect.Struct:
+		index := make(map[string]int, st.NumField())
+		for i := 0; i < st.NumField(); i++ {
+			index[fieldKey(st.Field(i))] = i
+		}
+		for i := 0; i < dt.NumField(); i++ {
+			if dt.Field(i).PkgPath != "" {
+				continue
+			}
+			j, ok := index[fieldKey(dt.Field(i))]
+			if !ok {
+				dst.Field(i).Set(reflect.Zero(dt.Field(i).Type))
+				continue
+			}
+			err := convertValue(dst.Field(i), src.Field(j))
+			if err != nil {
+				return err
+			}
+		}
+
+	default:
+		return fmt.Errorf("incompatible types (on-file=%v, in-memory=%v)", st, dt)
+	}
+
+	return nil
+}
+
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
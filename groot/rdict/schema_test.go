@@ -0,0 +1,265 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rdict
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rmeta"
+)
+
+var (
+	testEvoV1SI = NewCxxStreamerInfo("rdict::test::Evo", 1, 0x11, []rbytes.StreamerElement{
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Px", ""),
+			Type:  rmeta.Float32,
+			Size:  4,
+			EName: "float",
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Py", ""),
+			Type:  rmeta.Float32,
+			Size:  4,
+			EName: "float",
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Old", "removed in v2"),
+			Type:  rmeta.Int32,
+			Size:  4,
+			EName: "int",
+		}.New()},
+		&StreamerSTLstring{*NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("Name", ""),
+			Type:  rmeta.Streamer,
+			Size:  32,
+			EName: "string",
+		}.New(), rmeta.ESTLType(rmeta.STLstring), rmeta.STLstring)},
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("Vs", ""),
+			Type:  rmeta.Streamer,
+			Size:  24,
+			EName: "vector<int>",
+		}.New(), rmeta.STLvector, rmeta.Int32),
+	})
+
+	testEvoV2SI = NewCxxStreamerInfo("rdict::test::Evo", 2, 0x12, []rbytes.StreamerElement{
+		&StreamerSTLstring{*NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("Name", ""),
+			Type:  rmeta.Streamer,
+			Size:  32,
+			EName: "string",
+		}.New(), rmeta.ESTLType(rmeta.STLstring), rmeta.STLstring)},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("Px", ""),
+			Type:  rmeta.Float64,
+			Size:  8,
+			EName: "double",
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("R", "computed from px and py"),
+			Type:  rmeta.Float64,
+			Size:  8,
+			EName: "double",
+		}.New()},
+		&StreamerBasicType{StreamerElement: Element{
+			Name:  *rbase.NewNamed("New", "added in v2"),
+			Type:  rmeta.Int64,
+			Size:  8,
+			EName: "long",
+		}.New()},
+		NewCxxStreamerSTL(Element{
+			Name:  *rbase.NewNamed("Vs", ""),
+			Type:  rmeta.Streamer,
+			Size:  24,
+			EName: "vector<double>",
+		}.New(), rmeta.STLvector, rmeta.Float64),
+	})
+)
+
+func init() {
+	StreamerInfos.Add(testEvoV1SI)
+	StreamerInfos.Add(testEvoV2SI)
+
+	err := AddReadRule(ReadRule{
+		Class:    "rdict::test::Evo",
+		Versions: "[-1]",
+		Source:   []string{"Px", "Py"},
+		Target:   []string{"R"},
+		Func: func(onfile map[string]interface{}, obj interface{}) error {
+			var (
+				px = float64(onfile["Px"].(float32))
+				py = float64(onfile["Py"].(float32))
+				r  = math.Hypot(px, py)
+			)
+			switch obj := obj.(type) {
+			case *testEvo:
+				obj.R = r
+			default:
+				reflect.ValueOf(obj).Elem().FieldByName("ROOT_R").SetFloat(r)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// testEvo is the in-memory version of rdict::test::Evo, as generated
+// by root-gen-type.
+type testEvo struct {
+	Name string    `groot:"Name"`
+	Px   float64   `groot:"Px"`
+	R    float64   `groot:"R"`
+	New  int64     `groot:"New"`
+	Vs   []float64 `groot:"Vs"`
+}
+
+func testEvoV1Data(t *testing.T) []byte {
+	t.Helper()
+
+	obj := ObjectFrom(testEvoV1SI, StreamerInfos)
+	rv := reflect.ValueOf(obj.v).Elem()
+	rv.FieldByName("ROOT_Px").SetFloat(3)
+	rv.FieldByName("ROOT_Py").SetFloat(4)
+	rv.FieldByName("ROOT_Old").SetInt(42)
+	rv.FieldByName("ROOT_Name").SetString("evo")
+	rv.FieldByName("ROOT_Vs").Set(reflect.ValueOf([]int32{1, 2, 3}))
+
+	w := rbytes.NewWBuffer(nil, nil, 0, nil)
+	_, err := obj.MarshalROOT(w)
+	if err != nil {
+		t.Fatalf("could not marshal v1 object: %+v", err)
+	}
+	return w.Bytes()
+}
+
+func TestSchemaEvolutionObject(t *testing.T) {
+	obj := ObjectFrom(testEvoV2SI, StreamerInfos)
+	rv := reflect.ValueOf(obj.v).Elem()
+	rv.FieldByName("ROOT_New").SetInt(-1) // must be reset.
+
+	r := rbytes.NewRBuffer(testEvoV1Data(t), nil, 0, StreamerInfos)
+	err := obj.UnmarshalROOT(r)
+	if err != nil {
+		t.Fatalf("could not unmarshal v1 object: %+v", err)
+	}
+	if got, want := r.Len(), int64(0); got != want {
+		t.Fatalf("invalid number of remaining bytes: got=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		want interface{}
+	}{
+		{"Name", "evo"},
+		{"Px", 3.0},
+		{"R", 5.0},
+		{"New", int64(0)},
+		{"Vs", []float64{1, 2, 3}},
+	} {
+		got := rv.FieldByName("ROOT_" + tc.name).Interface()
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("invalid member %q: got=%v, want=%v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSchemaEvolutionGoType(t *testing.T) {
+	var (
+		got  = testEvo{New: -1}
+		want = testEvo{Name: "evo", Px: 3, R: 5, Vs: []float64{1, 2, 3}}
+	)
+
+	r := rbytes.NewRBuffer(testEvoV1Data(t), nil, 0, StreamerInfos)
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion("rdict::test::Evo")
+	if vers != 1 {
+		t.Fatalf("invalid on-file version: got=%d, want=1", vers)
+	}
+	err := ReadEvolved(r, &got, "rdict::test::Evo", vers)
+	if err != nil {
+		t.Fatalf("could not read v1 object: %+v", err)
+	}
+	r.CheckByteCount(pos, bcnt, beg, "rdict::test::Evo")
+	if err := r.Err(); err != nil {
+		t.Fatalf("invalid byte count: %+v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid evolved object:\ngot= %+v\nwant=%+v", got, want)
+	}
+}
+
+func TestSchemaEvolutionNoStreamer(t *testing.T) {
+	var v testEvo
+
+	w := rbytes.NewWBuffer(nil, nil, 0, nil)
+	pos := w.WriteVersion(42)
+	w.WriteF64(1)
+	_, _ = w.SetByteCount(pos, "rdict::test::Evo")
+
+	r := rbytes.NewRBuffer(w.Bytes(), nil, 0, StreamerInfos)
+	vers, _, _ := r.ReadVersion("rdict::test::Evo")
+	err := ReadEvolved(r, &v, "rdict::test::Evo", vers)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestSchemaEvolutionInvalidReceiver(t *testing.T) {
+	r := rbytes.NewRBuffer(testEvoV1Data(t), nil, 0, StreamerInfos)
+	vers, _, _ := r.ReadVersion("rdict::test::Evo")
+	err := ReadEvolved(r, testEvo{}, "rdict::test::Evo", vers)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if r.Err() != err {
+		t.Fatalf("error not stored in buffer: got=%v, want=%v", r.Err(), err)
+	}
+}
+
+func TestParseVersions(t *testing.T) {
+	for _, tc := range []struct {
+		str  string
+		ok   []int16
+		nok  []int16
+		fail bool
+	}{
+		{str: "", ok: []int16{-1, 0, 1, 42}},
+		{str: "[1]", ok: []int16{1}, nok: []int16{0, 2}},
+		{str: "[1-3,5,7-]", ok: []int16{1, 2, 3, 5, 7, 100}, nok: []int16{0, 4, 6}},
+		{str: "[-2]", ok: []int16{-1, 0, 1, 2}, nok: []int16{3}},
+		{str: "1-3", fail: true},
+		{str: "[a-3]", fail: true},
+	} {
+		t.Run(tc.str, func(t *testing.T) {
+			match, err := parseVersions(tc.str)
+			if tc.fail {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("could not parse versions: %+v", err)
+			}
+			for _, v := range tc.ok {
+				if !match(v) {
+					t.Fatalf("version %d should match", v)
+				}
+			}
+			for _, v := range tc.nok {
+				if match(v) {
+					t.Fatalf("version %d should not match", v)
+				}
+			}
+		})
+	}
+}
@@ -118,22 +118,13 @@ func (v *visitor) visitSE(depth int, se rbytes.StreamerElement) error {
 		switch se.STLType() {
 		case rmeta.STLdeque, rmeta.STLforwardlist, rmeta.STLlist,
 			rmeta.STLset, rmeta.STLunorderedset, rmeta.STLunorderedmultiset,
-			rmeta.STLvector:
-
-			etn := se.ElemTypeName()
-			tname := strings.TrimRight(etn[0], "*")
-			if _, ok := rmeta.CxxBuiltins[tname]; ok {
-				// no-op: C++ builtin.
-				return nil
-			}
-			si, err := v.ctx.StreamerInfo(tname, -1)
-			if err != nil {
-				return fmt.Errorf("could not find std::container<T> element %q: %w", tname, err)
-			}
-			return v.run(depth+1, si)
+			rmeta.STLvector,
+			rmeta.STLmap, rmeta.STLmultimap,
+			rmeta.STLunorderedmap, rmeta.STLunorderedmultimap:
+			return v.visitType(depth, se.TypeName())
 
 		default:
-			return fmt.Errorf("rdict: cant visit non-vector-like STL streamers %#v", se)
+			return fmt.Errorf("rdict: cant visit STL streamers %#v", se)
 		}
 
 	default:
@@ -142,3 +133,54 @@ func (v *visitor) visitSE(depth int, se rbytes.StreamerElement) error {
 
 	return nil
 }
+
+// visitType visits the streamer of the provided C++ type.
+// STL containers are visited through the types they contain.
+func (v *visitor) visitType(depth int, tname string) error {
+	tname = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(tname), "*"))
+	tname = strings.Replace(tname, "std::", "", -1)
+	if _, ok := rmeta.CxxBuiltins[tname]; ok {
+		// no-op: C++ builtin.
+		return nil
+	}
+	if tname == "TString" {
+		// no-op: TString has no streamer.
+		return nil
+	}
+
+	var (
+		i     = strings.Index(tname, "<")
+		cont  string
+		targs []string
+	)
+	if i > 0 && strings.HasSuffix(tname, ">") {
+		cont = tname[:i]
+		targs = rmeta.CxxTemplateArgsOf(tname)
+	}
+
+	switch cont {
+	case "vector", "list", "deque", "forward_list",
+		"set", "multiset", "unordered_set", "unordered_multiset":
+		return v.visitType(depth, targs[0])
+
+	case "map", "multimap", "unordered_map", "unordered_multimap":
+		// the std::pair<K,V> streamer is only present for non-builtin pairs.
+		pair := "pair<" + strings.Join(targs[:2], ",") + ">"
+		if si, err := v.ctx.StreamerInfo(pair, -1); err == nil {
+			return v.run(depth+1, si)
+		}
+		for _, targ := range targs[:2] {
+			err := v.visitType(depth, targ)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	si, err := v.ctx.StreamerInfo(tname, -1)
+	if err != nil {
+		return fmt.Errorf("could not find std::container<T> element %q: %w", tname, err)
+	}
+	return v.run(depth+1, si)
+}
@@ -7,6 +7,7 @@ package rhist
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rbytes"
@@ -99,6 +100,42 @@ func (a *taxis) BinWidth(i int) float64 {
 	return a.xbins.Data[i] - a.xbins.Data[i-1]
 }
 
+// findBin returns the bin number corresponding to x.
+// Underflows (resp. overflows) are mapped to bin 0 (resp. nbins+1).
+func (a *taxis) findBin(x float64) int {
+	switch {
+	case x < a.xmin:
+		return 0
+	case !(x < a.xmax):
+		return a.nbins + 1
+	}
+	if len(a.xbins.Data) == 0 {
+		return 1 + int(float64(a.nbins)*(x-a.xmin)/(a.xmax-a.xmin))
+	}
+	return sort.Search(len(a.xbins.Data), func(i int) bool {
+		return a.xbins.Data[i] > x
+	})
+}
+
+// sameBinning returns whether both axes share the same binning.
+func (a *taxis) sameBinning(o *taxis) bool {
+	if a.nbins != o.nbins || a.xmin != o.xmin || a.xmax != o.xmax {
+		return false
+	}
+	if len(a.xbins.Data) == 0 || len(o.xbins.Data) == 0 {
+		return true
+	}
+	if len(a.xbins.Data) != len(o.xbins.Data) {
+		return false
+	}
+	for i, v := range a.xbins.Data {
+		if v != o.xbins.Data[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (a *taxis) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
 		return 0, w.Err()
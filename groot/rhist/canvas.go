@@ -0,0 +1,483 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// virtualPad implements ROOT TVirtualPad
+type virtualPad struct {
+	obj     rbase.Object
+	attline rbase.AttLine
+	attfill rbase.AttFill
+	attpad  rbase.AttPad
+	// TQObject is not streamed.
+}
+
+func (*virtualPad) RVersion() int16 {
+	return rvers.VirtualPad
+}
+
+func (*virtualPad) Class() string {
+	return "TVirtualPad"
+}
+
+func (vp *virtualPad) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(vp.RVersion())
+	for _, v := range []rbytes.Marshaler{
+		&vp.obj,
+		&vp.attline,
+		&vp.attfill,
+		&vp.attpad,
+	} {
+		if _, err := v.MarshalROOT(w); err != nil {
+			return 0, err
+		}
+	}
+
+	return w.SetByteCount(pos, vp.Class())
+}
+
+func (vp *virtualPad) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	_, pos, bcnt := r.ReadVersion(vp.Class())
+
+	for _, v := range []rbytes.Unmarshaler{
+		&vp.obj,
+		&vp.attline,
+		&vp.attfill,
+		&vp.attpad,
+	} {
+		if err := v.UnmarshalROOT(r); err != nil {
+			return err
+		}
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, vp.Class())
+	return r.Err()
+}
+
+// Pad implements ROOT TPad
+type Pad struct {
+	vpad virtualPad
+	// TAttBBox2D is not streamed.
+
+	x1, y1, x2, y2 float64     // world coordinates of the pad
+	pix            [18]float64 // world/pixel conversion factors
+	xlowNDC        float64     // x of the lower left corner of the pad, in NDC
+	ylowNDC        float64     // y of the lower left corner of the pad, in NDC
+	xupNDC         float64     // x of the upper right corner of the pad, in NDC
+	yupNDC         float64     // y of the upper right corner of the pad, in NDC
+	wNDC           float64     // width of the pad, in NDC
+	hNDC           float64     // height of the pad, in NDC
+	absXlowNDC     float64     // absolute x of the lower left corner of the pad, in NDC
+	absYlowNDC     float64     // absolute y of the lower left corner of the pad, in NDC
+	absWNDC        float64     // absolute width of the pad, in NDC
+	absHNDC        float64     // absolute height of the pad, in NDC
+	uxmin          float64     // minimum value on the x axis
+	uymin          float64     // minimum value on the y axis
+	uxmax          float64     // maximum value on the x axis
+	uymax          float64     // maximum value on the y axis
+	theta          float64     // latitude of the 3D view
+	phi            float64     // longitude of the 3D view
+	aspectRatio    float64     // ratio of w/h in case of fixed ratio
+	number         int32       // pad number identifier
+	tickx          int32       // tick marks along x
+	ticky          int32       // tick marks along y
+	logx           int32       // log scale along x
+	logy           int32       // log scale along y
+	logz           int32       // log scale along z
+	padPaint       int32       // whether the pad is being painted
+	crosshair      int32       // crosshair type
+	crosshairPos   int32       // position of the crosshair
+	borderSize     int16       // pad border size in pixels
+	borderMode     int16       // pad border mode
+	modified       bool        // whether the pad has been modified
+	gridx          bool        // grid along x
+	gridy          bool        // grid along y
+	absCoord       bool        // use absolute coordinates
+	editable       bool        // whether the pad is editable
+	fixedAspect    bool        // whether the aspect ratio is fixed
+	prims          rcont.List  // list of primitives
+	execs          *rcont.List // list of commands to be executed when a pad event occurs
+	name           string      // pad name
+	title          string      // pad title
+	numPalColor    int32       // number of objects with an automatic color
+	nextPalColor   int32       // next automatic color
+}
+
+func newPad() *Pad {
+	return &Pad{
+		vpad: virtualPad{
+			obj:     *rbase.NewObject(),
+			attline: *rbase.NewAttLine(),
+			attfill: *rbase.NewAttFill(),
+			attpad:  *rbase.NewAttPad(),
+		},
+		x2:         1,
+		y2:         1,
+		xupNDC:     1,
+		yupNDC:     1,
+		wNDC:       1,
+		hNDC:       1,
+		absWNDC:    1,
+		absHNDC:    1,
+		uxmax:      1,
+		uymax:      1,
+		theta:      30,
+		phi:        30,
+		borderSize: 2,
+		editable:   true,
+		prims:      *rcont.NewList("", nil),
+	}
+}
+
+// NewPad creates a new pad holding the provided primitives.
+func NewPad(name, title string, primitives ...root.Object) *Pad {
+	p := newPad()
+	p.name = name
+	p.title = title
+	p.prims = *rcont.NewList("", primitives)
+	return p
+}
+
+func (*Pad) RVersion() int16 {
+	return rvers.Pad
+}
+
+func (*Pad) Class() string {
+	return "TPad"
+}
+
+// Name returns the name of the pad.
+func (p *Pad) Name() string {
+	return p.name
+}
+
+// Title returns the title of the pad.
+func (p *Pad) Title() string {
+	return p.title
+}
+
+// Primitives returns the list of primitives drawn in this pad.
+// Sub-pads and canvases appear as *Pad values in that list.
+func (p *Pad) Primitives() root.List {
+	return &p.prims
+}
+
+func (p *Pad) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(p.RVersion())
+	if _, err := p.vpad.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	for _, v := range []float64{p.x1, p.y1, p.x2, p.y2} {
+		w.WriteF64(v)
+	}
+	w.WriteFastArrayF64(p.pix[:])
+	for _, v := range []float64{
+		p.xlowNDC, p.ylowNDC, p.xupNDC, p.yupNDC,
+		p.wNDC, p.hNDC,
+		p.absXlowNDC, p.absYlowNDC, p.absWNDC, p.absHNDC,
+		p.uxmin, p.uymin, p.uxmax, p.uymax,
+		p.theta, p.phi, p.aspectRatio,
+	} {
+		w.WriteF64(v)
+	}
+	for _, v := range []int32{
+		p.number, p.tickx, p.ticky,
+		p.logx, p.logy, p.logz,
+		p.padPaint, p.crosshair, p.crosshairPos,
+	} {
+		w.WriteI32(v)
+	}
+	w.WriteI16(p.borderSize)
+	w.WriteI16(p.borderMode)
+	for _, v := range []bool{
+		p.modified, p.gridx, p.gridy,
+		p.absCoord, p.editable, p.fixedAspect,
+	} {
+		w.WriteBool(v)
+	}
+
+	if _, err := p.prims.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	var execs root.Object
+	if p.execs != nil {
+		execs = p.execs
+	}
+	if err := w.WriteObjectAny(execs); err != nil {
+		return 0, err
+	}
+
+	w.WriteString(p.name)
+	w.WriteString(p.title)
+	w.WriteI32(p.numPalColor)
+	w.WriteI32(p.nextPalColor)
+
+	return w.SetByteCount(pos, p.Class())
+}
+
+func (p *Pad) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(p.Class())
+	const minVers = 13
+	if vers < minVers {
+		return fmt.Errorf("rhist: TPad version too old (%d<%d)", vers, minVers)
+	}
+
+	if err := p.vpad.UnmarshalROOT(r); err != nil {
+		return err
+	}
+
+	for _, v := range []*float64{&p.x1, &p.y1, &p.x2, &p.y2} {
+		*v = r.ReadF64()
+	}
+	r.ReadArrayF64(p.pix[:])
+	for _, v := range []*float64{
+		&p.xlowNDC, &p.ylowNDC, &p.xupNDC, &p.yupNDC,
+		&p.wNDC, &p.hNDC,
+		&p.absXlowNDC, &p.absYlowNDC, &p.absWNDC, &p.absHNDC,
+		&p.uxmin, &p.uymin, &p.uxmax, &p.uymax,
+		&p.theta, &p.phi, &p.aspectRatio,
+	} {
+		*v = r.ReadF64()
+	}
+	for _, v := range []*int32{
+		&p.number, &p.tickx, &p.ticky,
+		&p.logx, &p.logy, &p.logz,
+		&p.padPaint, &p.crosshair, &p.crosshairPos,
+	} {
+		*v = r.ReadI32()
+	}
+	p.borderSize = r.ReadI16()
+	p.borderMode = r.ReadI16()
+	for _, v := range []*bool{
+		&p.modified, &p.gridx, &p.gridy,
+		&p.absCoord, &p.editable, &p.fixedAspect,
+	} {
+		*v = r.ReadBool()
+	}
+
+	if err := p.prims.UnmarshalROOT(r); err != nil {
+		return err
+	}
+
+	p.execs = nil
+	if obj := r.ReadObjectAny(); obj != nil {
+		p.execs = obj.(*rcont.List)
+	}
+
+	p.name = r.ReadString()
+	p.title = r.ReadString()
+	p.numPalColor = r.ReadI32()
+	p.nextPalColor = r.ReadI32()
+
+	r.CheckByteCount(pos, bcnt, beg, p.Class())
+	return r.Err()
+}
+
+// Canvas implements ROOT TCanvas
+type Canvas struct {
+	Pad
+
+	display      string          // name of destination screen
+	doubleBuffer int32           // double buffer flag (0=off, 1=on)
+	retained     bool            // retain structure flag
+	xsizeUser    float32         // user specified size of canvas along x, in cm
+	ysizeUser    float32         // user specified size of canvas along y, in cm
+	xsizeReal    float32         // current size of canvas along x, in cm
+	ysizeReal    float32         // current size of canvas along y, in cm
+	winTopX      int32           // top x position of window, in pixels
+	winTopY      int32           // top y position of window, in pixels
+	winWidth     uint32          // width of window, including borders
+	winHeight    uint32          // height of window, including borders
+	cw           uint32          // width of the canvas along x, in pixels
+	ch           uint32          // height of the canvas along y, in pixels
+	catt         rbase.AttCanvas // canvas attributes
+	moveOpaque   bool            // whether to move objects in opaque mode
+	resizeOpaque bool            // whether to resize objects in opaque mode
+	highlight    int16           // highlight color of active pad
+	batch        bool            // true when in batch mode
+	showEvtStat  bool            // whether to show the event status bar
+	autoExec     bool            // whether to execute the list of TExecs
+	menuBar      bool            // whether to show the menu bar
+}
+
+func newCanvas() *Canvas {
+	return &Canvas{
+		Pad:          *newPad(),
+		display:      "$DISPLAY",
+		doubleBuffer: 1,
+		retained:     true,
+		xsizeReal:    20,
+		ysizeReal:    14.28571,
+		winWidth:     700,
+		winHeight:    500,
+		cw:           696,
+		ch:           472,
+		catt:         *rbase.NewAttCanvas(),
+		highlight:    2,
+		batch:        true,
+		autoExec:     true,
+		menuBar:      true,
+	}
+}
+
+// NewCanvas creates a new canvas holding the provided primitives.
+func NewCanvas(name, title string, primitives ...root.Object) *Canvas {
+	c := newCanvas()
+	c.Pad.name = name
+	c.Pad.title = title
+	c.Pad.prims = *rcont.NewList("", primitives)
+	return c
+}
+
+func (*Canvas) RVersion() int16 {
+	return rvers.Canvas
+}
+
+func (*Canvas) Class() string {
+	return "TCanvas"
+}
+
+func (c *Canvas) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(c.RVersion())
+	if _, err := c.Pad.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	w.WriteString(c.display)
+	w.WriteI32(c.doubleBuffer)
+	w.WriteBool(c.retained)
+	w.WriteF32(c.xsizeUser)
+	w.WriteF32(c.ysizeUser)
+	w.WriteF32(c.xsizeReal)
+	w.WriteF32(c.ysizeReal)
+	w.WriteI32(c.winTopX)
+	w.WriteI32(c.winTopY)
+	w.WriteU32(c.winWidth)
+	w.WriteU32(c.winHeight)
+	w.WriteU32(c.cw)
+	w.WriteU32(c.ch)
+	if _, err := c.catt.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	w.WriteBool(c.moveOpaque)
+	w.WriteBool(c.resizeOpaque)
+	w.WriteI16(c.highlight)
+	w.WriteBool(c.batch)
+	w.WriteBool(c.showEvtStat)
+	w.WriteBool(c.autoExec)
+	w.WriteBool(c.menuBar)
+
+	return w.SetByteCount(pos, c.Class())
+}
+
+func (c *Canvas) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(c.Class())
+
+	if err := c.Pad.UnmarshalROOT(r); err != nil {
+		return err
+	}
+
+	c.display = r.ReadString()
+	c.doubleBuffer = r.ReadI32()
+	c.retained = r.ReadBool()
+	c.xsizeUser = r.ReadF32()
+	c.ysizeUser = r.ReadF32()
+	c.xsizeReal = r.ReadF32()
+	c.ysizeReal = r.ReadF32()
+	c.winTopX = r.ReadI32()
+	c.winTopY = r.ReadI32()
+	if vers > 2 {
+		c.winWidth = r.ReadU32()
+		c.winHeight = r.ReadU32()
+	}
+	c.cw = r.ReadU32()
+	c.ch = r.ReadU32()
+	if err := c.catt.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	c.moveOpaque = r.ReadBool()
+	c.resizeOpaque = r.ReadBool()
+	c.highlight = r.ReadI16()
+	c.batch = r.ReadBool()
+	if vers >= 2 {
+		c.showEvtStat = r.ReadBool()
+		if vers > 3 {
+			c.autoExec = r.ReadBool()
+		}
+		c.menuBar = r.ReadBool()
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, c.Class())
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newPad()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TPad", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := newCanvas()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TCanvas", f)
+	}
+}
+
+var (
+	_ root.Object        = (*Pad)(nil)
+	_ root.Named         = (*Pad)(nil)
+	_ rbytes.Marshaler   = (*Pad)(nil)
+	_ rbytes.Unmarshaler = (*Pad)(nil)
+
+	_ root.Object        = (*Canvas)(nil)
+	_ root.Named         = (*Canvas)(nil)
+	_ rbytes.Marshaler   = (*Canvas)(nil)
+	_ rbytes.Unmarshaler = (*Canvas)(nil)
+)
@@ -0,0 +1,440 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/rdict"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// statistic options of ROOT efficiencies.
+const (
+	effFCP      = 0 // Clopper-Pearson interval (default)
+	effFNormal  = 1 // normal approximation
+	effFWilson  = 2 // Wilson interval
+	effFAC      = 3 // Agresti-Coull interval
+	effFFC      = 4 // Feldman-Cousins interval
+	effBJeffrey = 5 // Jeffrey prior
+	effBUniform = 6 // uniform prior
+	effBBayes   = 7 // custom prior
+	effMidP     = 8 // mid-P Lancaster interval
+)
+
+// Efficiency implements ROOT TEfficiency
+type Efficiency struct {
+	rbase.Named
+	attline   rbase.AttLine
+	attfill   rbase.AttFill
+	attmarker rbase.AttMarker
+
+	betaAlpha  float64      // global parameter for prior beta distribution
+	betaBeta   float64      // global parameter for prior beta distribution
+	betaBins   [][2]float64 // parameters for prior beta distribution, bin by bin
+	confLevel  float64      // confidence level
+	funcs      rcont.List   // list of functions
+	passed     root.Object  // histogram for events which passed certain criteria
+	statOption int32        // how the confidence intervals are determined
+	total      root.Object  // histogram for total number of events
+	weight     float64      // weight for all events
+}
+
+func newEfficiency() *Efficiency {
+	return &Efficiency{
+		Named:      *rbase.NewNamed("", ""),
+		attline:    *rbase.NewAttLine(),
+		attfill:    *rbase.NewAttFill(),
+		attmarker:  *rbase.NewAttMarker(),
+		betaAlpha:  1,
+		betaBeta:   1,
+		confLevel:  0.682689492137,
+		funcs:      *rcont.NewList("", nil),
+		statOption: effFCP,
+		weight:     1,
+	}
+}
+
+// NewEfficiencyFrom creates a new efficiency from the provided histograms
+// of passed and total events.
+func NewEfficiencyFrom(passed, total *hbook.H1D) (*Efficiency, error) {
+	if passed.Len() != total.Len() || passed.XMin() != total.XMin() || passed.XMax() != total.XMax() {
+		return nil, fmt.Errorf("rhist: passed and total histograms have different binnings")
+	}
+	for i, bin := range passed.Binning.Bins {
+		if bin.SumW() > total.Binning.Bins[i].SumW() {
+			return nil, fmt.Errorf("rhist: passed events exceed total events in bin #%d", i)
+		}
+	}
+
+	e := newEfficiency()
+	e.SetName(passed.Name())
+	if v, ok := passed.Annotation()["title"]; ok {
+		e.SetTitle(v.(string))
+	}
+	e.passed = NewH1DFrom(passed)
+	e.total = NewH1DFrom(total)
+	return e, nil
+}
+
+func (*Efficiency) RVersion() int16 {
+	return rvers.Efficiency
+}
+
+func (*Efficiency) Class() string {
+	return "TEfficiency"
+}
+
+// Passed returns the histogram of passed events, or nil if it is not a
+// 1-dim histogram.
+func (e *Efficiency) Passed() H1 {
+	h, _ := e.passed.(H1)
+	return h
+}
+
+// Total returns the histogram of total events, or nil if it is not a
+// 1-dim histogram.
+func (e *Efficiency) Total() H1 {
+	h, _ := e.total.(H1)
+	return h
+}
+
+// ConfLevel returns the confidence level used to compute the intervals.
+func (e *Efficiency) ConfLevel() float64 {
+	return e.confLevel
+}
+
+// Weight returns the weight of all events.
+func (e *Efficiency) Weight() float64 {
+	return e.weight
+}
+
+// Funcs returns the list of functions attached to this efficiency.
+func (e *Efficiency) Funcs() root.List {
+	return &e.funcs
+}
+
+// NbinsX returns the number of bins of the efficiency.
+func (e *Efficiency) NbinsX() int {
+	h, ok := e.total.(xbinner)
+	if !ok {
+		return 0
+	}
+	return h.NbinsX()
+}
+
+// Efficiency returns the efficiency in the i-th bin.
+// Bin 0 is the underflow bin and bin NbinsX()+1 is the overflow bin.
+func (e *Efficiency) Efficiency(i int) float64 {
+	pass, tot := e.counts(i)
+	if e.bayesian() {
+		a, b := e.betaParams(i)
+		a += pass
+		b += tot - pass
+		return a / (a + b)
+	}
+	if tot == 0 {
+		return 0
+	}
+	return pass / tot
+}
+
+// ErrorLow returns the lower error on the efficiency in the i-th bin.
+func (e *Efficiency) ErrorLow(i int) float64 {
+	return e.Efficiency(i) - e.bound(i, false)
+}
+
+// ErrorUp returns the upper error on the efficiency in the i-th bin.
+func (e *Efficiency) ErrorUp(i int) float64 {
+	return e.bound(i, true) - e.Efficiency(i)
+}
+
+// AsS2D creates a new hbook.S2D from the efficiency, with one point per
+// (in-range) bin.
+func (e *Efficiency) AsS2D() *hbook.S2D {
+	h, ok := e.total.(xbinner)
+	if !ok {
+		return nil
+	}
+	pts := make([]hbook.Point2D, 0, h.NbinsX())
+	for i := 1; i <= h.NbinsX(); i++ {
+		pass, tot := e.counts(i)
+		if tot == 0 && pass == 0 {
+			continue
+		}
+		dx := 0.5 * h.XBinWidth(i)
+		pts = append(pts, hbook.Point2D{
+			X:    h.XBinCenter(i),
+			Y:    e.Efficiency(i),
+			ErrX: hbook.Range{Min: dx, Max: dx},
+			ErrY: hbook.Range{Min: e.ErrorLow(i), Max: e.ErrorUp(i)},
+		})
+	}
+	s2 := hbook.NewS2D(pts...)
+	s2.Annotation()["name"] = e.Name()
+	s2.Annotation()["title"] = e.Title()
+	return s2
+}
+
+type xbinner interface {
+	NbinsX() int
+	XBinContent(i int) float64
+	XBinCenter(i int) float64
+	XBinWidth(i int) float64
+}
+
+func (e *Efficiency) counts(i int) (pass, tot float64) {
+	if h, ok := e.passed.(xbinner); ok {
+		pass = h.XBinContent(i)
+	}
+	if h, ok := e.total.(xbinner); ok {
+		tot = h.XBinContent(i)
+	}
+	return pass, tot
+}
+
+func (e *Efficiency) bayesian() bool {
+	switch e.statOption {
+	case effBJeffrey, effBUniform, effBBayes:
+		return true
+	}
+	return false
+}
+
+func (e *Efficiency) betaParams(i int) (a, b float64) {
+	switch e.statOption {
+	case effBJeffrey:
+		return 0.5, 0.5
+	case effBUniform:
+		return 1, 1
+	}
+	if i < len(e.betaBins) && e.betaBins[i][0] > 0 && e.betaBins[i][1] > 0 {
+		return e.betaBins[i][0], e.betaBins[i][1]
+	}
+	return e.betaAlpha, e.betaBeta
+}
+
+// bound returns the lower or upper bound of the confidence interval
+// of the efficiency in the i-th bin.
+// Feldman-Cousins and mid-P intervals are computed as Clopper-Pearson ones.
+func (e *Efficiency) bound(i int, upper bool) float64 {
+	pass, tot := e.counts(i)
+	level := e.confLevel
+
+	if e.bayesian() {
+		a, b := e.betaParams(i)
+		a += pass
+		b += tot - pass
+		if a <= 0 || b <= 0 {
+			return e.Efficiency(i)
+		}
+		beta := distuv.Beta{Alpha: a, Beta: b}
+		if upper {
+			return beta.Quantile((1 + level) / 2)
+		}
+		return beta.Quantile((1 - level) / 2)
+	}
+
+	if tot == 0 {
+		if upper {
+			return 1
+		}
+		return 0
+	}
+
+	var (
+		alpha = (1 - level) / 2
+		eff   = pass / tot
+	)
+
+	switch e.statOption {
+	case effFNormal:
+		delta := distuv.UnitNormal.Quantile(1-alpha) * math.Sqrt(eff*(1-eff)/tot)
+		return effClamp(eff, delta, upper)
+
+	case effFWilson:
+		kappa := distuv.UnitNormal.Quantile(1 - alpha)
+		mode := (pass + 0.5*kappa*kappa) / (tot + kappa*kappa)
+		delta := kappa / (tot + kappa*kappa) * math.Sqrt(tot*eff*(1-eff)+kappa*kappa/4)
+		return effClamp(mode, delta, upper)
+
+	case effFAC:
+		kappa := distuv.UnitNormal.Quantile(1 - alpha)
+		mode := (pass + 0.5*kappa*kappa) / (tot + kappa*kappa)
+		delta := kappa * math.Sqrt(mode*(1-mode)/(tot+kappa*kappa))
+		return effClamp(mode, delta, upper)
+
+	default:
+		// Clopper-Pearson interval.
+		switch {
+		case upper && pass >= tot:
+			return 1
+		case upper:
+			return distuv.Beta{Alpha: pass + 1, Beta: tot - pass}.Quantile(1 - alpha)
+		case pass <= 0:
+			return 0
+		default:
+			return distuv.Beta{Alpha: pass, Beta: tot - pass + 1}.Quantile(alpha)
+		}
+	}
+}
+
+func effClamp(mode, delta float64, upper bool) float64 {
+	if upper {
+		return math.Min(mode+delta, 1)
+	}
+	return math.Max(mode-delta, 0)
+}
+
+func (e *Efficiency) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(e.RVersion())
+	for _, v := range []rbytes.Marshaler{
+		&e.Named,
+		&e.attline,
+		&e.attfill,
+		&e.attmarker,
+	} {
+		if _, err := v.MarshalROOT(w); err != nil {
+			return 0, err
+		}
+	}
+
+	w.WriteF64(e.betaAlpha)
+	w.WriteF64(e.betaBeta)
+	{
+		// std::vector<std::pair<double,double> >, streamed member-wise.
+		si, ok := rdict.StreamerInfos.Get("pair<double,double>", -1)
+		if !ok {
+			w.SetErr(fmt.Errorf("rhist: could not find streamer info for pair<double,double>"))
+			return 0, w.Err()
+		}
+		beg := w.WriteVersion(rvers.StreamerInfo | rbytes.StreamedMemberWise)
+		w.WriteI16(0) // std::pair has no class version, write its checksum.
+		w.WriteU32(uint32(si.CheckSum()))
+		w.WriteI32(int32(len(e.betaBins)))
+		for _, v := range e.betaBins {
+			w.WriteF64(v[0])
+		}
+		for _, v := range e.betaBins {
+			w.WriteF64(v[1])
+		}
+		if _, err := w.SetByteCount(beg, "vector<pair<double,double> >"); err != nil {
+			return 0, err
+		}
+	}
+	w.WriteF64(e.confLevel)
+	if _, err := e.funcs.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	if err := w.WriteObjectAny(e.passed); err != nil {
+		return 0, err
+	}
+	w.WriteI32(e.statOption)
+	if err := w.WriteObjectAny(e.total); err != nil {
+		return 0, err
+	}
+	w.WriteF64(e.weight)
+
+	return w.SetByteCount(pos, e.Class())
+}
+
+func (e *Efficiency) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(e.Class())
+	const minVers = 2
+	if vers < minVers {
+		return fmt.Errorf("rhist: TEfficiency version too old (%d<%d)", vers, minVers)
+	}
+
+	for _, v := range []rbytes.Unmarshaler{
+		&e.Named,
+		&e.attline,
+		&e.attfill,
+		&e.attmarker,
+	} {
+		if err := v.UnmarshalROOT(r); err != nil {
+			return err
+		}
+	}
+
+	e.betaAlpha = r.ReadF64()
+	e.betaBeta = r.ReadF64()
+	{
+		const typename = "vector<pair<double,double> >"
+		beg := r.Pos()
+		vers, pos, bcnt := r.ReadVersion(typename)
+		mbrwise := vers&rbytes.StreamedMemberWise != 0
+		if mbrwise {
+			if v := r.ReadI16(); v <= 0 {
+				_ = r.ReadU32() // checksum
+			}
+		}
+		n := int(r.ReadI32())
+		e.betaBins = nil
+		if n > 0 {
+			e.betaBins = make([][2]float64, n)
+		}
+		switch {
+		case mbrwise:
+			for i := range e.betaBins {
+				e.betaBins[i][0] = r.ReadF64()
+			}
+			for i := range e.betaBins {
+				e.betaBins[i][1] = r.ReadF64()
+			}
+		default:
+			for i := range e.betaBins {
+				e.betaBins[i][0] = r.ReadF64()
+				e.betaBins[i][1] = r.ReadF64()
+			}
+		}
+		r.CheckByteCount(pos, bcnt, beg, typename)
+	}
+	e.confLevel = r.ReadF64()
+	if err := e.funcs.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	e.passed = r.ReadObjectAny()
+	e.statOption = r.ReadI32()
+	e.total = r.ReadObjectAny()
+	e.weight = r.ReadF64()
+
+	r.CheckByteCount(pos, bcnt, beg, e.Class())
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newEfficiency()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TEfficiency", f)
+	}
+}
+
+var (
+	_ root.Object        = (*Efficiency)(nil)
+	_ root.Named         = (*Efficiency)(nil)
+	_ rbytes.Marshaler   = (*Efficiency)(nil)
+	_ rbytes.Unmarshaler = (*Efficiency)(nil)
+)
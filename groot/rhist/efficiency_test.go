@@ -0,0 +1,68 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestEfficiency(t *testing.T) {
+	var (
+		passed = hbook.NewH1D(4, 0, 4)
+		total  = hbook.NewH1D(4, 0, 4)
+	)
+	passed.Annotation()["name"] = "eff"
+	for i, v := range [][2]float64{{1, 2}, {0, 1}, {4, 4}, {0, 0}} {
+		x := float64(i) + 0.5
+		passed.Fill(x, v[0])
+		total.Fill(x, v[1])
+	}
+
+	eff, err := rhist.NewEfficiencyFrom(passed, total)
+	if err != nil {
+		t.Fatalf("could not create efficiency: %+v", err)
+	}
+
+	if got, want := eff.NbinsX(), 4; got != want {
+		t.Fatalf("invalid number of bins: got=%d, want=%d", got, want)
+	}
+
+	// Clopper-Pearson intervals at 1 sigma.
+	alpha := 0.5 * (1 - eff.ConfLevel())
+	for _, tc := range []struct {
+		bin    int
+		eff    float64
+		lo, hi float64
+	}{
+		{bin: 1, eff: 0.5, lo: 1 - math.Sqrt(1-alpha), hi: math.Sqrt(1 - alpha)},
+		{bin: 2, eff: 0, lo: 0, hi: 1 - alpha},
+		{bin: 3, eff: 1, lo: math.Pow(alpha, 0.25), hi: 1},
+	} {
+		const tol = 1e-6
+		if got := eff.Efficiency(tc.bin); math.Abs(got-tc.eff) > tol {
+			t.Fatalf("bin #%d: invalid efficiency: got=%v, want=%v", tc.bin, got, tc.eff)
+		}
+		if got, want := tc.eff-eff.ErrorLow(tc.bin), tc.lo; math.Abs(got-want) > tol {
+			t.Fatalf("bin #%d: invalid lower bound: got=%v, want=%v", tc.bin, got, want)
+		}
+		if got, want := tc.eff+eff.ErrorUp(tc.bin), tc.hi; math.Abs(got-want) > tol {
+			t.Fatalf("bin #%d: invalid upper bound: got=%v, want=%v", tc.bin, got, want)
+		}
+	}
+
+	s2 := eff.AsS2D()
+	if got, want := s2.Len(), 3; got != want {
+		t.Fatalf("invalid number of points: got=%d, want=%d", got, want)
+	}
+
+	_, err = rhist.NewEfficiencyFrom(total, passed)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// F1 implements ROOT TF1
+type F1 struct {
+	rbase.Named
+	attline   rbase.AttLine
+	attfill   rbase.AttFill
+	attmarker rbase.AttMarker
+
+	xmin      float64       // lower bound of the range
+	xmax      float64       // upper bound of the range
+	npar      int32         // number of parameters
+	ndim      int32         // function dimension
+	npx       int32         // number of points used for the graphical representation
+	ftype     int32         // type of function
+	npfits    int32         // number of points used in the fit
+	ndf       int32         // number of degrees of freedom in the fit
+	chi2      float64       // function fit chisquare
+	min       float64       // minimum value for plotting
+	max       float64       // maximum value for plotting
+	parErrs   []float64     // array of errors of the parameters
+	parMin    []float64     // array of lower limits of the parameters
+	parMax    []float64     // array of upper limits of the parameters
+	save      []float64     // array of function values
+	norm      bool          // normalization option
+	normInteg float64       // integral of the function before being normalized
+	formula   *Formula      // formula expression
+	params    *f1Parameters // parameters values and names
+	compo     root.Object   // composition of functions (for TF1NormSum and TF1Convolution)
+}
+
+func newF1() *F1 {
+	return &F1{
+		Named:     *rbase.NewNamed("", ""),
+		attline:   *rbase.NewAttLine(),
+		attfill:   *rbase.NewAttFill(),
+		attmarker: *rbase.NewAttMarker(),
+		npx:       100,
+		min:       -1111,
+		max:       -1111,
+	}
+}
+
+// NewF1 creates a new 1-dim function from the provided formula expression,
+// defined over the [xmin, xmax) range.
+// All the parameters of the function are initialized to zero.
+func NewF1(name, expr string, xmin, xmax float64) (*F1, error) {
+	formula, err := NewFormula(name, expr)
+	if err != nil {
+		return nil, fmt.Errorf("rhist: could not create TF1 %q: %w", name, err)
+	}
+
+	var (
+		f    = newF1()
+		npar = formula.NPar()
+	)
+	f.Named = *rbase.NewNamed(name, expr)
+	f.xmin = xmin
+	f.xmax = xmax
+	f.npar = int32(npar)
+	f.ndim = 1
+	f.parErrs = make([]float64, npar)
+	f.parMin = make([]float64, npar)
+	f.parMax = make([]float64, npar)
+	f.formula = formula
+	f.params = &f1Parameters{
+		params: make([]float64, npar),
+		names:  formula.ParNames(),
+	}
+	return f, nil
+}
+
+func (*F1) RVersion() int16 {
+	return rvers.F1
+}
+
+func (*F1) Class() string {
+	return "TF1"
+}
+
+// XMin returns the lower bound of the range of the function.
+func (f *F1) XMin() float64 {
+	return f.xmin
+}
+
+// XMax returns the upper bound of the range of the function.
+func (f *F1) XMax() float64 {
+	return f.xmax
+}
+
+// NPar returns the number of parameters of the function.
+func (f *F1) NPar() int {
+	return int(f.npar)
+}
+
+// Params returns the values of the parameters of the function.
+func (f *F1) Params() []float64 {
+	if f.params != nil {
+		return f.params.params
+	}
+	if f.formula != nil {
+		return f.formula.clingParams
+	}
+	return nil
+}
+
+// SetParams sets the values of the parameters of the function.
+func (f *F1) SetParams(ps ...float64) {
+	if f.params != nil {
+		copy(f.params.params, ps)
+	}
+	if f.formula != nil {
+		copy(f.formula.clingParams, ps)
+		f.formula.allParSet = true
+	}
+}
+
+// ParName returns the name of the i-th parameter.
+func (f *F1) ParName(i int) string {
+	if f.params != nil && i < len(f.params.names) {
+		return f.params.names[i]
+	}
+	return fmt.Sprintf("p%d", i)
+}
+
+// ParErrors returns the errors on the parameters of the function.
+func (f *F1) ParErrors() []float64 {
+	return f.parErrs
+}
+
+// ParLimits returns the lower and upper limits of the i-th parameter.
+func (f *F1) ParLimits(i int) (float64, float64) {
+	if i >= len(f.parMin) || i >= len(f.parMax) {
+		return 0, 0
+	}
+	return f.parMin[i], f.parMax[i]
+}
+
+// Chi2 returns the chisquare of the fit.
+func (f *F1) Chi2() float64 {
+	return f.chi2
+}
+
+// NDF returns the number of degrees of freedom of the fit.
+func (f *F1) NDF() int {
+	return int(f.ndf)
+}
+
+// Formula returns the formula of the function, if any.
+func (f *F1) Formula() *Formula {
+	return f.formula
+}
+
+// Eval evaluates the function at x with the current parameters.
+func (f *F1) Eval(x ...float64) (float64, error) {
+	fct, err := f.compile()
+	if err != nil {
+		return 0, err
+	}
+	return fct(x), nil
+}
+
+// Func returns the function, with its current parameters, as a 1-dim Go function.
+// The returned function can be drawn with hplot.NewFunction.
+func (f *F1) Func() (func(x float64) float64, error) {
+	fct, err := f.compile()
+	if err != nil {
+		return nil, err
+	}
+	return func(x float64) float64 {
+		return fct([]float64{x})
+	}, nil
+}
+
+func (f *F1) compile() (func(x []float64) float64, error) {
+	if f.formula == nil {
+		return nil, fmt.Errorf("rhist: TF1 %q has no formula", f.Name())
+	}
+	fct, err := f.formula.compile(f.Params())
+	if err != nil {
+		return nil, fmt.Errorf("rhist: could not compile TF1 %q: %w", f.Name(), err)
+	}
+	return fct, nil
+}
+
+func (f *F1) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(f.RVersion())
+	for _, v := range []rbytes.Marshaler{
+		&f.Named,
+		&f.attline,
+		&f.attfill,
+		&f.attmarker,
+	} {
+		if _, err := v.MarshalROOT(w); err != nil {
+			return 0, err
+		}
+	}
+
+	w.WriteF64(f.xmin)
+	w.WriteF64(f.xmax)
+	w.WriteI32(f.npar)
+	w.WriteI32(f.ndim)
+	w.WriteI32(f.npx)
+	w.WriteI32(f.ftype)
+	w.WriteI32(f.npfits)
+	w.WriteI32(f.ndf)
+	w.WriteF64(f.chi2)
+	w.WriteF64(f.min)
+	w.WriteF64(f.max)
+	writeStdVectorF64(w, f.parErrs)
+	writeStdVectorF64(w, f.parMin)
+	writeStdVectorF64(w, f.parMax)
+	writeStdVectorF64(w, f.save)
+	w.WriteBool(f.norm)
+	w.WriteF64(f.normInteg)
+
+	var formula root.Object
+	if f.formula != nil {
+		formula = f.formula
+	}
+	if err := w.WriteObjectAny(formula); err != nil {
+		return 0, err
+	}
+
+	var params root.Object
+	if f.params != nil {
+		params = f.params
+	}
+	if err := w.WriteObjectAny(params); err != nil {
+		return 0, err
+	}
+
+	if err := w.WriteObjectAny(f.compo); err != nil {
+		return 0, err
+	}
+
+	return w.SetByteCount(pos, f.Class())
+}
+
+func (f *F1) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(f.Class())
+	const minVers = 10
+	if vers < minVers {
+		return fmt.Errorf("rhist: TF1 version too old (%d<%d)", vers, minVers)
+	}
+
+	for _, v := range []rbytes.Unmarshaler{
+		&f.Named,
+		&f.attline,
+		&f.attfill,
+		&f.attmarker,
+	} {
+		if err := v.UnmarshalROOT(r); err != nil {
+			return err
+		}
+	}
+
+	f.xmin = r.ReadF64()
+	f.xmax = r.ReadF64()
+	f.npar = r.ReadI32()
+	f.ndim = r.ReadI32()
+	f.npx = r.ReadI32()
+	f.ftype = r.ReadI32()
+	f.npfits = r.ReadI32()
+	f.ndf = r.ReadI32()
+	f.chi2 = r.ReadF64()
+	f.min = r.ReadF64()
+	f.max = r.ReadF64()
+	f.parErrs = readStdVectorF64(r, f.parErrs)
+	f.parMin = readStdVectorF64(r, f.parMin)
+	f.parMax = readStdVectorF64(r, f.parMax)
+	f.save = readStdVectorF64(r, f.save)
+	f.norm = r.ReadBool()
+	f.normInteg = r.ReadF64()
+
+	f.formula = nil
+	if obj := r.ReadObjectAny(); obj != nil {
+		f.formula = obj.(*Formula)
+	}
+
+	f.params = nil
+	if obj := r.ReadObjectAny(); obj != nil {
+		f.params = obj.(*f1Parameters)
+	}
+
+	f.compo = nil
+	if vers >= 12 {
+		f.compo = r.ReadObjectAny()
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, f.Class())
+	return r.Err()
+}
+
+// f1Parameters implements ROOT TF1Parameters
+type f1Parameters struct {
+	params []float64 // parameter values
+	names  []string  // parameter names
+}
+
+func (*f1Parameters) RVersion() int16 {
+	return rvers.F1Parameters
+}
+
+func (*f1Parameters) Class() string {
+	return "TF1Parameters"
+}
+
+func (p *f1Parameters) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(p.RVersion())
+	writeStdVectorF64(w, p.params)
+	{
+		pos := w.WriteVersion(rvers.StreamerInfo)
+		w.WriteI32(int32(len(p.names)))
+		w.WriteFastArrayString(p.names)
+		if _, err := w.SetByteCount(pos, "vector<string>"); err != nil {
+			return 0, err
+		}
+	}
+
+	return w.SetByteCount(pos, p.Class())
+}
+
+func (p *f1Parameters) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	_, pos, bcnt := r.ReadVersion(p.Class())
+
+	p.params = readStdVectorF64(r, p.params)
+	{
+		beg := r.Pos()
+		_, pos, bcnt := r.ReadVersion("vector<string>")
+		n := int(r.ReadI32())
+		p.names = rbytes.ResizeStr(p.names, n)
+		for i := range p.names {
+			p.names[i] = r.ReadString()
+		}
+		r.CheckByteCount(pos, bcnt, beg, "vector<string>")
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, p.Class())
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newF1()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TF1", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := &f1Parameters{}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TF1Parameters", f)
+	}
+}
+
+var (
+	_ root.Object        = (*F1)(nil)
+	_ root.Named         = (*F1)(nil)
+	_ rbytes.Marshaler   = (*F1)(nil)
+	_ rbytes.Unmarshaler = (*F1)(nil)
+
+	_ root.Object        = (*f1Parameters)(nil)
+	_ rbytes.Marshaler   = (*f1Parameters)(nil)
+	_ rbytes.Unmarshaler = (*f1Parameters)(nil)
+)
@@ -0,0 +1,215 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hbook"
+)
+
+func TestF1Eval(t *testing.T) {
+	gaus := func(x, c, mu, sigma float64) float64 {
+		v := (x - mu) / sigma
+		return c * math.Exp(-0.5*v*v)
+	}
+
+	for _, tc := range []struct {
+		expr   string
+		params []float64
+		x      float64
+		want   float64
+	}{
+		{expr: "gaus", params: []float64{10, 1, 2}, x: 2, want: gaus(2, 10, 1, 2)},
+		{expr: "gausn", params: []float64{10, 1, 2}, x: 2, want: gaus(2, 10, 1, 2) / (2 * math.Sqrt(2*math.Pi))},
+		{expr: "expo", params: []float64{1, -0.5}, x: 2, want: math.Exp(1 - 0.5*2)},
+		{expr: "pol0", params: []float64{3}, x: 2, want: 3},
+		{expr: "pol3", params: []float64{1, 2, 3, 4}, x: 2, want: 1 + 2*2 + 3*4 + 4*8},
+		{expr: "landau", params: []float64{2, 0, 1}, x: 0, want: 2 * 0.1788541609},
+		{expr: "gaus+pol1", params: []float64{10, 1, 2, 3, 4}, x: 2, want: gaus(2, 10, 1, 2) + 3 + 4*2},
+		{expr: "pol1(3)+gaus(0)", params: []float64{10, 1, 2, 3, 4}, x: 2, want: gaus(2, 10, 1, 2) + 3 + 4*2},
+		{expr: "[0]+[1]*x+[p2]*x^2", params: []float64{1, 2, 3}, x: 2, want: 1 + 2*2 + 3*4},
+		{expr: "[0]*TMath::Exp(-x/[1])", params: []float64{2, 4}, x: 2, want: 2 * math.Exp(-0.5)},
+		{expr: "[0]*sin(x)/x", params: []float64{2}, x: 2, want: 2 * math.Sin(2) / 2},
+		{expr: "-x**2 + 2*-x", params: nil, x: 3, want: -15},
+		{expr: "2^3^2", params: nil, x: 0, want: 512},
+		{expr: "(x>1 && x<3)*[0] + TMath::Pi()", params: []float64{5}, x: 2, want: 5 + math.Pi},
+		{expr: "[Constant]*exp(-0.5*((x-[Mean])/[Sigma])**2)", params: []float64{10, 1, 2}, x: 2, want: gaus(2, 10, 1, 2)},
+		{expr: "std::max(x, 2.5e-1) + 1.5E1", params: nil, x: 0, want: 15.25},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			f, err := rhist.NewF1("f1", tc.expr, -5, 5)
+			if err != nil {
+				t.Fatalf("could not create TF1: %+v", err)
+			}
+			if got, want := f.NPar(), len(tc.params); got != want {
+				t.Fatalf("invalid number of parameters: got=%d, want=%d", got, want)
+			}
+			f.SetParams(tc.params...)
+
+			got, err := f.Eval(tc.x)
+			if err != nil {
+				t.Fatalf("could not evaluate TF1: %+v", err)
+			}
+			if math.Abs(got-tc.want) > 1e-9 {
+				t.Fatalf("invalid value: got=%v, want=%v", got, tc.want)
+			}
+
+			fct, err := f.Func()
+			if err != nil {
+				t.Fatalf("could not create Go function: %+v", err)
+			}
+			if got := fct(tc.x); math.Abs(got-tc.want) > 1e-9 {
+				t.Fatalf("invalid function value: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestF1Names(t *testing.T) {
+	f, err := rhist.NewF1("f1", "[Constant]*exp(-0.5*((x-[Mean])/[Sigma])**2)+[3]", -5, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Formula().ParNames(), []string{"Constant", "Mean", "Sigma", "p3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid parameter names: got=%q, want=%q", got, want)
+	}
+	for i, want := range []string{"Constant", "Mean", "Sigma", "p3"} {
+		if got := f.ParName(i); got != want {
+			t.Fatalf("invalid parameter name #%d: got=%q, want=%q", i, got, want)
+		}
+	}
+}
+
+func TestF1Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"x+",
+		"(x",
+		"[0",
+		"foo(x)",
+		"sqrt(x, 2)",
+		"x $ 2",
+		"unknown",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := rhist.NewF1("f1", expr, 0, 1)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestLandauContinuity(t *testing.T) {
+	f, err := rhist.NewF1("f1", "landau", -10, 400)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetParams(1, 0, 1)
+	fct, err := f.Func()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, x := range []float64{-5.5, -1, 1, 5, 12, 50, 300} {
+		const eps = 1e-9
+		lo, hi := fct(x-eps), fct(x+eps)
+		if math.Abs(lo-hi) > 1e-6*math.Max(math.Abs(lo), 1e-12) {
+			t.Fatalf("landau discontinuous at x=%v: %v != %v", x, lo, hi)
+		}
+	}
+
+	// most probable value of the standard landau distribution is ~ -0.22278.
+	if fct(-0.22278) < fct(-0.5) || fct(-0.22278) < fct(0) {
+		t.Fatalf("invalid landau maximum")
+	}
+}
+
+func TestF1RW(t *testing.T) {
+	dir, err := ioutil.TempDir("", "groot-rhist-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "f1.root")
+
+	want, err := rhist.NewF1("fit", "gaus(0)+pol1(3)", -4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.SetParams(10, 0.5, 1.5, 2, -0.25)
+
+	{
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		h := rhist.NewH1DFrom(hbook.NewH1D(10, -4, 4))
+		h.Funcs().(*rcont.List).Append(want)
+
+		err = f.Put("h1", h)
+		if err != nil {
+			t.Fatalf("could not write histogram: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("h1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	funcs := obj.(*rhist.H1D).Funcs()
+	if got, want := funcs.Len(), 1; got != want {
+		t.Fatalf("invalid number of functions: got=%d, want=%d", got, want)
+	}
+
+	got := funcs.At(0).(*rhist.F1)
+	if got.Name() != "fit" || got.XMin() != -4 || got.XMax() != 4 {
+		t.Fatalf("invalid TF1: name=%q, range=[%v, %v]", got.Name(), got.XMin(), got.XMax())
+	}
+	if !reflect.DeepEqual(got.Params(), want.Params()) {
+		t.Fatalf("invalid parameters: got=%v, want=%v", got.Params(), want.Params())
+	}
+	if got, want := got.Formula().Expr(), "gaus(0)+pol1(3)"; got != want {
+		t.Fatalf("invalid formula: got=%q, want=%q", got, want)
+	}
+
+	for _, x := range []float64{-3, 0, 0.5, 2} {
+		g, err := got.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := want.Eval(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g != w {
+			t.Fatalf("invalid value at x=%v: got=%v, want=%v", x, g, w)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Formula implements ROOT TFormula
+type Formula struct {
+	rbase.Named
+
+	clingParams []float64      // parameter values
+	allParSet   bool           // whether all parameters have been set
+	params      map[string]int // parameter names to parameter indices
+	formula     string         // formula expression
+	ndim        int32          // dimension of the formula
+	linear      []root.Object  // linear parts of the formula
+	vectorized  bool           // whether the formula is vectorized
+}
+
+func newFormula() *Formula {
+	return &Formula{
+		Named:  *rbase.NewNamed("", ""),
+		params: make(map[string]int),
+	}
+}
+
+// NewFormula creates a new TFormula from the provided expression.
+func NewFormula(name, expr string) (*Formula, error) {
+	f := newFormula()
+	_, npar, ndim, err := compileFormula(expr, f.params)
+	if err != nil {
+		return nil, err
+	}
+
+	named := make(map[int]bool, len(f.params))
+	for _, i := range f.params {
+		named[i] = true
+	}
+	for i := 0; i < npar; i++ {
+		if !named[i] {
+			f.params[fmt.Sprintf("p%d", i)] = i
+		}
+	}
+
+	f.Named = *rbase.NewNamed(name, expr)
+	f.formula = expr
+	f.ndim = int32(ndim)
+	f.clingParams = make([]float64, npar)
+	return f, nil
+}
+
+func (*Formula) RVersion() int16 {
+	return rvers.Formula
+}
+
+func (*Formula) Class() string {
+	return "TFormula"
+}
+
+// Expr returns the expression of the formula.
+func (f *Formula) Expr() string {
+	return f.formula
+}
+
+// NDim returns the number of dimensions of the formula.
+func (f *Formula) NDim() int {
+	return int(f.ndim)
+}
+
+// NPar returns the number of parameters of the formula.
+func (f *Formula) NPar() int {
+	return len(f.params)
+}
+
+// Params returns the values of the parameters of the formula.
+func (f *Formula) Params() []float64 {
+	return f.clingParams
+}
+
+// ParNames returns the names of the parameters of the formula,
+// ordered by parameter index.
+func (f *Formula) ParNames() []string {
+	names := make([]string, 0, len(f.params))
+	for k := range f.params {
+		names = append(names, k)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return f.params[names[i]] < f.params[names[j]]
+	})
+	return names
+}
+
+// Eval evaluates the formula at x, with the provided parameters.
+func (f *Formula) Eval(x, params []float64) (float64, error) {
+	fct, err := f.compile(params)
+	if err != nil {
+		return 0, err
+	}
+	return fct(x), nil
+}
+
+func (f *Formula) compile(params []float64) (func(x []float64) float64, error) {
+	pnames := make(map[string]int, len(f.params))
+	for k, v := range f.params {
+		pnames[k] = v
+	}
+	expr, npar, ndim, err := compileFormula(f.formula, pnames)
+	if err != nil {
+		return nil, err
+	}
+	if len(params) < npar {
+		return nil, fmt.Errorf(
+			"rhist: invalid number of parameters for formula %q (got=%d, want=%d)",
+			f.formula, len(params), npar,
+		)
+	}
+	return func(x []float64) float64 {
+		if len(x) < ndim {
+			x = append(x, make([]float64, ndim-len(x))...)
+		}
+		return expr.eval(x, params)
+	}, nil
+}
+
+func (f *Formula) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(f.RVersion())
+	if _, err := f.Named.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	writeStdVectorF64(w, f.clingParams)
+	w.WriteBool(f.allParSet)
+	{
+		pos := w.WriteVersion(rvers.StreamerInfo)
+		names := f.ParNames()
+		w.WriteI32(int32(len(names)))
+		for _, k := range names {
+			w.WriteString(k)
+			w.WriteI32(int32(f.params[k]))
+		}
+		if _, err := w.SetByteCount(pos, "map<TString,int,TFormulaParamOrder>"); err != nil {
+			return 0, err
+		}
+	}
+	w.WriteString(f.formula)
+	w.WriteI32(f.ndim)
+	{
+		pos := w.WriteVersion(rvers.StreamerInfo)
+		w.WriteI32(int32(len(f.linear)))
+		for _, obj := range f.linear {
+			if err := w.WriteObjectAny(obj); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := w.SetByteCount(pos, "vector<TObject*>"); err != nil {
+			return 0, err
+		}
+	}
+	w.WriteBool(f.vectorized)
+
+	return w.SetByteCount(pos, f.Class())
+}
+
+func (f *Formula) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(f.Class())
+	const minVers = 10
+	if vers < minVers {
+		return fmt.Errorf("rhist: TFormula version too old (%d<%d)", vers, minVers)
+	}
+
+	if err := f.Named.UnmarshalROOT(r); err != nil {
+		return err
+	}
+
+	f.clingParams = readStdVectorF64(r, f.clingParams)
+	f.allParSet = r.ReadBool()
+	{
+		beg := r.Pos()
+		vers, pos, bcnt := r.ReadVersion("map<TString,int,TFormulaParamOrder>")
+		mbrwise := vers&rbytes.StreamedMemberWise != 0
+		if mbrwise {
+			// version of std::pair<TString,int>, followed by its checksum.
+			if v := r.ReadI16(); v <= 0 {
+				_ = r.ReadU32()
+			}
+		}
+		n := int(r.ReadI32())
+		f.params = make(map[string]int, n)
+		switch {
+		case mbrwise:
+			keys := make([]string, n)
+			for i := range keys {
+				keys[i] = r.ReadString()
+			}
+			for _, k := range keys {
+				f.params[k] = int(r.ReadI32())
+			}
+		default:
+			for i := 0; i < n; i++ {
+				k := r.ReadString()
+				f.params[k] = int(r.ReadI32())
+			}
+		}
+		r.CheckByteCount(pos, bcnt, beg, "map<TString,int,TFormulaParamOrder>")
+	}
+	f.formula = r.ReadString()
+	f.ndim = r.ReadI32()
+	{
+		beg := r.Pos()
+		_, pos, bcnt := r.ReadVersion("vector<TObject*>")
+		n := int(r.ReadI32())
+		f.linear = nil
+		if n > 0 {
+			f.linear = make([]root.Object, n)
+			for i := range f.linear {
+				f.linear[i] = r.ReadObjectAny()
+			}
+		}
+		r.CheckByteCount(pos, bcnt, beg, "vector<TObject*>")
+	}
+	f.vectorized = false
+	if vers >= 12 {
+		f.vectorized = r.ReadBool()
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, f.Class())
+	return r.Err()
+}
+
+// writeStdVectorF64 writes a std::vector<double> data member.
+func writeStdVectorF64(w *rbytes.WBuffer, vs []float64) {
+	pos := w.WriteVersion(rvers.StreamerInfo)
+	w.WriteI32(int32(len(vs)))
+	w.WriteFastArrayF64(vs)
+	_, _ = w.SetByteCount(pos, "vector<double>")
+}
+
+// readStdVectorF64 reads a std::vector<double> data member.
+func readStdVectorF64(r *rbytes.RBuffer, vs []float64) []float64 {
+	beg := r.Pos()
+	_, pos, bcnt := r.ReadVersion("vector<double>")
+	n := int(r.ReadI32())
+	vs = rbytes.ResizeF64(vs, n)
+	r.ReadArrayF64(vs)
+	r.CheckByteCount(pos, bcnt, beg, "vector<double>")
+	return vs
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newFormula()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TFormula", f)
+	}
+}
+
+var (
+	_ root.Object        = (*Formula)(nil)
+	_ root.Named         = (*Formula)(nil)
+	_ rbytes.Marshaler   = (*Formula)(nil)
+	_ rbytes.Unmarshaler = (*Formula)(nil)
+)
@@ -94,6 +94,11 @@ func (g *tgraph) XY(i int) (float64, float64) {
 	return g.x[i], g.y[i]
 }
 
+// Funcs returns the list of functions (fits and user) attached to this graph.
+func (g *tgraph) Funcs() root.List {
+	return g.funcs
+}
+
 func (g *tgraph) ROOTMerge(src root.Object) error {
 	switch src := src.(type) {
 	case *tgraph:
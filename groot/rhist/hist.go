@@ -99,6 +99,11 @@ func (h *th1) SumW2s() []float64 {
 	return h.sumw2.Data
 }
 
+// Funcs returns the list of functions (fits and user) attached to this histogram.
+func (h *th1) Funcs() root.List {
+	return &h.funcs
+}
+
 func (h *th1) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	if w.Err() != nil {
 		return 0, w.Err()
@@ -325,6 +330,199 @@ func (h *th2) SumWXY() float64 {
 	return h.tsumwxy
 }
 
+type th3 struct {
+	th1
+	att3d   rbase.Att3D
+	tsumwy  float64 // total sum of weight*y
+	tsumwy2 float64 // total sum of weight*y*y
+	tsumwxy float64 // total sum of weight*x*y
+	tsumwz  float64 // total sum of weight*z
+	tsumwz2 float64 // total sum of weight*z*z
+	tsumwxz float64 // total sum of weight*x*z
+	tsumwyz float64 // total sum of weight*y*z
+}
+
+func newH3() *th3 {
+	return &th3{
+		th1:   *newH1(),
+		att3d: *rbase.NewAtt3D(),
+	}
+}
+
+func (*th3) RVersion() int16 {
+	return rvers.H3
+}
+
+func (*th3) Class() string {
+	return "TH3"
+}
+
+func (h *th3) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(h.RVersion())
+
+	for _, v := range []rbytes.Marshaler{
+		&h.th1,
+		&h.att3d,
+	} {
+		if _, err := v.MarshalROOT(w); err != nil {
+			return 0, err
+		}
+	}
+
+	w.WriteF64(h.tsumwy)
+	w.WriteF64(h.tsumwy2)
+	w.WriteF64(h.tsumwxy)
+	w.WriteF64(h.tsumwz)
+	w.WriteF64(h.tsumwz2)
+	w.WriteF64(h.tsumwxz)
+	w.WriteF64(h.tsumwyz)
+
+	return w.SetByteCount(pos, h.Class())
+}
+
+func (h *th3) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(h.Class())
+	if vers < 3 {
+		return fmt.Errorf("rhist: TH3 version too old (%d<3)", vers)
+	}
+
+	for _, v := range []rbytes.Unmarshaler{
+		&h.th1,
+		&h.att3d,
+	} {
+		if err := v.UnmarshalROOT(r); err != nil {
+			return err
+		}
+	}
+
+	h.tsumwy = r.ReadF64()
+	h.tsumwy2 = r.ReadF64()
+	h.tsumwxy = r.ReadF64()
+	h.tsumwz = r.ReadF64()
+	h.tsumwz2 = r.ReadF64()
+	h.tsumwxz = r.ReadF64()
+	h.tsumwyz = r.ReadF64()
+
+	r.CheckByteCount(pos, bcnt, beg, h.Class())
+	return r.Err()
+}
+
+// SumWY returns the total sum of weights*y
+func (h *th3) SumWY() float64 {
+	return h.tsumwy
+}
+
+// SumWY2 returns the total sum of weights*y*y
+func (h *th3) SumWY2() float64 {
+	return h.tsumwy2
+}
+
+// SumWXY returns the total sum of weights*x*y
+func (h *th3) SumWXY() float64 {
+	return h.tsumwxy
+}
+
+// SumWZ returns the total sum of weights*z
+func (h *th3) SumWZ() float64 {
+	return h.tsumwz
+}
+
+// SumWZ2 returns the total sum of weights*z*z
+func (h *th3) SumWZ2() float64 {
+	return h.tsumwz2
+}
+
+// SumWXZ returns the total sum of weights*x*z
+func (h *th3) SumWXZ() float64 {
+	return h.tsumwxz
+}
+
+// SumWYZ returns the total sum of weights*y*z
+func (h *th3) SumWYZ() float64 {
+	return h.tsumwyz
+}
+
+// add adds the statistics of o to h.
+// add returns an error if h and o do not share the same binning.
+func (h *th1) add(o *th1) error {
+	for _, ax := range []struct{ a, b *taxis }{
+		{&h.xaxis, &o.xaxis},
+		{&h.yaxis, &o.yaxis},
+		{&h.zaxis, &o.zaxis},
+	} {
+		if !ax.a.sameBinning(ax.b) {
+			return fmt.Errorf("rhist: histograms %q and %q have different %s binnings", h.Name(), o.Name(), ax.a.Name())
+		}
+	}
+
+	h.entries += o.entries
+	h.tsumw += o.tsumw
+	h.tsumw2 += o.tsumw2
+	h.tsumwx += o.tsumwx
+	h.tsumwx2 += o.tsumwx2
+	return nil
+}
+
+func (h *th2) add(o *th2) error {
+	err := h.th1.add(&o.th1)
+	if err != nil {
+		return err
+	}
+	h.tsumwy += o.tsumwy
+	h.tsumwy2 += o.tsumwy2
+	h.tsumwxy += o.tsumwxy
+	return nil
+}
+
+func (h *th3) add(o *th3) error {
+	err := h.th1.add(&o.th1)
+	if err != nil {
+		return err
+	}
+	h.tsumwy += o.tsumwy
+	h.tsumwy2 += o.tsumwy2
+	h.tsumwxy += o.tsumwxy
+	h.tsumwz += o.tsumwz
+	h.tsumwz2 += o.tsumwz2
+	h.tsumwxz += o.tsumwxz
+	h.tsumwyz += o.tsumwyz
+	return nil
+}
+
+// addSumw2 adds the sum of squares of weights of o to the ones of h.
+// hcont and ocont are the bin contents of h and o.
+// Histograms filled without weights do not carry a sum of squares of weights:
+// their bin contents are used instead.
+func (h *th1) addSumw2(o *th1, hcont, ocont func(i int) float64) {
+	switch {
+	case len(h.sumw2.Data) == 0 && len(o.sumw2.Data) == 0:
+		return
+	case len(h.sumw2.Data) == 0:
+		h.sumw2.Data = make([]float64, len(o.sumw2.Data))
+		for i := range h.sumw2.Data {
+			h.sumw2.Data[i] = hcont(i)
+		}
+	}
+
+	for i := range h.sumw2.Data {
+		switch {
+		case len(o.sumw2.Data) > 0:
+			h.sumw2.Data[i] += o.sumw2.Data[i]
+		default:
+			h.sumw2.Data[i] += ocont(i)
+		}
+	}
+}
+
 func init() {
 	{
 		f := func() reflect.Value {
@@ -340,6 +538,13 @@ func init() {
 		}
 		rtypes.Factory.Add("TH2", f)
 	}
+	{
+		f := func() reflect.Value {
+			o := newH3()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TH3", f)
+	}
 }
 
 var (
@@ -352,4 +557,9 @@ var (
 	_ root.Named         = (*th2)(nil)
 	_ rbytes.Marshaler   = (*th2)(nil)
 	_ rbytes.Unmarshaler = (*th2)(nil)
+
+	_ root.Object        = (*th3)(nil)
+	_ root.Named         = (*th3)(nil)
+	_ rbytes.Marshaler   = (*th3)(nil)
+	_ rbytes.Unmarshaler = (*th3)(nil)
 )
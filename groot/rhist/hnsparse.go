@@ -0,0 +1,803 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// defaultChunkSize is the default number of bins per THnSparseArrayChunk.
+const defaultChunkSize = 1024 * 16
+
+// thnbase implements ROOT THnBase
+type thnbase struct {
+	rbase.Named
+	ndims   int32          // number of dimensions
+	axes    rcont.ObjArray // axes of the histogram
+	entries float64        // number of entries
+	tsumw   float64        // total sum of weights
+	tsumw2  float64        // total sum of weights squared
+	tsumwx  rcont.ArrayD   // total sum of weight*X for each dimension
+	tsumwx2 rcont.ArrayD   // total sum of weight*X*X for each dimension
+}
+
+func newHnBase(name, title string, nbins []int, xmin, xmax []float64) *thnbase {
+	h := &thnbase{
+		Named: *rbase.NewNamed(name, title),
+		ndims: int32(len(nbins)),
+		axes:  *rcont.NewObjArray(),
+	}
+	axes := make([]root.Object, len(nbins))
+	for i := range nbins {
+		axis := NewAxis(fmt.Sprintf("axis%d", i))
+		axis.nbins = nbins[i]
+		axis.xmin = xmin[i]
+		axis.xmax = xmax[i]
+		axes[i] = axis
+	}
+	h.axes.SetElems(axes)
+	h.tsumwx.Data = make([]float64, len(nbins))
+	h.tsumwx2.Data = make([]float64, len(nbins))
+	return h
+}
+
+func (*thnbase) RVersion() int16 {
+	return rvers.HnBase
+}
+
+func (*thnbase) Class() string {
+	return "THnBase"
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *thnbase) Rank() int {
+	return int(h.ndims)
+}
+
+// Axis returns the i-th axis of this histogram.
+func (h *thnbase) Axis(i int) Axis {
+	return h.axis(i)
+}
+
+func (h *thnbase) axis(i int) *taxis {
+	return h.axes.At(i).(*taxis)
+}
+
+// Entries returns the number of entries for this histogram.
+func (h *thnbase) Entries() float64 {
+	return h.entries
+}
+
+// SumW returns the total sum of weights
+func (h *thnbase) SumW() float64 {
+	return h.tsumw
+}
+
+// SumW2 returns the total sum of squares of weights
+func (h *thnbase) SumW2() float64 {
+	return h.tsumw2
+}
+
+// SumWX returns the total sum of weights*x along the i-th dimension
+func (h *thnbase) SumWX(i int) float64 {
+	return h.tsumwx.Data[i]
+}
+
+// SumWX2 returns the total sum of weights*x*x along the i-th dimension
+func (h *thnbase) SumWX2(i int) float64 {
+	return h.tsumwx2.Data[i]
+}
+
+func (h *thnbase) fill(x []float64, w float64) {
+	h.entries++
+	h.tsumw += w
+	h.tsumw2 += w * w
+	for i, v := range x {
+		h.tsumwx.Data[i] += w * v
+		h.tsumwx2.Data[i] += w * v * v
+	}
+}
+
+func (h *thnbase) add(o *thnbase) error {
+	if h.ndims != o.ndims {
+		return fmt.Errorf("rhist: incompatible number of dimensions (%d != %d)", h.ndims, o.ndims)
+	}
+	for i := 0; i < int(h.ndims); i++ {
+		if !h.axis(i).sameBinning(o.axis(i)) {
+			return fmt.Errorf("rhist: incompatible binning for axis #%d", i)
+		}
+	}
+
+	h.entries += o.entries
+	h.tsumw += o.tsumw
+	h.tsumw2 += o.tsumw2
+	for i := range h.tsumwx.Data {
+		h.tsumwx.Data[i] += o.tsumwx.Data[i]
+		h.tsumwx2.Data[i] += o.tsumwx2.Data[i]
+	}
+	return nil
+}
+
+func (h *thnbase) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(h.RVersion())
+	if _, err := h.Named.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	w.WriteI32(h.ndims)
+	if _, err := h.axes.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	w.WriteF64(h.entries)
+	w.WriteF64(h.tsumw)
+	w.WriteF64(h.tsumw2)
+	for _, v := range []rbytes.Marshaler{
+		&h.tsumwx,
+		&h.tsumwx2,
+	} {
+		if _, err := v.MarshalROOT(w); err != nil {
+			return 0, err
+		}
+	}
+
+	return w.SetByteCount(pos, h.Class())
+}
+
+func (h *thnbase) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	_, pos, bcnt := r.ReadVersion(h.Class())
+
+	if err := h.Named.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	h.ndims = r.ReadI32()
+	if err := h.axes.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	h.entries = r.ReadF64()
+	h.tsumw = r.ReadF64()
+	h.tsumw2 = r.ReadF64()
+	for _, v := range []rbytes.Unmarshaler{
+		&h.tsumwx,
+		&h.tsumwx2,
+	} {
+		if err := v.UnmarshalROOT(r); err != nil {
+			return err
+		}
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, h.Class())
+	return r.Err()
+}
+
+// thnsparse implements ROOT THnSparse.
+//
+// The content of the filled bins is stored in chunks of chunkSize bins.
+// Each chunk holds the compact coordinates of its filled bins: the bin
+// index along each dimension is packed with the minimal number of bits
+// needed to represent that axis, including its under- and overflow bins.
+type thnsparse struct {
+	thnbase
+	chunkSize int32            // number of entries for each chunk
+	filled    int64            // number of filled bins
+	chunks    []*hnSparseChunk // chunks of bin contents
+
+	errors  bool             // whether to track sum of squares of weights
+	offsets []int            // bit offsets of each dimension in the compact coordinates
+	bins    map[string]int64 // compact coordinates -> linear bin index
+	ctype   string           // class name of the bin content arrays
+}
+
+func newHnSparse(name, title string, nbins []int, xmin, xmax []float64, ctype string) *thnsparse {
+	h := &thnsparse{
+		thnbase:   *newHnBase(name, title, nbins, xmin, xmax),
+		chunkSize: defaultChunkSize,
+		ctype:     ctype,
+	}
+	h.init()
+	return h
+}
+
+func (*thnsparse) RVersion() int16 {
+	return rvers.HnSparse
+}
+
+func (*thnsparse) Class() string {
+	return "THnSparse"
+}
+
+func (*thnsparse) isHnSparse() {}
+
+// NFilledBins returns the number of filled bins.
+func (h *thnsparse) NFilledBins() int64 {
+	return h.filled
+}
+
+// init computes the layout of the compact coordinates and the index
+// of the already filled bins.
+func (h *thnsparse) init() {
+	h.offsets = make([]int, h.ndims+1)
+	for i := 0; i < int(h.ndims); i++ {
+		h.offsets[i+1] = h.offsets[i] + numBits(h.axis(i).nbins+2)
+	}
+
+	h.bins = make(map[string]int64, h.filled)
+	for ichunk, c := range h.chunks {
+		if c.sumw2 != nil {
+			h.errors = true
+		}
+		n := c.len()
+		for i := 0; i < n; i++ {
+			idx := int64(ichunk)*int64(h.chunkSize) + int64(i)
+			h.bins[string(c.coord(i))] = idx
+		}
+	}
+}
+
+// coordSize returns the number of bytes of the compact coordinates of a bin.
+func (h *thnsparse) coordSize() int {
+	return (h.offsets[h.ndims] + 7) / 8
+}
+
+// encode packs the bin indices into compact coordinates.
+func (h *thnsparse) encode(idx []int) []byte {
+	buf := make([]byte, h.coordSize())
+	for i, v := range idx {
+		var (
+			beg = h.offsets[i]
+			end = h.offsets[i+1]
+		)
+		for j := beg; j < end; j++ {
+			if v&(1<<uint(j-beg)) != 0 {
+				buf[j/8] |= 1 << uint(j%8)
+			}
+		}
+	}
+	return buf
+}
+
+// decode unpacks compact coordinates into bin indices.
+func (h *thnsparse) decode(buf []byte, idx []int) {
+	for i := range idx {
+		var (
+			beg = h.offsets[i]
+			end = h.offsets[i+1]
+			v   = 0
+		)
+		for j := beg; j < end; j++ {
+			if buf[j/8]&(1<<uint(j%8)) != 0 {
+				v |= 1 << uint(j-beg)
+			}
+		}
+		idx[i] = v
+	}
+}
+
+// bin returns the chunk and the position in that chunk of the bin
+// with the provided compact coordinates.
+// If create is true, the bin is allocated if it did not exist.
+func (h *thnsparse) bin(coord []byte, create bool) (*hnSparseChunk, int) {
+	idx, ok := h.bins[string(coord)]
+	if !ok {
+		if !create {
+			return nil, -1
+		}
+		idx = h.filled
+		if int(idx/int64(h.chunkSize)) >= len(h.chunks) {
+			h.chunks = append(h.chunks, newHnSparseChunk(
+				h.coordSize(), newContent(h.ctype, int(h.chunkSize)), h.errors,
+			))
+		}
+		c := h.chunks[idx/int64(h.chunkSize)]
+		c.coords = append(c.coords, coord...)
+		h.bins[string(coord)] = idx
+		h.filled++
+	}
+	return h.chunks[idx/int64(h.chunkSize)], int(idx % int64(h.chunkSize))
+}
+
+// Fill fills this histogram with the n-dim coordinates x and weight w.
+func (h *thnsparse) Fill(x []float64, w float64) {
+	if w != 1 && !h.errors {
+		h.sumw2()
+	}
+
+	idx := make([]int, h.ndims)
+	for i, v := range x {
+		idx[i] = h.axis(i).findBin(v)
+	}
+	c, i := h.bin(h.encode(idx), true)
+	c.fill(i, w)
+	h.thnbase.fill(x, w)
+}
+
+// sumw2 enables the tracking of the sum of squares of weights.
+func (h *thnsparse) sumw2() {
+	h.errors = true
+	for _, c := range h.chunks {
+		c.sumw2 = &rcont.ArrayD{Data: make([]float64, h.chunkSize)}
+		for i := range c.sumw2.Data {
+			c.sumw2.Data[i] = contentAt(c.content, i)
+		}
+	}
+}
+
+// BinContent returns the content of the bin with the provided indices along
+// each dimension. Index 0 is the underflow bin and index nbins+1 the overflow
+// bin of the corresponding axis.
+func (h *thnsparse) BinContent(idx []int) float64 {
+	c, i := h.bin(h.encode(idx), false)
+	if c == nil {
+		return 0
+	}
+	return contentAt(c.content, i)
+}
+
+// BinError returns the error of the bin with the provided indices along
+// each dimension.
+func (h *thnsparse) BinError(idx []int) float64 {
+	c, i := h.bin(h.encode(idx), false)
+	if c == nil {
+		return 0
+	}
+	if c.sumw2 != nil {
+		return math.Sqrt(c.sumw2.Data[i])
+	}
+	return math.Sqrt(contentAt(c.content, i))
+}
+
+// FilledBin returns the bin indices and the content of the i-th filled bin.
+func (h *thnsparse) FilledBin(i int64) ([]int, float64) {
+	var (
+		c   = h.chunks[i/int64(h.chunkSize)]
+		j   = int(i % int64(h.chunkSize))
+		idx = make([]int, h.ndims)
+	)
+	h.decode(c.coord(j), idx)
+	return idx, contentAt(c.content, j)
+}
+
+func (h *thnsparse) add(o *thnsparse) error {
+	err := h.thnbase.add(&o.thnbase)
+	if err != nil {
+		return err
+	}
+
+	if o.errors && !h.errors {
+		h.sumw2()
+	}
+
+	for _, oc := range o.chunks {
+		n := oc.len()
+		for j := 0; j < n; j++ {
+			c, i := h.bin(oc.coord(j), true)
+			v := contentAt(oc.content, j)
+			contentAdd(c.content, i, v)
+			if c.sumw2 != nil {
+				switch oc.sumw2 {
+				case nil:
+					c.sumw2.Data[i] += v
+				default:
+					c.sumw2.Data[i] += oc.sumw2.Data[j]
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (h *thnsparse) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(h.RVersion())
+	if _, err := h.thnbase.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	w.WriteI32(h.chunkSize)
+	w.WriteI64(h.filled)
+
+	chunks := rcont.NewObjArray()
+	elems := make([]root.Object, len(h.chunks))
+	for i, c := range h.chunks {
+		elems[i] = c
+	}
+	chunks.SetElems(elems)
+	if _, err := chunks.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	return w.SetByteCount(pos, h.Class())
+}
+
+func (h *thnsparse) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	_, pos, bcnt := r.ReadVersion(h.Class())
+
+	if err := h.thnbase.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	h.chunkSize = r.ReadI32()
+	h.filled = r.ReadI64()
+
+	var chunks rcont.ObjArray
+	if err := chunks.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	h.chunks = make([]*hnSparseChunk, chunks.Len())
+	for i := range h.chunks {
+		c, ok := chunks.At(i).(*hnSparseChunk)
+		if !ok {
+			return fmt.Errorf("rhist: invalid THnSparse chunk #%d (%T)", i, chunks.At(i))
+		}
+		h.chunks[i] = c
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, h.Class())
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	h.init()
+	return nil
+}
+
+// hnSparseChunk implements ROOT THnSparseArrayChunk
+type hnSparseChunk struct {
+	obj     rbase.Object
+	csize   int32         // size of a single bin coordinate
+	coords  []byte        // compact bin coordinates
+	content root.Object   // bin contents (TArrayD or TArrayF)
+	sumw2   *rcont.ArrayD // bin errors
+}
+
+func newHnSparseChunk(csize int, content root.Object, errors bool) *hnSparseChunk {
+	c := &hnSparseChunk{
+		obj:     *rbase.NewObject(),
+		csize:   int32(csize),
+		content: content,
+	}
+	if errors {
+		c.sumw2 = &rcont.ArrayD{Data: make([]float64, contentLen(content))}
+	}
+	return c
+}
+
+func (*hnSparseChunk) RVersion() int16 {
+	return rvers.HnSparseArrayChunk
+}
+
+func (*hnSparseChunk) Class() string {
+	return "THnSparseArrayChunk"
+}
+
+// len returns the number of filled bins in this chunk.
+func (c *hnSparseChunk) len() int {
+	if c.csize == 0 {
+		return 0
+	}
+	return len(c.coords) / int(c.csize)
+}
+
+// coord returns the compact coordinates of the i-th bin of this chunk.
+func (c *hnSparseChunk) coord(i int) []byte {
+	return c.coords[i*int(c.csize) : (i+1)*int(c.csize)]
+}
+
+func (c *hnSparseChunk) fill(i int, w float64) {
+	contentAdd(c.content, i, w)
+	if c.sumw2 != nil {
+		c.sumw2.Data[i] += w * w
+	}
+}
+
+func (c *hnSparseChunk) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(c.RVersion())
+	if _, err := c.obj.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	w.WriteI32(c.csize)
+	w.WriteI32(int32(len(c.coords)))
+	switch len(c.coords) {
+	case 0:
+		w.WriteI8(0)
+	default:
+		w.WriteI8(1) // is-array
+		w.WriteFastArrayU8(c.coords)
+	}
+	if err := w.WriteObjectAny(c.content); err != nil {
+		return 0, err
+	}
+	var sumw2 root.Object
+	if c.sumw2 != nil {
+		sumw2 = c.sumw2
+	}
+	if err := w.WriteObjectAny(sumw2); err != nil {
+		return 0, err
+	}
+
+	return w.SetByteCount(pos, c.Class())
+}
+
+func (c *hnSparseChunk) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	_, pos, bcnt := r.ReadVersion(c.Class())
+
+	if err := c.obj.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	c.csize = r.ReadI32()
+	n := int(r.ReadI32())
+	c.coords = c.coords[:0]
+	if r.ReadI8() != 0 {
+		c.coords = rbytes.ResizeU8(c.coords, n)
+		r.ReadArrayU8(c.coords)
+	}
+
+	c.content = r.ReadObjectAny()
+	switch c.content.(type) {
+	case *rcont.ArrayD, *rcont.ArrayF:
+		// ok
+	default:
+		if r.Err() == nil {
+			return fmt.Errorf("rhist: invalid THnSparseArrayChunk content type %T", c.content)
+		}
+	}
+
+	c.sumw2 = nil
+	if sumw2 := r.ReadObjectAny(); sumw2 != nil {
+		c.sumw2 = sumw2.(*rcont.ArrayD)
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, c.Class())
+	return r.Err()
+}
+
+// HnSparseD implements ROOT THnSparseT<TArrayD>
+type HnSparseD struct {
+	thnsparse
+}
+
+// NewHnSparseD creates a new n-dim sparse histogram with nbins[i] bins
+// in [xmin[i], xmax[i]) along the i-th dimension.
+func NewHnSparseD(name, title string, nbins []int, xmin, xmax []float64) *HnSparseD {
+	return &HnSparseD{
+		thnsparse: *newHnSparse(name, title, nbins, xmin, xmax, "TArrayD"),
+	}
+}
+
+func newHnSparseD() *HnSparseD {
+	return &HnSparseD{thnsparse: thnsparse{ctype: "TArrayD"}}
+}
+
+func (*HnSparseD) RVersion() int16 {
+	return rvers.HnSparseT_TArrayD
+}
+
+// Class returns the ROOT class name.
+func (*HnSparseD) Class() string {
+	return "THnSparseT<TArrayD>"
+}
+
+func (h *HnSparseD) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(h.RVersion())
+	if _, err := h.thnsparse.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	return w.SetByteCount(pos, h.Class())
+}
+
+func (h *HnSparseD) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	_, pos, bcnt := r.ReadVersion(h.Class())
+	if err := h.thnsparse.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	r.CheckByteCount(pos, bcnt, beg, h.Class())
+	return r.Err()
+}
+
+func (h *HnSparseD) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*HnSparseD)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.HnSparseD (%T)", src.(root.Named).Name(), src)
+	}
+	return h.thnsparse.add(&hsrc.thnsparse)
+}
+
+// HnSparseF implements ROOT THnSparseT<TArrayF>
+type HnSparseF struct {
+	thnsparse
+}
+
+// NewHnSparseF creates a new n-dim sparse histogram with nbins[i] bins
+// in [xmin[i], xmax[i]) along the i-th dimension.
+func NewHnSparseF(name, title string, nbins []int, xmin, xmax []float64) *HnSparseF {
+	return &HnSparseF{
+		thnsparse: *newHnSparse(name, title, nbins, xmin, xmax, "TArrayF"),
+	}
+}
+
+func newHnSparseF() *HnSparseF {
+	return &HnSparseF{thnsparse: thnsparse{ctype: "TArrayF"}}
+}
+
+func (*HnSparseF) RVersion() int16 {
+	return rvers.HnSparseT_TArrayF
+}
+
+// Class returns the ROOT class name.
+func (*HnSparseF) Class() string {
+	return "THnSparseT<TArrayF>"
+}
+
+func (h *HnSparseF) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(h.RVersion())
+	if _, err := h.thnsparse.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	return w.SetByteCount(pos, h.Class())
+}
+
+func (h *HnSparseF) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	_, pos, bcnt := r.ReadVersion(h.Class())
+	if err := h.thnsparse.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	r.CheckByteCount(pos, bcnt, beg, h.Class())
+	return r.Err()
+}
+
+func (h *HnSparseF) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*HnSparseF)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.HnSparseF (%T)", src.(root.Named).Name(), src)
+	}
+	return h.thnsparse.add(&hsrc.thnsparse)
+}
+
+// newContent creates a new array of n bin contents of the provided class.
+func newContent(class string, n int) root.Object {
+	switch class {
+	case "TArrayD":
+		return &rcont.ArrayD{Data: make([]float64, n)}
+	case "TArrayF":
+		return &rcont.ArrayF{Data: make([]float32, n)}
+	}
+	panic(fmt.Errorf("rhist: invalid THnSparse content type %q", class))
+}
+
+func contentLen(arr root.Object) int {
+	switch arr := arr.(type) {
+	case *rcont.ArrayD:
+		return len(arr.Data)
+	case *rcont.ArrayF:
+		return len(arr.Data)
+	}
+	panic(fmt.Errorf("rhist: invalid THnSparse content type %T", arr))
+}
+
+func contentAt(arr root.Object, i int) float64 {
+	switch arr := arr.(type) {
+	case *rcont.ArrayD:
+		return arr.Data[i]
+	case *rcont.ArrayF:
+		return float64(arr.Data[i])
+	}
+	panic(fmt.Errorf("rhist: invalid THnSparse content type %T", arr))
+}
+
+func contentAdd(arr root.Object, i int, v float64) {
+	switch arr := arr.(type) {
+	case *rcont.ArrayD:
+		arr.Data[i] += v
+	case *rcont.ArrayF:
+		arr.Data[i] += float32(v)
+	default:
+		panic(fmt.Errorf("rhist: invalid THnSparse content type %T", arr))
+	}
+}
+
+// numBits returns the number of bits needed to store n.
+func numBits(n int) int {
+	r := 0
+	if n > 0 {
+		r = 1
+	}
+	for n /= 2; n != 0; n /= 2 {
+		r++
+	}
+	return r
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := &hnSparseChunk{}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("THnSparseArrayChunk", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := newHnSparseD()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("THnSparseT<TArrayD>", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := newHnSparseF()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("THnSparseT<TArrayF>", f)
+	}
+}
+
+var (
+	_ root.Object        = (*hnSparseChunk)(nil)
+	_ rbytes.Marshaler   = (*hnSparseChunk)(nil)
+	_ rbytes.Unmarshaler = (*hnSparseChunk)(nil)
+
+	_ root.Object        = (*HnSparseD)(nil)
+	_ root.Merger        = (*HnSparseD)(nil)
+	_ root.Named         = (*HnSparseD)(nil)
+	_ HnSparse           = (*HnSparseD)(nil)
+	_ rbytes.Marshaler   = (*HnSparseD)(nil)
+	_ rbytes.Unmarshaler = (*HnSparseD)(nil)
+
+	_ root.Object        = (*HnSparseF)(nil)
+	_ root.Merger        = (*HnSparseF)(nil)
+	_ root.Named         = (*HnSparseF)(nil)
+	_ HnSparse           = (*HnSparseF)(nil)
+	_ rbytes.Marshaler   = (*HnSparseF)(nil)
+	_ rbytes.Unmarshaler = (*HnSparseF)(nil)
+)
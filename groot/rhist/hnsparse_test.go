@@ -0,0 +1,199 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/root"
+)
+
+func TestHnSparse(t *testing.T) {
+	var (
+		nbins = []int{3, 4, 5}
+		xmin  = []float64{0, 0, 0}
+		xmax  = []float64{3, 4, 5}
+		fills = []struct {
+			x []float64
+			w float64
+		}{
+			{x: []float64{0.5, 0.5, 0.5}, w: 1},
+			{x: []float64{0.5, 0.5, 0.5}, w: 1},
+			{x: []float64{1.5, 3.5, 4.5}, w: 1},
+			{x: []float64{-1, 2.5, 10}, w: 2},
+			{x: []float64{2.5, 1.5, 3.5}, w: 0.5},
+		}
+	)
+
+	dir, err := ioutil.TempDir("", "groot-rhist-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tc := range []struct {
+		name string
+		new  func() rhist.HnSparse
+	}{
+		{
+			name: "THnSparseT<TArrayD>",
+			new: func() rhist.HnSparse {
+				return rhist.NewHnSparseD("hn", "my-title", nbins, xmin, xmax)
+			},
+		},
+		{
+			name: "THnSparseT<TArrayF>",
+			new: func() rhist.HnSparse {
+				return rhist.NewHnSparseF("hn", "my-title", nbins, xmin, xmax)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			type filler interface {
+				Fill(x []float64, w float64)
+				BinContent(idx []int) float64
+				BinError(idx []int) float64
+				FilledBin(i int64) ([]int, float64)
+			}
+
+			h := tc.new()
+			for _, v := range fills {
+				h.(filler).Fill(v.x, v.w)
+			}
+
+			if got, want := h.Rank(), 3; got != want {
+				t.Fatalf("invalid rank: got=%d, want=%d", got, want)
+			}
+			if got, want := h.NFilledBins(), int64(4); got != want {
+				t.Fatalf("invalid number of filled bins: got=%d, want=%d", got, want)
+			}
+			if got, want := h.Entries(), 5.0; got != want {
+				t.Fatalf("invalid entries: got=%v, want=%v", got, want)
+			}
+			if got, want := h.SumW(), 5.5; got != want {
+				t.Fatalf("invalid sumw: got=%v, want=%v", got, want)
+			}
+			if got, want := h.SumW2(), 7.25; got != want {
+				t.Fatalf("invalid sumw2: got=%v, want=%v", got, want)
+			}
+
+			for _, tc := range []struct {
+				idx []int
+				v   float64
+				err float64
+			}{
+				{idx: []int{1, 1, 1}, v: 2, err: math.Sqrt(2)},
+				{idx: []int{2, 4, 5}, v: 1, err: 1},
+				{idx: []int{0, 3, 6}, v: 2, err: 2},
+				{idx: []int{3, 2, 4}, v: 0.5, err: 0.5},
+				{idx: []int{2, 2, 2}, v: 0, err: 0},
+			} {
+				if got, want := h.(filler).BinContent(tc.idx), tc.v; got != want {
+					t.Fatalf("invalid bin content for %v: got=%v, want=%v", tc.idx, got, want)
+				}
+				if got, want := h.(filler).BinError(tc.idx), tc.err; got != want {
+					t.Fatalf("invalid bin error for %v: got=%v, want=%v", tc.idx, got, want)
+				}
+			}
+
+			fname := filepath.Join(dir, "hnsparse.root")
+			{
+				f, err := groot.Create(fname)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer f.Close()
+
+				err = f.Put("hn", h)
+				if err != nil {
+					t.Fatalf("could not write THnSparse: %+v", err)
+				}
+
+				err = f.Close()
+				if err != nil {
+					t.Fatalf("could not close file: %+v", err)
+				}
+			}
+
+			f, err := groot.Open(fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			obj, err := f.Get("hn")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := obj.Class(), tc.name; got != want {
+				t.Fatalf("invalid class: got=%q, want=%q", got, want)
+			}
+			got := obj.(rhist.HnSparse)
+
+			if got.Name() != h.Name() || got.Title() != h.Title() {
+				t.Fatalf("invalid name/title: got=(%q, %q), want=(%q, %q)", got.Name(), got.Title(), h.Name(), h.Title())
+			}
+			if got.Rank() != h.Rank() || got.NFilledBins() != h.NFilledBins() {
+				t.Fatalf("invalid rank/filled bins")
+			}
+			if got.Entries() != h.Entries() || got.SumW() != h.SumW() || got.SumW2() != h.SumW2() {
+				t.Fatalf("invalid statistics")
+			}
+			for i := 0; i < h.Rank(); i++ {
+				if got.SumWX(i) != h.SumWX(i) || got.SumWX2(i) != h.SumWX2(i) {
+					t.Fatalf("invalid statistics for dim=%d", i)
+				}
+				ga := got.Axis(i)
+				wa := h.Axis(i)
+				if ga.NBins() != wa.NBins() || ga.XMin() != wa.XMin() || ga.XMax() != wa.XMax() {
+					t.Fatalf("invalid axis #%d", i)
+				}
+			}
+			for i := int64(0); i < h.NFilledBins(); i++ {
+				gidx, gv := got.(filler).FilledBin(i)
+				widx, wv := h.(filler).FilledBin(i)
+				if !reflect.DeepEqual(gidx, widx) || gv != wv {
+					t.Fatalf("invalid bin #%d: got=(%v, %v), want=(%v, %v)", i, gidx, gv, widx, wv)
+				}
+				if got, want := got.(filler).BinError(gidx), h.(filler).BinError(widx); got != want {
+					t.Fatalf("invalid bin error #%d: got=%v, want=%v", i, got, want)
+				}
+			}
+
+			err = h.(root.Merger).ROOTMerge(got)
+			if err != nil {
+				t.Fatalf("could not merge: %+v", err)
+			}
+			if got, want := h.NFilledBins(), got.NFilledBins(); got != want {
+				t.Fatalf("invalid number of filled bins after merge: got=%d, want=%d", got, want)
+			}
+			if got, want := h.Entries(), 10.0; got != want {
+				t.Fatalf("invalid entries after merge: got=%v, want=%v", got, want)
+			}
+			for i := int64(0); i < h.NFilledBins(); i++ {
+				idx, v := h.(filler).FilledBin(i)
+				if got, want := v, 2*got.(filler).BinContent(idx); got != want {
+					t.Fatalf("invalid merged bin %v: got=%v, want=%v", idx, got, want)
+				}
+			}
+
+			bad := rhist.NewHnSparseD("hn", "", []int{3, 4, 6}, xmin, xmax)
+			if _, ok := h.(*rhist.HnSparseD); ok {
+				err = h.(root.Merger).ROOTMerge(bad)
+				if err == nil {
+					t.Fatalf("expected an error merging incompatible histograms")
+				}
+			}
+		})
+	}
+}
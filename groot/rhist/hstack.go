@@ -0,0 +1,148 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// HStack implements ROOT THStack
+type HStack struct {
+	rbase.Named
+
+	hists *rcont.List // list of histograms
+	histo H1          // pointer to histogram used for drawing axis
+	max   float64     // maximum value for plotting along y
+	min   float64     // minimum value for plotting along y
+}
+
+func newHStack() *HStack {
+	return &HStack{
+		Named: *rbase.NewNamed("", ""),
+		max:   -1111,
+		min:   -1111,
+	}
+}
+
+// NewHStack creates a new stack of the provided histograms.
+func NewHStack(name, title string, hs ...H1) *HStack {
+	objs := make([]root.Object, len(hs))
+	for i, h := range hs {
+		objs[i] = h
+	}
+	stack := newHStack()
+	stack.SetName(name)
+	stack.SetTitle(title)
+	stack.hists = rcont.NewList("", objs)
+	return stack
+}
+
+func (*HStack) RVersion() int16 {
+	return rvers.HStack
+}
+
+func (*HStack) Class() string {
+	return "THStack"
+}
+
+// Hists returns the 1-dim histograms of the stack.
+func (hs *HStack) Hists() []H1 {
+	if hs.hists == nil {
+		return nil
+	}
+	o := make([]H1, 0, hs.hists.Len())
+	for i := 0; i < hs.hists.Len(); i++ {
+		h, ok := hs.hists.At(i).(H1)
+		if !ok {
+			continue
+		}
+		o = append(o, h)
+	}
+	return o
+}
+
+func (hs *HStack) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(hs.RVersion())
+	if _, err := hs.Named.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	var hists root.Object
+	if hs.hists != nil {
+		hists = hs.hists
+	}
+	if err := w.WriteObjectAny(hists); err != nil {
+		return 0, err
+	}
+	if err := w.WriteObjectAny(hs.histo); err != nil {
+		return 0, err
+	}
+	w.WriteF64(hs.max)
+	w.WriteF64(hs.min)
+
+	return w.SetByteCount(pos, hs.Class())
+}
+
+func (hs *HStack) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(hs.Class())
+	const minVers = 2
+	if vers < minVers {
+		return fmt.Errorf("rhist: THStack version too old (%d<%d)", vers, minVers)
+	}
+
+	if err := hs.Named.UnmarshalROOT(r); err != nil {
+		return err
+	}
+
+	hs.hists = nil
+	if obj := r.ReadObjectAny(); obj != nil {
+		hs.hists = obj.(*rcont.List)
+	}
+
+	hs.histo = nil
+	if obj := r.ReadObjectAny(); obj != nil {
+		hs.histo = obj.(H1)
+	}
+
+	hs.max = r.ReadF64()
+	hs.min = r.ReadF64()
+
+	r.CheckByteCount(pos, bcnt, beg, hs.Class())
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newHStack()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("THStack", f)
+	}
+}
+
+var (
+	_ root.Object        = (*HStack)(nil)
+	_ root.Named         = (*HStack)(nil)
+	_ rbytes.Marshaler   = (*HStack)(nil)
+	_ rbytes.Unmarshaler = (*HStack)(nil)
+)
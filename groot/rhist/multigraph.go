@@ -0,0 +1,170 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// MultiGraph implements ROOT TMultiGraph
+type MultiGraph struct {
+	rbase.Named
+
+	graphs *rcont.List // list of graphs
+	funcs  *rcont.List // list of functions (fits and user)
+	histo  *H1F        // pointer to histogram used for drawing axis
+	max    float64     // maximum value for plotting along y
+	min    float64     // minimum value for plotting along y
+}
+
+func newMultiGraph() *MultiGraph {
+	return &MultiGraph{
+		Named: *rbase.NewNamed("", ""),
+		max:   -1111,
+		min:   -1111,
+	}
+}
+
+// NewMultiGraph creates a new multigraph from the provided graphs.
+func NewMultiGraph(name, title string, gs ...Graph) *MultiGraph {
+	objs := make([]root.Object, len(gs))
+	for i, g := range gs {
+		objs[i] = g
+	}
+	mg := newMultiGraph()
+	mg.SetName(name)
+	mg.SetTitle(title)
+	mg.graphs = rcont.NewList("", objs)
+	mg.funcs = rcont.NewList("", nil)
+	return mg
+}
+
+func (*MultiGraph) RVersion() int16 {
+	return rvers.MultiGraph
+}
+
+func (*MultiGraph) Class() string {
+	return "TMultiGraph"
+}
+
+// Graphs returns the graphs of the multigraph.
+func (mg *MultiGraph) Graphs() []Graph {
+	if mg.graphs == nil {
+		return nil
+	}
+	o := make([]Graph, 0, mg.graphs.Len())
+	for i := 0; i < mg.graphs.Len(); i++ {
+		g, ok := mg.graphs.At(i).(Graph)
+		if !ok {
+			continue
+		}
+		o = append(o, g)
+	}
+	return o
+}
+
+// Funcs returns the list of functions (fits and user) attached to this multigraph.
+func (mg *MultiGraph) Funcs() root.List {
+	if mg.funcs == nil {
+		return rcont.NewList("", nil)
+	}
+	return mg.funcs
+}
+
+func (mg *MultiGraph) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(mg.RVersion())
+	if _, err := mg.Named.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	for _, v := range []*rcont.List{mg.graphs, mg.funcs} {
+		var obj root.Object
+		if v != nil {
+			obj = v
+		}
+		if err := w.WriteObjectAny(obj); err != nil {
+			return 0, err
+		}
+	}
+
+	var histo root.Object
+	if mg.histo != nil {
+		histo = mg.histo
+	}
+	if err := w.WriteObjectAny(histo); err != nil {
+		return 0, err
+	}
+	w.WriteF64(mg.max)
+	w.WriteF64(mg.min)
+
+	return w.SetByteCount(pos, mg.Class())
+}
+
+func (mg *MultiGraph) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(mg.Class())
+	const minVers = 2
+	if vers < minVers {
+		return fmt.Errorf("rhist: TMultiGraph version too old (%d<%d)", vers, minVers)
+	}
+
+	if err := mg.Named.UnmarshalROOT(r); err != nil {
+		return err
+	}
+
+	mg.graphs = nil
+	if obj := r.ReadObjectAny(); obj != nil {
+		mg.graphs = obj.(*rcont.List)
+	}
+
+	mg.funcs = nil
+	if obj := r.ReadObjectAny(); obj != nil {
+		mg.funcs = obj.(*rcont.List)
+	}
+
+	mg.histo = nil
+	if obj := r.ReadObjectAny(); obj != nil {
+		mg.histo = obj.(*H1F)
+	}
+
+	mg.max = r.ReadF64()
+	mg.min = r.ReadF64()
+
+	r.CheckByteCount(pos, bcnt, beg, mg.Class())
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newMultiGraph()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TMultiGraph", f)
+	}
+}
+
+var (
+	_ root.Object        = (*MultiGraph)(nil)
+	_ root.Named         = (*MultiGraph)(nil)
+	_ rbytes.Marshaler   = (*MultiGraph)(nil)
+	_ rbytes.Unmarshaler = (*MultiGraph)(nil)
+)
@@ -0,0 +1,882 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+	"go-hep.org/x/hep/hbook"
+)
+
+// error modes of ROOT profile histograms.
+const (
+	profErrMean    = 0 // error on the mean of y (default)
+	profErrSpread  = 1 // spread of y
+	profErrSpreadI = 2 // spread of y, or 1/sqrt(12) for integer y values
+	profErrSpreadG = 3 // 1/sqrt(sum of weights), for gaussian y values with w=1/sigma^2
+)
+
+// Profile implements ROOT TProfile
+type Profile struct {
+	th1
+	arr        rcont.ArrayD // sum of weights*y per bin
+	binEntries rcont.ArrayD // sum of weights per bin
+	errMode    int32        // option to compute errors
+	ymin       float64      // lower limit in Y (if set)
+	ymax       float64      // upper limit in Y (if set)
+	tsumwy     float64      // total sum of weight*y
+	tsumwy2    float64      // total sum of weight*y*y
+	binSumw2   rcont.ArrayD // sum of squares of weights per bin
+}
+
+func newProfile() *Profile {
+	return &Profile{
+		th1: *newH1(),
+	}
+}
+
+// NewProfileFrom creates a new Profile from a hbook 1-dim profile histogram.
+func NewProfileFrom(p *hbook.P1D) *Profile {
+	var (
+		hroot = newProfile()
+		bng   = p.Binning()
+		bins  = bng.Bins()
+		nbins = len(bins)
+		edges = make([]float64, 0, nbins+1)
+		dist  = bng.Dist()
+	)
+
+	hroot.th1.entries = float64(dist.Entries())
+	hroot.th1.tsumw = dist.SumW()
+	hroot.th1.tsumw2 = dist.SumW2()
+	hroot.th1.tsumwx = dist.SumWX()
+	hroot.th1.tsumwx2 = dist.SumWX2()
+	hroot.tsumwy = dist.SumWY()
+	hroot.tsumwy2 = dist.SumWY2()
+	hroot.th1.ncells = nbins + 2
+
+	hroot.th1.xaxis.nbins = nbins
+	hroot.th1.xaxis.xmin = p.XMin()
+	hroot.th1.xaxis.xmax = p.XMax()
+
+	hroot.arr.Data = make([]float64, nbins+2)
+	hroot.th1.sumw2.Data = make([]float64, nbins+2)
+	hroot.binEntries.Data = make([]float64, nbins+2)
+	hroot.binSumw2.Data = make([]float64, nbins+2)
+
+	for i := range bins {
+		bin := &bins[i]
+		if i == 0 {
+			edges = append(edges, bin.XMin())
+		}
+		edges = append(edges, bin.XMax())
+		hroot.setDist2D(i+1, bin.Dist())
+	}
+	hroot.setDist2D(0, bng.Underflow())
+	hroot.setDist2D(nbins+1, bng.Overflow())
+
+	hroot.th1.SetName(p.Name())
+	if v, ok := p.Annotation()["title"]; ok {
+		hroot.th1.SetTitle(v.(string))
+	}
+	hroot.th1.xaxis.xbins.Data = edges
+	return hroot
+}
+
+func (*Profile) RVersion() int16 {
+	return rvers.Profile
+}
+
+func (*Profile) isH1() {}
+func (*Profile) isP1() {}
+
+// Class returns the ROOT class name.
+func (*Profile) Class() string {
+	return "TProfile"
+}
+
+func (h *Profile) Array() rcont.ArrayD {
+	return h.arr
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *Profile) Rank() int {
+	return 1
+}
+
+// NbinsX returns the number of bins in X.
+func (h *Profile) NbinsX() int {
+	return h.th1.xaxis.nbins
+}
+
+// XAxis returns the axis along X.
+func (h *Profile) XAxis() Axis {
+	return &h.th1.xaxis
+}
+
+// SumWY returns the total sum of weights*y
+func (h *Profile) SumWY() float64 {
+	return h.tsumwy
+}
+
+// SumWY2 returns the total sum of weights*y*y
+func (h *Profile) SumWY2() float64 {
+	return h.tsumwy2
+}
+
+// BinEntries returns the array of sum of weights per bin
+func (h *Profile) BinEntries() []float64 {
+	return h.binEntries.Data
+}
+
+// bin returns the regularized bin number given an x bin index.
+func (h *Profile) bin(ix int) int {
+	nx := h.th1.xaxis.nbins + 1 // overflow bin
+	switch {
+	case ix < 0:
+		ix = 0
+	case ix > nx:
+		ix = nx
+	}
+	return ix
+}
+
+// XBinCenter returns the bin center value in X.
+func (h *Profile) XBinCenter(i int) float64 {
+	return h.th1.xaxis.BinCenter(i)
+}
+
+// XBinContent returns the mean value of y in the i-th bin.
+func (h *Profile) XBinContent(i int) float64 {
+	ibin := h.bin(i)
+	sumw := h.binEntries.Data[ibin]
+	if sumw == 0 {
+		return 0
+	}
+	return h.arr.Data[ibin] / sumw
+}
+
+// XBinError returns the error on the mean value of y in the i-th bin,
+// according to the error mode of this profile.
+func (h *Profile) XBinError(i int) float64 {
+	ibin := h.bin(i)
+	return profBinError(
+		h.errMode,
+		h.arr.Data[ibin], binAt(h.th1.sumw2.Data, ibin),
+		h.binEntries.Data[ibin], h.binSumW2(ibin),
+	)
+}
+
+// XBinEntries returns the sum of weights in the i-th bin.
+func (h *Profile) XBinEntries(i int) float64 {
+	return h.binEntries.Data[h.bin(i)]
+}
+
+// XBinLowEdge returns the bin lower edge value in X.
+func (h *Profile) XBinLowEdge(i int) float64 {
+	return h.th1.xaxis.BinLowEdge(i)
+}
+
+// XBinWidth returns the bin width in X.
+func (h *Profile) XBinWidth(i int) float64 {
+	return h.th1.xaxis.BinWidth(i)
+}
+
+// binSumW2 returns the sum of squares of weights of the i-th bin.
+func (h *Profile) binSumW2(i int) float64 {
+	if len(h.binSumw2.Data) > 0 {
+		return h.binSumw2.Data[i]
+	}
+	return h.binEntries.Data[i]
+}
+
+func (h *Profile) dist2D(i int) hbook.Dist2D {
+	var (
+		xc    = h.XBinCenter(i)
+		sumw  = h.binEntries.Data[i]
+		sumw2 = h.binSumW2(i)
+		d     hbook.Dist2D
+	)
+	d.X.Dist = hbook.Dist0D{
+		N:     effEntries(sumw, sumw2),
+		SumW:  sumw,
+		SumW2: sumw2,
+	}
+	// TProfile does not record the per-bin x moments:
+	// use the bin center.
+	d.X.Stats.SumWX = sumw * xc
+	d.X.Stats.SumWX2 = sumw * xc * xc
+	d.Y.Dist = d.X.Dist
+	d.Y.Stats.SumWX = h.arr.Data[i]
+	d.Y.Stats.SumWX2 = binAt(h.th1.sumw2.Data, i)
+	d.Stats.SumWXY = xc * h.arr.Data[i]
+	return d
+}
+
+func (h *Profile) setDist2D(i int, d *hbook.Dist2D) {
+	h.arr.Data[i] = d.SumWY()
+	h.th1.sumw2.Data[i] = d.SumWY2()
+	h.binEntries.Data[i] = d.SumW()
+	h.binSumw2.Data[i] = d.SumW2()
+}
+
+// AsH1D creates a new hbook.H1D from this ROOT profile histogram.
+// The content of each bin is the mean value of y in that bin and its
+// error the one on that mean value, as TProfile::ProjectionX does.
+func (h *Profile) AsH1D() *hbook.H1D {
+	var (
+		nx = h.NbinsX()
+		hh = hbook.NewH1D(nx, h.XAxis().XMin(), h.XAxis().XMax())
+	)
+	hh.Ann = hbook.Annotation{
+		"name":  h.Name(),
+		"title": h.Title(),
+	}
+
+	proj := func(i int) hbook.Dist1D {
+		var (
+			xc = h.XBinCenter(i)
+			v  = h.XBinContent(i)
+			e  = h.XBinError(i)
+			d  hbook.Dist1D
+		)
+		d.Dist = hbook.Dist0D{
+			N:     effEntries(h.binEntries.Data[i], h.binSumW2(i)),
+			SumW:  v,
+			SumW2: e * e,
+		}
+		d.Stats.SumWX = v * xc
+		d.Stats.SumWX2 = v * xc * xc
+		return d
+	}
+
+	hh.Binning.Outflows = [2]hbook.Dist1D{
+		proj(0),      // underflow
+		proj(nx + 1), // overflow
+	}
+
+	for i := 0; i < nx; i++ {
+		bin := &hh.Binning.Bins[i]
+		xmin := h.XBinLowEdge(i + 1)
+		xmax := h.XBinWidth(i+1) + xmin
+		bin.Dist = proj(i + 1)
+		bin.Range.Min = xmin
+		bin.Range.Max = xmax
+	}
+
+	var dist hbook.Dist1D
+	for i := 0; i < nx+2; i++ {
+		d := proj(i)
+		dist.Dist.N += d.Dist.N
+		dist.Dist.SumW += d.Dist.SumW
+		dist.Dist.SumW2 += d.Dist.SumW2
+		dist.Stats.SumWX += d.Stats.SumWX
+		dist.Stats.SumWX2 += d.Stats.SumWX2
+	}
+	hh.Binning.Dist = dist
+
+	return hh
+}
+
+// AsP1D creates a new hbook.P1D from this ROOT profile histogram.
+func (h *Profile) AsP1D() *hbook.P1D {
+	var (
+		nx  = h.NbinsX()
+		p   = hbook.NewP1D(nx, h.XAxis().XMin(), h.XAxis().XMax())
+		bng = p.Binning()
+	)
+	p.Annotation()["name"] = h.Name()
+	p.Annotation()["title"] = h.Title()
+
+	*bng.Underflow() = h.dist2D(0)
+	*bng.Overflow() = h.dist2D(nx + 1)
+
+	bins := bng.Bins()
+	for i := range bins {
+		*bins[i].Dist() = h.dist2D(i + 1)
+	}
+
+	dist := bng.Dist()
+	dist.X.Dist = hbook.Dist0D{
+		N:     int64(h.Entries()),
+		SumW:  h.SumW(),
+		SumW2: h.SumW2(),
+	}
+	dist.X.Stats.SumWX = h.SumWX()
+	dist.X.Stats.SumWX2 = h.SumWX2()
+	dist.Y.Dist = dist.X.Dist
+	dist.Y.Stats.SumWX = h.SumWY()
+	dist.Y.Stats.SumWX2 = h.SumWY2()
+
+	return p
+}
+
+// MarshalYODA implements the YODAMarshaler interface.
+func (h *Profile) MarshalYODA() ([]byte, error) {
+	return h.AsP1D().MarshalYODA()
+}
+
+// UnmarshalYODA implements the YODAUnmarshaler interface.
+func (h *Profile) UnmarshalYODA(raw []byte) error {
+	var p hbook.P1D
+	err := p.UnmarshalYODA(raw)
+	if err != nil {
+		return err
+	}
+
+	*h = *NewProfileFrom(&p)
+	return nil
+}
+
+func (h *Profile) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(h.RVersion())
+
+	// TH1D base class.
+	{
+		pos := w.WriteVersion(rvers.H1D)
+		for _, v := range []rbytes.Marshaler{
+			&h.th1,
+			&h.arr,
+		} {
+			if _, err := v.MarshalROOT(w); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := w.SetByteCount(pos, "TH1D"); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := h.binEntries.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	w.WriteI32(h.errMode)
+	w.WriteF64(h.ymin)
+	w.WriteF64(h.ymax)
+	w.WriteF64(h.tsumwy)
+	w.WriteF64(h.tsumwy2)
+	if _, err := h.binSumw2.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	return w.SetByteCount(pos, h.Class())
+}
+
+func (h *Profile) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(h.Class())
+	if vers < 3 {
+		return fmt.Errorf("rhist: TProfile version too old (%d<3)", vers)
+	}
+
+	// TH1D base class.
+	{
+		beg := r.Pos()
+		_, pos, bcnt := r.ReadVersion("TH1D")
+		for _, v := range []rbytes.Unmarshaler{
+			&h.th1,
+			&h.arr,
+		} {
+			if err := v.UnmarshalROOT(r); err != nil {
+				return err
+			}
+		}
+		r.CheckByteCount(pos, bcnt, beg, "TH1D")
+	}
+
+	if err := h.binEntries.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	h.errMode = r.ReadI32()
+	h.ymin = r.ReadF64()
+	h.ymax = r.ReadF64()
+	if vers > 3 {
+		h.tsumwy = r.ReadF64()
+		h.tsumwy2 = r.ReadF64()
+	}
+	if vers > 5 {
+		if err := h.binSumw2.UnmarshalROOT(r); err != nil {
+			return err
+		}
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, h.Class())
+	return r.Err()
+}
+
+func (h *Profile) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*Profile)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.Profile (%T)", src.(root.Named).Name(), src)
+	}
+
+	err := h.th1.add(&hsrc.th1)
+	if err != nil {
+		return err
+	}
+	h.tsumwy += hsrc.tsumwy
+	h.tsumwy2 += hsrc.tsumwy2
+
+	h.th1.addSumw2(
+		&hsrc.th1,
+		func(i int) float64 { return h.arr.Data[i] },
+		func(i int) float64 { return hsrc.arr.Data[i] },
+	)
+	addBinSumw2(&h.binSumw2, &hsrc.binSumw2, h.binEntries.Data, hsrc.binEntries.Data)
+	for i := range h.arr.Data {
+		h.arr.Data[i] += hsrc.arr.Data[i]
+		h.binEntries.Data[i] += hsrc.binEntries.Data[i]
+	}
+
+	return nil
+}
+
+// Profile2D implements ROOT TProfile2D
+type Profile2D struct {
+	th2
+	arr        rcont.ArrayD // sum of weights*z per bin
+	binEntries rcont.ArrayD // sum of weights per bin
+	errMode    int32        // option to compute errors
+	zmin       float64      // lower limit in Z (if set)
+	zmax       float64      // upper limit in Z (if set)
+	tsumwz     float64      // total sum of weight*z
+	tsumwz2    float64      // total sum of weight*z*z
+	binSumw2   rcont.ArrayD // sum of squares of weights per bin
+}
+
+func newProfile2D() *Profile2D {
+	return &Profile2D{
+		th2: *newH2(),
+	}
+}
+
+func (*Profile2D) RVersion() int16 {
+	return rvers.Profile2D
+}
+
+func (*Profile2D) isH2() {}
+func (*Profile2D) isP2() {}
+
+// Class returns the ROOT class name.
+func (*Profile2D) Class() string {
+	return "TProfile2D"
+}
+
+func (h *Profile2D) Array() rcont.ArrayD {
+	return h.arr
+}
+
+// Rank returns the number of dimensions of this histogram.
+func (h *Profile2D) Rank() int {
+	return 2
+}
+
+// NbinsX returns the number of bins in X.
+func (h *Profile2D) NbinsX() int {
+	return h.th1.xaxis.nbins
+}
+
+// XAxis returns the axis along X.
+func (h *Profile2D) XAxis() Axis {
+	return &h.th1.xaxis
+}
+
+// NbinsY returns the number of bins in Y.
+func (h *Profile2D) NbinsY() int {
+	return h.th1.yaxis.nbins
+}
+
+// YAxis returns the axis along Y.
+func (h *Profile2D) YAxis() Axis {
+	return &h.th1.yaxis
+}
+
+// SumWZ returns the total sum of weights*z
+func (h *Profile2D) SumWZ() float64 {
+	return h.tsumwz
+}
+
+// SumWZ2 returns the total sum of weights*z*z
+func (h *Profile2D) SumWZ2() float64 {
+	return h.tsumwz2
+}
+
+// BinEntries returns the array of sum of weights per bin
+func (h *Profile2D) BinEntries() []float64 {
+	return h.binEntries.Data
+}
+
+// bin returns the regularized bin number given an (x,y) bin index pair.
+func (h *Profile2D) bin(ix, iy int) int {
+	nx := h.th1.xaxis.nbins + 1 // overflow bin
+	ny := h.th1.yaxis.nbins + 1 // overflow bin
+	switch {
+	case ix < 0:
+		ix = 0
+	case ix > nx:
+		ix = nx
+	}
+	switch {
+	case iy < 0:
+		iy = 0
+	case iy > ny:
+		iy = ny
+	}
+	return ix + (nx+1)*iy
+}
+
+// BinContent returns the mean value of z in the (ix,iy) bin.
+func (h *Profile2D) BinContent(ix, iy int) float64 {
+	i := h.bin(ix, iy)
+	sumw := h.binEntries.Data[i]
+	if sumw == 0 {
+		return 0
+	}
+	return h.arr.Data[i] / sumw
+}
+
+// BinError returns the error on the mean value of z in the (ix,iy) bin,
+// according to the error mode of this profile.
+func (h *Profile2D) BinError(ix, iy int) float64 {
+	i := h.bin(ix, iy)
+	return profBinError(
+		h.errMode,
+		h.arr.Data[i], binAt(h.th1.sumw2.Data, i),
+		h.binEntries.Data[i], h.binSumW2(i),
+	)
+}
+
+// BinEntriesAt returns the sum of weights in the (ix,iy) bin.
+func (h *Profile2D) BinEntriesAt(ix, iy int) float64 {
+	return h.binEntries.Data[h.bin(ix, iy)]
+}
+
+// binSumW2 returns the sum of squares of weights of the i-th bin.
+func (h *Profile2D) binSumW2(i int) float64 {
+	if len(h.binSumw2.Data) > 0 {
+		return h.binSumw2.Data[i]
+	}
+	return h.binEntries.Data[i]
+}
+
+// AsH2D creates a new hbook.H2D from this ROOT profile histogram.
+// The content of each bin is the mean value of z in that bin and its
+// error the one on that mean value, as TProfile2D::ProjectionXY does.
+func (h *Profile2D) AsH2D() *hbook.H2D {
+	var (
+		nx = h.NbinsX()
+		ny = h.NbinsY()
+		hh = hbook.NewH2D(
+			nx, h.XAxis().XMin(), h.XAxis().XMax(),
+			ny, h.YAxis().XMin(), h.YAxis().XMax(),
+		)
+		xinrange = 1
+		yinrange = 1
+	)
+	hh.Ann = hbook.Annotation{
+		"name":  h.Name(),
+		"title": h.Title(),
+	}
+
+	proj := func(ix, iy int) hbook.Dist2D {
+		var (
+			i  = h.bin(ix, iy)
+			xc = h.th1.xaxis.BinCenter(ix)
+			yc = h.th1.yaxis.BinCenter(iy)
+			v  = h.BinContent(ix, iy)
+			e  = h.BinError(ix, iy)
+			d  hbook.Dist2D
+		)
+		d.X.Dist = hbook.Dist0D{
+			N:     effEntries(h.binEntries.Data[i], h.binSumW2(i)),
+			SumW:  v,
+			SumW2: e * e,
+		}
+		d.X.Stats.SumWX = v * xc
+		d.X.Stats.SumWX2 = v * xc * xc
+		d.Y.Dist = d.X.Dist
+		d.Y.Stats.SumWX = v * yc
+		d.Y.Stats.SumWX2 = v * yc * yc
+		d.Stats.SumWXY = v * xc * yc
+		return d
+	}
+
+	hh.Binning.Outflows = [8]hbook.Dist2D{
+		proj(0, 0),
+		proj(0, yinrange),
+		proj(0, ny+1),
+		proj(nx+1, 0),
+		proj(nx+1, yinrange),
+		proj(nx+1, ny+1),
+		proj(xinrange, 0),
+		proj(xinrange, ny+1),
+	}
+
+	for ix := 0; ix < nx; ix++ {
+		for iy := 0; iy < ny; iy++ {
+			var (
+				i    = iy*nx + ix
+				xmin = h.th1.xaxis.BinLowEdge(ix + 1)
+				xmax = h.th1.xaxis.BinWidth(ix+1) + xmin
+				ymin = h.th1.yaxis.BinLowEdge(iy + 1)
+				ymax = h.th1.yaxis.BinWidth(iy+1) + ymin
+				bin  = &hh.Binning.Bins[i]
+			)
+			bin.XRange.Min = xmin
+			bin.XRange.Max = xmax
+			bin.YRange.Min = ymin
+			bin.YRange.Max = ymax
+			bin.Dist = proj(ix+1, iy+1)
+		}
+	}
+
+	var dist hbook.Dist2D
+	for ix := 0; ix < nx+2; ix++ {
+		for iy := 0; iy < ny+2; iy++ {
+			d := proj(ix, iy)
+			dist.X.Dist.N += d.X.Dist.N
+			dist.X.Dist.SumW += d.X.Dist.SumW
+			dist.X.Dist.SumW2 += d.X.Dist.SumW2
+			dist.X.Stats.SumWX += d.X.Stats.SumWX
+			dist.X.Stats.SumWX2 += d.X.Stats.SumWX2
+			dist.Y.Stats.SumWX += d.Y.Stats.SumWX
+			dist.Y.Stats.SumWX2 += d.Y.Stats.SumWX2
+			dist.Stats.SumWXY += d.Stats.SumWXY
+		}
+	}
+	dist.Y.Dist = dist.X.Dist
+	hh.Binning.Dist = dist
+
+	return hh
+}
+
+func (h *Profile2D) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(h.RVersion())
+
+	// TH2D base class.
+	{
+		pos := w.WriteVersion(rvers.H2D)
+		for _, v := range []rbytes.Marshaler{
+			&h.th2,
+			&h.arr,
+		} {
+			if _, err := v.MarshalROOT(w); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := w.SetByteCount(pos, "TH2D"); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := h.binEntries.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+	w.WriteI32(h.errMode)
+	w.WriteF64(h.zmin)
+	w.WriteF64(h.zmax)
+	w.WriteF64(h.tsumwz)
+	w.WriteF64(h.tsumwz2)
+	if _, err := h.binSumw2.MarshalROOT(w); err != nil {
+		return 0, err
+	}
+
+	return w.SetByteCount(pos, h.Class())
+}
+
+func (h *Profile2D) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(h.Class())
+	if vers < 3 {
+		return fmt.Errorf("rhist: TProfile2D version too old (%d<3)", vers)
+	}
+
+	// TH2D base class.
+	{
+		beg := r.Pos()
+		_, pos, bcnt := r.ReadVersion("TH2D")
+		for _, v := range []rbytes.Unmarshaler{
+			&h.th2,
+			&h.arr,
+		} {
+			if err := v.UnmarshalROOT(r); err != nil {
+				return err
+			}
+		}
+		r.CheckByteCount(pos, bcnt, beg, "TH2D")
+	}
+
+	if err := h.binEntries.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	h.errMode = r.ReadI32()
+	h.zmin = r.ReadF64()
+	h.zmax = r.ReadF64()
+	h.tsumwz = r.ReadF64()
+	h.tsumwz2 = r.ReadF64()
+	if vers > 6 {
+		if err := h.binSumw2.UnmarshalROOT(r); err != nil {
+			return err
+		}
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, h.Class())
+	return r.Err()
+}
+
+func (h *Profile2D) ROOTMerge(src root.Object) error {
+	hsrc, ok := src.(*Profile2D)
+	if !ok {
+		return fmt.Errorf("rhist: object %q is not a *rhist.Profile2D (%T)", src.(root.Named).Name(), src)
+	}
+
+	err := h.th2.add(&hsrc.th2)
+	if err != nil {
+		return err
+	}
+	h.tsumwz += hsrc.tsumwz
+	h.tsumwz2 += hsrc.tsumwz2
+
+	h.th1.addSumw2(
+		&hsrc.th1,
+		func(i int) float64 { return h.arr.Data[i] },
+		func(i int) float64 { return hsrc.arr.Data[i] },
+	)
+	addBinSumw2(&h.binSumw2, &hsrc.binSumw2, h.binEntries.Data, hsrc.binEntries.Data)
+	for i := range h.arr.Data {
+		h.arr.Data[i] += hsrc.arr.Data[i]
+		h.binEntries.Data[i] += hsrc.binEntries.Data[i]
+	}
+
+	return nil
+}
+
+// profBinError returns the error of a profile bin, following
+// TProfile::GetBinError (without the low statistics approximations.)
+// sumwy and sumwy2 are the sums of w*y and w*y*y in that bin, sumw and sumw2
+// the sums of w and w*w.
+func profBinError(mode int32, sumwy, sumwy2, sumw, sumw2 float64) float64 {
+	if sumw == 0 {
+		return 0
+	}
+
+	if mode == profErrSpreadG {
+		return 1 / math.Sqrt(sumw)
+	}
+
+	var (
+		mean   = sumwy / sumw
+		spread = math.Sqrt(math.Abs(sumwy2/sumw - mean*mean))
+		neff   = sumw * sumw / sumw2
+	)
+
+	switch mode {
+	case profErrSpread:
+		return spread
+	case profErrSpreadI:
+		if spread == 0 {
+			spread = 1 / math.Sqrt(12)
+		}
+		return spread / math.Sqrt(neff)
+	default:
+		return spread / math.Sqrt(neff)
+	}
+}
+
+// effEntries returns the number of effective entries (sumw^2/sumw2).
+func effEntries(sumw, sumw2 float64) int64 {
+	if sumw2 == 0 {
+		return 0
+	}
+	return int64(sumw*sumw/sumw2 + 0.5)
+}
+
+// binAt returns the i-th value of vs, or 0 if vs is empty.
+func binAt(vs []float64, i int) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+	return vs[i]
+}
+
+// addBinSumw2 adds the per-bin sum of squares of weights of src to the ones of dst.
+// Profiles filled without weights do not carry them: their per-bin sum of
+// weights (dstw, srcw) is used instead.
+func addBinSumw2(dst, src *rcont.ArrayD, dstw, srcw []float64) {
+	switch {
+	case len(dst.Data) == 0 && len(src.Data) == 0:
+		return
+	case len(dst.Data) == 0:
+		dst.Data = make([]float64, len(dstw))
+		copy(dst.Data, dstw)
+	}
+
+	for i := range dst.Data {
+		switch {
+		case len(src.Data) > 0:
+			dst.Data[i] += src.Data[i]
+		default:
+			dst.Data[i] += srcw[i]
+		}
+	}
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := newProfile()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TProfile", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := newProfile2D()
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TProfile2D", f)
+	}
+}
+
+var (
+	_ root.Object        = (*Profile)(nil)
+	_ root.Merger        = (*Profile)(nil)
+	_ root.Named         = (*Profile)(nil)
+	_ H1                 = (*Profile)(nil)
+	_ P1                 = (*Profile)(nil)
+	_ rbytes.Marshaler   = (*Profile)(nil)
+	_ rbytes.Unmarshaler = (*Profile)(nil)
+
+	_ root.Object        = (*Profile2D)(nil)
+	_ root.Merger        = (*Profile2D)(nil)
+	_ root.Named         = (*Profile2D)(nil)
+	_ H2                 = (*Profile2D)(nil)
+	_ P2                 = (*Profile2D)(nil)
+	_ rbytes.Marshaler   = (*Profile2D)(nil)
+	_ rbytes.Unmarshaler = (*Profile2D)(nil)
+)
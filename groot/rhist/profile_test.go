@@ -0,0 +1,117 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/hbook"
+)
+
+func newP1D() *hbook.P1D {
+	p := hbook.NewP1D(5, 0, 5)
+	p.Annotation()["name"] = "p1"
+	p.Annotation()["title"] = "my-title"
+	for i, v := range [][2]float64{
+		{0.5, 1},
+		{0.5, 3},
+		{2.5, 2},
+		{4.5, -2},
+		{-1, 10},
+		{6, 20},
+	} {
+		p.Fill(v[0], v[1], float64(i+1))
+	}
+	return p
+}
+
+func TestProfile(t *testing.T) {
+	var (
+		want = newP1D()
+		p1   = rhist.NewProfileFrom(want)
+	)
+
+	if got, want := p1.Name(), "p1"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		i    int
+		mean float64
+	}{
+		{i: 0, mean: 10},
+		{i: 1, mean: (1*1 + 2*3) / 3.0},
+		{i: 2, mean: 0},
+		{i: 3, mean: 2},
+		{i: 5, mean: -2},
+		{i: 6, mean: 20},
+	} {
+		if got, want := p1.XBinContent(tc.i), tc.mean; math.Abs(got-want) > 1e-12 {
+			t.Fatalf("invalid bin content #%d: got=%v, want=%v", tc.i, got, want)
+		}
+	}
+
+	// error on the mean of y, for the 2 entries of bin #1.
+	var (
+		sumw   = 1.0 + 2.0
+		sumw2  = 1.0 + 4.0
+		mean   = (1*1 + 2*3) / sumw
+		spread = math.Sqrt((1*1*1+2*3*3)/sumw - mean*mean)
+	)
+	if got, want := p1.XBinError(1), spread/math.Sqrt(sumw*sumw/sumw2); math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid bin error: got=%v, want=%v", got, want)
+	}
+
+	got := p1.AsP1D()
+	if got.Entries() != want.Entries() {
+		t.Fatalf("invalid entries: got=%d, want=%d", got.Entries(), want.Entries())
+	}
+	if got.SumW() != want.SumW() || got.SumW2() != want.SumW2() {
+		t.Fatalf("invalid sum of weights: got=(%v, %v), want=(%v, %v)", got.SumW(), got.SumW2(), want.SumW(), want.SumW2())
+	}
+	if got.XMean() != want.XMean() {
+		t.Fatalf("invalid x-mean: got=%v, want=%v", got.XMean(), want.XMean())
+	}
+	for i := range want.Binning().Bins() {
+		var (
+			g = got.Binning().Bins()[i]
+			w = want.Binning().Bins()[i]
+		)
+		if g.SumW() != w.SumW() || g.SumW2() != w.SumW2() || g.XMin() != w.XMin() || g.XMax() != w.XMax() {
+			t.Fatalf("invalid bin #%d:\ngot= %+v\nwant=%+v", i, g, w)
+		}
+		if g.Dist().SumWY() != w.Dist().SumWY() || g.Dist().SumWY2() != w.Dist().SumWY2() {
+			t.Fatalf("invalid y-moments for bin #%d:\ngot= %+v\nwant=%+v", i, g, w)
+		}
+	}
+
+	h1 := p1.AsH1D()
+	for i, bin := range h1.Binning.Bins {
+		if got, want := bin.SumW(), p1.XBinContent(i+1); got != want {
+			t.Fatalf("invalid projected bin #%d: got=%v, want=%v", i, got, want)
+		}
+	}
+
+	err := p1.ROOTMerge(rhist.NewProfileFrom(newP1D()))
+	if err != nil {
+		t.Fatalf("could not merge profiles: %+v", err)
+	}
+	if got, want := p1.Entries(), 2*float64(want.Entries()); got != want {
+		t.Fatalf("invalid merged entries: got=%v, want=%v", got, want)
+	}
+	if got, want := p1.XBinContent(1), (1*1+2*3)/3.0; math.Abs(got-want) > 1e-12 {
+		t.Fatalf("invalid merged bin content: got=%v, want=%v", got, want)
+	}
+	if got, want := p1.BinEntries()[1], 2*sumw; got != want {
+		t.Fatalf("invalid merged bin entries: got=%v, want=%v", got, want)
+	}
+
+	err = p1.ROOTMerge(rhist.NewProfileFrom(hbook.NewP1D(4, 0, 5)))
+	if err == nil {
+		t.Fatalf("expected an error merging profiles with different binnings")
+	}
+}
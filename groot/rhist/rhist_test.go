@@ -9,6 +9,7 @@ import (
 	"go-hep.org/x/hep/groot/rbase"
 	"go-hep.org/x/hep/groot/rcont"
 	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/hbook"
 )
 
 var HistoTestCases = []struct {
@@ -202,4 +203,204 @@ var HistoTestCases = []struct {
 			},
 		},
 	},
+	{
+		Name: "TH3F",
+		Want: newTestH3F(),
+	},
+	{
+		Name: "TProfile",
+		Want: newTestProfile(),
+	},
+	{
+		Name: "TProfile2D",
+		Want: newTestProfile2D(),
+	},
+}
+
+func newTestH3F() *H3F {
+	h := newH3F()
+	h.SetName("h3f")
+	h.SetTitle("my-title")
+	h.funcs = *rcont.NewList("", []root.Object{})
+	for _, axis := range []*taxis{&h.xaxis, &h.yaxis, &h.zaxis} {
+		axis.nbins = 2
+		axis.xmin = 0
+		axis.xmax = 2
+	}
+	h.ncells = 4 * 4 * 4
+	h.arr.Data = make([]float32, h.ncells)
+	h.sumw2.Data = make([]float64, h.ncells)
+
+	for i, v := range [][3]float64{
+		{0.5, 0.5, 0.5},
+		{1.5, 0.5, 1.5},
+		{1.5, 1.5, 1.5},
+		{-1, 3, 1},
+	} {
+		var (
+			x, y, z = v[0], v[1], v[2]
+			w       = float64(i + 1)
+			ibin    = h.bin(h.xaxis.findBin(x), h.yaxis.findBin(y), h.zaxis.findBin(z))
+		)
+		h.arr.Data[ibin] += float32(w)
+		h.sumw2.Data[ibin] += w * w
+		h.entries++
+		h.tsumw += w
+		h.tsumw2 += w * w
+		h.tsumwx += w * x
+		h.tsumwx2 += w * x * x
+		h.tsumwy += w * y
+		h.tsumwy2 += w * y * y
+		h.tsumwxy += w * x * y
+		h.tsumwz += w * z
+		h.tsumwz2 += w * z * z
+		h.tsumwxz += w * x * z
+		h.tsumwyz += w * y * z
+	}
+	return h
+}
+
+func newTestProfile() *Profile {
+	p := hbook.NewP1D(5, 0, 5)
+	p.Annotation()["name"] = "p1"
+	p.Annotation()["title"] = "my-title"
+	for i, v := range [][2]float64{
+		{0.5, 1},
+		{0.5, 3},
+		{2.5, 2},
+		{4.5, -2},
+		{-1, 10},
+		{6, 20},
+	} {
+		p.Fill(v[0], v[1], float64(i+1))
+	}
+
+	h := NewProfileFrom(p)
+	h.funcs = *rcont.NewList("", []root.Object{})
+	return h
+}
+
+func newTestProfile2D() *Profile2D {
+	h := newProfile2D()
+	h.SetName("p2")
+	h.SetTitle("my-title")
+	h.funcs = *rcont.NewList("", []root.Object{})
+	h.xaxis.nbins = 2
+	h.xaxis.xmin = 0
+	h.xaxis.xmax = 2
+	h.yaxis.nbins = 3
+	h.yaxis.xmin = 0
+	h.yaxis.xmax = 3
+	h.ncells = 4 * 5
+	h.arr.Data = make([]float64, h.ncells)
+	h.sumw2.Data = make([]float64, h.ncells)
+	h.binEntries.Data = make([]float64, h.ncells)
+	h.binSumw2.Data = make([]float64, h.ncells)
+
+	for i, v := range [][3]float64{
+		{0.5, 0.5, 1},
+		{0.5, 0.5, 3},
+		{1.5, 2.5, 2},
+		{-1, 1.5, 4},
+	} {
+		var (
+			x, y, z = v[0], v[1], v[2]
+			w       = float64(i + 1)
+			ibin    = h.bin(h.xaxis.findBin(x), h.yaxis.findBin(y))
+		)
+		h.arr.Data[ibin] += w * z
+		h.sumw2.Data[ibin] += w * z * z
+		h.binEntries.Data[ibin] += w
+		h.binSumw2.Data[ibin] += w * w
+		h.entries++
+		h.tsumw += w
+		h.tsumw2 += w * w
+		h.tsumwx += w * x
+		h.tsumwx2 += w * x * x
+		h.tsumwy += w * y
+		h.tsumwy2 += w * y * y
+		h.tsumwxy += w * x * y
+		h.tsumwz += w * z
+		h.tsumwz2 += w * z * z
+	}
+	return h
+}
+
+func newTestF1() *F1 {
+	f, err := NewF1("f1", "gaus(0)+pol1(3)", -5, 5)
+	if err != nil {
+		panic(err)
+	}
+	f.SetParams(10, 0.5, 2, 1, -0.1)
+	f.parErrs = []float64{0.1, 0.01, 0.02, 0.3, 0.01}
+	f.parMin[2] = 0
+	f.parMax[2] = 5
+	f.chi2 = 42
+	f.ndf = 40
+	f.npfits = 45
+	return f
+}
+
+func newTestHbookH1D(name string, ws ...float64) *hbook.H1D {
+	h := hbook.NewH1D(len(ws), 0, float64(len(ws)))
+	h.Annotation()["name"] = name
+	for i, w := range ws {
+		h.Fill(float64(i)+0.5, w)
+	}
+	return h
+}
+
+func newTestH1D(name string, ws ...float64) *H1D {
+	h := NewH1DFrom(newTestHbookH1D(name, ws...))
+	h.funcs = *rcont.NewList("", []root.Object{})
+	return h
+}
+
+func newTestEfficiency() *Efficiency {
+	e, err := NewEfficiencyFrom(
+		newTestHbookH1D("eff", 1, 2, 0, 4),
+		newTestHbookH1D("total", 2, 4, 1, 4),
+	)
+	if err != nil {
+		panic(err)
+	}
+	e.funcs = *rcont.NewList("", []root.Object{})
+	e.passed.(*H1D).funcs = *rcont.NewList("", []root.Object{})
+	e.total.(*H1D).funcs = *rcont.NewList("", []root.Object{})
+	e.betaBins = [][2]float64{{1, 1}, {2, 3}}
+	return e
+}
+
+func newTestHStack() *HStack {
+	hs := NewHStack("hs", "my-stack",
+		newTestH1D("h1", 1, 2, 3),
+		newTestH1D("h2", 3, 2, 1),
+	)
+	hs.histo = newTestH1D("axis", 0, 0, 0)
+	return hs
+}
+
+func newTestMultiGraph() *MultiGraph {
+	g1 := NewGraphFrom(hbook.NewS2DFrom([]float64{1, 2, 3}, []float64{2, 4, 6})).(*tgraph)
+	g1.funcs = rcont.NewList("", []root.Object{})
+	g2 := NewGraphErrorsFrom(hbook.NewS2D(
+		hbook.Point2D{X: 1, Y: 1, ErrX: hbook.Range{Min: 0.5, Max: 0.5}, ErrY: hbook.Range{Min: 1, Max: 1}},
+		hbook.Point2D{X: 2, Y: 3, ErrX: hbook.Range{Min: 0.5, Max: 0.5}, ErrY: hbook.Range{Min: 2, Max: 2}},
+	)).(*tgrapherrs)
+	g2.funcs = rcont.NewList("", []root.Object{})
+
+	mg := NewMultiGraph("mg", "my-multigraph", g1, g2)
+	mg.funcs = rcont.NewList("", []root.Object{})
+	return mg
+}
+
+func newTestCanvas() *Canvas {
+	pad := NewPad("c1_1", "sub-pad", newTestH1D("h2", 3, 2, 1))
+	pad.xlowNDC = 0.5
+	pad.number = 1
+	c := NewCanvas("c1", "my-canvas", newTestH1D("h1", 1, 2, 3), pad)
+	c.gridx = true
+	c.logy = 1
+	c.pix[3] = 42
+	return c
 }
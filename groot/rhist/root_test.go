@@ -0,0 +1,746 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rhist_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/internal/rtests"
+	"go-hep.org/x/hep/groot/rcmd"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/hbook"
+)
+
+// refs holds the reference values printed by a ROOT macro, as
+// "ref: <key> = <v1> <v2> ..." lines.
+type refs map[string][]float64
+
+// genROOTFile runs the provided ROOT macro to create a ROOT file under dir,
+// and returns the name of that file together with the reference values
+// printed by the macro.
+func genROOTFile(t *testing.T, dir, fct, code string) (string, refs) {
+	t.Helper()
+
+	fname := filepath.Join(dir, fct+".root")
+	out, err := rtests.RunCxxROOT(fct, []byte(code), fname)
+	if err != nil {
+		t.Fatalf("could not run ROOT macro: %+v\noutput:\n%s", err, out)
+	}
+
+	ref := make(refs)
+	for _, line := range strings.Split(string(out), "\n") {
+		if !strings.HasPrefix(line, "ref: ") {
+			continue
+		}
+		toks := strings.SplitN(strings.TrimPrefix(line, "ref: "), " = ", 2)
+		if len(toks) != 2 {
+			t.Fatalf("invalid reference line %q", line)
+		}
+		var vs []float64
+		for _, tok := range strings.Fields(toks[1]) {
+			v, err := strconv.ParseFloat(tok, 64)
+			if err != nil {
+				t.Fatalf("invalid reference value in %q: %+v", line, err)
+			}
+			vs = append(vs, v)
+		}
+		ref[toks[0]] = vs
+	}
+	if len(ref) == 0 {
+		t.Fatalf("no reference values in ROOT macro output:\n%s", out)
+	}
+
+	return fname, ref
+}
+
+// check compares the values decoded by groot with the reference ones.
+func (ref refs) check(t *testing.T, key string, got ...float64) {
+	t.Helper()
+	ref.near(t, key, 1e-12, got...)
+}
+
+// near compares the values decoded by groot with the reference ones,
+// within the provided relative tolerance.
+func (ref refs) near(t *testing.T, key string, tol float64, got ...float64) {
+	t.Helper()
+	want := ref[key]
+	if len(got) != len(want) {
+		t.Errorf("%s: invalid number of values: got=%d, want=%d", key, len(got), len(want))
+		return
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > tol*math.Max(1, math.Abs(want[i])) {
+			t.Errorf("%s: invalid value[%d]:\ngot= %v\nwant=%v", key, i, got, want)
+			return
+		}
+	}
+}
+
+func atois(t *testing.T, toks []string) []int {
+	t.Helper()
+	o := make([]int, len(toks))
+	for i, tok := range toks {
+		v, err := strconv.Atoi(tok)
+		if err != nil {
+			t.Fatalf("invalid index %q: %+v", tok, err)
+		}
+		o[i] = v
+	}
+	return o
+}
+
+func TestReadH3ProfileHnSparseFromROOT(t *testing.T) {
+	if !rtests.HasROOT {
+		t.Skip("ROOT not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "groot-rhist-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname, ref := genROOTFile(t, dir, "gen_hists", `
+#include "TFile.h"
+#include "TH3.h"
+#include "TProfile.h"
+#include "TProfile2D.h"
+#include "THnSparse.h"
+
+#include <cstdio>
+
+void dumpH3(TH3 *h) {
+	const char *n = h->GetName();
+	double s[11];
+	h->GetStats(s);
+	printf("ref: %s entries = %.17g\n", n, h->GetEntries());
+	printf("ref: %s stats =", n);
+	for (int i = 0; i < 11; i++) {
+		printf(" %.17g", s[i]);
+	}
+	printf("\n");
+	for (int ix = 0; ix <= h->GetNbinsX()+1; ix++) {
+		for (int iy = 0; iy <= h->GetNbinsY()+1; iy++) {
+			for (int iz = 0; iz <= h->GetNbinsZ()+1; iz++) {
+				printf("ref: %s bin %d %d %d = %.17g %.17g\n", n, ix, iy, iz,
+					h->GetBinContent(ix, iy, iz), h->GetBinError(ix, iy, iz));
+			}
+		}
+	}
+}
+
+void dumpP1(TProfile *p) {
+	const char *n = p->GetName();
+	double s[6];
+	p->GetStats(s);
+	printf("ref: %s entries = %.17g\n", n, p->GetEntries());
+	printf("ref: %s stats =", n);
+	for (int i = 0; i < 6; i++) {
+		printf(" %.17g", s[i]);
+	}
+	printf("\n");
+	for (int i = 0; i <= p->GetNbinsX()+1; i++) {
+		printf("ref: %s bin %d = %.17g %.17g %.17g\n", n, i,
+			p->GetBinContent(i), p->GetBinError(i), p->GetBinEntries(i));
+	}
+}
+
+void dumpP2(TProfile2D *p) {
+	const char *n = p->GetName();
+	double s[9];
+	p->GetStats(s);
+	printf("ref: %s entries = %.17g\n", n, p->GetEntries());
+	printf("ref: %s stats =", n);
+	for (int i = 0; i < 9; i++) {
+		printf(" %.17g", s[i]);
+	}
+	printf("\n");
+	for (int ix = 0; ix <= p->GetNbinsX()+1; ix++) {
+		for (int iy = 0; iy <= p->GetNbinsY()+1; iy++) {
+			printf("ref: %s bin %d %d = %.17g %.17g %.17g\n", n, ix, iy,
+				p->GetBinContent(ix, iy), p->GetBinError(ix, iy),
+				p->GetBinEntries(p->GetBin(ix, iy)));
+		}
+	}
+}
+
+void dumpHn(THnSparse *h) {
+	const char *n = h->GetName();
+	printf("ref: %s entries = %.17g\n", n, h->GetEntries());
+	printf("ref: %s nfilled = %lld\n", n, h->GetNbins());
+	printf("ref: %s stats = %.17g %.17g", n, h->GetSumw(), h->GetSumw2());
+	for (int i = 0; i < h->GetNdimensions(); i++) {
+		printf(" %.17g %.17g", h->GetSumwx(i), h->GetSumwx2(i));
+	}
+	printf("\n");
+	Int_t idx[3];
+	for (Long64_t i = 0; i < h->GetNbins(); i++) {
+		double c = h->GetBinContent(i, idx);
+		printf("ref: %s bin %d %d %d = %.17g %.17g\n", n, idx[0], idx[1], idx[2],
+			c, h->GetBinError(i));
+	}
+}
+
+void gen_hists(const char *fname) {
+	auto f = TFile::Open(fname, "RECREATE");
+
+	auto h3d = new TH3D("h3d", "h3d-title", 3, 0, 3, 4, 0, 4, 2, 0, 2);
+	h3d->Sumw2();
+	auto h3f = new TH3F("h3f", "h3f-title", 3, 0, 3, 4, 0, 4, 2, 0, 2);
+	auto h3i = new TH3I("h3i", "h3i-title", 3, 0, 3, 4, 0, 4, 2, 0, 2);
+
+	auto p1  = new TProfile("p1", "p1-title", 4, 0, 4);
+	auto p1s = new TProfile("p1s", "p1s-title", 4, 0, 4, "s");
+	auto p2  = new TProfile2D("p2", "p2-title", 3, 0, 3, 2, 0, 2);
+
+	Int_t    nbins[3] = {3, 4, 5};
+	Double_t xmin[3]  = {0, 0, 0};
+	Double_t xmax[3]  = {3, 4, 5};
+	auto hnd = new THnSparseD("hnd", "hnd-title", 3, nbins, xmin, xmax);
+	hnd->Sumw2();
+	auto hnf = new THnSparseF("hnf", "hnf-title", 3, nbins, xmin, xmax);
+
+	for (int i = 0; i < 100; i++) {
+		double x = -0.5 + 0.04*i;
+		double y = -0.5 + 0.05*i;
+		double z = -0.2 + 0.025*i;
+		double w = 1 + 0.5*(i%3);
+		h3d->Fill(x, y, z, w);
+		h3f->Fill(x, y, z);
+		h3i->Fill(x, y, z);
+		p1->Fill(x, y, w);
+		p1s->Fill(x, y);
+		p2->Fill(x, z, y, w);
+		double xs[3] = {x, y, 2*z};
+		hnd->Fill(xs, w);
+		hnf->Fill(xs);
+	}
+
+	dumpH3(h3d);
+	dumpH3(h3f);
+	dumpH3(h3i);
+	dumpP1(p1);
+	dumpP1(p1s);
+	dumpP2(p2);
+	dumpHn(hnd);
+	dumpHn(hnf);
+
+	f->WriteTObject(hnd);
+	f->WriteTObject(hnf);
+	f->Write();
+	f->Close();
+}
+`)
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	objs := make(map[string]root.Object)
+	for _, name := range []string{"h3d", "h3f", "h3i", "p1", "p1s", "p2", "hnd", "hnf"} {
+		o, err := f.Get(name)
+		if err != nil {
+			t.Fatalf("could not retrieve %q: %+v", name, err)
+		}
+		if got, want := o.(root.Named).Title(), name+"-title"; got != want {
+			t.Fatalf("invalid title for %q: got=%q, want=%q", name, got, want)
+		}
+		objs[name] = o
+	}
+
+	for key := range ref {
+		var (
+			toks = strings.Fields(key)
+			idx  = atois(t, toks[2:])
+		)
+		switch o := objs[toks[0]].(type) {
+		case rhist.H3:
+			h := o.(interface {
+				BinContent(ix, iy, iz int) float64
+				BinError(ix, iy, iz int) float64
+			})
+			switch toks[1] {
+			case "entries":
+				ref.check(t, key, o.Entries())
+			case "stats":
+				ref.check(t, key,
+					o.SumW(), o.SumW2(), o.SumWX(), o.SumWX2(), o.SumWY(), o.SumWY2(),
+					o.SumWXY(), o.SumWZ(), o.SumWZ2(), o.SumWXZ(), o.SumWYZ(),
+				)
+			case "bin":
+				ref.check(t, key, h.BinContent(idx[0], idx[1], idx[2]), h.BinError(idx[0], idx[1], idx[2]))
+			}
+
+		case *rhist.Profile:
+			switch toks[1] {
+			case "entries":
+				ref.check(t, key, o.Entries())
+			case "stats":
+				ref.check(t, key, o.SumW(), o.SumW2(), o.SumWX(), o.SumWX2(), o.SumWY(), o.SumWY2())
+			case "bin":
+				i := idx[0]
+				ref.check(t, key, o.XBinContent(i), o.XBinError(i), o.XBinEntries(i))
+			}
+
+		case *rhist.Profile2D:
+			switch toks[1] {
+			case "entries":
+				ref.check(t, key, o.Entries())
+			case "stats":
+				ref.check(t, key,
+					o.SumW(), o.SumW2(), o.SumWX(), o.SumWX2(), o.SumWY(), o.SumWY2(),
+					o.SumWXY(), o.SumWZ(), o.SumWZ2(),
+				)
+			case "bin":
+				ix, iy := idx[0], idx[1]
+				ref.check(t, key, o.BinContent(ix, iy), o.BinError(ix, iy), o.BinEntriesAt(ix, iy))
+			}
+
+		case rhist.HnSparse:
+			h := o.(interface {
+				BinContent(idx []int) float64
+				BinError(idx []int) float64
+			})
+			switch toks[1] {
+			case "entries":
+				ref.check(t, key, o.Entries())
+			case "nfilled":
+				ref.check(t, key, float64(o.NFilledBins()))
+			case "stats":
+				got := []float64{o.SumW(), o.SumW2()}
+				for i := 0; i < o.Rank(); i++ {
+					got = append(got, o.SumWX(i), o.SumWX2(i))
+				}
+				ref.check(t, key, got...)
+			case "bin":
+				ref.check(t, key, h.BinContent(idx), h.BinError(idx))
+			}
+
+		default:
+			t.Fatalf("unknown reference key %q", key)
+		}
+	}
+
+	// root2yoda: profiles are converted to YODA profiles with the same bin means.
+	for _, name := range []string{"p1", "p1s"} {
+		raw, err := objs[name].(*rhist.Profile).MarshalYODA()
+		if err != nil {
+			t.Fatalf("could not convert %q to YODA: %+v", name, err)
+		}
+		var p hbook.P1D
+		err = p.UnmarshalYODA(raw)
+		if err != nil {
+			t.Fatalf("could not read YODA profile %q: %+v", name, err)
+		}
+		for i, bin := range p.Binning().Bins() {
+			var (
+				key  = fmt.Sprintf("%s bin %d", name, i+1)
+				sumw = bin.SumW()
+				mean = 0.0
+			)
+			if sumw != 0 {
+				mean = bin.Dist().SumWY() / sumw
+			}
+			want := refs{key: {ref[key][0], ref[key][2]}}
+			want.check(t, key, mean, sumw)
+		}
+	}
+
+	// root-dump: all the keys are listed.
+	out := new(bytes.Buffer)
+	err = rcmd.Dump(out, fname, true, nil)
+	if err != nil {
+		t.Fatalf("could not dump ROOT file: %+v", err)
+	}
+	for _, k := range f.Keys() {
+		line := fmt.Sprintf("%s;%d %q (%s)", k.Name(), k.Cycle(), k.Title(), k.ClassName())
+		if !strings.Contains(out.String(), line) {
+			t.Fatalf("missing key %q in root-dump output:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestReadFittedF1FromROOT(t *testing.T) {
+	if !rtests.HasROOT {
+		t.Skip("ROOT not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "groot-rhist-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname, ref := genROOTFile(t, dir, "gen_fit", `
+#include "TFile.h"
+#include "TH1.h"
+#include "TF1.h"
+#include "TRandom3.h"
+
+#include <cstdio>
+
+void dumpF1(TF1 *f) {
+	const char *n = f->GetName();
+	printf("ref: %s npar = %d\n", n, f->GetNpar());
+	printf("ref: %s range = %.17g %.17g\n", n, f->GetXmin(), f->GetXmax());
+	printf("ref: %s fit = %.17g %d\n", n, f->GetChisquare(), f->GetNDF());
+	printf("ref: %s params =", n);
+	for (int i = 0; i < f->GetNpar(); i++) {
+		printf(" %.17g", f->GetParameter(i));
+	}
+	printf("\n");
+	printf("ref: %s errors =", n);
+	for (int i = 0; i < f->GetNpar(); i++) {
+		printf(" %.17g", f->GetParError(i));
+	}
+	printf("\n");
+	for (int i = 0; i < f->GetNpar(); i++) {
+		double lo, hi;
+		f->GetParLimits(i, lo, hi);
+		printf("ref: %s limits %d = %.17g %.17g\n", n, i, lo, hi);
+	}
+	const double xs[] = {-2, -0.5, 0, 0.3, 1.3, 3};
+	for (int i = 0; i < 6; i++) {
+		printf("ref: %s eval %d = %.17g %.17g\n", n, i, xs[i], f->Eval(xs[i]));
+	}
+}
+
+void gen_fit(const char *fname) {
+	auto f = TFile::Open(fname, "RECREATE");
+
+	auto h = new TH1D("h1", "h1-title", 40, -4, 4);
+	TRandom3 rnd(1234);
+	for (int i = 0; i < 10000; i++) {
+		h->Fill(rnd.Gaus(0.2, 1.1));
+	}
+
+	h->Fit("gaus", "Q");
+
+	auto fpol = new TF1("fpol", "[0]+[1]*x+[2]*x*x", -1, 1);
+	fpol->SetParLimits(2, -1000, 0);
+	h->Fit(fpol, "QR+");
+
+	for (auto o : *h->GetListOfFunctions()) {
+		auto fct = dynamic_cast<TF1*>(o);
+		if (fct) {
+			dumpF1(fct);
+		}
+	}
+
+	h->Write();
+	f->Close();
+}
+`)
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("h1")
+	if err != nil {
+		t.Fatalf("could not retrieve histogram: %+v", err)
+	}
+	funcs := o.(interface{ Funcs() root.List }).Funcs()
+
+	fcts := make(map[string]*rhist.F1)
+	for i := 0; i < funcs.Len(); i++ {
+		if fct, ok := funcs.At(i).(*rhist.F1); ok {
+			fcts[fct.Name()] = fct
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		pars []string
+	}{
+		{name: "gaus", pars: []string{"Constant", "Mean", "Sigma"}},
+		{name: "fpol", pars: []string{"p0", "p1", "p2"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fct, ok := fcts[tc.name]
+			if !ok {
+				t.Fatalf("could not find fitted function %q", tc.name)
+			}
+			if fct.Formula() == nil {
+				t.Fatalf("no formula for %q", tc.name)
+			}
+			if got, want := fct.Formula().NPar(), fct.NPar(); got != want {
+				t.Fatalf("invalid number of formula parameters: got=%d, want=%d", got, want)
+			}
+			for i, want := range tc.pars {
+				if got := fct.ParName(i); got != want {
+					t.Fatalf("invalid name for parameter %d: got=%q, want=%q", i, got, want)
+				}
+			}
+
+			ref.check(t, tc.name+" npar", float64(fct.NPar()))
+			ref.check(t, tc.name+" range", fct.XMin(), fct.XMax())
+			ref.check(t, tc.name+" fit", fct.Chi2(), float64(fct.NDF()))
+			ref.check(t, tc.name+" params", fct.Params()...)
+			ref.check(t, tc.name+" errors", fct.ParErrors()...)
+			for i := 0; i < fct.NPar(); i++ {
+				lo, hi := fct.ParLimits(i)
+				ref.check(t, fmt.Sprintf("%s limits %d", tc.name, i), lo, hi)
+			}
+
+			for i, x := range []float64{-2, -0.5, 0, 0.3, 1.3, 3} {
+				key := fmt.Sprintf("%s eval %d", tc.name, i)
+				v, err := fct.Eval(x)
+				if err != nil {
+					t.Fatalf("could not evaluate %q at x=%v: %+v", tc.name, x, err)
+				}
+				ref.check(t, key, x, v)
+			}
+		})
+	}
+}
+
+func TestReadGraphicsFromROOT(t *testing.T) {
+	if !rtests.HasROOT {
+		t.Skip("ROOT not installed")
+	}
+
+	dir, err := ioutil.TempDir("", "groot-rhist-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname, ref := genROOTFile(t, dir, "gen_graphics", `
+#include "TFile.h"
+#include "TH1.h"
+#include "TEfficiency.h"
+#include "THStack.h"
+#include "TGraph.h"
+#include "TMultiGraph.h"
+#include "TCanvas.h"
+#include "TPad.h"
+#include "TList.h"
+
+#include <cstdio>
+#include <string>
+
+void dumpEff(TEfficiency *e) {
+	const char *n = e->GetName();
+	int nbins = e->GetTotalHistogram()->GetNbinsX();
+	printf("ref: %s conf = %.17g %.17g\n", n, e->GetConfidenceLevel(), e->GetWeight());
+	printf("ref: %s nbins = %d\n", n, nbins);
+	for (int i = 0; i <= nbins+1; i++) {
+		printf("ref: %s bin %d = %.17g %.17g %.17g\n", n, i,
+			e->GetEfficiency(i), e->GetEfficiencyErrorLow(i), e->GetEfficiencyErrorUp(i));
+	}
+}
+
+void gen_graphics(const char *fname) {
+	auto f = TFile::Open(fname, "RECREATE");
+	auto c1 = new TCanvas("c1", "c1-title", 700, 500);
+
+	auto tot  = new TH1D("tot", "tot", 10, 0, 10);
+	auto pass = new TH1D("pass", "pass", 10, 0, 10);
+	tot->SetDirectory(nullptr);
+	pass->SetDirectory(nullptr);
+	for (int i = 0; i < 180; i++) {
+		double x = 0.05*i;
+		tot->Fill(x);
+		if (i%3 != 0 || x < 2) {
+			pass->Fill(x);
+		}
+	}
+
+	const struct {
+		const char *name;
+		TEfficiency::EStatOption opt;
+	} opts[] = {
+		{"eff_cp", TEfficiency::kFCP},
+		{"eff_normal", TEfficiency::kFNormal},
+		{"eff_wilson", TEfficiency::kFWilson},
+		{"eff_ac", TEfficiency::kFAC},
+		{"eff_jeffrey", TEfficiency::kBJeffrey},
+		{"eff_uniform", TEfficiency::kBUniform},
+		{"eff_bayes", TEfficiency::kBBayesian},
+	};
+	for (auto &o : opts) {
+		auto e = new TEfficiency(*pass, *tot);
+		e->SetDirectory(nullptr);
+		e->SetName(o.name);
+		e->SetTitle((std::string(o.name)+"-title").c_str());
+		e->SetStatisticOption(o.opt);
+		if (o.opt == TEfficiency::kBBayesian) {
+			e->SetBetaAlpha(2);
+			e->SetBetaBeta(3);
+			e->SetBetaBinParameters(3, 4, 5);
+			e->SetConfidenceLevel(0.9);
+			e->SetWeight(0.5);
+		}
+		dumpEff(e);
+		f->WriteTObject(e);
+	}
+
+	auto hs = new THStack("hs", "hs-title");
+	for (int k = 0; k < 3; k++) {
+		auto h = new TH1F(Form("hs_h%d", k), Form("hs_h%d-title", k), 5, 0, 5);
+		h->SetDirectory(nullptr);
+		for (int i = 0; i < 10*(k+1); i++) {
+			h->Fill(0.1*i + k, 1 + 0.5*k);
+		}
+		double s[4];
+		h->GetStats(s);
+		printf("ref: hs hist %d = %.17g %.17g %.17g %.17g %.17g\n", k, h->GetEntries(), s[0], s[1], s[2], s[3]);
+		for (int i = 0; i <= h->GetNbinsX()+1; i++) {
+			printf("ref: hs hist %d bin %d = %.17g %.17g\n", k, i, h->GetBinContent(i), h->GetBinError(i));
+		}
+		hs->Add(h);
+	}
+	f->WriteTObject(hs);
+
+	auto mg = new TMultiGraph("mg", "mg-title");
+	for (int k = 0; k < 2; k++) {
+		auto g = new TGraph();
+		g->SetName(Form("mg_g%d", k));
+		g->SetTitle(Form("mg_g%d-title", k));
+		for (int i = 0; i < 5+k; i++) {
+			g->SetPoint(i, 0.5*i, k + 0.25*i*i);
+		}
+		printf("ref: mg graph %d = %d\n", k, g->GetN());
+		for (int i = 0; i < g->GetN(); i++) {
+			printf("ref: mg graph %d point %d = %.17g %.17g\n", k, i, g->GetX()[i], g->GetY()[i]);
+		}
+		mg->Add(g);
+	}
+	f->WriteTObject(mg);
+
+	auto pad = new TPad("pad", "pad-title", 0.1, 0.1, 0.5, 0.5);
+	pad->GetListOfPrimitives()->Add(mg);
+	c1->GetListOfPrimitives()->Add(hs);
+	c1->GetListOfPrimitives()->Add(pad);
+	printf("ref: c1 nprims = %d\n", c1->GetListOfPrimitives()->GetSize());
+	printf("ref: pad nprims = %d\n", pad->GetListOfPrimitives()->GetSize());
+	f->WriteTObject(c1);
+
+	f->Close();
+}
+`)
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	get := func(name string) root.Object {
+		t.Helper()
+		o, err := f.Get(name)
+		if err != nil {
+			t.Fatalf("could not retrieve %q: %+v", name, err)
+		}
+		return o
+	}
+
+	t.Run("TEfficiency", func(t *testing.T) {
+		for _, name := range []string{
+			"eff_cp", "eff_normal", "eff_wilson", "eff_ac",
+			"eff_jeffrey", "eff_uniform", "eff_bayes",
+		} {
+			e := get(name).(*rhist.Efficiency)
+			if got, want := e.Title(), name+"-title"; got != want {
+				t.Fatalf("invalid title: got=%q, want=%q", got, want)
+			}
+			ref.check(t, name+" conf", e.ConfLevel(), e.Weight())
+			ref.check(t, name+" nbins", float64(e.NbinsX()))
+			for i := 0; i <= e.NbinsX()+1; i++ {
+				// confidence intervals are computed with gonum's quantile
+				// functions, and not with ROOT's.
+				ref.near(t, fmt.Sprintf("%s bin %d", name, i), 1e-8,
+					e.Efficiency(i), e.ErrorLow(i), e.ErrorUp(i),
+				)
+			}
+		}
+	})
+
+	t.Run("THStack", func(t *testing.T) {
+		hs := get("hs").(*rhist.HStack)
+		if got, want := hs.Title(), "hs-title"; got != want {
+			t.Fatalf("invalid title: got=%q, want=%q", got, want)
+		}
+		hists := hs.Hists()
+		if got, want := len(hists), 3; got != want {
+			t.Fatalf("invalid number of histograms: got=%d, want=%d", got, want)
+		}
+		for k, h := range hists {
+			if got, want := h.Name(), fmt.Sprintf("hs_h%d", k); got != want {
+				t.Fatalf("invalid histogram name: got=%q, want=%q", got, want)
+			}
+			ref.check(t, fmt.Sprintf("hs hist %d", k),
+				h.Entries(), h.SumW(), h.SumW2(), h.SumWX(), h.SumWX2(),
+			)
+			hx := h.(interface {
+				NbinsX() int
+				XBinContent(i int) float64
+				XBinError(i int) float64
+			})
+			for i := 0; i <= hx.NbinsX()+1; i++ {
+				ref.check(t, fmt.Sprintf("hs hist %d bin %d", k, i), hx.XBinContent(i), hx.XBinError(i))
+			}
+		}
+	})
+
+	t.Run("TMultiGraph", func(t *testing.T) {
+		mg := get("mg").(*rhist.MultiGraph)
+		if got, want := mg.Title(), "mg-title"; got != want {
+			t.Fatalf("invalid title: got=%q, want=%q", got, want)
+		}
+		graphs := mg.Graphs()
+		if got, want := len(graphs), 2; got != want {
+			t.Fatalf("invalid number of graphs: got=%d, want=%d", got, want)
+		}
+		for k, g := range graphs {
+			if got, want := g.Name(), fmt.Sprintf("mg_g%d", k); got != want {
+				t.Fatalf("invalid graph name: got=%q, want=%q", got, want)
+			}
+			ref.check(t, fmt.Sprintf("mg graph %d", k), float64(g.Len()))
+			for i := 0; i < g.Len(); i++ {
+				x, y := g.XY(i)
+				ref.check(t, fmt.Sprintf("mg graph %d point %d", k, i), x, y)
+			}
+		}
+	})
+
+	t.Run("TCanvas", func(t *testing.T) {
+		c1 := get("c1").(*rhist.Canvas)
+		if got, want := c1.Name(), "c1"; got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+		if got, want := c1.Title(), "c1-title"; got != want {
+			t.Fatalf("invalid title: got=%q, want=%q", got, want)
+		}
+		prims := c1.Primitives()
+		ref.check(t, "c1 nprims", float64(prims.Len()))
+		if hs, ok := prims.At(0).(*rhist.HStack); !ok || hs.Name() != "hs" || len(hs.Hists()) != 3 {
+			t.Fatalf("invalid canvas primitive: %#v", prims.At(0))
+		}
+		pad, ok := prims.At(1).(*rhist.Pad)
+		if !ok || pad.Name() != "pad" || pad.Title() != "pad-title" {
+			t.Fatalf("invalid canvas primitive: %#v", prims.At(1))
+		}
+		ref.check(t, "pad nprims", float64(pad.Primitives().Len()))
+		if mg, ok := pad.Primitives().At(0).(*rhist.MultiGraph); !ok || mg.Name() != "mg" || len(mg.Graphs()) != 2 {
+			t.Fatalf("invalid pad primitive: %#v", pad.Primitives().At(0))
+		}
+	})
+}
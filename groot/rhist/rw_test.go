@@ -208,6 +208,46 @@ func TestWRBuffer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "TH3F",
+			want: newTestH3F(),
+		},
+		{
+			name: "TProfile",
+			want: newTestProfile(),
+		},
+		{
+			name: "TProfile2D",
+			want: newTestProfile2D(),
+		},
+		{
+			name: "TF1",
+			want: newTestF1(),
+		},
+		{
+			name: "TFormula",
+			want: newTestF1().Formula(),
+		},
+		{
+			name: "TEfficiency",
+			want: newTestEfficiency(),
+		},
+		{
+			name: "THStack",
+			want: newTestHStack(),
+		},
+		{
+			name: "TMultiGraph",
+			want: newTestMultiGraph(),
+		},
+		{
+			name: "TPad",
+			want: &newTestCanvas().Pad,
+		},
+		{
+			name: "TCanvas",
+			want: newTestCanvas(),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			{
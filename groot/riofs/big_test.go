@@ -0,0 +1,376 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// startBigFile is the position in a ROOT file past which 64-bit offsets
+// are needed.
+const startBigFile = 2000000000
+
+func TestCreateBigFile(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-riofs-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, tc := range []struct {
+		name string
+		skip int64 // number of bytes before startBigFile
+		objs bool  // whether to write objects after the skip
+	}{
+		{name: "cross-on-put", skip: 16, objs: true},
+		{name: "cross-on-put-far", skip: 4096, objs: true},
+		{name: "cross-on-close-1", skip: 1, objs: false},
+		{name: "cross-on-close-64", skip: 64, objs: false},
+		{name: "cross-on-close-512", skip: 512, objs: false},
+		{name: "cross-on-close-4096", skip: 4096, objs: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(tmp, tc.name+".root")
+
+			f, err := riofs.Create(fname)
+			if err != nil {
+				t.Fatalf("could not create ROOT file: %+v", err)
+			}
+			defer f.Close()
+
+			want := make(map[string]string)
+			put := func(dir riofs.Directory, name, path string) {
+				t.Helper()
+				err := dir.Put(name, rbase.NewObjString(path))
+				if err != nil {
+					t.Fatalf("could not put %q: %+v", path, err)
+				}
+				want[path] = path
+			}
+
+			put(f, "small-1", "small-1")
+			put(f, "small-2", "small-2")
+			dir1, err := f.Mkdir("dir1")
+			if err != nil {
+				t.Fatalf("could not create dir1: %+v", err)
+			}
+			put(dir1, "small-1", "dir1/small-1")
+
+			// create a free segment.
+			err = f.Delete("small-2")
+			if err != nil {
+				t.Fatalf("could not delete small-2: %+v", err)
+			}
+			delete(want, "small-2")
+
+			err = riofs.SkipTo(f, startBigFile-tc.skip)
+			if err != nil {
+				t.Fatalf("could not skip to end of small file: %+v", err)
+			}
+
+			if tc.objs {
+				for i := 0; i < 10; i++ {
+					put(f, fmt.Sprintf("big-%d", i), fmt.Sprintf("big-%d", i))
+					put(dir1, fmt.Sprintf("big-%d", i), fmt.Sprintf("dir1/big-%d", i))
+				}
+
+				dir2, err := f.Mkdir("dir2")
+				if err != nil {
+					t.Fatalf("could not create dir2: %+v", err)
+				}
+				put(dir2, "big-1", "dir2/big-1")
+
+				// move past 3GB.
+				err = riofs.SkipTo(f, 3*startBigFile/2)
+				if err != nil {
+					t.Fatalf("could not skip to end of big file: %+v", err)
+				}
+				put(f, "huge-1", "huge-1")
+				put(dir1, "huge-1", "dir1/huge-1")
+				put(dir2, "huge-1", "dir2/huge-1")
+			}
+
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close ROOT file: %+v", err)
+			}
+
+			if tc.objs {
+				fi, err := os.Stat(fname)
+				if err != nil {
+					t.Fatalf("could not stat ROOT file: %+v", err)
+				}
+				if got, want := fi.Size(), int64(3*startBigFile/2); got < want {
+					t.Fatalf("invalid file size: got=%d, want>=%d", got, want)
+				}
+			}
+
+			testReadBigFile(t, fname, want)
+
+			if !tc.objs {
+				return
+			}
+
+			r, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open ROOT file: %+v", err)
+			}
+			defer r.Close()
+
+			for _, k := range r.Keys() {
+				big := k.SeekKey() > startBigFile
+				if got, want := k.RVersion() > 1000, big; got != want {
+					t.Fatalf("invalid key %q version %d (seek=%d)", k.Name(), k.RVersion(), k.SeekKey())
+				}
+			}
+
+			for _, name := range []string{"dir1", "dir2"} {
+				obj, err := r.Get(name)
+				if err != nil {
+					t.Fatalf("could not get %q: %+v", name, err)
+				}
+				dir := obj.(root.Object).(interface{ RVersion() int16 })
+				if got := dir.RVersion(); got < 1000 {
+					t.Fatalf("invalid directory %q version: got=%d, want>1000", name, got)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateBigFile(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-riofs-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "update.root")
+	want := make(map[string]string)
+
+	update := func(create bool, skip int64, names ...string) {
+		t.Helper()
+
+		var (
+			f   *riofs.File
+			err error
+		)
+		switch {
+		case create:
+			f, err = riofs.Create(fname)
+		default:
+			f, err = riofs.Update(fname)
+		}
+		if err != nil {
+			t.Fatalf("could not open ROOT file: %+v", err)
+		}
+		defer f.Close()
+
+		if skip > 0 {
+			err = riofs.SkipTo(f, skip)
+			if err != nil {
+				t.Fatalf("could not skip to %d: %+v", skip, err)
+			}
+		}
+
+		for _, name := range names {
+			err = f.Put(name, rbase.NewObjString(name))
+			if err != nil {
+				t.Fatalf("could not put %q: %+v", name, err)
+			}
+			want[name] = name
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close ROOT file: %+v", err)
+		}
+	}
+
+	update(true, 0, "small-1")
+	update(false, startBigFile-32, "big-1", "big-2")
+	update(false, 0, "big-3")
+	update(false, 3*startBigFile/2, "huge-1")
+
+	testReadBigFile(t, fname, want)
+}
+
+func TestCreateBigTree(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-riofs-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "tree.root")
+
+	const nevts = 10000
+
+	type Data struct {
+		I64 int64
+		Arr [10]float64
+		N   int32
+		Sli []float64
+	}
+
+	func() {
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create ROOT file: %+v", err)
+		}
+		defer f.Close()
+
+		var data Data
+		w, err := rtree.NewWriter(f, "tree", []rtree.WriteVar{
+			{Name: "I64", Value: &data.I64},
+			{Name: "Arr", Value: &data.Arr},
+			{Name: "N", Value: &data.N},
+			{Name: "Sli", Value: &data.Sli, Count: "N"},
+		}, rtree.WithBasketSize(8*1024))
+		if err != nil {
+			t.Fatalf("could not create tree writer: %+v", err)
+		}
+		defer w.Close()
+
+		for i := 0; i < nevts; i++ {
+			switch i {
+			case nevts / 3:
+				err = riofs.SkipTo(f, startBigFile-4096)
+			case 2 * nevts / 3:
+				err = riofs.SkipTo(f, 3*startBigFile/2)
+			}
+			if err != nil {
+				t.Fatalf("could not skip: %+v", err)
+			}
+
+			data.I64 = int64(i)
+			for j := range data.Arr {
+				data.Arr[j] = float64(i)
+			}
+			data.N = int32(i % 10)
+			data.Sli = data.Sli[:0]
+			for j := 0; j < int(data.N); j++ {
+				data.Sli = append(data.Sli, float64(i))
+			}
+
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event %d: %+v", i, err)
+			}
+		}
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree writer: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close ROOT file: %+v", err)
+		}
+	}()
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	err = f.SegmentMap(ioutil.Discard)
+	if err != nil {
+		t.Fatalf("invalid segment map: %+v", err)
+	}
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not get tree: %+v", err)
+	}
+	tree := obj.(rtree.Tree)
+	if got, want := tree.Entries(), int64(nevts); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	var data Data
+	r, err := rtree.NewReader(tree, []rtree.ReadVar{
+		{Name: "I64", Value: &data.I64},
+		{Name: "Arr", Value: &data.Arr},
+		{Name: "N", Value: &data.N},
+		{Name: "Sli", Value: &data.Sli},
+	})
+	if err != nil {
+		t.Fatalf("could not create tree reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		i := ctx.Entry
+		if got, want := data.I64, i; got != want {
+			return fmt.Errorf("entry %d: invalid I64: got=%d, want=%d", i, got, want)
+		}
+		if got, want := data.Arr[9], float64(i); got != want {
+			return fmt.Errorf("entry %d: invalid Arr: got=%v, want=%v", i, got, want)
+		}
+		if got, want := len(data.Sli), int(i%10); got != want {
+			return fmt.Errorf("entry %d: invalid Sli length: got=%d, want=%d", i, got, want)
+		}
+		for _, v := range data.Sli {
+			if v != float64(i) {
+				return fmt.Errorf("entry %d: invalid Sli: got=%v", i, data.Sli)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+}
+
+func testReadBigFile(t *testing.T, fname string, want map[string]string) {
+	t.Helper()
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	err = f.SegmentMap(ioutil.Discard)
+	if err != nil {
+		t.Fatalf("invalid segment map: %+v", err)
+	}
+
+	got := 0
+	err = riofs.Walk(f, func(path string, obj root.Object, err error) error {
+		if err != nil {
+			return err
+		}
+		str, ok := obj.(*rbase.ObjString)
+		if !ok {
+			return nil
+		}
+		got++
+		name := path[len(fname)+1:]
+		if v, ok := want[name]; !ok || v != str.String() {
+			return fmt.Errorf("invalid object %q: got=%q, want=%q", name, str.String(), v)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not walk ROOT file: %+v", err)
+	}
+
+	if got != len(want) {
+		t.Fatalf("invalid number of objects: got=%d, want=%d", got, len(want))
+	}
+}
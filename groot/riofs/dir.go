@@ -401,11 +401,98 @@ func (dir *tdirectoryFile) Get(namecycle string) (root.Object, error) {
 			if obj.Title() == "" {
 				obj.dir.named.SetTitle(name)
 			}
+			if dir.file.w != nil {
+				// make sure sub-directories of files opened in update mode
+				// are saved on close.
+				dir.addDir(obj)
+			}
 		}
 	}
 	return obj, nil
 }
 
+func (dir *tdirectoryFile) addDir(sub *tdirectoryFile) {
+	for _, d := range dir.dirs {
+		if d == sub {
+			return
+		}
+	}
+	dir.dirs = append(dir.dirs, sub)
+}
+
+// Delete removes the object identified by namecycle from this directory.
+// The space used by the deleted object on file is marked as free and
+// reused by new objects.
+//   namecycle has the format name;cycle
+//
+//   examples:
+//     foo   : delete all cycles of the object named foo
+//     foo;1 : delete cycle 1 of foo
+func (dir *tdirectoryFile) Delete(namecycle string) error {
+	if dir.file.w == nil {
+		return fmt.Errorf("could not delete %q from directory %q: %w", namecycle, dir.dir.Name(), ErrReadOnly)
+	}
+
+	name, cycle := decodeNameCycle(namecycle)
+	var (
+		keys = dir.keys[:0]
+		n    = 0
+	)
+	for i := range dir.keys {
+		key := dir.keys[i]
+		if key.name != name || (cycle != 9999 && key.cycle != cycle) {
+			keys = append(keys, key)
+			continue
+		}
+		err := dir.deleteKey(&key)
+		if err != nil {
+			return fmt.Errorf("riofs: could not delete key %q: %w", namecycle, err)
+		}
+		n++
+	}
+	if n == 0 {
+		return noKeyError{key: namecycle, obj: dir}
+	}
+	for i := len(keys); i < len(dir.keys); i++ {
+		dir.keys[i] = Key{}
+	}
+	dir.keys = keys
+
+	return nil
+}
+
+// deleteKey marks the space used by the provided key as free.
+// Sub-directories are recursively deleted.
+func (dir *tdirectoryFile) deleteKey(key *Key) error {
+	switch key.class {
+	case "TDirectory", "TDirectoryFile":
+		obj, err := key.Object()
+		if err != nil {
+			return err
+		}
+		sub := obj.(*tdirectoryFile)
+		for i := range sub.keys {
+			err = sub.deleteKey(&sub.keys[i])
+			if err != nil {
+				return err
+			}
+		}
+		sub.keys = nil
+		if sub.seekkeys > 0 {
+			dir.file.markFree(sub.seekkeys, sub.seekkeys+int64(sub.nbyteskeys)-1)
+		}
+		for i, d := range dir.dirs {
+			if d == sub {
+				dir.dirs = append(dir.dirs[:i], dir.dirs[i+1:]...)
+				break
+			}
+		}
+	}
+
+	dir.file.markFree(key.seekkey, key.seekkey+int64(key.nbytes)-1)
+	return nil
+}
+
 func (dir *tdirectoryFile) Put(name string, obj root.Object) error {
 	if dir.file.w == nil {
 		return fmt.Errorf("could not put %q into directory %q: %w", name, dir.dir.Name(), ErrReadOnly)
@@ -529,8 +616,17 @@ func (dir *tdirectoryFile) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 
 	beg := w.Pos()
 
-	version := dir.RVersion()
-	if dir.isBigFile() && version < 1000 {
+	// one of the addresses may be past the 2GB limit, even if this
+	// directory was created as a small one: switch to 64-bit addresses.
+	// recordSize always reserves enough space for the 64-bit layout.
+	var (
+		version = dir.RVersion()
+		big     = version > 1000 ||
+			dir.seekdir > kStartBigFile ||
+			dir.seekparent > kStartBigFile ||
+			dir.seekkeys > kStartBigFile
+	)
+	if big && version < 1000 {
 		version += 1000
 	}
 	w.WriteI16(version)
@@ -540,7 +636,7 @@ func (dir *tdirectoryFile) MarshalROOT(w *rbytes.WBuffer) (int, error) {
 	w.WriteI32(dir.nbytesname)
 
 	switch {
-	case dir.isBigFile():
+	case big:
 		w.WriteI64(dir.seekdir)
 		w.WriteI64(dir.seekparent)
 		w.WriteI64(dir.seekkeys)
@@ -609,21 +705,13 @@ func (dir *tdirectoryFile) addStreamer(streamer rbytes.StreamerInfo) {
 // The list of keys is written out as a single data record.
 func (dir *tdirectoryFile) writeKeys() error {
 	var (
-		err    error
-		nbytes = int32(4) // space for n-keys
+		err error
+		buf = rbytes.NewWBuffer(nil, nil, 0, nil)
 	)
 
-	if dir.file.end > kStartBigFile {
-		nbytes += 8
-	}
-	for i := range dir.Keys() {
-		key := &dir.keys[i]
-		nbytes += key.keylen
-	}
-
-	hdr := newKey(dir, dir.Name(), dir.Title(), "TDirectory", nbytes, dir.file)
-
-	buf := rbytes.NewWBuffer(make([]byte, nbytes), nil, 0, nil)
+	// keys read from old ROOT files may have been renamed (TDirectory to
+	// TDirectoryFile), so their size on file may differ from their keylen:
+	// marshal them first to compute the size of the record.
 	buf.WriteI32(int32(len(dir.Keys())))
 	for _, k := range dir.Keys() {
 		_, err = k.MarshalROOT(buf)
@@ -631,7 +719,19 @@ func (dir *tdirectoryFile) writeKeys() error {
 			return fmt.Errorf("riofs: could not write key: %w", err)
 		}
 	}
-	hdr.buf = buf.Bytes()
+
+	nbytes := int32(len(buf.Bytes()))
+	if dir.file.end > kStartBigFile {
+		nbytes += 8
+	}
+
+	if dir.seekkeys > 0 {
+		dir.file.markFree(dir.seekkeys, dir.seekkeys+int64(dir.nbyteskeys)-1)
+	}
+
+	hdr := newKey(dir, dir.Name(), dir.Title(), "TDirectory", nbytes, dir.file)
+	hdr.buf = make([]byte, nbytes)
+	copy(hdr.buf, buf.Bytes())
 
 	dir.seekkeys = hdr.seekkey
 	dir.nbyteskeys = hdr.nbytes
@@ -0,0 +1,40 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package riofs
+
+import (
+	"fmt"
+	"math"
+
+	"go-hep.org/x/hep/groot/rbytes"
+)
+
+// SkipTo moves the end of the file f to pos, leaving a gap of unused bytes
+// on file.
+// SkipTo is used to create big (sparse) files in tests.
+func SkipTo(f *File, pos int64) error {
+	gap := pos - f.end
+	if gap < 4 || gap > math.MaxInt32 {
+		return fmt.Errorf("riofs: invalid gap size %d", gap)
+	}
+
+	buf := rbytes.NewWBuffer(make([]byte, 4), nil, 0, f)
+	buf.WriteI32(-int32(gap))
+	_, err := f.w.WriteAt(buf.Bytes(), f.end)
+	if err != nil {
+		return fmt.Errorf("riofs: could not write gap header: %w", err)
+	}
+
+	last := f.spans.last()
+	if last == nil || last.first != f.end {
+		return fmt.Errorf("riofs: invalid free segments list")
+	}
+	last.first = pos
+	if last.last < pos {
+		last.last = pos + 1000000000
+	}
+	f.end = pos
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/rcont"
 	"go-hep.org/x/hep/groot/rdict"
+	"go-hep.org/x/hep/groot/rmeta"
 	"go-hep.org/x/hep/groot/root"
 )
 
@@ -39,11 +40,33 @@ type Writer interface {
 	io.Closer
 }
 
+// aborter is implemented by writers that can discard their content,
+// e.g. writers that only commit their content when closed.
+type aborter interface {
+	// Abort discards the content written so far and releases the
+	// resources held by the writer.
+	Abort() error
+}
+
 type syncer interface {
 	// Sync commits the current contents of the file to stable storage.
 	Sync() error
 }
 
+// Chunk is a region of a file.
+// Buf is filled with the len(Buf) bytes starting at offset Off.
+type Chunk struct {
+	Off int64
+	Buf []byte
+}
+
+// ReaderAtv is the interface implemented by readers that can read
+// multiple, possibly non-contiguous, regions of a file in one call.
+// Implementations may coalesce neighbouring regions into fewer requests.
+type ReaderAtv interface {
+	ReadAtv(chunks []Chunk) error
+}
+
 type stater interface {
 	// Stat returns a FileInfo describing the file.
 	Stat() (os.FileInfo, error)
@@ -161,8 +184,11 @@ func NewReader(r Reader) (*File, error) {
 }
 
 // Create creates the named ROOT file for writing.
+//
+// Create uses the plugin registered with RegisterWriter for the scheme of
+// the name, if any, and creates a local file otherwise.
 func Create(name string, opts ...FileOption) (*File, error) {
-	fd, err := os.Create(name)
+	fd, err := createFile(name)
 	if err != nil {
 		return nil, fmt.Errorf("riofs: unable to create %q: %w", name, err)
 	}
@@ -191,6 +217,7 @@ func Create(name string, opts ...FileOption) (*File, error) {
 		}
 		err := opt(f)
 		if err != nil {
+			discard(fd, name)
 			return nil, fmt.Errorf("riofs: could not apply option to ROOT file: %w", err)
 		}
 	}
@@ -207,8 +234,7 @@ func Create(name string, opts ...FileOption) (*File, error) {
 
 	err = f.writeHeader()
 	if err != nil {
-		_ = fd.Close()
-		_ = os.RemoveAll(name)
+		discard(fd, name)
 		return nil, fmt.Errorf("riofs: failed to write header %q: %w", name, err)
 	}
 
@@ -218,34 +244,128 @@ func Create(name string, opts ...FileOption) (*File, error) {
 
 	_, err = f.dir.MarshalROOT(buf)
 	if err != nil {
+		discard(fd, name)
 		return nil, fmt.Errorf("riofs: failed to write header: %w", err)
 	}
 	key.buf = buf.Bytes()
 
 	_, err = key.writeFile(f)
 	if err != nil {
+		discard(fd, name)
 		return nil, fmt.Errorf("riofs: failed to write key header: %w", err)
 	}
 
 	return f, nil
 }
 
-func (f *File) setEnd(pos int64) error {
-	f.end = pos
+// discard releases the writer of a file that could not be created,
+// without committing its content.
+func discard(fd Writer, name string) {
+	switch fd := fd.(type) {
+	case aborter:
+		_ = fd.Abort()
+	case *os.File:
+		_ = fd.Close()
+		_ = os.RemoveAll(name)
+	default:
+		_ = fd.Close()
+	}
+}
+
+// Update opens the named ROOT file for reading and writing.
+// New objects can be appended to the file with Put, and existing ones
+// removed with Delete.
+// The keys, streamer infos and free segments records of the file are
+// rewritten when the file is closed.
+func Update(name string, opts ...FileOption) (*File, error) {
+	fd, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("riofs: unable to open %q for update: %w", name, err)
+	}
+
+	f := &File{
+		r:      fd,
+		w:      fd,
+		closer: fd,
+		id:     name,
+		simap:  make(map[rbytes.StreamerInfo]struct{}),
+	}
+	f.dir.file = f
+
+	err = f.readHeader()
+	if err != nil {
+		_ = fd.Close()
+		return nil, fmt.Errorf("riofs: failed to read header %q: %w", name, err)
+	}
+
+	for _, si := range f.sinfos {
+		f.simap[si] = struct{}{}
+	}
+
 	if f.spans.Len() == 0 {
-		return fmt.Errorf("riofs: empty free segment list")
+		last := int64(kStartBigFile)
+		if f.end > last {
+			last = f.end + 1000000000
+		}
+		f.spans.add(f.end, last)
 	}
-	blk := f.spans.last()
-	if blk == nil {
-		return fmt.Errorf("riofs: last free segment is nil")
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		err := opt(f)
+		if err != nil {
+			_ = fd.Close()
+			return nil, fmt.Errorf("riofs: could not apply option to ROOT file: %w", err)
+		}
 	}
 
-	if blk.last != kStartBigFile {
-		return fmt.Errorf("riofs: last free segment is not the file ending")
+	return f, nil
+}
+
+// allocate reserves nbytes on file for a new record, using the best free
+// segment, and returns the position of that record.
+// When the record is stored into a larger free segment, the header of the
+// remaining gap is written right after the record.
+func (f *File) allocate(nbytes int64) (int64, error) {
+	best := f.spans.best(nbytes)
+	if best == nil {
+		return 0, fmt.Errorf("riofs: empty free segment list")
+	}
+
+	pos := best.first
+	if pos >= f.end {
+		f.end = pos + nbytes
+		best.first = f.end
+		if f.end > best.last {
+			best.last += 1000000000
+		}
+		return pos, nil
 	}
 
-	blk.first = pos
-	return nil
+	left := best.last - pos - nbytes + 1
+	switch {
+	case left == 0:
+		for i := range f.spans {
+			if &f.spans[i] == best {
+				f.spans.remove(i)
+				break
+			}
+		}
+	case left > 0:
+		best.first = pos + nbytes
+		if left > 2000000000 {
+			left = 2000000000
+		}
+		buf := rbytes.NewWBuffer(make([]byte, 4), nil, 0, f)
+		buf.WriteI32(-int32(left))
+		_, err := f.w.WriteAt(buf.Bytes(), best.first)
+		if err != nil {
+			return 0, fmt.Errorf("riofs: could not write free segment header: %w", err)
+		}
+	}
+	return pos, nil
 }
 
 // Stat returns the os.FileInfo structure describing this file.
@@ -273,8 +393,27 @@ func (f *File) ReadAt(p []byte, off int64) (int, error) {
 	return f.r.ReadAt(p, off)
 }
 
+// ReadAtv reads the provided chunks of data from the file.
+// ReadAtv uses the vectored read of the underlying reader when available,
+// and reads the chunks one after the other otherwise.
+func (f *File) ReadAtv(chunks []Chunk) error {
+	if r, ok := f.r.(ReaderAtv); ok {
+		return r.ReadAtv(chunks)
+	}
+	for _, chunk := range chunks {
+		_, err := f.r.ReadAt(chunk.Buf, chunk.Off)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // WriteAt implements io.WriterAt
 func (f *File) WriteAt(p []byte, off int64) (int, error) {
+	if f.w == nil {
+		return 0, ErrReadOnly
+	}
 	return f.w.WriteAt(p, off)
 }
 
@@ -609,7 +748,7 @@ func (f *File) findDepStreamers() error {
 				deps = append(deps, depsType{se.TypeName(), -1})
 
 			case *rdict.StreamerSTL:
-				for _, etn := range se.ElemTypeName() {
+				for _, etn := range stlElemTypeNames(se.ElemTypeName()) {
 					deps = append(deps, depsType{etn, -1})
 				}
 			}
@@ -634,6 +773,33 @@ func (f *File) findDepStreamers() error {
 	return nil
 }
 
+// stlElemTypeNames returns the names of the types held by STL containers
+// elements, looking through nested STL containers.
+func stlElemTypeNames(names []string) []string {
+	var o []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		tmpl := strings.Replace(name, "std::", "", -1)
+		switch i := strings.Index(tmpl, "<"); {
+		case i > 0 && isSTLContainer(tmpl[:i]) && strings.HasSuffix(tmpl, ">"):
+			o = append(o, stlElemTypeNames(rmeta.CxxTemplateArgsOf(tmpl))...)
+		default:
+			o = append(o, name)
+		}
+	}
+	return o
+}
+
+func isSTLContainer(name string) bool {
+	switch name {
+	case "vector", "list", "deque", "forward_list",
+		"set", "multiset", "unordered_set", "unordered_multiset",
+		"map", "multimap", "unordered_map", "unordered_multimap":
+		return true
+	}
+	return false
+}
+
 // markFree marks unused bytes on the file.
 // it's the equivalent of slice[beg:end] = nil.
 func (f *File) markFree(beg, end int64) {
@@ -706,7 +872,7 @@ func (f *File) writeFreeSegments() error {
 		f.markFree(f.seekfree, f.seekfree+int64(f.nbytesfree)-1)
 	}
 
-	key := func() *Key {
+	newKeyFree := func() *Key {
 		var nbytes int32
 		for _, span := range f.spans {
 			nbytes += span.sizeof()
@@ -719,20 +885,25 @@ func (f *File) writeFreeSegments() error {
 			return nil
 		}
 		return &key
-	}()
+	}
 
+	isBigFile := f.end > kStartBigFile
+	key := newKeyFree()
 	if key == nil {
 		return nil
 	}
 
-	isBigFile := f.end > kStartBigFile
 	if !isBigFile && f.end > kStartBigFile {
 		// the free block list is large enough to bring the file over the
 		// 2Gb limit.
 		// The references and offsets are now 64b, so we need to redo the
 		// calculation since the list of free blocks will not fit in the
 		// original size.
-		panic("not implemented")
+		f.markFree(key.seekkey, key.seekkey+int64(key.nbytes)-1)
+		key = newKeyFree()
+		if key == nil {
+			return nil
+		}
 	}
 
 	nbytes := key.objlen
@@ -751,7 +922,10 @@ func (f *File) writeFreeSegments() error {
 			// we thus have one less free-block to store than planned.
 			copy(buf.Bytes()[abytes:], make([]byte, int64(nbytes)-abytes))
 		default:
-			panic("riofs: free block list larger than expected")
+			return fmt.Errorf(
+				"riofs: free block list larger than expected (got=%d, want=%d)",
+				abytes, nbytes,
+			)
 		}
 	}
 
@@ -823,6 +997,12 @@ func (f *File) addStreamer(streamer rbytes.StreamerInfo) {
 		return
 	}
 
+	for _, si := range f.sinfos {
+		if si.Name() == streamer.Name() && si.ClassVersion() == streamer.ClassVersion() {
+			return
+		}
+	}
+
 	f.simap[streamer] = struct{}{}
 	f.sinfos = append(f.sinfos, streamer)
 }
@@ -848,6 +1028,21 @@ func (f *File) Put(name string, v root.Object) error {
 	return f.dir.Put(name, v)
 }
 
+// Delete removes the object identified by namecycle from the file.
+// The space used by the deleted object on file is marked as free and
+// reused by new objects.
+//   namecycle has the format name;cycle
+//
+//   examples:
+//     foo   : delete all cycles of the object named foo
+//     foo;1 : delete cycle 1 of foo
+func (f *File) Delete(namecycle string) error {
+	if f.w == nil {
+		return fmt.Errorf("could not delete %q from file %q: %w", namecycle, f.Name(), ErrReadOnly)
+	}
+	return f.dir.Delete(namecycle)
+}
+
 // Mkdir creates a new subdirectory
 func (f *File) Mkdir(name string) (Directory, error) {
 	if f.w == nil {
@@ -980,4 +1175,6 @@ var (
 	_ io.ReaderAt = (*File)(nil)
 	_ io.WriterAt = (*File)(nil)
 	_ io.Closer   = (*File)(nil)
+
+	_ ReaderAtv = (*File)(nil)
 )
@@ -541,3 +541,216 @@ func TestReadOnlyFile(t *testing.T) {
 		t.Fatalf("expected an error. got nil")
 	}
 }
+
+func TestUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riofs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fname := filepath.Join(dir, "update.root")
+
+	func() {
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		for _, v := range []struct {
+			name string
+			obj  root.Object
+		}{
+			{"s1", rbase.NewObjString("hello")},
+			{"s2", rbase.NewObjString(strings.Repeat("=", 256))},
+			{"n1", rbase.NewNamed("n1", "t1")},
+		} {
+			err = f.Put(v.name, v.obj)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_, err = riofs.Dir(f).Mkdir("dir1/dir11")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	var seek int64
+	func() {
+		f, err := groot.Update(fname)
+		if err != nil {
+			t.Fatalf("could not open file for update: %+v", err)
+		}
+		defer f.Close()
+
+		for _, k := range f.Keys() {
+			if k.Name() == "s2" {
+				seek = k.SeekKey()
+			}
+		}
+
+		err = f.Delete("s2")
+		if err != nil {
+			t.Fatalf("could not delete key: %+v", err)
+		}
+
+		err = f.Delete("s2")
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		err = f.Put("s3", rbase.NewObjString("world"))
+		if err != nil {
+			t.Fatalf("could not put object: %+v", err)
+		}
+
+		err = f.Put("s1", rbase.NewObjString("hello-2"))
+		if err != nil {
+			t.Fatalf("could not put new cycle: %+v", err)
+		}
+
+		err = f.Delete("n1;1")
+		if err != nil {
+			t.Fatalf("could not delete key: %+v", err)
+		}
+
+		err = riofs.Dir(f).Put("dir1/dir11/s4", rbase.NewObjString("in dir11"))
+		if err != nil {
+			t.Fatalf("could not put object in sub-directory: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not re-open file: %+v", err)
+	}
+	defer f.Close()
+
+	var keys []string
+	for _, k := range f.Keys() {
+		keys = append(keys, fmt.Sprintf("%s;%d", k.Name(), k.Cycle()))
+		if k.Name() == "s3" && k.SeekKey() != seek {
+			t.Fatalf("deleted key space not reused: got=%d, want=%d", k.SeekKey(), seek)
+		}
+	}
+	if got, want := keys, []string{"s1;1", "dir1;1", "s3;1", "s1;2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid keys:\ngot= %q\nwant=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{"s1;1", "hello"},
+		{"s1", "hello-2"},
+		{"s3", "world"},
+		{"dir1/dir11/s4", "in dir11"},
+	} {
+		o, err := riofs.Dir(f).Get(tc.name)
+		if err != nil {
+			t.Fatalf("could not get %q: %+v", tc.name, err)
+		}
+		if got := o.(*rbase.ObjString).String(); got != tc.want {
+			t.Fatalf("invalid value for %q: got=%q, want=%q", tc.name, got, tc.want)
+		}
+	}
+
+	for _, name := range []string{"s2", "n1"} {
+		_, err = f.Get(name)
+		if err == nil {
+			t.Fatalf("expected key %q to be deleted", name)
+		}
+	}
+}
+
+func TestUpdateFromROOT(t *testing.T) {
+	dir, err := ioutil.TempDir("", "riofs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	raw, err := ioutil.ReadFile("../testdata/dirs-6.14.00.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fname := filepath.Join(dir, "dirs.root")
+	err = ioutil.WriteFile(fname, raw, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	func() {
+		f, err := groot.Update(fname)
+		if err != nil {
+			t.Fatalf("could not open file for update: %+v", err)
+		}
+		defer f.Close()
+
+		rdir := riofs.Dir(f)
+		err = rdir.Put("dir1/dir11/str", rbase.NewObjString("hello"))
+		if err != nil {
+			t.Fatalf("could not put object: %+v", err)
+		}
+
+		err = rdir.Delete("dir3")
+		if err != nil {
+			t.Fatalf("could not delete directory: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+	}()
+
+	f, err := groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not re-open file: %+v", err)
+	}
+	defer f.Close()
+
+	var names []string
+	err = riofs.Walk(f, func(path string, obj root.Object, err error) error {
+		if err != nil {
+			return err
+		}
+		names = append(names, path+"["+obj.Class()+"]")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not walk file: %+v", err)
+	}
+	want := []string{
+		"dirs-6.14.00.root[TFile]",
+		"dirs-6.14.00.root/dir1[TDirectoryFile]",
+		"dirs-6.14.00.root/dir1/dir11[TDirectoryFile]",
+		"dirs-6.14.00.root/dir1/dir11/h1[TH1F]",
+		"dirs-6.14.00.root/dir1/dir11/str[TObjString]",
+		"dirs-6.14.00.root/dir2[TDirectoryFile]",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("invalid file content:\ngot= %q\nwant=%q", names, want)
+	}
+
+	o, err := riofs.Dir(f).Get("dir1/dir11/str")
+	if err != nil {
+		t.Fatalf("could not get object: %+v", err)
+	}
+	if got, want := o.(*rbase.ObjString).String(), "hello"; got != want {
+		t.Fatalf("invalid value: got=%q, want=%q", got, want)
+	}
+}
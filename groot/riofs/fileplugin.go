@@ -7,6 +7,7 @@ package riofs
 import (
 	"fmt"
 	"net/url"
+	"os"
 	"sort"
 	"strings"
 	"sync"
@@ -49,6 +50,31 @@ func Drivers() []string {
 	return names
 }
 
+var wdrivers = struct {
+	sync.RWMutex
+	db map[string]func(path string) (Writer, error)
+}{
+	db: make(map[string]func(path string) (Writer, error)),
+}
+
+// RegisterWriter registers a plugin to create ROOT files.
+// RegisterWriter panics if it is called twice with the same name of if the
+// plugin function is nil.
+//
+// Writers that only commit their content when closed should also provide
+// an "Abort() error" method, used to discard files that could not be created.
+func RegisterWriter(name string, f func(path string) (Writer, error)) {
+	wdrivers.Lock()
+	defer wdrivers.Unlock()
+	if f == nil {
+		panic("riofs: plugin function is nil")
+	}
+	if _, dup := wdrivers.db[name]; dup {
+		panic(fmt.Errorf("riofs: RegisterWriter called twice for plugin %q", name))
+	}
+	wdrivers.db[name] = f
+}
+
 func openFile(path string) (Reader, error) {
 	drivers.RLock()
 	defer drivers.RUnlock()
@@ -68,6 +94,21 @@ func openFile(path string) (Reader, error) {
 	return nil, fmt.Errorf("riofs: no ROOT plugin to open [%s] (scheme=%s)", path, scheme)
 }
 
+// createFile creates the named file, with the plugin registered for the
+// scheme of the path if any, or as a local file otherwise.
+func createFile(path string) (Writer, error) {
+	wdrivers.RLock()
+	defer wdrivers.RUnlock()
+
+	if u, err := url.Parse(path); err == nil && u.Scheme != "" {
+		if create, ok := wdrivers.db[u.Scheme]; ok {
+			return create(path)
+		}
+	}
+
+	return os.Create(path)
+}
+
 func openLocalFile(path string) (Reader, error) {
 	path = strings.TrimPrefix(path, "file://")
 	return mmap.Open(path)
@@ -5,6 +5,9 @@
 package riofs
 
 import (
+	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"sort"
@@ -63,3 +66,108 @@ func TestDrivers(t *testing.T) {
 		t.Fatalf("got=%v, want=%v", got, want)
 	}
 }
+
+func TestRegisterWriter(t *testing.T) {
+	func() {
+		defer func() {
+			e := recover()
+			if e == nil {
+				t.Fatalf("expected a panic")
+			}
+		}()
+		RegisterWriter("file1", nil)
+	}()
+
+	tmp, err := ioutil.TempDir("", "groot-riofs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const name = "test-register-writer"
+	defer func() {
+		wdrivers.Lock()
+		defer wdrivers.Unlock()
+		delete(wdrivers.db, name)
+	}()
+
+	var created []string
+	RegisterWriter(name, func(path string) (Writer, error) {
+		created = append(created, path)
+		return os.Create(filepath.Join(tmp, filepath.Base(path)))
+	})
+
+	func() {
+		defer func() {
+			e := recover()
+			if e == nil {
+				t.Fatalf("expected a panic")
+			}
+		}()
+		RegisterWriter(name, createFile)
+	}()
+
+	fname := name + "://bucket/out.root"
+	f, err := Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	if got, want := created, []string{fname}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid created files: got=%q, want=%q", got, want)
+	}
+
+	r, err := Open(filepath.Join(tmp, "out.root"))
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer r.Close()
+}
+
+type abortWriter struct {
+	closed  bool
+	aborted bool
+}
+
+func (w *abortWriter) Write(p []byte) (int, error)              { return 0, io.ErrShortWrite }
+func (w *abortWriter) WriteAt(p []byte, off int64) (int, error) { return 0, io.ErrShortWrite }
+
+func (w *abortWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func (w *abortWriter) Abort() error {
+	w.aborted = true
+	return nil
+}
+
+func TestCreateAbort(t *testing.T) {
+	const name = "test-create-abort"
+	defer func() {
+		wdrivers.Lock()
+		defer wdrivers.Unlock()
+		delete(wdrivers.db, name)
+	}()
+
+	w := new(abortWriter)
+	RegisterWriter(name, func(path string) (Writer, error) {
+		return w, nil
+	})
+
+	_, err := Create(name + "://bucket/out.root")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if w.closed {
+		t.Fatalf("writer of a failed file should not be closed")
+	}
+	if !w.aborted {
+		t.Fatalf("writer of a failed file should be aborted")
+	}
+}
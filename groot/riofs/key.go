@@ -84,7 +84,7 @@ type Key struct {
 func newKey(dir *tdirectoryFile, name, title, class string, objlen int32, f *File) Key {
 	k := Key{
 		f:        f,
-		rvers:    rvers.Key,
+		rvers:    keyVersionFor(f),
 		objlen:   objlen,
 		datetime: nowUTC(),
 		cycle:    1,
@@ -97,20 +97,16 @@ func newKey(dir *tdirectoryFile, name, title, class string, objlen int32, f *Fil
 	k.keylen = k.sizeof()
 	// FIXME(sbinet): this assumes the key-payload isn't compressed.
 	// if the key's payload is actually compressed, we introduce a hole
-	// with the f.setEnd call below.
+	// with the f.allocate call below.
 	k.nbytes = k.objlen + k.keylen
 	if objlen > 0 {
-		k.seekkey = f.end
-		err := f.setEnd(k.seekkey + int64(k.nbytes))
+		var err error
+		k.seekkey, err = f.allocate(int64(k.nbytes))
 		if err != nil {
 			panic(err)
 		}
 	}
 
-	if f.end > kStartBigFile {
-		k.rvers += 1000
-	}
-
 	if dir != nil {
 		k.seekpdir = dir.seekdir
 	}
@@ -135,7 +131,10 @@ func newKeyFrom(dir *tdirectoryFile, name, title, class string, obj root.Object,
 		dir = &f.dir
 	}
 
-	keylen := keylenFor(name, title, class, dir)
+	var (
+		vers   = keyVersionFor(f)
+		keylen = keylenFor(name, title, class, vers)
+	)
 
 	buf := rbytes.NewWBuffer(nil, nil, uint32(keylen), dir.file)
 	switch obj := obj.(type) {
@@ -152,7 +151,7 @@ func newKeyFrom(dir *tdirectoryFile, name, title, class string, obj root.Object,
 	k := Key{
 		f:        f,
 		nbytes:   keylen + objlen,
-		rvers:    rvers.Key,
+		rvers:    vers,
 		keylen:   keylen,
 		objlen:   objlen,
 		datetime: nowUTC(),
@@ -160,15 +159,11 @@ func newKeyFrom(dir *tdirectoryFile, name, title, class string, obj root.Object,
 		class:    class,
 		name:     name,
 		title:    title,
-		seekkey:  f.end,
 		seekpdir: dir.seekdir,
 		obj:      obj,
 		otyp:     reflect.TypeOf(obj),
 		parent:   dir,
 	}
-	if f.end > kStartBigFile {
-		k.rvers += 1000
-	}
 
 	k.buf, err = rcompress.Compress(nil, buf.Bytes(), k.f.compression)
 	if err != nil {
@@ -176,9 +171,9 @@ func newKeyFrom(dir *tdirectoryFile, name, title, class string, obj root.Object,
 	}
 	k.nbytes = k.keylen + int32(len(k.buf))
 
-	err = f.setEnd(k.seekkey + int64(k.nbytes))
+	k.seekkey, err = f.allocate(int64(k.nbytes))
 	if err != nil {
-		return k, fmt.Errorf("riofs: could not update ROOT file end: %w", err)
+		return k, fmt.Errorf("riofs: could not allocate space for key %q: %w", name, err)
 	}
 
 	return k, nil
@@ -190,12 +185,15 @@ func newKeyFromBuf(dir *tdirectoryFile, name, title, class string, cycle int16,
 		dir = &f.dir
 	}
 
-	keylen := keylenFor(name, title, class, dir)
-	objlen := int32(len(buf))
+	var (
+		vers   = keyVersionFor(f)
+		keylen = keylenFor(name, title, class, vers)
+		objlen = int32(len(buf))
+	)
 	k := Key{
 		f:        f,
 		nbytes:   keylen + objlen,
-		rvers:    rvers.Key,
+		rvers:    vers,
 		keylen:   keylen,
 		objlen:   objlen,
 		datetime: nowUTC(),
@@ -203,13 +201,9 @@ func newKeyFromBuf(dir *tdirectoryFile, name, title, class string, cycle int16,
 		class:    class,
 		name:     name,
 		title:    title,
-		seekkey:  f.end,
 		seekpdir: dir.seekdir,
 		parent:   dir,
 	}
-	if f.end > kStartBigFile {
-		k.rvers += 1000
-	}
 
 	k.buf, err = rcompress.Compress(nil, buf, k.f.compression)
 	if err != nil {
@@ -217,9 +211,9 @@ func newKeyFromBuf(dir *tdirectoryFile, name, title, class string, cycle int16,
 	}
 	k.nbytes = k.keylen + int32(len(k.buf))
 
-	err = f.setEnd(k.seekkey + int64(k.nbytes))
+	k.seekkey, err = f.allocate(int64(k.nbytes))
 	if err != nil {
-		return k, fmt.Errorf("riofs: could not update ROOT file end: %w", err)
+		return k, fmt.Errorf("riofs: could not allocate space for key %q: %w", name, err)
 	}
 
 	return k, nil
@@ -245,7 +239,7 @@ func NewKeyForBasketInternal(dir Directory, name, title, class string, cycle int
 
 	k := Key{
 		f:        f,
-		rvers:    rvers.Key,
+		rvers:    keyVersionFor(f),
 		cycle:    cycle,
 		datetime: nowUTC(),
 		class:    class,
@@ -256,9 +250,6 @@ func NewKeyForBasketInternal(dir Directory, name, title, class string, cycle int
 	}
 	k.keylen = k.sizeof()
 	k.nbytes = k.keylen
-	if f.end > kStartBigFile {
-		k.rvers += 1000
-	}
 
 	if d != nil {
 		k.seekpdir = d.seekdir
@@ -267,6 +258,116 @@ func NewKeyForBasketInternal(dir Directory, name, title, class string, cycle int
 	return k
 }
 
+// WriteBlobInternal writes the provided, possibly already compressed, payload
+// under a new anonymous RBlob key and returns the position of that payload
+// in the file.
+// objlen is the size of the uncompressed payload.
+// The key is not attached to any directory.
+// This is needed for RNTuple persistency.
+//
+// DO NOT USE.
+func WriteBlobInternal(f *File, blob []byte, objlen int32) (int64, error) {
+	const class = "RBlob"
+	var (
+		err    error
+		dir    = &f.dir
+		vers   = keyVersionFor(f)
+		keylen = keylenFor("", "", class, vers)
+	)
+
+	k := Key{
+		f:        f,
+		nbytes:   keylen + int32(len(blob)),
+		rvers:    vers,
+		keylen:   keylen,
+		objlen:   objlen,
+		datetime: nowUTC(),
+		cycle:    1,
+		class:    class,
+		seekpdir: dir.seekdir,
+		parent:   dir,
+		buf:      blob,
+	}
+
+	k.seekkey, err = f.allocate(int64(k.nbytes))
+	if err != nil {
+		return 0, fmt.Errorf("riofs: could not allocate space for blob: %w", err)
+	}
+
+	_, err = k.writeFile(f)
+	if err != nil {
+		return 0, fmt.Errorf("riofs: could not write blob: %w", err)
+	}
+
+	return k.seekkey + int64(k.keylen), nil
+}
+
+// CopyRawKeyInternal writes the provided raw key, i.e. the key header
+// followed by its (possibly compressed) payload, to the file holding dir.
+// The payload is neither decompressed nor recompressed.
+// CopyRawKeyInternal returns the relocated key.
+// This is needed for fast-copying Tree/Branch/Basket data between files.
+//
+// DO NOT USE.
+func CopyRawKeyInternal(dir Directory, raw []byte) (Key, error) {
+	var (
+		k Key
+		f = fileOf(dir)
+		d *tdirectoryFile
+	)
+	switch v := dir.(type) {
+	case *File:
+		d = &v.dir
+	case *tdirectoryFile:
+		d = v
+	default:
+		return k, fmt.Errorf("riofs: invalid directory type %T", dir)
+	}
+
+	r := rbytes.NewRBuffer(raw, nil, 0, nil)
+	err := k.UnmarshalROOT(r)
+	if err != nil {
+		return k, fmt.Errorf("riofs: could not decode raw key: %w", err)
+	}
+	if int(k.nbytes) != len(raw) {
+		return k, fmt.Errorf(
+			"riofs: invalid raw key %q size (got=%d, want=%d)",
+			k.name, len(raw), k.nbytes,
+		)
+	}
+	hdr := int(r.Pos())
+
+	k.f = f
+	k.parent = dir
+	k.seekpdir = d.seekdir
+	k.seekkey, err = f.allocate(int64(k.nbytes))
+	if err != nil {
+		return k, fmt.Errorf("riofs: could not allocate space for key %q: %w", k.name, err)
+	}
+	if !k.isBigFile() && (k.seekkey > kStartBigFile || k.seekpdir > kStartBigFile) {
+		return k, fmt.Errorf("riofs: could not relocate small-file key %q beyond 2GB", k.name)
+	}
+
+	w := rbytes.NewWBuffer(make([]byte, hdr), nil, 0, f)
+	_, err = k.MarshalROOT(w)
+	if err != nil {
+		return k, fmt.Errorf("riofs: could not encode key %q: %w", k.name, err)
+	}
+	if n := len(w.Bytes()); n != hdr {
+		return k, fmt.Errorf("riofs: invalid key %q header size (got=%d, want=%d)", k.name, n, hdr)
+	}
+
+	buf := make([]byte, len(raw))
+	copy(buf, w.Bytes())
+	copy(buf[hdr:], raw[hdr:])
+	_, err = f.WriteAt(buf, k.seekkey)
+	if err != nil {
+		return k, fmt.Errorf("riofs: could not write key %q: %w", k.name, err)
+	}
+
+	return k, nil
+}
+
 // KeyFromDir creates a new empty key (with no associated payload object)
 // with provided name and title, and the expected object type name.
 // The key will be held by the provided directory.
@@ -464,12 +565,21 @@ func (k *Key) isBigFile() bool {
 
 // sizeof returns the size in bytes of the key header structure.
 func (k *Key) sizeof() int32 {
-	return keylenFor(k.name, k.title, k.class, &k.f.dir)
+	return keylenFor(k.name, k.title, k.class, k.rvers)
+}
+
+// keyVersionFor returns the version of a new key written at the end of f.
+// Keys written past kStartBigFile use 64-bit seek pointers.
+func keyVersionFor(f *File) int16 {
+	if f.end > kStartBigFile {
+		return rvers.Key + 1000
+	}
+	return rvers.Key
 }
 
-func keylenFor(name, title, class string, dir *tdirectoryFile) int32 {
+func keylenFor(name, title, class string, vers int16) int32 {
 	nbytes := int32(22)
-	if dir.isBigFile() {
+	if vers > 1000 {
 		nbytes += 8
 	}
 	nbytes += datimeSizeof()
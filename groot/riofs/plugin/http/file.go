@@ -0,0 +1,313 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package http
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+// File is a remote file read with HTTP range requests.
+//
+// File reads the remote file by blocks and keeps the most recently used
+// blocks in a cache.
+// File is safe for concurrent use by multiple goroutines.
+type File struct {
+	c     *http.Client
+	url   string
+	size  int64 // size of the remote file
+	bsize int64 // size of a block
+
+	mu    sync.Mutex
+	pos   int64 // current position for Read and Seek
+	cache *lru
+}
+
+// Size returns the size in bytes of the remote file.
+func (f *File) Size() int64 { return f.size }
+
+// Close implements io.Closer.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cache = newLRU(f.cache.cap)
+	return nil
+}
+
+// Read implements io.Reader.
+func (f *File) Read(p []byte) (int, error) {
+	f.mu.Lock()
+	pos := f.pos
+	f.mu.Unlock()
+
+	n, err := f.ReadAt(p, pos)
+
+	f.mu.Lock()
+	f.pos = pos + int64(n)
+	f.mu.Unlock()
+	return n, err
+}
+
+// Seek implements io.Seeker.
+func (f *File) Seek(offset int64, whence int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pos := offset
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		pos += f.pos
+	case io.SeekEnd:
+		pos += f.size
+	default:
+		return 0, fmt.Errorf("http: invalid whence %d", whence)
+	}
+	if pos < 0 {
+		return 0, fmt.Errorf("http: negative position %d", pos)
+	}
+	f.pos = pos
+	return pos, nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (f *File) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("http: negative offset %d", off)
+	}
+	if off >= f.size {
+		return 0, io.EOF
+	}
+
+	var (
+		n   = len(p)
+		err error
+	)
+	if end := off + int64(n); end > f.size {
+		n = int(f.size - off)
+		err = io.EOF
+	}
+
+	blks, e := f.blocks([]span{{off, int64(n)}})
+	if e != nil {
+		return 0, e
+	}
+	f.fill(p[:n], off, blks)
+
+	return n, err
+}
+
+// ReadAtv implements riofs.ReaderAtv.
+//
+// ReadAtv fetches all the blocks missing from the cache needed by the
+// provided chunks, coalescing contiguous blocks into a single range
+// request.
+func (f *File) ReadAtv(chunks []riofs.Chunk) error {
+	spans := make([]span, 0, len(chunks))
+	for _, chunk := range chunks {
+		if chunk.Off < 0 || chunk.Off+int64(len(chunk.Buf)) > f.size {
+			return fmt.Errorf(
+				"http: invalid chunk [%d, %d) for file of size %d",
+				chunk.Off, chunk.Off+int64(len(chunk.Buf)), f.size,
+			)
+		}
+		spans = append(spans, span{chunk.Off, int64(len(chunk.Buf))})
+	}
+
+	blks, err := f.blocks(spans)
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range chunks {
+		f.fill(chunk.Buf, chunk.Off, blks)
+	}
+	return nil
+}
+
+// fill fills p with the content of the file starting at off.
+func (f *File) fill(p []byte, off int64, blks map[int64][]byte) {
+	for len(p) > 0 {
+		var (
+			id  = off / f.bsize
+			beg = off - id*f.bsize
+			n   = copy(p, blks[id][beg:])
+		)
+		p = p[n:]
+		off += int64(n)
+	}
+}
+
+// span is a region of the remote file.
+type span struct {
+	off int64
+	len int64
+}
+
+// blocks returns the blocks covering the provided spans, fetching the
+// blocks missing from the cache.
+func (f *File) blocks(spans []span) (map[int64][]byte, error) {
+	var (
+		ids  []int64
+		blks = make(map[int64][]byte)
+	)
+
+	f.mu.Lock()
+	for _, sp := range spans {
+		if sp.len <= 0 {
+			continue
+		}
+		beg := sp.off / f.bsize
+		end := (sp.off + sp.len - 1) / f.bsize
+		for id := beg; id <= end; id++ {
+			if _, dup := blks[id]; dup {
+				continue
+			}
+			blk, ok := f.cache.get(id)
+			blks[id] = blk
+			if !ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	if len(ids) == 0 {
+		return blks, nil
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for i := 0; i < len(ids); {
+		j := i + 1
+		for j < len(ids) && ids[j] == ids[j-1]+1 {
+			j++
+		}
+		err := f.fetch(ids[i], ids[j-1], blks)
+		if err != nil {
+			return nil, err
+		}
+		i = j
+	}
+
+	return blks, nil
+}
+
+// fetch fetches the [beg, end] blocks of the remote file with a single
+// range request, and stores them in the cache.
+func (f *File) fetch(beg, end int64, blks map[int64][]byte) error {
+	var (
+		off = beg * f.bsize
+		n   = end*f.bsize + f.blockLen(end) - off
+	)
+
+	req, err := http.NewRequest(http.MethodGet, f.url, nil)
+	if err != nil {
+		return fmt.Errorf("http: could not create range request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+n-1))
+
+	resp, err := f.c.Do(req)
+	if err != nil {
+		return fmt.Errorf("http: could not send range request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("http: invalid response to range request [%d, %d): %s", off, off+n, resp.Status)
+	}
+
+	rbeg, rend, _, err := parseContentRange(resp.Header.Get("Content-Range"))
+	if err != nil {
+		return fmt.Errorf("http: could not parse response to range request: %w", err)
+	}
+	if rbeg != off || rend != off+n-1 {
+		return fmt.Errorf(
+			"http: invalid range in response: got=[%d, %d], want=[%d, %d]",
+			rbeg, rend, off, off+n-1,
+		)
+	}
+
+	buf := make([]byte, n)
+	_, err = io.ReadFull(resp.Body, buf)
+	if err != nil {
+		return fmt.Errorf("http: could not read range [%d, %d): %w", off, off+n, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for id := beg; id <= end; id++ {
+		i := (id - beg) * f.bsize
+		blk := buf[i : i+f.blockLen(id) : i+f.blockLen(id)]
+		blks[id] = blk
+		f.cache.add(id, blk)
+	}
+
+	return nil
+}
+
+// blockLen returns the length of the id-th block.
+// The last block of a file may be shorter than the others.
+func (f *File) blockLen(id int64) int64 {
+	n := f.size - id*f.bsize
+	if n > f.bsize {
+		n = f.bsize
+	}
+	return n
+}
+
+// lru is a least recently used cache of blocks.
+type lru struct {
+	cap int
+	ll  *list.List
+	db  map[int64]*list.Element
+}
+
+type lruEntry struct {
+	id  int64
+	blk []byte
+}
+
+func newLRU(n int) *lru {
+	return &lru{
+		cap: n,
+		ll:  list.New(),
+		db:  make(map[int64]*list.Element, n),
+	}
+}
+
+func (c *lru) get(id int64) ([]byte, bool) {
+	elmt, ok := c.db[id]
+	if !ok {
+		return nil, false
+	}
+	c.ll.MoveToFront(elmt)
+	return elmt.Value.(*lruEntry).blk, true
+}
+
+func (c *lru) add(id int64, blk []byte) {
+	if elmt, ok := c.db[id]; ok {
+		c.ll.MoveToFront(elmt)
+		elmt.Value.(*lruEntry).blk = blk
+		return
+	}
+	c.db[id] = c.ll.PushFront(&lruEntry{id: id, blk: blk})
+	for c.ll.Len() > c.cap {
+		elmt := c.ll.Back()
+		c.ll.Remove(elmt)
+		delete(c.db, elmt.Value.(*lruEntry).id)
+	}
+}
+
+var (
+	_ riofs.Reader    = (*File)(nil)
+	_ riofs.ReaderAtv = (*File)(nil)
+	_ io.Seeker       = (*File)(nil)
+)
@@ -3,13 +3,21 @@
 // license that can be found in the LICENSE file.
 
 // Package http is a plugin for riofs.Open to support opening ROOT files over http(s).
+//
+// Remote files are read with HTTP range requests, through a LRU cache of
+// fixed-size blocks.
+// When the remote server does not support range requests, the whole file
+// is downloaded to a temporary file before being read.
 package http
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"go-hep.org/x/hep/groot/riofs"
 )
@@ -20,28 +28,150 @@ func init() {
 }
 
 func openFile(path string) (riofs.Reader, error) {
-	resp, err := http.Get(path)
+	return Open(path)
+}
+
+const (
+	defaultBlockSize = 256 * 1024 // default size in bytes of a cache block
+	defaultBlocks    = 256        // default number of blocks held in the cache
+)
+
+// Option configures how remote files are accessed.
+type Option func(f *File) error
+
+// WithClient configures the HTTP client used to access remote files.
+func WithClient(c *http.Client) Option {
+	return func(f *File) error {
+		f.c = c
+		return nil
+	}
+}
+
+// WithBlockSize sets the size in bytes of a block of the cache.
+// All range requests are aligned on blocks.
+func WithBlockSize(n int64) Option {
+	return func(f *File) error {
+		if n <= 0 {
+			return fmt.Errorf("http: invalid block size %d", n)
+		}
+		f.bsize = n
+		return nil
+	}
+}
+
+// WithCacheSize sets the number of blocks held in the cache.
+func WithCacheSize(n int) Option {
+	return func(f *File) error {
+		if n <= 0 {
+			return fmt.Errorf("http: invalid cache size %d", n)
+		}
+		f.cache = newLRU(n)
+		return nil
+	}
+}
+
+// Open opens the remote file at the provided URL for reading.
+//
+// Open probes the remote server with a range request.
+// When the server does not support range requests, Open downloads the
+// whole file to a temporary file, removed when the returned reader is closed.
+func Open(url string, opts ...Option) (riofs.Reader, error) {
+	f := &File{
+		c:     http.DefaultClient,
+		url:   url,
+		bsize: defaultBlockSize,
+		cache: newLRU(defaultBlocks),
+	}
+	for _, opt := range opts {
+		err := opt(f)
+		if err != nil {
+			return nil, fmt.Errorf("http: could not configure remote file: %w", err)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("http: could not create request: %w", err)
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=0-%d", f.bsize-1))
+
+	resp, err := f.c.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		_, _, size, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil {
+			return nil, fmt.Errorf("http: could not parse response to range request: %w", err)
+		}
+		f.size = size
+
+		blk := make([]byte, f.blockLen(0))
+		_, err = io.ReadFull(resp.Body, blk)
+		if err != nil {
+			return nil, fmt.Errorf("http: could not read first block of %q: %w", url, err)
+		}
+		f.cache.add(0, blk)
+		return f, nil
+
+	case http.StatusOK:
+		// ranges not supported: fall back to a full download.
+		return download(resp.Body)
+
+	default:
+		return nil, fmt.Errorf("http: could not open %q: %s", url, resp.Status)
+	}
+}
+
+func download(r io.Reader) (*tmpFile, error) {
 	f, err := ioutil.TempFile("", "riofs-remote-")
 	if err != nil {
 		return nil, err
 	}
-	_, err = io.CopyBuffer(f, resp.Body, make([]byte, 16*1024*1024))
+	_, err = io.CopyBuffer(f, r, make([]byte, 16*1024*1024))
 	if err != nil {
 		f.Close()
+		os.Remove(f.Name())
 		return nil, err
 	}
 	_, err = f.Seek(0, 0)
 	if err != nil {
 		f.Close()
+		os.Remove(f.Name())
 		return nil, err
 	}
 	return &tmpFile{f}, nil
+}
 
+// parseContentRange parses the value of a Content-Range header, as
+// "bytes <beg>-<end>/<size>".
+func parseContentRange(v string) (beg, end, size int64, err error) {
+	const prefix = "bytes "
+	if !strings.HasPrefix(v, prefix) {
+		return 0, 0, 0, fmt.Errorf("invalid Content-Range %q", v)
+	}
+	v = v[len(prefix):]
+	i := strings.Index(v, "-")
+	j := strings.Index(v, "/")
+	if i < 0 || j < i {
+		return 0, 0, 0, fmt.Errorf("invalid Content-Range %q", v)
+	}
+	beg, err = strconv.ParseInt(v[:i], 10, 64)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid Content-Range %q: %w", v, err)
+	}
+	end, err = strconv.ParseInt(v[i+1:j], 10, 64)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid Content-Range %q: %w", v, err)
+	}
+	size, err = strconv.ParseInt(v[j+1:], 10, 64)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid Content-Range %q: %w", v, err)
+	}
+	return beg, end, size, nil
 }
 
 // tmpFile wraps a regular os.File to automatically remove it when closed.
@@ -5,9 +5,20 @@
 package http
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
 )
 
 func TestTmpFile(t *testing.T) {
@@ -48,3 +59,244 @@ func TestTmpFile(t *testing.T) {
 		t.Fatalf("file %q should have been removed", tmp.Name())
 	}
 }
+
+func newTestServer(t *testing.T, ranges bool) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var (
+		n   = new(int32)
+		mux = http.NewServeMux()
+	)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(n, 1)
+		fname := filepath.Join("../../../testdata", filepath.Base(r.URL.Path))
+		if ranges {
+			http.ServeFile(w, r, fname)
+			return
+		}
+		raw, err := ioutil.ReadFile(fname)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(raw)
+	})
+	return httptest.NewServer(mux), n
+}
+
+func TestOpen(t *testing.T) {
+	for _, ranges := range []bool{true, false} {
+		t.Run(fmt.Sprintf("ranges=%v", ranges), func(t *testing.T) {
+			ts, _ := newTestServer(t, ranges)
+			defer ts.Close()
+
+			rr, err := Open(ts.URL + "/small-flat-tree.root")
+			if err != nil {
+				t.Fatalf("could not open remote file: %+v", err)
+			}
+			defer rr.Close()
+
+			switch r := rr.(type) {
+			case *File:
+				if !ranges {
+					t.Fatalf("invalid reader type %T", r)
+				}
+			case *tmpFile:
+				if ranges {
+					t.Fatalf("invalid reader type %T", r)
+				}
+			default:
+				t.Fatalf("invalid reader type %T", r)
+			}
+
+			f, err := riofs.Open(ts.URL + "/small-flat-tree.root")
+			if err != nil {
+				t.Fatalf("could not open remote ROOT file: %+v", err)
+			}
+			defer f.Close()
+
+			obj, err := f.Get("tree")
+			if err != nil {
+				t.Fatalf("could not get tree: %+v", err)
+			}
+			tree := obj.(rtree.Tree)
+
+			var (
+				i32 int32
+				sli []float64
+			)
+			r, err := rtree.NewReader(tree, []rtree.ReadVar{
+				{Name: "Int32", Value: &i32},
+				{Name: "SliceFloat64", Value: &sli},
+			})
+			if err != nil {
+				t.Fatalf("could not create tree reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx rtree.RCtx) error {
+				if got, want := i32, int32(ctx.Entry); got != want {
+					return fmt.Errorf("entry %d: invalid Int32: got=%d, want=%d", ctx.Entry, got, want)
+				}
+				if got, want := len(sli), int(ctx.Entry%10); got != want {
+					return fmt.Errorf("entry %d: invalid slice length: got=%d, want=%d", ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+		})
+	}
+}
+
+func TestOpenError(t *testing.T) {
+	ts, _ := newTestServer(t, true)
+	defer ts.Close()
+
+	_, err := Open(ts.URL + "/not-there.root")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), "404 Not Found"; !strings.Contains(got, want) {
+		t.Fatalf("invalid error: got=%q, want=%q", got, want)
+	}
+}
+
+func TestFileReadAt(t *testing.T) {
+	const fname = "small-flat-tree.root"
+	want, err := ioutil.ReadFile(filepath.Join("../../../testdata", fname))
+	if err != nil {
+		t.Fatalf("could not read reference file: %+v", err)
+	}
+
+	ts, nreqs := newTestServer(t, true)
+	defer ts.Close()
+
+	const bsize = 64
+	r, err := Open(ts.URL+"/"+fname, WithBlockSize(bsize), WithCacheSize(4))
+	if err != nil {
+		t.Fatalf("could not open remote file: %+v", err)
+	}
+	defer r.Close()
+	f := r.(*File)
+
+	if got, want := f.Size(), int64(len(want)); got != want {
+		t.Fatalf("invalid size: got=%d, want=%d", got, want)
+	}
+
+	for _, tc := range []struct {
+		off int64
+		n   int
+		err error
+	}{
+		{off: 0, n: 10},
+		{off: 10, n: 100},
+		{off: 1000, n: 3 * bsize},
+		{off: 1000, n: 10 * bsize},
+		{off: int64(len(want)) - 10, n: 10},
+		{off: int64(len(want)) - 10, n: 20, err: io.EOF},
+		{off: int64(len(want)), n: 20, err: io.EOF},
+	} {
+		t.Run(fmt.Sprintf("off=%d-n=%d", tc.off, tc.n), func(t *testing.T) {
+			p := make([]byte, tc.n)
+			n, err := f.ReadAt(p, tc.off)
+			if err != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%v", err, tc.err)
+			}
+			end := tc.off + int64(tc.n)
+			if end > int64(len(want)) {
+				end = int64(len(want))
+			}
+			if !bytes.Equal(p[:n], want[tc.off:end]) {
+				t.Fatalf("invalid content")
+			}
+		})
+	}
+
+	// blocks in cache should not be fetched again.
+	atomic.StoreInt32(nreqs, 0)
+	_, err = f.ReadAt(make([]byte, 10), int64(len(want))-10)
+	if err != nil {
+		t.Fatalf("could not read from cache: %+v", err)
+	}
+	if got := atomic.LoadInt32(nreqs); got != 0 {
+		t.Fatalf("invalid number of requests: got=%d, want=0", got)
+	}
+
+	// contiguous blocks should be fetched with a single request.
+	chunks := []riofs.Chunk{
+		{Off: 10 * bsize, Buf: make([]byte, 10)},
+		{Off: 11*bsize + 10, Buf: make([]byte, bsize)},
+		{Off: 10*bsize + 20, Buf: make([]byte, 10)},
+	}
+	err = f.ReadAtv(chunks)
+	if err != nil {
+		t.Fatalf("could not read chunks: %+v", err)
+	}
+	if got := atomic.LoadInt32(nreqs); got != 1 {
+		t.Fatalf("invalid number of requests: got=%d, want=1", got)
+	}
+	for _, chunk := range chunks {
+		if !bytes.Equal(chunk.Buf, want[chunk.Off:chunk.Off+int64(len(chunk.Buf))]) {
+			t.Fatalf("invalid content for chunk at %d", chunk.Off)
+		}
+	}
+
+	// non-contiguous blocks need one request per run of blocks.
+	atomic.StoreInt32(nreqs, 0)
+	err = f.ReadAtv([]riofs.Chunk{
+		{Off: 20 * bsize, Buf: make([]byte, 10)},
+		{Off: 30 * bsize, Buf: make([]byte, 10)},
+	})
+	if err != nil {
+		t.Fatalf("could not read chunks: %+v", err)
+	}
+	if got := atomic.LoadInt32(nreqs); got != 2 {
+		t.Fatalf("invalid number of requests: got=%d, want=2", got)
+	}
+
+	err = f.ReadAtv([]riofs.Chunk{{Off: int64(len(want)), Buf: make([]byte, 10)}})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	// read and seek.
+	pos, err := f.Seek(-10, io.SeekEnd)
+	if err != nil {
+		t.Fatalf("could not seek: %+v", err)
+	}
+	if got, want := pos, int64(len(want))-10; got != want {
+		t.Fatalf("invalid position: got=%d, want=%d", got, want)
+	}
+	raw, err := ioutil.ReadAll(f)
+	if err != nil {
+		t.Fatalf("could not read: %+v", err)
+	}
+	if !bytes.Equal(raw, want[pos:]) {
+		t.Fatalf("invalid content")
+	}
+}
+
+func TestLRU(t *testing.T) {
+	c := newLRU(2)
+	c.add(1, []byte("1"))
+	c.add(2, []byte("2"))
+	if _, ok := c.get(1); !ok {
+		t.Fatalf("could not find block 1")
+	}
+	c.add(3, []byte("3"))
+	if _, ok := c.get(2); ok {
+		t.Fatalf("block 2 should have been evicted")
+	}
+	for _, id := range []int64{1, 3} {
+		blk, ok := c.get(id)
+		if !ok {
+			t.Fatalf("could not find block %d", id)
+		}
+		if got, want := string(blk), fmt.Sprintf("%d", id); got != want {
+			t.Fatalf("invalid block %d: got=%q, want=%q", id, got, want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// config holds the configuration needed to access an S3 object store.
+type config struct {
+	endpoint string // URL of the S3 service
+	region   string
+	creds    credentials
+}
+
+// credentials holds the credentials used to sign requests.
+// Requests are sent anonymously when no access key is configured.
+type credentials struct {
+	key    string // access key ID
+	secret string // secret access key
+	token  string // session token
+}
+
+func (creds credentials) anonymous() bool {
+	return creds.key == "" || creds.secret == ""
+}
+
+// loadConfig loads the S3 configuration from the standard AWS environment
+// variables and configuration files.
+//
+// Environment variables take precedence over the configuration files:
+//  - AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN,
+//  - AWS_REGION or AWS_DEFAULT_REGION,
+//  - AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL,
+//  - AWS_PROFILE selects the profile of the configuration files,
+//  - AWS_SHARED_CREDENTIALS_FILE (default: ~/.aws/credentials),
+//  - AWS_CONFIG_FILE (default: ~/.aws/config).
+func loadConfig() (config, error) {
+	var (
+		cfg     config
+		profile = getenv("AWS_PROFILE", "AWS_DEFAULT_PROFILE")
+		home, _ = os.UserHomeDir()
+	)
+	if profile == "" {
+		profile = "default"
+	}
+
+	// the config file names profiles as "[profile name]", except for the
+	// default one.
+	cname := "profile " + profile
+	if profile == "default" {
+		cname = profile
+	}
+	for _, file := range []struct {
+		env     string
+		name    string
+		section string
+	}{
+		{"AWS_CONFIG_FILE", filepath.Join(home, ".aws", "config"), cname},
+		{"AWS_SHARED_CREDENTIALS_FILE", filepath.Join(home, ".aws", "credentials"), profile},
+	} {
+		fname := os.Getenv(file.env)
+		if fname == "" {
+			if home == "" {
+				continue
+			}
+			fname = file.name
+		}
+		kvs, err := loadINI(fname, file.section)
+		if err != nil {
+			return cfg, err
+		}
+		set := func(v *string, key string) {
+			if val, ok := kvs[key]; ok {
+				*v = val
+			}
+		}
+		set(&cfg.region, "region")
+		set(&cfg.endpoint, "endpoint_url")
+		set(&cfg.creds.key, "aws_access_key_id")
+		set(&cfg.creds.secret, "aws_secret_access_key")
+		set(&cfg.creds.token, "aws_session_token")
+	}
+
+	if key := os.Getenv("AWS_ACCESS_KEY_ID"); key != "" {
+		cfg.creds = credentials{
+			key:    key,
+			secret: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			token:  os.Getenv("AWS_SESSION_TOKEN"),
+		}
+	}
+	if v := getenv("AWS_REGION", "AWS_DEFAULT_REGION"); v != "" {
+		cfg.region = v
+	}
+	if v := getenv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"); v != "" {
+		cfg.endpoint = v
+	}
+
+	if cfg.region == "" {
+		cfg.region = "us-east-1"
+	}
+	if cfg.endpoint == "" {
+		cfg.endpoint = "https://s3." + cfg.region + ".amazonaws.com"
+	}
+	cfg.endpoint = strings.TrimRight(cfg.endpoint, "/")
+
+	return cfg, nil
+}
+
+// getenv returns the value of the first non-empty environment variable.
+func getenv(keys ...string) string {
+	for _, key := range keys {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// loadINI loads the key/value pairs of the named section of an INI file.
+// loadINI returns no error if the file does not exist.
+func loadINI(fname, section string) (map[string]string, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("s3: could not open configuration file: %w", err)
+	}
+	defer f.Close()
+
+	kvs, err := parseINI(f, section)
+	if err != nil {
+		return nil, fmt.Errorf("s3: could not parse configuration file %q: %w", fname, err)
+	}
+	return kvs, nil
+}
+
+func parseINI(r io.Reader, section string) (map[string]string, error) {
+	var (
+		kvs = make(map[string]string)
+		cur string
+		sc  = bufio.NewScanner(r)
+	)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "", strings.HasPrefix(line, "#"), strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("invalid section %q", line)
+			}
+			cur = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		if cur != section {
+			continue
+		}
+		i := strings.Index(line, "=")
+		if i < 0 {
+			// nested settings (e.g. "s3 =\n  key = value") are not supported
+			// and skipped.
+			continue
+		}
+		kvs[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return kvs, nil
+}
@@ -0,0 +1,330 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package s3 is a plugin for riofs.Open and riofs.Create to support
+// reading and writing ROOT files on S3-compatible object stores, with
+// URIs of the form:
+//
+//  s3://bucket/path/to/file.root
+//
+// Files are read with signed range requests.
+// Files are created locally in a temporary file and uploaded with a
+// multipart upload when closed.
+//
+// The endpoint, region and credentials are taken from the standard AWS
+// environment variables (AWS_ENDPOINT_URL, AWS_REGION, AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_PROFILE, ...) and
+// configuration files (~/.aws/config and ~/.aws/credentials).
+// Requests are sent anonymously when no credentials are configured.
+package s3
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-hep.org/x/hep/groot/riofs"
+	rhttp "go-hep.org/x/hep/groot/riofs/plugin/http"
+)
+
+func init() {
+	riofs.Register("s3", openFile)
+	riofs.RegisterWriter("s3", createFile)
+}
+
+func openFile(path string) (riofs.Reader, error) {
+	return Open(path)
+}
+
+func createFile(path string) (riofs.Writer, error) {
+	return Create(path)
+}
+
+const (
+	defaultPartSize = 16 * 1024 * 1024 // default size in bytes of an uploaded part
+)
+
+// Option configures how objects are accessed.
+type Option func(c *client) error
+
+// WithPartSize sets the size in bytes of the parts of multipart uploads.
+// S3 requires all parts but the last one to be at least 5MiB.
+func WithPartSize(n int64) Option {
+	return func(c *client) error {
+		if n <= 0 {
+			return fmt.Errorf("s3: invalid part size %d", n)
+		}
+		c.psize = n
+		return nil
+	}
+}
+
+// Open opens the object at the provided s3://bucket/key URI for reading.
+func Open(uri string, opts ...Option) (riofs.Reader, error) {
+	c, bucket, key, err := newClient(uri, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := rhttp.Open(c.url(bucket, key, nil), rhttp.WithClient(c.http))
+	if err != nil {
+		return nil, fmt.Errorf("s3: could not open %q: %w", uri, err)
+	}
+	return r, nil
+}
+
+// Create creates the object at the provided s3://bucket/key URI.
+//
+// The content of the object is written to a local temporary file, and
+// uploaded when the returned writer is closed.
+func Create(uri string, opts ...Option) (riofs.Writer, error) {
+	c, bucket, key, err := newClient(uri, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := ioutil.TempFile("", "riofs-s3-")
+	if err != nil {
+		return nil, fmt.Errorf("s3: could not create temporary file: %w", err)
+	}
+
+	return &writer{File: f, c: c, bucket: bucket, key: key}, nil
+}
+
+// client sends requests to an S3 service.
+type client struct {
+	http     *http.Client
+	endpoint string
+	psize    int64 // size of a part for multipart uploads
+}
+
+func newClient(uri string, opts []Option) (*client, string, string, error) {
+	bucket, key, err := parseURI(uri)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	c := &client{
+		http: &http.Client{
+			Transport: &signer{
+				rt:     http.DefaultTransport,
+				region: cfg.region,
+				creds:  cfg.creds,
+				now:    time.Now,
+			},
+		},
+		endpoint: cfg.endpoint,
+		psize:    defaultPartSize,
+	}
+	for _, opt := range opts {
+		err := opt(c)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("s3: could not configure client: %w", err)
+		}
+	}
+
+	return c, bucket, key, nil
+}
+
+// parseURI returns the bucket and key of an s3://bucket/key URI.
+func parseURI(uri string) (bucket, key string, err error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", fmt.Errorf("s3: could not parse URI %q: %w", uri, err)
+	}
+	if u.Scheme != "s3" {
+		return "", "", fmt.Errorf("s3: invalid URI scheme %q", u.Scheme)
+	}
+	bucket = u.Host
+	key = strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || key == "" {
+		return "", "", fmt.Errorf("s3: invalid URI %q (want s3://bucket/key)", uri)
+	}
+	return bucket, key, nil
+}
+
+// url returns the path-style URL of the provided object.
+func (c *client) url(bucket, key string, query url.Values) string {
+	u := c.endpoint + uriEncode("/"+bucket+"/"+key, false)
+	if len(query) > 0 {
+		u += "?" + strings.Replace(query.Encode(), "uploads=", "uploads", 1)
+	}
+	return u
+}
+
+// do sends a request with the provided payload and checks the status of
+// the response.
+func (c *client) do(method, uri string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, uri, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("s3: could not create request: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3: could not send request: %w", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("s3: invalid response to %s request: %s: %w", method, resp.Status, errorFrom(resp.Body))
+	}
+	return resp, nil
+}
+
+// upload uploads the content of r as the provided object, with a
+// multipart upload.
+// upload aborts the multipart upload on error.
+func (c *client) upload(bucket, key string, r io.Reader) (err error) {
+	resp, err := c.do(http.MethodPost, c.url(bucket, key, url.Values{"uploads": {""}}), nil)
+	if err != nil {
+		return fmt.Errorf("s3: could not initiate multipart upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var init struct {
+		UploadID string `xml:"UploadId"`
+	}
+	err = xml.NewDecoder(resp.Body).Decode(&init)
+	if err != nil {
+		return fmt.Errorf("s3: could not decode multipart upload: %w", err)
+	}
+	id := init.UploadID
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		resp, e := c.do(http.MethodDelete, c.url(bucket, key, url.Values{"uploadId": {id}}), nil)
+		if e == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	type part struct {
+		PartNumber int
+		ETag       string
+	}
+	var (
+		parts []part
+		buf   = make([]byte, c.psize)
+	)
+	for i := 1; ; i++ {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return fmt.Errorf("s3: could not read part %d: %w", i, err)
+		}
+		if n == 0 && i > 1 {
+			break
+		}
+
+		query := url.Values{
+			"partNumber": {strconv.Itoa(i)},
+			"uploadId":   {id},
+		}
+		resp, err := c.do(http.MethodPut, c.url(bucket, key, query), buf[:n])
+		if err != nil {
+			return fmt.Errorf("s3: could not upload part %d: %w", i, err)
+		}
+		resp.Body.Close()
+		parts = append(parts, part{PartNumber: i, ETag: resp.Header.Get("ETag")})
+
+		if n < len(buf) {
+			break
+		}
+	}
+
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"CompleteMultipartUpload"`
+		Parts   []part   `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return fmt.Errorf("s3: could not encode multipart upload completion: %w", err)
+	}
+
+	resp, err = c.do(http.MethodPost, c.url(bucket, key, url.Values{"uploadId": {id}}), body)
+	if err != nil {
+		return fmt.Errorf("s3: could not complete multipart upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// S3 may report an error with a 200 status once the upload has started
+	// to be processed.
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("s3: could not read multipart upload completion: %w", err)
+	}
+	if bytes.Contains(raw, []byte("<Error>")) {
+		return fmt.Errorf("s3: could not complete multipart upload: %w", errorFrom(bytes.NewReader(raw)))
+	}
+
+	return nil
+}
+
+// errorFrom decodes the error returned by an S3 service.
+func errorFrom(r io.Reader) error {
+	var e struct {
+		Code    string
+		Message string
+	}
+	err := xml.NewDecoder(r).Decode(&e)
+	if err != nil || e.Code == "" {
+		return fmt.Errorf("unknown error")
+	}
+	return fmt.Errorf("%s: %s", e.Code, e.Message)
+}
+
+// writer writes an object to a local temporary file and uploads it when
+// closed.
+type writer struct {
+	*os.File
+	c      *client
+	bucket string
+	key    string
+}
+
+// Close uploads the content of the object and removes the local
+// temporary file.
+func (w *writer) Close() error {
+	defer os.Remove(w.File.Name())
+	defer w.File.Close()
+
+	_, err := w.File.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("s3: could not rewind temporary file: %w", err)
+	}
+
+	err = w.c.upload(w.bucket, w.key, w.File)
+	if err != nil {
+		return fmt.Errorf("s3: could not upload s3://%s/%s: %w", w.bucket, w.key, err)
+	}
+
+	return w.File.Close()
+}
+
+// Abort removes the local temporary file without uploading its content.
+func (w *writer) Abort() error {
+	defer os.Remove(w.File.Name())
+	return w.File.Close()
+}
+
+var (
+	_ riofs.Writer = (*writer)(nil)
+)
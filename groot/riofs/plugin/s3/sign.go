@@ -0,0 +1,172 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	amzDate          = "20060102T150405Z"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// signer is a http.RoundTripper signing requests with the AWS signature
+// version 4.
+//
+// The X-Amz-Content-Sha256 header of requests should hold the SHA-256 of
+// their payload. Requests without that header are sent with an unsigned
+// payload.
+type signer struct {
+	rt     http.RoundTripper
+	region string
+	creds  credentials
+	now    func() time.Time
+}
+
+func (s *signer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if s.creds.anonymous() {
+		return s.rt.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	s.sign(req, s.now().UTC())
+	return s.rt.RoundTrip(req)
+}
+
+// sign adds the AWS signature version 4 of req to its headers.
+func (s *signer) sign(req *http.Request, now time.Time) {
+	var (
+		date  = now.Format(amzDate)
+		scope = date[:8] + "/" + s.region + "/s3/aws4_request"
+	)
+
+	req.Header.Set("X-Amz-Date", date)
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	}
+	if s.creds.token != "" {
+		req.Header.Set("X-Amz-Security-Token", s.creds.token)
+	}
+
+	hdrs, signed := canonicalHeaders(req)
+	creq := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req),
+		hdrs,
+		signed,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	str := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		date,
+		scope,
+		hexSHA256([]byte(creq)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.creds.secret), date[:8])
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	sig := hex.EncodeToString(hmacSHA256(key, str))
+
+	req.Header.Set("Authorization",
+		"AWS4-HMAC-SHA256 Credential="+s.creds.key+"/"+scope+
+			", SignedHeaders="+signed+
+			", Signature="+sig,
+	)
+}
+
+// canonicalHeaders returns the canonical headers of req and the list of
+// signed headers.
+// The Host, Content-MD5, Content-Type, Range and X-Amz-* headers are signed.
+func canonicalHeaders(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	hdrs := map[string]string{"host": host}
+	for k, v := range req.Header {
+		k = strings.ToLower(k)
+		switch {
+		case k == "content-md5", k == "content-type", k == "range", strings.HasPrefix(k, "x-amz-"):
+			hdrs[k] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+
+	keys := make([]string, 0, len(hdrs))
+	for k := range hdrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var o strings.Builder
+	for _, k := range keys {
+		o.WriteString(k + ":" + hdrs[k] + "\n")
+	}
+	return o.String(), strings.Join(keys, ";")
+}
+
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var kvs []string
+	for _, k := range keys {
+		vs := query[k]
+		sort.Strings(vs)
+		for _, v := range vs {
+			kvs = append(kvs, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(kvs, "&")
+}
+
+// uriEncode encodes s following the AWS rules: only the unreserved
+// characters are not percent-encoded.
+func uriEncode(s string, slash bool) string {
+	const hex = "0123456789ABCDEF"
+	var o strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			o.WriteByte(c)
+		case c == '/' && !slash:
+			o.WriteByte(c)
+		default:
+			o.WriteByte('%')
+			o.WriteByte(hex[c>>4])
+			o.WriteByte(hex[c&15])
+		}
+	}
+	return o.String()
+}
+
+func hexSHA256(p []byte) string {
+	sum := sha256.Sum256(p)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
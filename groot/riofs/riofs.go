@@ -37,6 +37,14 @@ type Directory interface {
 	// Put puts the object v under the key with the given name.
 	Put(name string, v root.Object) error
 
+	// Delete removes the object identified by namecycle from this directory.
+	//   namecycle has the format name;cycle
+	//
+	//   examples:
+	//     foo   : delete all cycles of the object named foo
+	//     foo;1 : delete cycle 1 of foo
+	Delete(namecycle string) error
+
 	// Keys returns the list of keys being held by this directory.
 	Keys() []Key
 
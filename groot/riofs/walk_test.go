@@ -362,6 +362,7 @@ type unknownDirImpl struct{}
 
 func (dir *unknownDirImpl) Get(namecycle string) (root.Object, error) { panic("not implemented") }
 func (dir *unknownDirImpl) Put(name string, v root.Object) error      { panic("not implemented") }
+func (dir *unknownDirImpl) Delete(namecycle string) error             { panic("not implemented") }
 func (dir *unknownDirImpl) Keys() []Key                               { panic("not implemented") }
 func (dir *unknownDirImpl) Mkdir(name string) (Directory, error)      { panic("not implemented") }
 func (dir *unknownDirImpl) Parent() Directory                         { return nil }
@@ -20,10 +20,10 @@ func CxxTemplateArgsOf(typename string) []string {
 	name = name[:len(name)-1]                // drop trailing '>'
 	name = strings.TrimSpace(name)
 
-	switch strings.Count(name, ",") {
-	case 0:
+	switch n := strings.Count(name, ","); {
+	case n == 0:
 		return []string{name}
-	case 1:
+	case n == 1 && !strings.Contains(name, "<"):
 		// easy case of std::map<K,V> where none of K or V are templated.
 		i := strings.Index(name, ",")
 		k := strings.TrimSpace(name[:i])
@@ -36,6 +36,10 @@ func TestCxxTemplateArgsOf(t *testing.T) {
 			n: "std::map<K,V>",
 			t: []string{"K", "V"},
 		},
+		{
+			n: "std::vector<std::map<K,V> >",
+			t: []string{"std::map<K,V>"},
+		},
 		{
 			n: "std::map<K, V>",
 			t: []string{"K", "V"},
@@ -24,6 +24,7 @@ func Connector(file *riofs.File) driver.Connector {
 type rootConnector struct {
 	drv  rootDriver
 	file *riofs.File
+	owns bool // whether the connector owns the ROOT file (and needs to close it)
 }
 
 // Connect returns a connection to the database.
@@ -38,7 +39,7 @@ type rootConnector struct {
 // The returned connection is only used by one goroutine at a
 // time.
 func (c *rootConnector) Connect(ctx context.Context) (driver.Conn, error) {
-	return c.drv.connect(c.file), nil
+	return c.drv.connect(c.file, c.owns), nil
 }
 
 // Driver returns the underlying Driver of the Connector,
@@ -49,6 +50,10 @@ func (c *rootConnector) Driver() driver.Driver {
 }
 
 // OpenDB opens a database/sql.DB from an already open ROOT file.
+//
+// Tables can be created and filled if the ROOT file has been opened for
+// writing (e.g. with riofs.Update).
+// The ROOT file should be closed after the returned database.
 func OpenDB(file *riofs.File) *sql.DB {
 	return sql.OpenDB(Connector(file))
 }
@@ -3,6 +3,13 @@
 // license that can be found in the LICENSE file.
 
 // Package rsqldrv registers a database/sql/driver.Driver implementation for ROOT files.
+//
+// Trees are exposed as SQL tables, and branches as columns.
+// SELECT statements support WHERE, GROUP BY (with COUNT, SUM, AVG, MIN and MAX),
+// HAVING, ORDER BY, LIMIT/OFFSET and DISTINCT clauses, the sqrt, abs, pow and log
+// functions, the id function (the entry number of a row), and (LEFT) JOINs
+// between two trees of the same file on a key column.
+// The right-hand side tree of a JOIN is loaded in memory.
 package rsqldrv // import "go-hep.org/x/hep/groot/rsql/rsqldrv"
 
 import (
@@ -12,6 +19,9 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/xwb1989/sqlparser"
@@ -35,8 +45,15 @@ func Open(name string) (*sql.DB, error) {
 // Create is a ROOT/SQL-driver helper function for sql.Open.
 //
 // It creates a new ROOT file, connected via the ROOT/SQL driver.
+// Tables created with CREATE TABLE, or by inserting rows into a new table,
+// are written as trees to the ROOT file, which is closed when the returned
+// database is closed.
 func Create(name string) (*sql.DB, error) {
-	panic("not implemented") // FIXME(sbinet)
+	f, err := riofs.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("rsqldrv: could not create file: %w", err)
+	}
+	return sql.OpenDB(&rootConnector{file: f, owns: true}), nil
 }
 
 // rootDriver implements the interface required by database/sql/driver.
@@ -79,7 +96,7 @@ func (drv *rootDriver) open(fname string) (driver.Conn, error) {
 	return conn, nil
 }
 
-func (drv *rootDriver) connect(f *riofs.File) driver.Conn {
+func (drv *rootDriver) connect(f *riofs.File, owns bool) driver.Conn {
 	drv.mu.Lock()
 	defer drv.mu.Unlock()
 	if drv.dbs == nil {
@@ -99,7 +116,7 @@ func (drv *rootDriver) connect(f *riofs.File) driver.Conn {
 			refs: 0,
 		}
 		drv.dbs[f.Name()] = conn
-		drv.owns[f.Name()] = false
+		drv.owns[f.Name()] = owns
 	}
 	conn.refs++
 
@@ -124,11 +141,14 @@ type driverConn struct {
 	drv  *rootDriver
 	stop map[*driverStmt]struct{}
 	refs int
+
+	tables map[string]*wtable // trees being written
+	tx     bool               // whether a transaction is in progress
 }
 
 // Prepare returns a prepared statement, bound to this connection.
 func (conn *driverConn) Prepare(query string) (driver.Stmt, error) {
-	stmt, err := sqlparser.Parse(query)
+	stmt, err := parse(query)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +182,16 @@ func (conn *driverConn) Close() error {
 		}
 	}
 
+	// rows of an unfinished transaction are discarded.
+	conn.tx = false
+	for _, tbl := range conn.tables {
+		err := tbl.w.Close()
+		if err != nil {
+			return fmt.Errorf("rsqldrv: could not close table %q: %w", tbl.name, err)
+		}
+	}
+	conn.tables = nil
+
 	var err error
 	if conn.drv.owns[conn.f.Name()] {
 		err = conn.f.Close()
@@ -179,20 +209,44 @@ func (conn *driverConn) Close() error {
 }
 
 // Begin starts and returns a new transaction.
+//
+// Rows inserted during a transaction are written to their tree when the
+// transaction is committed, and discarded when it is rolled back.
 func (conn *driverConn) Begin() (driver.Tx, error) {
-	panic("conn-begin: not implemented")
+	if conn.tx {
+		return nil, fmt.Errorf("rsqldrv: transaction already in progress")
+	}
+	conn.tx = true
+	return conn, nil
 }
 
 func (conn *driverConn) Commit() error {
-	panic("conn-commit: not implemented")
+	if !conn.tx {
+		return fmt.Errorf("rsqldrv: no transaction in progress")
+	}
+	conn.tx = false
+	for _, tbl := range conn.tables {
+		err := tbl.flush()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (conn *driverConn) Rollback() error {
-	panic("conn-rollback: not implemented")
+	if !conn.tx {
+		return fmt.Errorf("rsqldrv: no transaction in progress")
+	}
+	conn.tx = false
+	for _, tbl := range conn.tables {
+		tbl.rows = tbl.rows[:0]
+	}
+	return nil
 }
 
 func (conn *driverConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
-	stmt, err := sqlparser.Parse(query)
+	stmt, err := parse(query)
 	if err != nil {
 		return nil, err
 	}
@@ -200,12 +254,8 @@ func (conn *driverConn) ExecContext(ctx context.Context, query string, args []dr
 	return conn.exec(ctx, stmt, args)
 }
 
-func (conn *driverConn) exec(ctx context.Context, stmt sqlparser.Statement, args []driver.NamedValue) (driver.Result, error) {
-	panic("not implemented")
-}
-
 func (conn *driverConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
-	stmt, err := sqlparser.Parse(query)
+	stmt, err := parse(query)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +268,7 @@ func (conn *driverConn) query(ctx context.Context, stmt sqlparser.Statement, arg
 		rows, err := newDriverRows(ctx, conn, stmt, args)
 		return rows, err
 	}
-	panic("not implemented")
+	return nil, fmt.Errorf("rsqldrv: invalid query %q", sqlparser.String(stmt))
 }
 
 type driverResult struct {
@@ -234,13 +284,28 @@ type driverRows struct {
 	conn  *driverConn
 	args  []driver.NamedValue
 	cols  []string
-	types []colDescr    // types of the columns
-	deps  []string      // names of the columns to be read
-	vars  []interface{} // values of the columns that were read
+	types []colDescr // types of the columns
 
-	cursor *rtree.TreeScanner
-	eval   expression
+	ectx   *execCtx
+	src    rowSource
+	outs   []expression // expressions of the output columns
+	names  []string     // aliases of the output columns, if any
 	filter expression
+
+	groupBy  []expression
+	aggs     []*aggregate
+	having   expression
+	order    []orderKey
+	distinct bool
+	offset   int64
+	limit    int64 // -1 if no limit.
+
+	// whether the results need to be materialized before being returned
+	// (aggregation, ordering, de-duplication).
+	materialize bool
+	recs        []record
+	loaded      bool
+	nrows       int64 // number of rows read or returned so far
 }
 
 type colDescr struct {
@@ -250,96 +315,106 @@ type colDescr struct {
 	Type     reflect.Type
 }
 
-func newDriverRows(ctx context.Context, conn *driverConn, stmt *sqlparser.Select, args []driver.NamedValue) (*driverRows, error) {
-	var (
-		name = ""
-		f    = conn.f
-	)
-
-	switch len(stmt.From) {
-	case 1:
-		switch from := stmt.From[0].(type) {
-		case *sqlparser.AliasedTableExpr:
-			switch expr := from.Expr.(type) {
-			case sqlparser.TableName:
-				name = expr.Name.CompliantName()
-			default:
-				panic(fmt.Errorf("unknown FROM expression type: %#v", expr))
-			}
-
-		default:
-			panic(fmt.Errorf("unknown table expression: %#v", from))
-		}
-
-	default:
-		return nil, fmt.Errorf("rsqldrv: invalid number of tables (got=%d, want=1)", len(stmt.From))
-	}
+// selectCol describes an output column of a query.
+type selectCol struct {
+	name  string
+	alias string
+	expr  sqlparser.Expr
+	desc  *colDescr
+}
 
-	obj, err := riofs.Dir(f).Get(name)
+func newDriverRows(ctx context.Context, conn *driverConn, stmt *sqlparser.Select, args []driver.NamedValue) (*driverRows, error) {
+	tables, join, err := tablesFrom(conn.f, stmt.From)
 	if err != nil {
 		return nil, err
 	}
 
-	tree, ok := obj.(rtree.Tree)
-	if !ok {
-		return nil, fmt.Errorf("rsqldrv: object %q is not a Tree", name)
+	rows := &driverRows{
+		conn:     conn,
+		args:     args,
+		ectx:     newExecCtx(conn, args),
+		distinct: stmt.Distinct == sqlparser.DistinctStr,
+		limit:    -1,
 	}
 
-	rows := &driverRows{conn: conn, args: args}
-
-	rows.cols, err = rows.extractColsFromSelect(tree, stmt, args)
+	cols, err := selectColsFrom(tables, stmt.SelectExprs)
 	if err != nil {
 		return nil, fmt.Errorf("could not extract columns: %w", err)
 	}
-	rows.types = make([]colDescr, len(rows.cols))
-	for i, name := range rows.cols {
-		if name == "" {
-			rows.types[i].Type = reflect.TypeOf(new(interface{})).Elem()
-			continue
+
+	var (
+		aliases = make(map[string]bool)
+		anyType = reflect.TypeOf(new(interface{})).Elem()
+	)
+	rows.cols = make([]string, len(cols))
+	rows.types = make([]colDescr, len(cols))
+	rows.names = make([]string, len(cols))
+	for i, col := range cols {
+		rows.cols[i] = col.name
+		rows.names[i] = col.alias
+		if col.alias != "" {
+			aliases[col.alias] = true
 		}
-		rows.types[i].Name = name
-		branch := tree.Branch(name)
-		if branch == nil {
-			rows.types[i].Type = reflect.TypeOf(new(interface{})).Elem()
-			continue
+		switch col.desc {
+		case nil:
+			rows.types[i] = colDescr{Name: col.name, Len: -1, Type: anyType}
+		default:
+			rows.types[i] = *col.desc
 		}
-		rows.types[i] = colDescrFromLeaf(branch.Leaves()[0]) // FIXME(sbinet): multi-leaves' branches
 	}
 
-	vars, err := rows.extractDepsFromSelect(tree, stmt, args)
-	if err != nil {
-		return nil, fmt.Errorf("could not extract read-vars: %w", err)
+	// GROUP BY terms may refer to output columns by alias or position.
+	groupBy := make([]sqlparser.Expr, len(stmt.GroupBy))
+	for i, expr := range stmt.GroupBy {
+		groupBy[i] = expr
+		switch expr := expr.(type) {
+		case *sqlparser.SQLVal:
+			if expr.Type != sqlparser.IntVal {
+				break
+			}
+			pos, err := position(expr, len(cols))
+			if err != nil {
+				return nil, err
+			}
+			groupBy[i] = cols[pos].expr
+		case *sqlparser.ColName:
+			if !expr.Qualifier.IsEmpty() {
+				break
+			}
+			for _, col := range cols {
+				if col.alias != "" && col.alias == expr.Name.CompliantName() {
+					groupBy[i] = col.expr
+				}
+			}
+		}
 	}
-	rows.vars = varsFrom(vars)
-	for _, v := range vars {
-		rows.deps = append(rows.deps, v.Name)
+
+	using := make(map[string]bool)
+	if join != nil {
+		for _, col := range join.Condition.Using {
+			using[col.CompliantName()] = true
+		}
 	}
 
-	rows.cursor, err = rtree.NewTreeScannerVars(tree, vars...)
+	err = rows.extractDeps(tables, cols, stmt, join, groupBy, using, aliases)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not extract read-vars: %w", err)
 	}
 
-	switch expr := stmt.SelectExprs[0].(type) { // FIXME(sbinet): handle multiple select-expressions
-	case *sqlparser.AliasedExpr:
-		rows.eval, err = newExprFrom(expr.Expr, args)
+	rows.outs = make([]expression, len(cols))
+	for i, col := range cols {
+		rows.outs[i], err = newExprFrom(col.expr, args)
 		if err != nil {
 			return nil, fmt.Errorf("could not generate row expression: %w", err)
 		}
-	case *sqlparser.StarExpr:
-		tuple := make(sqlparser.ValTuple, len(rows.cols))
-		for i, name := range rows.cols {
-			tuple[i] = &sqlparser.ColName{Name: sqlparser.NewColIdent(name)}
-		}
-		rows.eval, err = newExprFrom(tuple, args)
-		if err != nil {
-			return nil, fmt.Errorf("could not generate row expression from 'select *': %w", err)
-		}
 	}
 
 	if stmt.Where != nil {
 		switch stmt.Where.Type {
 		case sqlparser.WhereStr:
+			if hasAggregate(stmt.Where.Expr) {
+				return nil, fmt.Errorf("rsqldrv: aggregate functions not allowed in WHERE clause")
+			}
 			rows.filter, err = newExprFrom(stmt.Where.Expr, args)
 			if err != nil {
 				return nil, err
@@ -349,6 +424,78 @@ func newDriverRows(ctx context.Context, conn *driverConn, stmt *sqlparser.Select
 		}
 	}
 
+	for _, expr := range groupBy {
+		if hasAggregate(expr) {
+			return nil, fmt.Errorf("rsqldrv: aggregate functions not allowed in GROUP BY clause")
+		}
+		v, err := newExprFrom(expr, args)
+		if err != nil {
+			return nil, err
+		}
+		rows.groupBy = append(rows.groupBy, v)
+	}
+
+	if stmt.Having != nil {
+		rows.having, err = newExprFrom(stmt.Having.Expr, args)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, order := range stmt.OrderBy {
+		key := orderKey{pos: -1, desc: order.Direction == sqlparser.DescScr}
+		switch expr := order.Expr.(type) {
+		case *sqlparser.SQLVal:
+			if expr.Type == sqlparser.IntVal {
+				key.pos, err = position(expr, len(cols))
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+			key.expr, err = newExprFrom(expr, args)
+		default:
+			key.expr, err = newExprFrom(expr, args)
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows.order = append(rows.order, key)
+	}
+
+	err = rows.extractAggregates(cols, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt.Limit != nil {
+		if stmt.Limit.Offset != nil {
+			rows.offset, err = rows.count(stmt.Limit.Offset)
+			if err != nil {
+				return nil, fmt.Errorf("rsqldrv: invalid OFFSET: %w", err)
+			}
+		}
+		rows.limit, err = rows.count(stmt.Limit.Rowcount)
+		if err != nil {
+			return nil, fmt.Errorf("rsqldrv: invalid LIMIT: %w", err)
+		}
+	}
+
+	if rows.having != nil && !rows.aggregating() {
+		return nil, fmt.Errorf("rsqldrv: HAVING clause requires GROUP BY or aggregate functions")
+	}
+	rows.materialize = rows.aggregating() || len(rows.order) > 0 || rows.distinct
+
+	switch join {
+	case nil:
+		rows.src, err = newTreeSource(tables[0])
+	default:
+		rows.src, err = rows.newJoinSource(tables, join, using)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	return rows, nil
 }
 
@@ -360,151 +507,317 @@ func varsFrom(vars []rtree.ReadVar) []interface{} {
 	return vs
 }
 
-// extractDepsFromSelect analyses the query and extracts the branches that need to be read
-// for the query to be properly executed.
-func (rows *driverRows) extractDepsFromSelect(tree rtree.Tree, stmt *sqlparser.Select, args []driver.NamedValue) ([]rtree.ReadVar, error) {
-	var (
-		vars []rtree.ReadVar
-
-		set  = make(map[string]struct{})
-		cols []string
-	)
-
-	markBranch := func(name string) {
-		if name != "" {
-			if _, dup := set[name]; !dup {
-				set[name] = struct{}{}
-				cols = append(cols, name)
-			}
-		}
-	}
-
-	collectCols := func(node sqlparser.SQLNode) (bool, error) {
-		switch node := node.(type) {
+// selectColsFrom returns the output columns of a query.
+func selectColsFrom(tables []*table, exprs sqlparser.SelectExprs) ([]selectCol, error) {
+	var cols []selectCol
+	for _, expr := range exprs {
+		switch expr := expr.(type) {
 		case *sqlparser.StarExpr:
-			other := node.TableName.Name.CompliantName()
-			switch other {
-			case "", tree.Name():
-				for _, b := range tree.Branches() {
-					markBranch(b.Name())
+			qual := expr.TableName.Name.CompliantName()
+			n := len(cols)
+			for _, tbl := range tables {
+				if !tbl.match(qual) {
+					continue
+				}
+				for _, b := range tbl.tree.Branches() {
+					col := &sqlparser.ColName{Name: sqlparser.NewColIdent(b.Name())}
+					if len(tables) > 1 {
+						col.Qualifier = sqlparser.TableName{Name: sqlparser.NewTableIdent(tbl.ref())}
+					}
+					desc := colDescrFromLeaf(b.Leaves()[0]) // multi-leaf branches are described by their first leaf.
+					cols = append(cols, selectCol{name: b.Name(), expr: col, desc: &desc})
 				}
-			default:
-				panic(fmt.Errorf("rsqldrv: star-expression with other table name not supported"))
 			}
-			return false, nil
+			if len(cols) == n {
+				return nil, fmt.Errorf("rsqldrv: unknown table %q in star-expression", qual)
+			}
 
-		case sqlparser.ColIdent:
-			name := node.CompliantName()
-			markBranch(name)
-			return false, nil
+		case *sqlparser.AliasedExpr:
+			if alias := expr.As.CompliantName(); alias != "" {
+				col := selectColFrom(tables, expr.Expr)
+				col.name = alias
+				col.alias = alias
+				if col.desc != nil {
+					col.desc.Name = alias
+				}
+				cols = append(cols, col)
+				continue
+			}
+			for _, e := range flatten(expr.Expr) {
+				cols = append(cols, selectColFrom(tables, e))
+			}
 
 		default:
-			return true, nil
+			return nil, fmt.Errorf("rsqldrv: invalid select-expr type %#v", expr)
 		}
 	}
+	return cols, nil
+}
 
-	nodes := make([]sqlparser.SQLNode, len(stmt.SelectExprs))
-	for i, expr := range stmt.SelectExprs {
-		nodes[i] = expr
+func selectColFrom(tables []*table, expr sqlparser.Expr) selectCol {
+	col := selectCol{expr: expr}
+	switch expr := expr.(type) {
+	case *sqlparser.ColName:
+		col.name = expr.Name.CompliantName()
+		tbl, name, err := lookup(tables, expr, nil)
+		if err == nil {
+			desc := colDescrFromLeaf(tbl.tree.Branch(name).Leaves()[0]) // multi-leaf branches are described by their first leaf.
+			col.desc = &desc
+		}
+	default:
+		// unaliased expressions are named after their SQL text.
+		col.name = sqlparser.String(expr)
 	}
+	return col
+}
 
-	if stmt.Where != nil {
-		nodes = append(nodes, stmt.Where.Expr)
+// flatten returns the list of expressions held by a (possibly nested and
+// parenthesized) tuple of expressions.
+func flatten(expr sqlparser.Expr) []sqlparser.Expr {
+	switch expr := expr.(type) {
+	case *sqlparser.ParenExpr:
+		return flatten(expr.Expr)
+	case sqlparser.ValTuple:
+		var exprs []sqlparser.Expr
+		for _, e := range expr {
+			exprs = append(exprs, flatten(e)...)
+		}
+		return exprs
 	}
+	return []sqlparser.Expr{expr}
+}
 
-	err := sqlparser.Walk(collectCols, nodes...)
+// position returns the 0-based index of the output column referenced by
+// its 1-based position.
+func position(expr *sqlparser.SQLVal, n int) (int, error) {
+	pos, err := strconv.Atoi(string(expr.Val))
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	if pos < 1 || pos > n {
+		return 0, fmt.Errorf("rsqldrv: invalid column position %d (ncols=%d)", pos, n)
 	}
+	return pos - 1, nil
+}
 
-	for _, name := range cols {
-		branch := tree.Branch(name)
-		if branch == nil {
-			return nil, fmt.Errorf("rsqldrv: could not find branch/leaf %q in tree %q", name, tree.Name())
+// hasAggregate returns whether the expression contains an aggregate function.
+func hasAggregate(expr sqlparser.Expr) bool {
+	found := false
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if node, ok := node.(*sqlparser.FuncExpr); ok && node.IsAggregate() {
+			found = true
 		}
-		leaf := branch.Leaves()[0] // FIXME(sbinet): handle sub-leaves
-		etyp := leaf.Type()
-		switch etyp.Kind() {
-		case reflect.Int8:
-			if leaf.IsUnsigned() {
-				etyp = reflect.TypeOf(uint8(0))
-			}
-		case reflect.Int16:
-			if leaf.IsUnsigned() {
-				etyp = reflect.TypeOf(uint16(0))
-			}
-		case reflect.Int32:
-			if leaf.IsUnsigned() {
-				etyp = reflect.TypeOf(uint32(0))
-			}
-		case reflect.Int64:
-			if leaf.IsUnsigned() {
-				etyp = reflect.TypeOf(uint64(0))
+		return !found, nil
+	}, expr)
+	return found
+}
+
+// extractDeps analyses the query and extracts the branches that need to be read
+// for the query to be properly executed.
+func (rows *driverRows) extractDeps(tables []*table, cols []selectCol, stmt *sqlparser.Select, join *sqlparser.JoinTableExpr, groupBy []sqlparser.Expr, using, aliases map[string]bool) error {
+	collect := func(withAliases bool) sqlparser.Visit {
+		var visit sqlparser.Visit
+		visit = func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.ColName:
+				if withAliases && node.Qualifier.IsEmpty() && aliases[node.Name.CompliantName()] {
+					// reference to an output column.
+					return false, nil
+				}
+				tbl, name, err := lookup(tables, node, using)
+				if err != nil {
+					return false, err
+				}
+				tbl.use(name, colKey(node))
+				return false, nil
+
+			case *sqlparser.FuncExpr:
+				for _, arg := range node.Exprs {
+					if arg, ok := arg.(*sqlparser.AliasedExpr); ok {
+						err := sqlparser.Walk(visit, arg.Expr)
+						if err != nil {
+							return false, err
+						}
+					}
+				}
+				return false, nil
+
+			case *sqlparser.Subquery:
+				return false, fmt.Errorf("rsqldrv: sub-queries not supported")
 			}
+			return true, nil
 		}
-		switch {
-		case leaf.LeafCount() != nil:
-			etyp = reflect.SliceOf(etyp)
-		case leaf.Len() > 1 && leaf.Kind() != reflect.String:
-			etyp = reflect.ArrayOf(leaf.Len(), etyp)
-		}
-		vars = append(vars, rtree.ReadVar{
-			Name:  branch.Name(),
-			Leaf:  leaf.Name(),
-			Value: reflect.New(etyp).Interface(),
-		})
+		return visit
 	}
 
-	return vars, nil
-}
+	var nodes []sqlparser.SQLNode
+	for _, col := range cols {
+		nodes = append(nodes, col.expr)
+	}
+	if stmt.Where != nil {
+		nodes = append(nodes, stmt.Where.Expr)
+	}
+	for _, expr := range groupBy {
+		nodes = append(nodes, expr)
+	}
+	if join != nil && join.Condition.On != nil {
+		nodes = append(nodes, join.Condition.On)
+	}
+	err := sqlparser.Walk(collect(false), nodes...)
+	if err != nil {
+		return err
+	}
 
-func (rows *driverRows) extractColsFromSelect(tree rtree.Tree, stmt *sqlparser.Select, args []driver.NamedValue) ([]string, error) {
-	var cols []string
+	nodes = nodes[:0]
+	if stmt.Having != nil {
+		nodes = append(nodes, stmt.Having.Expr)
+	}
+	for _, order := range stmt.OrderBy {
+		nodes = append(nodes, order.Expr)
+	}
+	return sqlparser.Walk(collect(true), nodes...)
+}
 
+// extractAggregates collects the aggregate functions used by the query.
+func (rows *driverRows) extractAggregates(cols []selectCol, stmt *sqlparser.Select) error {
+	set := make(map[aggKey]bool)
 	collect := func(node sqlparser.SQLNode) (bool, error) {
-		switch node := node.(type) {
-		case *sqlparser.ColName:
-			return true, nil
-		case sqlparser.ColIdent:
-			cols = append(cols, node.CompliantName())
-			return false, nil
-		case *sqlparser.ParenExpr:
+		fct, ok := node.(*sqlparser.FuncExpr)
+		if !ok || !fct.IsAggregate() {
 			return true, nil
-		case sqlparser.ValTuple:
-			return true, nil
-		case sqlparser.Exprs:
-			return true, nil
-		case *sqlparser.BinaryExpr:
-			// not a simple select query.
-			// add a dummy column name and stop recursion
-			cols = append(cols, "")
-			return false, nil
-		case *sqlparser.UnaryExpr:
-			return true, nil
-		case *sqlparser.SQLVal:
-			// not a simple select query.
-			// add a dummy column name and stop recursion
-			cols = append(cols, "")
-			return false, nil
+		}
+		agg, err := newAggregate(fct, rows.args)
+		if err != nil {
+			return false, err
+		}
+		if !set[agg.key] {
+			set[agg.key] = true
+			rows.aggs = append(rows.aggs, agg)
 		}
 		return false, nil
 	}
 
-	switch expr := stmt.SelectExprs[0].(type) { // FIXME(sbinet): handle multiple select-expressions
-	case *sqlparser.AliasedExpr:
-		err := sqlparser.Walk(collect, expr.Expr)
-		return cols, err
+	var nodes []sqlparser.SQLNode
+	for _, col := range cols {
+		nodes = append(nodes, col.expr)
+	}
+	if stmt.Having != nil {
+		nodes = append(nodes, stmt.Having.Expr)
+	}
+	for _, order := range stmt.OrderBy {
+		nodes = append(nodes, order.Expr)
+	}
+	return sqlparser.Walk(collect, nodes...)
+}
+
+// newJoinSource creates the source of rows for a JOIN query.
+// The first equality between a column of each tree, found in the JOIN
+// condition, is used as the join key.
+func (rows *driverRows) newJoinSource(tables []*table, join *sqlparser.JoinTableExpr, using map[string]bool) (rowSource, error) {
+	var (
+		left, right = tables[0], tables[1]
+		lkey, rkey  string
+		cond        = join.Condition.On
+	)
+
+	switch {
+	case len(join.Condition.Using) > 0:
+		for _, col := range join.Condition.Using {
+			name := col.CompliantName()
+			if left.tree.Branch(name) == nil || right.tree.Branch(name) == nil {
+				return nil, fmt.Errorf("rsqldrv: USING column %q not found in both trees", name)
+			}
+			lcol := &sqlparser.ColName{Name: col, Qualifier: sqlparser.TableName{Name: sqlparser.NewTableIdent(left.ref())}}
+			rcol := &sqlparser.ColName{Name: col, Qualifier: sqlparser.TableName{Name: sqlparser.NewTableIdent(right.ref())}}
+			left.use(name, colKey(lcol))
+			right.use(name, colKey(rcol))
+			eq := &sqlparser.ComparisonExpr{Operator: sqlparser.EqualStr, Left: lcol, Right: rcol}
+			if lkey == "" {
+				lkey, rkey = colKey(lcol), colKey(rcol)
+			}
+			switch cond {
+			case nil:
+				cond = eq
+			default:
+				cond = &sqlparser.AndExpr{Left: cond, Right: eq}
+			}
+		}
 
-	case *sqlparser.StarExpr:
-		branches := make([]string, len(tree.Branches()))
-		for i, b := range tree.Branches() {
-			branches[i] = b.Name()
+	default:
+		err := sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+			switch node := node.(type) {
+			case *sqlparser.AndExpr, *sqlparser.ParenExpr:
+				return lkey == "", nil
+			case *sqlparser.ComparisonExpr:
+				if node.Operator != sqlparser.EqualStr {
+					return false, nil
+				}
+				lcol, lok := node.Left.(*sqlparser.ColName)
+				rcol, rok := node.Right.(*sqlparser.ColName)
+				if !lok || !rok {
+					return false, nil
+				}
+				ltbl, _, err := lookup(tables, lcol, using)
+				if err != nil {
+					return false, err
+				}
+				rtbl, _, err := lookup(tables, rcol, using)
+				if err != nil {
+					return false, err
+				}
+				switch {
+				case ltbl == left && rtbl == right:
+					lkey, rkey = colKey(lcol), colKey(rcol)
+				case ltbl == right && rtbl == left:
+					lkey, rkey = colKey(rcol), colKey(lcol)
+				}
+				return false, nil
+			}
+			return false, nil
+		}, cond)
+		if err != nil {
+			return nil, err
+		}
+		if lkey == "" {
+			return nil, fmt.Errorf("rsqldrv: JOIN condition needs an equality between columns of both trees")
 		}
-		return branches, nil
+	}
+
+	expr, err := newExprFrom(cond, rows.args)
+	if err != nil {
+		return nil, fmt.Errorf("rsqldrv: could not generate JOIN condition: %w", err)
+	}
+
+	return newJoinSource(rows.ectx, left, right, lkey, rkey, expr, join.Join == sqlparser.LeftJoinStr)
+}
 
+// count evaluates the value of a LIMIT or OFFSET clause.
+func (rows *driverRows) count(expr sqlparser.Expr) (int64, error) {
+	e, err := newExprFrom(expr, rows.args)
+	if err != nil {
+		return 0, err
+	}
+	v, err := e.eval(rows.ectx, nil)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	switch rv := reflect.ValueOf(v); kindOf(rv.Kind()) {
+	case reflect.Int64:
+		n = rv.Int()
+	case reflect.Uint64:
+		n = int64(rv.Uint())
 	default:
-		panic(fmt.Errorf("rsqldrv: invalid select-expr type %#v", expr))
+		return 0, fmt.Errorf("rsqldrv: invalid value %#v (%T)", v, v)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("rsqldrv: invalid negative value %d", n)
 	}
+	return n, nil
+}
+
+func (rows *driverRows) aggregating() bool {
+	return len(rows.groupBy) > 0 || len(rows.aggs) > 0
 }
 
 // Columns returns the names of the columns. The number of columns of the
@@ -544,7 +857,7 @@ func (r *driverRows) ColumnTypeNullable(i int) (nullable, ok bool) {
 
 // Close closes the rows iterator.
 func (r *driverRows) Close() error {
-	return r.cursor.Close()
+	return r.src.close()
 }
 
 // Next is called to populate the next row of data into
@@ -557,78 +870,336 @@ func (r *driverRows) Close() error {
 // should be taken when closing Rows not to modify
 // a buffer held in dest.
 func (r *driverRows) Next(dest []driver.Value) error {
-	if !r.cursor.Next() {
-		return io.EOF
+	if r.materialize {
+		if !r.loaded {
+			err := r.load()
+			if err != nil {
+				return err
+			}
+			r.loaded = true
+		}
+		if len(r.recs) == 0 {
+			return io.EOF
+		}
+		setRow(dest, r.recs[0].vals)
+		r.recs = r.recs[1:]
+		return nil
+	}
+
+	for {
+		if r.limit >= 0 && r.nrows >= r.offset+r.limit {
+			return io.EOF
+		}
+		vctx, err := r.scan()
+		if err != nil {
+			return err
+		}
+		r.nrows++
+		if r.nrows <= r.offset {
+			continue
+		}
+
+		vals, err := r.values(vctx)
+		if err != nil {
+			return err
+		}
+		setRow(dest, vals)
+		return nil
 	}
-	err := r.cursor.Scan(r.vars...)
+}
+
+// scan returns the evaluation context of the next row passing the
+// WHERE clause, or io.EOF.
+func (r *driverRows) scan() (map[interface{}]interface{}, error) {
+	for {
+		vctx := make(map[interface{}]interface{})
+		ok, err := r.src.next(vctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, io.EOF
+		}
+
+		if r.filter == nil {
+			return vctx, nil
+		}
+
+		ok, err = r.test(r.filter, vctx)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return vctx, nil
+		}
+	}
+}
+
+func (r *driverRows) test(expr expression, vctx map[interface{}]interface{}) (bool, error) {
+	v, err := expr.eval(r.ectx, vctx)
 	if err != nil {
-		return err
+		return false, err
+	}
+	ok, isBool := v.(bool)
+	if !isBool {
+		return false, fmt.Errorf("rsqldrv: expression %s is not a boolean (got=%T)", sqlparser.String(expr.sql()), v)
 	}
+	return ok, nil
+}
 
-	ectx := newExecCtx(r.conn, r.args)
-	vctx := make(map[interface{}]interface{})
-	for i, v := range r.vars {
-		vctx[r.deps[i]] = reflect.Indirect(reflect.ValueOf(v)).Interface()
+// values evaluates the output columns for the provided row, and makes the
+// aliased values available in the evaluation context.
+func (r *driverRows) values(vctx map[interface{}]interface{}) ([]interface{}, error) {
+	vals := make([]interface{}, len(r.outs))
+	for i, expr := range r.outs {
+		v, err := expr.eval(r.ectx, vctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not evaluate row values: %w", err)
+		}
+		vals[i] = v
+	}
+	for i, name := range r.names {
+		if name != "" {
+			vctx[name] = vals[i]
+		}
 	}
+	return vals, nil
+}
 
-	switch r.filter {
-	case nil:
-		// no filter
-	default:
-		ok, err := r.filter.eval(ectx, vctx)
+// load reads all the rows of the query, computes the aggregates and
+// applies the DISTINCT, ORDER BY and LIMIT clauses.
+func (r *driverRows) load() error {
+	switch {
+	case r.aggregating():
+		err := r.loadGroups()
 		if err != nil {
-			//log.Printf("filter.eval: ok=%#v err=%v", ok, err)
 			return err
 		}
-		if !ok.(bool) {
-			return r.Next(dest)
+	default:
+		for {
+			vctx, err := r.scan()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			err = r.record(vctx)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
-	vs, err := r.eval.eval(ectx, vctx)
-	// log.Printf("row.eval: v=%#v, err=%v n=%d", vs, err, len(dest))
-	if err != nil {
-		return fmt.Errorf("could not evaluate row values: %w", err)
+	if r.distinct {
+		var (
+			recs = r.recs[:0]
+			set  = make(map[string]struct{}, len(r.recs))
+		)
+		for _, rec := range r.recs {
+			keys := make([]string, len(rec.vals))
+			for i, v := range rec.vals {
+				keys[i] = hashKey(v)
+			}
+			key := strings.Join(keys, ",")
+			if _, dup := set[key]; dup {
+				continue
+			}
+			set[key] = struct{}{}
+			recs = append(recs, rec)
+		}
+		r.recs = recs
 	}
 
-	switch vs := vs.(type) {
-	case []interface{}:
-		for i, v := range vs {
-			switch v := v.(type) {
-			case string:
-				dest[i] = []byte(v)
-			default:
-				dest[i] = v
+	if len(r.order) > 0 {
+		var err error
+		sort.SliceStable(r.recs, func(i, j int) bool {
+			for k, key := range r.order {
+				cmp, e := compareValues(r.recs[i].keys[k], r.recs[j].keys[k])
+				if e != nil && err == nil {
+					err = e
+				}
+				if cmp == 0 {
+					continue
+				}
+				if key.desc {
+					return cmp > 0
+				}
+				return cmp < 0
 			}
+			return false
+		})
+		if err != nil {
+			return fmt.Errorf("rsqldrv: could not sort rows: %w", err)
 		}
-	case string:
-		dest[0] = []byte(vs)
+	}
+
+	switch {
+	case r.offset >= int64(len(r.recs)):
+		r.recs = nil
 	default:
-		dest[0] = vs
+		r.recs = r.recs[r.offset:]
+	}
+	if r.limit >= 0 && r.limit < int64(len(r.recs)) {
+		r.recs = r.recs[:r.limit]
+	}
+	return nil
+}
+
+// loadGroups reads all the rows of the query and computes the aggregates
+// for each group of rows.
+func (r *driverRows) loadGroups() error {
+	var (
+		groups []*group
+		index  = make(map[string]*group)
+	)
+
+	for {
+		vctx, err := r.scan()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		keys := make([]string, len(r.groupBy))
+		for i, expr := range r.groupBy {
+			v, err := expr.eval(r.ectx, vctx)
+			if err != nil {
+				return fmt.Errorf("rsqldrv: could not evaluate GROUP BY term: %w", err)
+			}
+			keys[i] = hashKey(v)
+		}
+		key := strings.Join(keys, ",")
+
+		grp, ok := index[key]
+		if !ok {
+			grp = r.newGroup(vctx)
+			for k, v := range grp.vctx {
+				grp.vctx[k] = detach(v)
+			}
+			index[key] = grp
+			groups = append(groups, grp)
+		}
+
+		for _, acc := range grp.accs {
+			err = acc.add(r.ectx, vctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(groups) == 0 && len(r.groupBy) == 0 {
+		// aggregates over an empty set of rows still produce a row.
+		groups = append(groups, r.newGroup(make(map[interface{}]interface{})))
 	}
 
+	for _, grp := range groups {
+		for _, acc := range grp.accs {
+			grp.vctx[acc.fct.key] = acc.value()
+		}
+		if r.having != nil {
+			// make aliased output columns available to HAVING.
+			_, err := r.values(grp.vctx)
+			if err != nil {
+				return err
+			}
+			ok, err := r.test(r.having, grp.vctx)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+		err := r.record(grp.vctx)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (r *driverRows) newGroup(vctx map[interface{}]interface{}) *group {
+	grp := &group{
+		vctx: vctx,
+		accs: make([]*accumulator, len(r.aggs)),
+	}
+	for i, agg := range r.aggs {
+		grp.accs[i] = newAccumulator(agg)
+	}
+	return grp
+}
+
+// record evaluates and stores the output values and ORDER BY terms of a row.
+func (r *driverRows) record(vctx map[interface{}]interface{}) error {
+	vals, err := r.values(vctx)
+	if err != nil {
+		return err
+	}
+	for i, v := range vals {
+		vals[i] = detach(v)
+	}
+
+	keys := make([]interface{}, len(r.order))
+	for i, key := range r.order {
+		if key.pos >= 0 {
+			keys[i] = vals[key.pos]
+			continue
+		}
+		keys[i], err = key.expr.eval(r.ectx, vctx)
+		if err != nil {
+			return fmt.Errorf("rsqldrv: could not evaluate ORDER BY term: %w", err)
+		}
+	}
+
+	r.recs = append(r.recs, record{vals: vals, keys: keys})
+	return nil
+}
+
+func setRow(dest []driver.Value, vals []interface{}) {
+	for i, v := range vals {
+		switch v := v.(type) {
+		case string:
+			dest[i] = []byte(v)
+		default:
+			dest[i] = v
+		}
+	}
+}
+
 type driverStmt struct {
 	conn *driverConn
 	stmt sqlparser.Statement
 }
 
 func (stmt *driverStmt) Close() error {
-	panic("not implemented")
+	delete(stmt.conn.stop, stmt)
+	return nil
 }
 
+// NumInput returns -1 as the number of placeholder parameters is not
+// checked by the driver.
 func (stmt *driverStmt) NumInput() int {
-	panic("not implemented")
+	return -1
 }
 
 func (stmt *driverStmt) Exec(args []driver.Value) (driver.Result, error) {
-	panic("not implemented")
+	return stmt.conn.exec(context.Background(), stmt.stmt, namedValues(args))
 }
 
 func (stmt *driverStmt) Query(args []driver.Value) (driver.Rows, error) {
-	panic("not implemented")
+	return stmt.conn.query(context.Background(), stmt.stmt, namedValues(args))
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	nvs := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		nvs[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return nvs
 }
 
 func newExprFrom(expr sqlparser.Expr, args []driver.NamedValue) (expression, error) {
@@ -677,15 +1248,30 @@ func newExprFrom(expr sqlparser.Expr, args []driver.NamedValue) (expression, err
 	case *sqlparser.ColName:
 		return &identExpr{
 			expr: expr,
-			name: expr.Name.CompliantName(),
+			name: colKey(expr),
 		}, nil
 
+	case *sqlparser.FuncExpr:
+		return newFuncExpr(expr, args)
+
 	case *sqlparser.SQLVal:
 		return newValueExpr(expr, args)
 
 	case sqlparser.BoolVal:
 		return &valueExpr{expr: expr, v: bool(expr)}, nil
 
+	case *sqlparser.UnaryExpr:
+		switch expr.Operator {
+		case sqlparser.UPlusStr, sqlparser.UMinusStr, sqlparser.TildaStr, sqlparser.BangStr:
+		default:
+			return nil, fmt.Errorf("rsqldrv: invalid unary-expression operator %q", expr.Operator)
+		}
+		x, err := newExprFrom(expr.Expr, args)
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{expr: expr, op: expr.Operator, x: x}, nil
+
 	case *sqlparser.BinaryExpr:
 		l, err := newExprFrom(expr.Left, args)
 		if err != nil {
@@ -724,6 +1310,7 @@ var (
 
 	_ driver.Result = (*driverResult)(nil)
 	_ driver.Rows   = (*driverRows)(nil)
+	_ driver.Stmt   = (*driverStmt)(nil)
 )
 
 var (
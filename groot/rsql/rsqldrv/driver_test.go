@@ -7,7 +7,10 @@ package rsqldrv_test
 import (
 	"database/sql"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -163,7 +166,7 @@ func TestQuery(t *testing.T) {
 		},
 		{
 			query: `SELECT (one+10, two+20, "--"+three+"--") FROM tree`,
-			cols:  []string{"", "", ""},
+			cols:  []string{"one + 10", "two + 20", "'--' + three + '--'"},
 			want: []data{
 				{11, 21.1, "--uno--"},
 				{12, 22.2, "--dos--"},
@@ -173,7 +176,7 @@ func TestQuery(t *testing.T) {
 		},
 		{
 			query: `SELECT (one+?, two+?, ?+three+"--") FROM tree`,
-			cols:  []string{"", "", ""},
+			cols:  []string{"one + :v1", "two + :v2", ":v3 + three + '--'"},
 			args:  []interface{}{int32(10), 20.0, "++"},
 			want: []data{
 				{11, 21.1, "++uno--"},
@@ -527,3 +530,710 @@ func TestFlatTree(t *testing.T) {
 		i++
 	}
 }
+
+func TestQueryAggregate(t *testing.T) {
+	db, err := sql.Open("root", "../../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type eface = interface{}
+
+	for _, tc := range []struct {
+		query string
+		args  []interface{}
+		cols  []string
+		types []interface{}
+		vals  [][]eface
+	}{
+		{
+			query: `select count(*), sum(Int32), avg(Float64), min(Float32), max(Str) from tree`,
+			cols:  []string{"count(*)", "sum(Int32)", "avg(Float64)", "min(Float32)", "max(Str)"},
+			types: []interface{}{int64(0), int64(0), 0.0, float32(0), ""},
+			vals: [][]eface{
+				{int64(100), int64(4950), 49.5, float32(0), "evt-099"},
+			},
+		},
+		{
+			query: `select count(*) as n from tree where (Int32 > 1000)`,
+			cols:  []string{"n"},
+			types: []interface{}{int64(0)},
+			vals: [][]eface{
+				{int64(0)},
+			},
+		},
+		{
+			query: `select N, count(*) as n, sum(UInt32) from tree where (Int32 < 30) group by N order by N desc limit 3`,
+			cols:  []string{"N", "n", "sum(UInt32)"},
+			types: []interface{}{int32(0), int64(0), uint64(0)},
+			vals: [][]eface{
+				{int32(9), int64(3), uint64(57)},
+				{int32(8), int64(3), uint64(54)},
+				{int32(7), int64(3), uint64(51)},
+			},
+		},
+		{
+			query: `select N, max(Int64) as m from tree group by 1 having m > 95 order by m`,
+			cols:  []string{"N", "m"},
+			types: []interface{}{int32(0), int64(0)},
+			vals: [][]eface{
+				{int32(6), int64(96)},
+				{int32(7), int64(97)},
+				{int32(8), int64(98)},
+				{int32(9), int64(99)},
+			},
+		},
+		{
+			query: `select N from tree group by N having sum(Float64) >= 540`,
+			cols:  []string{"N"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(9)},
+			},
+		},
+		{
+			query: `select count(distinct N) from tree where (Int32 >= ?)`,
+			args:  []interface{}{95},
+			cols:  []string{"count(distinct N)"},
+			types: []interface{}{int64(0)},
+			vals: [][]eface{
+				{int64(5)},
+			},
+		},
+		{
+			query: `select distinct N from tree where (Int32 > 40) order by 1 limit 2, 3`,
+			cols:  []string{"N"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(2)},
+				{int32(3)},
+				{int32(4)},
+			},
+		},
+		{
+			query: `select Int32 from tree limit 2 offset 10`,
+			cols:  []string{"Int32"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(10)},
+				{int32(11)},
+			},
+		},
+		{
+			query: `select Int32 from tree where (N = 3) order by Int32 desc limit ?`,
+			args:  []interface{}{2},
+			cols:  []string{"Int32"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(93)},
+				{int32(83)},
+			},
+		},
+		{
+			query: `select Int32, sqrt(Float64), abs(Int32-50), pow(Int32, 2), log(Float64) from tree order by abs(Int32-50), Int32 limit 3 offset 1`,
+			cols:  []string{"Int32", "sqrt(Float64)", "abs(Int32 - 50)", "pow(Int32, 2)", "log(Float64)"},
+			types: []interface{}{int32(0), 0.0, int32(0), 0.0, 0.0},
+			vals: [][]eface{
+				{int32(49), 7.0, int32(1), 2401.0, math.Log(49)},
+				{int32(51), math.Sqrt(51), int32(1), 2601.0, math.Log(51)},
+				{int32(48), math.Sqrt(48), int32(2), 2304.0, math.Log(48)},
+			},
+		},
+		{
+			query: `select Int32, id() from tree where (N = 1) order by id() desc limit 2`,
+			cols:  []string{"Int32", "id()"},
+			types: []interface{}{int32(0), int64(0)},
+			vals: [][]eface{
+				{int32(91), int64(91)},
+				{int32(81), int64(81)},
+			},
+		},
+		{
+			query: `select Int32, -Int32, abs(-Int32), ~UInt32 from tree where (-Int32 <= -98)`,
+			cols:  []string{"Int32", "-Int32", "abs(-Int32)", "~UInt32"},
+			types: []interface{}{int32(0), int32(0), int32(0), uint32(0)},
+			vals: [][]eface{
+				{int32(98), int32(-98), int32(98), ^uint32(98)},
+				{int32(99), int32(-99), int32(99), ^uint32(99)},
+			},
+		},
+		{
+			query: `select Int32 from tree where (!(Int32 < 98))`,
+			cols:  []string{"Int32"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(98)},
+				{int32(99)},
+			},
+		},
+		{
+			query: `select Int32 from tree where (sqrt(Float64) > 9.8)`,
+			cols:  []string{"Int32"},
+			types: []interface{}{int32(0)},
+			vals: [][]eface{
+				{int32(97)},
+				{int32(98)},
+				{int32(99)},
+			},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			rows, err := db.Query(tc.query, tc.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+
+			cols, err := rows.Columns()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := cols, tc.cols; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid columns.\ngot= %q\nwant=%q", got, want)
+			}
+
+			var got [][]eface
+			for rows.Next() {
+				vars := make([]interface{}, len(tc.types))
+				for i, v := range tc.types {
+					vars[i] = reflect.New(reflect.TypeOf(v)).Interface()
+				}
+				err = rows.Scan(vars...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				row := make([]eface, len(vars))
+				for i, v := range vars {
+					row[i] = reflect.Indirect(reflect.ValueOf(v)).Interface()
+				}
+				got = append(got, row)
+			}
+			err = rows.Err()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := got, tc.vals; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid values.\ngot= %v\nwant=%v\n", got, want)
+			}
+		})
+	}
+}
+
+func TestQueryJoin(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rsqldrv-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "join.root")
+	f, err := groot.Create(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, tree := range []struct {
+		name string
+		ids  []int32
+		vals []float64
+	}{
+		{"evts", []int32{1, 2, 3, 4}, []float64{10, 20, 30, 40}},
+		{"runs", []int32{2, 4, 4, 5}, []float64{1.5, 2.5, 3.5, 4.5}},
+	} {
+		var (
+			id  int32
+			val float64
+		)
+		w, err := rtree.NewWriter(f, tree.name, []rtree.WriteVar{
+			{Name: "id", Value: &id},
+			{Name: "val", Value: &val},
+		})
+		if err != nil {
+			t.Fatalf("could not create tree %q: %+v", tree.name, err)
+		}
+		for i := range tree.ids {
+			id = tree.ids[i]
+			val = tree.vals[i]
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write entry %d of %q: %+v", i, tree.name, err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close tree %q: %+v", tree.name, err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	db, err := sql.Open("root", fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, tc := range []struct {
+		query string
+		cols  []string
+		want  [][]interface{}
+	}{
+		{
+			query: `select e.id, e.val, r.val from evts as e join runs as r on e.id = r.id`,
+			cols:  []string{"id", "val", "val"},
+			want: [][]interface{}{
+				{int32(2), 20.0, 1.5},
+				{int32(4), 40.0, 2.5},
+				{int32(4), 40.0, 3.5},
+			},
+		},
+		{
+			query: `select evts.id, evts.val, runs.val from evts join runs on runs.id = evts.id && runs.val > 3`,
+			cols:  []string{"id", "val", "val"},
+			want: [][]interface{}{
+				{int32(4), 40.0, 3.5},
+			},
+		},
+		{
+			query: `select id, evts.val, runs.val from evts left join runs using (id) order by id desc`,
+			cols:  []string{"id", "val", "val"},
+			want: [][]interface{}{
+				{int32(4), 40.0, 2.5},
+				{int32(4), 40.0, 3.5},
+				{int32(3), 30.0, nil},
+				{int32(2), 20.0, 1.5},
+				{int32(1), 10.0, nil},
+			},
+		},
+		{
+			query: `select e.id, count(*), sum(e.val * r.val) from evts e join runs r on e.id = r.id group by e.id`,
+			cols:  []string{"id", "count(*)", "sum(e.val * r.val)"},
+			want: [][]interface{}{
+				{int32(2), int64(1), 30.0},
+				{int32(4), int64(2), 240.0},
+			},
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			rows, err := db.Query(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+
+			cols, err := rows.Columns()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := cols, tc.cols; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid columns.\ngot= %q\nwant=%q", got, want)
+			}
+
+			var got [][]interface{}
+			for rows.Next() {
+				vals := make([]interface{}, len(cols))
+				ptrs := make([]interface{}, len(cols))
+				for i := range vals {
+					ptrs[i] = &vals[i]
+				}
+				err = rows.Scan(ptrs...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, vals)
+			}
+			err = rows.Err()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid values.\ngot= %v\nwant=%v\n", got, tc.want)
+			}
+		})
+	}
+
+	for _, tc := range []struct {
+		query string
+		err   string
+	}{
+		{
+			query: `select id from evts join runs on evts.id = runs.id`,
+			err:   `could not extract read-vars: rsqldrv: ambiguous column name "id"`,
+		},
+		{
+			query: `select evts.id from evts join runs on evts.val > runs.val`,
+			err:   `rsqldrv: JOIN condition needs an equality between columns of both trees`,
+		},
+		{
+			query: `select evts.id from evts right join runs on evts.id = runs.id`,
+			err:   `rsqldrv: "right join" not supported`,
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			_, err := db.Query(tc.query)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error.\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rsqldrv-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "create.root")
+	db, err := rsqldrv.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create db: %+v", err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("could not start transaction: %+v", err)
+	}
+
+	_, err = tx.Exec("create table evts (id int64, x float64, name string)")
+	if err != nil {
+		t.Fatalf("could not create table: %+v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		res, err := tx.Exec("insert into evts values (?, ?, ?)", i, float64(i)*1.5, fmt.Sprintf("evt-%d", i))
+		if err != nil {
+			t.Fatalf("could not insert row %d: %+v", i, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil || n != 1 {
+			t.Fatalf("invalid number of rows affected: n=%d, err=%v", n, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("could not commit transaction: %+v", err)
+	}
+
+	tx, err = db.Begin()
+	if err != nil {
+		t.Fatalf("could not start transaction: %+v", err)
+	}
+	_, err = tx.Exec("insert into evts values (?, ?, ?)", 42, 42.0, "rollback")
+	if err != nil {
+		t.Fatalf("could not insert row: %+v", err)
+	}
+	err = tx.Rollback()
+	if err != nil {
+		t.Fatalf("could not rollback transaction: %+v", err)
+	}
+
+	_, err = db.Exec("create table sqltypes (a int, b double, c varchar(10), d bigint unsigned)")
+	if err != nil {
+		t.Fatalf("could not create table: %+v", err)
+	}
+
+	res, err := db.Exec(`insert into sqltypes (b, a, c) values (1.5, 2, "two"), (2.5, ?, "three")`, 3)
+	if err != nil {
+		t.Fatalf("could not insert rows: %+v", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n != 2 {
+		t.Fatalf("invalid number of rows affected: n=%d, err=%v", n, err)
+	}
+
+	// missing columns are filled with their zero value.
+	_, err = db.Exec("insert into sqltypes (a) values (-1)")
+	if err != nil {
+		t.Fatalf("could not insert rows: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		query string
+		args  []interface{}
+		err   string
+	}{
+		{
+			query: "create table evts (id int64)",
+			err:   `rsqldrv: table "evts" already exists`,
+		},
+		{
+			query: "create table invalid (id complex128)",
+			err:   `rsqldrv: invalid column "id": rsqldrv: unsupported column type "complex128"`,
+		},
+		{
+			query: "insert into notable values (1)",
+			err:   `rsqldrv: missing columns to create table "notable"`,
+		},
+		{
+			query: "insert into notable (a, b) values (1)",
+			err:   `rsqldrv: invalid number of values (got=1, want=2)`,
+		},
+		{
+			query: "insert into evts values (1, 2)",
+			err:   `rsqldrv: invalid number of values (got=2, want=3)`,
+		},
+		{
+			query: "insert into evts values (?, 2, 3)",
+			args:  []interface{}{"one"},
+			err:   `rsqldrv: invalid value for column "id": can not convert "one" (string) to int64`,
+		},
+		{
+			query: "insert into sqltypes (a, d) values (1, -1)",
+			err:   `rsqldrv: invalid value for column "d": value -1 overflows uint64`,
+		},
+	} {
+		_, err := db.Exec(tc.query, tc.args...)
+		if err == nil {
+			t.Fatalf("%s: expected an error", tc.query)
+		}
+		if got, want := err.Error(), tc.err; got != want {
+			t.Fatalf("%s: invalid error.\ngot= %q\nwant=%q", tc.query, got, want)
+		}
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("could not close db: %+v", err)
+	}
+
+	db, err = rsqldrv.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open db: %+v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("select * from evts")
+	if err != nil {
+		t.Fatalf("could not query evts: %+v", err)
+	}
+	defer rows.Close()
+
+	type evt struct {
+		id   int64
+		x    float64
+		name string
+	}
+	var got []evt
+	for rows.Next() {
+		var v evt
+		err = rows.Scan(&v.id, &v.x, &v.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	want := []evt{
+		{0, 0, "evt-0"},
+		{1, 1.5, "evt-1"},
+		{2, 3.0, "evt-2"},
+		{3, 4.5, "evt-3"},
+		{4, 6.0, "evt-4"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows.\ngot= %v\nwant=%v", got, want)
+	}
+
+	rows, err = db.Query("select (a, b, c, d) from sqltypes")
+	if err != nil {
+		t.Fatalf("could not query sqltypes: %+v", err)
+	}
+	defer rows.Close()
+
+	type sqltypes struct {
+		a int32
+		b float64
+		c string
+		d uint64
+	}
+	var sgot []sqltypes
+	for rows.Next() {
+		var v sqltypes
+		err = rows.Scan(&v.a, &v.b, &v.c, &v.d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sgot = append(sgot, v)
+	}
+	swant := []sqltypes{
+		{2, 1.5, "two", 0},
+		{3, 2.5, "three", 0},
+		{-1, 0, "", 0},
+	}
+	if !reflect.DeepEqual(sgot, swant) {
+		t.Fatalf("invalid rows.\ngot= %v\nwant=%v", sgot, swant)
+	}
+}
+
+func TestInsertSelect(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rsqldrv-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	raw, err := ioutil.ReadFile("../../testdata/simple.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fname := filepath.Join(tmp, "simple.root")
+	err = ioutil.WriteFile(fname, raw, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := groot.Update(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	db := rsqldrv.OpenDB(f)
+	defer db.Close()
+
+	_, err = db.Exec("create table sel (n int32, x float64, s string)")
+	if err != nil {
+		t.Fatalf("could not create table: %+v", err)
+	}
+
+	res, err := db.Exec("insert into sel select one*10, two, three from tree where (one > ?)", 2)
+	if err != nil {
+		t.Fatalf("could not insert rows: %+v", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n != 2 {
+		t.Fatalf("invalid number of rows affected: n=%d, err=%v", n, err)
+	}
+
+	res, err = db.Exec("insert into lazy (n, x, s) select one, two, three from tree where (one > ?)", 2)
+	if err != nil {
+		t.Fatalf("could not insert rows into new table: %+v", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n != 2 {
+		t.Fatalf("invalid number of rows affected: n=%d, err=%v", n, err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("could not close db: %+v", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	db, err = rsqldrv.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open db: %+v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("select * from sel")
+	if err != nil {
+		t.Fatalf("could not query table: %+v", err)
+	}
+	defer rows.Close()
+
+	type data struct {
+		n int32
+		x float64
+		s string
+	}
+	var got []data
+	for rows.Next() {
+		var v data
+		err = rows.Scan(&v.n, &v.x, &v.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	want := []data{
+		{30, float64(float32(3.3)), "tres"},
+		{40, float64(float32(4.4)), "quatro"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows.\ngot= %v\nwant=%v", got, want)
+	}
+
+	f, err = groot.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("lazy")
+	if err != nil {
+		t.Fatalf("could not retrieve lazily created tree: %+v", err)
+	}
+	tree := o.(rtree.Tree)
+	for _, tc := range []struct {
+		name string
+		typ  string
+	}{
+		{"n", "I"},
+		{"x", "F"},
+		{"s", "C"},
+	} {
+		br := tree.Branch(tc.name)
+		if br == nil {
+			t.Fatalf("could not find branch %q", tc.name)
+		}
+		if got, want := br.Title(), tc.name+"/"+tc.typ; got != want {
+			t.Fatalf("invalid branch title: got=%q, want=%q", got, want)
+		}
+	}
+
+	rows, err = db.Query("select * from lazy")
+	if err != nil {
+		t.Fatalf("could not query table: %+v", err)
+	}
+	defer rows.Close()
+
+	got = got[:0]
+	for rows.Next() {
+		var v data
+		err = rows.Scan(&v.n, &v.x, &v.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	want = []data{
+		{3, 3.3, "tres"},
+		{4, 4.4, "quatro"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid rows.\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestCreateReadOnly(t *testing.T) {
+	db, err := rsqldrv.Open("../../testdata/simple.root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec("create table tbl (x float64)")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got, want := err.Error(), `rsqldrv: could not create table "tbl": riofs: file read-only`; got != want {
+		t.Fatalf("invalid error.\ngot= %q\nwant=%q", got, want)
+	}
+}
@@ -0,0 +1,551 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsqldrv // import "go-hep.org/x/hep/groot/rsql/rsqldrv"
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xwb1989/sqlparser"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// wtable is a tree being written through the ROOT/SQL driver.
+type wtable struct {
+	name  string
+	w     rtree.Writer
+	cols  []string
+	types []reflect.Type
+	vals  []reflect.Value // values of the write-vars
+
+	rows [][]interface{} // rows of the current transaction, not yet written
+}
+
+func (tbl *wtable) write(row []interface{}) error {
+	for i, v := range row {
+		tbl.vals[i].Set(reflect.ValueOf(v))
+	}
+	_, err := tbl.w.Write()
+	if err != nil {
+		return fmt.Errorf("rsqldrv: could not write row to table %q: %w", tbl.name, err)
+	}
+	return nil
+}
+
+func (tbl *wtable) flush() error {
+	for _, row := range tbl.rows {
+		err := tbl.write(row)
+		if err != nil {
+			return err
+		}
+	}
+	tbl.rows = tbl.rows[:0]
+	return nil
+}
+
+// createTableRe matches a CREATE TABLE statement and its columns definitions.
+var createTableRe = regexp.MustCompile("(?is)^\\s*create\\s+table\\s+`?(\\w+)`?\\s*\\((.*)\\)\\s*;?\\s*$")
+
+// parse parses the provided SQL statement.
+//
+// CREATE TABLE statements are parsed by parse itself: the MySQL dialect
+// of the SQL parser does not understand Go type names
+// (e.g. "create table tbl (x float64, s string)") and only partially
+// parses DDL statements.
+func parse(query string) (sqlparser.Statement, error) {
+	if m := createTableRe.FindStringSubmatch(query); m != nil {
+		return parseCreateTable(m[1], m[2])
+	}
+	return sqlparser.Parse(query)
+}
+
+func parseCreateTable(name, defs string) (*sqlparser.DDL, error) {
+	ddl := &sqlparser.DDL{
+		Action:    sqlparser.CreateStr,
+		NewName:   sqlparser.TableName{Name: sqlparser.NewTableIdent(name)},
+		TableSpec: &sqlparser.TableSpec{},
+	}
+	for _, def := range splitDefs(defs) {
+		toks := strings.Fields(def)
+		if len(toks) < 2 {
+			return nil, fmt.Errorf("rsqldrv: invalid column definition %q", strings.TrimSpace(def))
+		}
+		typ := toks[1]
+		if i := strings.Index(typ, "("); i > 0 {
+			typ = typ[:i] // drop the display width or length (e.g. "varchar(20)")
+		}
+		col := sqlparser.ColumnType{Type: typ}
+		for _, tok := range toks[2:] {
+			if strings.EqualFold(tok, "unsigned") {
+				col.Unsigned = true
+			}
+		}
+		ddl.TableSpec.Columns = append(ddl.TableSpec.Columns, &sqlparser.ColumnDefinition{
+			Name: sqlparser.NewColIdent(strings.Trim(toks[0], "`")),
+			Type: col,
+		})
+	}
+	return ddl, nil
+}
+
+// splitDefs splits a list of columns definitions on the commas that
+// are not enclosed in parentheses.
+func splitDefs(defs string) []string {
+	var (
+		o     []string
+		beg   = 0
+		depth = 0
+	)
+	for i, c := range defs {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				o = append(o, defs[beg:i])
+				beg = i + 1
+			}
+		}
+	}
+	return append(o, defs[beg:])
+}
+
+// typeFrom returns the Go type corresponding to a column type.
+// Both SQL types (e.g. "int", "double", "varchar(20)") and Go types
+// (e.g. "int32", "float64", "string" or "[3]float64") are understood.
+func typeFrom(col sqlparser.ColumnType) (reflect.Type, error) {
+	name := strings.ToLower(col.Type)
+	if strings.HasPrefix(name, "[") {
+		i := strings.Index(name, "]")
+		if i < 0 {
+			return nil, fmt.Errorf("rsqldrv: invalid array type %q", col.Type)
+		}
+		n, err := strconv.Atoi(name[1:i])
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("rsqldrv: invalid array length in %q", col.Type)
+		}
+		elt, err := typeFrom(sqlparser.ColumnType{Type: name[i+1:]})
+		if err != nil {
+			return nil, err
+		}
+		if elt.Kind() == reflect.String {
+			return nil, fmt.Errorf("rsqldrv: arrays of strings not supported")
+		}
+		return reflect.ArrayOf(n, elt), nil
+	}
+
+	var v interface{}
+	switch name {
+	case "bool", "boolean":
+		v = false
+	case "int8":
+		v = int8(0)
+	case "int16":
+		v = int16(0)
+	case "int32":
+		v = int32(0)
+	case "int64":
+		v = int64(0)
+	case "uint8", "byte":
+		v = uint8(0)
+	case "uint16":
+		v = uint16(0)
+	case "uint32":
+		v = uint32(0)
+	case "uint64":
+		v = uint64(0)
+	case "tinyint":
+		v = int8(0)
+		if col.Unsigned {
+			v = uint8(0)
+		}
+	case "smallint":
+		v = int16(0)
+		if col.Unsigned {
+			v = uint16(0)
+		}
+	case "mediumint", "int", "integer":
+		v = int32(0)
+		if col.Unsigned {
+			v = uint32(0)
+		}
+	case "bigint":
+		v = int64(0)
+		if col.Unsigned {
+			v = uint64(0)
+		}
+	case "float", "float32":
+		v = float32(0)
+	case "double", "real", "float64":
+		v = float64(0)
+	case "string", "char", "varchar", "text", "tinytext", "mediumtext", "longtext":
+		v = ""
+	default:
+		return nil, fmt.Errorf("rsqldrv: unsupported column type %q", col.Type)
+	}
+	return reflect.TypeOf(v), nil
+}
+
+// typeOfValue returns the Go type of a column inferred from one of its values.
+func typeOfValue(v interface{}) (reflect.Type, error) {
+	switch v.(type) {
+	case idealInt:
+		return reflect.TypeOf(int64(0)), nil
+	case idealUint:
+		return reflect.TypeOf(uint64(0)), nil
+	case idealFloat:
+		return reflect.TypeOf(float64(0)), nil
+	case []byte:
+		return reflect.TypeOf(""), nil
+	}
+
+	rt := reflect.TypeOf(v)
+	switch rt.Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return rt, nil
+	}
+	return nil, fmt.Errorf("rsqldrv: unsupported column type %T", v)
+}
+
+// writable returns whether the provided file can be written to.
+func writable(f *riofs.File) bool {
+	_, err := f.WriteAt(nil, 0)
+	return err == nil
+}
+
+func (conn *driverConn) exec(ctx context.Context, stmt sqlparser.Statement, args []driver.NamedValue) (driver.Result, error) {
+	switch stmt := stmt.(type) {
+	case *sqlparser.DDL:
+		switch stmt.Action {
+		case sqlparser.CreateStr:
+			return conn.createTable(stmt)
+		default:
+			return nil, fmt.Errorf("rsqldrv: %q statements not supported", strings.ToUpper(stmt.Action))
+		}
+
+	case *sqlparser.Insert:
+		return conn.insert(ctx, stmt, args)
+
+	default:
+		return nil, fmt.Errorf("rsqldrv: statement %q not supported", sqlparser.String(stmt))
+	}
+}
+
+func (conn *driverConn) createTable(stmt *sqlparser.DDL) (driver.Result, error) {
+	name := stmt.NewName.Name.CompliantName()
+	if _, dup := conn.tables[name]; dup {
+		return nil, fmt.Errorf("rsqldrv: table %q already exists", name)
+	}
+	if _, err := riofs.Dir(conn.f).Get(name); err == nil {
+		return nil, fmt.Errorf("rsqldrv: table %q already exists", name)
+	}
+
+	if stmt.TableSpec == nil || len(stmt.TableSpec.Columns) == 0 {
+		return nil, fmt.Errorf("rsqldrv: missing columns definition for table %q", name)
+	}
+
+	var (
+		cols  = make([]string, len(stmt.TableSpec.Columns))
+		types = make([]reflect.Type, len(stmt.TableSpec.Columns))
+	)
+	for i, col := range stmt.TableSpec.Columns {
+		rt, err := typeFrom(col.Type)
+		if err != nil {
+			return nil, fmt.Errorf("rsqldrv: invalid column %q: %w", col.Name.CompliantName(), err)
+		}
+		cols[i] = col.Name.CompliantName()
+		types[i] = rt
+	}
+
+	_, err := conn.newTable(name, cols, types)
+	if err != nil {
+		return nil, err
+	}
+	return &driverResult{}, nil
+}
+
+// createTableFrom creates a new table from the columns of an INSERT
+// statement, inferring the columns types from the first inserted row.
+func (conn *driverConn) createTableFrom(name string, cols sqlparser.Columns, row []interface{}) (*wtable, error) {
+	if len(row) != len(cols) {
+		return nil, fmt.Errorf("rsqldrv: invalid number of values (got=%d, want=%d)", len(row), len(cols))
+	}
+
+	var (
+		names = make([]string, len(cols))
+		types = make([]reflect.Type, len(cols))
+	)
+	for i, col := range cols {
+		rt, err := typeOfValue(row[i])
+		if err != nil {
+			return nil, fmt.Errorf("rsqldrv: invalid column %q: %w", col.CompliantName(), err)
+		}
+		names[i] = col.CompliantName()
+		types[i] = rt
+	}
+	return conn.newTable(name, names, types)
+}
+
+func (conn *driverConn) newTable(name string, cols []string, types []reflect.Type) (*wtable, error) {
+	if !writable(conn.f) {
+		return nil, fmt.Errorf("rsqldrv: could not create table %q: %w", name, riofs.ErrReadOnly)
+	}
+
+	tbl := &wtable{name: name, cols: cols, types: types}
+	wvars := make([]rtree.WriteVar, len(cols))
+	for i, rt := range types {
+		ptr := reflect.New(rt)
+		wvars[i] = rtree.WriteVar{Name: cols[i], Value: ptr.Interface()}
+		tbl.vals = append(tbl.vals, ptr.Elem())
+	}
+
+	var err error
+	tbl.w, err = rtree.NewWriter(conn.f, name, wvars)
+	if err != nil {
+		return nil, fmt.Errorf("rsqldrv: could not create tree %q: %w", name, err)
+	}
+
+	if conn.tables == nil {
+		conn.tables = make(map[string]*wtable)
+	}
+	conn.tables[name] = tbl
+	return tbl, nil
+}
+
+// index returns the index of each column of the table in the rows
+// inserted with the provided columns.
+func (tbl *wtable) index(cols sqlparser.Columns) ([]int, error) {
+	idx := make([]int, len(tbl.cols))
+	switch len(cols) {
+	case 0:
+		for i := range idx {
+			idx[i] = i
+		}
+	default:
+		for i := range idx {
+			idx[i] = -1
+		}
+		for i, col := range cols {
+			j := indexOf(tbl.cols, col.CompliantName())
+			if j < 0 {
+				return nil, fmt.Errorf("rsqldrv: unknown column %q in table %q", col.CompliantName(), tbl.name)
+			}
+			idx[j] = i
+		}
+	}
+	return idx, nil
+}
+
+// insert inserts rows into a table.
+// A new table is created from the first inserted row if no table with
+// that name exists in the ROOT file.
+func (conn *driverConn) insert(ctx context.Context, stmt *sqlparser.Insert, args []driver.NamedValue) (driver.Result, error) {
+	var (
+		name = stmt.Table.Name.CompliantName()
+		tbl  = conn.tables[name]
+		idx  []int
+	)
+	switch {
+	case tbl != nil:
+		var err error
+		idx, err = tbl.index(stmt.Columns)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		if _, err := riofs.Dir(conn.f).Get(name); err == nil {
+			return nil, fmt.Errorf("rsqldrv: no writable table %q", name)
+		}
+		if len(stmt.Columns) == 0 {
+			return nil, fmt.Errorf("rsqldrv: missing columns to create table %q", name)
+		}
+	}
+
+	add := func(vals []interface{}) error {
+		if tbl == nil {
+			var err error
+			tbl, err = conn.createTableFrom(name, stmt.Columns, vals)
+			if err != nil {
+				return err
+			}
+			idx, err = tbl.index(stmt.Columns)
+			if err != nil {
+				return err
+			}
+		}
+
+		n := len(tbl.cols)
+		if len(stmt.Columns) > 0 {
+			n = len(stmt.Columns)
+		}
+		if len(vals) != n {
+			return fmt.Errorf("rsqldrv: invalid number of values (got=%d, want=%d)", len(vals), n)
+		}
+
+		row := make([]interface{}, len(tbl.cols))
+		for i, j := range idx {
+			var v interface{}
+			if j >= 0 {
+				v = vals[j]
+			}
+			o, err := convert(v, tbl.types[i])
+			if err != nil {
+				return fmt.Errorf("rsqldrv: invalid value for column %q: %w", tbl.cols[i], err)
+			}
+			row[i] = o
+		}
+
+		if conn.tx {
+			tbl.rows = append(tbl.rows, row)
+			return nil
+		}
+		return tbl.write(row)
+	}
+
+	var n int64
+	switch rows := stmt.Rows.(type) {
+	case sqlparser.Values:
+		ectx := newExecCtx(conn, args)
+		for _, tuple := range rows {
+			vals := make([]interface{}, len(tuple))
+			for i, e := range tuple {
+				expr, err := newExprFrom(e, args)
+				if err != nil {
+					return nil, err
+				}
+				vals[i], err = expr.eval(ectx, nil)
+				if err != nil {
+					return nil, err
+				}
+			}
+			err := add(vals)
+			if err != nil {
+				return nil, err
+			}
+			n++
+		}
+
+	case *sqlparser.Select:
+		src, err := newDriverRows(ctx, conn, rows, args)
+		if err != nil {
+			return nil, err
+		}
+		defer src.Close()
+
+		dest := make([]driver.Value, len(src.cols))
+		for {
+			err := src.Next(dest)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			vals := make([]interface{}, len(dest))
+			for i, v := range dest {
+				vals[i] = v
+			}
+			err = add(vals)
+			if err != nil {
+				return nil, err
+			}
+			n++
+		}
+
+	default:
+		return nil, fmt.Errorf("rsqldrv: INSERT rows %q not supported", sqlparser.String(rows))
+	}
+
+	return &driverResult{rows: n}, nil
+}
+
+func indexOf(names []string, name string) int {
+	for i, v := range names {
+		if v == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// convert converts v to a value of type rt.
+// NULL values are converted to the zero value of rt.
+func convert(v interface{}, rt reflect.Type) (interface{}, error) {
+	if v == nil {
+		return reflect.Zero(rt).Interface(), nil
+	}
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch src, dst := kindOf(rv.Kind()), kindOf(rt.Kind()); {
+	case rv.Type() == rt:
+		return v, nil
+
+	case dst == reflect.Int64 || dst == reflect.Uint64 || dst == reflect.Float64:
+		o := reflect.New(rt).Elem()
+		switch src {
+		case reflect.Int64:
+			x := rv.Int()
+			switch dst {
+			case reflect.Int64:
+				if o.OverflowInt(x) {
+					return nil, fmt.Errorf("value %d overflows %v", x, rt)
+				}
+				o.SetInt(x)
+			case reflect.Uint64:
+				if x < 0 || o.OverflowUint(uint64(x)) {
+					return nil, fmt.Errorf("value %d overflows %v", x, rt)
+				}
+				o.SetUint(uint64(x))
+			case reflect.Float64:
+				o.SetFloat(float64(x))
+			}
+		case reflect.Uint64:
+			x := rv.Uint()
+			switch dst {
+			case reflect.Int64:
+				if x > uint64(1<<63-1) || o.OverflowInt(int64(x)) {
+					return nil, fmt.Errorf("value %d overflows %v", x, rt)
+				}
+				o.SetInt(int64(x))
+			case reflect.Uint64:
+				if o.OverflowUint(x) {
+					return nil, fmt.Errorf("value %d overflows %v", x, rt)
+				}
+				o.SetUint(x)
+			case reflect.Float64:
+				o.SetFloat(float64(x))
+			}
+		case reflect.Float64:
+			if dst != reflect.Float64 {
+				return nil, fmt.Errorf("can not convert %v (%T) to %v", v, v, rt)
+			}
+			o.SetFloat(rv.Float())
+		default:
+			return nil, fmt.Errorf("can not convert %#v (%T) to %v", v, v, rt)
+		}
+		return o.Interface(), nil
+
+	case src == dst && rv.Type().ConvertibleTo(rt):
+		return rv.Convert(rt).Interface(), nil
+	}
+
+	return nil, fmt.Errorf("can not convert %#v (%T) to %v", v, v, rt)
+}
@@ -7,6 +7,7 @@ package rsqldrv // import "go-hep.org/x/hep/groot/rsql/rsqldrv"
 import (
 	"database/sql/driver"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -1289,6 +1290,90 @@ func (op operator) String() string {
 	return fmt.Sprintf("%d", byte(op))
 }
 
+type unaryExpr struct {
+	expr sqlparser.Expr
+	op   string
+	x    expression
+}
+
+func (expr *unaryExpr) sql() sqlparser.Expr { return expr.expr }
+func (expr *unaryExpr) isStatic() bool      { return expr.x.isStatic() }
+
+func (expr *unaryExpr) eval(ectx *execCtx, vctx map[interface{}]interface{}) (interface{}, error) {
+	v, err := expr.x.eval(ectx, vctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.op {
+	case sqlparser.UPlusStr:
+		switch v.(type) {
+		case bool, string:
+			return nil, fmt.Errorf("rsqldrv: invalid operand %#v (%T) to unary +", v, v)
+		}
+		return v, nil
+	case sqlparser.UMinusStr:
+		return negOf(v)
+	case sqlparser.TildaStr:
+		return complOf(v)
+	case sqlparser.BangStr:
+		x, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("rsqldrv: invalid operand %#v (%T) to unary !", v, v)
+		}
+		return !x, nil
+	}
+	panic("impossible")
+}
+
+func negOf(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case idealInt:
+		return -v, nil
+	case idealFloat:
+		return -v, nil
+	case int8:
+		return -v, nil
+	case int16:
+		return -v, nil
+	case int32:
+		return -v, nil
+	case int64:
+		return -v, nil
+	case float32:
+		return -v, nil
+	case float64:
+		return -v, nil
+	}
+	return nil, fmt.Errorf("rsqldrv: invalid operand %#v (%T) to unary -", v, v)
+}
+
+func complOf(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case idealInt:
+		return ^v, nil
+	case idealUint:
+		return ^v, nil
+	case int8:
+		return ^v, nil
+	case int16:
+		return ^v, nil
+	case int32:
+		return ^v, nil
+	case int64:
+		return ^v, nil
+	case uint8:
+		return ^v, nil
+	case uint16:
+		return ^v, nil
+	case uint32:
+		return ^v, nil
+	case uint64:
+		return ^v, nil
+	}
+	return nil, fmt.Errorf("rsqldrv: invalid operand %#v (%T) to unary ~", v, v)
+}
+
 type identExpr struct {
 	expr sqlparser.Expr
 	name string
@@ -1376,6 +1461,12 @@ func idealValArgFrom(v interface{}) interface{} {
 		return idealFloat(rv.Float())
 	case reflect.String:
 		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Slice:
+		if b, ok := v.([]byte); ok {
+			return string(b)
+		}
 	}
 	panic(fmt.Errorf("rsqldrv: invalid ValArg type %#v", v))
 }
@@ -1414,9 +1505,183 @@ func (expr *tupleExpr) eval(ectx *execCtx, vctx map[interface{}]interface{}) (in
 	return o, nil
 }
 
+type funcExpr struct {
+	expr sqlparser.Expr
+	name string
+	args []expression
+}
+
+func newFuncExpr(expr *sqlparser.FuncExpr, args []driver.NamedValue) (expression, error) {
+	name := expr.Name.Lowered()
+	if expr.IsAggregate() {
+		return &aggExpr{expr: expr, key: aggKey(sqlparser.String(expr))}, nil
+	}
+
+	var nargs []int
+	switch name {
+	case "id":
+		nargs = []int{0}
+	case "abs", "sqrt":
+		nargs = []int{1}
+	case "log":
+		nargs = []int{1, 2}
+	case "pow", "power":
+		nargs = []int{2}
+	default:
+		return nil, fmt.Errorf("rsqldrv: unknown function %q", name)
+	}
+
+	valid := false
+	for _, n := range nargs {
+		if len(expr.Exprs) == n {
+			valid = true
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("rsqldrv: invalid number of arguments to %s (got=%d)", name, len(expr.Exprs))
+	}
+
+	fct := &funcExpr{expr: expr, name: name, args: make([]expression, len(expr.Exprs))}
+	for i, arg := range expr.Exprs {
+		arg, ok := arg.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, fmt.Errorf("rsqldrv: invalid argument to %s", sqlparser.String(expr))
+		}
+		v, err := newExprFrom(arg.Expr, args)
+		if err != nil {
+			return nil, err
+		}
+		fct.args[i] = v
+	}
+	return fct, nil
+}
+
+func (expr *funcExpr) sql() sqlparser.Expr { return expr.expr }
+func (expr *funcExpr) isStatic() bool {
+	for _, arg := range expr.args {
+		if !arg.isStatic() {
+			return false
+		}
+	}
+	return true
+}
+
+func (expr *funcExpr) eval(ectx *execCtx, vctx map[interface{}]interface{}) (interface{}, error) {
+	vs := make([]interface{}, len(expr.args))
+	for i, arg := range expr.args {
+		v, err := arg.eval(ectx, vctx)
+		if err != nil {
+			return nil, err
+		}
+		vs[i] = v
+	}
+
+	switch expr.name {
+	case "id":
+		v, ok := vctx[rowID{}]
+		if !ok {
+			return nil, fmt.Errorf("rsqldrv: invalid use of id()")
+		}
+		return v, nil
+	case "abs":
+		return absOf(vs[0])
+	}
+
+	xs := make([]float64, len(vs))
+	for i, v := range vs {
+		x, ok := toFloat64(v)
+		if !ok {
+			return nil, fmt.Errorf("rsqldrv: invalid argument %#v (%T) to %s", v, v, expr.name)
+		}
+		xs[i] = x
+	}
+
+	switch expr.name {
+	case "sqrt":
+		return math.Sqrt(xs[0]), nil
+	case "log":
+		if len(xs) == 2 {
+			// log(b, x) is the logarithm of x in base b.
+			return math.Log(xs[1]) / math.Log(xs[0]), nil
+		}
+		return math.Log(xs[0]), nil
+	case "pow", "power":
+		return math.Pow(xs[0], xs[1]), nil
+	}
+	panic("impossible")
+}
+
+func absOf(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case idealInt:
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case idealFloat:
+		return idealFloat(math.Abs(float64(v))), nil
+	case idealUint, uint8, uint16, uint32, uint64:
+		return v, nil
+	case int8:
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case int16:
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case int32:
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case int64:
+		if v < 0 {
+			return -v, nil
+		}
+		return v, nil
+	case float32:
+		return float32(math.Abs(float64(v))), nil
+	case float64:
+		return math.Abs(v), nil
+	}
+	return nil, fmt.Errorf("rsqldrv: invalid argument %#v (%T) to abs", v, v)
+}
+
+// rowID is the key under which the entry number of the current row is
+// stored in the evaluation context.
+type rowID struct{}
+
+// aggKey is the key under which the value of an aggregate function is
+// stored in the evaluation context.
+type aggKey string
+
+// aggExpr is a reference to the value of an aggregate function,
+// computed over a group of rows.
+type aggExpr struct {
+	expr sqlparser.Expr
+	key  aggKey
+}
+
+func (expr *aggExpr) sql() sqlparser.Expr { return expr.expr }
+func (expr *aggExpr) isStatic() bool      { return false }
+
+func (expr *aggExpr) eval(ectx *execCtx, vctx map[interface{}]interface{}) (interface{}, error) {
+	v, ok := vctx[expr.key]
+	if !ok {
+		return nil, fmt.Errorf("rsqldrv: invalid use of aggregate function %s", expr.key)
+	}
+	return v, nil
+}
+
 var (
 	_ expression = (*binExpr)(nil)
+	_ expression = (*unaryExpr)(nil)
 	_ expression = (*identExpr)(nil)
 	_ expression = (*valueExpr)(nil)
 	_ expression = (*tupleExpr)(nil)
+	_ expression = (*funcExpr)(nil)
+	_ expression = (*aggExpr)(nil)
 )
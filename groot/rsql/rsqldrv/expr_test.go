@@ -6,6 +6,7 @@ package rsqldrv // import "go-hep.org/x/hep/groot/rsql/rsqldrv"
 
 import (
 	"database/sql"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -774,6 +775,46 @@ func TestExpr(t *testing.T) {
 			vctx: vctxType{"x": float64(5), "y": float64(2)},
 			want: true,
 		},
+		{
+			expr: "select sqrt(x) from tbl",
+			vctx: vctxType{"x": int32(16)},
+			want: float64(4),
+		},
+		{
+			expr: "select abs(x-y) from tbl",
+			vctx: vctxType{"x": int64(2), "y": int64(5)},
+			want: int64(3),
+		},
+		{
+			expr: "select abs(x) from tbl",
+			vctx: vctxType{"x": float32(-2.5)},
+			want: float32(2.5),
+		},
+		{
+			expr: "select pow(x, 3) from tbl",
+			vctx: vctxType{"x": float64(2)},
+			want: float64(8),
+		},
+		{
+			expr: "select log(2, x) from tbl",
+			vctx: vctxType{"x": uint32(8)},
+			want: float64(3),
+		},
+		{
+			expr: "select (t.x + t.y) from tbl as t",
+			vctx: vctxType{"t.x": int32(1), "t.y": int32(2)},
+			want: int32(3),
+		},
+		{
+			expr: "select sqrt(x) from tbl",
+			vctx: vctxType{"x": "foo"},
+			err:  fmt.Errorf(`rsqldrv: invalid argument "foo" (string) to sqrt`),
+		},
+		{
+			expr: "select count(x) from tbl",
+			vctx: vctxType{"x": int32(1)},
+			err:  fmt.Errorf("rsqldrv: invalid use of aggregate function count(x)"),
+		},
 	} {
 		t.Run(tc.expr, func(t *testing.T) {
 			stmt, err := sqlparser.Parse(tc.expr)
@@ -909,7 +950,7 @@ func TestSelectColumns(t *testing.T) {
 		},
 		{
 			query: `select (?, two, ?) from tree`,
-			cols:  []string{"", "two", ""},
+			cols:  []string{":v1", "two", ":v2"},
 			types: []interface{}{"", 0.0, ""},
 			args:  []interface{}{"one", "three"},
 			vals: [][]eface{
@@ -921,7 +962,7 @@ func TestSelectColumns(t *testing.T) {
 		},
 		{
 			query: `select (:v1, two, :v2) from tree`,
-			cols:  []string{"", "two", ""},
+			cols:  []string{":v1", "two", ":v2"},
 			types: []interface{}{"", 0.0, ""},
 			args:  []interface{}{"one", "three"},
 			vals: [][]eface{
@@ -933,7 +974,7 @@ func TestSelectColumns(t *testing.T) {
 		},
 		{
 			query: `select (:v2, two, :v1) from tree`,
-			cols:  []string{"", "two", ""},
+			cols:  []string{":v2", "two", ":v1"},
 			types: []interface{}{"", 0.0, ""},
 			args:  []interface{}{"three", "one"},
 			vals: [][]eface{
@@ -945,7 +986,7 @@ func TestSelectColumns(t *testing.T) {
 		},
 		{
 			query: `select (:v2, two+:v3, :v1) from tree`,
-			cols:  []string{"", "", ""},
+			cols:  []string{":v2", "two + :v3", ":v1"},
 			types: []interface{}{"", 0.0, ""},
 			args:  []interface{}{"three", "one", 10},
 			vals: [][]eface{
@@ -982,7 +1023,7 @@ func TestSelectColumns(t *testing.T) {
 		},
 		{
 			query: `select (one, two, ?+:v2) from tree where (three="quatro")`,
-			cols:  []string{"one", "two", ""},
+			cols:  []string{"one", "two", ":v1 + :v2"},
 			types: []interface{}{int32(0), 0.0, uint64(0)},
 			args:  []interface{}{idealUint(5), idealUint(10)},
 			vals: [][]eface{
@@ -0,0 +1,547 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rsqldrv // import "go-hep.org/x/hep/groot/rsql/rsqldrv"
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/xwb1989/sqlparser"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+)
+
+// table describes a tree taking part in a query.
+type table struct {
+	name  string // name of the tree in the file
+	alias string // alias of the tree in the query, if any
+	tree  rtree.Tree
+
+	deps []string   // names of the branches to read
+	keys [][]string // names under which each branch is referenced in the query
+}
+
+func newTable(f *riofs.File, expr *sqlparser.AliasedTableExpr) (*table, error) {
+	var name string
+	switch tn := expr.Expr.(type) {
+	case sqlparser.TableName:
+		name = tn.Name.CompliantName()
+	default:
+		return nil, fmt.Errorf("rsqldrv: unknown FROM expression type %T", tn)
+	}
+
+	obj, err := riofs.Dir(f).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, ok := obj.(rtree.Tree)
+	if !ok {
+		return nil, fmt.Errorf("rsqldrv: object %q is not a Tree", name)
+	}
+
+	return &table{
+		name:  name,
+		alias: expr.As.CompliantName(),
+		tree:  tree,
+	}, nil
+}
+
+// ref returns the name under which the table is known in the query.
+func (tbl *table) ref() string {
+	if tbl.alias != "" {
+		return tbl.alias
+	}
+	return tbl.name
+}
+
+// match returns whether the provided column qualifier refers to this table.
+func (tbl *table) match(qual string) bool {
+	return qual == "" || qual == tbl.name || qual == tbl.alias
+}
+
+// use marks the named branch as needed by the query, under the provided key.
+func (tbl *table) use(branch, key string) {
+	for i, dep := range tbl.deps {
+		if dep != branch {
+			continue
+		}
+		for _, k := range tbl.keys[i] {
+			if k == key {
+				return
+			}
+		}
+		tbl.keys[i] = append(tbl.keys[i], key)
+		return
+	}
+	tbl.deps = append(tbl.deps, branch)
+	tbl.keys = append(tbl.keys, []string{key})
+}
+
+// tablesFrom returns the tables referenced by a FROM clause.
+func tablesFrom(f *riofs.File, from []sqlparser.TableExpr) ([]*table, *sqlparser.JoinTableExpr, error) {
+	if len(from) != 1 {
+		return nil, nil, fmt.Errorf("rsqldrv: invalid number of tables (got=%d, want=1)", len(from))
+	}
+
+	switch expr := from[0].(type) {
+	case *sqlparser.AliasedTableExpr:
+		tbl, err := newTable(f, expr)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []*table{tbl}, nil, nil
+
+	case *sqlparser.JoinTableExpr:
+		switch expr.Join {
+		case sqlparser.JoinStr, sqlparser.LeftJoinStr:
+			// ok
+		default:
+			return nil, nil, fmt.Errorf("rsqldrv: %q not supported", expr.Join)
+		}
+
+		var tables []*table
+		for _, side := range []sqlparser.TableExpr{expr.LeftExpr, expr.RightExpr} {
+			te, ok := side.(*sqlparser.AliasedTableExpr)
+			if !ok {
+				return nil, nil, fmt.Errorf("rsqldrv: nested joins not supported")
+			}
+			tbl, err := newTable(f, te)
+			if err != nil {
+				return nil, nil, err
+			}
+			tables = append(tables, tbl)
+		}
+		if tables[0].ref() == tables[1].ref() {
+			return nil, nil, fmt.Errorf("rsqldrv: joined trees need distinct names (got=%q)", tables[0].ref())
+		}
+		if expr.Condition.On == nil && len(expr.Condition.Using) == 0 {
+			return nil, nil, fmt.Errorf("rsqldrv: missing JOIN condition")
+		}
+		return tables, expr, nil
+
+	default:
+		return nil, nil, fmt.Errorf("rsqldrv: unknown table expression %T", expr)
+	}
+}
+
+// colKey returns the name under which a column is referenced in the
+// evaluation context of a query.
+func colKey(col *sqlparser.ColName) string {
+	name := col.Name.CompliantName()
+	if qual := col.Qualifier.Name.CompliantName(); qual != "" {
+		return qual + "." + name
+	}
+	return name
+}
+
+// lookup returns the table and branch name a column refers to.
+func lookup(tables []*table, col *sqlparser.ColName, using map[string]bool) (*table, string, error) {
+	var (
+		name  = col.Name.CompliantName()
+		qual  = col.Qualifier.Name.CompliantName()
+		found []*table
+	)
+	for _, tbl := range tables {
+		if tbl.match(qual) && tbl.tree.Branch(name) != nil {
+			found = append(found, tbl)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		if len(tables) == 1 {
+			tbl := tables[0]
+			if qual != "" && tbl.tree.Branch(qual+"."+name) != nil {
+				// column is a dotted branch name (e.g. from a split struct).
+				return tbl, qual + "." + name, nil
+			}
+			return nil, "", fmt.Errorf("rsqldrv: could not find branch/leaf %q in tree %q", colKey(col), tbl.tree.Name())
+		}
+		return nil, "", fmt.Errorf("rsqldrv: could not find column %q", colKey(col))
+	case 1:
+		return found[0], name, nil
+	default:
+		if !using[name] {
+			return nil, "", fmt.Errorf("rsqldrv: ambiguous column name %q", name)
+		}
+		return found[0], name, nil
+	}
+}
+
+// newReadVar returns the read-var needed to read the provided branch.
+func newReadVar(branch rtree.Branch) rtree.ReadVar {
+	leaf := branch.Leaves()[0] // only the first leaf of multi-leaf branches is read.
+	etyp := leaf.Type()
+	switch etyp.Kind() {
+	case reflect.Int8:
+		if leaf.IsUnsigned() {
+			etyp = reflect.TypeOf(uint8(0))
+		}
+	case reflect.Int16:
+		if leaf.IsUnsigned() {
+			etyp = reflect.TypeOf(uint16(0))
+		}
+	case reflect.Int32:
+		if leaf.IsUnsigned() {
+			etyp = reflect.TypeOf(uint32(0))
+		}
+	case reflect.Int64:
+		if leaf.IsUnsigned() {
+			etyp = reflect.TypeOf(uint64(0))
+		}
+	}
+	switch {
+	case leaf.LeafCount() != nil:
+		etyp = reflect.SliceOf(etyp)
+	case leaf.Len() > 1 && leaf.Kind() != reflect.String:
+		etyp = reflect.ArrayOf(leaf.Len(), etyp)
+	}
+	return rtree.ReadVar{
+		Name:  branch.Name(),
+		Leaf:  leaf.Name(),
+		Value: reflect.New(etyp).Interface(),
+	}
+}
+
+// rowSource produces the rows a query operates on.
+type rowSource interface {
+	// next fills the evaluation context with the values of the next row.
+	// next returns false when there are no more rows.
+	next(vctx map[interface{}]interface{}) (bool, error)
+	close() error
+}
+
+// treeSource iterates over the entries of a single tree.
+type treeSource struct {
+	tbl  *table
+	scan *rtree.TreeScanner
+	vals []interface{}
+}
+
+func newTreeSource(tbl *table) (*treeSource, error) {
+	if len(tbl.deps) == 0 {
+		// nothing needs to be read from that tree, but we still need
+		// to iterate over its entries (e.g. "select count(*) from tree")
+		tbl.deps = append(tbl.deps, tbl.tree.Branches()[0].Name())
+		tbl.keys = append(tbl.keys, nil)
+	}
+
+	vars := make([]rtree.ReadVar, len(tbl.deps))
+	for i, name := range tbl.deps {
+		vars[i] = newReadVar(tbl.tree.Branch(name))
+	}
+
+	scan, err := rtree.NewTreeScannerVars(tbl.tree, vars...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &treeSource{
+		tbl:  tbl,
+		scan: scan,
+		vals: varsFrom(vars),
+	}, nil
+}
+
+func (src *treeSource) next(vctx map[interface{}]interface{}) (bool, error) {
+	if !src.scan.Next() {
+		return false, nil
+	}
+	err := src.scan.Scan(src.vals...)
+	if err != nil {
+		return false, err
+	}
+	for i, v := range src.vals {
+		v := reflect.Indirect(reflect.ValueOf(v)).Interface()
+		for _, key := range src.tbl.keys[i] {
+			vctx[key] = v
+		}
+	}
+	vctx[rowID{}] = src.scan.Entry()
+	return true, nil
+}
+
+func (src *treeSource) close() error {
+	return src.scan.Close()
+}
+
+// joinSource joins the entries of two trees.
+//
+// joinSource performs a hash join on a pair of key columns: the right-hand
+// side tree is loaded in memory and indexed by its key column.
+// The complete JOIN condition is then evaluated on each candidate pair.
+type joinSource struct {
+	ectx  *execCtx
+	left  *treeSource
+	lkey  string     // name of the join column of the left tree
+	cond  expression // complete JOIN condition
+	outer bool       // whether to keep unmatched rows of the left tree
+
+	rows  []map[interface{}]interface{} // rows of the right tree
+	index map[string][]int              // rows of the right tree, indexed by join key
+	rkeys []string                      // names of the columns of the right tree
+
+	pending []map[interface{}]interface{} // joined rows not yet consumed
+}
+
+func newJoinSource(ectx *execCtx, left, right *table, lkey, rkey string, cond expression, outer bool) (*joinSource, error) {
+	lsrc, err := newTreeSource(left)
+	if err != nil {
+		return nil, err
+	}
+
+	rsrc, err := newTreeSource(right)
+	if err != nil {
+		lsrc.close()
+		return nil, err
+	}
+	defer rsrc.close()
+
+	src := &joinSource{
+		ectx:  ectx,
+		left:  lsrc,
+		lkey:  lkey,
+		cond:  cond,
+		outer: outer,
+		index: make(map[string][]int),
+	}
+	for _, keys := range right.keys {
+		src.rkeys = append(src.rkeys, keys...)
+	}
+
+	for {
+		row := make(map[interface{}]interface{})
+		ok, err := rsrc.next(row)
+		if err != nil {
+			lsrc.close()
+			return nil, fmt.Errorf("rsqldrv: could not read tree %q: %w", right.name, err)
+		}
+		if !ok {
+			break
+		}
+		for k, v := range row {
+			row[k] = detach(v)
+		}
+		key := hashKey(row[rkey])
+		src.index[key] = append(src.index[key], len(src.rows))
+		src.rows = append(src.rows, row)
+	}
+
+	return src, nil
+}
+
+func (src *joinSource) next(vctx map[interface{}]interface{}) (bool, error) {
+	for len(src.pending) == 0 {
+		lrow := make(map[interface{}]interface{})
+		ok, err := src.left.next(lrow)
+		if err != nil || !ok {
+			return ok, err
+		}
+
+		for _, i := range src.index[hashKey(lrow[src.lkey])] {
+			row := make(map[interface{}]interface{}, len(lrow)+len(src.rows[i]))
+			for k, v := range lrow {
+				row[k] = v
+			}
+			for k, v := range src.rows[i] {
+				row[k] = v
+			}
+			ok, err := src.cond.eval(src.ectx, row)
+			if err != nil {
+				return false, fmt.Errorf("rsqldrv: could not evaluate JOIN condition: %w", err)
+			}
+			if ok, _ := ok.(bool); ok {
+				src.pending = append(src.pending, row)
+			}
+		}
+
+		if len(src.pending) == 0 && src.outer {
+			for _, k := range src.rkeys {
+				lrow[k] = nil
+			}
+			src.pending = append(src.pending, lrow)
+		}
+	}
+
+	for k, v := range src.pending[0] {
+		vctx[k] = v
+	}
+	src.pending = src.pending[1:]
+	return true, nil
+}
+
+func (src *joinSource) close() error {
+	return src.left.close()
+}
+
+// aggregate describes an aggregate function call of a query.
+type aggregate struct {
+	key      aggKey
+	name     string     // one of count, sum, avg, min or max
+	arg      expression // argument of the function, nil for count(*)
+	distinct bool
+}
+
+func newAggregate(expr *sqlparser.FuncExpr, args []driver.NamedValue) (*aggregate, error) {
+	agg := &aggregate{
+		key:      aggKey(sqlparser.String(expr)),
+		name:     expr.Name.Lowered(),
+		distinct: expr.Distinct,
+	}
+	switch agg.name {
+	case "count", "sum", "avg", "min", "max":
+		// ok
+	default:
+		return nil, fmt.Errorf("rsqldrv: aggregate function %q not supported", agg.name)
+	}
+
+	if len(expr.Exprs) != 1 {
+		return nil, fmt.Errorf("rsqldrv: invalid number of arguments to %s (got=%d, want=1)", agg.name, len(expr.Exprs))
+	}
+
+	switch arg := expr.Exprs[0].(type) {
+	case *sqlparser.StarExpr:
+		if agg.name != "count" || agg.distinct {
+			return nil, fmt.Errorf("rsqldrv: invalid argument '*' to %s", sqlparser.String(expr))
+		}
+	case *sqlparser.AliasedExpr:
+		var err error
+		agg.arg, err = newExprFrom(arg.Expr, args)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("rsqldrv: invalid argument to %s", sqlparser.String(expr))
+	}
+
+	return agg, nil
+}
+
+// accumulator accumulates the values of an aggregate function over a group of rows.
+type accumulator struct {
+	fct  *aggregate
+	n    int64
+	kind reflect.Kind // kind of the sum: reflect.Int64, reflect.Uint64 or reflect.Float64
+	isum int64
+	usum uint64
+	fsum float64
+	v    interface{}         // current minimum or maximum
+	seen map[string]struct{} // values already seen, for "distinct" aggregates
+}
+
+func newAccumulator(fct *aggregate) *accumulator {
+	acc := &accumulator{fct: fct}
+	if fct.distinct {
+		acc.seen = make(map[string]struct{})
+	}
+	return acc
+}
+
+func (acc *accumulator) add(ectx *execCtx, vctx map[interface{}]interface{}) error {
+	var v interface{}
+	if acc.fct.arg != nil {
+		var err error
+		v, err = acc.fct.arg.eval(ectx, vctx)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			// NULL values are ignored by aggregate functions.
+			return nil
+		}
+	}
+
+	if acc.seen != nil {
+		key := hashKey(v)
+		if _, dup := acc.seen[key]; dup {
+			return nil
+		}
+		acc.seen[key] = struct{}{}
+	}
+
+	acc.n++
+	switch acc.fct.name {
+	case "sum", "avg":
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			acc.isum += rv.Int()
+			acc.fsum += float64(rv.Int())
+			if acc.kind == reflect.Invalid {
+				acc.kind = reflect.Int64
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			acc.usum += rv.Uint()
+			acc.fsum += float64(rv.Uint())
+			if acc.kind == reflect.Invalid {
+				acc.kind = reflect.Uint64
+			}
+		case reflect.Float32, reflect.Float64:
+			acc.fsum += rv.Float()
+			acc.kind = reflect.Float64
+		default:
+			return fmt.Errorf("rsqldrv: invalid value %#v (%T) for %s", v, v, acc.fct.key)
+		}
+
+	case "min", "max":
+		if acc.v == nil {
+			acc.v = detach(v)
+			return nil
+		}
+		cmp, err := compareValues(v, acc.v)
+		if err != nil {
+			return fmt.Errorf("rsqldrv: could not evaluate %s: %w", acc.fct.key, err)
+		}
+		if (acc.fct.name == "min" && cmp < 0) || (acc.fct.name == "max" && cmp > 0) {
+			acc.v = detach(v)
+		}
+	}
+	return nil
+}
+
+func (acc *accumulator) value() interface{} {
+	switch acc.fct.name {
+	case "count":
+		return acc.n
+	case "sum":
+		switch acc.kind {
+		case reflect.Int64:
+			return acc.isum
+		case reflect.Uint64:
+			return acc.usum
+		case reflect.Float64:
+			return acc.fsum
+		}
+		return nil
+	case "avg":
+		if acc.n == 0 {
+			return nil
+		}
+		return acc.fsum / float64(acc.n)
+	default:
+		return acc.v
+	}
+}
+
+// group is a group of rows sharing the same GROUP BY values.
+type group struct {
+	vctx map[interface{}]interface{} // values of the first row of the group
+	accs []*accumulator
+}
+
+// orderKey describes an ORDER BY term.
+type orderKey struct {
+	pos  int        // index of the referenced output column, or -1
+	expr expression // expression to sort on, when pos == -1
+	desc bool
+}
+
+// record is a materialized output row.
+type record struct {
+	vals []interface{} // values of the output columns
+	keys []interface{} // values of the ORDER BY terms
+}
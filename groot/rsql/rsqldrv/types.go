@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 
 	"go-hep.org/x/hep/groot/rtree"
 )
@@ -232,3 +233,130 @@ func colDescrFrom(name string, etyp reflect.Type, kind reflect.Kind, hasCount bo
 	col.Type = etyp
 	return col
 }
+
+// toFloat64 converts a numerical value to float64.
+func toFloat64(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// compareValues compares two values and returns -1, 0 or +1 when a is
+// respectively less than, equal to or greater than b.
+// NULL values sort before any other value.
+func compareValues(a, b interface{}) (int, error) {
+	switch {
+	case a == nil && b == nil:
+		return 0, nil
+	case a == nil:
+		return -1, nil
+	case b == nil:
+		return +1, nil
+	}
+
+	ra := reflect.ValueOf(a)
+	rb := reflect.ValueOf(b)
+	switch ka, kb := kindOf(ra.Kind()), kindOf(rb.Kind()); {
+	case ka == reflect.Int64 && kb == reflect.Int64:
+		x, y := ra.Int(), rb.Int()
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return +1, nil
+		}
+		return 0, nil
+	case ka == reflect.Uint64 && kb == reflect.Uint64:
+		x, y := ra.Uint(), rb.Uint()
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return +1, nil
+		}
+		return 0, nil
+	case ka == reflect.Bool && kb == reflect.Bool:
+		x, y := ra.Bool(), rb.Bool()
+		switch {
+		case x == y:
+			return 0, nil
+		case !x:
+			return -1, nil
+		}
+		return +1, nil
+	case ka == reflect.String && kb == reflect.String:
+		x, y := ra.String(), rb.String()
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return +1, nil
+		}
+		return 0, nil
+	}
+
+	x, okx := toFloat64(a)
+	y, oky := toFloat64(b)
+	if !okx || !oky {
+		return 0, fmt.Errorf("rsqldrv: can not compare values of types %T and %T", a, b)
+	}
+	switch {
+	case x < y:
+		return -1, nil
+	case x > y:
+		return +1, nil
+	}
+	return 0, nil
+}
+
+// kindOf returns the class of the provided kind, folding all the signed
+// integers into reflect.Int64, unsigned integers into reflect.Uint64 and
+// floating points into reflect.Float64.
+func kindOf(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int64
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Uint64
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return k
+}
+
+// hashKey returns a string representation of a value, suitable for
+// grouping and de-duplicating values.
+// Numerical values of different types that compare equal share the same key.
+func hashKey(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	switch kindOf(rv.Kind()) {
+	case reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.String:
+		return strconv.Quote(rv.String())
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+// detach returns a copy of v that does not share memory with the buffers
+// used to read the tree entries.
+func detach(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.IsNil() {
+		return v
+	}
+	o := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+	reflect.Copy(o, rv)
+	return o.Interface()
+}
@@ -87,6 +87,291 @@ type TreeResponse struct {
 	Tree Tree   `json:"tree"`
 }
 
+type H1DataRequest struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+}
+
+type H1DataResponse struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+	H1  H1Data `json:"h1"`
+}
+
+// H1Data holds the content of a 1-dim histogram.
+// Edges holds the nbins+1 bin edges.
+// SumW and SumW2 hold the sum of weights and the sum of squared weights
+// of the nbins in-range bins.
+type H1Data struct {
+	Type      string    `json:"type"`
+	Name      string    `json:"name"`
+	Title     string    `json:"title"`
+	Entries   float64   `json:"entries"`
+	Edges     []float64 `json:"edges"`
+	SumW      []float64 `json:"sumw"`
+	SumW2     []float64 `json:"sumw2"`
+	Underflow Outflow   `json:"underflow"`
+	Overflow  Outflow   `json:"overflow"`
+}
+
+// Outflow holds the content of an under- or over-flow bin.
+type Outflow struct {
+	SumW  float64 `json:"sumw"`
+	SumW2 float64 `json:"sumw2"`
+}
+
+type H2DataRequest struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+}
+
+type H2DataResponse struct {
+	URI string `json:"uri"`
+	Dir string `json:"dir"`
+	Obj string `json:"obj"`
+	H2  H2Data `json:"h2"`
+}
+
+// H2Data holds the content of a 2-dim histogram.
+// XEdges and YEdges hold the nx+1 and ny+1 bin edges.
+// SumW and SumW2 hold the sum of weights and the sum of squared weights
+// of the nx*ny in-range bins, where bin (ix,iy) is at index iy*nx+ix.
+type H2Data struct {
+	Type    string    `json:"type"`
+	Name    string    `json:"name"`
+	Title   string    `json:"title"`
+	Entries float64   `json:"entries"`
+	XEdges  []float64 `json:"xedges"`
+	YEdges  []float64 `json:"yedges"`
+	SumW    []float64 `json:"sumw"`
+	SumW2   []float64 `json:"sumw2"`
+}
+
+// TreeDataRequest describes a request for the column data of a tree.
+//
+// Vars lists the branches to read (all the branches if empty).
+// Only the entries in [Beg, End) are considered (End defaults to the
+// number of entries in the tree), and, if Selection is not empty, only
+// the entries passing the selection expression are returned.
+// At most Limit entries are returned (defaults to 1000).
+type TreeDataRequest struct {
+	URI       string   `json:"uri"`
+	Dir       string   `json:"dir"`
+	Obj       string   `json:"obj"`
+	Vars      []string `json:"vars,omitempty"`
+	Beg       int64    `json:"beg,omitempty"`
+	End       int64    `json:"end,omitempty"`
+	Selection string   `json:"selection,omitempty"`
+	Limit     int64    `json:"limit,omitempty"`
+}
+
+// TreeDataResponse holds a page of column data.
+// Entries holds the indices of the selected entries.
+// Next is the first entry of the next page, or -1 if there are no more
+// entries to read.
+type TreeDataResponse struct {
+	URI     string   `json:"uri"`
+	Dir     string   `json:"dir"`
+	Obj     string   `json:"obj"`
+	Entries []int64  `json:"entries"`
+	Columns []Column `json:"columns"`
+	Next    int64    `json:"next"`
+}
+
+// Column holds the data of a tree branch, one value per selected entry.
+type Column struct {
+	Name string      `json:"name"`
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// StreamerInfoRequest describes a request for the streamer infos of a
+// file. All the streamer infos are returned if Name is empty.
+type StreamerInfoRequest struct {
+	URI  string `json:"uri"`
+	Name string `json:"name,omitempty"`
+}
+
+type StreamerInfoResponse struct {
+	URI           string         `json:"uri"`
+	StreamerInfos []StreamerInfo `json:"streamer_infos"`
+}
+
+type StreamerInfo struct {
+	Name     string            `json:"name"`
+	Title    string            `json:"title,omitempty"`
+	Version  int               `json:"version"`
+	CheckSum int               `json:"checksum"`
+	Elements []StreamerElement `json:"elements"`
+}
+
+type StreamerElement struct {
+	Name     string `json:"name"`
+	Title    string `json:"title,omitempty"`
+	Type     string `json:"type"`
+	Kind     string `json:"kind"`
+	Size     int    `json:"size"`
+	ArrayLen int    `json:"array_len,omitempty"`
+	ArrayDim int    `json:"array_dim,omitempty"`
+}
+
+// BookH1Request describes a 1-dim histogram to book and fill from a tree.
+//
+// The histogram is filled with the values of the Expr expression, for
+// all the entries in [Beg, End) passing the Selection expression.
+// End defaults to the number of entries in the tree.
+type BookH1Request struct {
+	URI       string  `json:"uri"`
+	Dir       string  `json:"dir"`
+	Obj       string  `json:"obj"`
+	Name      string  `json:"name"`
+	Title     string  `json:"title,omitempty"`
+	Expr      string  `json:"expr"`
+	Selection string  `json:"selection,omitempty"`
+	Beg       int64   `json:"beg,omitempty"`
+	End       int64   `json:"end,omitempty"`
+	NBins     int     `json:"nbins"`
+	XMin      float64 `json:"xmin"`
+	XMax      float64 `json:"xmax"`
+}
+
+// BookH2Request describes a 2-dim histogram to book and fill from a tree.
+//
+// The histogram is filled with the values of the X and Y expressions, for
+// all the entries in [Beg, End) passing the Selection expression.
+// End defaults to the number of entries in the tree.
+type BookH2Request struct {
+	URI       string  `json:"uri"`
+	Dir       string  `json:"dir"`
+	Obj       string  `json:"obj"`
+	Name      string  `json:"name"`
+	Title     string  `json:"title,omitempty"`
+	X         string  `json:"x"`
+	Y         string  `json:"y"`
+	Selection string  `json:"selection,omitempty"`
+	Beg       int64   `json:"beg,omitempty"`
+	End       int64   `json:"end,omitempty"`
+	NX        int     `json:"nx"`
+	XMin      float64 `json:"xmin"`
+	XMax      float64 `json:"xmax"`
+	NY        int     `json:"ny"`
+	YMin      float64 `json:"ymin"`
+	YMax      float64 `json:"ymax"`
+}
+
+// BookP1Request describes a 1-dim profile histogram to book and fill from
+// a tree.
+//
+// The profile is filled with the values of the X and Y expressions, for
+// all the entries in [Beg, End) passing the Selection expression.
+// End defaults to the number of entries in the tree.
+type BookP1Request struct {
+	URI       string  `json:"uri"`
+	Dir       string  `json:"dir"`
+	Obj       string  `json:"obj"`
+	Name      string  `json:"name"`
+	Title     string  `json:"title,omitempty"`
+	X         string  `json:"x"`
+	Y         string  `json:"y"`
+	Selection string  `json:"selection,omitempty"`
+	Beg       int64   `json:"beg,omitempty"`
+	End       int64   `json:"end,omitempty"`
+	NBins     int     `json:"nbins"`
+	XMin      float64 `json:"xmin"`
+	XMax      float64 `json:"xmax"`
+}
+
+// Hist describes a histogram booked in the user's session.
+// Type is one of "H1D", "H2D", "P1D" or "S2D".
+type Hist struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Title   string `json:"title,omitempty"`
+	Entries int64  `json:"entries"`
+}
+
+type HistResponse struct {
+	Hist Hist `json:"hist"`
+}
+
+type ListHistsResponse struct {
+	Hists []Hist `json:"hists"`
+}
+
+type HistDataRequest struct {
+	Name string `json:"name"`
+}
+
+// HistDataResponse holds the content of a booked histogram.
+// Only the field corresponding to the type of the histogram is set.
+type HistDataResponse struct {
+	Hist Hist    `json:"hist"`
+	H1   *H1Data `json:"h1,omitempty"`
+	H2   *H2Data `json:"h2,omitempty"`
+	P1   *P1Data `json:"p1,omitempty"`
+	S2   *S2Data `json:"s2,omitempty"`
+}
+
+// P1Data holds the content of a 1-dim profile histogram.
+// Edges holds the nbins+1 bin edges.
+// SumW holds the sum of weights of the nbins in-range bins, Mean and
+// StdErr the mean and the standard error on the mean of their y values.
+type P1Data struct {
+	Type    string    `json:"type"`
+	Name    string    `json:"name"`
+	Title   string    `json:"title"`
+	Entries float64   `json:"entries"`
+	Edges   []float64 `json:"edges"`
+	SumW    []float64 `json:"sumw"`
+	Mean    []float64 `json:"mean"`
+	StdErr  []float64 `json:"stderr"`
+}
+
+// S2Data holds the content of a 2-dim scatter.
+type S2Data struct {
+	Type   string    `json:"type"`
+	Name   string    `json:"name"`
+	Title  string    `json:"title"`
+	Points []S2Point `json:"points"`
+}
+
+// S2Point is a point of a 2-dim scatter.
+// XErr and YErr hold the low and high errors along each axis.
+type S2Point struct {
+	X    float64    `json:"x"`
+	Y    float64    `json:"y"`
+	XErr [2]float64 `json:"xerr"`
+	YErr [2]float64 `json:"yerr"`
+}
+
+// DivideHistsRequest describes the division of the Num 1-dim histogram by
+// the Den 1-dim histogram.
+// The resulting 2-dim scatter is booked under Name.
+type DivideHistsRequest struct {
+	Name string `json:"name"`
+	Num  string `json:"num"`
+	Den  string `json:"den"`
+}
+
+// PlotHistsRequest describes the overlay plot of a set of booked
+// histograms.
+type PlotHistsRequest struct {
+	Names []string `json:"names"`
+
+	Options PlotOptions `json:"options"`
+}
+
+// SaveHistsRequest describes the booked histograms to save into a new ROOT
+// file, registered under URI.
+// All the booked histograms are saved if Names is empty.
+type SaveHistsRequest struct {
+	URI   string   `json:"uri"`
+	Names []string `json:"names,omitempty"`
+}
+
 type PlotH1Request struct {
 	URI string `json:"uri"`
 	Dir string `json:"dir"`
@@ -11,12 +11,14 @@ import (
 	"sync"
 
 	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/hbook"
 )
 
 type DB struct {
 	sync.RWMutex
 	dir   string
-	files map[string]*riofs.File // a map of URI -> ROOT file
+	files map[string]*riofs.File  // a map of URI -> ROOT file
+	hists map[string]hbook.Object // a map of name -> booked histogram
 }
 
 func NewDB(dir string) *DB {
@@ -27,6 +29,7 @@ func NewDB(dir string) *DB {
 	return &DB{
 		dir:   dir,
 		files: make(map[string]*riofs.File),
+		hists: make(map[string]hbook.Object),
 	}
 }
 
@@ -37,6 +40,7 @@ func (db *DB) Close() {
 		f.Close()
 	}
 	db.files = nil
+	db.hists = nil
 	os.RemoveAll(db.dir)
 }
 
@@ -87,3 +91,28 @@ func (db *DB) del(uri string) {
 	f.Close()
 	delete(db.files, uri)
 }
+
+// Hists returns the sorted list of names of the histograms booked in
+// this data base.
+func (db *DB) Hists() []string {
+	db.RLock()
+	defer db.RUnlock()
+	names := make([]string, 0, len(db.hists))
+	for name := range db.hists {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (db *DB) hist(name string) hbook.Object {
+	db.RLock()
+	defer db.RUnlock()
+	return db.hists[name]
+}
+
+func (db *DB) setHist(name string, h hbook.Object) {
+	db.Lock()
+	defer db.Unlock()
+	db.hists[name] = h
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/hbook"
 )
 
 func TestDB(t *testing.T) {
@@ -55,5 +56,25 @@ func TestDB(t *testing.T) {
 		t.Fatalf("expected an error")
 	}
 
+	if got, want := len(db.Hists()), 0; got != want {
+		t.Fatalf("invalid number of histograms. got=%d, want=%d", got, want)
+	}
+
+	h := hbook.NewH1D(10, 0, 10)
+	db.setHist("h1", h)
+	db.setHist("h0", hbook.NewH1D(10, 0, 10))
+
+	if got, want := db.Hists(), []string{"h0", "h1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid list of histograms. got=%v, want=%v", got, want)
+	}
+
+	if got := db.hist("h1"); got != h {
+		t.Fatalf("invalid histogram. got=%v, want=%v", got, h)
+	}
+
+	if got := db.hist("not-there"); got != nil {
+		t.Fatalf("invalid histogram. got=%v, want=nil", got)
+	}
+
 	db.Close()
 }
@@ -7,6 +7,7 @@ package rsrv
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -14,6 +15,7 @@ import (
 	"os"
 	stdpath "path"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -25,6 +27,7 @@ import (
 	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hbook/rootcnv"
 	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/plotutil"
 )
 
 // Ping verifies the connection to the server is alive.
@@ -891,3 +894,1179 @@ func (srv *Server) handlePlotTree(w http.ResponseWriter, r *http.Request) error
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(resp)
 }
+
+// H1Data returns the content of the 1-dim histogram specified by the H1DataRequest:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1"}
+// H1Data replies with a H1DataResponse:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h1",
+//    "h1": {
+//      "type": "TH1D", "name": "h1", "title": "my title", "entries": 42,
+//      "edges": [0, 1, 2], "sumw": [10, 32], "sumw2": [10, 32],
+//      "underflow": {"sumw": 0, "sumw2": 0}, "overflow": {"sumw": 0, "sumw2": 0}
+//    }
+//  }
+func (srv *Server) H1Data(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleH1Data)(w, r)
+}
+
+func (srv *Server) handleH1Data(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var (
+		req  H1DataRequest
+		resp H1DataResponse
+	)
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode data-h1 request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		if f == nil {
+			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
+		}
+
+		obj, err := riofs.Dir(f).Get(req.Dir)
+		if err != nil {
+			return fmt.Errorf("could not find directory %q in file %q: %w", req.Dir, req.URI, err)
+		}
+		dir, ok := obj.(riofs.Directory)
+		if !ok {
+			return fmt.Errorf("rsrv: %q in file %q is not a directory", req.Dir, req.URI)
+		}
+
+		obj, err = dir.Get(req.Obj)
+		if err != nil {
+			return fmt.Errorf("could not find object %q under directory %q in file %q: %w", req.Obj, req.Dir, req.URI, err)
+		}
+
+		robj, ok := obj.(rhist.H1)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a 1-dim histogram (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+
+		resp.URI = req.URI
+		resp.Dir = req.Dir
+		resp.Obj = req.Obj
+		resp.H1 = newH1Data(rootcnv.H1D(robj))
+		resp.H1.Type = robj.Class()
+		resp.H1.Name = robj.Name()
+		resp.H1.Title = robj.Title()
+		resp.H1.Entries = robj.Entries()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// H2Data returns the content of the 2-dim histogram specified by the H2DataRequest:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h2"}
+// H2Data replies with a H2DataResponse:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "h2",
+//    "h2": {
+//      "type": "TH2D", "name": "h2", "title": "my title", "entries": 42,
+//      "xedges": [0, 1, 2], "yedges": [0, 1],
+//      "sumw": [10, 32], "sumw2": [10, 32]
+//    }
+//  }
+func (srv *Server) H2Data(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleH2Data)(w, r)
+}
+
+func (srv *Server) handleH2Data(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var (
+		req  H2DataRequest
+		resp H2DataResponse
+	)
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode data-h2 request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		if f == nil {
+			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
+		}
+
+		obj, err := riofs.Dir(f).Get(req.Dir)
+		if err != nil {
+			return fmt.Errorf("could not find directory %q in file %q: %w", req.Dir, req.URI, err)
+		}
+		dir, ok := obj.(riofs.Directory)
+		if !ok {
+			return fmt.Errorf("rsrv: %q in file %q is not a directory", req.Dir, req.URI)
+		}
+
+		obj, err = dir.Get(req.Obj)
+		if err != nil {
+			return fmt.Errorf("could not find object %q under directory %q in file %q: %w", req.Obj, req.Dir, req.URI, err)
+		}
+
+		robj, ok := obj.(rhist.H2)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a 2-dim histogram (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+
+		resp.URI = req.URI
+		resp.Dir = req.Dir
+		resp.Obj = req.Obj
+		resp.H2 = newH2Data(rootcnv.H2D(robj))
+		resp.H2.Type = robj.Class()
+		resp.H2.Name = robj.Name()
+		resp.H2.Title = robj.Title()
+		resp.H2.Entries = robj.Entries()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// TreeData returns a page of column data of the tree specified by the TreeDataRequest:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree"}
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree",
+//   "vars": ["pt", "eta"], "beg": 10, "end": 1000, "selection": "pt > 20", "limit": 100}
+// TreeData replies with a TreeDataResponse:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree",
+//   "entries": [10, 12, ...],
+//   "columns": [
+//     {"name": "pt", "type": "float64", "data": [25.2, 42.1, ...]},
+//     {"name": "eta", "type": "float64", "data": [0.2, -1.1, ...]}
+//   ],
+//   "next": 125
+//  }
+// The "next" field holds the first entry of the next page, or -1 if there
+// are no more entries to read.
+func (srv *Server) TreeData(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleTreeData)(w, r)
+}
+
+func (srv *Server) handleTreeData(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var (
+		req  TreeDataRequest
+		resp TreeDataResponse
+	)
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode data-tree request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		if f == nil {
+			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
+		}
+
+		obj, err := riofs.Dir(f).Get(req.Dir)
+		if err != nil {
+			return fmt.Errorf("could not find directory %q in file %q: %w", req.Dir, req.URI, err)
+		}
+		dir, ok := obj.(riofs.Directory)
+		if !ok {
+			return fmt.Errorf("rsrv: %q in file %q is not a directory", req.Dir, req.URI)
+		}
+
+		obj, err = dir.Get(req.Obj)
+		if err != nil {
+			return fmt.Errorf("could not find object %q under directory %q in file %q: %w", req.Obj, req.Dir, req.URI, err)
+		}
+
+		tree, ok := obj.(rtree.Tree)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a tree (type=%s)", req.URI, req.Dir, req.Obj, obj.Class())
+		}
+
+		var (
+			beg   = req.Beg
+			end   = req.End
+			limit = req.Limit
+		)
+		if end <= 0 || end > tree.Entries() {
+			end = tree.Entries()
+		}
+		if beg < 0 || beg > end {
+			return fmt.Errorf("rsrv: invalid entry range [%d, %d) for tree %v:%s/%s", req.Beg, req.End, req.URI, req.Dir, req.Obj)
+		}
+		if limit <= 0 {
+			limit = defaultTreeDataLimit
+		}
+
+		rvars, err := treeDataVars(tree, req.Vars)
+		if err != nil {
+			return fmt.Errorf("rsrv: invalid variables for tree %v:%s/%s: %w", req.URI, req.Dir, req.Obj, err)
+		}
+
+		rd, err := rtree.NewReader(tree, rvars, rtree.WithRange(beg, end))
+		if err != nil {
+			return fmt.Errorf("could not create reader for tree %v:%s/%s: %w", req.URI, req.Dir, req.Obj, err)
+		}
+		defer rd.Close()
+
+		sel := func() bool { return true }
+		if req.Selection != "" {
+			form, err := rd.FormulaExpr(req.Selection)
+			if err != nil {
+				return fmt.Errorf("could not create selection %q: %w", req.Selection, err)
+			}
+			fct := form.Func().(func() float64)
+			sel = func() bool { return fct() != 0 }
+		}
+
+		cols := make([]reflect.Value, len(rvars))
+		for i, rvar := range rvars {
+			rt := reflect.TypeOf(rvar.Value).Elem()
+			cols[i] = reflect.MakeSlice(reflect.SliceOf(rt), 0, 0)
+		}
+
+		resp.Entries = []int64{}
+		resp.Next = -1
+		err = rd.Read(func(ctx rtree.RCtx) error {
+			if !sel() {
+				return nil
+			}
+			if int64(len(resp.Entries)) == limit {
+				resp.Next = ctx.Entry
+				return errTreeDataPage
+			}
+			resp.Entries = append(resp.Entries, ctx.Entry)
+			for i, rvar := range rvars {
+				cols[i] = reflect.Append(cols[i], clone(reflect.ValueOf(rvar.Value).Elem()))
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errTreeDataPage) {
+			return fmt.Errorf("could not read tree %v:%s/%s: %w", req.URI, req.Dir, req.Obj, err)
+		}
+
+		resp.URI = req.URI
+		resp.Dir = req.Dir
+		resp.Obj = req.Obj
+		resp.Columns = make([]Column, len(rvars))
+		for i, rvar := range rvars {
+			resp.Columns[i] = Column{
+				Name: rvar.Name,
+				Type: cols[i].Type().Elem().String(),
+				Data: cols[i].Interface(),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+const defaultTreeDataLimit = 1000
+
+var errTreeDataPage = errors.New("rsrv: page full")
+
+// treeDataVars returns the read variables of the tree matching the
+// requested branch names, or all the read variables of the tree if no
+// name was requested.
+func treeDataVars(tree rtree.Tree, names []string) ([]rtree.ReadVar, error) {
+	all := rtree.NewReadVars(tree)
+	if len(names) == 0 {
+		return all, nil
+	}
+
+	rvars := make([]rtree.ReadVar, 0, len(names))
+loop:
+	for _, name := range names {
+		for _, rvar := range all {
+			if rvar.Name == name {
+				rvars = append(rvars, rvar)
+				continue loop
+			}
+		}
+		return nil, fmt.Errorf("no branch named %q", name)
+	}
+	return rvars, nil
+}
+
+// clone returns a copy of v that does not share memory with v.
+func clone(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Slice {
+		return v
+	}
+	o := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(o, v)
+	return o
+}
+
+// StreamerInfos returns the streamer infos of the file specified by the StreamerInfoRequest:
+//  {"uri": "file:///some/file.root"}
+//  {"uri": "file:///some/file.root", "name": "TH1D"}
+// StreamerInfos replies with a StreamerInfoResponse:
+//  {"uri": "file:///some/file.root", "streamer_infos": [
+//    {"name": "TH1D", "version": 2, "checksum": 2097335305, "elements": [
+//      {"name": "TH1", "title": "1-Dim histogram base class", "type": "BASE", "kind": "Base", "size": 0},
+//      {"name": "TArrayD", "title": "Array of doubles", "type": "BASE", "kind": "TArray", "size": 24}
+//    ]}
+//  ]}
+func (srv *Server) StreamerInfos(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleStreamerInfos)(w, r)
+}
+
+func (srv *Server) handleStreamerInfos(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var (
+		req  StreamerInfoRequest
+		resp StreamerInfoResponse
+	)
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode streamer-infos request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	err = db.Tx(req.URI, func(f *riofs.File) error {
+		if f == nil {
+			return fmt.Errorf("rsrv: could not find ROOT file named %q", req.URI)
+		}
+
+		resp.URI = req.URI
+		resp.StreamerInfos = []StreamerInfo{}
+		for _, si := range f.StreamerInfos() {
+			if req.Name != "" && si.Name() != req.Name {
+				continue
+			}
+			o := StreamerInfo{
+				Name:     si.Name(),
+				Title:    si.Title(),
+				Version:  si.ClassVersion(),
+				CheckSum: si.CheckSum(),
+				Elements: make([]StreamerElement, len(si.Elements())),
+			}
+			for i, se := range si.Elements() {
+				o.Elements[i] = StreamerElement{
+					Name:     se.Name(),
+					Title:    se.Title(),
+					Type:     se.TypeName(),
+					Kind:     se.Type().String(),
+					Size:     int(se.Size()),
+					ArrayLen: se.ArrayLen(),
+					ArrayDim: se.ArrayDim(),
+				}
+			}
+			resp.StreamerInfos = append(resp.StreamerInfos, o)
+		}
+
+		if req.Name != "" && len(resp.StreamerInfos) == 0 {
+			return fmt.Errorf("rsrv: no streamer info for %q in file %q", req.Name, req.URI)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// BookH1 books a 1-dim histogram and fills it from the tree specified by
+// the BookH1Request:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree",
+//   "name": "h-pt", "title": "my title", "expr": "pt", "selection": "abs(eta) < 2.5",
+//   "nbins": 100, "xmin": 0, "xmax": 250}
+// The histogram is stored in the user's session under the requested name,
+// replacing any previously booked histogram with the same name.
+// BookH1 replies with a HistResponse:
+//  {"hist": {"name": "h-pt", "type": "H1D", "title": "my title", "entries": 42}}
+func (srv *Server) BookH1(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleBookH1)(w, r)
+}
+
+func (srv *Server) handleBookH1(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req BookH1Request
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode book-h1 request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("rsrv: empty histogram name")
+	case req.NBins <= 0 || req.XMin >= req.XMax:
+		return fmt.Errorf("rsrv: invalid binning for histogram %q (nbins=%d, xmin=%v, xmax=%v)", req.Name, req.NBins, req.XMin, req.XMax)
+	}
+
+	h := hbook.NewH1D(req.NBins, req.XMin, req.XMax)
+	h.Annotation()["name"] = req.Name
+	h.Annotation()["title"] = req.Title
+
+	err = fillFromTree(
+		db, req.URI, req.Dir, req.Obj, req.Beg, req.End,
+		req.Selection, []string{req.Expr},
+		func(vs []float64) { h.Fill(vs[0], 1) },
+	)
+	if err != nil {
+		return fmt.Errorf("could not fill histogram %q: %w", req.Name, err)
+	}
+
+	db.setHist(req.Name, h)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(HistResponse{Hist: newHist(h)})
+}
+
+// BookH2 books a 2-dim histogram and fills it from the tree specified by
+// the BookH2Request:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree",
+//   "name": "h-eta-phi", "x": "eta", "y": "phi", "selection": "pt > 20",
+//   "nx": 50, "xmin": -2.5, "xmax": 2.5, "ny": 64, "ymin": -3.2, "ymax": 3.2}
+// The histogram is stored in the user's session under the requested name,
+// replacing any previously booked histogram with the same name.
+// BookH2 replies with a HistResponse:
+//  {"hist": {"name": "h-eta-phi", "type": "H2D", "entries": 42}}
+func (srv *Server) BookH2(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleBookH2)(w, r)
+}
+
+func (srv *Server) handleBookH2(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req BookH2Request
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode book-h2 request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("rsrv: empty histogram name")
+	case req.NX <= 0 || req.XMin >= req.XMax:
+		return fmt.Errorf("rsrv: invalid x-binning for histogram %q (nx=%d, xmin=%v, xmax=%v)", req.Name, req.NX, req.XMin, req.XMax)
+	case req.NY <= 0 || req.YMin >= req.YMax:
+		return fmt.Errorf("rsrv: invalid y-binning for histogram %q (ny=%d, ymin=%v, ymax=%v)", req.Name, req.NY, req.YMin, req.YMax)
+	}
+
+	h := hbook.NewH2D(req.NX, req.XMin, req.XMax, req.NY, req.YMin, req.YMax)
+	h.Annotation()["name"] = req.Name
+	h.Annotation()["title"] = req.Title
+
+	err = fillFromTree(
+		db, req.URI, req.Dir, req.Obj, req.Beg, req.End,
+		req.Selection, []string{req.X, req.Y},
+		func(vs []float64) { h.Fill(vs[0], vs[1], 1) },
+	)
+	if err != nil {
+		return fmt.Errorf("could not fill histogram %q: %w", req.Name, err)
+	}
+
+	db.setHist(req.Name, h)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(HistResponse{Hist: newHist(h)})
+}
+
+// BookP1 books a 1-dim profile histogram and fills it from the tree
+// specified by the BookP1Request:
+//  {"uri": "file:///some/file.root", "dir": "/some/dir", "obj": "tree",
+//   "name": "p-pt-eta", "x": "eta", "y": "pt", "selection": "pt > 20",
+//   "nbins": 50, "xmin": -2.5, "xmax": 2.5}
+// The profile is stored in the user's session under the requested name,
+// replacing any previously booked histogram with the same name.
+// BookP1 replies with a HistResponse:
+//  {"hist": {"name": "p-pt-eta", "type": "P1D", "entries": 42}}
+func (srv *Server) BookP1(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleBookP1)(w, r)
+}
+
+func (srv *Server) handleBookP1(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req BookP1Request
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode book-p1 request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("rsrv: empty histogram name")
+	case req.NBins <= 0 || req.XMin >= req.XMax:
+		return fmt.Errorf("rsrv: invalid binning for profile %q (nbins=%d, xmin=%v, xmax=%v)", req.Name, req.NBins, req.XMin, req.XMax)
+	}
+
+	p := hbook.NewP1D(req.NBins, req.XMin, req.XMax)
+	p.Annotation()["name"] = req.Name
+	p.Annotation()["title"] = req.Title
+
+	err = fillFromTree(
+		db, req.URI, req.Dir, req.Obj, req.Beg, req.End,
+		req.Selection, []string{req.X, req.Y},
+		func(vs []float64) { p.Fill(vs[0], vs[1], 1) },
+	)
+	if err != nil {
+		return fmt.Errorf("could not fill profile %q: %w", req.Name, err)
+	}
+
+	db.setHist(req.Name, p)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(HistResponse{Hist: newHist(p)})
+}
+
+// fillFromTree evaluates the exprs expressions for all the entries in
+// [beg, end) of the tree specified by uri, dname and oname that pass the
+// sel selection, and calls fill with the resulting values.
+func fillFromTree(db *DB, uri, dname, oname string, beg, end int64, sel string, exprs []string, fill func(vs []float64)) error {
+	for _, expr := range exprs {
+		if strings.TrimSpace(expr) == "" {
+			return fmt.Errorf("rsrv: empty expression")
+		}
+	}
+
+	return db.Tx(uri, func(f *riofs.File) error {
+		if f == nil {
+			return fmt.Errorf("rsrv: could not find ROOT file named %q", uri)
+		}
+
+		obj, err := riofs.Dir(f).Get(dname)
+		if err != nil {
+			return fmt.Errorf("could not find directory %q in file %q: %w", dname, uri, err)
+		}
+		dir, ok := obj.(riofs.Directory)
+		if !ok {
+			return fmt.Errorf("rsrv: %q in file %q is not a directory", dname, uri)
+		}
+
+		obj, err = dir.Get(oname)
+		if err != nil {
+			return fmt.Errorf("could not find object %q under directory %q in file %q: %w", oname, dname, uri, err)
+		}
+
+		tree, ok := obj.(rtree.Tree)
+		if !ok {
+			return fmt.Errorf("rsrv: object %v:%s/%q is not a tree (type=%s)", uri, dname, oname, obj.Class())
+		}
+
+		if end <= 0 || end > tree.Entries() {
+			end = tree.Entries()
+		}
+		if beg < 0 || beg > end {
+			return fmt.Errorf("rsrv: invalid entry range [%d, %d) for tree %v:%s/%s", beg, end, uri, dname, oname)
+		}
+
+		rd, err := rtree.NewReader(tree, nil, rtree.WithRange(beg, end))
+		if err != nil {
+			return fmt.Errorf("could not create reader for tree %v:%s/%s: %w", uri, dname, oname, err)
+		}
+		defer rd.Close()
+
+		accept := func() bool { return true }
+		if sel != "" {
+			form, err := rd.FormulaExpr(sel)
+			if err != nil {
+				return fmt.Errorf("could not create selection %q: %w", sel, err)
+			}
+			fct := form.Func().(func() float64)
+			accept = func() bool { return fct() != 0 }
+		}
+
+		fcts := make([]func() float64, len(exprs))
+		for i, expr := range exprs {
+			form, err := rd.FormulaExpr(expr)
+			if err != nil {
+				return fmt.Errorf("could not create expression %q: %w", expr, err)
+			}
+			fcts[i] = form.Func().(func() float64)
+		}
+
+		vs := make([]float64, len(exprs))
+		err = rd.Read(func(ctx rtree.RCtx) error {
+			if !accept() {
+				return nil
+			}
+			for i, fct := range fcts {
+				vs[i] = fct()
+			}
+			fill(vs)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not read tree %v:%s/%s: %w", uri, dname, oname, err)
+		}
+
+		return rd.Close()
+	})
+}
+
+// ListHists lists all the histograms booked in the user's session.
+// ListHists replies with a ListHistsResponse:
+//  {"hists": [
+//    {"name": "h-eta-phi", "type": "H2D", "entries": 42},
+//    {"name": "h-pt", "type": "H1D", "title": "my title", "entries": 42}
+//  ]}
+func (srv *Server) ListHists(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleListHists)(w, r)
+}
+
+func (srv *Server) handleListHists(w http.ResponseWriter, r *http.Request) error {
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	resp := ListHistsResponse{Hists: []Hist{}}
+	for _, name := range db.Hists() {
+		h := db.hist(name)
+		if h == nil {
+			continue
+		}
+		resp.Hists = append(resp.Hists, newHist(h))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// HistData returns the content of the booked histogram specified by the
+// HistDataRequest:
+//  {"name": "h-pt"}
+// HistData replies with a HistDataResponse, where only the field
+// corresponding to the type of the histogram is set:
+//  {"hist": {"name": "h-pt", "type": "H1D", "entries": 42},
+//   "h1": {"type": "H1D", "name": "h-pt", "entries": 42, "edges": [...], ...}
+//  }
+func (srv *Server) HistData(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleHistData)(w, r)
+}
+
+func (srv *Server) handleHistData(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req HistDataRequest
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode hist-data request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	h := db.hist(req.Name)
+	if h == nil {
+		return fmt.Errorf("rsrv: no histogram named %q", req.Name)
+	}
+
+	resp := HistDataResponse{Hist: newHist(h)}
+	switch h := h.(type) {
+	case *hbook.H1D:
+		data := newH1Data(h)
+		resp.H1 = &data
+	case *hbook.H2D:
+		data := newH2Data(h)
+		resp.H2 = &data
+	case *hbook.P1D:
+		data := newP1Data(h)
+		resp.P1 = &data
+	case *hbook.S2D:
+		data := newS2Data(h)
+		resp.S2 = &data
+	default:
+		return fmt.Errorf("rsrv: histogram %q has an invalid type %T", req.Name, h)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// DivideHists divides two booked 1-dim histograms, as specified by the
+// DivideHistsRequest:
+//  {"name": "eff", "num": "h-pass", "den": "h-all"}
+// The resulting 2-dim scatter is stored in the user's session under the
+// requested name. Bins with a null denominator are skipped.
+// DivideHists replies with a HistResponse:
+//  {"hist": {"name": "eff", "type": "S2D", "title": "h-pass / h-all", "entries": 10}}
+func (srv *Server) DivideHists(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleDivideHists)(w, r)
+}
+
+func (srv *Server) handleDivideHists(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req DivideHistsRequest
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode divide-hists request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	if req.Name == "" {
+		return fmt.Errorf("rsrv: empty histogram name")
+	}
+
+	h1d := func(name string) (*hbook.H1D, error) {
+		h := db.hist(name)
+		if h == nil {
+			return nil, fmt.Errorf("rsrv: no histogram named %q", name)
+		}
+		h1, ok := h.(*hbook.H1D)
+		if !ok {
+			return nil, fmt.Errorf("rsrv: histogram %q is not a 1-dim histogram (type=%s)", name, newHist(h).Type)
+		}
+		return h1, nil
+	}
+
+	num, err := h1d(req.Num)
+	if err != nil {
+		return err
+	}
+	den, err := h1d(req.Den)
+	if err != nil {
+		return err
+	}
+
+	if n, d := len(num.Binning.Bins), len(den.Binning.Bins); n != d {
+		return fmt.Errorf("rsrv: can not divide %q by %q: incompatible number of bins (%d != %d)", req.Num, req.Den, n, d)
+	}
+
+	div, err := hbook.DivideH1D(num, den, hbook.DivIgnoreNaNs())
+	if err != nil {
+		return fmt.Errorf("could not divide %q by %q: %w", req.Num, req.Den, err)
+	}
+
+	s2 := hbook.NewS2D(div.Points()...)
+	s2.Annotation()["name"] = req.Name
+	s2.Annotation()["title"] = req.Num + " / " + req.Den
+
+	db.setHist(req.Name, s2)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(HistResponse{Hist: newHist(s2)})
+}
+
+// PlotHists overlays the booked histograms specified by the PlotHistsRequest:
+//  {"names": ["h-pt-1", "h-pt-2"], "options": {"type": "png", "title": "my plot title"}}
+// 2-dim histograms can not be overlaid with other histograms.
+// PlotHists replies with a PlotResponse, where "data" contains the base64 encoded representation of
+// the plot.
+func (srv *Server) PlotHists(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handlePlotHists)(w, r)
+}
+
+func (srv *Server) handlePlotHists(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var (
+		req  PlotHistsRequest
+		resp PlotResponse
+	)
+
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode plot-hists request: %w", err)
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	if len(req.Names) == 0 {
+		return fmt.Errorf("rsrv: no histogram to plot")
+	}
+
+	req.Options.init()
+
+	pl := hplot.New()
+	pl.X.Label.Text = req.Options.X
+	pl.Y.Label.Text = req.Options.Y
+
+	for i, name := range req.Names {
+		h := db.hist(name)
+		if h == nil {
+			return fmt.Errorf("rsrv: no histogram named %q", name)
+		}
+
+		if i == 0 {
+			pl.Title.Text = newHist(h).Title
+		}
+
+		color := req.Options.Line.Color
+		if i > 0 {
+			color = plotutil.Color(i)
+		}
+
+		switch h := h.(type) {
+		case *hbook.H1D:
+			ph := hplot.NewH1D(h)
+			ph.Color = color
+			ph.FillColor = req.Options.FillColor
+			pl.Add(ph)
+			pl.Legend.Add(name, ph)
+		case *hbook.H2D:
+			if len(req.Names) > 1 {
+				return fmt.Errorf("rsrv: can not overlay 2-dim histogram %q with other histograms", name)
+			}
+			ph := hplot.NewH2D(h, nil)
+			ph.Infos.Style = hplot.HInfoSummary
+			pl.Add(ph)
+		case *hbook.P1D:
+			data := newP1Data(h)
+			pts := make([]hbook.Point2D, len(data.Mean))
+			for j := range pts {
+				var (
+					xmin = data.Edges[j]
+					xmax = data.Edges[j+1]
+					x    = 0.5 * (xmin + xmax)
+				)
+				pts[j] = hbook.Point2D{
+					X:    x,
+					Y:    data.Mean[j],
+					ErrX: hbook.Range{Min: x - xmin, Max: xmax - x},
+					ErrY: hbook.Range{Min: data.StdErr[j], Max: data.StdErr[j]},
+				}
+			}
+			ph := hplot.NewS2D(hbook.NewS2D(pts...), hplot.WithXErrBars(true), hplot.WithYErrBars(true))
+			ph.Color = color
+			pl.Add(ph)
+			pl.Legend.Add(name, ph)
+		case *hbook.S2D:
+			ph := hplot.NewS2D(h, hplot.WithXErrBars(true), hplot.WithYErrBars(true))
+			ph.Color = color
+			pl.Add(ph)
+			pl.Legend.Add(name, ph)
+		default:
+			return fmt.Errorf("rsrv: histogram %q has an invalid type %T", name, h)
+		}
+	}
+	if req.Options.Title != "" {
+		pl.Title.Text = req.Options.Title
+	}
+	pl.Add(hplot.NewGrid())
+
+	out, err := srv.render(pl, req.Options)
+	if err != nil {
+		return fmt.Errorf("could not render histograms plot: %w", err)
+	}
+
+	resp.Obj = strings.Join(req.Names, ",")
+	resp.Data = base64.StdEncoding.EncodeToString(out)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// SaveHists saves booked histograms into a new ROOT file, as specified by
+// the SaveHistsRequest:
+//  {"uri": "upload-store:///my-hists.root", "names": ["h-pt", "eff"]}
+// All the booked histograms are saved if no name is given.
+// The new ROOT file is then available under the requested URI, as if it
+// had been uploaded to the server.
+//
+// SaveHists replies with a StatusConflict if a file with the named file
+// already exists in the remote server.
+// Otherwise, SaveHists replies with a File:
+//  {"uri": "upload-store:///my-hists.root", "version": 61804}
+func (srv *Server) SaveHists(w http.ResponseWriter, r *http.Request) {
+	srv.wrap(srv.handleSaveHists)(w, r)
+}
+
+func (srv *Server) handleSaveHists(w http.ResponseWriter, r *http.Request) error {
+	dec := json.NewDecoder(r.Body)
+	defer r.Body.Close()
+
+	var req SaveHistsRequest
+	err := dec.Decode(&req)
+	if err != nil {
+		return fmt.Errorf("could not decode save-hists request: %w", err)
+	}
+
+	if req.URI == "" {
+		return fmt.Errorf("empty destination for saved ROOT file")
+	}
+
+	db, err := srv.db(r)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file database: %w", err)
+	}
+
+	if f := db.get(req.URI); f != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		return json.NewEncoder(w).Encode(nil)
+	}
+
+	names := req.Names
+	if len(names) == 0 {
+		names = db.Hists()
+	}
+
+	objs := make([]root.Object, len(names))
+	for i, name := range names {
+		h := db.hist(name)
+		if h == nil {
+			return fmt.Errorf("rsrv: no histogram named %q", name)
+		}
+		switch h := h.(type) {
+		case *hbook.H1D:
+			objs[i] = rootcnv.FromH1D(h)
+		case *hbook.H2D:
+			objs[i] = rootcnv.FromH2D(h)
+		case *hbook.P1D:
+			objs[i] = rootcnv.FromP1D(h)
+		case *hbook.S2D:
+			objs[i] = rootcnv.FromS2D(h)
+		default:
+			return fmt.Errorf("rsrv: histogram %q has an invalid type %T", name, h)
+		}
+	}
+
+	fid, err := uuid.GenerateUUID()
+	if err != nil {
+		return fmt.Errorf("could not generate UUID for %q: %w", req.URI, err)
+	}
+
+	fname := filepath.Join(srv.dir, fid+".root")
+	o, err := riofs.Create(fname)
+	if err != nil {
+		return fmt.Errorf("could not create ROOT file: %w", err)
+	}
+	defer o.Close()
+
+	for i, name := range names {
+		err = riofs.Dir(o).Put(name, objs[i])
+		if err != nil {
+			return fmt.Errorf("could not save histogram %q: %w", name, err)
+		}
+	}
+
+	err = o.Close()
+	if err != nil {
+		return fmt.Errorf("could not close ROOT file: %w", err)
+	}
+
+	rfile, err := riofs.Open(fname)
+	if err != nil {
+		return fmt.Errorf("could not open ROOT file %q: %w", req.URI, err)
+	}
+
+	db.set(req.URI, rfile)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(File{URI: req.URI, Version: rfile.Version()})
+}
+
+// newHist returns the description of a booked histogram.
+func newHist(h hbook.Object) Hist {
+	o := Hist{Name: h.Name()}
+	if v, ok := h.Annotation()["title"].(string); ok {
+		o.Title = v
+	}
+	switch h := h.(type) {
+	case *hbook.H1D:
+		o.Type = "H1D"
+		o.Entries = h.Entries()
+	case *hbook.H2D:
+		o.Type = "H2D"
+		o.Entries = h.Entries()
+	case *hbook.P1D:
+		o.Type = "P1D"
+		o.Entries = h.Entries()
+	case *hbook.S2D:
+		o.Type = "S2D"
+		o.Entries = h.Entries()
+	}
+	return o
+}
+
+func newH1Data(h *hbook.H1D) H1Data {
+	hist := newHist(h)
+	bins := h.Binning.Bins
+	o := H1Data{
+		Type:    hist.Type,
+		Name:    hist.Name,
+		Title:   hist.Title,
+		Entries: float64(hist.Entries),
+		Edges:   make([]float64, len(bins)+1),
+		SumW:    make([]float64, len(bins)),
+		SumW2:   make([]float64, len(bins)),
+		Underflow: Outflow{
+			SumW:  h.Binning.Outflows[0].SumW(),
+			SumW2: h.Binning.Outflows[0].SumW2(),
+		},
+		Overflow: Outflow{
+			SumW:  h.Binning.Outflows[1].SumW(),
+			SumW2: h.Binning.Outflows[1].SumW2(),
+		},
+	}
+	for i := range bins {
+		bin := &bins[i]
+		o.Edges[i] = bin.XMin()
+		o.Edges[i+1] = bin.XMax()
+		o.SumW[i] = bin.SumW()
+		o.SumW2[i] = bin.SumW2()
+	}
+	return o
+}
+
+func newH2Data(h *hbook.H2D) H2Data {
+	hist := newHist(h)
+	bng := &h.Binning
+	o := H2Data{
+		Type:    hist.Type,
+		Name:    hist.Name,
+		Title:   hist.Title,
+		Entries: float64(hist.Entries),
+		XEdges:  make([]float64, len(bng.XEdges)+1),
+		YEdges:  make([]float64, len(bng.YEdges)+1),
+		SumW:    make([]float64, len(bng.Bins)),
+		SumW2:   make([]float64, len(bng.Bins)),
+	}
+	for i := range bng.XEdges {
+		bin := &bng.XEdges[i]
+		o.XEdges[i] = bin.XMin()
+		o.XEdges[i+1] = bin.XMax()
+	}
+	for i := range bng.YEdges {
+		bin := &bng.YEdges[i]
+		o.YEdges[i] = bin.XMin()
+		o.YEdges[i+1] = bin.XMax()
+	}
+	for i := range bng.Bins {
+		bin := &bng.Bins[i]
+		o.SumW[i] = bin.SumW()
+		o.SumW2[i] = bin.SumW2()
+	}
+	return o
+}
+
+func newP1Data(p *hbook.P1D) P1Data {
+	hist := newHist(p)
+	bins := p.Binning().Bins()
+	o := P1Data{
+		Type:    hist.Type,
+		Name:    hist.Name,
+		Title:   hist.Title,
+		Entries: float64(hist.Entries),
+		Edges:   make([]float64, len(bins)+1),
+		SumW:    make([]float64, len(bins)),
+		Mean:    make([]float64, len(bins)),
+		StdErr:  make([]float64, len(bins)),
+	}
+	for i := range bins {
+		bin := &bins[i]
+		o.Edges[i] = bin.XMin()
+		o.Edges[i+1] = bin.XMax()
+
+		var (
+			dist = bin.Dist()
+			sumw = dist.SumW()
+		)
+		o.SumW[i] = sumw
+		if sumw == 0 {
+			continue
+		}
+		mean := dist.SumWY() / sumw
+		o.Mean[i] = mean
+		if neff := dist.EffEntries(); neff > 0 {
+			variance := math.Max(0, dist.SumWY2()/sumw-mean*mean)
+			o.StdErr[i] = math.Sqrt(variance / neff)
+		}
+	}
+	return o
+}
+
+func newS2Data(s *hbook.S2D) S2Data {
+	hist := newHist(s)
+	o := S2Data{
+		Type:   hist.Type,
+		Name:   hist.Name,
+		Title:  hist.Title,
+		Points: make([]S2Point, s.Len()),
+	}
+	for i, pt := range s.Points() {
+		o.Points[i] = S2Point{
+			X:    pt.X,
+			Y:    pt.Y,
+			XErr: [2]float64{pt.ErrX.Min, pt.ErrX.Max},
+			YErr: [2]float64{pt.ErrY.Min, pt.ErrY.Max},
+		}
+	}
+	return o
+}
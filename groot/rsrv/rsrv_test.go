@@ -24,8 +24,11 @@ import (
 	"time"
 
 	uuid "github.com/hashicorp/go-uuid"
+	"go-hep.org/x/hep/groot/riofs"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/http"
 	_ "go-hep.org/x/hep/groot/riofs/plugin/xrootd"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hbook/rootcnv"
 	"gonum.org/v1/plot/cmpimg"
 )
 
@@ -58,6 +61,18 @@ func newTestServer() *httptest.Server {
 	mux.HandleFunc("/plot-h2", srv.PlotH2)
 	mux.HandleFunc("/plot-s2", srv.PlotS2)
 	mux.HandleFunc("/plot-tree", srv.PlotTree)
+	mux.HandleFunc("/data-h1", srv.H1Data)
+	mux.HandleFunc("/data-h2", srv.H2Data)
+	mux.HandleFunc("/data-tree", srv.TreeData)
+	mux.HandleFunc("/streamer-infos", srv.StreamerInfos)
+	mux.HandleFunc("/book-h1", srv.BookH1)
+	mux.HandleFunc("/book-h2", srv.BookH2)
+	mux.HandleFunc("/book-p1", srv.BookP1)
+	mux.HandleFunc("/hists", srv.ListHists)
+	mux.HandleFunc("/hist-data", srv.HistData)
+	mux.HandleFunc("/divide-hists", srv.DivideHists)
+	mux.HandleFunc("/plot-hists", srv.PlotHists)
+	mux.HandleFunc("/save-hists", srv.SaveHists)
 
 	return httptest.NewServer(mux)
 }
@@ -1021,3 +1036,455 @@ func setupCookie(srv *Server) {
 	srv.sessions[cookie.Value] = NewDB(filepath.Join(srv.dir, cookie.Value))
 	srv.cookies[cookie.Value] = cookie
 }
+
+func TestH1Data(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	tmp, err := ioutil.TempDir("", "groot-rsrv-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "h1.root")
+	func() {
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create ROOT file: %+v", err)
+		}
+		defer f.Close()
+
+		h := hbook.NewH1D(4, 0, 4)
+		h.Annotation()["name"] = "h1"
+		h.Annotation()["title"] = "my title"
+		h.Fill(-1, 1)
+		h.Fill(0.5, 1)
+		h.Fill(1.5, 2)
+		h.Fill(1.5, 3)
+		h.Fill(10, 4)
+
+		err = riofs.Dir(f).Put("dir/h1", rootcnv.FromH1D(h))
+		if err != nil {
+			t.Fatalf("could not save histogram: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close ROOT file: %+v", err)
+		}
+	}()
+
+	uri := "file://" + fname
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	var resp H1DataResponse
+	testPost(t, ts, "/data-h1", H1DataRequest{URI: uri, Dir: "/dir", Obj: "h1"}, &resp)
+
+	want := H1Data{
+		Type:      "TH1D",
+		Name:      "h1",
+		Title:     "my title",
+		Entries:   5,
+		Edges:     []float64{0, 1, 2, 3, 4},
+		SumW:      []float64{1, 5, 0, 0},
+		SumW2:     []float64{1, 13, 0, 0},
+		Underflow: Outflow{SumW: 1, SumW2: 1},
+		Overflow:  Outflow{SumW: 4, SumW2: 16},
+	}
+	if !reflect.DeepEqual(resp.H1, want) {
+		t.Fatalf("invalid h1 data:\ngot= %+v\nwant=%+v", resp.H1, want)
+	}
+
+	testPostStatus(t, ts, "/data-h1", H1DataRequest{URI: uri, Dir: "/dir", Obj: "h2"}, http.StatusInternalServerError)
+}
+
+func TestH2Data(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	tmp, err := ioutil.TempDir("", "groot-rsrv-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "h2.root")
+	func() {
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create ROOT file: %+v", err)
+		}
+		defer f.Close()
+
+		h := hbook.NewH2D(2, 0, 2, 3, 0, 3)
+		h.Annotation()["name"] = "h2"
+		h.Annotation()["title"] = "my title"
+		h.Fill(0.5, 0.5, 1)
+		h.Fill(1.5, 0.5, 2)
+		h.Fill(1.5, 2.5, 3)
+		h.Fill(1.5, 2.5, 1)
+
+		err = riofs.Dir(f).Put("h2", rootcnv.FromH2D(h))
+		if err != nil {
+			t.Fatalf("could not save histogram: %+v", err)
+		}
+
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close ROOT file: %+v", err)
+		}
+	}()
+
+	uri := "file://" + fname
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	var resp H2DataResponse
+	testPost(t, ts, "/data-h2", H2DataRequest{URI: uri, Obj: "h2"}, &resp)
+
+	want := H2Data{
+		Type:    "TH2D",
+		Name:    "h2",
+		Title:   "my title",
+		Entries: 4,
+		XEdges:  []float64{0, 1, 2},
+		YEdges:  []float64{0, 1, 2, 3},
+		SumW:    []float64{1, 2, 0, 0, 0, 4},
+		SumW2:   []float64{1, 4, 0, 0, 0, 10},
+	}
+	if !reflect.DeepEqual(resp.H2, want) {
+		t.Fatalf("invalid h2 data:\ngot= %+v\nwant=%+v", resp.H2, want)
+	}
+}
+
+func TestTreeData(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	local, err := filepath.Abs("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	uri := "file://" + local
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	for _, tc := range []struct {
+		name    string
+		req     TreeDataRequest
+		entries []int64
+		cols    string
+		next    int64
+	}{
+		{
+			name:    "range",
+			req:     TreeDataRequest{URI: uri, Obj: "tree", Vars: []string{"Int32", "Str", "SliceInt64"}, Beg: 2, End: 5},
+			entries: []int64{2, 3, 4},
+			cols:    `[{"name":"Int32","type":"int32","data":[2,3,4]},{"name":"Str","type":"string","data":["evt-002","evt-003","evt-004"]},{"name":"SliceInt64","type":"[]int64","data":[[2,2],[3,3,3],[4,4,4,4]]}]`,
+			next:    -1,
+		},
+		{
+			name:    "selection-page",
+			req:     TreeDataRequest{URI: uri, Obj: "tree", Vars: []string{"Int32", "ArrayInt32"}, Selection: "Int32 % 20 == 0", Limit: 2},
+			entries: []int64{0, 20},
+			cols:    `[{"name":"Int32","type":"int32","data":[0,20]},{"name":"ArrayInt32","type":"[10]int32","data":[[0,0,0,0,0,0,0,0,0,0],[20,20,20,20,20,20,20,20,20,20]]}]`,
+			next:    40,
+		},
+		{
+			name:    "selection-last-page",
+			req:     TreeDataRequest{URI: uri, Obj: "tree", Vars: []string{"Int32"}, Selection: "Int32 % 20 == 0", Beg: 40, Limit: 3},
+			entries: []int64{40, 60, 80},
+			cols:    `[{"name":"Int32","type":"int32","data":[40,60,80]}]`,
+			next:    -1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp TreeDataResponse
+			testPost(t, ts, "/data-tree", tc.req, &resp)
+
+			if !reflect.DeepEqual(resp.Entries, tc.entries) {
+				t.Fatalf("invalid entries:\ngot= %v\nwant=%v", resp.Entries, tc.entries)
+			}
+
+			cols, err := json.Marshal(resp.Columns)
+			if err != nil {
+				t.Fatalf("could not marshal columns: %+v", err)
+			}
+			if got, want := string(cols), tc.cols; got != want {
+				t.Fatalf("invalid columns:\ngot= %s\nwant=%s", got, want)
+			}
+
+			if got, want := resp.Next, tc.next; got != want {
+				t.Fatalf("invalid next entry: got=%d, want=%d", got, want)
+			}
+		})
+	}
+
+	for _, req := range []TreeDataRequest{
+		{URI: uri, Obj: "tree", Vars: []string{"NotThere"}},
+		{URI: uri, Obj: "tree", Beg: 10, End: 5},
+		{URI: uri, Obj: "tree", Selection: "Int32 >"},
+	} {
+		testPostStatus(t, ts, "/data-tree", req, http.StatusInternalServerError)
+	}
+}
+
+func TestStreamerInfos(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	local, err := filepath.Abs("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	uri := "file://" + local
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	var all StreamerInfoResponse
+	testPost(t, ts, "/streamer-infos", StreamerInfoRequest{URI: uri}, &all)
+	if len(all.StreamerInfos) == 0 {
+		t.Fatalf("no streamer info")
+	}
+
+	var resp StreamerInfoResponse
+	testPost(t, ts, "/streamer-infos", StreamerInfoRequest{URI: uri, Name: "TTree"}, &resp)
+	if got, want := len(resp.StreamerInfos), 1; got != want {
+		t.Fatalf("invalid number of streamer infos: got=%d, want=%d", got, want)
+	}
+
+	si := resp.StreamerInfos[0]
+	if got, want := si.Name, "TTree"; got != want {
+		t.Fatalf("invalid streamer name: got=%q, want=%q", got, want)
+	}
+	if got, want := si.Version, 19; got != want {
+		t.Fatalf("invalid streamer version: got=%d, want=%d", got, want)
+	}
+	if got, want := si.Elements[0], (StreamerElement{
+		Name:  "TNamed",
+		Title: "The basis for a named object (name, title)",
+		Type:  "BASE",
+		Kind:  "TNamed",
+		Size:  0,
+	}); got != want {
+		t.Fatalf("invalid first element:\ngot= %+v\nwant=%+v", got, want)
+	}
+
+	testPostStatus(t, ts, "/streamer-infos", StreamerInfoRequest{URI: uri, Name: "NotThere"}, http.StatusInternalServerError)
+}
+
+func testPost(t *testing.T, ts *httptest.Server, path string, req, resp interface{}) {
+	t.Helper()
+
+	hresp := testPostStatus(t, ts, path, req, http.StatusOK)
+	defer hresp.Body.Close()
+
+	err := json.NewDecoder(hresp.Body).Decode(resp)
+	if err != nil {
+		t.Fatalf("could not decode response: %v", err)
+	}
+}
+
+func testPostStatus(t *testing.T, ts *httptest.Server, path string, req interface{}, status int) *http.Response {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	err := json.NewEncoder(body).Encode(req)
+	if err != nil {
+		t.Fatalf("could not encode request: %v", err)
+	}
+
+	hreq, err := http.NewRequest(http.MethodPost, ts.URL+path, body)
+	if err != nil {
+		t.Fatalf("could not create http request: %v", err)
+	}
+	srv.addCookies(hreq)
+
+	hresp, err := ts.Client().Do(hreq)
+	if err != nil {
+		t.Fatalf("could not post http request: %v", err)
+	}
+
+	if got, want := hresp.StatusCode, status; got != want {
+		hresp.Body.Close()
+		t.Fatalf("invalid status code for %s: got=%v, want=%v", path, got, want)
+	}
+	return hresp
+}
+
+func TestBookHists(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	local, err := filepath.Abs("../testdata/small-flat-tree.root")
+	if err != nil {
+		t.Fatalf("%+v", err)
+	}
+	uri := "file://" + local
+	testOpenFile(t, ts, uri, http.StatusOK)
+	defer testCloseFile(t, ts, uri)
+
+	for _, tc := range []struct {
+		path string
+		req  interface{}
+		want Hist
+	}{
+		{
+			path: "/book-h1",
+			req: BookH1Request{
+				URI: uri, Obj: "tree", Name: "h-all", Title: "all", Expr: "Int32",
+				NBins: 10, XMin: 0, XMax: 100,
+			},
+			want: Hist{Name: "h-all", Type: "H1D", Title: "all", Entries: 100},
+		},
+		{
+			path: "/book-h1",
+			req: BookH1Request{
+				URI: uri, Obj: "tree", Name: "h-sel", Expr: "Int32", Selection: "Int32 < 50",
+				NBins: 10, XMin: 0, XMax: 100,
+			},
+			want: Hist{Name: "h-sel", Type: "H1D", Entries: 50},
+		},
+		{
+			path: "/book-h2",
+			req: BookH2Request{
+				URI: uri, Obj: "tree", Name: "h2", X: "Int32", Y: "Float64", Beg: 10, End: 20,
+				NX: 2, XMin: 0, XMax: 20, NY: 2, YMin: 0, YMax: 20,
+			},
+			want: Hist{Name: "h2", Type: "H2D", Entries: 10},
+		},
+		{
+			path: "/book-p1",
+			req: BookP1Request{
+				URI: uri, Obj: "tree", Name: "p1", X: "Int32", Y: "2*Float64",
+				NBins: 4, XMin: 0, XMax: 100,
+			},
+			want: Hist{Name: "p1", Type: "P1D", Entries: 100},
+		},
+		{
+			path: "/divide-hists",
+			req:  DivideHistsRequest{Name: "eff", Num: "h-sel", Den: "h-all"},
+			want: Hist{Name: "eff", Type: "S2D", Title: "h-sel / h-all", Entries: 10},
+		},
+	} {
+		var resp HistResponse
+		testPost(t, ts, tc.path, tc.req, &resp)
+		if got, want := resp.Hist, tc.want; got != want {
+			t.Fatalf("invalid booked histogram:\ngot= %+v\nwant=%+v", got, want)
+		}
+	}
+
+	var list ListHistsResponse
+	testPost(t, ts, "/hists", nil, &list)
+	if got, want := len(list.Hists), 5; got != want {
+		t.Fatalf("invalid number of booked histograms: got=%d, want=%d", got, want)
+	}
+	for i, want := range []string{"eff", "h-all", "h-sel", "h2", "p1"} {
+		if got := list.Hists[i].Name; got != want {
+			t.Fatalf("invalid histogram name %d: got=%q, want=%q", i, got, want)
+		}
+	}
+
+	{
+		var resp HistDataResponse
+		testPost(t, ts, "/hist-data", HistDataRequest{Name: "h-sel"}, &resp)
+		if resp.H1 == nil || resp.H2 != nil || resp.P1 != nil || resp.S2 != nil {
+			t.Fatalf("invalid hist-data response: %+v", resp)
+		}
+		want := []float64{10, 10, 10, 10, 10, 0, 0, 0, 0, 0}
+		if got := resp.H1.SumW; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid h1 content:\ngot= %v\nwant=%v", got, want)
+		}
+	}
+	{
+		var resp HistDataResponse
+		testPost(t, ts, "/hist-data", HistDataRequest{Name: "h2"}, &resp)
+		if resp.H2 == nil {
+			t.Fatalf("invalid hist-data response: %+v", resp)
+		}
+		want := []float64{0, 0, 0, 10}
+		if got := resp.H2.SumW; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid h2 content:\ngot= %v\nwant=%v", got, want)
+		}
+	}
+	{
+		var resp HistDataResponse
+		testPost(t, ts, "/hist-data", HistDataRequest{Name: "p1"}, &resp)
+		if resp.P1 == nil {
+			t.Fatalf("invalid hist-data response: %+v", resp)
+		}
+		want := []float64{24, 74, 124, 174}
+		if got := resp.P1.Mean; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid p1 content:\ngot= %v\nwant=%v", got, want)
+		}
+	}
+	{
+		var resp HistDataResponse
+		testPost(t, ts, "/hist-data", HistDataRequest{Name: "eff"}, &resp)
+		if resp.S2 == nil {
+			t.Fatalf("invalid hist-data response: %+v", resp)
+		}
+		want := []float64{1, 1, 1, 1, 1, 0, 0, 0, 0, 0}
+		got := make([]float64, len(resp.S2.Points))
+		for i, pt := range resp.S2.Points {
+			got[i] = pt.Y
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid s2 content:\ngot= %v\nwant=%v", got, want)
+		}
+	}
+
+	{
+		var resp PlotResponse
+		testPost(t, ts, "/plot-hists", PlotHistsRequest{
+			Names:   []string{"h-all", "h-sel", "p1", "eff"},
+			Options: PlotOptions{Type: "svg"},
+		}, &resp)
+		if resp.Data == "" {
+			t.Fatalf("empty plot")
+		}
+	}
+
+	const dst = "upload-store:///hists.root"
+	{
+		var resp File
+		testPost(t, ts, "/save-hists", SaveHistsRequest{URI: dst, Names: []string{"h-all", "h2", "p1", "eff"}}, &resp)
+		if got, want := resp.URI, dst; got != want {
+			t.Fatalf("invalid saved file URI: got=%q, want=%q", got, want)
+		}
+
+		defer testCloseFile(t, ts, dst)
+
+		var dirent DirentResponse
+		testPost(t, ts, "/list-dirs", DirentRequest{URI: dst, Dir: "/"}, &dirent)
+		var got []string
+		for _, c := range dirent.Content[1:] { // skip the top-level file.
+			got = append(got, c.Name+":"+c.Type)
+		}
+		want := []string{"h-all:TH1D", "h2:TH2D", "p1:TProfile", "eff:TGraphAsymmErrors"}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid saved file content:\ngot= %q\nwant=%q", got, want)
+		}
+
+		testPostStatus(t, ts, "/save-hists", SaveHistsRequest{URI: dst}, http.StatusConflict)
+	}
+
+	for _, tc := range []struct {
+		path string
+		req  interface{}
+	}{
+		{"/book-h1", BookH1Request{URI: uri, Obj: "tree", Name: "", Expr: "Int32", NBins: 10, XMin: 0, XMax: 100}},
+		{"/book-h1", BookH1Request{URI: uri, Obj: "tree", Name: "h", Expr: "Int32", NBins: 0, XMin: 0, XMax: 100}},
+		{"/book-h1", BookH1Request{URI: uri, Obj: "tree", Name: "h", Expr: "NotThere", NBins: 10, XMin: 0, XMax: 100}},
+		{"/book-h2", BookH2Request{URI: uri, Obj: "tree", Name: "h", X: "Int32", Y: "", NX: 1, XMin: 0, XMax: 1, NY: 1, YMin: 0, YMax: 1}},
+		{"/book-p1", BookP1Request{URI: uri, Obj: "tree", Name: "h", X: "Int32", Y: "Int32", Selection: "Int32 >", NBins: 1, XMin: 0, XMax: 1}},
+		{"/hist-data", HistDataRequest{Name: "not-there"}},
+		{"/divide-hists", DivideHistsRequest{Name: "r", Num: "h2", Den: "h-all"}},
+		{"/plot-hists", PlotHistsRequest{Names: []string{"h-all", "h2"}}},
+		{"/save-hists", SaveHistsRequest{URI: "upload-store:///other.root", Names: []string{"not-there"}}},
+	} {
+		testPostStatus(t, ts, tc.path, tc.req, http.StatusInternalServerError)
+	}
+}
@@ -312,16 +312,33 @@ func (b *Basket) writeFile(f *riofs.File) (totBytes int64, zipBytes int64, err e
 	defer func() {
 		b.header = header
 	}()
+	// the file may have crossed the 2GB limit since this basket was
+	// created: the key header is then larger, and so are the offsets of
+	// the entries in the basket.
+	key := riofs.NewKeyForBasketInternal(f, b.key.Name(), b.key.Title(), b.Class(), int16(b.key.Cycle()))
+	if key.KeyLen() != b.key.KeyLen() {
+		delta := key.KeyLen() - b.key.KeyLen()
+		if len(b.offsets) > 0 {
+			for i := range b.offsets[:b.nevbuf] {
+				b.offsets[i] += delta
+			}
+		}
+		b.key = key
+	}
 	b.last = int(int64(b.key.KeyLen()) + b.wbuf.Len())
 	if b.offsets != nil {
 		b.wbuf.WriteI32(int32(b.nevbuf + 1))
 		b.wbuf.WriteFastArrayI32(b.offsets[:b.nevbuf])
 		b.wbuf.WriteI32(0)
 	}
+	keylen := b.key.KeyLen()
 	b.key, err = riofs.NewKey(nil, b.key.Name(), b.key.Title(), b.Class(), int16(b.key.Cycle()), b.wbuf.Bytes(), f)
 	if err != nil {
 		return 0, 0, fmt.Errorf("rtree: could not create basket-key: %w", err)
 	}
+	if b.key.KeyLen() != keylen {
+		return 0, 0, fmt.Errorf("rtree: invalid basket-key length (got=%d, want=%d)", b.key.KeyLen(), keylen)
+	}
 
 	nbytes := b.key.KeyLen() + b.key.ObjLen()
 	buf := rbytes.NewWBuffer(make([]byte, nbytes), nil, uint32(b.key.KeyLen()), f)
@@ -9,6 +9,7 @@ import (
 	"io"
 	"runtime"
 
+	"go-hep.org/x/hep/groot/rbytes"
 	"go-hep.org/x/hep/groot/riofs"
 )
 
@@ -25,6 +26,7 @@ type bkreader struct {
 	n      int           // number of in-flight baskets
 	cur    *rbasket      // current buffer being served
 	closed chan struct{} // channel is closed when the async reader shuts down
+	raw    [][]byte      // on-disk content of the current batch of baskets
 
 	name string
 }
@@ -47,7 +49,7 @@ func newBkReader(b Branch, n int, beg, end int64) *bkreader {
 	}
 	bkr := &bkreader{
 		f:      b.getTree().f,
-		spans:  make([]rspan, len(base.basketSeek)),
+		spans:  rspansOf(b),
 		beg:    beg,
 		end:    end,
 		ready:  make(chan bkReq, n),
@@ -58,40 +60,10 @@ func newBkReader(b Branch, n int, beg, end int64) *bkreader {
 		name:   b.Name(),
 	}
 
-	for i, seek := range base.basketSeek {
-		bkr.spans[i] = rspan{
-			pos: seek,
-			sz:  base.basketBytes[i],
-			beg: base.basketEntry[i],
-			end: base.basketEntry[i+1],
-		}
-	}
-
 	for i := 0; i < n; i++ {
 		bkr.reuse <- bkReq{bkt: new(rbasket), err: nil}
 	}
 
-	switch {
-	case base.entries == base.basketEntry[len(base.basketSeek)]:
-		// ok, normal case.
-	default: // recover baskets
-		var beg int64
-		if len(bkr.spans) > 0 {
-			beg = bkr.spans[len(bkr.spans)-1].end
-		}
-		for i := range base.baskets {
-			bkt := &base.baskets[i]
-			span := rspan{
-				pos: 0,
-				beg: beg,
-				end: beg + int64(bkt.nevbuf),
-				bkt: bkt,
-			}
-			bkr.spans = append(bkr.spans, span)
-			beg = span.end
-		}
-	}
-
 	all := rspan{
 		beg: bkr.spans[0].beg,
 		end: bkr.spans[len(bkr.spans)-1].end,
@@ -117,6 +89,44 @@ func newBkReader(b Branch, n int, beg, end int64) *bkreader {
 	return bkr
 }
 
+// rspansOf returns the spans of entries held by the baskets of the
+// provided branch, including the recovered baskets.
+func rspansOf(b Branch) []rspan {
+	base := asBranch(b)
+	spans := make([]rspan, len(base.basketSeek))
+	for i, seek := range base.basketSeek {
+		spans[i] = rspan{
+			pos: seek,
+			sz:  base.basketBytes[i],
+			beg: base.basketEntry[i],
+			end: base.basketEntry[i+1],
+		}
+	}
+
+	switch {
+	case base.entries == base.basketEntry[len(base.basketSeek)]:
+		// ok, normal case.
+	default: // recover baskets
+		var beg int64
+		if len(spans) > 0 {
+			beg = spans[len(spans)-1].end
+		}
+		for i := range base.baskets {
+			bkt := &base.baskets[i]
+			span := rspan{
+				pos: 0,
+				beg: beg,
+				end: beg + int64(bkt.nevbuf),
+				bkt: bkt,
+			}
+			spans = append(spans, span)
+			beg = span.end
+		}
+	}
+
+	return spans
+}
+
 func (bkr *bkreader) findBaskets(beg, end int64) (int, int) {
 	var (
 		ibeg = -1
@@ -140,10 +150,18 @@ func (bkr *bkreader) findBaskets(beg, end int64) (int, int) {
 func (bkr *bkreader) run(eoff, beg, end int) {
 	defer close(bkr.closed)
 	defer close(bkr.ready)
-	for i, span := range bkr.spans[beg:end] {
+	spans := bkr.spans[beg:end]
+	for i, span := range spans {
+		if i%bkr.n == 0 {
+			j := i + bkr.n
+			if j > len(spans) {
+				j = len(spans)
+			}
+			bkr.prefetch(spans[i:j])
+		}
 		select {
 		case tok := <-bkr.reuse:
-			tok.err = tok.bkt.inflate(bkr.name, beg+i, span, eoff, bkr.f)
+			tok.err = tok.bkt.inflate(bkr.name, beg+i, span, eoff, bkr.f, bkr.raw[i%bkr.n])
 			bkr.ready <- tok
 		case <-bkr.exit:
 			return
@@ -151,6 +169,35 @@ func (bkr *bkreader) run(eoff, beg, end int) {
 	}
 }
 
+// prefetch reads the on-disk content of the provided batch of baskets
+// with a single vectored read, so remote files may coalesce these reads
+// into fewer requests.
+// Baskets that could not be prefetched are read one by one during inflation.
+func (bkr *bkreader) prefetch(spans []rspan) {
+	if len(bkr.raw) != bkr.n {
+		bkr.raw = make([][]byte, bkr.n)
+	}
+	chunks := make([]riofs.Chunk, 0, len(spans))
+	for i, span := range spans {
+		bkr.raw[i] = bkr.raw[i][:0]
+		if span.sz == 0 {
+			continue
+		}
+		bkr.raw[i] = rbytes.ResizeU8(bkr.raw[i], int(span.sz))
+		chunks = append(chunks, riofs.Chunk{Off: span.pos, Buf: bkr.raw[i]})
+	}
+	if len(chunks) == 0 {
+		return
+	}
+
+	err := bkr.f.ReadAtv(chunks)
+	if err != nil {
+		for i := range bkr.raw {
+			bkr.raw[i] = bkr.raw[i][:0]
+		}
+	}
+}
+
 func (bkr *bkreader) read() (*rbasket, error) {
 	if bkr.cur != nil {
 		bkr.cur.reset()
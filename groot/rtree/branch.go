@@ -14,6 +14,7 @@ import (
 	"go-hep.org/x/hep/groot/rcont"
 	"go-hep.org/x/hep/groot/rdict"
 	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rmeta"
 	"go-hep.org/x/hep/groot/root"
 	"go-hep.org/x/hep/groot/rtypes"
 	"go-hep.org/x/hep/groot/rvers"
@@ -60,31 +61,17 @@ type tbranch struct {
 }
 
 func newBranchFromWVar(w *wtree, name string, wvar WriteVar, parent Branch, lvl int, cfg wopt) (Branch, error) {
-	base := &tbranch{
-		named:    *rbase.NewNamed(name, ""),
-		attfill:  *rbase.NewAttFill(),
-		compress: int(cfg.compress),
-
-		iobits:      w.ttree.iobits,
-		basketSize:  int(cfg.bufsize),
-		maxBaskets:  defaultMaxBaskets,
-		basketBytes: make([]int32, 0, defaultMaxBaskets),
-		basketEntry: make([]int64, 1, defaultMaxBaskets),
-		basketSeek:  make([]int64, 0, defaultMaxBaskets),
-
-		tree: &w.ttree,
-		btop: btopOf(parent),
-		bup:  parent,
-		dir:  w.dir,
-	}
+	base := newBranchBase(w, name, parent, cfg)
 
 	var (
-		b Branch = base
-
 		title = new(strings.Builder)
 		rt    = reflect.TypeOf(wvar.Value).Elem()
 	)
 
+	if wvar.Unordered && rt.Kind() != reflect.Map {
+		return nil, fmt.Errorf("rtree: invalid type %v for unordered write-var %q", rt, wvar.Name)
+	}
+
 	title.WriteString(wvar.Name)
 	switch k := rt.Kind(); k {
 	case reflect.Array:
@@ -96,7 +83,8 @@ func newBranchFromWVar(w *wtree, name string, wvar WriteVar, parent Branch, lvl
 
 	case reflect.Slice:
 		if wvar.Count == "" {
-			return nil, fmt.Errorf("rtree: empty name for count-leaf of slice %q", wvar.Name)
+			// no count-leaf: stream the slice as a std::vector<T>.
+			return newBranchElementFromWVar(w, base, wvar, cfg)
 		}
 		fmt.Fprintf(title, "[%s]", wvar.Count)
 		rt = rt.Elem()
@@ -105,30 +93,14 @@ func newBranchFromWVar(w *wtree, name string, wvar WriteVar, parent Branch, lvl
 	case reflect.String:
 		base.entryOffsetLen = 1000 // string, so we need an offset array
 
-	case reflect.Struct:
-		return newBranchElementFromWVar(w, base, wvar, parent, lvl, cfg)
-	}
-
-	isBranchElem := false
-	if parent != nil {
-		if parent, ok := parent.(*tbranchElement); ok {
-			isBranchElem = true
-			be := &tbranchElement{
-				tbranch: *base,
-				class:   parent.class,
-				parent:  parent.class,
-			}
-			b = be
-			base = &be.tbranch
-		}
+	case reflect.Struct, reflect.Map, reflect.Ptr:
+		return newBranchElementFromWVar(w, base, wvar, cfg)
 	}
 
-	if !isBranchElem && rt.Kind() != reflect.Struct {
-		code := gotypeToROOTTypeCode(rt)
-		fmt.Fprintf(title, "/%s", code)
-	}
+	code := gotypeToROOTTypeCode(rt)
+	fmt.Fprintf(title, "/%s", code)
 
-	_, err := newLeafFromWVar(w, b, wvar, lvl, cfg)
+	_, err := newLeafFromWVar(w, base, wvar, lvl, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +108,27 @@ func newBranchFromWVar(w *wtree, name string, wvar WriteVar, parent Branch, lvl
 	base.named.SetTitle(title.String())
 	base.createNewBasket()
 
-	return b, nil
+	return base, nil
+}
+
+func newBranchBase(w *wtree, name string, parent Branch, cfg wopt) *tbranch {
+	return &tbranch{
+		named:    *rbase.NewNamed(name, ""),
+		attfill:  *rbase.NewAttFill(),
+		compress: int(cfg.compress),
+
+		iobits:      w.ttree.iobits,
+		basketSize:  int(cfg.bufsize),
+		maxBaskets:  defaultMaxBaskets,
+		basketBytes: make([]int32, 0, defaultMaxBaskets),
+		basketEntry: make([]int64, 1, defaultMaxBaskets),
+		basketSeek:  make([]int64, 0, defaultMaxBaskets),
+
+		tree: &w.ttree,
+		btop: btopOf(parent),
+		bup:  parent,
+		dir:  w.dir,
+	}
 }
 
 func (b *tbranch) RVersion() int16 {
@@ -758,15 +750,48 @@ func (b *tbranch) setAddress(ptr interface{}) error {
 					return fmt.Errorf("rtree: could not set address for leaf[%d][%s]: %w", i, leaf.Name(), err)
 				}
 			}
+		case reflect.Map:
+			if rt.Key().Kind() != reflect.String {
+				return fmt.Errorf("rtree: multi-leaf branches need a map with string keys (got=%T)", ptr)
+			}
+			for i, leaf := range b.leaves {
+				fv := rv.MapIndex(reflect.ValueOf(leaf.Name()))
+				if !fv.IsValid() {
+					return fmt.Errorf("rtree: no value for leaf[%d][%s] in map (name=%q)", i, leaf.Name(), b.Name())
+				}
+				err := setLeafAddress(leaf, fv)
+				if err != nil {
+					return fmt.Errorf("rtree: could not set address for leaf[%d][%s]: %w", i, leaf.Name(), err)
+				}
+			}
+		case reflect.Slice:
+			if len(b.leaves) != rv.Len() {
+				return fmt.Errorf("rtree: values/leaves number mismatch (name=%q, values=%d, leaves=%d)", b.Name(), rv.Len(), len(b.leaves))
+			}
+			for i, leaf := range b.leaves {
+				err := setLeafAddress(leaf, rv.Index(i))
+				if err != nil {
+					return fmt.Errorf("rtree: could not set address for leaf[%d][%s]: %w", i, leaf.Name(), err)
+				}
+			}
 		default:
-			// TODO(sbinet): also support map[string]*T ?
-			// TODO(sbinet): also support []*T ?
-			return fmt.Errorf("rtree: multi-leaf branches need a pointer-to-struct (got=%T)", ptr)
+			return fmt.Errorf("rtree: multi-leaf branches need a pointer-to-struct, a map or a slice of pointers (got=%T)", ptr)
 		}
 	}
 	return nil
 }
 
+// setLeafAddress sets the address of the leaf to the provided pointer value.
+func setLeafAddress(leaf Leaf, rv reflect.Value) error {
+	if rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("rtree: invalid address %v, need a non-nil pointer", rv)
+	}
+	return leaf.setAddress(rv.Interface())
+}
+
 func (b *tbranch) setStreamer(s rbytes.StreamerInfo, ctx rbytes.StreamerInfoContext) {
 	// no op
 }
@@ -776,11 +801,10 @@ func (b *tbranch) setStreamerElement(s rbytes.StreamerElement, ctx rbytes.Stream
 }
 
 func (b *tbranch) createNewBasket() {
-	b.writeBasket = len(b.baskets)
 	cycle := int16(b.writeBasket)
 	b.baskets = append(b.baskets, newBasketFrom(b.tree, b, cycle, b.basketSize, b.entryOffsetLen))
-	b.ctx.bk = &b.baskets[b.writeBasket]
-	if n := len(b.baskets); n > b.maxBaskets {
+	b.ctx.bk = &b.baskets[len(b.baskets)-1]
+	if n := b.writeBasket + 1; n > b.maxBaskets {
 		b.maxBaskets = n
 	}
 }
@@ -803,7 +827,7 @@ func (b *tbranch) write() (int, error) {
 
 	// FIXME(sbinet): harmonize or drive via "auto-flush" ?
 	if szNew+int64(n) >= int64(b.basketSize) {
-		err = b.flush()
+		err = b.flushBasket()
 		if err != nil {
 			return n, fmt.Errorf("could not flush branch (auto-flush): %w", err)
 		}
@@ -833,6 +857,12 @@ func (b *tbranch) flush() error {
 		}
 	}
 
+	return b.flushBasket()
+}
+
+// flushBasket writes the current basket of this branch to file.
+// Sub-branches are not flushed.
+func (b *tbranch) flushBasket() error {
 	f := b.tree.getFile()
 	totBytes, zipBytes, err := b.ctx.bk.writeFile(f)
 	if err != nil {
@@ -871,43 +901,301 @@ type tbranchElement struct {
 	scanfct   func(b *tbranchElement, ptr interface{}) error
 }
 
-func newBranchElementFromWVar(w *wtree, base *tbranch, wvar WriteVar, parent Branch, lvl int, cfg wopt) (Branch, error) {
+func newBranchElementFromWVar(w *wtree, base *tbranch, wvar WriteVar, cfg wopt) (Branch, error) {
 	var (
-		rv       = reflect.ValueOf(wvar.Value)
-		streamer = rdict.StreamerOf(w.ttree.f, reflect.Indirect(rv).Type())
-		pclass   = ""
+		f  = w.ttree.f
+		rv = reflect.ValueOf(wvar.Value).Elem()
+		rt = rv.Type()
 	)
 
-	if parent, ok := parent.(*tbranchElement); ok {
-		pclass = parent.class
+	var (
+		si  rbytes.StreamerInfo
+		err error
+	)
+	switch {
+	case wvar.Unordered:
+		si, err = unorderedInfoOf(f, rt)
+	default:
+		si, err = streamerInfoOf(f, rt)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create streamer for write-var %q: %w", wvar.Name, err)
 	}
 
 	b := &tbranchElement{
 		tbranch:  *base,
-		class:    streamer.Name(),
-		parent:   pclass,
-		chksum:   uint32(streamer.CheckSum()),
-		clsver:   uint16(streamer.ClassVersion()),
-		streamer: streamer,
+		class:    si.Name(),
+		chksum:   uint32(si.CheckSum()),
+		clsver:   uint16(si.ClassVersion()),
+		id:       -1,
+		stype:    -1,
+		streamer: si,
 	}
-	w.ttree.f.RegisterStreamer(b.streamer)
+	b.named.SetTitle(wvar.Name)
+	b.entryOffsetLen = 1000
+	b.splitLevel = int(cfg.splitlvl)
+
+	leaf := newLeafElement(b, wvar.Name, nil, -1, -1, nil)
+	leaf.src = rv
+
+	switch {
+	case rt.Kind() == reflect.Struct && cfg.splitlvl > 0:
+		// the data members are written by the sub-branches.
+		b.id = -2
+		leaf.id = -2
+		b.addLeaf(w, leaf)
 
-	_, err := newLeafFromWVar(w, b, wvar, lvl, cfg)
+		err = b.split(w, si, rv, "", cfg)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not split write-var %q: %w", wvar.Name, err)
+		}
+
+	case cfg.splitlvl > 0 && isSplitCollection(f, rt):
+		// the elements of the collection are written member-wise
+		// by the sub-branches.
+		err = b.splitCollection(w, rv, wvar.Name, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not split write-var %q: %w", wvar.Name, err)
+		}
+
+	default:
+		var wfunc wstreamerFunc
+		switch indirectType(rt).Kind() {
+		case reflect.Struct:
+			wfunc, err = wstreamerOf(f, si, rt)
+		default:
+			wfunc, err = wstreamerFrom(f, si.Elements()[0], rt)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not create streamer for write-var %q: %w", wvar.Name, err)
+		}
+		leaf.wstreamer = &wstreamerImpl{rv: rv, funcs: []wstreamerFunc{wfunc}}
+		b.addLeaf(w, leaf)
+	}
+
+	b.createNewBasket()
+	return b, nil
+}
+
+// split creates the sub-branches of b, one for each data member described
+// by the provided StreamerInfo.
+// Data members that are structs are split recursively.
+func (b *tbranchElement) split(w *wtree, si rbytes.StreamerInfo, rv reflect.Value, prefix string, cfg wopt) error {
+	var (
+		f  = w.ttree.f
+		rt = rv.Type()
+	)
+
+	for i, se := range si.Elements() {
+		field := fieldOf(rt, se.Name())
+		if field < 0 {
+			return fmt.Errorf("rtree: no such field %q in type %v", se.Name(), rt)
+		}
+
+		var (
+			name = prefix + se.Name()
+			sub  = &tbranchElement{
+				tbranch:   *newBranchBase(w, name, b, cfg),
+				class:     si.Name(),
+				parent:    si.Name(),
+				chksum:    uint32(si.CheckSum()),
+				clsver:    uint16(si.ClassVersion()),
+				id:        int32(i),
+				stype:     int32(se.Type()),
+				streamer:  si,
+				estreamer: se,
+			}
+			shape []int
+			count leafCount
+		)
+		sub.named.SetTitle(name)
+		sub.splitLevel = b.splitLevel - 1
+
+		switch se := se.(type) {
+		case *rdict.StreamerObjectAny:
+			if rt.Field(field).Type.Kind() == reflect.Ptr {
+				// pointees may change from entry to entry: stream the
+				// pointed-at value in a single branch.
+				sub.splitLevel = 0
+				sub.entryOffsetLen = 400
+				break
+			}
+			esi, err := streamerInfoOf(f, rt.Field(field).Type)
+			if err != nil {
+				return err
+			}
+			sub.btype = 2
+			err = sub.split(w, esi, rv.Field(field), name+".", cfg)
+			if err != nil {
+				return err
+			}
+			sub.createNewBasket()
+			b.branches = append(b.branches, sub)
+			continue
+
+		case *rdict.StreamerBasicType:
+			if n := se.ArrayLen(); n > 0 {
+				shape = []int{n}
+				sub.named = *rbase.NewNamed(fmt.Sprintf("%s[%d]", name, n), fmt.Sprintf("%s[%d]", name, n))
+				sub.entryOffsetLen = 400
+			}
+
+		case *rdict.StreamerBasicPointer:
+			cbr, ok := b.Branch(prefix + se.CountName()).(*tbranchElement)
+			if !ok || len(cbr.leaves) != 1 {
+				return fmt.Errorf("rtree: could not find count branch %q for %q", prefix+se.CountName(), name)
+			}
+			sub.bcount1 = cbr
+			sub.named.SetTitle(fmt.Sprintf("%s[%s]", name, cbr.leaves[0].Name()))
+			sub.entryOffsetLen = 400
+			count = cbr.leaves[0].(leafCount)
+
+		case *rdict.StreamerString:
+			sub.splitLevel = 0
+			sub.entryOffsetLen = 400
+
+		case *rdict.StreamerSTL:
+			sub.stype = int32(rmeta.STL)
+			if ft := rt.Field(field).Type; sub.splitLevel > 0 && isSplitCollection(f, ft) {
+				err := sub.splitCollection(w, rv.Field(field), name, cfg)
+				if err != nil {
+					return err
+				}
+				sub.createNewBasket()
+				b.branches = append(b.branches, sub)
+				continue
+			}
+			sub.splitLevel = 0
+			sub.entryOffsetLen = 400
+		}
+
+		wfunc, err := wstreamerFrom(f, se, rt)
+		if err != nil {
+			return err
+		}
+
+		leaf := newLeafElement(sub, name, shape, sub.id, sub.stype, count)
+		leaf.src = rv.Field(field)
+		leaf.wstreamer = &wstreamerImpl{rv: rv, funcs: []wstreamerFunc{wfunc}}
+		sub.addLeaf(w, leaf)
+
+		sub.createNewBasket()
+		b.branches = append(b.branches, sub)
+	}
+
+	return nil
+}
+
+// isSplitCollection returns whether values of the provided type are written
+// as split collections: slices of structs whose data members are all
+// basic types, arrays of basic types or strings.
+func isSplitCollection(f *riofs.File, rt reflect.Type) bool {
+	if rt.Kind() != reflect.Slice || rt.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	esi, err := streamerInfoOf(f, rt.Elem())
+	if err != nil || len(esi.Elements()) == 0 {
+		return false
+	}
+	for _, se := range esi.Elements() {
+		switch se.(type) {
+		case *rdict.StreamerBasicType, *rdict.StreamerString:
+			// ok.
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// splitCollection creates the leaf-count of the std::vector<T> branch b,
+// holding the number of elements of the collection, and the sub-branches
+// of b, one for each data member of T.
+// As ROOT does, the sub-branches are named "name.member" and the leaf-count
+// is named "name_".
+func (b *tbranchElement) splitCollection(w *wtree, rv reflect.Value, name string, cfg wopt) error {
+	var (
+		f  = w.ttree.f
+		et = rv.Type().Elem()
+	)
+
+	esi, err := streamerInfoOf(f, et)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	wvars := WriteVarsFromStruct(rv.Interface())
-	for _, wvar := range wvars {
-		sub, err := newBranchFromWVar(w, wvar.Name, wvar, b, lvl+1, cfg)
+
+	b.btype = 4
+	b.stltyp = int32(rmeta.STLvector)
+	b.clones = esi.Name()
+
+	count := newLeafElement(b, name+"_", nil, b.id, b.stype, nil)
+	count.src = rv
+	count.wstreamer = &wstreamerImpl{rv: rv, funcs: []wstreamerFunc{
+		func(w *rbytes.WBuffer, rv reflect.Value) error {
+			w.WriteI32(int32(rv.Len()))
+			return w.Err()
+		},
+	}}
+	b.addLeaf(w, count)
+
+	for i, se := range esi.Elements() {
+		var (
+			sname = name + "." + se.Name()
+			sub   = &tbranchElement{
+				tbranch:   *newBranchBase(w, sname, b, cfg),
+				class:     esi.Name(),
+				parent:    esi.Name(),
+				chksum:    uint32(esi.CheckSum()),
+				clsver:    uint16(esi.ClassVersion()),
+				id:        int32(i),
+				btype:     41,
+				stype:     int32(se.Type()),
+				bcount1:   b,
+				streamer:  esi,
+				estreamer: se,
+			}
+			shape []int
+		)
+		sub.named.SetTitle(sname)
+		sub.splitLevel = b.splitLevel - 1
+		sub.entryOffsetLen = 400
+
+		if se, ok := se.(*rdict.StreamerBasicType); ok && se.ArrayLen() > 0 {
+			n := se.ArrayLen()
+			shape = []int{n}
+			sub.named = *rbase.NewNamed(fmt.Sprintf("%s[%d]", sname, n), fmt.Sprintf("%s[%d]", sname, n))
+		}
+
+		wfunc, err := wstreamerFrom(f, se, et)
 		if err != nil {
-			return nil, fmt.Errorf("rtree: could not create sub-branch for write-var %#v: %w", wvar, err)
+			return err
 		}
+
+		leaf := newLeafElement(sub, sname, shape, sub.id, sub.stype, count)
+		leaf.src = rv
+		leaf.wstreamer = &wstreamerImpl{rv: rv, funcs: []wstreamerFunc{
+			func(w *rbytes.WBuffer, rv reflect.Value) error {
+				for i := 0; i < rv.Len(); i++ {
+					err := wfunc(w, rv.Index(i))
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}}
+		sub.addLeaf(w, leaf)
+
+		sub.createNewBasket()
 		b.branches = append(b.branches, sub)
 	}
 
-	b.named.SetTitle(wvar.Name)
-	b.createNewBasket()
-	return b, nil
+	return nil
+}
+
+func (b *tbranchElement) addLeaf(w *wtree, leaf Leaf) {
+	b.leaves = append(b.leaves, leaf)
+	w.ttree.leaves = append(w.ttree.leaves, leaf)
 }
 
 func (b *tbranchElement) RVersion() int16 {
@@ -1162,8 +1450,31 @@ func (b *tbranchElement) setStreamerElement(se rbytes.StreamerElement, ctx rbyte
 	}
 }
 
-func (b *tbranchElement) write() (int, error)                          { panic("not implemented") }
-func (b *tbranchElement) writeToBuffer(w *rbytes.WBuffer) (int, error) { panic("not implemented") }
+func (b *tbranchElement) write() (int, error) {
+	n, err := b.tbranch.write()
+	if err != nil {
+		return n, err
+	}
+
+	if b.stype == int32(rmeta.Counter) || b.btype == 4 {
+		if v := int32(b.leaves[0].(*tleafElement).ivalue()); v > b.max {
+			b.max = v
+		}
+	}
+
+	for i, sub := range b.branches {
+		nn, err := sub.write()
+		n += nn
+		if err != nil {
+			return n, fmt.Errorf("could not write sub-branch[%d]=%q of branch %q: %w", i, sub.Name(), b.Name(), err)
+		}
+	}
+	return n, nil
+}
+
+func (b *tbranchElement) writeToBuffer(w *rbytes.WBuffer) (int, error) {
+	return b.tbranch.writeToBuffer(w)
+}
 
 func btopOf(b Branch) Branch {
 	if b == nil {
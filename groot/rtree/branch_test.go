@@ -81,11 +81,22 @@ func TestBranchSetAddress(t *testing.T) {
 					&LeafF{},
 				},
 			},
+			ptr: new(int32),
+			err: fmt.Errorf("rtree: multi-leaf branches need a pointer-to-struct, a map or a slice of pointers (got=%s)", "*int32"),
+		},
+		{
+			name: "slice-of-ptrs",
+			b: &tbranch{
+				named: *rbase.NewNamed("branch", "branch"),
+				leaves: []Leaf{
+					&LeafI{},
+					&LeafF{},
+				},
+			},
 			ptr: []interface{}{new(int32), new(float32)},
-			err: fmt.Errorf("rtree: multi-leaf branches need a pointer-to-struct (got=%s)", "[]interface {}"),
 		},
 		{
-			name: "not-a-struct",
+			name: "ptr-to-slice-of-ptrs",
 			b: &tbranch{
 				named: *rbase.NewNamed("branch", "branch"),
 				leaves: []Leaf{
@@ -94,7 +105,54 @@ func TestBranchSetAddress(t *testing.T) {
 				},
 			},
 			ptr: &[]interface{}{new(int32), new(float32)},
-			err: fmt.Errorf("rtree: multi-leaf branches need a pointer-to-struct (got=%s)", "*[]interface {}"),
+		},
+		{
+			name: "slice-of-ptrs-mismatch",
+			b: &tbranch{
+				named: *rbase.NewNamed("branch", "branch"),
+				leaves: []Leaf{
+					&LeafI{},
+					&LeafF{},
+				},
+			},
+			ptr: []interface{}{new(int32)},
+			err: fmt.Errorf("rtree: values/leaves number mismatch (name=%q, values=1, leaves=2)", "branch"),
+		},
+		{
+			name: "slice-of-values",
+			b: &tbranch{
+				named: *rbase.NewNamed("branch", "branch"),
+				leaves: []Leaf{
+					&LeafI{},
+					&LeafF{},
+				},
+			},
+			ptr: []int32{1, 2},
+			err: fmt.Errorf("rtree: could not set address for leaf[0][]: rtree: invalid address 1, need a non-nil pointer"),
+		},
+		{
+			name: "map-with-int-keys",
+			b: &tbranch{
+				named: *rbase.NewNamed("branch", "branch"),
+				leaves: []Leaf{
+					&LeafI{},
+					&LeafF{},
+				},
+			},
+			ptr: map[int]*int32{},
+			err: fmt.Errorf("rtree: multi-leaf branches need a map with string keys (got=%s)", "map[int]*int32"),
+		},
+		{
+			name: "map-of-ptrs-missing",
+			b: &tbranch{
+				named: *rbase.NewNamed("branch", "branch"),
+				leaves: []Leaf{
+					&LeafI{},
+					&LeafF{},
+				},
+			},
+			ptr: map[string]interface{}{"x": new(int32)},
+			err: fmt.Errorf("rtree: no value for leaf[0][] in map (name=%q)", "branch"),
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -20,6 +20,8 @@ type chain struct {
 	tree Tree  // current tree
 	off  int64 // current offset
 	tot  int64 // current number of entries
+
+	index Index // index of the chain, if any
 }
 
 // Chain returns a Tree that is the concatenation of all the input Trees.
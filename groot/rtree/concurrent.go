@@ -0,0 +1,266 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ReadTask describes the work done by a worker of a concurrent tree read.
+type ReadTask struct {
+	RVars []ReadVar            // read-vars, owned by the worker, to read data into
+	Read  func(ctx RCtx) error // function called for each entry read by the worker
+}
+
+// ReadConcurrent reads data from the provided tree (or chain of trees)
+// concurrently, using at most n workers.
+// If n is <= 0, runtime.NumCPU() workers are used.
+//
+// The range of entries to read is split into contiguous chunks, along the
+// cluster boundaries of the tree (or along the basket boundaries if the tree
+// does not carry any cluster information.)
+// Chunks are then handed out to the workers, as they become available.
+// Entries within a chunk are processed in order, but chunks are processed in
+// no particular order.
+//
+// newTask is called once for each worker, sequentially, with the index
+// of the worker.
+// Each worker reads data into its own set of ReadVars and calls its own
+// Read function for each entry it processes.
+//
+// Once all entries have been processed, reduce (if not nil) is called
+// sequentially, in worker order, with the index of each worker.
+// reduce is typically used to merge the per-worker results.
+//
+// The provided options configure the range of entries to read, the list of
+// entries to read and the number of baskets to prefetch per branch and per
+// worker.
+func ReadConcurrent(t Tree, n int, newTask func(worker int) (ReadTask, error), reduce func(worker int) error, opts ...ReadOption) error {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+
+	cfg := Reader{
+		beg:  0,
+		end:  -1,
+		nrab: 2,
+		tree: t,
+	}
+	for i, opt := range opts {
+		err := opt(&cfg)
+		if err != nil {
+			return fmt.Errorf("rtree: could not set reader option %d: %w", i, err)
+		}
+	}
+	if cfg.end < 0 {
+		cfg.end = t.Entries()
+	}
+	if cfg.beg < 0 || cfg.beg > cfg.end || cfg.end > t.Entries() {
+		return fmt.Errorf(
+			"rtree: invalid event reader range [%d, %d) (tree-entries=%d)",
+			cfg.beg, cfg.end, t.Entries(),
+		)
+	}
+
+	chunks := chunksOf(t, cfg.beg, cfg.end, n)
+	if len(chunks) < n {
+		n = len(chunks)
+	}
+
+	tasks := make([]ReadTask, n)
+	for i := range tasks {
+		task, err := newTask(i)
+		if err != nil {
+			return fmt.Errorf("rtree: could not create task for worker %d: %w", i, err)
+		}
+		if task.Read == nil {
+			return fmt.Errorf("rtree: invalid nil read function for worker %d", i)
+		}
+		tasks[i] = task
+	}
+
+	var (
+		mu    sync.Mutex // serializes the creation of tree readers
+		queue = make(chan rspan, len(chunks))
+		ropts = []ReadOption{WithPrefetchBaskets(cfg.nrab)}
+	)
+	if cfg.elist != nil {
+		ropts = append(ropts, WithEntryList(cfg.elist))
+	}
+	for _, chunk := range chunks {
+		queue <- chunk
+	}
+	close(queue)
+
+	grp, ctx := errgroup.WithContext(context.Background())
+	for i := range tasks {
+		task := tasks[i]
+		grp.Go(func() error {
+			for chunk := range queue {
+				select {
+				case <-ctx.Done():
+					return nil
+				default:
+				}
+
+				mu.Lock()
+				r, err := NewReader(t, task.RVars, append(ropts, WithRange(chunk.beg, chunk.end))...)
+				mu.Unlock()
+				if err != nil {
+					return fmt.Errorf(
+						"rtree: could not create reader for entries [%d, %d): %w",
+						chunk.beg, chunk.end, err,
+					)
+				}
+
+				err = r.Read(task.Read)
+				_ = r.Close()
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	err := grp.Wait()
+	if err != nil {
+		return err
+	}
+
+	if reduce == nil {
+		return nil
+	}
+
+	for i := range tasks {
+		err := reduce(i)
+		if err != nil {
+			return fmt.Errorf("rtree: could not reduce worker %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// chunksOf splits the half-open range [beg, end) of entries of the
+// provided tree into chunks aligned with the cluster boundaries of the tree.
+// Adjacent clusters are merged so that each of the n workers gets a few
+// chunks to process.
+func chunksOf(t Tree, beg, end int64, n int) []rspan {
+	if beg >= end {
+		return nil
+	}
+
+	const chunksPerWorker = 4
+	min := (end - beg) / int64(chunksPerWorker*n)
+
+	var (
+		edges  = clusterEdgesOf(t)
+		chunks []rspan
+		cur    = rspan{beg: beg}
+	)
+	for _, edge := range edges {
+		if edge <= beg {
+			continue
+		}
+		if edge >= end {
+			break
+		}
+		if edge-cur.beg < min {
+			continue
+		}
+		cur.end = edge
+		chunks = append(chunks, cur)
+		cur = rspan{beg: edge}
+	}
+	cur.end = end
+	chunks = append(chunks, cur)
+
+	return chunks
+}
+
+// clusterEdgesOf returns the sorted list of entries starting a new cluster
+// in the provided tree.
+func clusterEdgesOf(t Tree) []int64 {
+	var edges []int64
+	switch t := t.(type) {
+	case *ttree:
+		edges = t.clusterEdges()
+	case *chain:
+		for i, tree := range t.trees {
+			off := t.offs[i]
+			edges = append(edges, off)
+			for _, edge := range clusterEdgesOf(tree) {
+				edges = append(edges, off+edge)
+			}
+		}
+	case *join:
+		if len(t.trees) > 0 {
+			edges = clusterEdgesOf(t.trees[0])
+		}
+	case *friend:
+		edges = clusterEdgesOf(t.main)
+	}
+	edges = append(edges, t.Entries())
+	sort.Slice(edges, func(i, j int) bool { return edges[i] < edges[j] })
+	return edges
+}
+
+// clusterEdges returns the entries starting a new cluster.
+// clusterEdges falls back on the basket boundaries of the first branch
+// when the tree has no cluster information.
+func (tree *ttree) clusterEdges() []int64 {
+	var (
+		edges []int64
+		beg   int64
+	)
+	for i, last := range tree.clusters.ranges {
+		size := tree.clusters.sizes[i]
+		if size <= 0 {
+			return tree.basketEdges()
+		}
+		for entry := beg; entry <= last; entry += size {
+			edges = append(edges, entry)
+		}
+		beg = last + 1
+	}
+
+	switch {
+	case beg >= tree.entries:
+		return edges
+	case tree.autoFlush > 0:
+		for entry := beg; entry < tree.entries; entry += tree.autoFlush {
+			edges = append(edges, entry)
+		}
+		return edges
+	case len(edges) == 0:
+		return tree.basketEdges()
+	default:
+		return append(edges, beg)
+	}
+}
+
+func (tree *ttree) basketEdges() []int64 {
+	if len(tree.branches) == 0 {
+		return nil
+	}
+	b := asBranch(tree.branches[0])
+	n := len(b.basketSeek)
+	if n >= len(b.basketEntry) {
+		n = len(b.basketEntry) - 1
+	}
+	if n < 0 {
+		return nil
+	}
+	edges := make([]int64, n+1)
+	copy(edges, b.basketEntry[:n+1])
+	return edges
+}
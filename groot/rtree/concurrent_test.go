@@ -0,0 +1,264 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+func TestReadConcurrent(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const (
+		nevts = 10000
+		nfile = 3
+	)
+
+	var fnames []string
+	for i := 0; i < nfile; i++ {
+		fname := filepath.Join(tmp, fmt.Sprintf("data-%d.root", i))
+		fnames = append(fnames, fname)
+		func() {
+			f, err := riofs.Create(fname)
+			if err != nil {
+				t.Fatalf("could not create file: %+v", err)
+			}
+			defer f.Close()
+
+			var (
+				evt struct {
+					I64 int64
+					N   int32
+					F64 []float64 `groot:"F64[N]"`
+				}
+				wvars = WriteVarsFromStruct(&evt)
+			)
+			w, err := NewWriter(f, "tree", wvars, WithBasketSize(1024))
+			if err != nil {
+				t.Fatalf("could not create writer: %+v", err)
+			}
+			for j := 0; j < nevts; j++ {
+				evt.I64 = int64(i*nevts + j)
+				evt.N = int32(j % 5)
+				evt.F64 = evt.F64[:0]
+				for k := 0; k < int(evt.N); k++ {
+					evt.F64 = append(evt.F64, float64(evt.I64))
+				}
+				_, err = w.Write()
+				if err != nil {
+					t.Fatalf("could not write event %d: %+v", j, err)
+				}
+			}
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close writer: %+v", err)
+			}
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %+v", err)
+			}
+		}()
+	}
+
+	var trees []Tree
+	for _, fname := range fnames {
+		f, err := riofs.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+		o, err := riofs.Dir(f).Get("tree")
+		if err != nil {
+			t.Fatalf("could not get tree: %+v", err)
+		}
+		trees = append(trees, o.(Tree))
+	}
+
+	type result struct {
+		n   int64
+		sum float64
+		ids []bool
+	}
+
+	seqRead := func(tree Tree, beg, end int64) result {
+		var (
+			res = result{ids: make([]bool, tree.Entries())}
+			i64 int64
+			f64 []float64
+		)
+		r, err := NewReader(tree, []ReadVar{
+			{Name: "I64", Value: &i64},
+			{Name: "F64", Value: &f64},
+		}, WithRange(beg, end))
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		err = r.Read(func(ctx RCtx) error {
+			res.n++
+			res.ids[ctx.Entry] = i64 == ctx.Entry
+			for _, v := range f64 {
+				res.sum += v
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read tree: %+v", err)
+		}
+		return res
+	}
+
+	for _, tc := range []struct {
+		name     string
+		tree     Tree
+		nworkers int
+		beg, end int64
+	}{
+		{name: "tree-1", tree: trees[0], nworkers: 1, beg: 0, end: -1},
+		{name: "tree-4", tree: trees[0], nworkers: 4, beg: 0, end: -1},
+		{name: "tree-ncpu", tree: trees[0], nworkers: 0, beg: 0, end: -1},
+		{name: "tree-range", tree: trees[0], nworkers: 3, beg: 123, end: 7891},
+		{name: "tree-empty", tree: trees[0], nworkers: 3, beg: 10, end: 10},
+		{name: "chain-4", tree: Chain(trees...), nworkers: 4, beg: 0, end: -1},
+		{name: "chain-range", tree: Chain(trees...), nworkers: 5, beg: 9000, end: 21000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			end := tc.end
+			if end < 0 {
+				end = tc.tree.Entries()
+			}
+			want := seqRead(tc.tree, tc.beg, end)
+
+			var (
+				tot = result{ids: make([]bool, tc.tree.Entries())}
+				res []*result
+			)
+			err := ReadConcurrent(tc.tree, tc.nworkers, func(i int) (ReadTask, error) {
+				res = append(res, &result{ids: make([]bool, tc.tree.Entries())})
+				var (
+					res = res[i]
+					i64 = new(int64)
+					f64 = new([]float64)
+				)
+				return ReadTask{
+					RVars: []ReadVar{
+						{Name: "I64", Value: i64},
+						{Name: "F64", Value: f64},
+					},
+					Read: func(ctx RCtx) error {
+						res.n++
+						res.ids[ctx.Entry] = *i64 == ctx.Entry
+						for _, v := range *f64 {
+							res.sum += v
+						}
+						return nil
+					},
+				}, nil
+			}, func(i int) error {
+				tot.n += res[i].n
+				tot.sum += res[i].sum
+				for j, ok := range res[i].ids {
+					tot.ids[j] = tot.ids[j] || ok
+				}
+				return nil
+			}, WithRange(tc.beg, tc.end))
+			if err != nil {
+				t.Fatalf("could not read tree concurrently: %+v", err)
+			}
+
+			if !reflect.DeepEqual(tot, want) {
+				t.Fatalf("invalid concurrent read:\ngot= n=%d sum=%v\nwant=n=%d sum=%v", tot.n, tot.sum, want.n, want.sum)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		newTask := func(i int) (ReadTask, error) {
+			var i64 int64
+			return ReadTask{
+				RVars: []ReadVar{{Name: "I64", Value: &i64}},
+				Read: func(ctx RCtx) error {
+					if ctx.Entry == 4242 {
+						return fmt.Errorf("boom")
+					}
+					return nil
+				},
+			}, nil
+		}
+
+		for _, tc := range []struct {
+			name    string
+			newTask func(int) (ReadTask, error)
+			reduce  func(int) error
+			opts    []ReadOption
+			want    string
+		}{
+			{
+				name:    "invalid-range",
+				newTask: newTask,
+				opts:    []ReadOption{WithRange(0, nevts+1)},
+				want:    "rtree: invalid event reader range [0, 10001) (tree-entries=10000)",
+			},
+			{
+				name: "task",
+				newTask: func(i int) (ReadTask, error) {
+					return ReadTask{}, fmt.Errorf("no task")
+				},
+				want: "rtree: could not create task for worker 0: no task",
+			},
+			{
+				name: "nil-read",
+				newTask: func(i int) (ReadTask, error) {
+					return ReadTask{}, nil
+				},
+				want: "rtree: invalid nil read function for worker 0",
+			},
+			{
+				name: "invalid-rvar",
+				newTask: func(i int) (ReadTask, error) {
+					return ReadTask{
+						RVars: []ReadVar{{Name: "NotThere", Value: new(int64)}},
+						Read:  func(RCtx) error { return nil },
+					}, nil
+				},
+				want: "rtree: could not create reader for entries",
+			},
+			{
+				name:    "read",
+				newTask: newTask,
+				want:    "rtree: could not process entry 4242: boom",
+			},
+			{
+				name:    "reduce",
+				newTask: newTask,
+				opts:    []ReadOption{WithRange(0, 10)},
+				reduce:  func(i int) error { return fmt.Errorf("no reduce") },
+				want:    "rtree: could not reduce worker 0: no reduce",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				err := ReadConcurrent(trees[0], 4, tc.newTask, tc.reduce, tc.opts...)
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if got, want := err.Error(), tc.want; len(got) < len(want) || got[:len(want)] != want {
+					t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+				}
+			})
+		}
+	})
+}
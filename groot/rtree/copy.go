@@ -6,6 +6,8 @@ package rtree
 
 import (
 	"fmt"
+
+	"go-hep.org/x/hep/groot/riofs"
 )
 
 // Copy copies from src to dst until either the reader is depleted or
@@ -53,3 +55,220 @@ func Copy(dst Writer, src *Reader) (int64, error) {
 
 	return tot, err
 }
+
+// FastCopy appends the entries of the src tree to the dst tree, copying
+// the compressed baskets of src as they are stored on file, without
+// decompressing nor recompressing them.
+// The branches and leaves of dst and src must match.
+// FastCopy returns the number of bytes (before compression) copied.
+func FastCopy(dst Writer, src Tree) (int64, error) {
+	w, ok := dst.(*wtree)
+	if !ok {
+		return 0, fmt.Errorf("rtree: invalid tree writer type %T", dst)
+	}
+	t, ok := src.(*ttree)
+	if !ok {
+		return 0, fmt.Errorf("rtree: can not fast-copy tree of type %T", src)
+	}
+
+	err := fastCopyCheck(w.ttree.branches, t.branches)
+	if err != nil {
+		return 0, fmt.Errorf("rtree: can not fast-copy tree %q: %w", t.Name(), err)
+	}
+
+	var tot, zip int64
+	for i := range w.ttree.branches {
+		n, nz, err := fastCopyBranch(asBranch(w.ttree.branches[i]), asBranch(t.branches[i]))
+		tot += n
+		zip += nz
+		if err != nil {
+			return tot, fmt.Errorf("rtree: could not fast-copy branch %q: %w", t.branches[i].Name(), err)
+		}
+	}
+
+	w.ttree.entries += t.entries
+	w.ttree.totBytes += tot
+	w.ttree.zipBytes += zip
+
+	return tot, nil
+}
+
+// fastCopyCheck checks the dst and src branches are stored in the same
+// order and that all the src baskets are stored on file.
+func fastCopyCheck(dst, src []Branch) error {
+	err := checkBranches(dst, src)
+	if err != nil {
+		return err
+	}
+	for i := range dst {
+		var (
+			bdst = dst[i]
+			bsrc = src[i]
+		)
+		if bdst.Name() != bsrc.Name() {
+			return fmt.Errorf("branch #%d names differ (dst=%q, src=%q)", i, bdst.Name(), bsrc.Name())
+		}
+
+		b := asBranch(bsrc)
+		if n := b.basketEntry[b.writeBasket]; n != b.entries {
+			return fmt.Errorf("branch %q: baskets not all stored on file (on-file=%d, entries=%d)", b.Name(), n, b.entries)
+		}
+
+		err := fastCopyCheck(bdst.Branches(), bsrc.Branches())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBranches checks the dst and src branches have the same names,
+// leaves and sub-branches.
+func checkBranches(dst, src []Branch) error {
+	if len(dst) != len(src) {
+		return fmt.Errorf("number of branches differ (dst=%d, src=%d)", len(dst), len(src))
+	}
+	for _, bdst := range dst {
+		var bsrc Branch
+		for _, b := range src {
+			if b.Name() == bdst.Name() {
+				bsrc = b
+				break
+			}
+		}
+		if bsrc == nil {
+			return fmt.Errorf("branch %q not found in src", bdst.Name())
+		}
+
+		ldst := bdst.Leaves()
+		lsrc := bsrc.Leaves()
+		if len(ldst) != len(lsrc) {
+			return fmt.Errorf("branch %q: number of leaves differ (dst=%d, src=%d)", bdst.Name(), len(ldst), len(lsrc))
+		}
+		for j := range ldst {
+			if ldst, lsrc := leafLayout(ldst[j]), leafLayout(lsrc[j]); ldst != lsrc {
+				return fmt.Errorf("branch %q: leaves differ (dst=%s, src=%s)", bdst.Name(), ldst, lsrc)
+			}
+		}
+
+		err := checkBranches(bdst.Branches(), bsrc.Branches())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leafLayout describes the name, type and shape of a leaf.
+func leafLayout(leaf Leaf) string {
+	if _, ok := leaf.(*LeafC); ok {
+		// the length of a string leaf is the length of its longest value.
+		return fmt.Sprintf("%s/%s", leaf.Name(), leaf.TypeName())
+	}
+	if lc := leaf.LeafCount(); lc != nil {
+		return fmt.Sprintf("%s[%s]/%s", leaf.Name(), lc.Name(), leaf.TypeName())
+	}
+	return fmt.Sprintf("%s[%d]/%s", leaf.Name(), leaf.Len(), leaf.TypeName())
+}
+
+// fastCopyBranch appends the on-file baskets of src and of its
+// sub-branches to dst.
+func fastCopyBranch(dst, src *tbranch) (tot, zip int64, err error) {
+	var (
+		fdst = dst.tree.getFile()
+		fsrc = src.tree.getFile()
+	)
+
+	// commit entries already written to dst, so baskets stay in order.
+	if dst.ctx.bk != nil && dst.ctx.bk.nevbuf > 0 {
+		err = dst.flushBasket()
+		if err != nil {
+			return tot, zip, fmt.Errorf("could not flush basket: %w", err)
+		}
+		dst.createNewBasket()
+	}
+
+	for i := 0; i < src.writeBasket; i++ {
+		n := src.basketEntry[i+1] - src.basketEntry[i]
+		if n == 0 {
+			continue
+		}
+
+		raw := make([]byte, src.basketBytes[i])
+		_, err = fsrc.ReadAt(raw, src.basketSeek[i])
+		if err != nil {
+			return tot, zip, fmt.Errorf("could not read basket %d: %w", i, err)
+		}
+
+		key, err := riofs.CopyRawKeyInternal(fdst, raw)
+		if err != nil {
+			return tot, zip, fmt.Errorf("could not copy basket %d: %w", i, err)
+		}
+
+		dst.entries += n
+		dst.entryNumber += n
+		dst.totBytes += int64(key.KeyLen() + key.ObjLen())
+		dst.zipBytes += int64(key.Nbytes())
+		dst.basketBytes = append(dst.basketBytes, key.Nbytes())
+		dst.basketEntry = append(dst.basketEntry, dst.entryNumber)
+		dst.basketSeek = append(dst.basketSeek, key.SeekKey())
+		dst.writeBasket++
+
+		tot += int64(key.KeyLen() + key.ObjLen())
+		zip += int64(key.Nbytes())
+	}
+
+	if dst.ctx.bk != nil {
+		// replace the pending (empty) basket, so its cycle follows the
+		// copied ones.
+		dst.baskets = dst.baskets[:len(dst.baskets)-1]
+		dst.createNewBasket()
+	}
+
+	for i := range dst.leaves {
+		fastCopyLeafMax(dst.leaves[i], src.leaves[i])
+	}
+
+	for i := range dst.branches {
+		n, nz, err := fastCopyBranch(asBranch(dst.branches[i]), asBranch(src.branches[i]))
+		tot += n
+		zip += nz
+		if err != nil {
+			return tot, zip, fmt.Errorf("could not fast-copy branch %q: %w", src.branches[i].Name(), err)
+		}
+	}
+
+	return tot, zip, nil
+}
+
+// fastCopyLeafMax updates the maximum value held by a leaf-count or
+// the maximum length of a string leaf.
+func fastCopyLeafMax(dst, src Leaf) {
+	switch dst := dst.(type) {
+	case *LeafC:
+		if src, ok := src.(*LeafC); ok {
+			if src.max > dst.max {
+				dst.max = src.max
+			}
+			if src.tleaf.len > dst.tleaf.len {
+				dst.tleaf.len = src.tleaf.len
+			}
+		}
+	case *LeafB:
+		if src, ok := src.(*LeafB); ok && src.max > dst.max {
+			dst.max = src.max
+		}
+	case *LeafS:
+		if src, ok := src.(*LeafS); ok && src.max > dst.max {
+			dst.max = src.max
+		}
+	case *LeafI:
+		if src, ok := src.(*LeafI); ok && src.max > dst.max {
+			dst.max = src.max
+		}
+	case *LeafL:
+		if src, ok := src.(*LeafL); ok && src.max > dst.max {
+			dst.max = src.max
+		}
+	}
+}
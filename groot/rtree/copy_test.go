@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"go-hep.org/x/hep/groot"
@@ -373,3 +374,205 @@ func TestCopyTree(t *testing.T) {
 		})
 	}
 }
+
+func TestFastCopyTree(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-fastcopy-")
+	if err != nil {
+		t.Fatalf("could not create tmpdir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	type Data struct {
+		I32 int32
+		F64 float64
+		Str string
+		N   int32
+		Sli []float64 `groot:"Sli[N]"`
+	}
+
+	const nevts = 100
+	fill := func(evt *Data, i int) {
+		evt.I32 = int32(i)
+		evt.F64 = float64(i)
+		evt.Str = fmt.Sprintf("evt-%03d", i)
+		evt.N = int32(i % 10)
+		evt.Sli = make([]float64, evt.N)
+		for j := range evt.Sli {
+			evt.Sli[j] = float64(i + j)
+		}
+	}
+
+	iname := filepath.Join(tmp, "in.root")
+	{
+		f, err := groot.Create(iname)
+		if err != nil {
+			t.Fatalf("could not create input file: %+v", err)
+		}
+		defer f.Close()
+
+		var evt Data
+		w, err := rtree.NewWriter(f, "tree", rtree.WriteVarsFromStruct(&evt), rtree.WithBasketSize(256))
+		if err != nil {
+			t.Fatalf("could not create input tree: %+v", err)
+		}
+		for i := 0; i < nevts; i++ {
+			fill(&evt, i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event %d: %+v", i, err)
+			}
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close input tree: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close input file: %+v", err)
+		}
+	}
+
+	f, err := groot.Open(iname)
+	if err != nil {
+		t.Fatalf("could not open input file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not get input tree: %+v", err)
+	}
+	src := obj.(rtree.Tree)
+
+	// output tree: 2 entries, 2 fast-copies of the input tree, 2 entries.
+	oname := filepath.Join(tmp, "out.root")
+	{
+		o, err := groot.Create(oname)
+		if err != nil {
+			t.Fatalf("could not create output file: %+v", err)
+		}
+		defer o.Close()
+
+		var evt Data
+		w, err := rtree.NewWriter(o, "tree", rtree.WriteVarsFromStruct(&evt))
+		if err != nil {
+			t.Fatalf("could not create output tree: %+v", err)
+		}
+		write := func(i int) {
+			fill(&evt, i)
+			_, err = w.Write()
+			if err != nil {
+				t.Fatalf("could not write event %d: %+v", i, err)
+			}
+		}
+
+		write(0)
+		write(1)
+		for i := 0; i < 2; i++ {
+			_, err = rtree.FastCopy(w, src)
+			if err != nil {
+				t.Fatalf("could not fast-copy tree: %+v", err)
+			}
+		}
+		write(2)
+		write(3)
+
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close output tree: %+v", err)
+		}
+		err = o.Close()
+		if err != nil {
+			t.Fatalf("could not close output file: %+v", err)
+		}
+	}
+
+	o, err := groot.Open(oname)
+	if err != nil {
+		t.Fatalf("could not open output file: %+v", err)
+	}
+	defer o.Close()
+
+	obj, err = o.Get("tree")
+	if err != nil {
+		t.Fatalf("could not get output tree: %+v", err)
+	}
+	tree := obj.(rtree.Tree)
+	if got, want := tree.Entries(), int64(2*nevts+4); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+
+	var (
+		ids  []int
+		data Data
+	)
+	ids = append(ids, 0, 1)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < nevts; j++ {
+			ids = append(ids, j)
+		}
+	}
+	ids = append(ids, 2, 3)
+
+	r, err := rtree.NewReader(tree, rtree.ReadVarsFromStruct(&data))
+	if err != nil {
+		t.Fatalf("could not create reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		var want Data
+		fill(&want, ids[ctx.Entry])
+		if !reflect.DeepEqual(data, want) {
+			return fmt.Errorf("entry %d: got=%+v, want=%+v", ctx.Entry, data, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("invalid output tree: %+v", err)
+	}
+}
+
+func TestFastCopyTreeSchema(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-fastcopy-")
+	if err != nil {
+		t.Fatalf("could not create tmpdir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	f, err := groot.Open("../testdata/simple.root")
+	if err != nil {
+		t.Fatalf("could not open input file: %+v", err)
+	}
+	defer f.Close()
+
+	obj, err := f.Get("tree")
+	if err != nil {
+		t.Fatalf("could not get input tree: %+v", err)
+	}
+	src := obj.(rtree.Tree)
+
+	o, err := groot.Create(filepath.Join(tmp, "out.root"))
+	if err != nil {
+		t.Fatalf("could not create output file: %+v", err)
+	}
+	defer o.Close()
+
+	var data struct {
+		One int32 `groot:"one"`
+	}
+	w, err := rtree.NewWriter(o, "tree", rtree.WriteVarsFromStruct(&data))
+	if err != nil {
+		t.Fatalf("could not create output tree: %+v", err)
+	}
+	defer w.Close()
+
+	_, err = rtree.FastCopy(w, src)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	const want = `rtree: can not fast-copy tree "tree": number of branches differ (dst=1, src=3)`
+	if got := err.Error(); got != want {
+		t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+	}
+}
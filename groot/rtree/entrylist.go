@@ -0,0 +1,709 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rcont"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// EntrySelection describes a set of selected entries of a tree, such as
+// an EntryList or an EventList.
+type EntrySelection interface {
+	root.Named
+
+	// Len returns the number of selected entries.
+	Len() int64
+
+	// selected returns the sorted list of entries of the provided tree
+	// that are part of the selection.
+	selected(t Tree) ([]int64, error)
+}
+
+const (
+	// maxEntries is the default number of entries to process of ROOT's TEntryList.
+	maxEntries = 1000000000000000000
+
+	// entryListBlockSize is the number of consecutive entries held by
+	// a TEntryListBlock.
+	entryListBlockSize = 4000
+)
+
+// EntryList is a list of selected entries of a tree, or of a chain of trees.
+//
+// The entries of an EntryList attached to a tree are numbered relatively
+// to that tree.
+// An EntryList attached to a chain holds one sub-list for each tree of the
+// chain.
+//
+// EntryList is the equivalent of ROOT's TEntryList.
+type EntryList struct {
+	named   rbase.Named
+	lists   []*EntryList // sub-lists, one for each tree of a chain
+	entries []int64      // sorted list of selected entries
+	nproc   int64        // number of entries to process
+	tree    string       // name of the tree
+	file    string       // name of the file holding the tree
+	reapply bool
+
+	offs []int64 // number of entries before the tree of each sub-list (transient)
+}
+
+// NewEntryList creates a new empty list of entries attached to the provided
+// tree or chain of trees.
+func NewEntryList(name, title string, t Tree) *EntryList {
+	list := &EntryList{
+		named: *rbase.NewNamed(name, title),
+		nproc: maxEntries,
+	}
+
+	switch t := t.(type) {
+	case nil:
+		// no tree attached.
+	case *chain:
+		list.lists = make([]*EntryList, len(t.trees))
+		list.offs = t.offs
+		for i, tree := range t.trees {
+			list.lists[i] = NewEntryList(name, title, tree)
+		}
+	default:
+		list.tree = t.Name()
+		list.file = fileNameOf(t)
+	}
+
+	return list
+}
+
+func (*EntryList) Class() string      { return "TEntryList" }
+func (*EntryList) RVersion() int16    { return rvers.EntryList }
+func (list *EntryList) Name() string  { return list.named.Name() }
+func (list *EntryList) Title() string { return list.named.Title() }
+
+// TreeName returns the name of the tree the list is attached to.
+func (list *EntryList) TreeName() string { return list.tree }
+
+// FileName returns the name of the file holding the tree the list is
+// attached to.
+func (list *EntryList) FileName() string { return list.file }
+
+// Lists returns the sub-lists of a list attached to a chain of trees.
+func (list *EntryList) Lists() []*EntryList { return list.lists }
+
+// Len returns the number of selected entries.
+func (list *EntryList) Len() int64 {
+	n := int64(len(list.entries))
+	for _, sub := range list.lists {
+		n += sub.Len()
+	}
+	return n
+}
+
+// Entries returns the sorted list of selected entries of the tree the list
+// is attached to.
+// Entries returns nil for lists attached to a chain of trees.
+func (list *EntryList) Entries() []int64 {
+	if len(list.lists) > 0 {
+		return nil
+	}
+	return append([]int64(nil), list.entries...)
+}
+
+// Contains returns whether the provided entry is part of the list.
+// Entries of a list attached to a chain are numbered relatively to the
+// chain.
+func (list *EntryList) Contains(entry int64) bool {
+	sub, entry := list.route(entry)
+	if sub == nil {
+		return false
+	}
+	i := sort.Search(len(sub.entries), func(i int) bool { return sub.entries[i] >= entry })
+	return i < len(sub.entries) && sub.entries[i] == entry
+}
+
+// Enter adds the provided entry to the list.
+// Entries of a list attached to a chain are numbered relatively to the
+// chain.
+// Enter returns false if the entry was already part of the list, or if
+// the entry could not be added to the list.
+func (list *EntryList) Enter(entry int64) bool {
+	if entry < 0 {
+		return false
+	}
+	sub, entry := list.route(entry)
+	if sub == nil {
+		return false
+	}
+	var ok bool
+	sub.entries, ok = insertEntry(sub.entries, entry)
+	return ok
+}
+
+// route returns the list holding the provided entry and the entry number
+// relative to that list.
+func (list *EntryList) route(entry int64) (*EntryList, int64) {
+	if len(list.lists) == 0 {
+		return list, entry
+	}
+	if len(list.offs) != len(list.lists) {
+		// list read from a file, not attached to a chain.
+		return nil, entry
+	}
+	i := sort.Search(len(list.offs), func(i int) bool { return list.offs[i] > entry }) - 1
+	if i < 0 {
+		return nil, entry
+	}
+	return list.lists[i], entry - list.offs[i]
+}
+
+func (list *EntryList) selected(t Tree) ([]int64, error) {
+	switch t := t.(type) {
+	case *friend:
+		return list.selected(t.main)
+	case *join:
+		return list.selected(t.trees[0])
+	case *chain:
+		if len(list.lists) == 0 {
+			return list.entries, nil
+		}
+		var entries []int64
+		for i, tree := range t.trees {
+			sub := list.find(tree, i, len(t.trees))
+			if sub == nil {
+				continue
+			}
+			for _, entry := range sub.entries {
+				if entry >= tree.Entries() {
+					return nil, fmt.Errorf(
+						"rtree: entry list %q has entry %d out of range for tree %d of chain (entries=%d)",
+						list.Name(), entry, i, tree.Entries(),
+					)
+				}
+				entries = append(entries, t.offs[i]+entry)
+			}
+		}
+		return entries, nil
+	default:
+		if len(list.lists) == 0 {
+			return list.entries, nil
+		}
+		sub := list.find(t, 0, 1)
+		if sub == nil {
+			return nil, nil
+		}
+		return sub.entries, nil
+	}
+}
+
+// find returns the sub-list matching the provided tree, the i-th tree of
+// a chain of n trees.
+// Sub-lists are matched by tree and file names, and then by position if
+// the list has exactly one sub-list for each tree of the chain.
+func (list *EntryList) find(t Tree, i, n int) *EntryList {
+	var (
+		tname = t.Name()
+		fname = fileNameOf(t)
+	)
+	for _, sub := range list.lists {
+		if sub.tree == tname && sub.file == fname {
+			return sub
+		}
+	}
+	for _, sub := range list.lists {
+		if sub.tree == tname && filepath.Base(sub.file) == filepath.Base(fname) {
+			return sub
+		}
+	}
+	if len(list.lists) == n {
+		return list.lists[i]
+	}
+	return nil
+}
+
+// blocks returns the selected entries, packed into TEntryListBlocks.
+func (list *EntryList) blocks() []root.Object {
+	if len(list.entries) == 0 {
+		return nil
+	}
+	var (
+		last   = list.entries[len(list.entries)-1]
+		blocks = make([]root.Object, last/entryListBlockSize+1)
+		beg    = 0
+	)
+	for i := range blocks {
+		var (
+			off = int64(i) * entryListBlockSize
+			end = beg
+		)
+		for end < len(list.entries) && list.entries[end] < off+entryListBlockSize {
+			end++
+		}
+		blocks[i] = newEntryListBlock(list.entries[beg:end], off)
+		beg = end
+	}
+	return blocks
+}
+
+func (list *EntryList) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(list.RVersion())
+	if n, err := list.named.MarshalROOT(w); err != nil {
+		return n, err
+	}
+
+	{
+		var obj root.Object
+		if len(list.lists) > 0 {
+			objs := make([]root.Object, len(list.lists))
+			for i, sub := range list.lists {
+				objs[i] = sub
+			}
+			obj = rcont.NewList("", objs)
+		}
+		if err := w.WriteObjectAny(obj); err != nil {
+			return int(w.Pos() - pos), err
+		}
+	}
+
+	blocks := list.blocks()
+	w.WriteI32(int32(len(blocks)))
+	{
+		var obj root.Object
+		if len(blocks) > 0 {
+			arr := rcont.NewObjArray()
+			arr.SetElems(blocks)
+			obj = arr
+		}
+		if err := w.WriteObjectAny(obj); err != nil {
+			return int(w.Pos() - pos), err
+		}
+	}
+
+	w.WriteI64(list.Len())
+	w.WriteI64(list.nproc)
+	w.WriteString(list.tree)
+	w.WriteString(list.file)
+	w.WriteBool(list.reapply)
+
+	return w.SetByteCount(pos, list.Class())
+}
+
+func (list *EntryList) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(list.Class())
+	if vers > rvers.EntryList {
+		panic(fmt.Errorf("rtree: invalid TEntryList version=%d > %d", vers, rvers.EntryList))
+	}
+
+	if err := list.named.UnmarshalROOT(r); err != nil {
+		return err
+	}
+
+	list.lists = nil
+	list.entries = nil
+	list.offs = nil
+
+	if v := r.ReadObjectAny(); v != nil {
+		subs := v.(*rcont.List)
+		list.lists = make([]*EntryList, subs.Len())
+		for i := range list.lists {
+			sub, ok := subs.At(i).(*EntryList)
+			if !ok {
+				return fmt.Errorf("rtree: invalid TEntryList sub-list type %T", subs.At(i))
+			}
+			list.lists[i] = sub
+		}
+	}
+
+	nblocks := int(r.ReadI32())
+	if v := r.ReadObjectAny(); v != nil {
+		blocks := v.(*rcont.ObjArray)
+		if blocks.Len() < nblocks {
+			nblocks = blocks.Len()
+		}
+		for i := 0; i < nblocks; i++ {
+			blk, ok := blocks.At(i).(*entryListBlock)
+			if !ok {
+				return fmt.Errorf("rtree: invalid TEntryList block type %T", blocks.At(i))
+			}
+			list.entries = blk.appendEntries(list.entries, int64(i)*entryListBlockSize)
+		}
+	}
+
+	_ = r.ReadI64() // fN
+	list.nproc = r.ReadI64()
+	list.tree = r.ReadString()
+	list.file = r.ReadString()
+	if vers > 1 {
+		list.reapply = r.ReadBool()
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, list.Class())
+	return r.Err()
+}
+
+// entryListBlock holds the selected entries of a block of
+// entryListBlockSize consecutive entries.
+//
+// entryListBlock is the equivalent of ROOT's TEntryListBlock.
+type entryListBlock struct {
+	obj     rbase.Object
+	npassed int32    // number of selected entries
+	indices []uint16 // bits of selected entries, or list of (un)selected entries
+	typ     int32    // 0: bits, 1: list
+	passing bool     // whether the list holds the selected or the unselected entries
+}
+
+// newEntryListBlock packs the provided sorted entries of the block starting
+// at entry off, using the most compact representation.
+func newEntryListBlock(entries []int64, off int64) *entryListBlock {
+	var (
+		n   = len(entries)
+		blk = &entryListBlock{
+			obj:     *rbase.NewObject(),
+			npassed: int32(n),
+			typ:     1,
+			passing: true,
+		}
+	)
+
+	switch {
+	case n < entryListBlockSize/16:
+		blk.indices = make([]uint16, n)
+		for i, entry := range entries {
+			blk.indices[i] = uint16(entry - off)
+		}
+
+	case n > entryListBlockSize*15/16:
+		blk.passing = false
+		blk.indices = make([]uint16, 0, entryListBlockSize-n)
+		j := 0
+		for i := 0; i < entryListBlockSize; i++ {
+			if j < n && entries[j]-off == int64(i) {
+				j++
+				continue
+			}
+			blk.indices = append(blk.indices, uint16(i))
+		}
+
+	default:
+		blk.typ = 0
+		blk.indices = make([]uint16, entryListBlockSize/16)
+		for _, entry := range entries {
+			i := entry - off
+			blk.indices[i>>4] |= 1 << uint(i&15)
+		}
+	}
+
+	return blk
+}
+
+// appendEntries appends the selected entries of the block starting at
+// entry off to the provided slice.
+func (blk *entryListBlock) appendEntries(entries []int64, off int64) []int64 {
+	switch {
+	case blk.typ == 0:
+		for i, bits := range blk.indices {
+			for j := 0; j < 16; j++ {
+				if bits&(1<<uint(j)) != 0 {
+					entries = append(entries, off+int64(i<<4+j))
+				}
+			}
+		}
+
+	case blk.passing:
+		for _, i := range blk.indices {
+			entries = append(entries, off+int64(i))
+		}
+
+	default:
+		var (
+			n = 0
+			j = 0
+		)
+		for i := 0; i < entryListBlockSize && n < int(blk.npassed); i++ {
+			if j < len(blk.indices) && int(blk.indices[j]) == i {
+				j++
+				continue
+			}
+			entries = append(entries, off+int64(i))
+			n++
+		}
+	}
+	return entries
+}
+
+func (*entryListBlock) Class() string   { return "TEntryListBlock" }
+func (*entryListBlock) RVersion() int16 { return rvers.EntryListBlock }
+
+func (blk *entryListBlock) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(blk.RVersion())
+	if n, err := blk.obj.MarshalROOT(w); err != nil {
+		return n, err
+	}
+	w.WriteI32(blk.npassed)
+	w.WriteI32(int32(len(blk.indices)))
+	writeBasicPointerU16(w, blk.indices)
+	w.WriteI32(blk.typ)
+	w.WriteBool(blk.passing)
+
+	return w.SetByteCount(pos, blk.Class())
+}
+
+func (blk *entryListBlock) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(blk.Class())
+	if vers > rvers.EntryListBlock {
+		panic(fmt.Errorf("rtree: invalid TEntryListBlock version=%d > %d", vers, rvers.EntryListBlock))
+	}
+
+	if err := blk.obj.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	blk.npassed = r.ReadI32()
+	n := int(r.ReadI32())
+	blk.indices = nil
+	if isArray := r.ReadI8(); isArray != 0 && n > 0 {
+		blk.indices = make([]uint16, n)
+		r.ReadArrayU16(blk.indices)
+	}
+	blk.typ = r.ReadI32()
+	blk.passing = r.ReadBool()
+
+	r.CheckByteCount(pos, bcnt, beg, blk.Class())
+	return r.Err()
+}
+
+// EventList is a list of selected entries of a tree, or of a chain of
+// trees.
+// The entries of an EventList attached to a chain are numbered relatively
+// to the chain.
+//
+// EventList is the equivalent of ROOT's TEventList.
+type EventList struct {
+	named   rbase.Named
+	delta   int32 // increment size
+	reapply bool
+	entries []int64 // sorted list of selected entries
+}
+
+// NewEventList creates a new empty list of entries.
+func NewEventList(name, title string) *EventList {
+	return &EventList{
+		named: *rbase.NewNamed(name, title),
+		delta: 100,
+	}
+}
+
+func (*EventList) Class() string      { return "TEventList" }
+func (*EventList) RVersion() int16    { return rvers.EventList }
+func (list *EventList) Name() string  { return list.named.Name() }
+func (list *EventList) Title() string { return list.named.Title() }
+
+// Len returns the number of selected entries.
+func (list *EventList) Len() int64 { return int64(len(list.entries)) }
+
+// Entries returns the sorted list of selected entries.
+func (list *EventList) Entries() []int64 {
+	return append([]int64(nil), list.entries...)
+}
+
+// Contains returns whether the provided entry is part of the list.
+func (list *EventList) Contains(entry int64) bool {
+	i := sort.Search(len(list.entries), func(i int) bool { return list.entries[i] >= entry })
+	return i < len(list.entries) && list.entries[i] == entry
+}
+
+// Enter adds the provided entry to the list.
+// Enter returns false if the entry was already part of the list.
+func (list *EventList) Enter(entry int64) bool {
+	if entry < 0 {
+		return false
+	}
+	var ok bool
+	list.entries, ok = insertEntry(list.entries, entry)
+	return ok
+}
+
+func (list *EventList) selected(t Tree) ([]int64, error) {
+	return list.entries, nil
+}
+
+func (list *EventList) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(list.RVersion())
+	if n, err := list.named.MarshalROOT(w); err != nil {
+		return n, err
+	}
+	w.WriteI32(int32(len(list.entries))) // fN
+	w.WriteI32(int32(len(list.entries))) // fSize
+	w.WriteI32(list.delta)
+	w.WriteBool(list.reapply)
+	switch len(list.entries) {
+	case 0:
+		w.WriteI8(0)
+	default:
+		w.WriteI8(1)
+		w.WriteFastArrayI64(list.entries)
+	}
+
+	return w.SetByteCount(pos, list.Class())
+}
+
+func (list *EventList) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(list.Class())
+	if vers > rvers.EventList {
+		panic(fmt.Errorf("rtree: invalid TEventList version=%d > %d", vers, rvers.EventList))
+	}
+
+	if err := list.named.UnmarshalROOT(r); err != nil {
+		return err
+	}
+
+	n := int(r.ReadI32())
+	_ = r.ReadI32() // fSize
+	list.delta = r.ReadI32()
+	list.entries = nil
+
+	switch {
+	case vers < 2:
+		// old versions store 32b entries, without a reapply flag.
+		if n > 0 {
+			entries := make([]int32, n)
+			r.ReadArrayI32(entries)
+			list.entries = make([]int64, n)
+			for i, v := range entries {
+				list.entries[i] = int64(v)
+			}
+		}
+	case vers < rvers.EventList:
+		return fmt.Errorf("rtree: unsupported TEventList version %d", vers)
+	default:
+		list.reapply = r.ReadBool()
+		if isArray := r.ReadI8(); isArray != 0 && n > 0 {
+			list.entries = make([]int64, n)
+			r.ReadArrayI64(list.entries)
+		}
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, list.Class())
+	return r.Err()
+}
+
+// insertEntry inserts the provided entry into the sorted slice of entries.
+// insertEntry returns false if the entry was already in the slice.
+func insertEntry(entries []int64, entry int64) ([]int64, bool) {
+	n := len(entries)
+	if n == 0 || entries[n-1] < entry {
+		return append(entries, entry), true
+	}
+	i := sort.Search(n, func(i int) bool { return entries[i] >= entry })
+	if entries[i] == entry {
+		return entries, false
+	}
+	entries = append(entries, 0)
+	copy(entries[i+1:], entries[i:])
+	entries[i] = entry
+	return entries, true
+}
+
+// writeBasicPointerU16 writes a counted array of uint16, prefixed by
+// the flag marking a non-empty array.
+func writeBasicPointerU16(w *rbytes.WBuffer, v []uint16) {
+	if len(v) == 0 {
+		w.WriteI8(0)
+		return
+	}
+	w.WriteI8(1)
+	w.WriteFastArrayU16(v)
+}
+
+// fileNameOf returns the name of the file holding the provided tree.
+func fileNameOf(t Tree) string {
+	switch t := t.(type) {
+	case *ttree:
+		if t.f != nil {
+			return t.f.Name()
+		}
+	case *friend:
+		return fileNameOf(t.main)
+	case *join:
+		return fileNameOf(t.trees[0])
+	}
+	return ""
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := &EntryList{named: *rbase.NewNamed("", ""), nproc: maxEntries}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TEntryList", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := &entryListBlock{obj: *rbase.NewObject()}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TEntryListBlock", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := NewEventList("", "")
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TEventList", f)
+	}
+}
+
+var (
+	_ root.Object        = (*EntryList)(nil)
+	_ root.Named         = (*EntryList)(nil)
+	_ EntrySelection     = (*EntryList)(nil)
+	_ rbytes.Marshaler   = (*EntryList)(nil)
+	_ rbytes.Unmarshaler = (*EntryList)(nil)
+
+	_ root.Object        = (*entryListBlock)(nil)
+	_ rbytes.Marshaler   = (*entryListBlock)(nil)
+	_ rbytes.Unmarshaler = (*entryListBlock)(nil)
+
+	_ root.Object        = (*EventList)(nil)
+	_ root.Named         = (*EventList)(nil)
+	_ EntrySelection     = (*EventList)(nil)
+	_ rbytes.Marshaler   = (*EventList)(nil)
+	_ rbytes.Unmarshaler = (*EventList)(nil)
+)
@@ -0,0 +1,594 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/internal/rtests"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/root"
+)
+
+func TestEntryListBlock(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		n       int
+		stride  int
+		typ     int32
+		passing bool
+		size    int
+	}{
+		{name: "empty", n: 0, stride: 1, typ: 1, passing: true, size: 0},
+		{name: "sparse", n: 100, stride: 7, typ: 1, passing: true, size: 100},
+		{name: "bits", n: 2000, stride: 2, typ: 0, passing: true, size: 250},
+		{name: "dense", n: 3990, stride: 1, typ: 1, passing: false, size: 10},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			const off = 3 * entryListBlockSize
+			var want []int64
+			for i := 0; i < tc.n; i++ {
+				want = append(want, off+int64(i*tc.stride))
+			}
+
+			blk := newEntryListBlock(want, off)
+			if got, want := blk.typ, tc.typ; got != want {
+				t.Fatalf("invalid block type: got=%d, want=%d", got, want)
+			}
+			if got, want := blk.passing, tc.passing; got != want {
+				t.Fatalf("invalid block passing: got=%v, want=%v", got, want)
+			}
+			if got, want := len(blk.indices), tc.size; got != want {
+				t.Fatalf("invalid block size: got=%d, want=%d", got, want)
+			}
+
+			wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+			_, err := blk.MarshalROOT(wbuf)
+			if err != nil {
+				t.Fatalf("could not marshal block: %+v", err)
+			}
+
+			var rblk entryListBlock
+			err = rblk.UnmarshalROOT(rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, nil))
+			if err != nil {
+				t.Fatalf("could not unmarshal block: %+v", err)
+			}
+
+			got := rblk.appendEntries(nil, off)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestEntryListRW(t *testing.T) {
+	single := NewEntryList("elist", "my list", nil)
+	single.tree = "tree"
+	single.file = "file.root"
+	for _, entry := range []int64{12001, 3, 0, 3, 42, 4000, 4001, 17000} {
+		single.Enter(entry)
+	}
+	for i := int64(0); i < 2000; i++ {
+		single.Enter(8000 + 2*i)
+	}
+
+	chain := NewEntryList("clist", "my chain list", nil)
+	chain.lists = []*EntryList{
+		NewEntryList("clist", "my chain list", nil),
+		NewEntryList("clist", "my chain list", nil),
+	}
+	chain.lists[0].tree = "tree"
+	chain.lists[0].file = "f1.root"
+	chain.lists[0].Enter(1)
+	chain.lists[1].tree = "tree"
+	chain.lists[1].file = "f2.root"
+	chain.lists[1].Enter(2)
+	chain.lists[1].Enter(4)
+
+	events := NewEventList("evtlist", "my event list")
+	for _, entry := range []int64{5, 1, 3, 1} {
+		events.Enter(entry)
+	}
+
+	for _, tc := range []struct {
+		name string
+		want rbytes.Marshaler
+		len  int64
+	}{
+		{name: "TEntryList", want: single, len: 2007},
+		{name: "TEntryList-chain", want: chain, len: 3},
+		{name: "TEntryList-empty", want: NewEntryList("empty", "", nil), len: 0},
+		{name: "TEventList", want: events, len: 3},
+		{name: "TEventList-empty", want: NewEventList("empty", ""), len: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, want := tc.want.(EntrySelection).Len(), tc.len; got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+			_, err := tc.want.MarshalROOT(wbuf)
+			if err != nil {
+				t.Fatalf("could not marshal list: %+v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want).Elem()).Interface().(rbytes.Unmarshaler)
+			err = got.UnmarshalROOT(rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, nil))
+			if err != nil {
+				t.Fatalf("could not unmarshal list: %+v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("round trip failed:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+		})
+	}
+
+	if got, want := events.Entries(), []int64{1, 3, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid event list entries: got=%v, want=%v", got, want)
+	}
+	if !events.Contains(3) || events.Contains(4) {
+		t.Fatalf("invalid event list content")
+	}
+	if !single.Contains(4001) || single.Contains(4002) {
+		t.Fatalf("invalid entry list content")
+	}
+	if chain.Contains(1) || chain.Enter(1) || chain.Entries() != nil {
+		t.Fatalf("invalid chain list: not attached to a chain")
+	}
+}
+
+func TestReaderEntryList(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const (
+		nevts = 10000
+		nfile = 2
+	)
+
+	var fnames []string
+	for i := 0; i < nfile; i++ {
+		fname := filepath.Join(tmp, fmt.Sprintf("data-%d.root", i))
+		fnames = append(fnames, fname)
+		func() {
+			f, err := riofs.Create(fname)
+			if err != nil {
+				t.Fatalf("could not create file: %+v", err)
+			}
+			defer f.Close()
+
+			var (
+				evt struct {
+					I64 int64
+					N   int32
+					F64 []float64 `groot:"F64[N]"`
+				}
+				wvars = WriteVarsFromStruct(&evt)
+			)
+			w, err := NewWriter(f, "tree", wvars, WithBasketSize(1024))
+			if err != nil {
+				t.Fatalf("could not create writer: %+v", err)
+			}
+			for j := 0; j < nevts; j++ {
+				evt.I64 = int64(i*nevts + j)
+				evt.N = int32(j % 3)
+				evt.F64 = evt.F64[:0]
+				for k := 0; k < int(evt.N); k++ {
+					evt.F64 = append(evt.F64, float64(evt.I64))
+				}
+				_, err = w.Write()
+				if err != nil {
+					t.Fatalf("could not write event %d: %+v", j, err)
+				}
+			}
+			err = w.Close()
+			if err != nil {
+				t.Fatalf("could not close writer: %+v", err)
+			}
+			err = f.Close()
+			if err != nil {
+				t.Fatalf("could not close file: %+v", err)
+			}
+		}()
+	}
+
+	var trees []Tree
+	for _, fname := range fnames {
+		tree, done := openTree(t, fname, "tree")
+		defer done()
+		trees = append(trees, tree)
+	}
+	ch := Chain(trees...)
+
+	selected := []int64{0, 1, 42, 3999, 4000, 5000, 9999, 10000, 10001, 15000, 19999}
+
+	elist := NewEntryList("elist", "", ch)
+	evlist := NewEventList("evlist", "")
+	for _, entry := range selected {
+		elist.Enter(entry)
+		evlist.Enter(entry)
+	}
+
+	// write and read back the entry list, to exercise the matching of
+	// sub-lists by tree and file names.
+	var rlist *EntryList
+	func() {
+		fname := filepath.Join(tmp, "elist.root")
+		f, err := riofs.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create file: %+v", err)
+		}
+		defer f.Close()
+
+		for _, obj := range []root.Object{elist, evlist} {
+			err = riofs.Dir(f).Put(obj.(root.Named).Name(), obj)
+			if err != nil {
+				t.Fatalf("could not write %s: %+v", obj.Class(), err)
+			}
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close file: %+v", err)
+		}
+
+		f, err = riofs.Open(fname)
+		if err != nil {
+			t.Fatalf("could not open file: %+v", err)
+		}
+		defer f.Close()
+
+		o, err := riofs.Dir(f).Get("elist")
+		if err != nil {
+			t.Fatalf("could not read entry list: %+v", err)
+		}
+		rlist = o.(*EntryList)
+
+		o, err = riofs.Dir(f).Get("evlist")
+		if err != nil {
+			t.Fatalf("could not read event list: %+v", err)
+		}
+		if got, want := o.(*EventList).Entries(), selected; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid event list:\ngot= %v\nwant=%v", got, want)
+		}
+	}()
+
+	if got, want := rlist.Len(), int64(len(selected)); got != want {
+		t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+	}
+	if got, want := len(rlist.Lists()), nfile; got != want {
+		t.Fatalf("invalid number of sub-lists: got=%d, want=%d", got, want)
+	}
+	if got, want := rlist.Lists()[1].FileName(), fnames[1]; got != want {
+		t.Fatalf("invalid sub-list file name: got=%q, want=%q", got, want)
+	}
+
+	read := func(t *testing.T, tree Tree, opts ...ReadOption) []int64 {
+		t.Helper()
+		var (
+			i64 int64
+			f64 []float64
+			got []int64
+		)
+		r, err := NewReader(tree, []ReadVar{
+			{Name: "I64", Value: &i64},
+			{Name: "F64", Value: &f64},
+		}, opts...)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		err = r.Read(func(ctx RCtx) error {
+			if i64%nevts != ctx.Entry%nevts {
+				return fmt.Errorf("invalid entry: got=%d, want=%d", i64, ctx.Entry)
+			}
+			if n := int(i64 % nevts % 3); len(f64) != n {
+				return fmt.Errorf("invalid number of F64 values: got=%d, want=%d", len(f64), n)
+			}
+			got = append(got, ctx.Entry)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read tree: %+v", err)
+		}
+		return got
+	}
+
+	for _, tc := range []struct {
+		name string
+		tree Tree
+		opts []ReadOption
+		want []int64
+	}{
+		{
+			name: "chain-entry-list",
+			tree: ch,
+			opts: []ReadOption{WithEntryList(elist)},
+			want: selected,
+		},
+		{
+			name: "chain-entry-list-from-file",
+			tree: ch,
+			opts: []ReadOption{WithEntryList(rlist)},
+			want: selected,
+		},
+		{
+			name: "chain-event-list",
+			tree: ch,
+			opts: []ReadOption{WithEntryList(evlist)},
+			want: selected,
+		},
+		{
+			name: "chain-range",
+			tree: ch,
+			opts: []ReadOption{WithEntryList(elist), WithRange(42, 10001)},
+			want: []int64{42, 3999, 4000, 5000, 9999, 10000},
+		},
+		{
+			name: "chain-empty-range",
+			tree: ch,
+			opts: []ReadOption{WithEntryList(elist), WithRange(2, 10)},
+			want: nil,
+		},
+		{
+			name: "tree-sub-list",
+			tree: trees[1],
+			opts: []ReadOption{WithEntryList(rlist)},
+			want: []int64{0, 1, 5000, 9999},
+		},
+		{
+			name: "tree-empty-list",
+			tree: trees[0],
+			opts: []ReadOption{WithEntryList(NewEntryList("empty", "", trees[0]))},
+			want: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := read(t, tc.tree, tc.opts...)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("concurrent", func(t *testing.T) {
+		var (
+			i64  = make([]int64, 4)
+			seen = make([][]bool, 4)
+		)
+		err := ReadConcurrent(ch, 4, func(i int) (ReadTask, error) {
+			seen[i] = make([]bool, ch.Entries())
+			return ReadTask{
+				RVars: []ReadVar{{Name: "I64", Value: &i64[i]}},
+				Read: func(ctx RCtx) error {
+					seen[i][i64[i]] = true
+					return nil
+				},
+			}, nil
+		}, nil, WithEntryList(elist))
+		if err != nil {
+			t.Fatalf("could not read chain concurrently: %+v", err)
+		}
+
+		var got []int64
+		for entry := int64(0); entry < ch.Entries(); entry++ {
+			for i := range seen {
+				if seen[i] != nil && seen[i][entry] {
+					got = append(got, entry)
+				}
+			}
+		}
+		if !reflect.DeepEqual(got, selected) {
+			t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, selected)
+		}
+	})
+
+	t.Run("scanner", func(t *testing.T) {
+		var i64 int64
+		sc, err := NewScannerVars(ch, ReadVar{Name: "I64", Value: &i64})
+		if err != nil {
+			t.Fatalf("could not create scanner: %+v", err)
+		}
+		defer sc.Close()
+
+		err = sc.SetEntryList(rlist)
+		if err != nil {
+			t.Fatalf("could not set entry list: %+v", err)
+		}
+
+		var got []int64
+		for sc.Next() {
+			err := sc.Scan()
+			if err != nil {
+				t.Fatalf("could not scan entry %d: %+v", sc.Entry(), err)
+			}
+			if i64 != sc.Entry() {
+				t.Fatalf("invalid entry: got=%d, want=%d", i64, sc.Entry())
+			}
+			got = append(got, i64)
+		}
+		if err := sc.Err(); err != nil {
+			t.Fatalf("scanner error: %+v", err)
+		}
+		if !reflect.DeepEqual(got, selected) {
+			t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, selected)
+		}
+
+		err = sc.SeekEntry(9000)
+		if err != nil {
+			t.Fatalf("could not seek entry: %+v", err)
+		}
+		if !sc.Next() {
+			t.Fatalf("could not go to next entry")
+		}
+		if got, want := sc.Entry(), int64(9999); got != want {
+			t.Fatalf("invalid entry after seek: got=%d, want=%d", got, want)
+		}
+	})
+
+	t.Run("out-of-range", func(t *testing.T) {
+		list := NewEventList("bad", "")
+		list.Enter(nevts)
+
+		_, err := NewReader(trees[0], []ReadVar{{Name: "I64", Value: new(int64)}}, WithEntryList(list))
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		if got, want := err.Error(), `rtree: could not create reader: rtree: entry list "bad" has entry 10000 out of range [0, 10000)`; got != want {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+		}
+	})
+}
+
+func TestReaderEntryListFromROOT(t *testing.T) {
+	if !rtests.HasROOT {
+		t.Skip("skip test: no C++ ROOT")
+	}
+
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const (
+		nevts = 10000
+		nfile = 2
+	)
+
+	// sparse selections are stored as lists of entries, dense ones as
+	// bit-arrays, by ROOT's TEntryListBlock.
+	const code = `#include <iostream>
+#include "TChain.h"
+#include "TFile.h"
+#include "TTree.h"
+
+void genlists(const char* fname0, const char* fname1, const char* oname) {
+	const char* fnames[] = {fname0, fname1};
+	for (int i = 0; i < 2; i++) {
+		auto f = TFile::Open(fnames[i], "RECREATE");
+		auto t = new TTree("tree", "tree");
+		Long64_t i64;
+		t->Branch("I64", &i64, "I64/L");
+		for (Long64_t j = 0; j < 10000; j++) {
+			i64 = i * 10000 + j;
+			t->Fill();
+		}
+		f->Write();
+		f->Close();
+	}
+
+	TChain chain("tree");
+	chain.Add(fname0);
+	chain.Add(fname1);
+
+	auto o = TFile::Open(oname, "RECREATE");
+	chain.Draw(">>sparse", "I64 % 997 == 0", "entrylist");
+	chain.Draw(">>dense", "I64 % 3 == 0", "entrylist");
+	chain.Draw(">>evlist", "I64 % 997 == 0");
+	for (auto name : {"sparse", "dense", "evlist"}) {
+		if (!o->Get(name)) {
+			std::cerr << "could not create list [" << name << "]\n";
+			exit(1);
+		}
+	}
+	o->Write();
+	o->Close();
+}
+`
+
+	var (
+		fnames = make([]string, nfile)
+		oname  = filepath.Join(tmp, "lists.root")
+	)
+	for i := range fnames {
+		fnames[i] = filepath.Join(tmp, fmt.Sprintf("data-%d.root", i))
+	}
+
+	out, err := rtests.RunCxxROOT("genlists", []byte(code), fnames[0], fnames[1], oname)
+	if err != nil {
+		t.Fatalf("could not run C++ ROOT: %+v\noutput:\n%s", err, out)
+	}
+
+	var trees []Tree
+	for _, fname := range fnames {
+		tree, done := openTree(t, fname, "tree")
+		defer done()
+		trees = append(trees, tree)
+	}
+	ch := Chain(trees...)
+
+	f, err := riofs.Open(oname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	for _, tc := range []struct {
+		name  string
+		class string
+		mod   int64
+	}{
+		{name: "sparse", class: "TEntryList", mod: 997},
+		{name: "dense", class: "TEntryList", mod: 3},
+		{name: "evlist", class: "TEventList", mod: 997},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := riofs.Dir(f).Get(tc.name)
+			if err != nil {
+				t.Fatalf("could not read list: %+v", err)
+			}
+			if got, want := o.Class(), tc.class; got != want {
+				t.Fatalf("invalid list class: got=%q, want=%q", got, want)
+			}
+			list := o.(EntrySelection)
+
+			var want []int64
+			for entry := int64(0); entry < nfile*nevts; entry++ {
+				if entry%tc.mod == 0 {
+					want = append(want, entry)
+				}
+			}
+			if got, want := list.Len(), int64(len(want)); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			var (
+				i64 int64
+				got []int64
+			)
+			r, err := NewReader(ch, []ReadVar{{Name: "I64", Value: &i64}}, WithEntryList(list))
+			if err != nil {
+				t.Fatalf("could not create reader: %+v", err)
+			}
+			defer r.Close()
+
+			err = r.Read(func(ctx RCtx) error {
+				if i64 != ctx.Entry {
+					return fmt.Errorf("invalid entry: got=%d, want=%d", i64, ctx.Entry)
+				}
+				got = append(got, ctx.Entry)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read chain: %+v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
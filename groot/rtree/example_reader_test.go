@@ -103,6 +103,75 @@ func ExampleReader_withRange() {
 	// evt[2]: 3, 3.3, tres
 }
 
+func ExampleReader_withEntryList() {
+	f, err := groot.Open("../testdata/simple.root")
+	if err != nil {
+		log.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("tree")
+	if err != nil {
+		log.Fatalf("could not retrieve ROOT tree: %+v", err)
+	}
+	t := o.(rtree.Tree)
+
+	// first pass: select the entries of interest.
+	list := rtree.NewEntryList("sel", "one is even", t)
+	{
+		r, err := rtree.NewReader(t, nil)
+		if err != nil {
+			log.Fatalf("could not create tree reader: %+v", err)
+		}
+		defer r.Close()
+
+		cut, err := r.FormulaExpr("one % 2 == 0")
+		if err != nil {
+			log.Fatalf("could not create selection: %+v", err)
+		}
+		sel := cut.Func().(func() float64)
+
+		err = r.Read(func(ctx rtree.RCtx) error {
+			if sel() != 0 {
+				list.Enter(ctx.Entry)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("could not process tree: %+v", err)
+		}
+	}
+
+	// second pass: only visit the selected entries.
+	var (
+		v1 int32
+		v3 string
+
+		rvars = []rtree.ReadVar{
+			{Name: "one", Value: &v1},
+			{Name: "three", Value: &v3},
+		}
+	)
+
+	r, err := rtree.NewReader(t, rvars, rtree.WithEntryList(list))
+	if err != nil {
+		log.Fatalf("could not create tree reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx rtree.RCtx) error {
+		fmt.Printf("evt[%d]: %v, %v\n", ctx.Entry, v1, v3)
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("could not process tree: %+v", err)
+	}
+
+	// Output:
+	// evt[1]: 2, dos
+	// evt[3]: 4, quatro
+}
+
 func ExampleReader_withChain() {
 	f, err := groot.Open("../testdata/simple.root")
 	if err != nil {
@@ -414,3 +483,52 @@ func ExampleReader_withFormulaFromUser() {
 	// evt[2]: 3, 3.3, tres -> 3433 | "one": 3, "two": 3.3, "three": tres
 	// evt[3]: 4, 4.4, quatro -> 4644 | "one": 4, "two": 4.4, "three": quatro
 }
+
+func ExampleReadConcurrent() {
+	f, err := groot.Open("../testdata/simple.root")
+	if err != nil {
+		log.Fatalf("could not open ROOT file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := f.Get("tree")
+	if err != nil {
+		log.Fatalf("could not retrieve ROOT tree: %+v", err)
+	}
+	t := o.(rtree.Tree)
+
+	const nworkers = 4
+
+	var (
+		sums = make([]float64, nworkers)
+		tot  float64
+	)
+
+	err = rtree.ReadConcurrent(t, nworkers, func(i int) (rtree.ReadTask, error) {
+		var (
+			v1 int32
+			v2 float32
+		)
+		return rtree.ReadTask{
+			RVars: []rtree.ReadVar{
+				{Name: "one", Value: &v1},
+				{Name: "two", Value: &v2},
+			},
+			Read: func(ctx rtree.RCtx) error {
+				sums[i] += float64(v1) + float64(v2)
+				return nil
+			},
+		}, nil
+	}, func(i int) error {
+		tot += sums[i]
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("could not process tree: %+v", err)
+	}
+
+	fmt.Printf("sum: %.1f\n", tot)
+
+	// Output:
+	// sum: 21.0
+}
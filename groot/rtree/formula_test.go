@@ -536,3 +536,115 @@ func BenchmarkFormulaFunc(b *testing.B) {
 		})
 	}
 }
+
+func TestFormulaExpr(t *testing.T) {
+	for _, tc := range []struct {
+		fname string
+		tname string
+		expr  string
+		want  []float64
+		err   error
+	}{
+		{
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			expr:  "one",
+			want:  []float64{1, 2},
+		},
+		{
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			expr:  "2*one - 1",
+			want:  []float64{1, 3},
+		},
+		{
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			expr:  "one > 1 && abs(two) < 2.4",
+			want:  []float64{0, 1},
+		},
+		{
+			fname: "../testdata/leaves.root",
+			tname: "tree",
+			expr:  "Sum$(ArrU64) + ArrU64[9]",
+			want:  []float64{0, 11},
+		},
+		{
+			fname: "../testdata/leaves.root",
+			tname: "tree",
+			expr:  "Length$(SliF32) + Max$(2*SliF32)",
+			want:  []float64{0, 3},
+		},
+		{
+			fname: "../testdata/leaves.root",
+			tname: "tree",
+			expr:  "D16 + D32",
+			want:  []float64{0, 2},
+		},
+		{
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			expr:  "one + xxx",
+			err:   fmt.Errorf(`rtree: could not create formula: rtree: could not find all needed ReadVars (missing: [xxx])`),
+		},
+		{
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			expr:  "one +",
+			err:   fmt.Errorf(`rtree: could not create formula: rfunc: could not parse expression "one +": unexpected end of expression`),
+		},
+		{
+			fname: "../testdata/simple.root",
+			tname: "tree",
+			expr:  "three",
+			err:   fmt.Errorf(`rtree: could not create formula: rtree: could not bind formula to rvars: rfunc: argument type 0 (name=three) invalid: invalid type *string`),
+		},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			f, err := riofs.Open(tc.fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			o, err := riofs.Dir(f).Get(tc.tname)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tree := o.(Tree)
+
+			r, err := NewReader(tree, nil, WithRange(0, 2))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			form, err := r.FormulaExpr(tc.expr)
+			switch {
+			case err != nil && tc.err != nil:
+				if got, want := err.Error(), tc.err.Error(); got != want {
+					t.Fatalf("invalid error.\ngot= %v\nwant=%v", got, want)
+				}
+				return
+			case err != nil && tc.err == nil:
+				t.Fatalf("unexpected error: %+v", err)
+			case err == nil && tc.err != nil:
+				t.Fatalf("expected an error: %v (got=nil)", tc.err)
+			case err == nil && tc.err == nil:
+				// ok.
+			}
+
+			fct := form.Func().(func() float64)
+			err = r.Read(func(ctx RCtx) error {
+				if got, want := fct(), tc.want[ctx.Entry]; got != want {
+					return fmt.Errorf("entry[%d]: invalid form-eval: got=%v, want=%v", ctx.Entry, got, want)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("error: %+v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"strings"
+
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtree/rfunc"
+)
+
+type friend struct {
+	main     Tree
+	friends  []Tree
+	branches []Branch
+	leaves   []Leaf
+}
+
+// Friend returns a new Tree that represents the main tree t, augmented
+// with the columns of the provided friend trees.
+// The returned tree has the name, title and number of entries of the main
+// tree.
+//
+// For each entry of the main tree, the matching entry of a friend tree is
+// located through the index attached to the friend tree (see BuildIndex
+// and IndexOf), if any:
+// the major and minor expressions of the index are evaluated against the
+// main tree and the resulting (major, minor) key is looked up in the index.
+// Friend trees without an index are matched by entry number.
+// When no matching entry exists in a friend tree, the values read from
+// that friend tree are set to their zero value.
+//
+// Branches of the main tree shadow branches of the friend trees with the
+// same name, and branches of a friend tree shadow branches with the same
+// name of the friend trees that follow it.
+// The branches of a friend tree are also always accessible with the name
+// of the friend tree as a prefix, as in "calib.run".
+//
+// Friend errors out if no friend tree is provided, if two trees have
+// the same name or if the index of a friend tree needs a branch missing
+// from the main tree.
+func Friend(t Tree, friends ...Tree) (Tree, error) {
+	if len(friends) == 0 {
+		return nil, fmt.Errorf("rtree: no friend trees")
+	}
+
+	names := map[string]struct{}{t.Name(): {}}
+	for _, f := range friends {
+		if _, dup := names[f.Name()]; dup {
+			return nil, fmt.Errorf("rtree: duplicate friend tree name %q", f.Name())
+		}
+		names[f.Name()] = struct{}{}
+	}
+
+	err := checkIndexKeys(t, friends)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &friend{
+		main:     t,
+		friends:  friends,
+		branches: append([]Branch(nil), t.Branches()...),
+		leaves:   append([]Leaf(nil), t.Leaves()...),
+	}
+
+	for i, f := range friends {
+		for _, b := range f.Branches() {
+			if tree.owner(b.Name()) != i {
+				continue
+			}
+			tree.branches = append(tree.branches, b)
+		}
+		for _, leaf := range f.Leaves() {
+			if tree.Leaf(leaf.Name()) != leaf {
+				continue
+			}
+			tree.leaves = append(tree.leaves, leaf)
+		}
+	}
+
+	return tree, nil
+}
+
+// checkIndexKeys checks that the branches needed by the index keys of the
+// friend trees exist in the main tree.
+func checkIndexKeys(t Tree, friends []Tree) error {
+	var names map[string]struct{} // names of the main tree read-vars, loaded on demand.
+	for _, f := range friends {
+		idx := IndexOf(f)
+		if idx == nil {
+			continue
+		}
+		for _, expr := range []string{idx.MajorName(), idx.MinorName()} {
+			if expr == "" {
+				continue
+			}
+			form, err := rfunc.NewExprFormula(expr)
+			if err != nil {
+				return fmt.Errorf("rtree: invalid index key %q of friend tree %q: %w", expr, f.Name(), err)
+			}
+			if names == nil {
+				names = make(map[string]struct{})
+				for _, rv := range NewReadVars(t) {
+					names[rv.Name] = struct{}{}
+				}
+			}
+			for _, name := range form.RVars() {
+				if _, ok := names[name]; !ok {
+					return fmt.Errorf(
+						"rtree: tree %q has no branch named %q (needed by index key %q of friend tree %q)",
+						t.Name(), name, expr, f.Name(),
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Class returns the ROOT class of the argument.
+func (*friend) Class() string {
+	return "TFriend"
+}
+
+// Name returns the name of the ROOT objet in the argument.
+func (t *friend) Name() string {
+	return t.main.Name()
+}
+
+// Title returns the title of the ROOT object in the argument.
+func (t *friend) Title() string {
+	return t.main.Title()
+}
+
+// Entries returns the total number of entries.
+func (t *friend) Entries() int64 {
+	return t.main.Entries()
+}
+
+// Branches returns the list of branches.
+// Shadowed branches of friend trees are not part of the list.
+func (t *friend) Branches() []Branch {
+	return t.branches
+}
+
+// Branch returns the branch whose name is the argument.
+func (t *friend) Branch(name string) Branch {
+	switch i, name := t.resolve(name); i {
+	case -2:
+		return nil
+	case -1:
+		return t.main.Branch(name)
+	default:
+		return t.friends[i].Branch(name)
+	}
+}
+
+// Leaves returns direct pointers to individual branch leaves.
+func (t *friend) Leaves() []Leaf {
+	return t.leaves
+}
+
+// Leaf returns the leaf whose name is the argument.
+func (t *friend) Leaf(name string) Leaf {
+	if leaf := t.main.Leaf(name); leaf != nil {
+		return leaf
+	}
+	for _, f := range t.friends {
+		if leaf := f.Leaf(name); leaf != nil {
+			return leaf
+		}
+	}
+	return nil
+}
+
+// owner returns the index of the friend tree holding the named branch,
+// or -1 if the branch belongs to the main tree.
+// owner returns -2 if no tree holds the named branch.
+func (t *friend) owner(name string) int {
+	i, _ := t.resolve(name)
+	return i
+}
+
+// resolve returns the index of the friend tree holding the named branch
+// and the name of that branch within its tree.
+// The index is -1 for the main tree and -2 if no tree holds the branch.
+func (t *friend) resolve(name string) (int, string) {
+	if t.main.Branch(name) != nil {
+		return -1, name
+	}
+	for i, f := range t.friends {
+		if f.Branch(name) != nil {
+			return i, name
+		}
+	}
+	for i, f := range t.friends {
+		prefix := f.Name() + "."
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		sub := name[len(prefix):]
+		if f.Branch(sub) != nil {
+			return i, sub
+		}
+	}
+	return -2, name
+}
+
+// aliasOf returns the name under which the named branch of the i-th
+// friend tree is accessible from the friend tree t.
+func (t *friend) aliasOf(i int, name string) string {
+	if t.owner(name) == i {
+		return name
+	}
+	return t.friends[i].Name() + "." + name
+}
+
+var (
+	_ root.Object = (*friend)(nil)
+	_ root.Named  = (*friend)(nil)
+	_ Tree        = (*friend)(nil)
+)
@@ -0,0 +1,330 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+type calibEvt struct {
+	Run  int32
+	Gain float64
+	N    int32
+	Peds []float64 `groot:"Peds[N]"`
+}
+
+func createCalibTree(t *testing.T, fname, tname string, evts []calibEvt) {
+	t.Helper()
+
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	var evt calibEvt
+	w, err := NewWriter(f, tname, WriteVarsFromStruct(&evt), WithBasketSize(32))
+	if err != nil {
+		t.Fatalf("could not create writer: %+v", err)
+	}
+	for _, v := range evts {
+		evt = v
+		evt.N = int32(len(evt.Peds))
+		_, err = w.Write()
+		if err != nil {
+			t.Fatalf("could not write event: %+v", err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close writer: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+}
+
+func TestFriend(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var (
+		evts = filepath.Join(tmp, "evts.root")
+		cal1 = filepath.Join(tmp, "calib-1.root")
+		cal2 = filepath.Join(tmp, "calib-2.root")
+	)
+
+	createIndexTree(t, evts, "evts", []indexEvt{
+		{Run: 1, Event: 1, E: 10},
+		{Run: 1, Event: 2, E: 20},
+		{Run: 2, Event: 1, E: 30},
+		{Run: 3, Event: 1, E: 40},
+		{Run: 4, Event: 1, E: 50},
+		{Run: 4, Event: 2, E: 60},
+	})
+	createCalibTree(t, cal1, "calib", []calibEvt{
+		{Run: 4, Gain: 4, Peds: []float64{4, 4}},
+		{Run: 1, Gain: 1, Peds: []float64{1}},
+	})
+	createCalibTree(t, cal2, "calib", []calibEvt{
+		{Run: 6, Gain: 6},
+		{Run: 7, Gain: 7, Peds: []float64{7}},
+		{Run: 2, Gain: 2, Peds: []float64{2, 2, 2}},
+	})
+
+	main, done := openTree(t, evts, "evts")
+	defer done()
+
+	type entry struct {
+		Run  int32
+		E    float64
+		CRun int32
+		Gain float64
+		Peds []float64
+	}
+
+	read := func(t *testing.T, tree Tree, calib string) []entry {
+		t.Helper()
+
+		var (
+			evt  entry
+			rvar = []ReadVar{
+				{Name: "Run", Value: &evt.Run},
+				{Name: "E", Value: &evt.E},
+				{Name: calib + ".Run", Value: &evt.CRun},
+				{Name: "Gain", Value: &evt.Gain},
+				{Name: "Peds", Value: &evt.Peds},
+			}
+			got []entry
+		)
+
+		r, err := NewReader(tree, rvar)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		err = r.Read(func(ctx RCtx) error {
+			v := evt
+			v.Peds = append([]float64(nil), evt.Peds...)
+			got = append(got, v)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read tree: %+v", err)
+		}
+		return got
+	}
+
+	t.Run("index", func(t *testing.T) {
+		calib, done := openTree(t, cal1, "calib")
+		defer done()
+
+		_, err := BuildIndex(calib, "Run", "")
+		if err != nil {
+			t.Fatalf("could not build index: %+v", err)
+		}
+
+		tree, err := Friend(main, calib)
+		if err != nil {
+			t.Fatalf("could not create friend tree: %+v", err)
+		}
+
+		if got, want := tree.Entries(), main.Entries(); got != want {
+			t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+		}
+		if got, want := tree.Name(), main.Name(); got != want {
+			t.Fatalf("invalid name: got=%q, want=%q", got, want)
+		}
+
+		var names []string
+		for _, b := range tree.Branches() {
+			names = append(names, b.Name())
+		}
+		if got, want := names, []string{"Run", "Event", "E", "Gain", "N", "Peds"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid branches:\ngot= %q\nwant=%q", got, want)
+		}
+
+		if got, want := tree.Branch("Run"), main.Branch("Run"); got != want {
+			t.Fatalf("main branch not shadowing friend branch")
+		}
+		if got, want := tree.Branch("calib.Run"), calib.Branch("Run"); got != want {
+			t.Fatalf("friend branch not accessible with its prefix")
+		}
+		if got, want := tree.Branch("calib.Gain"), calib.Branch("Gain"); got != want {
+			t.Fatalf("friend branch not accessible with its prefix")
+		}
+		if got := tree.Branch("calib.NotThere"); got != nil {
+			t.Fatalf("unexpected branch: %v", got)
+		}
+
+		var rvars []string
+		for _, rv := range NewReadVars(tree) {
+			rvars = append(rvars, rv.Name)
+		}
+		if got, want := rvars, []string{"Run", "Event", "E", "Gain", "N", "Peds", "calib.Run"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid read-vars:\ngot= %q\nwant=%q", got, want)
+		}
+
+		got := read(t, tree, "calib")
+		want := []entry{
+			{Run: 1, E: 10, CRun: 1, Gain: 1, Peds: []float64{1}},
+			{Run: 1, E: 20, CRun: 1, Gain: 1, Peds: []float64{1}},
+			{Run: 2, E: 30},
+			{Run: 3, E: 40},
+			{Run: 4, E: 50, CRun: 4, Gain: 4, Peds: []float64{4, 4}},
+			{Run: 4, E: 60, CRun: 4, Gain: 4, Peds: []float64{4, 4}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, want)
+		}
+
+		r, err := NewReader(tree, nil)
+		if err != nil {
+			t.Fatalf("could not create reader: %+v", err)
+		}
+		defer r.Close()
+
+		form, err := r.FormulaExpr("E * Gain + calib.Run")
+		if err != nil {
+			t.Fatalf("could not create formula: %+v", err)
+		}
+		fct := form.Func().(func() float64)
+
+		var vals []float64
+		err = r.Read(func(ctx RCtx) error {
+			vals = append(vals, fct())
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("could not read tree: %+v", err)
+		}
+		if got, want := vals, []float64{11, 21, 0, 0, 204, 244}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid formula values:\ngot= %v\nwant=%v", got, want)
+		}
+	})
+
+	t.Run("chain-index", func(t *testing.T) {
+		c1, done1 := openTree(t, cal1, "calib")
+		defer done1()
+		c2, done2 := openTree(t, cal2, "calib")
+		defer done2()
+
+		calib := Chain(c1, c2)
+		_, err := BuildIndex(calib, "Run", "")
+		if err != nil {
+			t.Fatalf("could not build index: %+v", err)
+		}
+
+		tree, err := Friend(main, calib)
+		if err != nil {
+			t.Fatalf("could not create friend tree: %+v", err)
+		}
+
+		got := read(t, tree, "calib")
+		want := []entry{
+			{Run: 1, E: 10, CRun: 1, Gain: 1, Peds: []float64{1}},
+			{Run: 1, E: 20, CRun: 1, Gain: 1, Peds: []float64{1}},
+			{Run: 2, E: 30, CRun: 2, Gain: 2, Peds: []float64{2, 2, 2}},
+			{Run: 3, E: 40},
+			{Run: 4, E: 50, CRun: 4, Gain: 4, Peds: []float64{4, 4}},
+			{Run: 4, E: 60, CRun: 4, Gain: 4, Peds: []float64{4, 4}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, want)
+		}
+	})
+
+	t.Run("positional", func(t *testing.T) {
+		calib, done := openTree(t, cal2, "calib")
+		defer done()
+
+		tree, err := Friend(main, calib)
+		if err != nil {
+			t.Fatalf("could not create friend tree: %+v", err)
+		}
+
+		got := read(t, tree, "calib")
+		want := []entry{
+			{Run: 1, E: 10, CRun: 6, Gain: 6},
+			{Run: 1, E: 20, CRun: 7, Gain: 7, Peds: []float64{7}},
+			{Run: 2, E: 30, CRun: 2, Gain: 2, Peds: []float64{2, 2, 2}},
+			{Run: 3, E: 40},
+			{Run: 4, E: 50},
+			{Run: 4, E: 60},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid entries:\ngot= %v\nwant=%v", got, want)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Friend(main)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		if got, want := err.Error(), "rtree: no friend trees"; got != want {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+		}
+
+		_, err = Friend(main, main)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		if got, want := err.Error(), `rtree: duplicate friend tree name "evts"`; got != want {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+		}
+	})
+
+	t.Run("missing-index-key", func(t *testing.T) {
+		calib, done := openTree(t, cal1, "calib")
+		defer done()
+
+		events, done := openTree(t, evts, "evts")
+		defer done()
+
+		// index evts on a branch missing from the calib (main) tree,
+		// once the friend tree has been created.
+		tree, err := Friend(calib, events)
+		if err != nil {
+			t.Fatalf("could not create friend tree: %+v", err)
+		}
+
+		_, err = BuildIndex(events, "Run", "Event")
+		if err != nil {
+			t.Fatalf("could not build index: %+v", err)
+		}
+
+		const want = `rtree: tree "calib" has no branch named "Event" (needed by index key "Event")`
+
+		_, err = NewReader(tree, []ReadVar{{Name: "E", Value: new(float64)}})
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		if got := err.Error(); !strings.Contains(got, want) {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+		}
+
+		// index keys are checked when the friend tree is attached.
+		_, err = Friend(calib, events)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		if got, want := err.Error(), `rtree: tree "calib" has no branch named "Event" (needed by index key "Event" of friend tree "evts")`; got != want {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+		}
+	})
+}
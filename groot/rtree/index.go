@@ -0,0 +1,511 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rdict"
+	"go-hep.org/x/hep/groot/rmeta"
+	"go-hep.org/x/hep/groot/root"
+	"go-hep.org/x/hep/groot/rtypes"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+// Index describes a mapping between (major, minor) key values and tree
+// entries, such as (run, event) pairs.
+//
+// Index is the equivalent of ROOT's TVirtualIndex.
+type Index interface {
+	root.Named
+
+	// MajorName returns the expression of the major key of the index.
+	MajorName() string
+	// MinorName returns the expression of the minor key of the index.
+	MinorName() string
+
+	// Entry returns the tree entry whose key is (major, minor).
+	// Entry returns -1 if no entry matches the key.
+	Entry(major, minor int64) int64
+}
+
+// IndexOf returns the index attached to the provided tree, if any.
+func IndexOf(t Tree) Index {
+	switch t := t.(type) {
+	case *ttree:
+		if idx, ok := t.treeIndex.(Index); ok {
+			return idx
+		}
+	case *chain:
+		return t.index
+	}
+	return nil
+}
+
+// BuildIndex builds an index for the provided tree from the major and minor
+// expressions, evaluated for each entry of the tree, and attaches it to the
+// tree.
+// An empty minor expression is equivalent to "0".
+//
+// The major and minor expressions follow the syntax of rfunc.NewExprFormula.
+// Their values are truncated to integers.
+//
+// For chains, BuildIndex builds (and attaches) an index for each tree of the
+// chain.
+// The chain index then dispatches lookups to the index of the tree whose
+// range of keys contains the requested key.
+// If the ranges of keys of the trees of the chain overlap, a single index
+// spanning the whole chain is built instead.
+func BuildIndex(t Tree, major, minor string) (Index, error) {
+	switch t := t.(type) {
+	case *ttree:
+		idx, err := newTreeIndex(t, major, minor)
+		if err != nil {
+			return nil, err
+		}
+		t.treeIndex = idx
+		return idx, nil
+
+	case *chain:
+		idx, err := newChainIndex(t, major, minor)
+		if err != nil {
+			return nil, err
+		}
+		t.index = idx
+		return idx, nil
+
+	default:
+		return nil, fmt.Errorf("rtree: can not build index for tree type %T", t)
+	}
+}
+
+// treeIndex is a tree index, sorted by (major, minor) key values.
+type treeIndex struct {
+	named rbase.Named
+	major string // major expression
+	minor string // minor expression
+	maj   []int64
+	min   []int64
+	index []int64 // tree entries of the sorted (major, minor) key values
+}
+
+func newTreeIndex(t Tree, major, minor string) (*treeIndex, error) {
+	if minor == "" {
+		minor = "0"
+	}
+
+	r, err := NewReader(t, nil)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create reader for index: %w", err)
+	}
+	defer r.Close()
+
+	fmaj, err := r.FormulaExpr(major)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create index major formula: %w", err)
+	}
+	fmin, err := r.FormulaExpr(minor)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create index minor formula: %w", err)
+	}
+
+	var (
+		n    = t.Entries()
+		keys = fmaj.Func().(func() float64)
+		vals = fmin.Func().(func() float64)
+		idx  = &treeIndex{
+			named: *rbase.NewNamed("", ""),
+			major: major,
+			minor: minor,
+			maj:   make([]int64, 0, n),
+			min:   make([]int64, 0, n),
+			index: make([]int64, 0, n),
+		}
+	)
+
+	err = r.Read(func(ctx RCtx) error {
+		idx.maj = append(idx.maj, int64(keys()))
+		idx.min = append(idx.min, int64(vals()))
+		idx.index = append(idx.index, ctx.Entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not build index: %w", err)
+	}
+
+	sort.Stable(idx)
+	return idx, nil
+}
+
+func (*treeIndex) Class() string     { return "TTreeIndex" }
+func (*treeIndex) RVersion() int16   { return rvers.TreeIndex }
+func (idx *treeIndex) Name() string  { return idx.named.Name() }
+func (idx *treeIndex) Title() string { return idx.named.Title() }
+
+// MajorName returns the expression of the major key of the index.
+func (idx *treeIndex) MajorName() string { return idx.major }
+
+// MinorName returns the expression of the minor key of the index.
+func (idx *treeIndex) MinorName() string { return idx.minor }
+
+// Entry returns the tree entry whose key is (major, minor).
+// Entry returns -1 if no entry matches the key.
+func (idx *treeIndex) Entry(major, minor int64) int64 {
+	i := sort.Search(len(idx.maj), func(i int) bool {
+		return !lessKey(idx.maj[i], idx.min[i], major, minor)
+	})
+	if i < len(idx.maj) && idx.maj[i] == major && idx.min[i] == minor {
+		return idx.index[i]
+	}
+	return -1
+}
+
+// bounds returns the smallest and largest keys of the index.
+func (idx *treeIndex) bounds() (lo, hi chainIndexKey) {
+	n := len(idx.maj)
+	if n == 0 {
+		return lo, hi
+	}
+	lo = chainIndexKey{idx.maj[0], idx.min[0]}
+	hi = chainIndexKey{idx.maj[n-1], idx.min[n-1]}
+	return lo, hi
+}
+
+func (idx *treeIndex) Len() int { return len(idx.index) }
+func (idx *treeIndex) Less(i, j int) bool {
+	return lessKey(idx.maj[i], idx.min[i], idx.maj[j], idx.min[j])
+}
+func (idx *treeIndex) Swap(i, j int) {
+	idx.maj[i], idx.maj[j] = idx.maj[j], idx.maj[i]
+	idx.min[i], idx.min[j] = idx.min[j], idx.min[i]
+	idx.index[i], idx.index[j] = idx.index[j], idx.index[i]
+}
+
+func lessKey(imaj, imin, jmaj, jmin int64) bool {
+	if imaj != jmaj {
+		return imaj < jmaj
+	}
+	return imin < jmin
+}
+
+func (idx *treeIndex) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(idx.RVersion())
+	if n, err := marshalVirtualIndex(w, &idx.named); err != nil {
+		return n, err
+	}
+	w.WriteString(idx.major)
+	w.WriteString(idx.minor)
+	w.WriteI64(int64(len(idx.index)))
+	w.WriteFastArrayI64(idx.maj)
+	w.WriteFastArrayI64(idx.min)
+	w.WriteFastArrayI64(idx.index)
+
+	return w.SetByteCount(pos, idx.Class())
+}
+
+func (idx *treeIndex) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(idx.Class())
+	if vers > rvers.TreeIndex {
+		panic(fmt.Errorf("rtree: invalid TTreeIndex version=%d > %d", vers, rvers.TreeIndex))
+	}
+
+	if err := unmarshalVirtualIndex(r, &idx.named); err != nil {
+		return err
+	}
+
+	idx.major = r.ReadString()
+	idx.minor = r.ReadString()
+	n := int(r.ReadI64())
+
+	idx.maj = make([]int64, n)
+	r.ReadArrayI64(idx.maj)
+
+	switch {
+	case vers > 1:
+		idx.min = make([]int64, n)
+		r.ReadArrayI64(idx.min)
+	default:
+		// v1 indices pack the major and minor values together.
+		const shift = 31
+		idx.min = make([]int64, n)
+		for i, v := range idx.maj {
+			idx.maj[i] = v >> shift
+			idx.min[i] = v & (1<<shift - 1)
+		}
+	}
+
+	idx.index = make([]int64, n)
+	r.ReadArrayI64(idx.index)
+
+	r.CheckByteCount(pos, bcnt, beg, idx.Class())
+	return r.Err()
+}
+
+// chainIndexKey is a (major, minor) key.
+type chainIndexKey struct {
+	maj int64
+	min int64
+}
+
+func (k chainIndexKey) less(o chainIndexKey) bool {
+	return lessKey(k.maj, k.min, o.maj, o.min)
+}
+
+// chainIndexEntry describes the range of keys held by a tree of a chain.
+type chainIndexEntry struct {
+	lo chainIndexKey // smallest key
+	hi chainIndexKey // largest key
+}
+
+// chainIndex is a chain index, dispatching lookups to the indices of the
+// trees of a chain.
+type chainIndex struct {
+	named   rbase.Named
+	major   string // major expression
+	minor   string // minor expression
+	entries []chainIndexEntry
+
+	trees []Index // indices of the trees of the chain (transient)
+	offs  []int64 // number of entries before each tree of the chain (transient)
+}
+
+func newChainIndex(ch *chain, major, minor string) (Index, error) {
+	if minor == "" {
+		minor = "0"
+	}
+
+	idx := &chainIndex{
+		named:   *rbase.NewNamed("", ""),
+		major:   major,
+		minor:   minor,
+		entries: make([]chainIndexEntry, len(ch.trees)),
+		trees:   make([]Index, len(ch.trees)),
+		offs:    ch.offs,
+	}
+
+	sorted := true
+	for i, t := range ch.trees {
+		tidx, err := BuildIndex(t, major, minor)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not build index for tree %d of chain: %w", i, err)
+		}
+		idx.trees[i] = tidx
+
+		ti, ok := tidx.(*treeIndex)
+		if !ok || ti.Len() == 0 {
+			sorted = false
+			continue
+		}
+		lo, hi := ti.bounds()
+		idx.entries[i] = chainIndexEntry{lo: lo, hi: hi}
+		if i > 0 && !idx.entries[i-1].hi.less(lo) {
+			sorted = false
+		}
+	}
+
+	if !sorted {
+		// fall back to a single index over the whole chain.
+		return newTreeIndex(ch, major, minor)
+	}
+
+	return idx, nil
+}
+
+func (*chainIndex) Class() string     { return "TChainIndex" }
+func (*chainIndex) RVersion() int16   { return rvers.ChainIndex }
+func (idx *chainIndex) Name() string  { return idx.named.Name() }
+func (idx *chainIndex) Title() string { return idx.named.Title() }
+
+// MajorName returns the expression of the major key of the index.
+func (idx *chainIndex) MajorName() string { return idx.major }
+
+// MinorName returns the expression of the minor key of the index.
+func (idx *chainIndex) MinorName() string { return idx.minor }
+
+// Entry returns the chain entry whose key is (major, minor).
+// Entry returns -1 if no entry matches the key, or if the chain index is
+// not attached to the trees of a chain.
+func (idx *chainIndex) Entry(major, minor int64) int64 {
+	key := chainIndexKey{major, minor}
+	for i, e := range idx.entries {
+		if key.less(e.lo) || e.hi.less(key) {
+			continue
+		}
+		if i >= len(idx.trees) || idx.trees[i] == nil {
+			return -1
+		}
+		entry := idx.trees[i].Entry(major, minor)
+		if entry < 0 {
+			return -1
+		}
+		return idx.offs[i] + entry
+	}
+	return -1
+}
+
+func (idx *chainIndex) MarshalROOT(w *rbytes.WBuffer) (int, error) {
+	if w.Err() != nil {
+		return 0, w.Err()
+	}
+
+	pos := w.WriteVersion(idx.RVersion())
+	if n, err := marshalVirtualIndex(w, &idx.named); err != nil {
+		return n, err
+	}
+	w.WriteString(idx.major)
+	w.WriteString(idx.minor)
+
+	// std::vector<TChainIndex::TChainIndexEntry>, streamed member-wise.
+	beg := w.WriteVersion(rvers.StreamerInfo | rbytes.StreamedMemberWise)
+	w.WriteI16(0) // TChainIndexEntry has no class version, write its checksum.
+	w.WriteU32(uint32(chainIndexEntryInfo.CheckSum()))
+	w.WriteI32(int32(len(idx.entries)))
+	for _, e := range idx.entries {
+		w.WriteI64(e.lo.maj)
+	}
+	for _, e := range idx.entries {
+		w.WriteI64(e.lo.min)
+	}
+	for _, e := range idx.entries {
+		w.WriteI64(e.hi.maj)
+	}
+	for _, e := range idx.entries {
+		w.WriteI64(e.hi.min)
+	}
+	if _, err := w.SetByteCount(beg, "vector<TChainIndex::TChainIndexEntry>"); err != nil {
+		return 0, err
+	}
+
+	return w.SetByteCount(pos, idx.Class())
+}
+
+func (idx *chainIndex) UnmarshalROOT(r *rbytes.RBuffer) error {
+	if r.Err() != nil {
+		return r.Err()
+	}
+
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion(idx.Class())
+	if vers > rvers.ChainIndex {
+		panic(fmt.Errorf("rtree: invalid TChainIndex version=%d > %d", vers, rvers.ChainIndex))
+	}
+
+	if err := unmarshalVirtualIndex(r, &idx.named); err != nil {
+		return err
+	}
+
+	idx.major = r.ReadString()
+	idx.minor = r.ReadString()
+
+	{
+		const typename = "vector<TChainIndex::TChainIndexEntry>"
+		beg := r.Pos()
+		vers, pos, bcnt := r.ReadVersion(typename)
+		if vers&rbytes.StreamedMemberWise == 0 {
+			return fmt.Errorf("rtree: %s not streamed member-wise (vers=0x%x)", typename, vers)
+		}
+		if ev := r.ReadI16(); ev <= 0 {
+			_ = r.ReadU32() // checksum
+		}
+		n := int(r.ReadI32())
+		idx.entries = make([]chainIndexEntry, n)
+		for i := range idx.entries {
+			idx.entries[i].lo.maj = r.ReadI64()
+		}
+		for i := range idx.entries {
+			idx.entries[i].lo.min = r.ReadI64()
+		}
+		for i := range idx.entries {
+			idx.entries[i].hi.maj = r.ReadI64()
+		}
+		for i := range idx.entries {
+			idx.entries[i].hi.min = r.ReadI64()
+		}
+		r.CheckByteCount(pos, bcnt, beg, typename)
+	}
+
+	r.CheckByteCount(pos, bcnt, beg, idx.Class())
+	return r.Err()
+}
+
+// chainIndexEntryInfo is the streamer info of TChainIndex::TChainIndexEntry.
+var chainIndexEntryInfo = func() rbytes.StreamerInfo {
+	names := []string{"fMinIndexValue", "fMinIndexValMinor", "fMaxIndexValue", "fMaxIndexValMinor"}
+	elems := make([]rbytes.StreamerElement, len(names))
+	for i, name := range names {
+		elems[i] = &rdict.StreamerBasicType{StreamerElement: rdict.Element{
+			Name:  *rbase.NewNamed(name, ""),
+			Type:  rmeta.Long64,
+			Size:  8,
+			EName: "Long64_t",
+		}.New()}
+	}
+	return rdict.NewStreamerInfo("TChainIndex::TChainIndexEntry", 1, elems)
+}()
+
+func marshalVirtualIndex(w *rbytes.WBuffer, named *rbase.Named) (int, error) {
+	pos := w.WriteVersion(rvers.VirtualIndex)
+	if n, err := named.MarshalROOT(w); err != nil {
+		return n, err
+	}
+	return w.SetByteCount(pos, "TVirtualIndex")
+}
+
+func unmarshalVirtualIndex(r *rbytes.RBuffer, named *rbase.Named) error {
+	beg := r.Pos()
+	vers, pos, bcnt := r.ReadVersion("TVirtualIndex")
+	if vers > rvers.VirtualIndex {
+		panic(fmt.Errorf("rtree: invalid TVirtualIndex version=%d > %d", vers, rvers.VirtualIndex))
+	}
+	if err := named.UnmarshalROOT(r); err != nil {
+		return err
+	}
+	r.CheckByteCount(pos, bcnt, beg, "TVirtualIndex")
+	return r.Err()
+}
+
+func init() {
+	{
+		f := func() reflect.Value {
+			o := &treeIndex{named: *rbase.NewNamed("", "")}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TTreeIndex", f)
+	}
+	{
+		f := func() reflect.Value {
+			o := &chainIndex{named: *rbase.NewNamed("", "")}
+			return reflect.ValueOf(o)
+		}
+		rtypes.Factory.Add("TChainIndex", f)
+	}
+}
+
+var (
+	_ root.Object        = (*treeIndex)(nil)
+	_ root.Named         = (*treeIndex)(nil)
+	_ Index              = (*treeIndex)(nil)
+	_ rbytes.Marshaler   = (*treeIndex)(nil)
+	_ rbytes.Unmarshaler = (*treeIndex)(nil)
+
+	_ root.Object        = (*chainIndex)(nil)
+	_ root.Named         = (*chainIndex)(nil)
+	_ Index              = (*chainIndex)(nil)
+	_ rbytes.Marshaler   = (*chainIndex)(nil)
+	_ rbytes.Unmarshaler = (*chainIndex)(nil)
+)
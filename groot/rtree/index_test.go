@@ -0,0 +1,326 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/riofs"
+)
+
+type indexEvt struct {
+	Run   int32
+	Event int64
+	E     float64
+}
+
+func createIndexTree(t *testing.T, fname, tname string, evts []indexEvt) {
+	t.Helper()
+
+	f, err := riofs.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	var evt indexEvt
+	w, err := NewWriter(f, tname, WriteVarsFromStruct(&evt))
+	if err != nil {
+		t.Fatalf("could not create writer: %+v", err)
+	}
+	for _, v := range evts {
+		evt = v
+		_, err = w.Write()
+		if err != nil {
+			t.Fatalf("could not write event: %+v", err)
+		}
+	}
+	err = w.Close()
+	if err != nil {
+		t.Fatalf("could not close writer: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+}
+
+func openTree(t *testing.T, fname, tname string) (Tree, func()) {
+	t.Helper()
+
+	f, err := riofs.Open(fname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	o, err := riofs.Dir(f).Get(tname)
+	if err != nil {
+		f.Close()
+		t.Fatalf("could not get tree: %+v", err)
+	}
+	return o.(Tree), func() { f.Close() }
+}
+
+func TestBuildIndex(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "index.root")
+	createIndexTree(t, fname, "tree", []indexEvt{
+		{Run: 2, Event: 10},
+		{Run: 1, Event: 20},
+		{Run: 2, Event: 5},
+		{Run: 1, Event: 10},
+		{Run: 3, Event: 1},
+	})
+
+	tree, done := openTree(t, fname, "tree")
+	defer done()
+
+	if idx := IndexOf(tree); idx != nil {
+		t.Fatalf("unexpected index: %#v", idx)
+	}
+
+	idx, err := BuildIndex(tree, "Run", "Event")
+	if err != nil {
+		t.Fatalf("could not build index: %+v", err)
+	}
+
+	if got, want := IndexOf(tree), idx; got != want {
+		t.Fatalf("index not attached to tree")
+	}
+	if got, want := idx.MajorName(), "Run"; got != want {
+		t.Fatalf("invalid major name: got=%q, want=%q", got, want)
+	}
+	if got, want := idx.MinorName(), "Event"; got != want {
+		t.Fatalf("invalid minor name: got=%q, want=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		maj, min int64
+		want     int64
+	}{
+		{1, 10, 3},
+		{1, 20, 1},
+		{2, 5, 2},
+		{2, 10, 0},
+		{3, 1, 4},
+		{0, 0, -1},
+		{1, 15, -1},
+		{4, 1, -1},
+	} {
+		if got, want := idx.Entry(tc.maj, tc.min), tc.want; got != want {
+			t.Fatalf("invalid entry for (%d, %d): got=%d, want=%d", tc.maj, tc.min, got, want)
+		}
+	}
+
+	idx, err = BuildIndex(tree, "Run", "")
+	if err != nil {
+		t.Fatalf("could not build major-only index: %+v", err)
+	}
+	if got, want := idx.MinorName(), "0"; got != want {
+		t.Fatalf("invalid minor name: got=%q, want=%q", got, want)
+	}
+	if got, want := idx.Entry(3, 0), int64(4); got != want {
+		t.Fatalf("invalid entry: got=%d, want=%d", got, want)
+	}
+
+	_, err = BuildIndex(tree, "NotThere", "")
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestBuildChainIndex(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var (
+		f1 = filepath.Join(tmp, "f1.root")
+		f2 = filepath.Join(tmp, "f2.root")
+		f3 = filepath.Join(tmp, "f3.root")
+	)
+	createIndexTree(t, f1, "tree", []indexEvt{{Run: 1, Event: 2}, {Run: 1, Event: 1}})
+	createIndexTree(t, f2, "tree", []indexEvt{{Run: 2, Event: 1}, {Run: 3, Event: 1}})
+	createIndexTree(t, f3, "tree", []indexEvt{{Run: 1, Event: 3}})
+
+	t1, done1 := openTree(t, f1, "tree")
+	defer done1()
+	t2, done2 := openTree(t, f2, "tree")
+	defer done2()
+	t3, done3 := openTree(t, f3, "tree")
+	defer done3()
+
+	for _, tc := range []struct {
+		name  string
+		trees []Tree
+		class string
+		keys  [][3]int64
+	}{
+		{
+			name:  "sorted",
+			trees: []Tree{t1, t2},
+			class: "TChainIndex",
+			keys: [][3]int64{
+				{1, 1, 1}, {1, 2, 0}, {2, 1, 2}, {3, 1, 3}, {2, 2, -1}, {4, 1, -1},
+			},
+		},
+		{
+			name:  "overlapping",
+			trees: []Tree{t1, t2, t3},
+			class: "TTreeIndex",
+			keys: [][3]int64{
+				{1, 1, 1}, {1, 2, 0}, {1, 3, 4}, {2, 1, 2}, {3, 1, 3}, {2, 2, -1},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ch := Chain(tc.trees...)
+			idx, err := BuildIndex(ch, "Run", "Event")
+			if err != nil {
+				t.Fatalf("could not build index: %+v", err)
+			}
+			if got, want := idx.Class(), tc.class; got != want {
+				t.Fatalf("invalid index class: got=%q, want=%q", got, want)
+			}
+			if got, want := IndexOf(ch), idx; got != want {
+				t.Fatalf("index not attached to chain")
+			}
+			for _, key := range tc.keys {
+				if got, want := idx.Entry(key[0], key[1]), key[2]; got != want {
+					t.Fatalf("invalid entry for (%d, %d): got=%d, want=%d", key[0], key[1], got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexRW(t *testing.T) {
+	type rwObject interface {
+		rbytes.Marshaler
+		rbytes.Unmarshaler
+	}
+
+	for _, tc := range []struct {
+		name string
+		want rwObject
+	}{
+		{
+			name: "TTreeIndex",
+			want: &treeIndex{
+				named: *rbase.NewNamed("", ""),
+				major: "run",
+				minor: "event",
+				maj:   []int64{1, 1, 2},
+				min:   []int64{1, 2, 1},
+				index: []int64{2, 0, 1},
+			},
+		},
+		{
+			name: "TChainIndex",
+			want: &chainIndex{
+				named: *rbase.NewNamed("", ""),
+				major: "run",
+				minor: "0",
+				entries: []chainIndexEntry{
+					{lo: chainIndexKey{1, 0}, hi: chainIndexKey{2, 0}},
+					{lo: chainIndexKey{3, 0}, hi: chainIndexKey{7, 0}},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			wbuf := rbytes.NewWBuffer(nil, nil, 0, nil)
+			_, err := tc.want.MarshalROOT(wbuf)
+			if err != nil {
+				t.Fatalf("could not marshal index: %+v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tc.want).Elem()).Interface().(rwObject)
+			rbuf := rbytes.NewRBuffer(wbuf.Bytes(), nil, 0, nil)
+			err = got.UnmarshalROOT(rbuf)
+			if err != nil {
+				t.Fatalf("could not unmarshal index: %+v", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("round trip failed:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIndexFromFile(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "index.root")
+	createIndexTree(t, fname, "tree", []indexEvt{
+		{Run: 2, Event: 1},
+		{Run: 1, Event: 1},
+	})
+
+	var want Index
+	func() {
+		tree, done := openTree(t, fname, "tree")
+		defer done()
+
+		want, err = BuildIndex(tree, "Run", "Event")
+		if err != nil {
+			t.Fatalf("could not build index: %+v", err)
+		}
+	}()
+
+	oname := filepath.Join(tmp, "index-out.root")
+	f, err := riofs.Create(oname)
+	if err != nil {
+		t.Fatalf("could not create file: %+v", err)
+	}
+	defer f.Close()
+
+	err = riofs.Dir(f).Put("index", want)
+	if err != nil {
+		t.Fatalf("could not store index: %+v", err)
+	}
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("could not close file: %+v", err)
+	}
+
+	f, err = riofs.Open(oname)
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("index")
+	if err != nil {
+		t.Fatalf("could not read index: %+v", err)
+	}
+	got, ok := o.(Index)
+	if !ok {
+		t.Fatalf("invalid index type %T", o)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid index:\ngot= %#v\nwant=%#v", got, want)
+	}
+	if got, want := got.Entry(1, 1), int64(1); got != want {
+		t.Fatalf("invalid entry: got=%d, want=%d", got, want)
+	}
+}
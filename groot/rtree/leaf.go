@@ -253,14 +253,29 @@ type tleafElement struct {
 	ptr       interface{}
 	src       reflect.Value
 	rstreamer rbytes.RStreamer
+	wstreamer rbytes.WStreamer
 	streamers []rbytes.StreamerElement
 }
 
+func newLeafElement(b Branch, name string, shape []int, id, ltype int32, count leafCount) *tleafElement {
+	const etype = 4
+	return &tleafElement{
+		rvers: rvers.LeafElement,
+		tleaf: newLeaf(name, shape, etype, 0, false, false, count, b),
+		id:    id,
+		ltype: ltype,
+	}
+}
+
 func (leaf *tleafElement) Class() string {
 	return "TLeafElement"
 }
 
 func (leaf *tleafElement) ivalue() int {
+	if leaf.src.Kind() == reflect.Slice {
+		// leaf-count of a split collection.
+		return leaf.src.Len()
+	}
 	return int(leaf.src.Int())
 }
 
@@ -373,7 +388,14 @@ func (leaf *tleafElement) setAddress(ptr interface{}) error {
 }
 
 func (leaf *tleafElement) writeToBuffer(w *rbytes.WBuffer) (int, error) {
-	panic("not implemented")
+	if leaf.wstreamer == nil {
+		// data members are written by the sub-branches.
+		return 0, nil
+	}
+
+	pos := w.Pos()
+	err := leaf.wstreamer.WStreamROOT(w)
+	return int(w.Pos() - pos), err
 }
 
 func (leaf *tleafElement) canGenerateOffsetArray() bool {
@@ -507,26 +529,6 @@ func newLeafFromWVar(w *wtree, b Branch, v WriteVar, lvl int, cfg wopt) (Leaf, e
 	switch b := b.(type) {
 	case *tbranch:
 		addLeaf = func(leaf Leaf) {
-			b.leaves = append(b.leaves, leaf)
-			w.ttree.leaves = append(w.ttree.leaves, leaf)
-		}
-	case *tbranchElement:
-		addLeaf = func(leaf Leaf) {
-			if _, ok := leaf.(*tleafElement); !ok {
-				lb, ltyp := asLeafBase(leaf)
-
-				if b.bup != nil {
-					lb.named.SetName(b.bup.Name() + "." + lb.Name())
-					lb.named.SetTitle(b.bup.Name() + "." + lb.Title())
-				}
-				leaf = &tleafElement{
-					rvers: rvers.LeafElement,
-					tleaf: *lb,
-					id:    -1, // FIXME(sbinet): infer correct index
-					ltype: int32(ltyp),
-				}
-			}
-
 			b.leaves = append(b.leaves, leaf)
 			w.ttree.leaves = append(w.ttree.leaves, leaf)
 		}
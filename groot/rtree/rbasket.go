@@ -34,7 +34,9 @@ func (rbk *rbasket) loadRLeaf(entry int64, leaf rleaf) error {
 	return leaf.readFromBuffer(rbk.bk.rbuf)
 }
 
-func (rbk *rbasket) inflate(name string, id int, span rspan, eoff int, f *riofs.File) error {
+// inflate loads the basket described by span.
+// raw, if not nil, holds the already read content of the basket on disk.
+func (rbk *rbasket) inflate(name string, id int, span rspan, eoff int, f *riofs.File, raw []byte) error {
 	var (
 		bufsz = span.sz
 		seek  = span.pos
@@ -69,9 +71,14 @@ func (rbk *rbasket) inflate(name string, id int, span rspan, eoff int, f *riofs.
 
 	default:
 		rbk.buf = rbytes.ResizeU8(rbk.buf, int(bufsz))
-		_, err = f.ReadAt(rbk.buf, seek)
-		if err != nil {
-			return fmt.Errorf("rtree: could not read basket buffer from file: %w", err)
+		switch {
+		case len(raw) == int(bufsz):
+			copy(rbk.buf, raw)
+		default:
+			_, err = f.ReadAt(rbk.buf, seek)
+			if err != nil {
+				return fmt.Errorf("rtree: could not read basket buffer from file: %w", err)
+			}
 		}
 
 		rbk.bk.rbuf = rbk.bk.rbuf.Reset(rbk.buf, nil, 0, sictx)
@@ -24,7 +24,7 @@ var (
 	_ reader = (*rchain)(nil)
 )
 
-func newRChain(ch *chain, rvars []ReadVar, n int, beg, end int64) *rchain {
+func newRChain(ch *chain, rvars []ReadVar, n int, beg, end int64) (*rchain, error) {
 	r := &rchain{
 		ch:   ch,
 		rvs:  rvars,
@@ -43,9 +43,12 @@ func newRChain(ch *chain, rvars []ReadVar, n int, beg, end int64) *rchain {
 	r.ibeg = tbeg
 	r.iend = tend
 
-	r.loadRVars()
+	err := r.loadRVars()
+	if err != nil {
+		return nil, err
+	}
 
-	return r
+	return r, nil
 }
 
 func (r *rchain) Close() error {
@@ -54,14 +57,18 @@ func (r *rchain) Close() error {
 
 func (r *rchain) rvars() []ReadVar { return r.rvs }
 
-func (r *rchain) loadRVars() {
+func (r *rchain) loadRVars() error {
 	if len(r.ch.trees) == 0 {
-		return
+		return nil
 	}
 
-	rr := newReader(r.ch.trees[0], r.rvs, r.nrab, 0, 1)
+	rr, err := newReader(r.ch.trees[0], r.rvs, r.nrab, 0, 1)
+	if err != nil {
+		return err
+	}
 	defer rr.Close()
 	r.rvs = rr.rvars()
+	return nil
 }
 
 func (r *rchain) run(off, beg, end int64, f func(RCtx) error) error {
@@ -83,7 +90,7 @@ func (r *rchain) run(off, beg, end int64, f func(RCtx) error) error {
 			eoff = r.ch.offs[i]
 			tots = r.ch.tots[i]
 			ibeg = maxI64(beg-eoff, 0)
-			iend = minI64(end, tots-eoff)
+			iend = minI64(end, tots) - eoff
 			err  = r.runTree(i, eoff+off, ibeg, iend, f)
 		)
 		if err != nil {
@@ -103,7 +110,7 @@ func (r *rchain) findTrees(beg, end int64) (int, int) {
 
 	for i, t := range r.ch.trees {
 		n := t.Entries()
-		if ibeg < 0 && beg <= eoff {
+		if ibeg < 0 && beg < eoff+n {
 			ibeg = i
 		}
 		if iend < 0 && end <= eoff+n {
@@ -111,6 +118,10 @@ func (r *rchain) findTrees(beg, end int64) (int, int) {
 		}
 		eoff += n
 	}
+	if ibeg < 0 {
+		// empty range, past the last entry.
+		ibeg = len(r.ch.trees)
+	}
 	if iend < 0 {
 		iend = len(r.ch.trees)
 	}
@@ -119,7 +130,10 @@ func (r *rchain) findTrees(beg, end int64) (int, int) {
 }
 
 func (r *rchain) runTree(itree int, off, beg, end int64, f func(RCtx) error) error {
-	rr := newReader(r.ch.trees[itree], r.rvs, r.nrab, beg, end)
+	rr, err := newReader(r.ch.trees[itree], r.rvs, r.nrab, beg, end)
+	if err != nil {
+		return err
+	}
 	return rr.run(off, beg, end, f)
 }
 
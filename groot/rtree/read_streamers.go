@@ -62,16 +62,19 @@ func fieldOf(rt reflect.Type, field string) int {
 }
 
 func rstreamerFrom(se rbytes.StreamerElement, ptr interface{}, lcnt leafCount, sictx rbytes.StreamerInfoContext) rstreamerFunc {
-	rt := reflect.TypeOf(ptr).Elem()
-	rv := reflect.ValueOf(ptr).Elem()
+	rv := derefValue(reflect.ValueOf(ptr).Elem())
+	rt := rv.Type()
 	rf := rv
 	if rt.Kind() == reflect.Struct {
 		field := fieldOf(rt, se.Name())
-		if field < 0 {
+		switch {
+		case field >= 0:
+			rf = derefValue(rv.Field(field))
+		case isObjectElement(se):
+			// ptr points to the object described by se.
+		default:
 			panic(fmt.Errorf("rtree: no such field %q in type %T", se.Name(), ptr))
 		}
-
-		rf = rv.Field(field)
 	}
 
 	switch se := se.(type) {
@@ -585,6 +588,21 @@ func rstreamerFrom(se rbytes.StreamerElement, ptr interface{}, lcnt leafCount, s
 		switch se.STLType() {
 		case rmeta.STLvector:
 			switch se.ContainedType() {
+			case rmeta.Char:
+				fptr := rf.Addr().Interface().(*[]int8)
+				return func(r *rbytes.RBuffer) error {
+					var hdr [6]byte
+					_, _ = r.Read(hdr[:])
+					n := int(r.ReadI32())
+					*fptr = rbytes.ResizeI8(*fptr, n)
+					if n > 0 {
+						r.ReadArrayI8(*fptr)
+					} else {
+						*fptr = []int8{}
+					}
+					return r.Err()
+				}
+
 			case rmeta.Short:
 				fptr := rf.Addr().Interface().(*[]int16)
 				return func(r *rbytes.RBuffer) error {
@@ -660,6 +678,21 @@ func rstreamerFrom(se rbytes.StreamerElement, ptr interface{}, lcnt leafCount, s
 					return r.Err()
 				}
 
+			case rmeta.UChar:
+				fptr := rf.Addr().Interface().(*[]uint8)
+				return func(r *rbytes.RBuffer) error {
+					var hdr [6]byte
+					_, _ = r.Read(hdr[:])
+					n := int(r.ReadI32())
+					*fptr = rbytes.ResizeU8(*fptr, n)
+					if n > 0 {
+						r.ReadArrayU8(*fptr)
+					} else {
+						*fptr = []uint8{}
+					}
+					return r.Err()
+				}
+
 			case rmeta.UShort:
 				fptr := rf.Addr().Interface().(*[]uint16)
 				return func(r *rbytes.RBuffer) error {
@@ -737,6 +770,12 @@ func rstreamerFrom(se rbytes.StreamerElement, ptr interface{}, lcnt leafCount, s
 						return r.Err()
 					}
 				default:
+					if rf.Type().Elem().Kind() != reflect.Struct {
+						rstl := rstlOf(rf.Type(), se.TypeName(), true, sictx)
+						return func(r *rbytes.RBuffer) error {
+							return rstl(r, rf)
+						}
+					}
 					// FIXME(sbinet): always load latest version?
 					etn := se.ElemTypeName()
 					subsi, err := sictx.StreamerInfo(etn[0], -1)
@@ -744,18 +783,46 @@ func rstreamerFrom(se rbytes.StreamerElement, ptr interface{}, lcnt leafCount, s
 						panic(fmt.Errorf("rtree: could not retrieve streamer for %q: %w", etn[0], err))
 					}
 					eptr := reflect.New(rf.Type().Elem())
-					felt := rstreamerFrom(subsi.Elements()[0], eptr.Interface(), lcnt, sictx)
+					var felt rstreamerFunc
+					switch elts := subsi.Elements(); {
+					case len(elts) == 1 && elts[0].Name() == "This":
+						felt = rstreamerFrom(elts[0], eptr.Interface(), lcnt, sictx)
+					default:
+						// objects are streamed object-wise, each with a version header.
+						var funcs []rstreamerFunc
+						for _, elt := range elts {
+							funcs = append(funcs, rstreamerFrom(elt, eptr.Interface(), lcnt, sictx))
+						}
+						ename := subsi.Name()
+						felt = func(r *rbytes.RBuffer) error {
+							start := r.Pos()
+							_, pos, bcnt := r.ReadVersion(ename)
+							for _, fct := range funcs {
+								err := fct(r)
+								if err != nil {
+									return err
+								}
+							}
+							r.CheckByteCount(pos, bcnt, start, ename)
+							return r.Err()
+						}
+					}
 					fptr := rf.Addr()
 					typename := se.TypeName()
 					return func(r *rbytes.RBuffer) error {
 						start := r.Pos()
 						_, pos, bcnt := r.ReadVersion(typename)
 						n := int(r.ReadI32())
-						if fptr.Elem().Len() < n {
-							fptr.Elem().Set(reflect.MakeSlice(rf.Type(), n, n))
-						}
 						sli := fptr.Elem()
+						switch {
+						case sli.IsNil() || sli.Cap() < n:
+							sli.Set(reflect.MakeSlice(rf.Type(), n, n))
+						default:
+							sli.SetLen(n)
+						}
 						for i := 0; i < n; i++ {
+							// reset the element so slices are not shared.
+							eptr.Elem().Set(reflect.Zero(eptr.Elem().Type()))
 							_ = felt(r)
 							sli.Index(i).Set(eptr.Elem())
 						}
@@ -765,6 +832,11 @@ func rstreamerFrom(se rbytes.StreamerElement, ptr interface{}, lcnt leafCount, s
 					}
 				}
 			}
+		case rmeta.STLmap, rmeta.STLunorderedmap, rmeta.STLset, rmeta.STLunorderedset:
+			rstl := rstlOf(rf.Type(), se.TypeName(), true, sictx)
+			return func(r *rbytes.RBuffer) error {
+				return rstl(r, rf)
+			}
 		default:
 			panic(fmt.Errorf("rtree: invalid STL type %d for %#v", se.STLType(), se))
 		}
@@ -1035,7 +1107,7 @@ func gotypeFromSE(se rbytes.StreamerElement, lcount Leaf, ctx rbytes.StreamerInf
 				case "vector<vector<char> >":
 					return reflect.TypeOf([][]int8(nil))
 				case "vector<vector<short> >":
-					return reflect.TypeOf([][]uint16(nil))
+					return reflect.TypeOf([][]int16(nil))
 				case "vector<vector<int> >":
 					return reflect.TypeOf([][]int32(nil))
 				case "vector<vector<long int> >", "vector<vector<long> >":
@@ -1064,6 +1136,9 @@ func gotypeFromSE(se rbytes.StreamerElement, lcount Leaf, ctx rbytes.StreamerInf
 					if et, ok := rmeta.CxxBuiltins[eltname]; ok {
 						return reflect.SliceOf(et)
 					}
+					if stlTypeOf(eltname) != rmeta.NotSTL {
+						return reflect.SliceOf(gotypeFromCxx(eltname, ctx))
+					}
 					// FIXME(sbinet): always load latest version?
 					sielt, err := ctx.StreamerInfo(eltname, -1)
 					if err != nil {
@@ -1077,7 +1152,7 @@ func gotypeFromSE(se rbytes.StreamerElement, lcount Leaf, ctx rbytes.StreamerInf
 				}
 			}
 		default:
-			panic(fmt.Errorf("rtree: invalid STL type %d for %#v", se.STLType(), se))
+			return gotypeFromCxx(se.TypeName(), ctx)
 		}
 
 	case *rdict.StreamerObjectAny:
@@ -1133,3 +1208,322 @@ func gotypeFromSE(se rbytes.StreamerElement, lcount Leaf, ctx rbytes.StreamerInf
 
 	panic(fmt.Errorf("rtree: unknown streamer element: %#v", se))
 }
+
+// gotypeFromCxx returns the Go type corresponding to the provided C++ type
+// name.
+// std::vector<T> is mapped to []T, std::map<K,V> and std::unordered_map<K,V>
+// to map[K]V, std::set<T> and std::unordered_set<T> to map[T]struct{}.
+func gotypeFromCxx(name string, ctx rbytes.StreamerInfoContext) reflect.Type {
+	name = strings.Replace(strings.TrimSpace(name), "std::", "", -1)
+	if typ, ok := rmeta.CxxBuiltins[name]; ok {
+		return typ
+	}
+	if typ, ok := builtins[name]; ok {
+		return typ
+	}
+
+	switch stlTypeOf(name) {
+	case rmeta.STLvector:
+		args := rmeta.CxxTemplateArgsOf(name)
+		return reflect.SliceOf(gotypeFromCxx(args[0], ctx))
+	case rmeta.STLmap, rmeta.STLunorderedmap:
+		args := rmeta.CxxTemplateArgsOf(name)
+		if len(args) < 2 {
+			panic(fmt.Errorf("rtree: invalid std::map type %q", name))
+		}
+		return reflect.MapOf(gotypeFromCxx(args[0], ctx), gotypeFromCxx(args[1], ctx))
+	case rmeta.STLset, rmeta.STLunorderedset:
+		args := rmeta.CxxTemplateArgsOf(name)
+		return reflect.MapOf(gotypeFromCxx(args[0], ctx), reflect.TypeOf(struct{}{}))
+	}
+
+	// the latest version of the streamer is used.
+	si, err := ctx.StreamerInfo(name, -1)
+	if err != nil {
+		panic(fmt.Errorf("rtree: could not find streamer for %q: %w", name, err))
+	}
+	return gotypeFromSI(si, ctx)
+}
+
+// stlTypeOf returns the kind of STL container named by the provided C++
+// type name.
+func stlTypeOf(name string) rmeta.ESTLType {
+	name = strings.Replace(strings.TrimSpace(name), "std::", "", -1)
+	i := strings.Index(name, "<")
+	if i < 0 || !strings.HasSuffix(name, ">") {
+		return rmeta.NotSTL
+	}
+	switch name[:i] {
+	case "vector":
+		return rmeta.STLvector
+	case "map":
+		return rmeta.STLmap
+	case "unordered_map":
+		return rmeta.STLunorderedmap
+	case "set":
+		return rmeta.STLset
+	case "unordered_set":
+		return rmeta.STLunorderedset
+	}
+	return rmeta.NotSTL
+}
+
+// isObjectElement returns whether the streamer element describes an
+// object data member.
+func isObjectElement(se rbytes.StreamerElement) bool {
+	switch se.(type) {
+	case *rdict.StreamerObject, *rdict.StreamerObjectAny:
+		return true
+	}
+	return false
+}
+
+// derefValue returns the value the provided pointers point to.
+// nil pointers are allocated.
+func derefValue(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// rvalueFunc reads a value from the provided buffer into rv.
+type rvalueFunc func(r *rbytes.RBuffer, rv reflect.Value) error
+
+// rstlOf returns the function reading STL containers named typename into
+// values of type rt.
+// Containers nested into other containers are read without a header.
+//
+// std::map<K,V> are read object-wise or member-wise, as indicated by their
+// header.
+func rstlOf(rt reflect.Type, typename string, header bool, sictx rbytes.StreamerInfoContext) rvalueFunc {
+	args := rmeta.CxxTemplateArgsOf(strings.Replace(typename, "std::", "", -1))
+
+	readHeader := func(r *rbytes.RBuffer) (vers int16, start int64, pos, bcnt int32) {
+		if !header {
+			return 0, 0, 0, 0
+		}
+		start = r.Pos()
+		vers, pos, bcnt = r.ReadVersion(typename)
+		return vers, start, pos, bcnt
+	}
+	checkHeader := func(r *rbytes.RBuffer, start int64, pos, bcnt int32) error {
+		if header {
+			r.CheckByteCount(pos, bcnt, start, typename)
+		}
+		return r.Err()
+	}
+
+	switch {
+	case rt.Kind() == reflect.Slice:
+		efunc := relemOf(rt.Elem(), args[0], sictx)
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			_, start, pos, bcnt := readHeader(r)
+			n := int(r.ReadI32())
+			switch {
+			case rv.IsNil() || rv.Cap() < n:
+				rv.Set(reflect.MakeSlice(rt, n, n))
+			default:
+				rv.SetLen(n)
+			}
+			for i := 0; i < n; i++ {
+				err := efunc(r, rv.Index(i))
+				if err != nil {
+					return err
+				}
+			}
+			return checkHeader(r, start, pos, bcnt)
+		}
+
+	case rt.Kind() == reflect.Map && len(args) == 1:
+		var (
+			kfunc = relemOf(rt.Key(), args[0], sictx)
+			empty = reflect.New(rt.Elem()).Elem()
+		)
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			_, start, pos, bcnt := readHeader(r)
+			n := int(r.ReadI32())
+			rv.Set(reflect.MakeMapWithSize(rt, n))
+			for i := 0; i < n; i++ {
+				key := reflect.New(rt.Key()).Elem()
+				err := kfunc(r, key)
+				if err != nil {
+					return err
+				}
+				rv.SetMapIndex(key, empty)
+			}
+			return checkHeader(r, start, pos, bcnt)
+		}
+
+	case rt.Kind() == reflect.Map && len(args) == 2:
+		var (
+			kt    = reflect.SliceOf(rt.Key())
+			vt    = reflect.SliceOf(rt.Elem())
+			kfunc = relemOf(rt.Key(), args[0], sictx)
+			vfunc = relemOf(rt.Elem(), args[1], sictx)
+		)
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			vers, start, pos, bcnt := readHeader(r)
+			mbrwise := vers&rbytes.StreamedMemberWise != 0
+			if mbrwise {
+				// version of std::pair<K,V>, followed by its checksum.
+				if v := r.ReadI16(); v <= 0 {
+					_ = r.ReadU32()
+				}
+			}
+			n := int(r.ReadI32())
+			keys := reflect.MakeSlice(kt, n, n)
+			vals := reflect.MakeSlice(vt, n, n)
+			switch {
+			case mbrwise:
+				err := readMembers(r, keys, kfunc)
+				if err != nil {
+					return err
+				}
+				err = readMembers(r, vals, vfunc)
+				if err != nil {
+					return err
+				}
+			default:
+				for i := 0; i < n; i++ {
+					err := kfunc(r, keys.Index(i))
+					if err != nil {
+						return err
+					}
+					err = vfunc(r, vals.Index(i))
+					if err != nil {
+						return err
+					}
+				}
+			}
+			rv.Set(reflect.MakeMapWithSize(rt, n))
+			for i := 0; i < n; i++ {
+				rv.SetMapIndex(keys.Index(i), vals.Index(i))
+			}
+			return checkHeader(r, start, pos, bcnt)
+		}
+	}
+
+	panic(fmt.Errorf("rtree: invalid STL container %q for type %v", typename, rt))
+}
+
+// readMembers reads a block of members of std::pair<K,V> values into the
+// provided slice.
+// Strings are read as a std::string collection, with a header.
+func readMembers(r *rbytes.RBuffer, sli reflect.Value, efunc rvalueFunc) error {
+	if sli.Len() == 0 {
+		return r.Err()
+	}
+	var (
+		start int64
+		pos   int32
+		bcnt  int32
+		isStr = sli.Type().Elem().Kind() == reflect.String
+	)
+	if isStr {
+		start = r.Pos()
+		_, pos, bcnt = r.ReadVersion("string")
+	}
+	for i := 0; i < sli.Len(); i++ {
+		err := efunc(r, sli.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+	if isStr {
+		r.CheckByteCount(pos, bcnt, start, "string")
+	}
+	return r.Err()
+}
+
+// relemOf returns the function reading elements of STL containers, named
+// ename, into values of type et.
+func relemOf(et reflect.Type, ename string, sictx rbytes.StreamerInfoContext) rvalueFunc {
+	ename = strings.TrimSpace(ename)
+	switch et.Kind() {
+	case reflect.Ptr:
+		efunc := relemOf(et.Elem(), ename, sictx)
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			if rv.IsNil() {
+				rv.Set(reflect.New(et.Elem()))
+			}
+			return efunc(r, rv.Elem())
+		}
+
+	case reflect.String:
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			rv.SetString(r.ReadString())
+			return r.Err()
+		}
+
+	case reflect.Slice, reflect.Map:
+		return rstlOf(et, ename, false, sictx)
+
+	case reflect.Struct:
+		// the latest version of the streamer is used.
+		si, err := sictx.StreamerInfo(ename, -1)
+		if err != nil {
+			panic(fmt.Errorf("rtree: could not retrieve streamer for %q: %w", ename, err))
+		}
+		var (
+			eptr  = reflect.New(et)
+			zero  = reflect.Zero(et)
+			funcs = make([]rstreamerFunc, len(si.Elements()))
+		)
+		for i, elt := range si.Elements() {
+			funcs[i] = rstreamerFrom(elt, eptr.Interface(), nil, sictx)
+		}
+		return func(r *rbytes.RBuffer, rv reflect.Value) error {
+			// reset the element so slices and maps are not shared.
+			eptr.Elem().Set(zero)
+			start := r.Pos()
+			_, pos, bcnt := r.ReadVersion(ename)
+			for _, fct := range funcs {
+				err := fct(r)
+				if err != nil {
+					return err
+				}
+			}
+			r.CheckByteCount(pos, bcnt, start, ename)
+			rv.Set(eptr.Elem())
+			return r.Err()
+		}
+	}
+
+	return func(r *rbytes.RBuffer, rv reflect.Value) error {
+		readBasic(r, rv)
+		return r.Err()
+	}
+}
+
+func readBasic(r *rbytes.RBuffer, rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		rv.SetBool(r.ReadBool())
+	case reflect.Int8:
+		rv.SetInt(int64(r.ReadI8()))
+	case reflect.Int16:
+		rv.SetInt(int64(r.ReadI16()))
+	case reflect.Int32:
+		rv.SetInt(int64(r.ReadI32()))
+	case reflect.Int64:
+		rv.SetInt(r.ReadI64())
+	case reflect.Uint8:
+		rv.SetUint(uint64(r.ReadU8()))
+	case reflect.Uint16:
+		rv.SetUint(uint64(r.ReadU16()))
+	case reflect.Uint32:
+		rv.SetUint(uint64(r.ReadU32()))
+	case reflect.Uint64:
+		rv.SetUint(r.ReadU64())
+	case reflect.Float32:
+		rv.SetFloat(float64(r.ReadF32()))
+	case reflect.Float64:
+		rv.SetFloat(r.ReadF64())
+	default:
+		panic(fmt.Errorf("rtree: invalid basic type %v", rv.Type()))
+	}
+}
@@ -7,6 +7,7 @@ package rtree
 import (
 	"fmt"
 	"io"
+	"sort"
 
 	"go-hep.org/x/hep/groot/rtree/rfunc"
 )
@@ -21,6 +22,9 @@ type Reader struct {
 	tree  Tree
 	rvars []ReadVar
 
+	elist EntrySelection // list of entries to read, if any
+	sel   []int64        // selected entries within [beg, end)
+
 	evals []rfunc.Formula
 	dirty bool // whether we need to re-create scanner (if formula needed new branches)
 }
@@ -48,6 +52,19 @@ func WithPrefetchBaskets(n int) ReadOption {
 	}
 }
 
+// WithEntryList specifies the list of entries a Tree reader will read through.
+// Only the entries of the list that are within the range of entries of the
+// reader are read.
+//
+// Entries of a list attached to a chain are mapped to the trees of the
+// chain by tree and file names.
+func WithEntryList(list EntrySelection) ReadOption {
+	return func(r *Reader) error {
+		r.elist = list
+		return nil
+	}
+}
+
 // NewReader creates a new Tree Reader from the provided ROOT Tree and
 // the set of read-variables into which data will be read.
 func NewReader(t Tree, rvars []ReadVar, opts ...ReadOption) (*Reader, error) {
@@ -96,12 +113,24 @@ func NewReader(t Tree, rvars []ReadVar, opts ...ReadOption) (*Reader, error) {
 		)
 	}
 
+	if r.elist != nil {
+		sel, err := selectEntries(t, r.elist, r.beg, r.end)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not create reader: %w", err)
+		}
+		r.sel = sel
+	}
+
 	rvars, err := sanitizeRVars(t, rvars)
 	if err != nil {
 		return nil, fmt.Errorf("rtree: could not create reader: %w", err)
 	}
 
-	r.r = newReader(t, rvars, r.nrab, r.beg, r.end)
+	beg, end := r.span()
+	r.r, err = newReader(t, rvars, r.nrab, beg, end)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create reader: %w", err)
+	}
 	r.rvars = r.r.rvars()
 
 	return &r, nil
@@ -125,16 +154,75 @@ type RCtx struct {
 
 // Read will read data from the underlying tree over the whole specified range.
 // Read calls the provided user function f for each entry successfully read.
+//
+// If an entry list was provided, only the entries of that list are read.
 func (r *Reader) Read(f func(ctx RCtx) error) error {
+	beg, end := r.span()
 	if r.dirty {
 		r.dirty = false
 		_ = r.r.Close()
-		r.r = newReader(r.tree, r.rvars, r.nrab, r.beg, r.end)
+		rr, err := newReader(r.tree, r.rvars, r.nrab, beg, end)
+		if err != nil {
+			return fmt.Errorf("rtree: could not create reader: %w", err)
+		}
+		r.r = rr
 	}
 	r.r.reset()
 
 	const eoff = 0 // entry offset
-	return r.r.run(eoff, r.beg, r.end, f)
+	if r.elist == nil {
+		return r.r.run(eoff, beg, end, f)
+	}
+
+	var (
+		sel = r.sel
+		cur = 0
+	)
+	return r.r.run(eoff, beg, end, func(ctx RCtx) error {
+		if cur >= len(sel) || sel[cur] != ctx.Entry {
+			return nil
+		}
+		cur++
+		return f(ctx)
+	})
+}
+
+// span returns the half-open interval of entries the underlying
+// reader needs to go through.
+func (r *Reader) span() (beg, end int64) {
+	switch {
+	case r.elist == nil:
+		return r.beg, r.end
+	case len(r.sel) == 0:
+		return r.beg, r.beg
+	default:
+		return r.sel[0], r.sel[len(r.sel)-1] + 1
+	}
+}
+
+// selectEntries returns the sorted entries of the provided tree, within
+// the half-open interval [beg, end), that are part of the list.
+func selectEntries(t Tree, list EntrySelection, beg, end int64) ([]int64, error) {
+	entries, err := list.selected(t)
+	if err != nil {
+		return nil, err
+	}
+
+	n := t.Entries()
+	for _, entry := range entries {
+		if entry < 0 || entry >= n {
+			return nil, fmt.Errorf(
+				"rtree: entry list %q has entry %d out of range [0, %d)",
+				list.Name(), entry, n,
+			)
+		}
+	}
+
+	var (
+		ibeg = sort.Search(len(entries), func(i int) bool { return entries[i] >= beg })
+		iend = sort.Search(len(entries), func(i int) bool { return entries[i] >= end })
+	)
+	return entries[ibeg:iend], nil
 }
 
 // FormulaFunc creates a new formula based on the provided function and
@@ -147,6 +235,18 @@ func (r *Reader) FormulaFunc(branches []string, fct interface{}) (rfunc.Formula,
 	return r.Formula(f)
 }
 
+// FormulaExpr creates a new formula based on the provided string expression.
+// The returned formula's Func method returns a func() float64.
+//
+// See rfunc.NewExprFormula for the syntax of expressions.
+func (r *Reader) FormulaExpr(expr string) (rfunc.Formula, error) {
+	f, err := rfunc.NewExprFormula(expr)
+	if err != nil {
+		return nil, fmt.Errorf("rtree: could not create formula: %w", err)
+	}
+	return r.Formula(f)
+}
+
 // Formula creates a new formula based on the provided user provided formula.
 // Formula binds the provided function with the requested list of leaves.
 func (r *Reader) Formula(f rfunc.Formula) (rfunc.Formula, error) {
@@ -216,25 +316,41 @@ var (
 
 func (r *rtree) rvars() []ReadVar { return r.rvs }
 
-func newReader(t Tree, rvars []ReadVar, n int, beg, end int64) reader {
+func newReader(t Tree, rvars []ReadVar, n int, beg, end int64) (reader, error) {
 	rvars, err := sanitizeRVars(t, rvars)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	switch t := t.(type) {
 	case *ttree:
-		return newRTree(t, rvars, n, beg, end)
+		return newRTree(t, rvars, n, beg, end), nil
 	case *chain:
 		return newRChain(t, rvars, n, beg, end)
 	case *join:
-		return newRJoin(t, rvars, n, beg, end)
+		return newRJoin(t, rvars, n, beg, end), nil
+	case *friend:
+		return newRFriend(t, rvars, n, beg, end)
 	default:
 		panic(fmt.Errorf("rtree: unknown Tree implementation %T", t))
 	}
 }
 
 func newRTree(t *ttree, rvars []ReadVar, n int, beg, end int64) *rtree {
+	r, brs := bindRLeaves(t, rvars)
+	r.brs = make([]rbranch, len(brs))
+	for i, leaves := range brs {
+		branch := leaves[0].Leaf().Branch()
+		r.brs[i] = newRBranch(branch, n, beg, end, leaves, r)
+	}
+
+	return r
+}
+
+// bindRLeaves binds the provided read-vars (and the read-vars of their
+// leaf-counts) to the leaves of the provided tree.
+// bindRLeaves returns the leaves, regrouped by holding branch.
+func bindRLeaves(t *ttree, rvars []ReadVar) (*rtree, [][]rleaf) {
 	r := &rtree{
 		tree: t,
 		rvs:  rvars,
@@ -295,14 +411,9 @@ func newRTree(t *ttree, rvars []ReadVar, n int, beg, end int64) *rtree {
 		brs[id] = append(brs[id], leaf)
 	}
 
-	r.brs = make([]rbranch, len(brs))
-	for i, leaves := range brs {
-		branch := leaves[0].Leaf().Branch()
-		r.brs[i] = newRBranch(branch, n, beg, end, leaves, r)
-	}
-
-	return r
+	return r, brs
 }
+
 func (r *rtree) Close() error {
 	for i := range r.brs {
 		rb := &r.brs[i]
@@ -0,0 +1,337 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree/rfunc"
+)
+
+// rfriend reads a tree and its friend trees.
+type rfriend struct {
+	f    *friend
+	main reader
+	rs   []*rafriend
+	rvs  []ReadVar
+}
+
+var (
+	_ reader = (*rfriend)(nil)
+)
+
+func newRFriend(t *friend, rvars []ReadVar, n int, beg, end int64) (*rfriend, error) {
+	var (
+		mrvs []ReadVar
+		frvs = make([][]ReadVar, len(t.friends))
+	)
+	for _, rv := range rvars {
+		i, name := t.resolve(rv.Name)
+		switch i {
+		case -2:
+			return nil, fmt.Errorf("rtree: tree %q has no branch named %q", t.Name(), rv.Name)
+		case -1:
+			mrvs = append(mrvs, rv)
+		default:
+			if rv.Leaf == rv.Name {
+				rv.Leaf = name
+			}
+			rv.Name = name
+			frvs[i] = append(frvs[i], rv)
+		}
+	}
+
+	var all []ReadVar // all the read-vars of the main tree, loaded on demand.
+	keyOf := func(expr string) (func() float64, error) {
+		form, err := rfunc.NewExprFormula(expr)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not create index key formula: %w", err)
+		}
+		names := form.RVars()
+		ptrs := make([]interface{}, len(names))
+	loop:
+		for j, name := range names {
+			for _, rv := range mrvs {
+				if rv.Name == name {
+					ptrs[j] = rv.Value
+					continue loop
+				}
+			}
+			if all == nil {
+				all = NewReadVars(t.main)
+			}
+			for _, rv := range all {
+				if rv.Name == name {
+					mrvs = append(mrvs, rv)
+					ptrs[j] = rv.Value
+					continue loop
+				}
+			}
+			return nil, fmt.Errorf(
+				"rtree: tree %q has no branch named %q (needed by index key %q)",
+				t.main.Name(), name, expr,
+			)
+		}
+		err = form.Bind(ptrs)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not bind index key formula: %w", err)
+		}
+		return form.Func().(func() float64), nil
+	}
+
+	r := &rfriend{
+		f:  t,
+		rs: make([]*rafriend, len(t.friends)),
+	}
+	for i, f := range t.friends {
+		ra, err := newRAReader(f, frvs[i])
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not create reader for friend tree %q: %w", f.Name(), err)
+		}
+		rf := &rafriend{
+			name: f.Name(),
+			r:    ra,
+			idx:  IndexOf(f),
+			n:    f.Entries(),
+		}
+		if rf.idx != nil {
+			minor := rf.idx.MinorName()
+			if minor == "" {
+				minor = "0"
+			}
+			rf.maj, err = keyOf(rf.idx.MajorName())
+			if err != nil {
+				return nil, err
+			}
+			rf.min, err = keyOf(minor)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, rv := range rf.r.rvars() {
+			rf.zero = append(rf.zero, reflect.ValueOf(rv.Value).Elem())
+		}
+		r.rs[i] = rf
+	}
+
+	mr, err := newReader(t.main, mrvs, n, beg, end)
+	if err != nil {
+		return nil, err
+	}
+	r.main = mr
+	r.rvs = append(r.rvs, r.main.rvars()...)
+	for i, rf := range r.rs {
+		for _, rv := range rf.r.rvars() {
+			rv.Name = t.aliasOf(i, rv.Name)
+			r.rvs = append(r.rvs, rv)
+		}
+	}
+
+	return r, nil
+}
+
+func (r *rfriend) Close() error {
+	return r.main.Close()
+}
+
+func (r *rfriend) rvars() []ReadVar { return r.rvs }
+
+func (r *rfriend) run(off, beg, end int64, f func(RCtx) error) error {
+	defer r.Close()
+
+	return r.main.run(off, beg, end, func(ctx RCtx) error {
+		for _, rf := range r.rs {
+			err := rf.read(ctx.Entry - off)
+			if err != nil {
+				return fmt.Errorf("rtree: could not read friend tree %q: %w", rf.name, err)
+			}
+		}
+		return f(ctx)
+	})
+}
+
+func (r *rfriend) start() error { return r.main.start() }
+func (r *rfriend) stop()        { r.main.stop() }
+func (r *rfriend) reset()       { r.main.reset() }
+
+// rafriend reads the entries of a friend tree matching the entries
+// of its main tree.
+type rafriend struct {
+	name string
+	r    rareader
+	idx  Index
+	maj  func() float64 // major key of the current main entry
+	min  func() float64 // minor key of the current main entry
+	n    int64          // number of entries of the friend tree
+
+	zero []reflect.Value // values to reset when no entry matches
+}
+
+func (rf *rafriend) read(entry int64) error {
+	if rf.idx != nil {
+		entry = rf.idx.Entry(int64(rf.maj()), int64(rf.min()))
+	}
+	if entry < 0 || entry >= rf.n {
+		for _, v := range rf.zero {
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+	return rf.r.read(entry)
+}
+
+// rareader reads the entries of a tree in random order.
+type rareader interface {
+	rvars() []ReadVar
+	read(i int64) error
+}
+
+func newRAReader(t Tree, rvars []ReadVar) (rareader, error) {
+	rvars, err := sanitizeRVars(t, rvars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := t.(type) {
+	case *ttree:
+		return newRATree(t, rvars), nil
+	case *chain:
+		return newRAChain(t, rvars)
+	default:
+		panic(fmt.Errorf("rtree: unknown friend Tree implementation %T", t))
+	}
+}
+
+// ratree reads the entries of a tree in random order.
+type ratree struct {
+	r   *rtree
+	brs []rabranch
+}
+
+func newRATree(t *ttree, rvars []ReadVar) *ratree {
+	r, brs := bindRLeaves(t, rvars)
+	ra := &ratree{
+		r:   r,
+		brs: make([]rabranch, len(brs)),
+	}
+	for i, leaves := range brs {
+		b := leaves[0].Leaf().Branch()
+		ra.brs[i] = rabranch{
+			f:      t.f,
+			name:   b.Name(),
+			eoff:   asBranch(b).entryOffsetLen,
+			spans:  rspansOf(b),
+			cur:    new(rbasket),
+			leaves: leaves,
+		}
+	}
+	return ra
+}
+
+func (r *ratree) rvars() []ReadVar { return r.r.rvs }
+
+func (r *ratree) read(i int64) error {
+	for j := range r.brs {
+		err := r.brs[j].read(i)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rabranch reads the entries of a branch in random order, loading
+// the needed baskets on demand.
+type rabranch struct {
+	f      *riofs.File
+	name   string
+	eoff   int
+	spans  []rspan
+	cur    *rbasket
+	leaves []rleaf
+}
+
+func (rb *rabranch) read(i int64) error {
+	if i < rb.cur.span.beg || rb.cur.span.end <= i {
+		j := sort.Search(len(rb.spans), func(j int) bool {
+			return rb.spans[j].end > i
+		})
+		if j == len(rb.spans) || i < rb.spans[j].beg {
+			return fmt.Errorf("rtree: could not find basket for entry %d of branch %q", i, rb.name)
+		}
+		rb.cur.reset()
+		err := rb.cur.inflate(rb.name, j, rb.spans[j], rb.eoff, rb.f, nil)
+		if err != nil {
+			rb.cur.reset()
+			return fmt.Errorf("rtree: could not load basket %d of branch %q: %w", j, rb.name, err)
+		}
+	}
+
+	j := i - rb.cur.span.beg
+	for _, leaf := range rb.leaves {
+		err := rb.cur.loadRLeaf(j, leaf)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rachain reads the entries of a chain in random order.
+type rachain struct {
+	ch  *chain
+	rvs []ReadVar
+	cur int      // index of the current tree
+	r   rareader // reader of the current tree
+}
+
+func newRAChain(ch *chain, rvars []ReadVar) (*rachain, error) {
+	r := &rachain{
+		ch:  ch,
+		rvs: rvars,
+		cur: -1,
+	}
+	if len(ch.trees) > 0 {
+		err := r.load(0)
+		if err != nil {
+			return nil, err
+		}
+		r.rvs = r.r.rvars()
+	}
+	return r, nil
+}
+
+func (r *rachain) rvars() []ReadVar { return r.rvs }
+
+func (r *rachain) load(i int) error {
+	rvars := make([]ReadVar, len(r.rvs))
+	copy(rvars, r.rvs)
+	rr, err := newRAReader(r.ch.trees[i], rvars)
+	if err != nil {
+		return fmt.Errorf("rtree: could not load tree %d of chain: %w", i, err)
+	}
+	r.r = rr
+	r.cur = i
+	return nil
+}
+
+func (r *rachain) read(i int64) error {
+	j := sort.Search(len(r.ch.tots), func(j int) bool {
+		return r.ch.tots[j] > i
+	})
+	if j == len(r.ch.tots) {
+		return fmt.Errorf("rtree: could not find tree for entry %d of chain", i)
+	}
+	if j != r.cur {
+		err := r.load(j)
+		if err != nil {
+			return err
+		}
+	}
+	return r.r.read(i - r.ch.offs[j])
+}
@@ -0,0 +1,581 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rfunc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// NewExprFormula returns a new formula evaluating the provided string
+// expression, in the spirit of ROOT's TTreeFormula.
+//
+// Expressions are made of numbers, tree variables, the arithmetic
+// operators + - * / %, the comparison operators == != < <= > >=, the
+// logical operators && || !, parentheses and calls to functions.
+// Comparisons and logical operators evaluate to 1 (true) or 0 (false).
+//
+// Tree variables holding arrays or slices are indexed with x[i].
+// Out of range indices evaluate to NaN.
+// Without an index, operations on such variables are applied element-wise
+// and must be reduced to a single value with one of:
+//   - Sum$(x): sum of the elements,
+//   - Length$(x): number of elements,
+//   - Min$(x), Max$(x): minimum and maximum of the elements,
+//   - MinIf$(x, cond), MaxIf$(x, cond): minimum and maximum of the
+//     elements satisfying cond.
+//
+// Min$ and Max$ of an empty collection evaluate to 0.
+//
+// The following mathematical functions are available: abs, sqrt, exp,
+// log, log10, pow, sin, cos, tan, asin, acos, atan, atan2, sinh, cosh,
+// tanh, floor, ceil, fmod, min and max.
+// They may also be spelled in the TMath way, e.g. TMath::Abs.
+//
+// The Func method of the returned formula returns a func() float64.
+//
+// Example:
+//
+//	f, err := rfunc.NewExprFormula("pt > 20 && abs(eta) < 2.4")
+func NewExprFormula(expr string) (Formula, error) {
+	root, err := parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("rfunc: could not parse expression %q: %w", expr, err)
+	}
+
+	f := &exprFormula{expr: expr, root: root}
+	err = f.collect(root)
+	if err != nil {
+		return nil, fmt.Errorf("rfunc: invalid expression %q: %w", expr, err)
+	}
+	return f, nil
+}
+
+type exprFormula struct {
+	expr  string
+	root  node
+	names []string
+	vars  map[string]exprValue
+
+	fct func() float64
+}
+
+func (f *exprFormula) collect(n node) error {
+	switch n := n.(type) {
+	case *numNode:
+	case *identNode:
+		for _, name := range f.names {
+			if name == n.name {
+				return nil
+			}
+		}
+		f.names = append(f.names, n.name)
+	case *unaryNode:
+		return f.collect(n.x)
+	case *binaryNode:
+		if err := f.collect(n.x); err != nil {
+			return err
+		}
+		return f.collect(n.y)
+	case *indexNode:
+		if err := f.collect(n.x); err != nil {
+			return err
+		}
+		return f.collect(n.idx)
+	case *callNode:
+		name := funcName(n.name)
+		if _, ok := exprReductions[name]; !ok {
+			if _, ok := exprFuncs[name]; !ok {
+				return fmt.Errorf("unknown function %q at offset %d", n.name, n.p)
+			}
+		}
+		for _, arg := range n.args {
+			if err := f.collect(arg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RVars implements rfunc.Formula
+func (f *exprFormula) RVars() []string { return f.names }
+
+// Bind implements rfunc.Formula
+func (f *exprFormula) Bind(args []interface{}) error {
+	if got, want := len(args), len(f.names); got != want {
+		return fmt.Errorf(
+			"rfunc: invalid number of bind arguments (got=%d, want=%d)",
+			got, want,
+		)
+	}
+
+	f.vars = make(map[string]exprValue, len(args))
+	for i, arg := range args {
+		v, err := exprVarOf(arg)
+		if err != nil {
+			return fmt.Errorf("rfunc: argument type %d (name=%s) invalid: %w", i, f.names[i], err)
+		}
+		f.vars[f.names[i]] = v
+	}
+
+	v, err := f.compile(f.root)
+	if err != nil {
+		return fmt.Errorf("rfunc: could not compile expression %q: %w", f.expr, err)
+	}
+	if v.isArray() {
+		return fmt.Errorf(
+			"rfunc: expression %q evaluates to a collection (use an index or a reduction such as Sum$)",
+			f.expr,
+		)
+	}
+	f.fct = v.scalar
+	return nil
+}
+
+// Func implements rfunc.Formula
+func (f *exprFormula) Func() interface{} {
+	return f.fct
+}
+
+// exprValue is a compiled expression.
+// Scalar expressions are evaluated with scalar.
+// Collection expressions hold their number of elements and an accessor to
+// each of their elements.
+type exprValue struct {
+	scalar func() float64
+	n      func() int
+	at     func(i int) float64
+}
+
+func (v exprValue) isArray() bool { return v.n != nil }
+
+// elems returns an element-wise view of the value.
+// Scalars are seen as collections of one element.
+func (v exprValue) elems() (func() int, func(i int) float64) {
+	if v.isArray() {
+		return v.n, v.at
+	}
+	fct := v.scalar
+	return func() int { return 1 }, func(int) float64 { return fct() }
+}
+
+func (f *exprFormula) compile(n node) (exprValue, error) {
+	switch n := n.(type) {
+	case *numNode:
+		v := n.v
+		return exprValue{scalar: func() float64 { return v }}, nil
+
+	case *identNode:
+		v, ok := f.vars[n.name]
+		if !ok {
+			return exprValue{}, fmt.Errorf("unknown variable %q at offset %d", n.name, n.p)
+		}
+		return v, nil
+
+	case *unaryNode:
+		x, err := f.compile(n.x)
+		if err != nil {
+			return exprValue{}, err
+		}
+		var op func(x float64) float64
+		switch n.op {
+		case "-":
+			op = func(x float64) float64 { return -x }
+		case "+":
+			return x, nil
+		case "!":
+			op = func(x float64) float64 { return b2f(x == 0) }
+		}
+		return mapValue(x, op), nil
+
+	case *binaryNode:
+		x, err := f.compile(n.x)
+		if err != nil {
+			return exprValue{}, err
+		}
+		y, err := f.compile(n.y)
+		if err != nil {
+			return exprValue{}, err
+		}
+		if !x.isArray() && !y.isArray() {
+			// short-circuit logical operators.
+			switch xs, ys := x.scalar, y.scalar; n.op {
+			case "&&":
+				return exprValue{scalar: func() float64 { return b2f(xs() != 0 && ys() != 0) }}, nil
+			case "||":
+				return exprValue{scalar: func() float64 { return b2f(xs() != 0 || ys() != 0) }}, nil
+			}
+		}
+		return zipValues(x, y, exprBinaryOps[n.op]), nil
+
+	case *indexNode:
+		x, err := f.compile(n.x)
+		if err != nil {
+			return exprValue{}, err
+		}
+		if !x.isArray() {
+			return exprValue{}, fmt.Errorf("invalid index of a scalar value at offset %d", n.p)
+		}
+		idx, err := f.compile(n.idx)
+		if err != nil {
+			return exprValue{}, err
+		}
+		if idx.isArray() {
+			return exprValue{}, fmt.Errorf("invalid collection index at offset %d", n.p)
+		}
+		var (
+			xn  = x.n
+			xat = x.at
+			ix  = idx.scalar
+		)
+		return exprValue{scalar: func() float64 {
+			i := int(ix())
+			if i < 0 || i >= xn() {
+				return math.NaN()
+			}
+			return xat(i)
+		}}, nil
+
+	case *callNode:
+		name := funcName(n.name)
+		args := make([]exprValue, len(n.args))
+		for i, arg := range n.args {
+			v, err := f.compile(arg)
+			if err != nil {
+				return exprValue{}, err
+			}
+			args[i] = v
+		}
+
+		if red, ok := exprReductions[name]; ok {
+			if len(args) != red.nargs {
+				return exprValue{}, fmt.Errorf(
+					"invalid number of arguments to %q at offset %d (got=%d, want=%d)",
+					n.name, n.p, len(args), red.nargs,
+				)
+			}
+			return exprValue{scalar: red.fct(args)}, nil
+		}
+
+		fct := exprFuncs[name]
+		switch fct := fct.(type) {
+		case func(float64) float64:
+			if len(args) != 1 {
+				return exprValue{}, fmt.Errorf(
+					"invalid number of arguments to %q at offset %d (got=%d, want=1)",
+					n.name, n.p, len(args),
+				)
+			}
+			return mapValue(args[0], fct), nil
+		case func(float64, float64) float64:
+			if len(args) != 2 {
+				return exprValue{}, fmt.Errorf(
+					"invalid number of arguments to %q at offset %d (got=%d, want=2)",
+					n.name, n.p, len(args),
+				)
+			}
+			return zipValues(args[0], args[1], fct), nil
+		}
+	}
+	return exprValue{}, fmt.Errorf("invalid expression node %T", n)
+}
+
+// mapValue applies op to a scalar value or to each element of a collection.
+func mapValue(x exprValue, op func(float64) float64) exprValue {
+	if !x.isArray() {
+		xs := x.scalar
+		return exprValue{scalar: func() float64 { return op(xs()) }}
+	}
+	xat := x.at
+	return exprValue{n: x.n, at: func(i int) float64 { return op(xat(i)) }}
+}
+
+// zipValues applies op to scalar values or to the elements of collections.
+// Operations between collections are applied to the first n elements, where
+// n is the length of the smallest collection.
+func zipValues(x, y exprValue, op func(x, y float64) float64) exprValue {
+	switch {
+	case !x.isArray() && !y.isArray():
+		xs, ys := x.scalar, y.scalar
+		return exprValue{scalar: func() float64 { return op(xs(), ys()) }}
+	case !y.isArray():
+		xat, ys := x.at, y.scalar
+		return exprValue{n: x.n, at: func(i int) float64 { return op(xat(i), ys()) }}
+	case !x.isArray():
+		xs, yat := x.scalar, y.at
+		return exprValue{n: y.n, at: func(i int) float64 { return op(xs(), yat(i)) }}
+	}
+	var (
+		xn, xat = x.n, x.at
+		yn, yat = y.n, y.at
+	)
+	return exprValue{
+		n: func() int {
+			n := xn()
+			if m := yn(); m < n {
+				n = m
+			}
+			return n
+		},
+		at: func(i int) float64 { return op(xat(i), yat(i)) },
+	}
+}
+
+func b2f(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+var exprBinaryOps = map[string]func(x, y float64) float64{
+	"+":  func(x, y float64) float64 { return x + y },
+	"-":  func(x, y float64) float64 { return x - y },
+	"*":  func(x, y float64) float64 { return x * y },
+	"/":  func(x, y float64) float64 { return x / y },
+	"%":  math.Mod,
+	"==": func(x, y float64) float64 { return b2f(x == y) },
+	"!=": func(x, y float64) float64 { return b2f(x != y) },
+	"<":  func(x, y float64) float64 { return b2f(x < y) },
+	"<=": func(x, y float64) float64 { return b2f(x <= y) },
+	">":  func(x, y float64) float64 { return b2f(x > y) },
+	">=": func(x, y float64) float64 { return b2f(x >= y) },
+	"&&": func(x, y float64) float64 { return b2f(x != 0 && y != 0) },
+	"||": func(x, y float64) float64 { return b2f(x != 0 || y != 0) },
+}
+
+// funcName returns the normalized name of a function.
+func funcName(name string) string {
+	if strings.HasSuffix(name, "$") {
+		return name
+	}
+	name = strings.ToLower(strings.TrimPrefix(name, "TMath::"))
+	switch name {
+	case "fabs":
+		return "abs"
+	case "power":
+		return "pow"
+	}
+	return name
+}
+
+var exprFuncs = map[string]interface{}{
+	"abs":   math.Abs,
+	"sqrt":  math.Sqrt,
+	"exp":   math.Exp,
+	"log":   math.Log,
+	"log10": math.Log10,
+	"sin":   math.Sin,
+	"cos":   math.Cos,
+	"tan":   math.Tan,
+	"asin":  math.Asin,
+	"acos":  math.Acos,
+	"atan":  math.Atan,
+	"sinh":  math.Sinh,
+	"cosh":  math.Cosh,
+	"tanh":  math.Tanh,
+	"floor": math.Floor,
+	"ceil":  math.Ceil,
+	"pow":   math.Pow,
+	"atan2": math.Atan2,
+	"min":   math.Min,
+	"max":   math.Max,
+	"fmod":  math.Mod,
+}
+
+type exprReduction struct {
+	nargs int
+	fct   func(args []exprValue) func() float64
+}
+
+var exprReductions = map[string]exprReduction{
+	"Sum$": {1, func(args []exprValue) func() float64 {
+		n, at := args[0].elems()
+		return func() float64 {
+			sum := 0.0
+			for i, n := 0, n(); i < n; i++ {
+				sum += at(i)
+			}
+			return sum
+		}
+	}},
+	"Length$": {1, func(args []exprValue) func() float64 {
+		n, _ := args[0].elems()
+		return func() float64 { return float64(n()) }
+	}},
+	"Min$": {1, func(args []exprValue) func() float64 {
+		return reduceIf(args[0], exprValue{}, math.Min)
+	}},
+	"Max$": {1, func(args []exprValue) func() float64 {
+		return reduceIf(args[0], exprValue{}, math.Max)
+	}},
+	"MinIf$": {2, func(args []exprValue) func() float64 {
+		return reduceIf(args[0], args[1], math.Min)
+	}},
+	"MaxIf$": {2, func(args []exprValue) func() float64 {
+		return reduceIf(args[0], args[1], math.Max)
+	}},
+}
+
+// reduceIf reduces the elements of x for which cond is true.
+// A zero cond selects all the elements.
+func reduceIf(x, cond exprValue, op func(x, y float64) float64) func() float64 {
+	n, at := x.elems()
+	sel := func(int) bool { return true }
+	if cond.isArray() || cond.scalar != nil {
+		n = zipValues(x, cond, exprBinaryOps["+"]).n
+		if n == nil {
+			n = func() int { return 1 }
+		}
+		_, cat := cond.elems()
+		sel = func(i int) bool { return cat(i) != 0 }
+	}
+	return func() float64 {
+		var (
+			v  float64
+			ok bool
+		)
+		for i, n := 0, n(); i < n; i++ {
+			if !sel(i) {
+				continue
+			}
+			if !ok {
+				v, ok = at(i), true
+				continue
+			}
+			v = op(v, at(i))
+		}
+		return v
+	}
+}
+
+// exprVarOf returns the expression value reading the tree variable pointed
+// at by ptr.
+func exprVarOf(ptr interface{}) (exprValue, error) {
+	switch ptr := ptr.(type) {
+	case *bool:
+		return exprValue{scalar: func() float64 { return b2f(*ptr) }}, nil
+	case *int8:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *int16:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *int32:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *int64:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *uint8:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *uint16:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *uint32:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *uint64:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *float32:
+		return exprValue{scalar: func() float64 { return float64(*ptr) }}, nil
+	case *float64:
+		return exprValue{scalar: func() float64 { return *ptr }}, nil
+
+	case *[]bool:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return b2f((*ptr)[i]) },
+		}, nil
+	case *[]int8:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]int16:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]int32:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]int64:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]uint8:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]uint16:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]uint32:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]uint64:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]float32:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return float64((*ptr)[i]) },
+		}, nil
+	case *[]float64:
+		return exprValue{
+			n:  func() int { return len(*ptr) },
+			at: func(i int) float64 { return (*ptr)[i] },
+		}, nil
+	}
+
+	rv := reflect.ValueOf(ptr)
+	if rv.Kind() != reflect.Ptr {
+		return exprValue{}, fmt.Errorf("invalid non-pointer type %T", ptr)
+	}
+	rv = rv.Elem()
+	switch rv.Kind() {
+	case reflect.Array, reflect.Slice:
+		conv, ok := floatOf(rv.Type().Elem())
+		if !ok {
+			return exprValue{}, fmt.Errorf("invalid collection type %T", ptr)
+		}
+		return exprValue{
+			n:  func() int { return rv.Len() },
+			at: func(i int) float64 { return conv(rv.Index(i)) },
+		}, nil
+	}
+	conv, ok := floatOf(rv.Type())
+	if !ok {
+		return exprValue{}, fmt.Errorf("invalid type %T", ptr)
+	}
+	return exprValue{scalar: func() float64 { return conv(rv) }}, nil
+}
+
+// floatOf returns a function converting values of type rt to float64.
+func floatOf(rt reflect.Type) (func(rv reflect.Value) float64, bool) {
+	switch rt.Kind() {
+	case reflect.Bool:
+		return func(rv reflect.Value) float64 { return b2f(rv.Bool()) }, true
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		return func(rv reflect.Value) float64 { return float64(rv.Int()) }, true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return func(rv reflect.Value) float64 { return float64(rv.Uint()) }, true
+	case reflect.Float32, reflect.Float64:
+		return func(rv reflect.Value) float64 { return rv.Float() }, true
+	}
+	return nil, false
+}
+
+var (
+	_ Formula = (*exprFormula)(nil)
+)
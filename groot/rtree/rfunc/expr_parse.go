@@ -0,0 +1,365 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rfunc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNum
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBrack
+	tokRBrack
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	text string
+}
+
+// lexer splits an expression into tokens.
+//
+// Identifiers may contain dots (to name sub-branches), '$' (for ROOT
+// special functions such as Sum$) and '::' (for TMath functions).
+type lexer struct {
+	src  string
+	pos  int
+	toks []token
+}
+
+func lex(src string) ([]token, error) {
+	lx := &lexer{src: src}
+	for {
+		tok, err := lx.next()
+		if err != nil {
+			return nil, err
+		}
+		lx.toks = append(lx.toks, tok)
+		if tok.kind == tokEOF {
+			return lx.toks, nil
+		}
+	}
+}
+
+func (lx *lexer) next() (token, error) {
+	for lx.pos < len(lx.src) && unicode.IsSpace(rune(lx.src[lx.pos])) {
+		lx.pos++
+	}
+	if lx.pos >= len(lx.src) {
+		return token{kind: tokEOF, pos: lx.pos}, nil
+	}
+
+	var (
+		beg = lx.pos
+		c   = lx.src[lx.pos]
+	)
+	switch {
+	case isDigit(c) || (c == '.' && lx.pos+1 < len(lx.src) && isDigit(lx.src[lx.pos+1])):
+		return lx.number()
+
+	case isIdentStart(c):
+		for lx.pos < len(lx.src) {
+			c := lx.src[lx.pos]
+			switch {
+			case isIdentStart(c) || isDigit(c) || c == '.' || c == '$':
+				lx.pos++
+			case c == ':' && strings.HasPrefix(lx.src[lx.pos:], "::"):
+				lx.pos += 2
+			default:
+				return token{kind: tokIdent, pos: beg, text: lx.src[beg:lx.pos]}, nil
+			}
+		}
+		return token{kind: tokIdent, pos: beg, text: lx.src[beg:lx.pos]}, nil
+	}
+
+	lx.pos++
+	switch c {
+	case '(':
+		return token{kind: tokLParen, pos: beg, text: "("}, nil
+	case ')':
+		return token{kind: tokRParen, pos: beg, text: ")"}, nil
+	case '[':
+		return token{kind: tokLBrack, pos: beg, text: "["}, nil
+	case ']':
+		return token{kind: tokRBrack, pos: beg, text: "]"}, nil
+	case ',':
+		return token{kind: tokComma, pos: beg, text: ","}, nil
+	case '+', '-', '*', '/', '%':
+		return token{kind: tokOp, pos: beg, text: string(c)}, nil
+	case '<', '>', '=', '!':
+		if lx.pos < len(lx.src) && lx.src[lx.pos] == '=' {
+			lx.pos++
+			return token{kind: tokOp, pos: beg, text: lx.src[beg:lx.pos]}, nil
+		}
+		if c == '=' {
+			return token{}, fmt.Errorf("invalid operator %q at offset %d (use '==')", c, beg)
+		}
+		return token{kind: tokOp, pos: beg, text: string(c)}, nil
+	case '&', '|':
+		if lx.pos < len(lx.src) && lx.src[lx.pos] == c {
+			lx.pos++
+			return token{kind: tokOp, pos: beg, text: lx.src[beg:lx.pos]}, nil
+		}
+		return token{}, fmt.Errorf("invalid operator %q at offset %d", c, beg)
+	}
+	return token{}, fmt.Errorf("invalid character %q at offset %d", c, beg)
+}
+
+func (lx *lexer) number() (token, error) {
+	beg := lx.pos
+loop:
+	for lx.pos < len(lx.src) {
+		switch c := lx.src[lx.pos]; {
+		case isDigit(c) || c == '.':
+			lx.pos++
+		case c == 'e' || c == 'E':
+			lx.pos++
+			if lx.pos < len(lx.src) && (lx.src[lx.pos] == '+' || lx.src[lx.pos] == '-') {
+				lx.pos++
+			}
+		default:
+			break loop
+		}
+	}
+	txt := lx.src[beg:lx.pos]
+	if _, err := strconv.ParseFloat(txt, 64); err != nil {
+		return token{}, fmt.Errorf("invalid number %q at offset %d", txt, beg)
+	}
+	return token{kind: tokNum, pos: beg, text: txt}, nil
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+func isIdentStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// node is a node of the abstract syntax tree of an expression.
+type node interface {
+	pos() int
+}
+
+type (
+	numNode struct {
+		p int
+		v float64
+	}
+
+	identNode struct {
+		p    int
+		name string
+	}
+
+	unaryNode struct {
+		p  int
+		op string
+		x  node
+	}
+
+	binaryNode struct {
+		p    int
+		op   string
+		x, y node
+	}
+
+	callNode struct {
+		p    int
+		name string
+		args []node
+	}
+
+	indexNode struct {
+		p   int
+		x   node
+		idx node
+	}
+)
+
+func (n *numNode) pos() int    { return n.p }
+func (n *identNode) pos() int  { return n.p }
+func (n *unaryNode) pos() int  { return n.p }
+func (n *binaryNode) pos() int { return n.p }
+func (n *callNode) pos() int   { return n.p }
+func (n *indexNode) pos() int  { return n.p }
+
+// binaryPrec holds the precedence of binary operators.
+var binaryPrec = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+const unaryPrec = 7
+
+// parser is a Pratt parser for expressions.
+type parser struct {
+	toks []token
+	cur  int
+}
+
+func parse(src string) (node, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	n, err := p.expr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token { return p.toks[p.cur] }
+func (p *parser) advance() token {
+	tok := p.toks[p.cur]
+	if tok.kind != tokEOF {
+		p.cur++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.advance()
+	if tok.kind != kind {
+		if tok.kind == tokEOF {
+			return tok, fmt.Errorf("missing %q at end of expression", what)
+		}
+		return tok, fmt.Errorf("expected %q at offset %d, got %q", what, tok.pos, tok.text)
+	}
+	return tok, nil
+}
+
+func (p *parser) expr(prec int) (node, error) {
+	lhs, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != tokOp {
+			return lhs, nil
+		}
+		bp, ok := binaryPrec[tok.text]
+		if !ok {
+			return nil, fmt.Errorf("invalid binary operator %q at offset %d", tok.text, tok.pos)
+		}
+		if bp <= prec {
+			return lhs, nil
+		}
+		p.advance()
+		rhs, err := p.expr(bp)
+		if err != nil {
+			return nil, err
+		}
+		lhs = &binaryNode{p: tok.pos, op: tok.text, x: lhs, y: rhs}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	tok := p.peek()
+	if tok.kind == tokOp {
+		switch tok.text {
+		case "-", "+", "!":
+			p.advance()
+			x, err := p.expr(unaryPrec)
+			if err != nil {
+				return nil, err
+			}
+			return &unaryNode{p: tok.pos, op: tok.text, x: x}, nil
+		}
+	}
+	return p.postfix()
+}
+
+func (p *parser) postfix() (node, error) {
+	x, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokLBrack {
+		tok := p.advance()
+		idx, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(tokRBrack, "]")
+		if err != nil {
+			return nil, err
+		}
+		x = &indexNode{p: tok.pos, x: x, idx: idx}
+	}
+	return x, nil
+}
+
+func (p *parser) primary() (node, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokNum:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at offset %d", tok.text, tok.pos)
+		}
+		return &numNode{p: tok.pos, v: v}, nil
+
+	case tokIdent:
+		if p.peek().kind != tokLParen {
+			return &identNode{p: tok.pos, name: tok.text}, nil
+		}
+		p.advance()
+		call := &callNode{p: tok.pos, name: tok.text}
+		if p.peek().kind == tokRParen {
+			p.advance()
+			return call, nil
+		}
+		for {
+			arg, err := p.expr(0)
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			tok, err := p.expect(tokComma, ",")
+			if err == nil {
+				continue
+			}
+			if tok.kind == tokRParen {
+				return call, nil
+			}
+			return nil, fmt.Errorf("expected ',' or ')' at offset %d in call to %q", tok.pos, call.name)
+		}
+
+	case tokLParen:
+		x, err := p.expr(0)
+		if err != nil {
+			return nil, err
+		}
+		_, err = p.expect(tokRParen, ")")
+		if err != nil {
+			return nil, err
+		}
+		return x, nil
+
+	case tokEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	return nil, fmt.Errorf("unexpected %q at offset %d", tok.text, tok.pos)
+}
@@ -0,0 +1,260 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rfunc
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExprFormula(t *testing.T) {
+	var (
+		pt   = 25.0
+		eta  = float32(-1.5)
+		n    = int32(3)
+		ok   = true
+		u8   = uint8(2)
+		jet  = []float64{10, 40, 30}
+		ids  = []int32{1, -1, 2}
+		arr  = [3]int16{1, 2, 3}
+		none []float32
+	)
+
+	vars := map[string]interface{}{
+		"pt":      &pt,
+		"eta":     &eta,
+		"n":       &n,
+		"ok":      &ok,
+		"u8":      &u8,
+		"jet.pt":  &jet,
+		"ids":     &ids,
+		"arr":     &arr,
+		"empty":   &none,
+		"Muon_pt": &jet,
+	}
+
+	for _, tc := range []struct {
+		expr  string
+		rvars []string
+		want  float64
+	}{
+		{expr: "42", want: 42},
+		{expr: "1.5e2", want: 150},
+		{expr: ".5", want: 0.5},
+		{expr: "pt", rvars: []string{"pt"}, want: 25},
+		{expr: "-pt + 2*pt", rvars: []string{"pt"}, want: 25},
+		{expr: "1 + 2 * 3", want: 7},
+		{expr: "(1 + 2) * 3", want: 9},
+		{expr: "7 % 4", want: 3},
+		{expr: "2 - 3 - 4", want: -5},
+		{expr: "8 / 2 / 2", want: 2},
+		{expr: "pt > 20 && abs(eta) < 2.4", rvars: []string{"pt", "eta"}, want: 1},
+		{expr: "pt > 30 || abs(eta) < 1", rvars: []string{"pt", "eta"}, want: 0},
+		{expr: "!(pt > 30)", rvars: []string{"pt"}, want: 1},
+		{expr: "pt == 25 && n != 2", rvars: []string{"pt", "n"}, want: 1},
+		{expr: "ok + u8", rvars: []string{"ok", "u8"}, want: 3},
+		{expr: "1 < 2 == 1", want: 1},
+		{expr: "TMath::Abs(eta)", rvars: []string{"eta"}, want: 1.5},
+		{expr: "fabs(eta)", rvars: []string{"eta"}, want: 1.5},
+		{expr: "TMath::Power(2, 10)", rvars: nil, want: 1024},
+		{expr: "sqrt(pt)", rvars: []string{"pt"}, want: 5},
+		{expr: "max(pt, 30)", rvars: []string{"pt"}, want: 30},
+		{expr: "atan2(0, 1)", want: 0},
+		{expr: "fmod(7, 4)", want: 3},
+		{expr: "jet.pt[1]", rvars: []string{"jet.pt"}, want: 40},
+		{expr: "jet.pt[n-1]", rvars: []string{"jet.pt", "n"}, want: 30},
+		{expr: "arr[2]", rvars: []string{"arr"}, want: 3},
+		{expr: "Sum$(jet.pt)", rvars: []string{"jet.pt"}, want: 80},
+		{expr: "Sum$(jet.pt > 20)", rvars: []string{"jet.pt"}, want: 2},
+		{expr: "Sum$(jet.pt * ids)", rvars: []string{"jet.pt", "ids"}, want: 30},
+		{expr: "Sum$(2*arr)", rvars: []string{"arr"}, want: 12},
+		{expr: "Sum$(pt)", rvars: []string{"pt"}, want: 25},
+		{expr: "Length$(jet.pt)", rvars: []string{"jet.pt"}, want: 3},
+		{expr: "Length$(empty)", rvars: []string{"empty"}, want: 0},
+		{expr: "Min$(jet.pt)", rvars: []string{"jet.pt"}, want: 10},
+		{expr: "Max$(abs(ids))", rvars: []string{"ids"}, want: 2},
+		{expr: "Max$(empty)", rvars: []string{"empty"}, want: 0},
+		{expr: "MaxIf$(jet.pt, ids > 0)", rvars: []string{"jet.pt", "ids"}, want: 30},
+		{expr: "MinIf$(jet.pt, jet.pt > 15)", rvars: []string{"jet.pt"}, want: 30},
+		{expr: "MinIf$(jet.pt, 0)", rvars: []string{"jet.pt"}, want: 0},
+		{expr: "Sum$(Muon_pt > 20) >= 2", rvars: []string{"Muon_pt"}, want: 1},
+		{expr: "Length$(jet.pt) + Length$(jet.pt)", rvars: []string{"jet.pt"}, want: 6},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			form, err := NewExprFormula(tc.expr)
+			if err != nil {
+				t.Fatalf("could not create formula: %+v", err)
+			}
+
+			if got, want := form.RVars(), tc.rvars; !reflect.DeepEqual(got, want) {
+				t.Fatalf("invalid rvars: got=%q, want=%q", got, want)
+			}
+
+			ptrs := make([]interface{}, len(tc.rvars))
+			for i, name := range tc.rvars {
+				ptrs[i] = vars[name]
+			}
+
+			err = form.Bind(ptrs)
+			if err != nil {
+				t.Fatalf("could not bind formula: %+v", err)
+			}
+
+			got := form.Func().(func() float64)()
+			if got != tc.want {
+				t.Fatalf("invalid value: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExprFormulaUpdate(t *testing.T) {
+	form, err := NewExprFormula("Sum$(x > cut)")
+	if err != nil {
+		t.Fatalf("could not create formula: %+v", err)
+	}
+
+	var (
+		x   []float64
+		cut float64
+	)
+	err = form.Bind([]interface{}{&x, &cut})
+	if err != nil {
+		t.Fatalf("could not bind formula: %+v", err)
+	}
+	fct := form.Func().(func() float64)
+
+	for _, tc := range []struct {
+		x    []float64
+		cut  float64
+		want float64
+	}{
+		{nil, 0, 0},
+		{[]float64{1, 2, 3}, 0, 3},
+		{[]float64{1, 2, 3}, 1, 2},
+		{[]float64{1, 2, 3, 4, 5}, 1, 4},
+		{[]float64{1}, 1, 0},
+	} {
+		x = tc.x
+		cut = tc.cut
+		if got, want := fct(), tc.want; got != want {
+			t.Fatalf("invalid value for x=%v, cut=%v: got=%v, want=%v", x, cut, got, want)
+		}
+	}
+}
+
+func TestExprFormulaOutOfRange(t *testing.T) {
+	form, err := NewExprFormula("x[2]")
+	if err != nil {
+		t.Fatalf("could not create formula: %+v", err)
+	}
+	x := []float64{1, 2}
+	err = form.Bind([]interface{}{&x})
+	if err != nil {
+		t.Fatalf("could not bind formula: %+v", err)
+	}
+	if got := form.Func().(func() float64)(); !math.IsNaN(got) {
+		t.Fatalf("invalid value: got=%v, want=NaN", got)
+	}
+}
+
+func TestExprFormulaParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		err  string
+	}{
+		{"", "unexpected end of expression"},
+		{"1 +", "unexpected end of expression"},
+		{"(1 + 2", `missing ")" at end of expression`},
+		{"x[1", `missing "]" at end of expression`},
+		{"1 2", `unexpected "2" at offset 2`},
+		{"x = 2", `invalid operator '=' at offset 2 (use '==')`},
+		{"x & y", `invalid operator '&' at offset 2`},
+		{"x # 2", `invalid character '#' at offset 2`},
+		{"1.2.3", `invalid number "1.2.3" at offset 0`},
+		{"foo(x)", `unknown function "foo" at offset 0`},
+		{"abs(x y)", `expected ',' or ')' at offset 6 in call to "abs"`},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := NewExprFormula(tc.expr)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; !strings.Contains(got, want) {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
+
+func TestExprFormulaBindErrors(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		ptrs []interface{}
+		err  string
+	}{
+		{
+			expr: "x + y",
+			ptrs: []interface{}{new(float64)},
+			err:  "rfunc: invalid number of bind arguments (got=1, want=2)",
+		},
+		{
+			expr: "x",
+			ptrs: []interface{}{nil},
+			err:  "rfunc: argument type 0 (name=x) invalid: invalid non-pointer type <nil>",
+		},
+		{
+			expr: "x",
+			ptrs: []interface{}{new(string)},
+			err:  "rfunc: argument type 0 (name=x) invalid: invalid type *string",
+		},
+		{
+			expr: "x",
+			ptrs: []interface{}{new([]string)},
+			err:  "rfunc: argument type 0 (name=x) invalid: invalid collection type *[]string",
+		},
+		{
+			expr: "x",
+			ptrs: []interface{}{new([]float64)},
+			err:  `rfunc: expression "x" evaluates to a collection (use an index or a reduction such as Sum$)`,
+		},
+		{
+			expr: "x[0]",
+			ptrs: []interface{}{new(float64)},
+			err:  `rfunc: could not compile expression "x[0]": invalid index of a scalar value at offset 1`,
+		},
+		{
+			expr: "x[y]",
+			ptrs: []interface{}{new([]float64), new([]int32)},
+			err:  `rfunc: could not compile expression "x[y]": invalid collection index at offset 1`,
+		},
+		{
+			expr: "abs(x, x)",
+			ptrs: []interface{}{new(float64)},
+			err:  `rfunc: could not compile expression "abs(x, x)": invalid number of arguments to "abs" at offset 0 (got=2, want=1)`,
+		},
+		{
+			expr: "Sum$(x, x)",
+			ptrs: []interface{}{new([]float64)},
+			err:  `rfunc: could not compile expression "Sum$(x, x)": invalid number of arguments to "Sum$" at offset 0 (got=2, want=1)`,
+		},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			form, err := NewExprFormula(tc.expr)
+			if err != nil {
+				t.Fatalf("could not create formula: %+v", err)
+			}
+			err = form.Bind(tc.ptrs)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%v", got, want)
+			}
+		})
+	}
+}
@@ -131,8 +131,19 @@ func newRLeafElem(leaf *tleafElement, rvar ReadVar, rctx rleafCtx) rleaf {
 		impl  rstreamerImpl
 		sictx = leaf.branch.getTree().getFile()
 	)
-	switch rv := reflect.ValueOf(rvar.Value).Elem(); rv.Kind() {
-	case reflect.Struct:
+	// split collections are read through their leaf-count and
+	// one leaf per data member of their elements.
+	switch rv := reflect.ValueOf(rvar.Value).Elem(); {
+	case rv.Kind() == reflect.Int32 && len(leaf.branch.Branches()) > 0:
+		return newRLeafCollCount(leaf, rvar)
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Struct && rvar.count != "":
+		return newRLeafCollElem(leaf, rvar, rctx)
+	}
+	// leaves of data members may hold the streamers of all their siblings:
+	// only bind the streamer of the data member.
+	member := leaf.id >= 0
+	switch rv := derefValue(reflect.ValueOf(rvar.Value).Elem()); {
+	case rv.Kind() == reflect.Struct && !member:
 		var lc leafCount
 		if leaf.count != nil {
 			lc = rctx.rcountLeaf(leaf.count.Name())
@@ -221,6 +232,95 @@ var (
 	_ rleaf = (*rleafElem)(nil)
 )
 
+// newRLeafCollCount returns the rleaf reading the number of elements
+// of a split collection.
+func newRLeafCollCount(leaf *tleafElement, rvar ReadVar) *rleafElem {
+	ptr := rvar.Value.(*int32)
+	return &rleafElem{
+		base: leaf,
+		v:    rvar.Value,
+		streamer: &rstreamerImpl{funcs: []rstreamerFunc{
+			func(r *rbytes.RBuffer) error {
+				*ptr = r.ReadI32()
+				return r.Err()
+			},
+		}},
+	}
+}
+
+// rleafCollElem reads a data member of the elements of a split collection.
+type rleafCollElem struct {
+	base  *tleafElement
+	v     reflect.Value // slice of elements to fill
+	elt   reflect.Value // element receiving the data member
+	field int           // index of the data member
+	n     func() int    // number of elements of the collection
+	rfunc rstreamerFunc
+}
+
+func newRLeafCollElem(leaf *tleafElement, rvar ReadVar, rctx rleafCtx) *rleafCollElem {
+	var (
+		sictx = leaf.branch.getTree().getFile()
+		v     = reflect.ValueOf(rvar.Value).Elem()
+		elt   = reflect.New(v.Type().Elem())
+		lname = leaf.Name()
+	)
+	if idx := strings.LastIndex(lname, "."); idx >= 0 {
+		lname = lname[idx+1:]
+	}
+	if idx := strings.Index(lname, "["); idx > 0 {
+		lname = lname[:idx]
+	}
+
+	var se rbytes.StreamerElement
+	for _, elmt := range leaf.streamers {
+		if elmt.Name() == lname {
+			se = elmt
+			break
+		}
+	}
+	if se == nil {
+		panic(fmt.Errorf(
+			"rtree: could not find streamer element for rleaf %q", leaf.Name(),
+		))
+	}
+
+	return &rleafCollElem{
+		base:  leaf,
+		v:     v,
+		elt:   elt.Elem(),
+		field: fieldOf(elt.Elem().Type(), se.Name()),
+		n:     rctx.rcountFunc(rvar.count),
+		rfunc: rstreamerFrom(se, elt.Interface(), nil, sictx),
+	}
+}
+
+func (leaf *rleafCollElem) Leaf() Leaf { return leaf.base }
+
+func (leaf *rleafCollElem) Offset() int64 {
+	return int64(leaf.base.Offset())
+}
+
+func (leaf *rleafCollElem) readFromBuffer(r *rbytes.RBuffer) error {
+	n := leaf.n()
+	if leaf.v.IsNil() || leaf.v.Cap() < n {
+		leaf.v.Set(reflect.MakeSlice(leaf.v.Type(), n, n))
+	}
+	leaf.v.SetLen(n)
+	for i := 0; i < n; i++ {
+		err := leaf.rfunc(r)
+		if err != nil {
+			return err
+		}
+		leaf.v.Index(i).Field(leaf.field).Set(leaf.elt.Field(leaf.field))
+	}
+	return r.Err()
+}
+
+var (
+	_ rleaf = (*rleafCollElem)(nil)
+)
+
 type rleafCount struct {
 	Leaf
 	n    func() int
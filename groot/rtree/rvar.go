@@ -36,6 +36,20 @@ func NewReadVars(t Tree) []ReadVar {
 		}
 	}
 
+	if t, ok := t.(*friend); ok {
+		// shadowed branches of friend trees are only accessible
+		// with the name of their tree as a prefix.
+		for i, f := range t.friends {
+			for _, rv := range NewReadVars(f) {
+				if t.owner(rv.Name) == i {
+					continue
+				}
+				rv.Name = t.aliasOf(i, rv.Name)
+				vars = append(vars, rv)
+			}
+		}
+	}
+
 	return vars
 }
 
@@ -121,8 +135,6 @@ func ReadVarsFromStruct(ptr interface{}) []ReadVar {
 		switch ft.Type.Kind() {
 		case reflect.Int, reflect.Uint, reflect.UnsafePointer, reflect.Uintptr, reflect.Chan, reflect.Interface:
 			panic(fmt.Errorf("rtree: invalid field type for %q: %T", ft.Name, fv.Interface()))
-		case reflect.Map:
-			panic(fmt.Errorf("rtree: invalid field type for %q: %T (not yet supported)", ft.Name, fv.Interface()))
 		}
 
 		rvar.Leaf = rvar.Name
@@ -143,9 +155,12 @@ func bindRVarsTo(t Tree, rvars []ReadVar) []ReadVar {
 	ors := make([]ReadVar, 0, len(rvars))
 	var flatten func(b Branch, rvar ReadVar) []ReadVar
 	flatten = func(br Branch, rvar ReadVar) []ReadVar {
+		rv := reflect.ValueOf(rvar.Value).Elem()
+		if rv.Kind() == reflect.Slice {
+			return collectionRVars(br, rvar)
+		}
 		nsub := len(br.Branches())
 		subs := make([]ReadVar, 0, nsub)
-		rv := reflect.ValueOf(rvar.Value).Elem()
 		get := func(name string) int {
 			rt := rv.Type()
 			for i := 0; i < rt.NumField(); i++ {
@@ -173,6 +188,9 @@ func bindRVarsTo(t Tree, rvars []ReadVar) []ReadVar {
 				toks := strings.Split(bn, ".")
 				bn = toks[len(toks)-1]
 			}
+			if idx := strings.Index(bn, "["); idx > 0 {
+				bn = string(bn[:idx])
+			}
 			j := get(bn)
 			if j < 0 {
 				continue
@@ -230,3 +248,46 @@ func bindRVarsTo(t Tree, rvars []ReadVar) []ReadVar {
 	}
 	return ors
 }
+
+// collectionRVars returns the read-vars of the split collection held by
+// the provided branch: the leaf-count of the collection, followed by one
+// read-var per data member of its elements, all filling the slice pointed
+// at by rvar.
+func collectionRVars(br Branch, rvar ReadVar) []ReadVar {
+	var (
+		et    = reflect.TypeOf(rvar.Value).Elem().Elem()
+		count = br.Leaves()[0]
+		subs  = make([]ReadVar, 0, 1+len(br.Branches()))
+	)
+	subs = append(subs, ReadVar{
+		Name:  rvar.Name,
+		Leaf:  count.Name(),
+		Value: new(int32),
+		leaf:  count,
+	})
+	if et.Kind() != reflect.Struct {
+		return subs
+	}
+
+	for _, sub := range br.Branches() {
+		name := sub.Name()
+		if idx := strings.LastIndex(name, "."); idx >= 0 {
+			name = name[idx+1:]
+		}
+		if idx := strings.Index(name, "["); idx > 0 {
+			name = name[:idx]
+		}
+		if fieldOf(et, name) < 0 {
+			continue
+		}
+		leaf := sub.Leaves()[0]
+		subs = append(subs, ReadVar{
+			Name:  rvar.Name + "." + name,
+			Leaf:  leaf.Name(),
+			Value: rvar.Value,
+			count: count.Name(),
+			leaf:  leaf,
+		})
+	}
+	return subs
+}
@@ -125,11 +125,13 @@ func TestReadVarsFromStruct(t *testing.T) {
 			panics: "rtree: invalid field type for \"I32\": int",
 		},
 		{
-			name: "struct-with-map", // FIXME(sbinet)
+			name: "struct-with-map",
 			ptr: &struct {
 				Map map[int32]string
+				Set map[string]struct{}
+				Ptr *float64
 			}{},
-			panics: "rtree: invalid field type for \"Map\": map[int32]string (not yet supported)",
+			want: []ReadVar{{Name: "Map"}, {Name: "Set"}, {Name: "Ptr"}},
 		},
 		{
 			name: "invalid-struct-tag",
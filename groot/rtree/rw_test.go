@@ -1707,6 +1707,560 @@ void scan(const char *fname, const char *tree, const char *oname) {
 
 }
 
+type wP3 struct {
+	Px int32
+	Py float64
+	Pz int32
+}
+
+type wHit struct {
+	ID    int32
+	E     float64
+	Label string
+}
+
+type wEvent struct {
+	Name    string
+	I16     int16
+	U64     uint64
+	F32     float32
+	ArrF64  [3]float64
+	N       int32
+	SliF64  []float64 `groot:"SliF64[N]"`
+	P3      wP3
+	VecI16  []int16
+	VecU8   []uint8
+	VecStr  []string
+	Hits    []wHit
+	private int32
+}
+
+func newWEvent(i int) wEvent {
+	evt := wEvent{
+		Name:   fmt.Sprintf("evt-%03d", i),
+		I16:    int16(-i),
+		U64:    uint64(i),
+		F32:    float32(i),
+		ArrF64: [3]float64{float64(i), float64(i + 1), float64(i + 2)},
+		N:      int32(i % 5),
+		P3:     wP3{Px: int32(i - 1), Py: float64(i), Pz: int32(i + 1)},
+	}
+	for j := 0; j < int(evt.N); j++ {
+		evt.SliF64 = append(evt.SliF64, float64(i*10+j))
+		evt.VecI16 = append(evt.VecI16, int16(-j))
+		evt.VecU8 = append(evt.VecU8, uint8(j))
+		evt.VecStr = append(evt.VecStr, fmt.Sprintf("str-%d-%d", i, j))
+		evt.Hits = append(evt.Hits, wHit{ID: int32(j), E: float64(i + j), Label: fmt.Sprintf("hit-%d", j)})
+	}
+	if evt.N == 0 {
+		evt.SliF64 = []float64{}
+		evt.VecI16 = []int16{}
+		evt.VecU8 = []uint8{}
+		evt.VecStr = []string{}
+		evt.Hits = []wHit{}
+	}
+	return evt
+}
+
+func TestTreeRWStructs(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create dir: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const nevts = 100
+
+	for _, tc := range []struct {
+		name  string
+		split int
+		nsubs int
+	}{
+		{name: "unsplit", split: 0, nsubs: 0},
+		{name: "split", split: 99, nsubs: 12},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(tmp, "structs-"+tc.name+".root")
+
+			func() {
+				f, err := riofs.Create(fname)
+				if err != nil {
+					t.Fatalf("could not create file: %+v", err)
+				}
+				defer f.Close()
+
+				var (
+					evt  wEvent
+					vf64 []float64
+					hits []wHit
+				)
+				wvars := []WriteVar{
+					{Name: "evt", Value: &evt},
+					{Name: "vf64", Value: &vf64},
+					{Name: "hits", Value: &hits},
+				}
+				w, err := NewWriter(f, "tree", wvars, WithSplitLevel(tc.split), WithBasketSize(512))
+				if err != nil {
+					t.Fatalf("could not create tree writer: %+v", err)
+				}
+				defer w.Close()
+
+				for i := 0; i < nevts; i++ {
+					evt = newWEvent(i)
+					vf64 = evt.SliF64
+					hits = evt.Hits
+					_, err = w.Write()
+					if err != nil {
+						t.Fatalf("could not write event %d: %+v", i, err)
+					}
+				}
+
+				err = w.Close()
+				if err != nil {
+					t.Fatalf("could not close tree writer: %+v", err)
+				}
+
+				err = f.Close()
+				if err != nil {
+					t.Fatalf("could not close file: %+v", err)
+				}
+			}()
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			o, err := riofs.Dir(f).Get("tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+			tree := o.(Tree)
+
+			if got, want := tree.Entries(), int64(nevts); got != want {
+				t.Fatalf("invalid number of entries: got=%d, want=%d", got, want)
+			}
+
+			if got, want := len(tree.Branch("evt").Branches()), tc.nsubs; got != want {
+				t.Fatalf("invalid number of sub-branches: got=%d, want=%d", got, want)
+			}
+
+			if tc.split > 0 {
+				// collections of structs are split member-wise.
+				for _, br := range []Branch{tree.Branch("hits"), tree.Branch("evt").Branch("Hits")} {
+					if got, want := len(br.Branches()), 3; got != want {
+						t.Fatalf("invalid number of sub-branches for %q: got=%d, want=%d", br.Name(), got, want)
+					}
+					if got, want := br.Leaves()[0].Name(), br.Name()+"_"; got != want {
+						t.Fatalf("invalid leaf-count name for %q: got=%q, want=%q", br.Name(), got, want)
+					}
+				}
+			}
+
+			for _, name := range []string{"evt", "vf64", "hits"} {
+				if _, ok := tree.Branch(name).(*tbranchElement); !ok {
+					t.Fatalf("invalid branch type for %q: %T", name, tree.Branch(name))
+				}
+			}
+
+			var (
+				evt  wEvent
+				vf64 []float64
+				hits []wHit
+			)
+			rvars := []ReadVar{
+				{Name: "evt", Value: &evt},
+				{Name: "vf64", Value: &vf64},
+				{Name: "hits", Value: &hits},
+			}
+			r, err := NewReader(tree, rvars)
+			if err != nil {
+				t.Fatalf("could not create tree reader: %+v", err)
+			}
+			defer r.Close()
+
+			n := 0
+			err = r.Read(func(ctx RCtx) error {
+				want := newWEvent(int(ctx.Entry))
+				if !reflect.DeepEqual(evt, want) {
+					return fmt.Errorf("invalid event %d:\ngot= %#v\nwant=%#v", ctx.Entry, evt, want)
+				}
+				if !reflect.DeepEqual(vf64, want.SliF64) {
+					return fmt.Errorf("invalid vf64 %d:\ngot= %v\nwant=%v", ctx.Entry, vf64, want.SliF64)
+				}
+				if !reflect.DeepEqual(hits, want.Hits) {
+					return fmt.Errorf("invalid hits %d:\ngot= %v\nwant=%v", ctx.Entry, hits, want.Hits)
+				}
+				n++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+			if n != nevts {
+				t.Fatalf("invalid number of events read: got=%d, want=%d", n, nevts)
+			}
+
+			if !rtests.HasROOT {
+				return
+			}
+
+			code := `#include <cstdio>
+#include <iostream>
+#include "TFile.h"
+#include "TTree.h"
+
+void check(const char *fname, const char *oname) {
+	auto f = TFile::Open(fname);
+	auto t = (TTree*)f->Get("tree");
+	if (!t) {
+		std::cerr << "could not fetch TTree [tree] from file [" << fname << "]\n";
+		exit(1);
+	}
+
+	auto o = fopen(oname, "w");
+	fprintf(o, "entries: %lld\n", t->GetEntries());
+	for (Long64_t i = 0; i < t->GetEntries(); i++) {
+		if (t->GetEntry(i) <= 0) {
+			std::cerr << "could not read entry " << i << "\n";
+			exit(1);
+		}
+	}
+
+	const char *exprs[] = {
+		"evt.I16", "evt.U64", "evt.F32", "evt.ArrF64", "evt.N",
+		"evt.SliF64", "evt.P3.Px", "evt.P3.Py", "evt.VecI16", "evt.Hits.E",
+		"vf64", "hits.ID", "hits.E",
+	};
+	for (auto expr : exprs) {
+		t->SetEstimate(-1);
+		auto n = t->Draw(expr, "", "goff");
+		if (n < 0) {
+			std::cerr << "could not draw [" << expr << "]\n";
+			exit(1);
+		}
+		double sum = 0;
+		auto v = t->GetV1();
+		for (Long64_t i = 0; i < n; i++) {
+			sum += v[i];
+		}
+		fprintf(o, "%s: %.1f\n", expr, sum);
+	}
+	fclose(o);
+}
+`
+			ofile := filepath.Join(tmp, "structs-"+tc.name+".txt")
+			out, err := rtests.RunCxxROOT("check", []byte(code), fname, ofile)
+			if err != nil {
+				t.Fatalf("could not run C++ ROOT: %+v\noutput:\n%s", err, out)
+			}
+
+			got, err := ioutil.ReadFile(ofile)
+			if err != nil {
+				t.Fatalf("could not read C++ ROOT output file %q: %+v\noutput:\n%s", ofile, err, out)
+			}
+
+			var sums [13]float64
+			for i := 0; i < nevts; i++ {
+				evt := newWEvent(i)
+				sums[0] += float64(evt.I16)
+				sums[1] += float64(evt.U64)
+				sums[2] += float64(evt.F32)
+				for _, v := range evt.ArrF64 {
+					sums[3] += v
+				}
+				sums[4] += float64(evt.N)
+				for _, v := range evt.SliF64 {
+					sums[5] += v
+					sums[10] += v
+				}
+				sums[6] += float64(evt.P3.Px)
+				sums[7] += evt.P3.Py
+				for _, v := range evt.VecI16 {
+					sums[8] += float64(v)
+				}
+				for _, hit := range evt.Hits {
+					sums[9] += hit.E
+					sums[11] += float64(hit.ID)
+					sums[12] += hit.E
+				}
+			}
+			want := new(strings.Builder)
+			fmt.Fprintf(want, "entries: %d\n", nevts)
+			for i, expr := range []string{
+				"evt.I16", "evt.U64", "evt.F32", "evt.ArrF64", "evt.N",
+				"evt.SliF64", "evt.P3.Px", "evt.P3.Py", "evt.VecI16", "evt.Hits.E",
+				"vf64", "hits.ID", "hits.E",
+			} {
+				fmt.Fprintf(want, "%s: %.1f\n", expr, sums[i])
+			}
+
+			if got, want := string(got), want.String(); got != want {
+				t.Fatalf("invalid ROOT output:\ngot:\n%v\nwant:\n%v\noutput:\n%s", got, want, out)
+			}
+		})
+	}
+}
+
+type wColls struct {
+	N      int32
+	MapI32 map[int32]int32
+	MapStr map[string]float64
+	MapSS  map[string]string
+	SetI32 map[int32]struct{}
+	VecVec [][]float64
+	VecStr []string
+	Ptr    *wP3
+	PtrI32 *int32
+	VecPtr []*wHit
+}
+
+func newWColls(i int) wColls {
+	var (
+		n    = i % 4
+		i32  = int32(i)
+		evts = wColls{
+			N:      int32(n),
+			MapI32: make(map[int32]int32),
+			MapStr: make(map[string]float64),
+			MapSS:  make(map[string]string),
+			SetI32: make(map[int32]struct{}),
+			VecVec: make([][]float64, n),
+			VecStr: make([]string, n),
+			Ptr:    &wP3{Px: int32(i), Py: float64(-i), Pz: int32(2 * i)},
+			PtrI32: &i32,
+			VecPtr: make([]*wHit, n),
+		}
+	)
+	for j := 0; j < n; j++ {
+		evts.MapI32[int32(j)] = int32(i * j)
+		evts.MapStr[fmt.Sprintf("key-%03d", j)] = float64(i + j)
+		evts.MapSS[fmt.Sprintf("key-%03d", j)] = fmt.Sprintf("val-%03d-%03d", i, j)
+		evts.SetI32[int32(i+j)] = struct{}{}
+		evts.VecVec[j] = make([]float64, j)
+		for k := range evts.VecVec[j] {
+			evts.VecVec[j][k] = float64(i + j + k)
+		}
+		evts.VecStr[j] = fmt.Sprintf("str-%d", j)
+		evts.VecPtr[j] = &wHit{ID: int32(j), E: float64(i), Label: fmt.Sprintf("hit-%d", j)}
+	}
+	return evts
+}
+
+func TestTreeRWColls(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create dir: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const nevts = 50
+
+	for _, tc := range []struct {
+		name  string
+		split int
+	}{
+		{name: "unsplit", split: 0},
+		{name: "split", split: 99},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(tmp, "colls-"+tc.name+".root")
+
+			func() {
+				f, err := riofs.Create(fname)
+				if err != nil {
+					t.Fatalf("could not create file: %+v", err)
+				}
+				defer f.Close()
+
+				var (
+					evt    wColls
+					mss    map[string]string
+					set    map[int32]struct{}
+					vecvec [][]float64
+					ptr    *wP3
+					umap   map[int32]int32
+					uset   map[int32]struct{}
+				)
+				wvars := []WriteVar{
+					{Name: "evt", Value: &evt},
+					{Name: "mss", Value: &mss},
+					{Name: "set", Value: &set},
+					{Name: "vecvec", Value: &vecvec},
+					{Name: "ptr", Value: &ptr},
+					{Name: "umap", Value: &umap, Unordered: true},
+					{Name: "uset", Value: &uset, Unordered: true},
+				}
+				w, err := NewWriter(f, "tree", wvars, WithSplitLevel(tc.split), WithBasketSize(512))
+				if err != nil {
+					t.Fatalf("could not create tree writer: %+v", err)
+				}
+				defer w.Close()
+
+				for i := 0; i < nevts; i++ {
+					evt = newWColls(i)
+					mss = evt.MapSS
+					set = evt.SetI32
+					vecvec = evt.VecVec
+					ptr = evt.Ptr
+					umap = evt.MapI32
+					uset = evt.SetI32
+					_, err = w.Write()
+					if err != nil {
+						t.Fatalf("could not write event %d: %+v", i, err)
+					}
+				}
+
+				err = w.Close()
+				if err != nil {
+					t.Fatalf("could not close tree writer: %+v", err)
+				}
+
+				err = f.Close()
+				if err != nil {
+					t.Fatalf("could not close file: %+v", err)
+				}
+			}()
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			o, err := riofs.Dir(f).Get("tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+			tree := o.(Tree)
+
+			for _, v := range []struct {
+				name  string
+				class string
+			}{
+				{"mss", "map<string,string>"},
+				{"set", "set<int>"},
+				{"umap", "unordered_map<int,int>"},
+				{"uset", "unordered_set<int>"},
+			} {
+				if got, want := tree.Branch(v.name).(*tbranchElement).class, v.class; got != want {
+					t.Fatalf("invalid class for branch %q: got=%q, want=%q", v.name, got, want)
+				}
+			}
+
+			var (
+				evt    wColls
+				mss    map[string]string
+				set    map[int32]struct{}
+				vecvec [][]float64
+				ptr    *wP3
+				umap   map[int32]int32
+				uset   map[int32]struct{}
+			)
+			rvars := []ReadVar{
+				{Name: "evt", Value: &evt},
+				{Name: "mss", Value: &mss},
+				{Name: "set", Value: &set},
+				{Name: "vecvec", Value: &vecvec},
+				{Name: "ptr", Value: &ptr},
+				{Name: "umap", Value: &umap},
+				{Name: "uset", Value: &uset},
+			}
+			r, err := NewReader(tree, rvars)
+			if err != nil {
+				t.Fatalf("could not create tree reader: %+v", err)
+			}
+			defer r.Close()
+
+			n := 0
+			err = r.Read(func(ctx RCtx) error {
+				want := newWColls(int(ctx.Entry))
+				if !reflect.DeepEqual(evt, want) {
+					return fmt.Errorf("invalid event %d:\ngot= %#v\nwant=%#v", ctx.Entry, evt, want)
+				}
+				if !reflect.DeepEqual(mss, want.MapSS) {
+					return fmt.Errorf("invalid mss %d:\ngot= %v\nwant=%v", ctx.Entry, mss, want.MapSS)
+				}
+				if !reflect.DeepEqual(set, want.SetI32) {
+					return fmt.Errorf("invalid set %d:\ngot= %v\nwant=%v", ctx.Entry, set, want.SetI32)
+				}
+				if !reflect.DeepEqual(vecvec, want.VecVec) {
+					return fmt.Errorf("invalid vecvec %d:\ngot= %v\nwant=%v", ctx.Entry, vecvec, want.VecVec)
+				}
+				if !reflect.DeepEqual(ptr, want.Ptr) {
+					return fmt.Errorf("invalid ptr %d:\ngot= %v\nwant=%v", ctx.Entry, ptr, want.Ptr)
+				}
+				if !reflect.DeepEqual(umap, want.MapI32) {
+					return fmt.Errorf("invalid umap %d:\ngot= %v\nwant=%v", ctx.Entry, umap, want.MapI32)
+				}
+				if !reflect.DeepEqual(uset, want.SetI32) {
+					return fmt.Errorf("invalid uset %d:\ngot= %v\nwant=%v", ctx.Entry, uset, want.SetI32)
+				}
+				n++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+			if n != nevts {
+				t.Fatalf("invalid number of events read: got=%d, want=%d", n, nevts)
+			}
+		})
+	}
+}
+
+func TestReadStdMap(t *testing.T) {
+	f, err := riofs.Open("../testdata/stdmap.root")
+	if err != nil {
+		t.Fatalf("could not open file: %+v", err)
+	}
+	defer f.Close()
+
+	o, err := riofs.Dir(f).Get("tree")
+	if err != nil {
+		t.Fatalf("could not retrieve tree: %+v", err)
+	}
+	tree := o.(Tree)
+
+	var evt struct {
+		MapI32 map[int32]int32   `groot:"mi32"`
+		MapSI  map[string]int32  `groot:"msi32"`
+		MapSS  map[string]string `groot:"mss"`
+	}
+	r, err := NewReader(tree, []ReadVar{{Name: "evt", Value: &evt}})
+	if err != nil {
+		t.Fatalf("could not create tree reader: %+v", err)
+	}
+	defer r.Close()
+
+	err = r.Read(func(ctx RCtx) error {
+		i := int(ctx.Entry)
+		if got, want := len(evt.MapI32), i; got != want {
+			return fmt.Errorf("entry %d: invalid mi32 length: got=%d, want=%d", i, got, want)
+		}
+		for j := 0; j < i; j++ {
+			var (
+				key = fmt.Sprintf("key-%03d", j)
+				val = fmt.Sprintf("val-%03d", j)
+			)
+			if got, want := evt.MapI32[int32(j)], int32(j); got != want {
+				return fmt.Errorf("entry %d: invalid mi32[%d]: got=%d, want=%d", i, j, got, want)
+			}
+			if got, want := evt.MapSI[key], int32(j); got != want {
+				return fmt.Errorf("entry %d: invalid msi32[%q]: got=%d, want=%d", i, key, got, want)
+			}
+			if got, want := evt.MapSS[key], val; got != want {
+				return fmt.Errorf("entry %d: invalid mss[%q]: got=%q, want=%q", i, key, got, want)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not read tree: %+v", err)
+	}
+}
+
 var sumBenchReadTreeF64 = 0.0
 
 func BenchmarkReadTreeF64(b *testing.B) {
@@ -1917,3 +2471,154 @@ func BenchmarkReadTreeSliF64(b *testing.B) {
 		})
 	}
 }
+
+func TestReadSplitCollsFromROOT(t *testing.T) {
+	if !rtests.HasROOT {
+		t.Skip("skip test: no C++ ROOT")
+	}
+
+	tmp, err := ioutil.TempDir("", "groot-rtree-")
+	if err != nil {
+		t.Fatalf("could not create dir: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	const code = `#include <string>
+#include <vector>
+#include "TFile.h"
+#include "TString.h"
+#include "TTree.h"
+
+struct Hit {
+	int32_t     ID;
+	double      E;
+	float       Pos[3];
+	std::string Label;
+};
+
+struct Event {
+	int32_t          N;
+	std::vector<Hit> Hits;
+};
+
+void gentree(const char* fname, int splitlvl) {
+	auto f = TFile::Open(fname, "RECREATE");
+	auto t = new TTree("tree", "split collections");
+
+	Event evt;
+	std::vector<Hit> hits;
+	t->Branch("evt", &evt, 32000, splitlvl);
+	t->Branch("hits", &hits, 32000, splitlvl);
+
+	for (int i = 0; i < 10; i++) {
+		evt.N = i % 4;
+		evt.Hits.clear();
+		for (int j = 0; j < evt.N; j++) {
+			Hit hit;
+			hit.ID = j;
+			hit.E = double(i + j);
+			hit.Pos[0] = float(i);
+			hit.Pos[1] = float(j);
+			hit.Pos[2] = float(i + j);
+			hit.Label = std::string(TString::Format("hit-%d-%d", i, j).Data());
+			evt.Hits.push_back(hit);
+		}
+		hits = evt.Hits;
+		t->Fill();
+	}
+
+	f->Write();
+	f->Close();
+}
+`
+
+	type Hit struct {
+		ID    int32
+		E     float64
+		Pos   [3]float32
+		Label string
+	}
+
+	type Event struct {
+		N    int32
+		Hits []Hit
+	}
+
+	newEvent := func(i int) Event {
+		evt := Event{N: int32(i % 4), Hits: []Hit{}}
+		for j := 0; j < int(evt.N); j++ {
+			evt.Hits = append(evt.Hits, Hit{
+				ID:    int32(j),
+				E:     float64(i + j),
+				Pos:   [3]float32{float32(i), float32(j), float32(i + j)},
+				Label: fmt.Sprintf("hit-%d-%d", i, j),
+			})
+		}
+		return evt
+	}
+
+	for _, tc := range []struct {
+		name  string
+		split int
+		nsubs int
+	}{
+		{name: "unsplit", split: 0, nsubs: 0},
+		{name: "split", split: 99, nsubs: 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fname := filepath.Join(tmp, "split-colls-"+tc.name+".root")
+			out, err := rtests.RunCxxROOT("gentree", []byte(code), fname, tc.split)
+			if err != nil {
+				t.Fatalf("could not run C++ ROOT: %+v\noutput:\n%s", err, out)
+			}
+
+			f, err := riofs.Open(fname)
+			if err != nil {
+				t.Fatalf("could not open file: %+v", err)
+			}
+			defer f.Close()
+
+			o, err := riofs.Dir(f).Get("tree")
+			if err != nil {
+				t.Fatalf("could not retrieve tree: %+v", err)
+			}
+			tree := o.(Tree)
+
+			if got, want := len(tree.Branch("hits").Branches()), tc.nsubs; got != want {
+				t.Fatalf("invalid number of sub-branches: got=%d, want=%d", got, want)
+			}
+
+			var (
+				evt  Event
+				hits []Hit
+			)
+			r, err := NewReader(tree, []ReadVar{
+				{Name: "evt", Value: &evt},
+				{Name: "hits", Value: &hits},
+			})
+			if err != nil {
+				t.Fatalf("could not create tree reader: %+v", err)
+			}
+			defer r.Close()
+
+			n := 0
+			err = r.Read(func(ctx RCtx) error {
+				want := newEvent(int(ctx.Entry))
+				if !reflect.DeepEqual(evt, want) {
+					return fmt.Errorf("invalid event %d:\ngot= %#v\nwant=%#v", ctx.Entry, evt, want)
+				}
+				if !reflect.DeepEqual(hits, want.Hits) {
+					return fmt.Errorf("invalid hits %d:\ngot= %#v\nwant=%#v", ctx.Entry, hits, want.Hits)
+				}
+				n++
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("could not read tree: %+v", err)
+			}
+			if got, want := n, 10; got != want {
+				t.Fatalf("invalid number of events read: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package rtree
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"go-hep.org/x/hep/groot/root"
@@ -26,6 +27,9 @@ type baseScanner struct {
 	cbr []Branch    // branches activated because holding slice index
 	ibr []scanField // indices of activated branches
 
+	sel  []int64 // selected entries, if any
+	isel int     // index of the next selected entry
+
 	closed bool
 }
 
@@ -53,13 +57,23 @@ func (s *baseScanner) Entry() int64 {
 }
 
 // SeekEntry points the scanner to the i-th entry, ready to call Next.
+// If an entry list was set, SeekEntry points the scanner to the first
+// selected entry greater or equal to i.
 func (s *baseScanner) SeekEntry(i int64) error {
 	if s.err != nil {
 		return s.err
 	}
+	if s.sel != nil {
+		s.isel = sort.Search(len(s.sel), func(j int) bool { return s.sel[j] >= i })
+		return nil
+	}
+	return s.seek(i)
+}
+
+func (s *baseScanner) seek(i int64) error {
 	if s.chain {
 		ch := s.tree.(*chain)
-		if i >= ch.off+ch.tree.Entries() || i < ch.off {
+		if ch.tree == nil || i >= ch.off+ch.tree.Entries() || i < ch.off {
 			itree := s.findTree(i)
 			if itree == -1 {
 				s.err = fmt.Errorf("rtree: could not find Tree containing entry %d", i)
@@ -77,7 +91,7 @@ func (s *baseScanner) SeekEntry(i int64) error {
 func (s *baseScanner) findTree(i int64) int {
 	ch := s.tree.(*chain)
 	for j := range ch.trees {
-		if i <= ch.tots[j] {
+		if i < ch.tots[j] {
 			return j
 		}
 	}
@@ -91,6 +105,9 @@ func (s *baseScanner) Next() bool {
 	if s.closed {
 		return false
 	}
+	if s.sel != nil {
+		return s.nextSelected()
+	}
 	next := s.i < s.n
 	s.cur++
 	s.i++
@@ -105,6 +122,35 @@ func (s *baseScanner) Next() bool {
 	return next
 }
 
+// nextSelected points the scanner to the next selected entry.
+func (s *baseScanner) nextSelected() bool {
+	if s.err != nil || s.isel >= len(s.sel) {
+		return false
+	}
+	i := s.sel[s.isel]
+	s.isel++
+	if err := s.seek(i); err != nil {
+		return false
+	}
+	s.cur = i
+	s.i = i + 1
+	return true
+}
+
+// setEntryList restricts the scanner to the entries of the provided list.
+func (s *baseScanner) setEntryList(list EntrySelection) error {
+	sel, err := selectEntries(s.tree, list, 0, s.tree.Entries())
+	if err != nil {
+		return err
+	}
+	if sel == nil {
+		sel = []int64{}
+	}
+	s.sel = sel
+	s.isel = 0
+	return nil
+}
+
 func (s *baseScanner) loadTree(i int) {
 	ch := s.tree.(*chain)
 	ch.loadTree(i)
@@ -378,6 +424,12 @@ func (s *TreeScanner) SeekEntry(i int64) error {
 	return s.scan.SeekEntry(i)
 }
 
+// SetEntryList restricts the scanner to the entries of the provided list.
+// SetEntryList rewinds the scanner to the first selected entry.
+func (s *TreeScanner) SetEntryList(list EntrySelection) error {
+	return s.scan.setEntryList(list)
+}
+
 // Next prepares the next result row for reading with the Scan method.
 // It returns true on success, false if there is no next result row.
 // Every call to Scan, even the first one, must be preceded by a call to Next.
@@ -713,6 +765,12 @@ func (s *Scanner) SeekEntry(i int64) error {
 	return s.scan.SeekEntry(i)
 }
 
+// SetEntryList restricts the scanner to the entries of the provided list.
+// SetEntryList rewinds the scanner to the first selected entry.
+func (s *Scanner) SetEntryList(list EntrySelection) error {
+	return s.scan.setEntryList(list)
+}
+
 // Next prepares the next result row for reading with the Scan method.
 // It returns true on success, false if there is no next result row.
 // Every call to Scan, even the first one, must be preceded by a call to Next.
@@ -757,7 +815,7 @@ func newValue(leaf Leaf) interface{} {
 	unsigned := leaf.IsUnsigned()
 
 	switch etype.Kind() {
-	case reflect.Interface, reflect.Map, reflect.Chan:
+	case reflect.Interface, reflect.Chan:
 		panic(fmt.Errorf("rtree: type %T not supported", reflect.New(etype).Elem().Interface()))
 	case reflect.Int8:
 		if unsigned {
@@ -554,17 +554,22 @@ func (tree *ttree) attachStreamerElement(br Branch, se rbytes.StreamerElement, c
 		members = info.Elements()
 	case *rdict.StreamerSTL:
 		typename := strings.TrimSpace(se.TypeName())
-		// FIXME(sbinet): this string manipulation only works for one-parameter templates
 		if strings.Contains(typename, "<") {
 			typename = typename[strings.Index(typename, "<")+1 : strings.LastIndex(typename, ">")]
 			typename = strings.TrimRight(typename, "*")
 		}
 		typename = strings.TrimSpace(typename)
+		switch stlTypeOf(se.TypeName()) {
+		case rmeta.STLmap, rmeta.STLunorderedmap:
+			// elements of std::map<K,V> are std::pair<K,V>.
+			typename = "pair<" + typename + ">"
+		}
 		typevers := -1
 		// FIXME(sbinet): always load latest version?
 		info, err := ctx.StreamerInfo(typename, typevers)
 		if err != nil {
-			if _, ok := rmeta.CxxBuiltins[typename]; !ok {
+			_, builtin := rmeta.CxxBuiltins[typename]
+			if !builtin && stlTypeOf(typename) == rmeta.NotSTL {
 				panic(err)
 			}
 		}
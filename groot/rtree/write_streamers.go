@@ -0,0 +1,820 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rtree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go-hep.org/x/hep/groot/rbase"
+	"go-hep.org/x/hep/groot/rbytes"
+	"go-hep.org/x/hep/groot/rdict"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rmeta"
+	"go-hep.org/x/hep/groot/rvers"
+)
+
+const (
+	structVersion = 1 // class version of user structs
+	stlVersion    = 6 // class version of STL containers
+)
+
+// wstreamerFunc writes the value rv to the provided buffer.
+type wstreamerFunc func(w *rbytes.WBuffer, rv reflect.Value) error
+
+// wstreamerImpl writes the value of a write-variable with a list of
+// streaming functions.
+type wstreamerImpl struct {
+	rv    reflect.Value
+	funcs []wstreamerFunc
+}
+
+func (ws *wstreamerImpl) WStreamROOT(w *rbytes.WBuffer) error {
+	for _, wfunc := range ws.funcs {
+		err := wfunc(w, ws.rv)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamerInfoOf returns the StreamerInfo describing the provided Go type.
+// The StreamerInfo, and the ones of the types it depends on, are registered
+// with the provided file.
+//
+// Named structs are described as classes with the name of the Go type.
+// Slices are described as std::vector<T> instantiations, maps as
+// std::map<K,V> instantiations and maps of empty structs as std::set<K>
+// instantiations.
+// Pointers are described as the type they point to.
+func streamerInfoOf(f *riofs.File, rt reflect.Type) (rbytes.StreamerInfo, error) {
+	rt = indirectType(rt)
+	name, err := cxxNameOf(rt, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if si := streamerInfoFrom(f, name); si != nil {
+		return si, nil
+	}
+
+	var si rbytes.StreamerInfo
+	switch rt.Kind() {
+	case reflect.Struct:
+		elems, err := streamerElementsOf(f, rt)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not create streamer elements for %q: %w", name, err)
+		}
+		si = rdict.NewStreamerInfo(name, structVersion, elems)
+
+	case reflect.Slice, reflect.Map:
+		se, err := streamerElementOf(f, name, "This", "", rt, false)
+		if err != nil {
+			return nil, fmt.Errorf("rtree: could not create streamer element for %q: %w", name, err)
+		}
+		si = rdict.NewStreamerInfo(name, stlVersion, []rbytes.StreamerElement{se})
+
+	default:
+		return nil, fmt.Errorf("rtree: no streamer for type %v", rt)
+	}
+
+	f.RegisterStreamer(si)
+	return si, nil
+}
+
+// streamerInfoFrom returns the StreamerInfo with the provided name
+// registered with the file, or nil.
+func streamerInfoFrom(f *riofs.File, name string) rbytes.StreamerInfo {
+	for _, si := range f.StreamerInfos() {
+		if si.Name() == name {
+			return si
+		}
+	}
+	return nil
+}
+
+// streamerElementsOf returns the streamer elements describing the exported
+// fields of the provided struct type.
+func streamerElementsOf(f *riofs.File, rt reflect.Type) ([]rbytes.StreamerElement, error) {
+	var (
+		class  = rt.Name()
+		names  = make([]string, rt.NumField())
+		counts = make([]string, rt.NumField())
+		isCnt  = make(map[string]bool)
+	)
+
+	for i := range names {
+		ft := rt.Field(i)
+		names[i] = nameOf(ft)
+		if idx := strings.Index(names[i], "["); idx > 0 {
+			counts[i] = strings.Trim(names[i][idx:], "[]")
+			names[i] = names[i][:idx]
+		}
+		if ft.Type.Kind() == reflect.Slice && counts[i] != "" {
+			isCnt[counts[i]] = true
+		}
+	}
+
+	elems := make([]rbytes.StreamerElement, 0, len(names))
+	for i, name := range names {
+		ft := rt.Field(i)
+		if ft.Name != strings.Title(ft.Name) {
+			// not exported. ignore.
+			continue
+		}
+		if ft.Type.Kind() != reflect.Slice {
+			counts[i] = ""
+		}
+		se, err := streamerElementOf(f, class, name, counts[i], ft.Type, isCnt[name])
+		if err != nil {
+			return nil, fmt.Errorf("could not create streamer element for field %q: %w", ft.Name, err)
+		}
+		elems = append(elems, se)
+	}
+
+	return elems, nil
+}
+
+// streamerElementOf returns the streamer element describing a data member
+// of a class, with the provided name and type.
+// count is the name of the data member holding the length of a slice.
+func streamerElementOf(f *riofs.File, class, name, count string, rt reflect.Type, isCounter bool) (rbytes.StreamerElement, error) {
+	if isCounter && rt.Kind() == reflect.Ptr {
+		return nil, fmt.Errorf("rtree: invalid pointer type %v for count %q", rt, name)
+	}
+	rt = indirectType(rt)
+
+	switch kind := rt.Kind(); kind {
+	case reflect.String:
+		return &rdict.StreamerString{StreamerElement: rdict.Element{
+			Name:  *rbase.NewNamed(name, ""),
+			Type:  rmeta.TString,
+			Size:  24,
+			EName: "TString",
+		}.New()}, nil
+
+	case reflect.Array:
+		et := rt.Elem()
+		etype, ok := basicEnumOf(et)
+		if !ok {
+			return nil, fmt.Errorf("rtree: invalid array element type %v", et)
+		}
+		return &rdict.StreamerBasicType{StreamerElement: rdict.Element{
+			Name:   *rbase.NewNamed(name, ""),
+			Type:   rmeta.OffsetL + etype,
+			Size:   int32(rt.Size()),
+			ArrLen: int32(rt.Len()),
+			ArrDim: 1,
+			MaxIdx: [5]int32{int32(rt.Len())},
+			EName:  rmeta.GoType2Cxx[et.Kind().String()],
+		}.New()}, nil
+
+	case reflect.Slice:
+		if count == "" {
+			return stlElementOf(f, name, rt, false)
+		}
+		et := rt.Elem()
+		etype, ok := basicEnumOf(et)
+		if !ok {
+			return nil, fmt.Errorf("rtree: invalid element type %v for slice with count %q", et, count)
+		}
+		se := rdict.Element{
+			Name:  *rbase.NewNamed(name, "["+count+"]"),
+			Type:  rmeta.OffsetP + etype,
+			Size:  int32(et.Size()),
+			EName: rmeta.GoType2Cxx[et.Kind().String()] + "*",
+		}.New()
+		return rdict.NewStreamerBasicPointer(se, structVersion, count, class), nil
+
+	case reflect.Map:
+		return stlElementOf(f, name, rt, false)
+
+	case reflect.Struct:
+		si, err := streamerInfoOf(f, rt)
+		if err != nil {
+			return nil, err
+		}
+		return &rdict.StreamerObjectAny{StreamerElement: rdict.Element{
+			Name:  *rbase.NewNamed(name, ""),
+			Type:  rmeta.Any,
+			Size:  int32(rt.Size()),
+			EName: si.Name(),
+		}.New()}, nil
+	}
+
+	etype, ok := basicEnumOf(rt)
+	if !ok {
+		return nil, fmt.Errorf("rtree: invalid type %v", rt)
+	}
+	if isCounter {
+		switch rt.Kind() {
+		case reflect.Int32, reflect.Int64:
+			etype = rmeta.Counter
+		default:
+			return nil, fmt.Errorf("rtree: invalid type %v for count %q", rt, name)
+		}
+	}
+	return &rdict.StreamerBasicType{StreamerElement: rdict.Element{
+		Name:  *rbase.NewNamed(name, ""),
+		Type:  etype,
+		Size:  int32(rt.Size()),
+		EName: rmeta.GoType2Cxx[rt.Kind().String()],
+	}.New()}, nil
+}
+
+// stlElementOf returns the streamer element describing a STL container
+// data member, with the provided name and Go type.
+// unordered selects std::unordered_map<K,V> and std::unordered_set<K>
+// for maps.
+func stlElementOf(f *riofs.File, name string, rt reflect.Type, unordered bool) (rbytes.StreamerElement, error) {
+	ename, err := cxxNameOf(rt, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		vtype rmeta.ESTLType
+		ctype rmeta.Enum
+		size  int32
+	)
+	switch {
+	case rt.Kind() == reflect.Slice:
+		vtype = rmeta.STLvector
+		size = 24
+		ctype, err = stlElementEnumOf(f, rt.Elem())
+	case isSet(rt):
+		vtype = rmeta.STLset
+		size = 48
+		ctype, err = stlElementEnumOf(f, rt.Key())
+	default:
+		vtype = rmeta.STLmap
+		size = 48
+		ctype = rmeta.Object
+		_, err = pairInfoOf(f, rt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if unordered {
+		// unordered containers are streamed as their ordered counterparts.
+		switch vtype {
+		case rmeta.STLset:
+			vtype = rmeta.STLunorderedset
+		case rmeta.STLmap:
+			vtype = rmeta.STLunorderedmap
+		default:
+			return nil, fmt.Errorf("rtree: invalid type %v for unordered STL container", rt)
+		}
+		ename = "unordered_" + ename
+		size = 56
+	}
+
+	se := rdict.Element{
+		Name:  *rbase.NewNamed(name, ""),
+		Type:  rmeta.Streamer,
+		Size:  size,
+		EName: ename,
+	}.New()
+	return rdict.NewCxxStreamerSTL(se, vtype, ctype), nil
+}
+
+// unorderedInfoOf returns the StreamerInfo describing the provided map type
+// as a std::unordered_map<K,V>, or as a std::unordered_set<K> for maps of
+// empty structs.
+// The StreamerInfo is registered with the provided file.
+func unorderedInfoOf(f *riofs.File, rt reflect.Type) (rbytes.StreamerInfo, error) {
+	se, err := stlElementOf(f, "This", indirectType(rt), true)
+	if err != nil {
+		return nil, err
+	}
+
+	name := se.TypeName()
+	if si := streamerInfoFrom(f, name); si != nil {
+		return si, nil
+	}
+
+	si := rdict.NewStreamerInfo(name, stlVersion, []rbytes.StreamerElement{se})
+	f.RegisterStreamer(si)
+	return si, nil
+}
+
+// stlElementEnumOf returns the ROOT type code of the elements of a STL
+// container, registering the StreamerInfo of these elements with the file
+// as needed.
+func stlElementEnumOf(f *riofs.File, et reflect.Type) (rmeta.Enum, error) {
+	et = indirectType(et)
+	if etype, ok := basicEnumOf(et); ok {
+		return etype, nil
+	}
+
+	switch et.Kind() {
+	case reflect.String:
+		return rmeta.Object, nil
+	case reflect.Struct:
+		_, err := streamerInfoOf(f, et)
+		if err != nil {
+			return 0, err
+		}
+		return rmeta.Object, nil
+	case reflect.Slice, reflect.Map:
+		if et.Kind() == reflect.Map && !isSet(et) {
+			return 0, fmt.Errorf("rtree: invalid STL element type %v (nested std::map not supported)", et)
+		}
+		_, err := cxxNameOf(et, true)
+		if err != nil {
+			return 0, err
+		}
+		return rmeta.Object, nil
+	}
+	return 0, fmt.Errorf("rtree: invalid STL element type %v", et)
+}
+
+// pairInfoOf returns the StreamerInfo of the std::pair<K,V> elements of
+// the std::map<K,V> corresponding to the provided map type.
+// Only builtin and string keys and values are supported.
+func pairInfoOf(f *riofs.File, rt reflect.Type) (rbytes.StreamerInfo, error) {
+	var (
+		names = make([]string, 2)
+		elems = make([]rbytes.StreamerElement, 2)
+	)
+	for i, v := range []struct {
+		name string
+		typ  reflect.Type
+	}{
+		{"first", rt.Key()},
+		{"second", rt.Elem()},
+	} {
+		switch etype, ok := basicEnumOf(v.typ); {
+		case ok:
+			names[i] = rmeta.GoType2Cxx[v.typ.Kind().String()]
+			elems[i] = &rdict.StreamerBasicType{StreamerElement: rdict.Element{
+				Name:  *rbase.NewNamed(v.name, ""),
+				Type:  etype,
+				Size:  int32(v.typ.Size()),
+				EName: names[i],
+			}.New()}
+		case v.typ.Kind() == reflect.String:
+			names[i] = "string"
+			se := rdict.Element{
+				Name:  *rbase.NewNamed(v.name, ""),
+				Type:  rmeta.Streamer,
+				Size:  32,
+				EName: names[i],
+			}.New()
+			elems[i] = &rdict.StreamerSTLstring{
+				StreamerSTL: *rdict.NewCxxStreamerSTL(se, rmeta.ESTLType(rmeta.STLstring), rmeta.STLstring),
+			}
+		default:
+			return nil, fmt.Errorf("rtree: invalid std::map %s type %v", v.name, v.typ)
+		}
+	}
+
+	name := "pair<" + names[0] + "," + names[1] + ">"
+	if si := streamerInfoFrom(f, name); si != nil {
+		return si, nil
+	}
+
+	// the checksum of the elements is streamed with the map contents.
+	chksum := uint32(rdict.NewStreamerInfo(name, structVersion, elems).CheckSum())
+	si := rdict.NewCxxStreamerInfo(name, structVersion, chksum, elems)
+	f.RegisterStreamer(si)
+	return si, nil
+}
+
+// basicEnumOf returns the ROOT type code of the provided builtin Go type.
+func basicEnumOf(rt reflect.Type) (rmeta.Enum, bool) {
+	etype, ok := rmeta.GoType2ROOTEnum[rt]
+	switch etype {
+	case rmeta.TString, rmeta.Float16, rmeta.Double32:
+		// Float16 and Double32 fields are not supported yet.
+		return etype, false
+	}
+	return etype, ok
+}
+
+// isSet returns whether the provided type is a map of empty structs,
+// streamed as a std::set.
+func isSet(rt reflect.Type) bool {
+	return rt.Kind() == reflect.Map &&
+		rt.Elem().Kind() == reflect.Struct &&
+		rt.Elem().NumField() == 0
+}
+
+// cxxNameOf returns the C++ name of the provided Go type.
+// inSTL indicates whether the type is an element of a STL container, where
+// strings are streamed as std::string.
+func cxxNameOf(rt reflect.Type, inSTL bool) (string, error) {
+	rt = indirectType(rt)
+	switch rt.Kind() {
+	case reflect.String:
+		if inSTL {
+			return "string", nil
+		}
+		return "TString", nil
+
+	case reflect.Struct:
+		name := rt.Name()
+		if name == "" {
+			return "", fmt.Errorf("rtree: anonymous struct %v can not be streamed", rt)
+		}
+		return name, nil
+
+	case reflect.Slice:
+		ename, err := cxxNameOf(rt.Elem(), true)
+		if err != nil {
+			return "", err
+		}
+		return cxxTemplate("vector", ename), nil
+
+	case reflect.Map:
+		kname, err := cxxNameOf(rt.Key(), true)
+		if err != nil {
+			return "", err
+		}
+		if isSet(rt) {
+			return cxxTemplate("set", kname), nil
+		}
+		vname, err := cxxNameOf(rt.Elem(), true)
+		if err != nil {
+			return "", err
+		}
+		return cxxTemplate("map", kname+","+vname), nil
+	}
+
+	if _, ok := basicEnumOf(rt); !ok {
+		return "", fmt.Errorf("rtree: invalid type %v", rt)
+	}
+	return rmeta.GoType2Cxx[rt.Kind().String()], nil
+}
+
+func cxxTemplate(name, args string) string {
+	if strings.HasSuffix(args, ">") {
+		args += " "
+	}
+	return name + "<" + args + ">"
+}
+
+// indirectType returns the type pointers of type rt point to.
+func indirectType(rt reflect.Type) reflect.Type {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	return rt
+}
+
+// indirect returns the value pointers point to.
+// nil pointers are indirected to zero values.
+func indirect(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv = reflect.Zero(rv.Type().Elem())
+			continue
+		}
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// wstreamerOf returns the function writing the data members described by
+// the provided StreamerInfo, from values of type rt.
+// Slices and maps are written as STL containers.
+func wstreamerOf(f *riofs.File, si rbytes.StreamerInfo, rt reflect.Type) (wstreamerFunc, error) {
+	elems := si.Elements()
+	funcs := make([]wstreamerFunc, len(elems))
+	for i, se := range elems {
+		fct, err := wstreamerFrom(f, se, rt)
+		if err != nil {
+			return nil, err
+		}
+		funcs[i] = fct
+	}
+
+	return func(w *rbytes.WBuffer, rv reflect.Value) error {
+		for _, fct := range funcs {
+			err := fct(w, rv)
+			if err != nil {
+				return err
+			}
+		}
+		return w.Err()
+	}, nil
+}
+
+// wstreamerFrom returns the function writing the data member described by
+// the provided streamer element, from values of type rt.
+func wstreamerFrom(f *riofs.File, se rbytes.StreamerElement, rt reflect.Type) (wstreamerFunc, error) {
+	rt = indirectType(rt)
+	var (
+		ft  = rt
+		get = indirect
+	)
+	if rt.Kind() == reflect.Struct {
+		i := fieldOf(rt, se.Name())
+		if i < 0 {
+			return nil, fmt.Errorf("rtree: no such field %q in type %v", se.Name(), rt)
+		}
+		ft = indirectType(rt.Field(i).Type)
+		get = func(rv reflect.Value) reflect.Value { return indirect(indirect(rv).Field(i)) }
+	}
+
+	switch se := se.(type) {
+	case *rdict.StreamerBasicType:
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			rf := get(rv)
+			switch rf.Kind() {
+			case reflect.Array:
+				for i := 0; i < rf.Len(); i++ {
+					writeBasic(w, rf.Index(i))
+				}
+			default:
+				writeBasic(w, rf)
+			}
+			return w.Err()
+		}, nil
+
+	case *rdict.StreamerString:
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			w.WriteString(get(rv).String())
+			return w.Err()
+		}, nil
+
+	case *rdict.StreamerBasicPointer:
+		i := fieldOf(rt, se.CountName())
+		if i < 0 {
+			return nil, fmt.Errorf("rtree: no such count field %q in type %v", se.CountName(), rt)
+		}
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			var (
+				rf = get(rv)
+				n  = int(indirect(rv).Field(i).Int())
+			)
+			if n > rf.Len() {
+				return fmt.Errorf("rtree: invalid count %q=%d for slice %q of length %d", se.CountName(), n, se.Name(), rf.Len())
+			}
+			w.WriteI8(1)
+			for j := 0; j < n; j++ {
+				writeBasic(w, rf.Index(j))
+			}
+			return w.Err()
+		}, nil
+
+	case *rdict.StreamerSTL:
+		wfunc, err := wstlOf(f, ft, true)
+		if err != nil {
+			return nil, err
+		}
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			return wfunc(w, get(rv))
+		}, nil
+
+	case *rdict.StreamerObjectAny:
+		esi, err := streamerInfoOf(f, ft)
+		if err != nil {
+			return nil, err
+		}
+		wfunc, err := wobjectOf(f, esi, ft)
+		if err != nil {
+			return nil, err
+		}
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			return wfunc(w, get(rv))
+		}, nil
+	}
+
+	return nil, fmt.Errorf("rtree: unknown streamer element: %#v", se)
+}
+
+// wobjectOf returns the function writing values of type rt as objects,
+// with a version header.
+func wobjectOf(f *riofs.File, si rbytes.StreamerInfo, rt reflect.Type) (wstreamerFunc, error) {
+	var (
+		vers  = int16(si.ClassVersion())
+		class = si.Name()
+	)
+	members, err := wstreamerOf(f, si, rt)
+	if err != nil {
+		return nil, err
+	}
+	return func(w *rbytes.WBuffer, rv reflect.Value) error {
+		pos := w.WriteVersion(vers)
+		err := members(w, rv)
+		if err != nil {
+			return err
+		}
+		_, err = w.SetByteCount(pos, class)
+		return err
+	}, nil
+}
+
+// wstlOf returns the function writing values of type rt as STL containers.
+// Containers nested into other containers are written without a header.
+func wstlOf(f *riofs.File, rt reflect.Type, header bool) (wstreamerFunc, error) {
+	rt = indirectType(rt)
+	class, err := cxxNameOf(rt, true)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case rt.Kind() == reflect.Slice:
+		efunc, err := welemOf(f, rt.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			rv = indirect(rv)
+			var pos int64
+			if header {
+				pos = w.WriteVersion(rvers.StreamerInfo)
+			}
+			w.WriteI32(int32(rv.Len()))
+			for i := 0; i < rv.Len(); i++ {
+				err := efunc(w, rv.Index(i))
+				if err != nil {
+					return err
+				}
+			}
+			if header {
+				_, err := w.SetByteCount(pos, class)
+				return err
+			}
+			return w.Err()
+		}, nil
+
+	case isSet(rt):
+		efunc, err := welemOf(f, rt.Key())
+		if err != nil {
+			return nil, err
+		}
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			rv = indirect(rv)
+			var pos int64
+			if header {
+				pos = w.WriteVersion(rvers.StreamerInfo)
+			}
+			keys := sortedKeys(rv)
+			w.WriteI32(int32(len(keys)))
+			for _, key := range keys {
+				err := efunc(w, key)
+				if err != nil {
+					return err
+				}
+			}
+			if header {
+				_, err := w.SetByteCount(pos, class)
+				return err
+			}
+			return w.Err()
+		}, nil
+
+	case rt.Kind() == reflect.Map:
+		if !header {
+			return nil, fmt.Errorf("rtree: invalid nested std::map %v", rt)
+		}
+		psi, err := pairInfoOf(f, rt)
+		if err != nil {
+			return nil, err
+		}
+		chksum := uint32(psi.CheckSum())
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			rv = indirect(rv)
+			// std::map<K,V> is written member-wise: all the keys, then
+			// all the values.
+			pos := w.WriteVersion(rvers.StreamerInfo | rbytes.StreamedMemberWise)
+			w.WriteI16(0) // version of std::pair<K,V>, followed by its checksum.
+			w.WriteU32(chksum)
+			keys := sortedKeys(rv)
+			w.WriteI32(int32(len(keys)))
+			writeMembers(w, keys)
+			vals := make([]reflect.Value, len(keys))
+			for i, key := range keys {
+				vals[i] = rv.MapIndex(key)
+			}
+			writeMembers(w, vals)
+			_, err := w.SetByteCount(pos, class)
+			return err
+		}, nil
+	}
+
+	return nil, fmt.Errorf("rtree: invalid STL container type %v", rt)
+}
+
+// welemOf returns the function writing elements of STL containers with
+// the provided type.
+func welemOf(f *riofs.File, et reflect.Type) (wstreamerFunc, error) {
+	et = indirectType(et)
+	if _, ok := basicEnumOf(et); ok {
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			writeBasic(w, indirect(rv))
+			return w.Err()
+		}, nil
+	}
+
+	switch et.Kind() {
+	case reflect.String:
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			w.WriteString(indirect(rv).String())
+			return w.Err()
+		}, nil
+
+	case reflect.Struct:
+		esi, err := streamerInfoOf(f, et)
+		if err != nil {
+			return nil, err
+		}
+		wfunc, err := wobjectOf(f, esi, et)
+		if err != nil {
+			return nil, err
+		}
+		return func(w *rbytes.WBuffer, rv reflect.Value) error {
+			return wfunc(w, indirect(rv))
+		}, nil
+
+	case reflect.Slice, reflect.Map:
+		return wstlOf(f, et, false)
+	}
+
+	return nil, fmt.Errorf("rtree: invalid STL element type %v", et)
+}
+
+// writeMembers writes a block of members of std::pair<K,V> values.
+// Strings are written as a std::string collection, with a header.
+func writeMembers(w *rbytes.WBuffer, vs []reflect.Value) {
+	if len(vs) == 0 {
+		return
+	}
+	switch vs[0].Kind() {
+	case reflect.String:
+		pos := w.WriteVersion(rvers.StreamerInfo)
+		for _, v := range vs {
+			w.WriteString(v.String())
+		}
+		_, _ = w.SetByteCount(pos, "string")
+	default:
+		for _, v := range vs {
+			writeBasic(w, v)
+		}
+	}
+}
+
+// sortedKeys returns the keys of the provided map, sorted in increasing
+// order as in a std::map or a std::set.
+func sortedKeys(rv reflect.Value) []reflect.Value {
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		ki, kj := keys[i], keys[j]
+		switch ki.Kind() {
+		case reflect.Bool:
+			return !ki.Bool() && kj.Bool()
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return ki.Int() < kj.Int()
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return ki.Uint() < kj.Uint()
+		case reflect.Float32, reflect.Float64:
+			return ki.Float() < kj.Float()
+		case reflect.String:
+			return ki.String() < kj.String()
+		}
+		panic(fmt.Errorf("rtree: invalid map key type %v", ki.Type()))
+	})
+	return keys
+}
+
+func writeBasic(w *rbytes.WBuffer, rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		w.WriteBool(rv.Bool())
+	case reflect.Int8:
+		w.WriteI8(int8(rv.Int()))
+	case reflect.Int16:
+		w.WriteI16(int16(rv.Int()))
+	case reflect.Int32:
+		w.WriteI32(int32(rv.Int()))
+	case reflect.Int64:
+		w.WriteI64(rv.Int())
+	case reflect.Uint8:
+		w.WriteU8(uint8(rv.Uint()))
+	case reflect.Uint16:
+		w.WriteU16(uint16(rv.Uint()))
+	case reflect.Uint32:
+		w.WriteU32(uint32(rv.Uint()))
+	case reflect.Uint64:
+		w.WriteU64(rv.Uint())
+	case reflect.Float32:
+		w.WriteF32(float32(rv.Float()))
+	case reflect.Float64:
+		w.WriteF64(rv.Float())
+	default:
+		panic(fmt.Errorf("rtree: invalid basic type %v", rv.Type()))
+	}
+}
+
+var (
+	_ rbytes.WStreamer = (*wstreamerImpl)(nil)
+)
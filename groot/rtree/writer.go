@@ -84,6 +84,19 @@ func WithBasketSize(size int) WriteOption {
 	}
 }
 
+// WithSplitLevel sets the maximum branch depth split level.
+// A split level of 0 writes structs in a single branch.
+// A split level greater than 0 creates one sub-branch per struct field.
+func WithSplitLevel(lvl int) WriteOption {
+	return func(opt *wopt) error {
+		if lvl < 0 {
+			return fmt.Errorf("rtree: invalid split level %d", lvl)
+		}
+		opt.splitlvl = int32(lvl)
+		return nil
+	}
+}
+
 // WithTitle sets the title of the tree writer.
 func WithTitle(title string) WriteOption {
 	return func(opt *wopt) error {
@@ -159,6 +172,11 @@ func (w *wtree) SetTitle(title string) { w.ttree.named.SetTitle(title) }
 func (w *wtree) ROOTMerge(src root.Object) error {
 	switch src := src.(type) {
 	case Tree:
+		err := checkBranches(w.ttree.branches, src.Branches())
+		if err != nil {
+			return fmt.Errorf("rtree: can not merge tree %q: %w", src.Name(), err)
+		}
+
 		r, err := NewReader(src, nil)
 		if err != nil {
 			return fmt.Errorf("rtree: could not create tree reader: %w", err)
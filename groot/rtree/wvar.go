@@ -12,10 +12,24 @@ import (
 )
 
 // WriteVar describes a variable to be written out to a tree.
+//
+// Slices without a count, maps and structs are written as C++ objects:
+//   - []T is written as std::vector<T>,
+//   - map[K]V is written as std::map<K,V>,
+//   - map[K]struct{} is written as std::set<K>,
+//   - string elements of these containers are written as std::string,
+//   - *T is written as T (nil pointers are written as zero values).
+//
+// Keys and values of std::map<K,V> must be builtin types or strings.
+// Maps written with Unordered set are written as std::unordered_map<K,V>
+// and std::unordered_set<K>.
+// std::unordered_map<K,V> and std::unordered_set<K> are read back as
+// map[K]V and map[K]struct{}.
 type WriteVar struct {
-	Name  string      // name of the variable
-	Value interface{} // pointer to the value to write
-	Count string      // name of the branch holding the count-leaf value for slices
+	Name      string      // name of the variable
+	Value     interface{} // pointer to the value to write
+	Count     string      // name of the branch holding the count-leaf value for slices
+	Unordered bool        // whether a map is written as an unordered STL container
 }
 
 // WriteVarsFromStruct creates a slice of WriteVars from the ptr value.
@@ -98,8 +112,6 @@ func WriteVarsFromStruct(ptr interface{}) []WriteVar {
 		switch ft.Type.Kind() {
 		case reflect.Int, reflect.Uint, reflect.UnsafePointer, reflect.Uintptr, reflect.Chan, reflect.Interface:
 			panic(fmt.Errorf("rtree: invalid field type for %q: %T", ft.Name, fv.Interface()))
-		case reflect.Map:
-			panic(fmt.Errorf("rtree: invalid field type for %q: %T (not yet supported)", ft.Name, fv.Interface()))
 		}
 
 		wvars = append(wvars, wvar)
@@ -11,94 +11,126 @@ const ROOT = 62004
 
 // ROOT classes versions
 const (
-	AttAxis                  = 4  // ROOT version for TAttAxis
-	AttFill                  = 2  // ROOT version for TAttFill
-	AttLine                  = 2  // ROOT version for TAttLine
-	AttMarker                = 2  // ROOT version for TAttMarker
-	Named                    = 1  // ROOT version for TNamed
-	Object                   = 1  // ROOT version for TObject
-	ObjString                = 1  // ROOT version for TObjString
-	ProcessID                = 1  // ROOT version for TProcessID
-	ProcessUUID              = 1  // ROOT version for TProcessUUID
-	Ref                      = 1  // ROOT version for TRef
-	UUID                     = 1  // ROOT version for TUUID
-	Array                    = 1  // ROOT version for TArray
-	ArrayC                   = 1  // ROOT version for TArrayC
-	ArrayS                   = 1  // ROOT version for TArrayS
-	ArrayI                   = 1  // ROOT version for TArrayI
-	ArrayL                   = 1  // ROOT version for TArrayL
-	ArrayL64                 = 1  // ROOT version for TArrayL64
-	ArrayF                   = 1  // ROOT version for TArrayF
-	ArrayD                   = 1  // ROOT version for TArrayD
-	Bits                     = 1  // ROOT version for TBits
-	Collection               = 3  // ROOT version for TCollection
-	ClonesArray              = 4  // ROOT version for TClonesArray
-	List                     = 5  // ROOT version for TList
-	HashList                 = 0  // ROOT version for THashList
-	Map                      = 3  // ROOT version for TMap
-	ObjArray                 = 3  // ROOT version for TObjArray
-	RefArray                 = 1  // ROOT version for TRefArray
-	SeqCollection            = 0  // ROOT version for TSeqCollection
-	StreamerInfo             = 9  // ROOT version for TStreamerInfo
-	StreamerElement          = 4  // ROOT version for TStreamerElement
-	StreamerBase             = 3  // ROOT version for TStreamerBase
-	StreamerBasicType        = 2  // ROOT version for TStreamerBasicType
-	StreamerBasicPointer     = 2  // ROOT version for TStreamerBasicPointer
-	StreamerLoop             = 2  // ROOT version for TStreamerLoop
-	StreamerObject           = 2  // ROOT version for TStreamerObject
-	StreamerObjectPointer    = 2  // ROOT version for TStreamerObjectPointer
-	StreamerObjectAny        = 2  // ROOT version for TStreamerObjectAny
-	StreamerObjectAnyPointer = 1  // ROOT version for TStreamerObjectAnyPointer
-	StreamerString           = 2  // ROOT version for TStreamerString
-	StreamerSTL              = 3  // ROOT version for TStreamerSTL
-	StreamerSTLstring        = 2  // ROOT version for TStreamerSTLstring
-	StreamerArtificial       = 0  // ROOT version for TStreamerArtificial
-	Axis                     = 10 // ROOT version for TAxis
-	Graph                    = 4  // ROOT version for TGraph
-	GraphErrors              = 3  // ROOT version for TGraphErrors
-	GraphAsymmErrors         = 3  // ROOT version for TGraphAsymmErrors
-	H1                       = 8  // ROOT version for TH1
-	H1C                      = 3  // ROOT version for TH1C
-	H1D                      = 3  // ROOT version for TH1D
-	H1F                      = 3  // ROOT version for TH1F
-	H1I                      = 3  // ROOT version for TH1I
-	H1K                      = 2  // ROOT version for TH1K
-	H1S                      = 3  // ROOT version for TH1S
-	H2                       = 5  // ROOT version for TH2
-	H2C                      = 4  // ROOT version for TH2C
-	H2D                      = 4  // ROOT version for TH2D
-	H2F                      = 4  // ROOT version for TH2F
-	H2I                      = 4  // ROOT version for TH2I
-	H2Poly                   = 3  // ROOT version for TH2Poly
-	H2PolyBin                = 1  // ROOT version for TH2PolyBin
-	H2S                      = 4  // ROOT version for TH2S
-	Directory                = 5  // ROOT version for TDirectory
-	DirectoryFile            = 5  // ROOT version for TDirectoryFile
-	File                     = 8  // ROOT version for TFile
-	Key                      = 4  // ROOT version for TKey
-	FeldmanCousins           = 1  // ROOT version for TFeldmanCousins
-	LorentzVector            = 4  // ROOT version for TLorentzVector
-	Vector2                  = 3  // ROOT version for TVector2
-	Vector3                  = 3  // ROOT version for TVector3
-	ROOT_IOFeatures          = 1  // ROOT version for ROOT::TIOFeatures
-	Basket                   = 3  // ROOT version for TBasket
-	Branch                   = 13 // ROOT version for TBranch
-	BranchElement            = 10 // ROOT version for TBranchElement
-	BranchRef                = 1  // ROOT version for TBranchRef
-	Chain                    = 5  // ROOT version for TChain
-	Leaf                     = 2  // ROOT version for TLeaf
-	LeafElement              = 1  // ROOT version for TLeafElement
-	LeafO                    = 1  // ROOT version for TLeafO
-	LeafB                    = 1  // ROOT version for TLeafB
-	LeafS                    = 1  // ROOT version for TLeafS
-	LeafI                    = 1  // ROOT version for TLeafI
-	LeafL                    = 1  // ROOT version for TLeafL
-	LeafF                    = 1  // ROOT version for TLeafF
-	LeafD                    = 1  // ROOT version for TLeafD
-	LeafF16                  = 1  // ROOT version for TLeafF16
-	LeafD32                  = 1  // ROOT version for TLeafD32
-	LeafC                    = 1  // ROOT version for TLeafC
-	Ntuple                   = 2  // ROOT version for TNtuple
-	RefTable                 = 3  // ROOT version for TRefTable
-	Tree                     = 20 // ROOT version for TTree
+	Att3D                     = 1  // ROOT version for TAtt3D
+	AttAxis                   = 4  // ROOT version for TAttAxis
+	AttBBox2D                 = 0  // ROOT version for TAttBBox2D
+	AttCanvas                 = 1  // ROOT version for TAttCanvas
+	AttFill                   = 2  // ROOT version for TAttFill
+	AttLine                   = 2  // ROOT version for TAttLine
+	AttMarker                 = 2  // ROOT version for TAttMarker
+	AttPad                    = 4  // ROOT version for TAttPad
+	Named                     = 1  // ROOT version for TNamed
+	Object                    = 1  // ROOT version for TObject
+	ObjString                 = 1  // ROOT version for TObjString
+	ProcessID                 = 1  // ROOT version for TProcessID
+	ProcessUUID               = 1  // ROOT version for TProcessUUID
+	QObject                   = 1  // ROOT version for TQObject
+	Ref                       = 1  // ROOT version for TRef
+	UUID                      = 1  // ROOT version for TUUID
+	Array                     = 1  // ROOT version for TArray
+	ArrayC                    = 1  // ROOT version for TArrayC
+	ArrayS                    = 1  // ROOT version for TArrayS
+	ArrayI                    = 1  // ROOT version for TArrayI
+	ArrayL                    = 1  // ROOT version for TArrayL
+	ArrayL64                  = 1  // ROOT version for TArrayL64
+	ArrayF                    = 1  // ROOT version for TArrayF
+	ArrayD                    = 1  // ROOT version for TArrayD
+	Bits                      = 1  // ROOT version for TBits
+	Collection                = 3  // ROOT version for TCollection
+	ClonesArray               = 4  // ROOT version for TClonesArray
+	List                      = 5  // ROOT version for TList
+	HashList                  = 0  // ROOT version for THashList
+	Map                       = 3  // ROOT version for TMap
+	ObjArray                  = 3  // ROOT version for TObjArray
+	RefArray                  = 1  // ROOT version for TRefArray
+	SeqCollection             = 0  // ROOT version for TSeqCollection
+	StreamerInfo              = 9  // ROOT version for TStreamerInfo
+	StreamerElement           = 4  // ROOT version for TStreamerElement
+	StreamerBase              = 3  // ROOT version for TStreamerBase
+	StreamerBasicType         = 2  // ROOT version for TStreamerBasicType
+	StreamerBasicPointer      = 2  // ROOT version for TStreamerBasicPointer
+	StreamerLoop              = 2  // ROOT version for TStreamerLoop
+	StreamerObject            = 2  // ROOT version for TStreamerObject
+	StreamerObjectPointer     = 2  // ROOT version for TStreamerObjectPointer
+	StreamerObjectAny         = 2  // ROOT version for TStreamerObjectAny
+	StreamerObjectAnyPointer  = 1  // ROOT version for TStreamerObjectAnyPointer
+	StreamerString            = 2  // ROOT version for TStreamerString
+	StreamerSTL               = 3  // ROOT version for TStreamerSTL
+	StreamerSTLstring         = 2  // ROOT version for TStreamerSTLstring
+	StreamerArtificial        = 0  // ROOT version for TStreamerArtificial
+	Axis                      = 10 // ROOT version for TAxis
+	Canvas                    = 8  // ROOT version for TCanvas
+	Efficiency                = 2  // ROOT version for TEfficiency
+	F1                        = 12 // ROOT version for TF1
+	F1Parameters              = 1  // ROOT version for TF1Parameters
+	Formula                   = 12 // ROOT version for TFormula
+	Graph                     = 4  // ROOT version for TGraph
+	GraphErrors               = 3  // ROOT version for TGraphErrors
+	GraphAsymmErrors          = 3  // ROOT version for TGraphAsymmErrors
+	H1                        = 8  // ROOT version for TH1
+	H1C                       = 3  // ROOT version for TH1C
+	H1D                       = 3  // ROOT version for TH1D
+	H1F                       = 3  // ROOT version for TH1F
+	H1I                       = 3  // ROOT version for TH1I
+	H1K                       = 2  // ROOT version for TH1K
+	H1S                       = 3  // ROOT version for TH1S
+	H2                        = 5  // ROOT version for TH2
+	H2C                       = 4  // ROOT version for TH2C
+	H2D                       = 4  // ROOT version for TH2D
+	H2F                       = 4  // ROOT version for TH2F
+	H2I                       = 4  // ROOT version for TH2I
+	H2Poly                    = 3  // ROOT version for TH2Poly
+	H2PolyBin                 = 1  // ROOT version for TH2PolyBin
+	H2S                       = 4  // ROOT version for TH2S
+	H3                        = 6  // ROOT version for TH3
+	H3D                       = 4  // ROOT version for TH3D
+	H3F                       = 4  // ROOT version for TH3F
+	H3I                       = 4  // ROOT version for TH3I
+	HStack                    = 2  // ROOT version for THStack
+	HnBase                    = 1  // ROOT version for THnBase
+	HnSparse                  = 3  // ROOT version for THnSparse
+	HnSparseArrayChunk        = 1  // ROOT version for THnSparseArrayChunk
+	HnSparseT_TArrayD         = 1  // ROOT version for THnSparseT<TArrayD>
+	HnSparseT_TArrayF         = 1  // ROOT version for THnSparseT<TArrayF>
+	MultiGraph                = 2  // ROOT version for TMultiGraph
+	Pad                       = 13 // ROOT version for TPad
+	Profile                   = 7  // ROOT version for TProfile
+	Profile2D                 = 8  // ROOT version for TProfile2D
+	VirtualPad                = 2  // ROOT version for TVirtualPad
+	Directory                 = 5  // ROOT version for TDirectory
+	DirectoryFile             = 5  // ROOT version for TDirectoryFile
+	File                      = 8  // ROOT version for TFile
+	Key                       = 4  // ROOT version for TKey
+	ROOT_Experimental_RNTuple = 1  // ROOT version for ROOT::Experimental::RNTuple
+	FeldmanCousins            = 1  // ROOT version for TFeldmanCousins
+	LorentzVector             = 4  // ROOT version for TLorentzVector
+	Vector2                   = 3  // ROOT version for TVector2
+	Vector3                   = 3  // ROOT version for TVector3
+	ROOT_IOFeatures           = 1  // ROOT version for ROOT::TIOFeatures
+	Basket                    = 3  // ROOT version for TBasket
+	Branch                    = 13 // ROOT version for TBranch
+	BranchElement             = 10 // ROOT version for TBranchElement
+	BranchRef                 = 1  // ROOT version for TBranchRef
+	Chain                     = 5  // ROOT version for TChain
+	ChainIndex                = 1  // ROOT version for TChainIndex
+	EntryList                 = 2  // ROOT version for TEntryList
+	EntryListBlock            = 1  // ROOT version for TEntryListBlock
+	EventList                 = 4  // ROOT version for TEventList
+	Leaf                      = 2  // ROOT version for TLeaf
+	LeafElement               = 1  // ROOT version for TLeafElement
+	LeafO                     = 1  // ROOT version for TLeafO
+	LeafB                     = 1  // ROOT version for TLeafB
+	LeafS                     = 1  // ROOT version for TLeafS
+	LeafI                     = 1  // ROOT version for TLeafI
+	LeafL                     = 1  // ROOT version for TLeafL
+	LeafF                     = 1  // ROOT version for TLeafF
+	LeafD                     = 1  // ROOT version for TLeafD
+	LeafF16                   = 1  // ROOT version for TLeafF16
+	LeafD32                   = 1  // ROOT version for TLeafD32
+	LeafC                     = 1  // ROOT version for TLeafC
+	Ntuple                    = 2  // ROOT version for TNtuple
+	RefTable                  = 3  // ROOT version for TRefTable
+	Tree                      = 20 // ROOT version for TTree
+	TreeIndex                 = 2  // ROOT version for TTreeIndex
+	VirtualIndex              = 1  // ROOT version for TVirtualIndex
 )
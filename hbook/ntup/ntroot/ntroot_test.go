@@ -6,8 +6,14 @@ package ntroot_test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
+	"go-hep.org/x/hep/groot/rsql/rsqldrv"
+	"go-hep.org/x/hep/hbook/ntup"
 	"go-hep.org/x/hep/hbook/ntup/ntroot"
 )
 
@@ -58,3 +64,68 @@ func TestOpen(t *testing.T) {
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "hbook-ntroot-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	fname := filepath.Join(tmp, "ntup.root")
+	db, err := rsqldrv.Create(fname)
+	if err != nil {
+		t.Fatalf("could not create ROOT db: %+v", err)
+	}
+	defer db.Close()
+
+	type data struct {
+		N int32   `hbook:"n"`
+		X float64 `hbook:"x"`
+	}
+
+	nt, err := ntup.Create(db, "nt", data{})
+	if err != nil {
+		t.Fatalf("could not create n-tuple: %+v", err)
+	}
+
+	tx, err := nt.DB().Begin()
+	if err != nil {
+		t.Fatalf("could not start transaction: %+v", err)
+	}
+	for i := 0; i < 4; i++ {
+		_, err = tx.Exec("insert into nt (n, x) values (?, ?)", i, float64(i)+0.5)
+		if err != nil {
+			t.Fatalf("could not insert row %d: %+v", i, err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		t.Fatalf("could not commit: %+v", err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("could not close ROOT db: %+v", err)
+	}
+
+	nt, err = ntroot.Open(fname, "nt")
+	if err != nil {
+		t.Fatalf("could not open n-tuple: %+v", err)
+	}
+	defer nt.DB().Close()
+
+	var got []data
+	err = nt.Scan("(n, x)", func(n int32, x float64) error {
+		got = append(got, data{n, x})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("could not scan n-tuple: %+v", err)
+	}
+
+	want := []data{{0, 0.5}, {1, 1.5}, {2, 2.5}, {3, 3.5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid n-tuple content.\ngot= %v\nwant=%v", got, want)
+	}
+}
@@ -644,6 +644,22 @@ func (bng *binningP1D) Bins() []BinP1D {
 	return bng.bins
 }
 
+// Dist returns the distribution of all the entries of this binning,
+// under- and over-flows included.
+func (bng *binningP1D) Dist() *Dist2D {
+	return &bng.dist
+}
+
+// Underflow returns the under-flow distribution of this binning.
+func (bng *binningP1D) Underflow() *Dist2D {
+	return &bng.outflows[0]
+}
+
+// Overflow returns the over-flow distribution of this binning.
+func (bng *binningP1D) Overflow() *Dist2D {
+	return &bng.outflows[1]
+}
+
 // BinP1D models a bin in a 1-dim space.
 type BinP1D struct {
 	xrange Range
@@ -661,6 +677,11 @@ func (b *BinP1D) fill(x, y, w float64) {
 	b.dist.fill(x, y, w)
 }
 
+// Dist returns the distribution of the entries in this bin.
+func (b *BinP1D) Dist() *Dist2D {
+	return &b.dist
+}
+
 // Entries returns the number of entries in this bin.
 func (b *BinP1D) Entries() int64 {
 	return b.dist.Entries()
@@ -28,6 +28,15 @@ func H2D(h2 rhist.H2) *hbook.H2D {
 	return h2.(h2der).AsH2D()
 }
 
+type p1der interface {
+	AsP1D() *hbook.P1D
+}
+
+// P1D creates a new P1D from a TProfile.
+func P1D(p1 rhist.P1) *hbook.P1D {
+	return p1.(p1der).AsP1D()
+}
+
 // S2D creates a new S2D from a TGraph, TGraphErrors or TGraphAsymmErrors.
 func S2D(g rhist.Graph) *hbook.S2D {
 	pts := make([]hbook.Point2D, g.Len())
@@ -62,6 +71,11 @@ func FromH2D(h2 *hbook.H2D) *rhist.H2D {
 	return rhist.NewH2DFrom(h2)
 }
 
+// FromP1D creates a new ROOT TProfile from a 1-dim hbook profile histogram.
+func FromP1D(p1 *hbook.P1D) *rhist.Profile {
+	return rhist.NewProfileFrom(p1)
+}
+
 // FromS2D creates a new ROOT TGraphAsymmErrors from 2-dim hbook data points.
 func FromS2D(s2 *hbook.S2D) rhist.GraphErrors {
 	return rhist.NewGraphAsymmErrorsFrom(s2)
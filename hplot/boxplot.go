@@ -0,0 +1,535 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Distribution summarizes a 1-dim distribution of values, located at a
+// given position along the x-axis.
+//
+// Distributions are built from raw samples with NewDistribution, or
+// from the x-slices of a 2-dim histogram with NewDistributionsFromH2D.
+type Distribution struct {
+	X     float64 // position of the distribution along the x-axis.
+	Width float64 // width of the distribution along the x-axis, in data units. Zero to derive it from the other distributions.
+
+	N              float64 // sum of weights of the distribution.
+	Mean           float64 // weighted mean of the distribution.
+	Min, Max       float64 // minimum and maximum values of the distribution.
+	Q1, Median, Q3 float64 // quartiles of the distribution.
+
+	vs    []float64 // sorted sample values, or bin centers for binned distributions.
+	ws    []float64 // weights of each value.
+	edges []float64 // bin edges, for binned distributions.
+}
+
+// NewDistribution returns the distribution of the provided samples,
+// located at x.
+// ws holds the weights of each sample. A nil ws gives a weight of 1 to
+// each sample.
+func NewDistribution(x float64, vs, ws []float64) Distribution {
+	d := Distribution{
+		X:  x,
+		vs: make([]float64, 0, len(vs)),
+		ws: make([]float64, 0, len(vs)),
+	}
+
+	idx := make([]int, 0, len(vs))
+	for i, v := range vs {
+		if math.IsNaN(v) || (ws != nil && ws[i] <= 0) {
+			continue
+		}
+		idx = append(idx, i)
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return vs[idx[i]] < vs[idx[j]] })
+
+	var sumwx float64
+	for _, i := range idx {
+		w := 1.0
+		if ws != nil {
+			w = ws[i]
+		}
+		d.vs = append(d.vs, vs[i])
+		d.ws = append(d.ws, w)
+		d.N += w
+		sumwx += w * vs[i]
+	}
+	if d.N == 0 {
+		return d
+	}
+
+	d.Mean = sumwx / d.N
+	d.Min = d.vs[0]
+	d.Max = d.vs[len(d.vs)-1]
+	d.quartiles()
+	return d
+}
+
+// NewDistributionsFromH2D returns the distributions of the y-values of
+// the provided 2-dim histogram, one for each x-bin.
+func NewDistributionsFromH2D(h *hbook.H2D) []Distribution {
+	var (
+		nx    = h.Binning.Nx
+		ny    = h.Binning.Ny
+		dists = make([]Distribution, nx)
+	)
+
+	for ix := range dists {
+		var (
+			d     = &dists[ix]
+			sumwy float64
+			xbin  = h.Binning.XEdges[ix]
+		)
+		d.X = 0.5 * (xbin.Range.Min + xbin.Range.Max)
+		d.Width = xbin.Range.Max - xbin.Range.Min
+		d.vs = make([]float64, ny)
+		d.ws = make([]float64, ny)
+		d.edges = make([]float64, ny+1)
+		for iy := 0; iy < ny; iy++ {
+			bin := &h.Binning.Bins[iy*nx+ix]
+			w := bin.SumW()
+			d.vs[iy] = bin.YMid()
+			d.ws[iy] = w
+			d.edges[iy] = bin.YMin()
+			d.edges[iy+1] = bin.YMax()
+			d.N += w
+			sumwy += bin.Dist.SumWY()
+		}
+		if d.N <= 0 {
+			d.N = 0
+			continue
+		}
+
+		d.Mean = sumwy / d.N
+		for iy, w := range d.ws {
+			if w > 0 {
+				d.Min = d.edges[iy]
+				break
+			}
+		}
+		for iy := ny - 1; iy >= 0; iy-- {
+			if d.ws[iy] > 0 {
+				d.Max = d.edges[iy+1]
+				break
+			}
+		}
+		d.quartiles()
+	}
+
+	return dists
+}
+
+func (d *Distribution) quartiles() {
+	d.Q1 = d.Quantile(0.25)
+	d.Median = d.Quantile(0.50)
+	d.Q3 = d.Quantile(0.75)
+}
+
+func (d *Distribution) binned() bool { return d.edges != nil }
+
+// Quantile returns the p-quantile of the distribution, with p in [0,1].
+//
+// Quantiles of binned distributions are linearly interpolated within
+// bins.
+// Quantiles of unbinned distributions are linearly interpolated between
+// the samples, each sample being located at the middle of its
+// cumulative weight.
+func (d *Distribution) Quantile(p float64) float64 {
+	if d.N <= 0 {
+		return math.NaN()
+	}
+	switch {
+	case p <= 0:
+		return d.Min
+	case p >= 1:
+		return d.Max
+	}
+
+	var (
+		target = p * d.N
+		cum    = 0.0
+	)
+
+	if d.binned() {
+		for i, w := range d.ws {
+			if w <= 0 {
+				continue
+			}
+			if cum+w >= target {
+				lo, hi := d.edges[i], d.edges[i+1]
+				return lo + (target-cum)/w*(hi-lo)
+			}
+			cum += w
+		}
+		return d.Max
+	}
+
+	prev := math.Inf(-1)
+	for i, w := range d.ws {
+		pos := cum + 0.5*w
+		if pos >= target {
+			if i == 0 {
+				return d.vs[0]
+			}
+			v0, v1 := d.vs[i-1], d.vs[i]
+			return v0 + (target-prev)/(pos-prev)*(v1-v0)
+		}
+		prev = pos
+		cum += w
+	}
+	return d.Max
+}
+
+// whiskers returns the extent of the whiskers of the distribution.
+// Whiskers extend to the most extreme values within k inter-quartile
+// ranges of the box. A non-positive k extends the whiskers to the
+// minimum and maximum values of the distribution.
+func (d *Distribution) whiskers(k float64) (lo, hi float64) {
+	if k <= 0 {
+		return d.Min, d.Max
+	}
+	var (
+		iqr  = d.Q3 - d.Q1
+		vmin = d.Q1 - k*iqr
+		vmax = d.Q3 + k*iqr
+	)
+	lo, hi = d.Q1, d.Q3
+	for i, v := range d.vs {
+		if d.ws[i] <= 0 {
+			continue
+		}
+		if vmin <= v && v < lo {
+			lo = v
+		}
+		if hi < v && v <= vmax {
+			hi = v
+		}
+	}
+	return lo, hi
+}
+
+// outliers returns the values of the distribution outside [lo,hi].
+func (d *Distribution) outliers(lo, hi float64) []float64 {
+	var vs []float64
+	for i, v := range d.vs {
+		if d.ws[i] <= 0 {
+			continue
+		}
+		if v < lo || hi < v {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+// density returns a sampling of the density of the distribution.
+// Binned distributions are sampled at their bin centers.
+// Unbinned distributions are sampled at n points with a gaussian kernel
+// density estimate, using Silverman's rule of thumb for the bandwidth.
+func (d *Distribution) density(n int) (ys, ds []float64) {
+	if d.N <= 0 {
+		return nil, nil
+	}
+
+	if d.binned() {
+		beg, end := 0, len(d.ws)
+		for beg < end && d.ws[beg] <= 0 {
+			beg++
+		}
+		for end > beg && d.ws[end-1] <= 0 {
+			end--
+		}
+		ys = append(ys, d.edges[beg])
+		ds = append(ds, d.ws[beg]/(d.edges[beg+1]-d.edges[beg]))
+		for i := beg; i < end; i++ {
+			ys = append(ys, d.vs[i])
+			ds = append(ds, d.ws[i]/(d.edges[i+1]-d.edges[i]))
+		}
+		ys = append(ys, d.edges[end])
+		ds = append(ds, d.ws[end-1]/(d.edges[end]-d.edges[end-1]))
+		return ys, ds
+	}
+
+	var sumw2, sumwx2 float64
+	for i, w := range d.ws {
+		dx := d.vs[i] - d.Mean
+		sumw2 += w * w
+		sumwx2 += w * dx * dx
+	}
+	var (
+		neff  = d.N * d.N / sumw2
+		sigma = math.Sqrt(sumwx2 / d.N)
+		bw    = 1.06 * sigma * math.Pow(neff, -0.2)
+	)
+	if bw <= 0 {
+		bw = 1e-3 * (math.Abs(d.Mean) + 1)
+	}
+
+	if n < 2 {
+		n = 2
+	}
+	if d.Min == d.Max {
+		n = 1
+	}
+	ys = make([]float64, n)
+	ds = make([]float64, n)
+	norm := 1 / (d.N * bw * math.Sqrt(2*math.Pi))
+	for i := range ys {
+		y := d.Min
+		if n > 1 {
+			y += float64(i) * (d.Max - d.Min) / float64(n-1)
+		}
+		var sum float64
+		for j, v := range d.vs {
+			u := (y - v) / bw
+			sum += d.ws[j] * math.Exp(-0.5*u*u)
+		}
+		ys[i] = y
+		ds[i] = sum * norm
+	}
+	return ys, ds
+}
+
+// distWidth returns the default width, in data units, of the
+// distributions: the minimal spacing between two distributions.
+func distWidth(dists []Distribution) float64 {
+	xs := make([]float64, 0, len(dists))
+	for _, d := range dists {
+		xs = append(xs, d.X)
+	}
+	sort.Float64s(xs)
+	w := math.Inf(+1)
+	for i := 1; i < len(xs); i++ {
+		if dx := xs[i] - xs[i-1]; dx > 0 && dx < w {
+			w = dx
+		}
+	}
+	if math.IsInf(w, +1) {
+		w = 1
+	}
+	return w
+}
+
+// distRange returns the data range of the distributions, each of them
+// being drawn with the provided fraction of their width.
+func distRange(dists []Distribution, frac float64) (xmin, xmax, ymin, ymax float64) {
+	xmin = math.Inf(+1)
+	xmax = math.Inf(-1)
+	ymin = math.Inf(+1)
+	ymax = math.Inf(-1)
+	dw := distWidth(dists)
+	for _, d := range dists {
+		w := d.Width
+		if w <= 0 {
+			w = dw
+		}
+		xmin = math.Min(xmin, d.X-0.5*w*frac)
+		xmax = math.Max(xmax, d.X+0.5*w*frac)
+		if d.N <= 0 {
+			continue
+		}
+		ymin = math.Min(ymin, d.Min)
+		ymax = math.Max(ymax, d.Max)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// BoxPlot implements the plot.Plotter interface, drawing box plots of
+// a set of distributions.
+//
+// A box spans the inter-quartile range of a distribution, and is split
+// at its median.
+// Whiskers extend from the box to the most extreme values within
+// Whiskers inter-quartile ranges of the box.
+// Values outside the whiskers are drawn as outliers.
+type BoxPlot struct {
+	// Dists are the distributions to display.
+	Dists []Distribution
+
+	// Width is the fraction of the width of each distribution used to
+	// draw its box.
+	Width float64
+
+	// FillColor is the color used to fill the boxes.
+	// Use nil to disable the filling.
+	FillColor color.Color
+
+	// LineStyle is the style of the boxes and of the whiskers.
+	draw.LineStyle
+
+	// MedianStyle is the style of the median lines.
+	MedianStyle draw.LineStyle
+
+	// Whiskers is the maximal extent of the whiskers, in units of the
+	// inter-quartile range.
+	// A non-positive value extends the whiskers to the minimum and
+	// maximum values of the distributions.
+	Whiskers float64
+
+	// Anchors is the fraction of the width of a box used to draw the
+	// anchors at the end of the whiskers.
+	// Use zero to disable the anchors.
+	Anchors float64
+
+	// MeanStyle is the style of the glyph marking the mean of the
+	// distributions.
+	// Use a zero radius to disable the glyph.
+	MeanStyle draw.GlyphStyle
+
+	// OutlierStyle is the style of the glyphs marking the outliers.
+	// Use a zero radius to disable the glyphs.
+	OutlierStyle draw.GlyphStyle
+}
+
+// NewBoxPlot returns a box plot of the provided distributions, drawn with
+// Tukey's conventions: whiskers extend to 1.5 inter-quartile ranges and
+// outliers are drawn as circles.
+func NewBoxPlot(dists []Distribution) *BoxPlot {
+	return &BoxPlot{
+		Dists:       dists,
+		Width:       0.8,
+		LineStyle:   plotter.DefaultLineStyle,
+		MedianStyle: plotter.DefaultLineStyle,
+		Whiskers:    1.5,
+		Anchors:     0.5,
+		OutlierStyle: draw.GlyphStyle{
+			Color:  color.Black,
+			Radius: vg.Points(2),
+			Shape:  draw.CircleGlyph{},
+		},
+	}
+}
+
+// NewCandle returns a candle plot of the provided distributions, drawn
+// like the "CANDLE" option of ROOT: filled boxes with a thick median
+// line, anchored whiskers, a marker for the mean and dots for the
+// outliers.
+func NewCandle(dists []Distribution) *BoxPlot {
+	bp := NewBoxPlot(dists)
+	bp.Width = 0.6
+	bp.FillColor = color.NRGBA{R: 0x99, G: 0xbb, B: 0xdd, A: 0xff}
+	bp.MedianStyle.Width = 2 * bp.LineStyle.Width
+	bp.Anchors = 1
+	bp.MeanStyle = draw.GlyphStyle{
+		Color:  color.Black,
+		Radius: vg.Points(2.5),
+		Shape:  draw.PlusGlyph{},
+	}
+	bp.OutlierStyle = draw.GlyphStyle{
+		Color:  color.Black,
+		Radius: vg.Points(1),
+		Shape:  draw.CircleGlyph{},
+	}
+	return bp
+}
+
+// Plot implements the Plotter interface, drawing the boxes, whiskers and
+// outliers of each distribution.
+func (bp *BoxPlot) Plot(c draw.Canvas, plt *plot.Plot) {
+	var (
+		trX, trY = plt.Transforms(&c)
+		dw       = distWidth(bp.Dists)
+	)
+
+	for i := range bp.Dists {
+		d := &bp.Dists[i]
+		if d.N <= 0 {
+			continue
+		}
+		w := d.Width
+		if w <= 0 {
+			w = dw
+		}
+		var (
+			half   = 0.5 * w * bp.Width
+			x0     = trX(d.X - half)
+			x1     = trX(d.X + half)
+			xm     = trX(d.X)
+			q1     = trY(d.Q1)
+			q3     = trY(d.Q3)
+			med    = trY(d.Median)
+			lo, hi = d.whiskers(bp.Whiskers)
+			ylo    = trY(lo)
+			yhi    = trY(hi)
+		)
+
+		box := []vg.Point{{X: x0, Y: q1}, {X: x1, Y: q1}, {X: x1, Y: q3}, {X: x0, Y: q3}}
+		if bp.FillColor != nil {
+			c.FillPolygon(bp.FillColor, c.ClipPolygonXY(box))
+		}
+		box = append(box, box[0])
+		c.StrokeLines(bp.LineStyle, c.ClipLinesXY(box)...)
+		c.StrokeLines(bp.MedianStyle, c.ClipLinesXY([]vg.Point{{X: x0, Y: med}, {X: x1, Y: med}})...)
+
+		c.StrokeLines(bp.LineStyle, c.ClipLinesXY(
+			[]vg.Point{{X: xm, Y: q1}, {X: xm, Y: ylo}},
+			[]vg.Point{{X: xm, Y: q3}, {X: xm, Y: yhi}},
+		)...)
+		if bp.Anchors > 0 {
+			var (
+				a0 = trX(d.X - half*bp.Anchors)
+				a1 = trX(d.X + half*bp.Anchors)
+			)
+			c.StrokeLines(bp.LineStyle, c.ClipLinesXY(
+				[]vg.Point{{X: a0, Y: ylo}, {X: a1, Y: ylo}},
+				[]vg.Point{{X: a0, Y: yhi}, {X: a1, Y: yhi}},
+			)...)
+		}
+
+		if bp.MeanStyle.Radius > 0 {
+			pt := vg.Point{X: xm, Y: trY(d.Mean)}
+			if c.Contains(pt) {
+				c.DrawGlyph(bp.MeanStyle, pt)
+			}
+		}
+
+		if bp.OutlierStyle.Radius > 0 {
+			for _, v := range d.outliers(lo, hi) {
+				pt := vg.Point{X: xm, Y: trY(v)}
+				if c.Contains(pt) {
+					c.DrawGlyph(bp.OutlierStyle, pt)
+				}
+			}
+		}
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values, implementing
+// the plot.DataRanger interface.
+func (bp *BoxPlot) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return distRange(bp.Dists, bp.Width)
+}
+
+// Thumbnail draws a box in the given style, implementing the
+// plot.Thumbnailer interface.
+func (bp *BoxPlot) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+	}
+	if bp.FillColor != nil {
+		c.FillPolygon(bp.FillColor, c.ClipPolygonXY(pts))
+	}
+	pts = append(pts, pts[0])
+	c.StrokeLines(bp.LineStyle, c.ClipLinesXY(pts)...)
+}
+
+var (
+	_ plot.Plotter     = (*BoxPlot)(nil)
+	_ plot.DataRanger  = (*BoxPlot)(nil)
+	_ plot.Thumbnailer = (*BoxPlot)(nil)
+)
@@ -0,0 +1,84 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"math"
+	"testing"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestBoxPlot(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleBoxPlot, t, "boxplot.png")
+}
+
+func TestCandle(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleNewCandle, t, "candle.png")
+}
+
+func TestDistribution(t *testing.T) {
+	const eps = 1e-12
+	cmp := func(t *testing.T, name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > eps {
+			t.Fatalf("invalid %s: got=%v, want=%v", name, got, want)
+		}
+	}
+
+	t.Run("samples", func(t *testing.T) {
+		d := hplot.NewDistribution(1, []float64{5, 1, 4, math.NaN(), 2, 3}, nil)
+		cmp(t, "N", d.N, 5)
+		cmp(t, "mean", d.Mean, 3)
+		cmp(t, "min", d.Min, 1)
+		cmp(t, "max", d.Max, 5)
+		cmp(t, "q1", d.Q1, 1.75)
+		cmp(t, "median", d.Median, 3)
+		cmp(t, "q3", d.Q3, 4.25)
+	})
+
+	t.Run("weighted-samples", func(t *testing.T) {
+		d := hplot.NewDistribution(1, []float64{1, 2, 3, 4}, []float64{1, 0, 2, 1})
+		cmp(t, "N", d.N, 4)
+		cmp(t, "mean", d.Mean, 2.75)
+		cmp(t, "median", d.Median, 3)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		d := hplot.NewDistribution(1, nil, nil)
+		if !math.IsNaN(d.Quantile(0.5)) {
+			t.Fatalf("invalid median of empty distribution: %v", d.Quantile(0.5))
+		}
+	})
+
+	t.Run("h2d", func(t *testing.T) {
+		h := hbook.NewH2D(2, 0, 2, 4, 0, 4)
+		h.Fill(0.5, 1.5, 2)
+		h.Fill(0.5, 2.5, 2)
+		h.Fill(1.5, 3.5, 1)
+
+		ds := hplot.NewDistributionsFromH2D(h)
+		if got, want := len(ds), 2; got != want {
+			t.Fatalf("invalid number of distributions: got=%d, want=%d", got, want)
+		}
+
+		d := ds[0]
+		cmp(t, "x", d.X, 0.5)
+		cmp(t, "width", d.Width, 1)
+		cmp(t, "N", d.N, 4)
+		cmp(t, "mean", d.Mean, 2)
+		cmp(t, "min", d.Min, 1)
+		cmp(t, "max", d.Max, 3)
+		cmp(t, "q1", d.Q1, 1.5)
+		cmp(t, "median", d.Median, 2)
+		cmp(t, "q3", d.Q3, 2.5)
+
+		d = ds[1]
+		cmp(t, "x", d.X, 1.5)
+		cmp(t, "median", d.Median, 3.5)
+	})
+}
@@ -0,0 +1,889 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg"
+)
+
+var errQuit = errors.New("hplot: quit")
+
+type tokenKind int
+
+const (
+	tokWord   tokenKind = iota // a bare word
+	tokString                  // a quoted string
+	tokSource                  // a data source: 'fname':spec:...
+	tokComma                   // ,
+	tokSemi                    // ;
+)
+
+type lexeme struct {
+	kind tokenKind
+	text string
+	src  source // only valid for tokSource
+}
+
+// lex splits a line of commands into tokens.
+// Commas nested inside parentheses are part of the enclosing word.
+// lex errors out on unbalanced parentheses.
+func lex(line string) ([]lexeme, error) {
+	var (
+		toks  []lexeme
+		rs    = []rune(line)
+		depth = 0
+	)
+
+	quoted := func(i int) (string, int, error) {
+		q := rs[i]
+		j := i + 1
+		for j < len(rs) && rs[j] != q {
+			j++
+		}
+		if j >= len(rs) {
+			return "", 0, fmt.Errorf("unterminated string %s", string(rs[i:]))
+		}
+		return string(rs[i+1 : j]), j + 1, nil
+	}
+
+	paren := func(r rune) error {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return fmt.Errorf("unbalanced parentheses in %q", line)
+			}
+		}
+		return nil
+	}
+
+	isDelim := func(r rune) bool {
+		switch r {
+		case ' ', '\t', '\n', '\r', ';':
+			return true
+		case ',':
+			return depth == 0
+		}
+		return false
+	}
+
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '#':
+			return toks, nil
+		case r == ';':
+			toks = append(toks, lexeme{kind: tokSemi, text: ";"})
+			i++
+		case r == ',' && depth == 0:
+			toks = append(toks, lexeme{kind: tokComma, text: ","})
+			i++
+		case r == '\'' || r == '"':
+			str, j, err := quoted(i)
+			if err != nil {
+				return nil, err
+			}
+			if j < len(rs) && rs[j] == ':' {
+				k := j
+				for k < len(rs) && !isDelim(rs[k]) {
+					err := paren(rs[k])
+					if err != nil {
+						return nil, err
+					}
+					k++
+				}
+				specs := strings.Split(string(rs[j+1:k]), ":")
+				toks = append(toks, lexeme{
+					kind: tokSource,
+					text: string(rs[i:k]),
+					src:  source{fname: str, specs: specs},
+				})
+				i = k
+				continue
+			}
+			toks = append(toks, lexeme{kind: tokString, text: str})
+			i = j
+		default:
+			j := i
+			for j < len(rs) && !isDelim(rs[j]) {
+				err := paren(rs[j])
+				if err != nil {
+					return nil, err
+				}
+				j++
+			}
+			toks = append(toks, lexeme{kind: tokWord, text: string(rs[i:j])})
+			i = j
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in %q", line)
+	}
+	return toks, nil
+}
+
+type axisRange struct {
+	set      bool
+	min, max float64
+}
+
+// interp interprets hplot commands.
+type interp struct {
+	msg io.Writer
+
+	// settings
+	title  string
+	xlabel string
+	ylabel string
+	xrange axisRange
+	yrange axisRange
+	logy   bool
+	grid   bool
+	output string
+	width  vg.Length
+	height vg.Length
+	sep    string
+	nbins  int
+
+	items []item // plotters of the current plot
+	dirty bool   // whether the current plot has not been saved yet
+}
+
+// item is a plotter with an optional legend entry.
+type item struct {
+	p     plot.Plotter
+	title string
+}
+
+func newInterp(msg io.Writer, output string) *interp {
+	it := &interp{msg: msg}
+	it.reset()
+	it.output = output
+	return it
+}
+
+func (it *interp) reset() {
+	output := it.output
+	*it = interp{
+		msg:    it.msg,
+		grid:   true,
+		nbins:  100,
+		width:  -1,
+		height: -1,
+		output: output,
+	}
+}
+
+// run executes all the commands read from r.
+func (it *interp) run(r io.Reader) error {
+	var (
+		sc   = bufio.NewScanner(r)
+		line = 0
+		buf  strings.Builder
+	)
+	for sc.Scan() {
+		line++
+		txt := sc.Text()
+		if strings.HasSuffix(txt, "\\") {
+			// line continuation.
+			buf.WriteString(strings.TrimSuffix(txt, "\\"))
+			buf.WriteString(" ")
+			continue
+		}
+		buf.WriteString(txt)
+		err := it.exec(buf.String())
+		buf.Reset()
+		if err != nil {
+			if errors.Is(err, errQuit) {
+				return err
+			}
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("could not read commands: %w", err)
+	}
+	if buf.Len() > 0 {
+		return it.exec(buf.String())
+	}
+	return nil
+}
+
+// exec executes a line of commands.
+// Multiple commands can be separated by semicolons.
+func (it *interp) exec(line string) error {
+	toks, err := lex(line)
+	if err != nil {
+		return err
+	}
+
+	beg := 0
+	for i := 0; i <= len(toks); i++ {
+		if i < len(toks) && toks[i].kind != tokSemi {
+			continue
+		}
+		if i > beg {
+			err := it.cmd(toks[beg:i])
+			if err != nil {
+				return err
+			}
+		}
+		beg = i + 1
+	}
+	return nil
+}
+
+func (it *interp) cmd(toks []lexeme) error {
+	name := toks[0].text
+	args := toks[1:]
+	switch name {
+	case "plot", "p":
+		it.items = it.items[:0]
+		return it.plot(args)
+	case "replot", "rep":
+		return it.plot(args)
+	case "set":
+		return it.set(args)
+	case "unset":
+		return it.unset(args)
+	case "save":
+		fname := it.output
+		switch len(args) {
+		case 0:
+		case 1:
+			fname = args[0].text
+		default:
+			return fmt.Errorf("invalid save command: too many arguments")
+		}
+		return it.save(fname)
+	case "reset":
+		it.reset()
+		return nil
+	case "quit", "exit", "q":
+		return errQuit
+	}
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func (it *interp) set(args []lexeme) error {
+	if len(args) == 0 {
+		return fmt.Errorf("invalid set command: missing option name")
+	}
+
+	str := func() (string, error) {
+		if len(args) != 2 {
+			return "", fmt.Errorf("invalid set %s command: need exactly one argument", args[0].text)
+		}
+		return args[1].text, nil
+	}
+
+	var err error
+	switch opt := args[0].text; opt {
+	case "title":
+		it.title, err = str()
+	case "xlabel":
+		it.xlabel, err = str()
+	case "ylabel":
+		it.ylabel, err = str()
+	case "output", "o":
+		it.output, err = str()
+	case "xrange":
+		it.xrange, err = parseRange(args[1:])
+	case "yrange":
+		it.yrange, err = parseRange(args[1:])
+	case "logy":
+		it.logy = true
+	case "grid":
+		it.grid = true
+	case "bins":
+		var v string
+		v, err = str()
+		if err != nil {
+			return err
+		}
+		it.nbins, err = strconv.Atoi(v)
+		if err == nil && it.nbins <= 0 {
+			err = fmt.Errorf("invalid number of bins %d", it.nbins)
+		}
+	case "size":
+		it.width, it.height, err = parseSize(args[1:])
+	case "datafile":
+		if len(args) != 3 || args[1].text != "separator" {
+			return fmt.Errorf("invalid set datafile command: expected 'set datafile separator \"c\"'")
+		}
+		switch sep := args[2].text; sep {
+		case "whitespace", "tab":
+			it.sep = " "
+		case "comma":
+			it.sep = ","
+		default:
+			it.sep = sep
+		}
+	default:
+		return fmt.Errorf("unknown option %q", opt)
+	}
+	return err
+}
+
+func (it *interp) unset(args []lexeme) error {
+	if len(args) != 1 {
+		return fmt.Errorf("invalid unset command")
+	}
+	switch opt := args[0].text; opt {
+	case "title":
+		it.title = ""
+	case "xlabel":
+		it.xlabel = ""
+	case "ylabel":
+		it.ylabel = ""
+	case "xrange":
+		it.xrange = axisRange{}
+	case "yrange":
+		it.yrange = axisRange{}
+	case "logy":
+		it.logy = false
+	case "grid":
+		it.grid = false
+	default:
+		return fmt.Errorf("unknown option %q", opt)
+	}
+	return nil
+}
+
+// parseRange parses a range of the form [min:max].
+// Either bound may be omitted (e.g. [:10]) to keep the automatic bound.
+func parseRange(args []lexeme) (axisRange, error) {
+	var str strings.Builder
+	for _, arg := range args {
+		str.WriteString(arg.text)
+	}
+	v := str.String()
+	if !strings.HasPrefix(v, "[") || !strings.HasSuffix(v, "]") {
+		return axisRange{}, fmt.Errorf("invalid range %q", v)
+	}
+	toks := strings.Split(v[1:len(v)-1], ":")
+	if len(toks) != 2 {
+		return axisRange{}, fmt.Errorf("invalid range %q", v)
+	}
+	rng := axisRange{set: true, min: math.NaN(), max: math.NaN()}
+	for i, tok := range toks {
+		if tok == "" || tok == "*" {
+			continue
+		}
+		x, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return axisRange{}, fmt.Errorf("invalid range %q: %w", v, err)
+		}
+		switch i {
+		case 0:
+			rng.min = x
+		case 1:
+			rng.max = x
+		}
+	}
+	return rng, nil
+}
+
+// parseSize parses a canvas size of the form: W,H (in centimeters.)
+func parseSize(args []lexeme) (w, h vg.Length, err error) {
+	var str strings.Builder
+	for _, arg := range args {
+		str.WriteString(arg.text)
+	}
+	toks := strings.Split(str.String(), ",")
+	if len(toks) != 2 {
+		return 0, 0, fmt.Errorf("invalid size %q", str.String())
+	}
+	vs := make([]vg.Length, 2)
+	for i, tok := range toks {
+		v, err := strconv.ParseFloat(strings.TrimSpace(tok), 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid size %q: %w", str.String(), err)
+		}
+		vs[i] = vg.Length(v) * vg.Centimeter
+	}
+	return vs[0], vs[1], nil
+}
+
+// plotSpec describes a single data set to plot.
+type plotSpec struct {
+	src   source
+	sel   string    // selection expression
+	bins  []float64 // binning: nbins, min, max [, nbins, min, max]
+	kind  string    // kind of plot: hist, h2d, points, lines, linespoints
+	title string    // legend entry
+}
+
+func parsePlot(args []lexeme) ([]plotSpec, error) {
+	var (
+		specs []plotSpec
+		cur   *plotSpec
+	)
+
+	// isKeyword returns whether the provided token is a keyword of the
+	// plot command.
+	// Abbreviated keywords are not recognized inside selection expressions,
+	// where they could be variable names.
+	isKeyword := func(tok lexeme, abbrev bool) bool {
+		if tok.kind != tokWord {
+			return false
+		}
+		switch tok.text {
+		case "if", "bins", "with", "title":
+			return true
+		case "w", "t":
+			return abbrev
+		}
+		return false
+	}
+
+	for i := 0; i < len(args); i++ {
+		tok := args[i]
+		if cur == nil {
+			if tok.kind != tokSource && tok.kind != tokString {
+				return nil, fmt.Errorf("invalid plot command: expected a data source, got %q", tok.text)
+			}
+			src := tok.src
+			if tok.kind == tokString {
+				src = source{fname: tok.text}
+			}
+			specs = append(specs, plotSpec{src: src})
+			cur = &specs[len(specs)-1]
+			continue
+		}
+
+		switch {
+		case tok.kind == tokComma:
+			cur = nil
+
+		case tok.kind == tokWord && tok.text == "if":
+			var sel []string
+			for i+1 < len(args) && args[i+1].kind != tokComma && !isKeyword(args[i+1], false) {
+				i++
+				sel = append(sel, args[i].text)
+			}
+			if len(sel) == 0 {
+				return nil, fmt.Errorf("invalid plot command: empty selection")
+			}
+			cur.sel = strings.Join(sel, " ")
+
+		case tok.kind == tokWord && tok.text == "bins":
+			for i+1 < len(args) && args[i+1].kind == tokWord && !isKeyword(args[i+1], true) {
+				i++
+				v, err := strconv.ParseFloat(args[i].text, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid plot command: invalid binning value %q: %w", args[i].text, err)
+				}
+				cur.bins = append(cur.bins, v)
+			}
+			switch len(cur.bins) {
+			case 3, 6:
+				for j := 0; j < len(cur.bins); j += 3 {
+					if n := cur.bins[j]; n <= 0 || n != math.Trunc(n) {
+						return nil, fmt.Errorf("invalid plot command: invalid number of bins %v", n)
+					}
+					if cur.bins[j+1] >= cur.bins[j+2] {
+						return nil, fmt.Errorf("invalid plot command: invalid bin range [%v, %v]", cur.bins[j+1], cur.bins[j+2])
+					}
+				}
+			default:
+				return nil, fmt.Errorf("invalid plot command: binning expects 'N min max' or 'NX XMIN XMAX NY YMIN YMAX'")
+			}
+
+		case tok.kind == tokWord && (tok.text == "with" || tok.text == "w"):
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("invalid plot command: missing plot kind")
+			}
+			i++
+			switch kind := args[i].text; kind {
+			case "hist", "h", "histogram":
+				cur.kind = "hist"
+			case "h2d", "colz":
+				cur.kind = "h2d"
+			case "points", "p":
+				cur.kind = "points"
+			case "lines", "l":
+				cur.kind = "lines"
+			case "linespoints", "lp":
+				cur.kind = "linespoints"
+			default:
+				return nil, fmt.Errorf("invalid plot command: unknown plot kind %q", kind)
+			}
+
+		case tok.kind == tokWord && (tok.text == "title" || tok.text == "t"):
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("invalid plot command: missing title")
+			}
+			i++
+			cur.title = args[i].text
+
+		default:
+			return nil, fmt.Errorf("invalid plot command: unexpected token %q", tok.text)
+		}
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("invalid plot command: no data source")
+	}
+
+	return specs, nil
+}
+
+func (it *interp) plot(args []lexeme) error {
+	specs, err := parsePlot(args)
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range specs {
+		p, err := it.build(spec, len(it.items))
+		if err != nil {
+			return fmt.Errorf("could not plot %v: %w", spec.src, err)
+		}
+		it.items = append(it.items, item{p: p, title: spec.title})
+	}
+	it.dirty = true
+	return nil
+}
+
+// build creates the plotter for the provided specification.
+// The i-th plotter of a plot is drawn with the i-th color of the
+// default palette.
+func (it *interp) build(spec plotSpec, i int) (plot.Plotter, error) {
+	ds, err := load(spec.src, it.sep)
+	if err != nil {
+		return nil, err
+	}
+	defer ds.Close()
+
+	if ds.obj != nil {
+		if spec.sel != "" || spec.bins != nil {
+			return nil, fmt.Errorf("selection and binning are only supported for tabular data")
+		}
+		return it.plotObject(ds.obj, spec.kind, i)
+	}
+
+	var (
+		sel  *expr
+		vars []string
+		cols = make([]*expr, len(ds.cols))
+	)
+	if spec.sel != "" {
+		sel, err = newExpr(spec.sel)
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, sel.vars...)
+	}
+	for i, col := range ds.cols {
+		if isIndex(col) {
+			// numbered column of a text file.
+			name := col
+			cols[i] = &expr{
+				src:  col,
+				vars: []string{name},
+				eval: func(vs map[string]float64) float64 { return vs[name] },
+			}
+		} else {
+			cols[i], err = newExpr(col)
+			if err != nil {
+				return nil, err
+			}
+		}
+		vars = append(vars, cols[i].vars...)
+	}
+	vars = uniq(vars)
+
+	var (
+		data = make([][]float64, len(cols))
+		vs   = make(map[string]float64, len(vars))
+	)
+	err = ds.tbl.scan(vars, func(vals [][]float64) error {
+		n := -1
+		for _, v := range vals {
+			if len(v) == 1 {
+				continue
+			}
+			if n < 0 || len(v) < n {
+				n = len(v)
+			}
+		}
+		if n < 0 {
+			n = 1
+		}
+		for j := 0; j < n; j++ {
+			for k, name := range vars {
+				v := vals[k]
+				switch len(v) {
+				case 1:
+					vs[name] = v[0]
+				default:
+					vs[name] = v[j]
+				}
+			}
+			if sel != nil && sel.eval(vs) == 0 {
+				continue
+			}
+			for k, col := range cols {
+				data[k] = append(data[k], col.eval(vs))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	kind := spec.kind
+	switch len(cols) {
+	case 1:
+		switch kind {
+		case "", "hist":
+		default:
+			return nil, fmt.Errorf("plot kind %q needs 2 columns", kind)
+		}
+		bins := spec.bins
+		switch len(bins) {
+		case 0:
+			min, max := autoRange(data[0])
+			bins = []float64{float64(it.nbins), min, max}
+		case 3:
+		default:
+			return nil, fmt.Errorf("invalid binning for a 1-dim histogram")
+		}
+		h := hbook.NewH1D(int(bins[0]), bins[1], bins[2])
+		for _, x := range data[0] {
+			h.Fill(x, 1)
+		}
+		return it.plotObject(h, "hist", i)
+
+	case 2:
+		switch kind {
+		case "h2d":
+			bins := spec.bins
+			switch len(bins) {
+			case 0:
+				xmin, xmax := autoRange(data[0])
+				ymin, ymax := autoRange(data[1])
+				bins = []float64{float64(it.nbins), xmin, xmax, float64(it.nbins), ymin, ymax}
+			case 6:
+			default:
+				return nil, fmt.Errorf("invalid binning for a 2-dim histogram")
+			}
+			h := hbook.NewH2D(int(bins[0]), bins[1], bins[2], int(bins[3]), bins[4], bins[5])
+			for j := range data[0] {
+				h.Fill(data[0][j], data[1][j], 1)
+			}
+			return it.plotObject(h, kind, i)
+		case "", "points", "lines", "linespoints":
+			if spec.bins != nil {
+				return nil, fmt.Errorf("binning is only supported for histograms")
+			}
+			if kind == "" {
+				kind = "points"
+			}
+			return it.plotObject(hbook.NewS2DFrom(data[0], data[1]), kind, i)
+		default:
+			return nil, fmt.Errorf("plot kind %q needs 1 column", kind)
+		}
+	}
+
+	return nil, fmt.Errorf("invalid number of columns (%d)", len(cols))
+}
+
+func (it *interp) plotObject(obj hbook.Object, kind string, i int) (plot.Plotter, error) {
+	col := plotutil.Color(i)
+	switch obj := obj.(type) {
+	case *hbook.H1D:
+		switch kind {
+		case "", "hist":
+		default:
+			return nil, fmt.Errorf("invalid plot kind %q for a 1-dim histogram", kind)
+		}
+		h := hplot.NewH1D(obj)
+		h.LineStyle.Color = col
+		h.FillColor = nil
+		return h, nil
+
+	case *hbook.H2D:
+		switch kind {
+		case "", "h2d":
+		default:
+			return nil, fmt.Errorf("invalid plot kind %q for a 2-dim histogram", kind)
+		}
+		return hplot.NewH2D(obj, nil), nil
+
+	case *hbook.P1D:
+		return it.plotObject(hbook.NewS2DFromP1D(obj), kind, i)
+
+	case *hbook.S2D:
+		var opts []hplot.Options
+		if obj.Len() > 0 {
+			if xlo, xhi := obj.XError(0); xlo != 0 || xhi != 0 {
+				opts = append(opts, hplot.WithXErrBars(true))
+			}
+			if ylo, yhi := obj.YError(0); ylo != 0 || yhi != 0 {
+				opts = append(opts, hplot.WithYErrBars(true))
+			}
+		}
+		s := hplot.NewS2D(obj, opts...)
+		s.GlyphStyle.Color = col
+		switch kind {
+		case "", "points":
+		case "lines":
+			s.GlyphStyle.Radius = 0
+			s.LineStyle = plotter.DefaultLineStyle
+			s.LineStyle.Color = col
+		case "linespoints":
+			s.LineStyle = plotter.DefaultLineStyle
+			s.LineStyle.Color = col
+		default:
+			return nil, fmt.Errorf("invalid plot kind %q for a scatter", kind)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("unsupported object type %T", obj)
+}
+
+// draw creates the plot from the current settings and plotters.
+func (it *interp) draw() *hplot.Plot {
+	p := hplot.New()
+	p.Title.Text = it.title
+	p.X.Label.Text = it.xlabel
+	p.Y.Label.Text = it.ylabel
+
+	if it.grid {
+		p.Add(hplot.NewGrid())
+	}
+
+	for _, item := range it.items {
+		if h, ok := item.p.(*hplot.H1D); ok {
+			h.LogY = it.logy
+		}
+		p.Add(item.p)
+		if item.title == "" {
+			continue
+		}
+		if th, ok := item.p.(plot.Thumbnailer); ok {
+			p.Legend.Add(item.title, th)
+		}
+	}
+	p.Legend.Top = true
+
+	if it.logy {
+		p.Y.Scale = plot.LogScale{}
+		p.Y.Tick.Marker = plot.LogTicks{}
+	}
+
+	if it.xrange.set {
+		if !math.IsNaN(it.xrange.min) {
+			p.X.Min = it.xrange.min
+		}
+		if !math.IsNaN(it.xrange.max) {
+			p.X.Max = it.xrange.max
+		}
+	}
+	if it.yrange.set {
+		if !math.IsNaN(it.yrange.min) {
+			p.Y.Min = it.yrange.min
+		}
+		if !math.IsNaN(it.yrange.max) {
+			p.Y.Max = it.yrange.max
+		}
+	}
+
+	return p
+}
+
+func (it *interp) save(fname string) error {
+	if len(it.items) == 0 {
+		return fmt.Errorf("nothing to save")
+	}
+	if fname == "" {
+		return fmt.Errorf("no output file")
+	}
+	p := it.draw()
+	err := hplot.Save(p, it.width, it.height, fname)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(it.msg, ":: saved plot to %q\n", fname)
+	it.dirty = false
+	return nil
+}
+
+// flush saves the current plot if it has not been saved yet.
+func (it *interp) flush() error {
+	if !it.dirty {
+		return nil
+	}
+	return it.save(it.output)
+}
+
+func isIndex(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func uniq(vs []string) []string {
+	var (
+		set = make(map[string]struct{}, len(vs))
+		out = vs[:0]
+	)
+	for _, v := range vs {
+		if _, dup := set[v]; dup {
+			continue
+		}
+		set[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// autoRange returns a range enclosing all the finite provided values.
+func autoRange(vs []float64) (min, max float64) {
+	min = +math.MaxFloat64
+	max = -math.MaxFloat64
+	for _, v := range vs {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+	}
+	if min > max {
+		return 0, 1
+	}
+	if min == max {
+		return min - 0.5, max + 0.5
+	}
+	min = math.Nextafter(min, min-1)
+	max = math.Nextafter(max, max+1)
+	return min, max
+}
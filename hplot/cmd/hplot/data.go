@@ -0,0 +1,473 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/groot/rhist"
+	"go-hep.org/x/hep/groot/riofs"
+	"go-hep.org/x/hep/groot/rtree"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hbook/rootcnv"
+	"go-hep.org/x/hep/hbook/yodacnv"
+	"go-hep.org/x/hep/rio"
+)
+
+// source describes a data source: a file name and a list of
+// specifiers (an object name, a tree and its branches, columns, ...)
+//
+// Sources are written as:
+//  'file.csv':x:y
+//  'file.yoda':/name
+//  'file.rio':name
+//  'file.root':dir/h1
+//  'file.root':tree:pt:eta
+type source struct {
+	fname string
+	specs []string
+}
+
+func (src source) String() string {
+	o := new(strings.Builder)
+	fmt.Fprintf(o, "'%s'", src.fname)
+	for _, spec := range src.specs {
+		fmt.Fprintf(o, ":%s", spec)
+	}
+	return o.String()
+}
+
+// table is a tabular data source made of named columns.
+type table interface {
+	// scan iterates over all the entries of the table and calls f with
+	// the values of the requested columns.
+	// Each column value may hold multiple elements (e.g. for
+	// variable-length branches of a ROOT tree.)
+	scan(cols []string, f func(vals [][]float64) error) error
+}
+
+// dataset is a loaded data source.
+// A dataset holds either an hbook object (histogram, scatter, ...) or
+// a table and the names of the columns to display.
+type dataset struct {
+	obj  hbook.Object
+	tbl  table
+	cols []string
+
+	close func() error
+}
+
+func (ds *dataset) Close() error {
+	if ds.close == nil {
+		return nil
+	}
+	err := ds.close()
+	ds.close = nil
+	return err
+}
+
+// load loads the data described by the provided source.
+// sep is the separator for the fields of text-based tabular data.
+func load(src source, sep string) (*dataset, error) {
+	fname := src.fname
+	ext := strings.ToLower(filepath.Ext(fname))
+	if ext == ".gz" {
+		ext = strings.ToLower(filepath.Ext(strings.TrimSuffix(fname, filepath.Ext(fname))))
+	}
+
+	switch {
+	case ext == ".root" || strings.HasPrefix(fname, "root://"):
+		return loadROOT(src)
+	case ext == ".yoda":
+		return loadYODA(src)
+	case ext == ".rio":
+		return loadRIO(src)
+	case ext == ".txt" || ext == ".dat":
+		if sep == "" {
+			sep = " "
+		}
+		return loadText(src, sep)
+	default:
+		if sep == "" {
+			sep = ","
+		}
+		return loadText(src, sep)
+	}
+}
+
+func loadROOT(src source) (*dataset, error) {
+	if len(src.specs) == 0 {
+		return nil, fmt.Errorf("missing object name for ROOT file %q", src.fname)
+	}
+
+	f, err := groot.Open(src.fname)
+	if err != nil {
+		return nil, fmt.Errorf("could not open ROOT file %q: %w", src.fname, err)
+	}
+
+	obj, err := riofs.Dir(f).Get(src.specs[0])
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("could not find object %q in ROOT file %q: %w", src.specs[0], src.fname, err)
+	}
+
+	ds := &dataset{close: f.Close}
+	switch obj := obj.(type) {
+	case rtree.Tree:
+		ds.tbl = &rtable{tree: obj}
+		ds.cols = src.specs[1:]
+		if len(ds.cols) == 0 {
+			_ = ds.Close()
+			return nil, fmt.Errorf("missing branch name for tree %q in ROOT file %q", src.specs[0], src.fname)
+		}
+	case rhist.H2:
+		ds.obj = rootcnv.H2D(obj)
+	case rhist.H1:
+		ds.obj = rootcnv.H1D(obj)
+	case rhist.Graph:
+		ds.obj = rootcnv.S2D(obj)
+	default:
+		_ = ds.Close()
+		return nil, fmt.Errorf("object %q in ROOT file %q has unsupported type %T", src.specs[0], src.fname, obj)
+	}
+
+	if ds.obj != nil {
+		// histograms and graphs have been converted to hbook values.
+		// no need to keep the file around.
+		err = ds.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not close ROOT file %q: %w", src.fname, err)
+		}
+	}
+
+	return ds, nil
+}
+
+func loadYODA(src source) (*dataset, error) {
+	f, err := os.Open(src.fname)
+	if err != nil {
+		return nil, fmt.Errorf("could not open YODA file %q: %w", src.fname, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if filepath.Ext(src.fname) == ".gz" {
+		rz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("could not open gzip YODA file %q: %w", src.fname, err)
+		}
+		defer rz.Close()
+		r = rz
+	}
+
+	objs, err := yodacnv.Read(r)
+	if err != nil {
+		return nil, fmt.Errorf("could not read YODA file %q: %w", src.fname, err)
+	}
+
+	switch len(src.specs) {
+	case 0:
+		if len(objs) != 1 {
+			return nil, fmt.Errorf("YODA file %q contains %d objects: need an object name", src.fname, len(objs))
+		}
+		return &dataset{obj: objs[0]}, nil
+	case 1:
+		name := strings.TrimPrefix(src.specs[0], "/")
+		for _, obj := range objs {
+			if strings.TrimPrefix(obj.Name(), "/") == name {
+				return &dataset{obj: obj}, nil
+			}
+		}
+		return nil, fmt.Errorf("could not find object %q in YODA file %q", src.specs[0], src.fname)
+	default:
+		return nil, fmt.Errorf("invalid YODA source %v", src)
+	}
+}
+
+func loadRIO(src source) (*dataset, error) {
+	if len(src.specs) != 1 {
+		return nil, fmt.Errorf("invalid rio source %v: need exactly one object name", src)
+	}
+
+	f, err := os.Open(src.fname)
+	if err != nil {
+		return nil, fmt.Errorf("could not open rio file %q: %w", src.fname, err)
+	}
+	defer f.Close()
+
+	r, err := rio.Open(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not open rio stream %q: %w", src.fname, err)
+	}
+	defer r.Close()
+
+	name := src.specs[0]
+	for _, key := range r.Keys() {
+		if key.Name != name {
+			continue
+		}
+		var obj hbook.Object
+		switch key.Blocks[0].Type {
+		case "*go-hep.org/x/hep/hbook.H1D":
+			obj = new(hbook.H1D)
+		case "*go-hep.org/x/hep/hbook.H2D":
+			obj = new(hbook.H2D)
+		case "*go-hep.org/x/hep/hbook.P1D":
+			obj = new(hbook.P1D)
+		case "*go-hep.org/x/hep/hbook.S2D":
+			obj = new(hbook.S2D)
+		default:
+			return nil, fmt.Errorf("object %q in rio file %q has unsupported type %q", name, src.fname, key.Blocks[0].Type)
+		}
+		err = r.Get(name, obj)
+		if err != nil {
+			return nil, fmt.Errorf("could not read object %q from rio file %q: %w", name, src.fname, err)
+		}
+		return &dataset{obj: obj}, nil
+	}
+
+	return nil, fmt.Errorf("could not find object %q in rio file %q", name, src.fname)
+}
+
+func loadText(src source, sep string) (*dataset, error) {
+	f, err := os.Open(src.fname)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file %q: %w", src.fname, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if filepath.Ext(src.fname) == ".gz" {
+		rz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("could not open gzip file %q: %w", src.fname, err)
+		}
+		defer rz.Close()
+		r = rz
+	}
+
+	var recs [][]string
+	switch sep {
+	case " ", "\t":
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			txt := strings.TrimSpace(sc.Text())
+			if txt == "" || strings.HasPrefix(txt, "#") {
+				continue
+			}
+			recs = append(recs, strings.Fields(txt))
+		}
+		err = sc.Err()
+	default:
+		if len([]rune(sep)) != 1 {
+			return nil, fmt.Errorf("invalid field separator %q", sep)
+		}
+		rr := csv.NewReader(r)
+		rr.Comma = []rune(sep)[0]
+		rr.Comment = '#'
+		rr.FieldsPerRecord = -1
+		rr.TrimLeadingSpace = true
+		recs, err = rr.ReadAll()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %q: %w", src.fname, err)
+	}
+
+	tbl, err := newCSVTable(recs)
+	if err != nil {
+		return nil, fmt.Errorf("could not load file %q: %w", src.fname, err)
+	}
+
+	cols := src.specs
+	if len(cols) == 0 {
+		cols = []string{"1"}
+	}
+
+	return &dataset{tbl: tbl, cols: cols}, nil
+}
+
+// csvTable is a table loaded from a text file.
+type csvTable struct {
+	hdr  []string
+	rows [][]float64
+}
+
+func newCSVTable(recs [][]string) (*csvTable, error) {
+	var tbl csvTable
+	if len(recs) == 0 {
+		return &tbl, nil
+	}
+
+	// a first record with non-numerical fields is a header.
+	for _, field := range recs[0] {
+		if _, err := strconv.ParseFloat(field, 64); err != nil {
+			tbl.hdr = recs[0]
+			recs = recs[1:]
+			break
+		}
+	}
+
+	tbl.rows = make([][]float64, len(recs))
+	for i, rec := range recs {
+		row := make([]float64, len(rec))
+		for j, field := range rec {
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse field %d of record %d: %w", j+1, i+1, err)
+			}
+			row[j] = v
+		}
+		tbl.rows[i] = row
+	}
+
+	return &tbl, nil
+}
+
+// index returns the index of the named column.
+// Columns can be referred to by name (from the header) or by their
+// 1-based index.
+func (tbl *csvTable) index(col string) (int, error) {
+	for i, name := range tbl.hdr {
+		if name == col {
+			return i, nil
+		}
+	}
+	i, err := strconv.Atoi(col)
+	if err != nil || i <= 0 {
+		return 0, fmt.Errorf("no such column %q", col)
+	}
+	return i - 1, nil
+}
+
+func (tbl *csvTable) scan(cols []string, f func(vals [][]float64) error) error {
+	idx := make([]int, len(cols))
+	for i, col := range cols {
+		j, err := tbl.index(col)
+		if err != nil {
+			return err
+		}
+		idx[i] = j
+	}
+
+	vals := make([][]float64, len(cols))
+	for irow, row := range tbl.rows {
+		for i, j := range idx {
+			if j >= len(row) {
+				return fmt.Errorf("record %d has no column %q", irow+1, cols[i])
+			}
+			vals[i] = row[j : j+1]
+		}
+		err := f(vals)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rtable is a table backed by a ROOT tree.
+type rtable struct {
+	tree rtree.Tree
+}
+
+func (tbl *rtable) scan(cols []string, f func(vals [][]float64) error) error {
+	var (
+		all   = rtree.NewReadVars(tbl.tree)
+		rvars = make([]rtree.ReadVar, 0, len(cols))
+		index = make([]int, len(cols))
+	)
+
+cols:
+	for i, col := range cols {
+		for j, rv := range rvars {
+			if rv.Name == col {
+				index[i] = j
+				continue cols
+			}
+		}
+		for _, rv := range all {
+			if rv.Name != col {
+				continue
+			}
+			if !isNumeric(reflect.TypeOf(rv.Value).Elem()) {
+				return fmt.Errorf("branch %q of tree %q is not numerical", col, tbl.tree.Name())
+			}
+			index[i] = len(rvars)
+			rvars = append(rvars, rv)
+			continue cols
+		}
+		return fmt.Errorf("tree %q has no branch %q", tbl.tree.Name(), col)
+	}
+
+	r, err := rtree.NewReader(tbl.tree, rvars)
+	if err != nil {
+		return fmt.Errorf("could not create reader for tree %q: %w", tbl.tree.Name(), err)
+	}
+	defer r.Close()
+
+	var (
+		vals = make([][]float64, len(cols))
+		bufs = make([][]float64, len(rvars))
+	)
+	err = r.Read(func(ctx rtree.RCtx) error {
+		for i, rv := range rvars {
+			bufs[i] = toFloats(bufs[i][:0], reflect.ValueOf(rv.Value).Elem())
+		}
+		for i, j := range index {
+			vals[i] = bufs[j]
+		}
+		return f(vals)
+	})
+	if err != nil {
+		return fmt.Errorf("could not read tree %q: %w", tbl.tree.Name(), err)
+	}
+
+	return r.Close()
+}
+
+func isNumeric(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Array, reflect.Slice:
+		return isNumeric(rt.Elem())
+	}
+	return false
+}
+
+func toFloats(dst []float64, rv reflect.Value) []float64 {
+	switch rv.Kind() {
+	case reflect.Bool:
+		v := 0.0
+		if rv.Bool() {
+			v = 1
+		}
+		return append(dst, v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return append(dst, float64(rv.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return append(dst, float64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return append(dst, rv.Float())
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < rv.Len(); i++ {
+			dst = toFloats(dst, rv.Index(i))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,59 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sort"
+
+	"go-hep.org/x/hep/groot/rtree/rfunc"
+)
+
+// expr is a compiled selection or value expression.
+//
+// Expressions are the string formulae of groot/rtree/rfunc (see
+// rfunc.NewExprFormula), evaluated on scalar values, e.g.:
+//  pt > 20 && abs(eta) < 2.4
+// Booleans are represented as 1 (true) or 0 (false).
+type expr struct {
+	src  string
+	vars []string // names of the variables needed by the expression
+	eval func(vs map[string]float64) float64
+}
+
+func newExpr(src string) (*expr, error) {
+	form, err := rfunc.NewExprFormula(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		names = form.RVars()
+		args  = make([]float64, len(names))
+		ptrs  = make([]interface{}, len(names))
+	)
+	for i := range args {
+		ptrs[i] = &args[i]
+	}
+	err = form.Bind(ptrs)
+	if err != nil {
+		return nil, err
+	}
+	fct := form.Func().(func() float64)
+
+	vars := make([]string, len(names))
+	copy(vars, names)
+	sort.Strings(vars)
+
+	return &expr{
+		src:  src,
+		vars: vars,
+		eval: func(vs map[string]float64) float64 {
+			for i, name := range names {
+				args[i] = vs[name]
+			}
+			return fct()
+		},
+	}, nil
+}
@@ -2,68 +2,167 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// hplot is a simple gnuplot-like command to create plots
-package main
+// hplot is a simple gnuplot-like command to create plots.
+//
+// hplot reads commands from the scripts given as arguments (or from
+// standard input if no script was given) and creates plots out of data
+// stored in CSV, YODA, rio or ROOT files.
+//
+// Usage: hplot [options] [script1.hplot [script2.hplot [...]]]
+//
+// ex:
+//  $> hplot -e "plot 'data.csv':x"
+//  $> hplot -o out.pdf -e "plot 'f.root':tree:pt if eta<2.5 bins 50 0 200"
+//  $> hplot ./script.hplot
+//  $> echo "plot 'f.yoda':/h1" | hplot -o out.svg
+//
+// Commands:
+//
+//  plot SOURCE [if EXPR] [bins N MIN MAX [N MIN MAX]] [with KIND] [title STR] [, SOURCE ...]
+//        creates a new plot from one or more data sources.
+//        'with' and 'title' may be abbreviated as 'w' and 't', except after a selection.
+//  replot SOURCE [...]
+//        adds data sources to the current plot.
+//  set OPTION [VALUE]
+//        sets an option of the current plot.
+//  unset OPTION
+//        unsets an option of the current plot.
+//  save [FILE]
+//        saves the current plot to FILE (or the current output file.)
+//  reset
+//        resets all the options to their default values.
+//  quit
+//        exits hplot.
+//
+// Commands can be separated by semicolons.
+// A line ending with a backslash is continued on the next line.
+// Everything after a '#' is a comment.
+//
+// Data sources:
+//
+//  'file.csv'               first column of a CSV file
+//  'file.csv':x:y           columns x and y of a CSV file, by name or 1-based index
+//  'file.txt':1:3           columns 1 and 3 of a whitespace separated file
+//  'file.yoda':/name        histogram or scatter from a YODA file
+//  'file.rio':name          histogram or scatter from a rio file
+//  'file.root':dir/name     histogram or graph from a ROOT file
+//  'file.root':tree:x       branch x of a ROOT tree
+//  'file.root':tree:x:y     branches x and y of a ROOT tree
+//
+// Columns and branches can also be expressions, e.g. 'f.root':tree:sqrt(px*px+py*py).
+// Selections and columns are groot/rtree/rfunc string formulae, e.g.:
+//  pt > 20 && abs(eta) < 2.4
+// Expressions on variable-length branches are evaluated element-wise.
+//
+// Plot kinds:
+//
+//  hist         1-dim histogram (default for 1 column)
+//  h2d          2-dim histogram
+//  points       scatter plot (default for 2 columns)
+//  lines        lines
+//  linespoints  lines and points
+//
+// Options:
+//
+//  title "STR"                  plot title
+//  xlabel "STR", ylabel "STR"   axes labels
+//  xrange [MIN:MAX]             x-axis range (either bound may be omitted)
+//  yrange [MIN:MAX]             y-axis range (either bound may be omitted)
+//  logy                         log-scale for the y-axis
+//  grid                         display a grid (default)
+//  bins N                       default number of bins (default: 100)
+//  size W,H                     canvas size, in centimeters
+//  output "FILE"                output file: .eps, .jpg, .jpeg, .pdf, .png, .svg, .tex, .tif or .tiff
+//  datafile separator "C"       field separator for text files ("comma", "whitespace", ...)
+//
+// The current plot, if any, is saved to the current output file once all
+// commands have been executed, unless it has already been saved.
+package main // import "go-hep.org/x/hep/hplot/cmd/hplot"
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"log"
 	"os"
-
-	"go-hep.org/x/hep/hplot"
-	"gonum.org/v1/plot/plotter"
-	"gonum.org/v1/plot/vg"
+	"strings"
 )
 
-//var (
-//	persist = flag.Bool("p", false, "lets plot windows survive after hplot exists")
-//	cmdlist = flag.String("e", "", "executes the requested commands before loading the next input file")
-//)
-
 func main() {
-	fmt.Printf(":: welcome to hplot\n")
-	flag.Parse()
+	log.SetPrefix("hplot: ")
+	log.SetFlags(0)
+
+	var (
+		cmds   = flag.String("e", "", "executes the requested commands before loading the scripts")
+		output = flag.String("o", "out.png", "path to output file")
+	)
 
-	if flag.NArg() < 1 {
-		fmt.Fprintf(os.Stderr, "**error** you need to give an input data file\n")
-		os.Exit(1)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: hplot [options] [script1.hplot [script2.hplot [...]]]
+
+ex:
+ $> hplot -e "plot 'data.csv':x"
+ $> hplot -o out.pdf -e "plot 'f.root':tree:pt if eta<2.5 bins 50 0 200"
+ $> hplot ./script.hplot
+ $> echo "plot 'f.yoda':/h1" | hplot -o out.svg
+
+options:
+`)
+		flag.PrintDefaults()
 	}
 
-	f, err := os.Open(flag.Args()[0])
+	flag.Parse()
+
+	err := process(os.Stdout, os.Stdin, *output, *cmds, flag.Args())
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "**error** opening file [%s]: %v\n", flag.Args()[0], err)
-		os.Exit(1)
+		log.Fatalf("%+v", err)
 	}
-	defer f.Close()
+}
 
-	p := hplot.New()
+func process(msg io.Writer, stdin io.Reader, output, cmds string, scripts []string) error {
+	it := newInterp(msg, output)
 
-	values := make(plotter.Values, 0, 10)
-	for err == nil {
-		var v float64
-		_, err = fmt.Fscanf(f, "%f\n", &v)
-		if err == io.EOF {
-			break
+	run := func() error {
+		if cmds != "" {
+			err := it.run(strings.NewReader(cmds))
+			if err != nil {
+				return fmt.Errorf("could not execute commands: %w", err)
+			}
 		}
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "** error ** %v\n", err)
-			os.Exit(1)
+
+		for _, fname := range scripts {
+			err := func() error {
+				f, err := os.Open(fname)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				return it.run(f)
+			}()
+			if err != nil {
+				return fmt.Errorf("could not execute script %q: %w", fname, err)
+			}
 		}
 
-		values = append(values, v)
+		if cmds == "" && len(scripts) == 0 {
+			err := it.run(stdin)
+			if err != nil {
+				return fmt.Errorf("could not execute commands: %w", err)
+			}
+		}
+		return nil
 	}
 
-	// create histogram from data
-	h := hplot.NewH1FromValuer(values, 16)
-	p.Add(h)
-	p.Add(hplot.NewGrid())
+	err := run()
+	if err != nil && !errors.Is(err, errQuit) {
+		return err
+	}
 
-	// Save the plot to a PDF file.
-	if err := p.Save(-1, 10*vg.Centimeter, "hist.pdf"); err != nil {
-		fmt.Fprintf(os.Stderr, "**error** saving plot: %v\n", err)
-		os.Exit(1)
+	err = it.flush()
+	if err != nil {
+		return fmt.Errorf("could not save plot: %w", err)
 	}
 
-	fmt.Printf(":: bye.\n")
+	return nil
 }
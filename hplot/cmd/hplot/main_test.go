@@ -0,0 +1,421 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-hep.org/x/hep/groot"
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hbook/rootcnv"
+	"go-hep.org/x/hep/rio"
+)
+
+func TestLex(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want []lexeme
+		err  string
+	}{
+		{
+			line: `set title "a title"; plot 'f.csv':x`,
+			want: []lexeme{
+				{kind: tokWord, text: "set"},
+				{kind: tokWord, text: "title"},
+				{kind: tokString, text: "a title"},
+				{kind: tokSemi, text: ";"},
+				{kind: tokWord, text: "plot"},
+				{
+					kind: tokSource, text: "'f.csv':x",
+					src: source{fname: "f.csv", specs: []string{"x"}},
+				},
+			},
+		},
+		{
+			line: `plot 'f.root':t:pt if pow(eta, 2)<4, "f.csv" # comment`,
+			want: []lexeme{
+				{kind: tokWord, text: "plot"},
+				{
+					kind: tokSource, text: "'f.root':t:pt",
+					src: source{fname: "f.root", specs: []string{"t", "pt"}},
+				},
+				{kind: tokWord, text: "if"},
+				{kind: tokWord, text: "pow(eta,"},
+				{kind: tokWord, text: "2)<4"},
+				{kind: tokComma, text: ","},
+				{kind: tokString, text: "f.csv"},
+			},
+		},
+		{
+			line: `plot 'f.root':t:pt if pow(eta, 2<4`,
+			err:  `unbalanced parentheses in "plot 'f.root':t:pt if pow(eta, 2<4"`,
+		},
+		{
+			line: `plot 'f.root':t:sqrt(pt)) if eta<4`,
+			err:  `unbalanced parentheses in "plot 'f.root':t:sqrt(pt)) if eta<4"`,
+		},
+	} {
+		t.Run(tc.line, func(t *testing.T) {
+			got, err := lex(tc.line)
+			switch {
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+				}
+				return
+			case err != nil && tc.err == "":
+				t.Fatalf("could not lex line: %+v", err)
+			case err == nil && tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid tokens:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParsePlot(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want []plotSpec
+		err  string
+	}{
+		{
+			line: `plot 'f.root':tree:pt if eta<2.5 && pt > 20 bins 50 0 200 title "pt"`,
+			want: []plotSpec{{
+				src:   source{fname: "f.root", specs: []string{"tree", "pt"}},
+				sel:   "eta<2.5 && pt > 20",
+				bins:  []float64{50, 0, 200},
+				title: "pt",
+			}},
+		},
+		{
+			line: `plot 'f.csv':x:y with lines, 'f.csv':x:z w p`,
+			want: []plotSpec{
+				{
+					src:  source{fname: "f.csv", specs: []string{"x", "y"}},
+					kind: "lines",
+				},
+				{
+					src:  source{fname: "f.csv", specs: []string{"x", "z"}},
+					kind: "points",
+				},
+			},
+		},
+		{
+			line: `plot 'f.root':tree:pt if t > 2 && w < 3 with p title "pt"`,
+			want: []plotSpec{{
+				src:   source{fname: "f.root", specs: []string{"tree", "pt"}},
+				sel:   "t > 2 && w < 3",
+				kind:  "points",
+				title: "pt",
+			}},
+		},
+		{
+			line: `plot 'f.csv':x bins 10 0`,
+			err:  "invalid plot command: binning expects 'N min max' or 'NX XMIN XMAX NY YMIN YMAX'",
+		},
+		{
+			line: `plot 'f.csv':x bins 10 2 1`,
+			err:  "invalid plot command: invalid bin range [2, 1]",
+		},
+		{
+			line: `plot 'f.csv':x with boxes`,
+			err:  `invalid plot command: unknown plot kind "boxes"`,
+		},
+		{
+			line: `plot if x>2`,
+			err:  `invalid plot command: expected a data source, got "if"`,
+		},
+	} {
+		t.Run(tc.line, func(t *testing.T) {
+			toks, err := lex(tc.line)
+			if err != nil {
+				t.Fatalf("could not lex line: %+v", err)
+			}
+			got, err := parsePlot(toks[1:])
+			switch {
+			case err != nil && tc.err != "":
+				if got, want := err.Error(), tc.err; got != want {
+					t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+				}
+				return
+			case err != nil && tc.err == "":
+				t.Fatalf("could not parse plot command: %+v", err)
+			case err == nil && tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid plot specs:\ngot= %#v\nwant=%#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpr(t *testing.T) {
+	vs := map[string]float64{"pt": 25, "eta": -1.5, "n": 3}
+	for _, tc := range []struct {
+		expr string
+		vars []string
+		want float64
+	}{
+		{expr: "pt", vars: []string{"pt"}, want: 25},
+		{expr: "pt > 20 && abs(eta) < 2.4", vars: []string{"eta", "pt"}, want: 1},
+		{expr: "pt > 20 && !(abs(eta) < 2.4)", vars: []string{"eta", "pt"}, want: 0},
+		{expr: "pt < 20 || n == 3", vars: []string{"n", "pt"}, want: 1},
+		{expr: "sqrt(pow(n, 2)) + 2*pt - n%2", vars: []string{"n", "pt"}, want: 52},
+		{expr: "TMath::Max(pt, 10*n)", vars: []string{"n", "pt"}, want: 30},
+		{expr: "-eta", vars: []string{"eta"}, want: 1.5},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := newExpr(tc.expr)
+			if err != nil {
+				t.Fatalf("could not compile expression: %+v", err)
+			}
+			if !reflect.DeepEqual(e.vars, tc.vars) {
+				t.Fatalf("invalid vars: got=%v, want=%v", e.vars, tc.vars)
+			}
+			if got, want := e.eval(vs), tc.want; got != want {
+				t.Fatalf("invalid value: got=%v, want=%v", got, want)
+			}
+		})
+	}
+
+	for _, tc := range []string{"pt >", "foo(pt)", "abs(pt, eta)", `"str"`, "x[0]"} {
+		t.Run(tc, func(t *testing.T) {
+			_, err := newExpr(tc)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "hplot-")
+	if err != nil {
+		t.Fatalf("could not create tmp dir: %+v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	h1 := hbook.NewH1D(20, -4, 4)
+	h1.Annotation()["name"] = "h1"
+	h2 := hbook.NewH2D(10, -4, 4, 10, -4, 4)
+	h2.Annotation()["name"] = "h2"
+	for i := 0; i < 100; i++ {
+		v := float64(i)/25 - 2
+		h1.Fill(v, 1)
+		h2.Fill(v, -v, 1)
+	}
+
+	{
+		fname := filepath.Join(tmp, "data.csv")
+		err := ioutil.WriteFile(fname, []byte("# data\nx,y\n1,2\n2,4\n3,8\n4,16\n"), 0644)
+		if err != nil {
+			t.Fatalf("could not create CSV file: %+v", err)
+		}
+	}
+
+	{
+		fname := filepath.Join(tmp, "data.txt")
+		err := ioutil.WriteFile(fname, []byte("1 2\n2 4\n3 8\n"), 0644)
+		if err != nil {
+			t.Fatalf("could not create text file: %+v", err)
+		}
+	}
+
+	{
+		fname := filepath.Join(tmp, "hists.yoda")
+		o := new(bytes.Buffer)
+		for _, h := range []interface{ MarshalYODA() ([]byte, error) }{h1, h2} {
+			raw, err := h.MarshalYODA()
+			if err != nil {
+				t.Fatalf("could not marshal to YODA: %+v", err)
+			}
+			o.Write(raw)
+		}
+		err := ioutil.WriteFile(fname, o.Bytes(), 0644)
+		if err != nil {
+			t.Fatalf("could not create YODA file: %+v", err)
+		}
+	}
+
+	{
+		fname := filepath.Join(tmp, "hists.rio")
+		f, err := os.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create rio file: %+v", err)
+		}
+		defer f.Close()
+		w, err := rio.NewWriter(f)
+		if err != nil {
+			t.Fatalf("could not create rio writer: %+v", err)
+		}
+		err = w.WriteValue("h1", h1)
+		if err != nil {
+			t.Fatalf("could not write h1: %+v", err)
+		}
+		err = w.Close()
+		if err != nil {
+			t.Fatalf("could not close rio writer: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close rio file: %+v", err)
+		}
+	}
+
+	{
+		fname := filepath.Join(tmp, "hists.root")
+		f, err := groot.Create(fname)
+		if err != nil {
+			t.Fatalf("could not create ROOT file: %+v", err)
+		}
+		defer f.Close()
+		err = f.Put("h1", rootcnv.FromH1D(h1))
+		if err != nil {
+			t.Fatalf("could not write h1: %+v", err)
+		}
+		err = f.Put("h2", rootcnv.FromH2D(h2))
+		if err != nil {
+			t.Fatalf("could not write h2: %+v", err)
+		}
+		err = f.Close()
+		if err != nil {
+			t.Fatalf("could not close ROOT file: %+v", err)
+		}
+	}
+
+	const tree = "../../../groot/testdata/small-flat-tree.root"
+
+	for _, tc := range []struct {
+		name string
+		cmds string
+		err  string
+	}{
+		{
+			name: "csv",
+			cmds: `set title "CSV"; plot '$TMP/data.csv':x:y w lp title "y", '$TMP/data.csv':1:2*y if x>1`,
+		},
+		{
+			name: "csv-hist",
+			cmds: `set logy; plot '$TMP/data.csv':y bins 4 0 20`,
+		},
+		{
+			name: "txt",
+			cmds: `plot '$TMP/data.txt':1:2 with lines`,
+		},
+		{
+			name: "yoda",
+			cmds: `plot '$TMP/hists.yoda':/h1; replot '$TMP/hists.yoda':/h1`,
+		},
+		{
+			name: "yoda-h2",
+			cmds: `plot '$TMP/hists.yoda':h2`,
+		},
+		{
+			name: "rio",
+			cmds: `set xrange [-2:*]; plot '$TMP/hists.rio':h1`,
+		},
+		{
+			name: "root-h1",
+			cmds: `plot '$TMP/hists.root':h1 title "h1"`,
+		},
+		{
+			name: "root-h2",
+			cmds: `plot '$TMP/hists.root':h2 with h2d`,
+		},
+		{
+			name: "root-tree",
+			cmds: `plot '` + tree + `':tree:Float64 if Int32%2 == 0 && Float64 < 50 bins 10 0 50`,
+		},
+		{
+			name: "root-tree-slice",
+			cmds: `plot '` + tree + `':tree:SliceFloat64:sqrt(SliceFloat64) if N > 2`,
+		},
+		{
+			name: "root-tree-h2d",
+			cmds: `plot '` + tree + `':tree:Float64:ArrayFloat64 with h2d`,
+		},
+		{
+			name: "root-tree-no-branch",
+			cmds: `plot '` + tree + `':tree:NoBranch`,
+			err:  `could not execute commands: line 1: could not plot '` + tree + `':tree:NoBranch: tree "tree" has no branch "NoBranch"`,
+		},
+		{
+			name: "root-tree-str",
+			cmds: `plot '` + tree + `':tree:Str`,
+			err:  `could not execute commands: line 1: could not plot '` + tree + `':tree:Str: branch "Str" of tree "tree" is not numerical`,
+		},
+		{
+			name: "root-h1-selection",
+			cmds: `plot '$TMP/hists.root':h1 if x > 2`,
+			err:  `could not execute commands: line 1: could not plot '$TMP/hists.root':h1: selection and binning are only supported for tabular data`,
+		},
+		{
+			name: "unknown-command",
+			cmds: `splot '$TMP/data.csv':x`,
+			err:  `could not execute commands: line 1: unknown command "splot"`,
+		},
+		{
+			name: "quit",
+			cmds: "plot '$TMP/data.csv':x\nquit\nfoo",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				msg   = new(bytes.Buffer)
+				oname = filepath.Join(tmp, tc.name+".png")
+				cmds  = strings.Replace(tc.cmds, "$TMP", tmp, -1)
+			)
+
+			err := process(msg, nil, oname, cmds, nil)
+			switch {
+			case err != nil && tc.err != "":
+				want := strings.Replace(tc.err, "$TMP", tmp, -1)
+				if got := err.Error(); got != want {
+					t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+				}
+				return
+			case err != nil && tc.err == "":
+				t.Fatalf("could not process commands: %+v", err)
+			case err == nil && tc.err != "":
+				t.Fatalf("expected an error (%s)", tc.err)
+			}
+
+			fi, err := os.Stat(oname)
+			if err != nil {
+				t.Fatalf("could not stat output file: %+v", err)
+			}
+			if fi.Size() == 0 {
+				t.Fatalf("empty output file")
+			}
+		})
+	}
+}
+
+func TestAutoRange(t *testing.T) {
+	for _, tc := range []struct {
+		vs       []float64
+		min, max float64
+	}{
+		{vs: nil, min: 0, max: 1},
+		{vs: []float64{2, 2}, min: 1.5, max: 2.5},
+		{vs: []float64{math.NaN(), 1, math.Inf(+1), 3}, min: math.Nextafter(1, 0), max: math.Nextafter(3, 4)},
+	} {
+		min, max := autoRange(tc.vs)
+		if min != tc.min || max != tc.max {
+			t.Fatalf("invalid range for %v: got=[%v, %v], want=[%v, %v]", tc.vs, min, max, tc.min, tc.max)
+		}
+	}
+}
@@ -2,143 +2,104 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package main
+// iplot is a demo of the hplot/hview interactive viewer.
+//
+// iplot displays two histograms, filled in the background, and refreshes
+// the display periodically.
+// The plot can be zoomed, panned and displayed with log-scale axes.
+//
+// Usage: iplot [options]
+//
+// ex:
+//  $> iplot
+//  $> iplot -http :8080
+//
+// options:
+//  -http string
+//    	address on which to serve the plot (e.g. ":8080")
+//  -refresh duration
+//    	refresh period (default 500ms)
+package main // import "go-hep.org/x/hep/hplot/cmd/iplot"
 
 import (
-	"image"
+	"flag"
 	"image/color"
 	"log"
 	"math/rand"
+	"net/http"
+	"sync"
+	"time"
 
-	"go-hep.org/x/exp/vgshiny"
 	"go-hep.org/x/hep/hbook"
 	"go-hep.org/x/hep/hplot"
-	"golang.org/x/exp/shiny/driver"
-	"golang.org/x/exp/shiny/screen"
-	"golang.org/x/mobile/event/key"
-	"golang.org/x/mobile/event/paint"
+	"go-hep.org/x/hep/hplot/hview"
 	"gonum.org/v1/plot/plotter"
-	"gonum.org/v1/plot/vg"
-	"gonum.org/v1/plot/vg/draw"
 )
 
-const (
-	NPOINTS = 100000
-	xmax    = 400
-	ymax    = 400
-)
-
-func newPlot() *hplot.Plot {
-	// Draw some random values from the standard
-	// normal distribution.
-	hist1 := hbook.NewH1D(100, -5, +5)
-	hist2 := hbook.NewH1D(100, -5, +5)
-	for i := 0; i < NPOINTS; i++ {
-		v1 := rand.NormFloat64() - 1
-		v2 := rand.NormFloat64() + 1
-		hist1.Fill(v1, 1)
-		hist2.Fill(v2, 1)
-	}
-
-	// Make a plot and set its title.
-	p := hplot.New()
-	p.Title.Text = "Histogram"
-	p.X.Label.Text = "X"
-	p.Y.Label.Text = "Y"
-
-	// Create a histogram of our values drawn
-	// from the standard normal.
-	h1 := hplot.NewH1D(hist1)
-	h1.Infos.Style = hplot.HInfoSummary
-	h1.Color = color.Black
-	h1.FillColor = nil
-
-	h2 := hplot.NewH1D(hist2)
-	h2.Infos.Style = hplot.HInfoNone
-	h2.Color = color.RGBA{255, 0, 0, 255}
-	h2.FillColor = nil
-
-	p.Add(h1, h2)
-
-	p.Add(plotter.NewGrid())
-	return p
-}
-
 func main() {
-	driver.Main(func(scr screen.Screen) {
-		{
-			p := newPlot()
-
-			w, h := hplot.Dims(-1, -1)
-			c, err := vgshiny.New(scr, w, h)
-			if err != nil {
-				log.Fatalf("could not create shiny canvas: %+v", err)
+	log.SetPrefix("iplot: ")
+	log.SetFlags(0)
+
+	var (
+		addr    = flag.String("http", "", "address on which to serve the plot (e.g. \":8080\")")
+		refresh = flag.Duration("refresh", 500*time.Millisecond, "refresh period")
+	)
+
+	flag.Parse()
+
+	var (
+		mu    sync.Mutex
+		hist1 = hbook.NewH1D(100, -5, +5)
+		hist2 = hbook.NewH1D(100, -5, +5)
+	)
+
+	// fill the histograms in the background, as a running job would.
+	go func() {
+		for {
+			mu.Lock()
+			for i := 0; i < 100; i++ {
+				hist1.Fill(rand.NormFloat64()-1, 1)
+				hist2.Fill(rand.NormFloat64()+1, 1)
 			}
-			p.Draw(draw.New(c))
-			c.Paint()
-
-			go func() {
-				c.Run(nil)
-				c.Release()
-			}()
-		}
-		w, err := newWidget(scr, image.Point{xmax, ymax})
-		if err != nil {
-			log.Fatal(err)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
 		}
-		defer w.Release()
-
-		w.canvas.Run(func(e interface{}) bool {
-			switch e := e.(type) {
-			case key.Event:
-				repaint := false
-				switch e.Code {
-				case key.CodeEscape, key.CodeQ:
-					if e.Direction == key.DirPress {
-						return false
-					}
-				case key.CodeR:
-					if e.Direction == key.DirPress {
-						repaint = true
-					}
-
-				case key.CodeN, key.CodeSpacebar:
-					if e.Direction == key.DirPress {
-						p := newPlot()
-						p.Draw(draw.New(w.canvas))
-						repaint = true
-					}
-				}
-				if repaint {
-					w.canvas.Send(paint.Event{})
-				}
-
-			case paint.Event:
-				w.canvas.Paint()
-			}
-			return true
-		})
-	})
-}
+	}()
+
+	newPlot := func() (hplot.Drawer, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		p := hplot.New()
+		p.Title.Text = "Histogram"
+		p.X.Label.Text = "X"
+		p.Y.Label.Text = "Y"
+
+		h1 := hplot.NewH1D(hist1.Clone())
+		h1.Infos.Style = hplot.HInfoSummary
+		h1.Color = color.Black
+		h1.FillColor = nil
+
+		h2 := hplot.NewH1D(hist2.Clone())
+		h2.Infos.Style = hplot.HInfoNone
+		h2.Color = color.RGBA{255, 0, 0, 255}
+		h2.FillColor = nil
+
+		p.Add(h1, h2)
+		p.Add(plotter.NewGrid())
+		return p, nil
+	}
 
-type widget struct {
-	s      screen.Screen
-	canvas *vgshiny.Canvas
-}
+	p, _ := newPlot()
+	v := hview.New(p, hview.WithRefresh(*refresh, newPlot))
 
-func newWidget(s screen.Screen, size image.Point) (*widget, error) {
-	c, err := vgshiny.New(s, vg.Length(size.X), vg.Length(size.Y))
-	if err != nil {
-		return nil, err
+	if *addr != "" {
+		log.Printf("serving plot on %q...", *addr)
+		log.Fatal(http.ListenAndServe(*addr, hview.Handler(v)))
 	}
 
-	return &widget{s: s, canvas: c}, err
-}
-
-func (w *widget) Release() {
-	if w.canvas != nil {
-		w.canvas.Release()
-		w.canvas = nil
+	err := hview.Run(v)
+	if err != nil {
+		log.Fatalf("%+v", err)
 	}
-	w.s = nil
 }
@@ -0,0 +1,76 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// An example of making box plots from raw samples.
+func ExampleBoxPlot() {
+	const npoints = 1000
+
+	rnd := rand.New(rand.NewSource(1234))
+
+	var dists []hplot.Distribution
+	for i := 0; i < 4; i++ {
+		norm := distuv.Normal{
+			Mu:    float64(i),
+			Sigma: 1 + 0.5*float64(i),
+			Src:   rnd,
+		}
+		vs := make([]float64, npoints)
+		for j := range vs {
+			vs[j] = norm.Rand()
+		}
+		dists = append(dists, hplot.NewDistribution(float64(i+1), vs, nil))
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Box plots"
+	p.X.Label.Text = "sample"
+	p.Y.Label.Text = "y"
+
+	p.Add(hplot.NewBoxPlot(dists))
+	p.Add(plotter.NewGrid())
+
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/boxplot.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// An example of making candle plots from the x-slices of a 2-dim histogram.
+func ExampleNewCandle() {
+	const npoints = 10000
+
+	rnd := rand.New(rand.NewSource(1234))
+	h := hbook.NewH2D(10, 0, 10, 50, -10, 10)
+	for i := 0; i < npoints; i++ {
+		x := 10 * rnd.Float64()
+		y := rnd.NormFloat64() * (0.5 + 0.3*x)
+		h.Fill(x, y, 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Candle plots"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "resolution"
+
+	p.Add(hplot.NewCandle(hplot.NewDistributionsFromH2D(h)))
+	p.Add(plotter.NewGrid())
+
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/candle.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
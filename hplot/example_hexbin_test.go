@@ -0,0 +1,54 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"log"
+
+	"go-hep.org/x/hep/hplot"
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distmv"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// An example of making a hexagonal binning plot of a large point cloud.
+func ExampleHexBin() {
+	const npoints = 100000
+
+	dist, ok := distmv.NewNormal(
+		[]float64{0, 1},
+		mat.NewSymDense(2, []float64{4, 1.5, 1.5, 2}),
+		rand.New(rand.NewSource(1234)),
+	)
+	if !ok {
+		log.Fatalf("error creating distmv.Normal")
+	}
+
+	// points are accumulated in the hexagonal bins as they are
+	// generated: they do not need to be kept in memory.
+	hb := hplot.NewHexBin(30, -8, 8, -6, 8, nil)
+	hb.Log = true
+
+	v := make([]float64, 2)
+	for i := 0; i < npoints; i++ {
+		v = dist.Rand(v)
+		hb.Fill(v[0], v[1], 1)
+	}
+
+	p := hplot.New()
+	p.Title.Text = "Hexagonal binning"
+	p.X.Label.Text = "x"
+	p.Y.Label.Text = "y"
+
+	p.Add(hb)
+	p.Add(plotter.NewGrid())
+
+	err := p.Save(10*vg.Centimeter, 10*vg.Centimeter, "testdata/hexbin.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"math"
+
+	"go-hep.org/x/hep/hbook"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette"
+	"gonum.org/v1/plot/palette/brewer"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// HexBin implements the plot.Plotter interface, drawing the density of
+// a 2-dim scatter of points as a grid of hexagonal bins.
+//
+// Points are accumulated in the bins as they are filled, so arbitrarily
+// large point clouds can be displayed with a memory footprint bounded
+// by the number of bins.
+type HexBin struct {
+	// Palette is the color palette used to render the bins contents.
+	Palette palette.Palette
+
+	// Min and Max define the range of bins contents mapped to the
+	// palette.
+	// NaN values are replaced with the minimum and maximum contents of
+	// the non-empty bins.
+	Min, Max float64
+
+	// Log controls whether the bins contents are mapped to the palette
+	// with a logarithmic scale.
+	Log bool
+
+	// LineStyle is the style of the outline of the bins.
+	// Use a zero width to disable the outline.
+	LineStyle draw.LineStyle
+
+	nx, ny int
+	xmin   float64
+	xmax   float64
+	ymin   float64
+	ymax   float64
+	sx, sy float64 // sizes of the hexagonal grid cells.
+
+	// bins holds the contents of the two interleaved lattices of
+	// hexagons: (nx+1)*(ny+1) bins centered on the grid nodes, followed
+	// by nx*ny bins centered on the grid cells.
+	bins []float64
+}
+
+// NewHexBin returns a new hexagonal binning of the [xmin,xmax]x[ymin,ymax]
+// area, with nx hexagons along the x-axis.
+// The number of hexagons along the y-axis is chosen to make regular
+// hexagons in a square plot.
+// A nil palette selects a default palette.
+func NewHexBin(nx int, xmin, xmax, ymin, ymax float64, p palette.Palette) *HexBin {
+	if nx < 1 {
+		nx = 1
+	}
+	ny := int(float64(nx) / math.Sqrt(3))
+	if ny < 1 {
+		ny = 1
+	}
+	if p == nil {
+		p, _ = brewer.GetPalette(brewer.TypeAny, "RdYlBu", 11)
+	}
+	return &HexBin{
+		Palette: p,
+		Min:     math.NaN(),
+		Max:     math.NaN(),
+		nx:      nx,
+		ny:      ny,
+		xmin:    xmin,
+		xmax:    xmax,
+		ymin:    ymin,
+		ymax:    ymax,
+		sx:      (xmax - xmin) / float64(nx),
+		sy:      (ymax - ymin) / float64(ny),
+		bins:    make([]float64, (nx+1)*(ny+1)+nx*ny),
+	}
+}
+
+// NewHexBinFromXYer returns a new hexagonal binning of the provided
+// points, with nx hexagons along the x-axis.
+// A nil palette selects a default palette.
+func NewHexBinFromXYer(data plotter.XYer, nx int, p palette.Palette) *HexBin {
+	xmin, xmax, ymin, ymax := plotter.XYRange(data)
+	if xmin == xmax {
+		xmin, xmax = xmin-0.5, xmax+0.5
+	}
+	if ymin == ymax {
+		ymin, ymax = ymin-0.5, ymax+0.5
+	}
+	hb := NewHexBin(nx, xmin, xmax, ymin, ymax, p)
+	for i := 0; i < data.Len(); i++ {
+		x, y := data.XY(i)
+		hb.Fill(x, y, 1)
+	}
+	return hb
+}
+
+// NewHexBinFromH2D returns a new hexagonal binning of the provided 2-dim
+// histogram, with nx hexagons along the x-axis.
+// The content of each bin of the histogram is filled at the bin center.
+// A nil palette selects a default palette.
+func NewHexBinFromH2D(h *hbook.H2D, nx int, p palette.Palette) *HexBin {
+	hb := NewHexBin(nx, h.XMin(), h.XMax(), h.YMin(), h.YMax(), p)
+	for i := range h.Binning.Bins {
+		bin := &h.Binning.Bins[i]
+		if bin.Entries() == 0 {
+			continue
+		}
+		x, y := bin.XYMid()
+		hb.Fill(x, y, bin.SumW())
+	}
+	return hb
+}
+
+// Fill fills the hexagonal bin containing (x,y) with weight w.
+// Points outside the binned area are discarded.
+func (hb *HexBin) Fill(x, y, w float64) {
+	if !(hb.xmin <= x && x <= hb.xmax && hb.ymin <= y && y <= hb.ymax) {
+		return
+	}
+	var (
+		u = (x - hb.xmin) / hb.sx
+		v = (y - hb.ymin) / hb.sy
+
+		ix1 = math.Round(u)
+		iy1 = math.Round(v)
+		ix2 = math.Floor(u)
+		iy2 = math.Floor(v)
+
+		d1 = (u-ix1)*(u-ix1) + 3*(v-iy1)*(v-iy1)
+		d2 = (u-ix2-0.5)*(u-ix2-0.5) + 3*(v-iy2-0.5)*(v-iy2-0.5)
+	)
+
+	if d1 < d2 || int(ix2) == hb.nx || int(iy2) == hb.ny {
+		hb.bins[int(ix1)*(hb.ny+1)+int(iy1)] += w
+		return
+	}
+	hb.bins[(hb.nx+1)*(hb.ny+1)+int(ix2)*hb.ny+int(iy2)] += w
+}
+
+// center returns the center of the i-th hexagonal bin.
+func (hb *HexBin) center(i int) (x, y float64) {
+	n1 := (hb.nx + 1) * (hb.ny + 1)
+	if i < n1 {
+		ix, iy := i/(hb.ny+1), i%(hb.ny+1)
+		return hb.xmin + float64(ix)*hb.sx, hb.ymin + float64(iy)*hb.sy
+	}
+	i -= n1
+	ix, iy := i/hb.ny, i%hb.ny
+	return hb.xmin + (float64(ix)+0.5)*hb.sx, hb.ymin + (float64(iy)+0.5)*hb.sy
+}
+
+// hexagon holds the vertices of a hexagonal bin, relative to its center,
+// in units of the grid cell sizes.
+var hexagon = [6][2]float64{
+	{+0.5, -1.0 / 6}, {+0.5, +1.0 / 6}, {0, +1.0 / 3},
+	{-0.5, +1.0 / 6}, {-0.5, -1.0 / 6}, {0, -1.0 / 3},
+}
+
+// Plot implements the Plotter interface, drawing the non-empty hexagonal
+// bins.
+func (hb *HexBin) Plot(c draw.Canvas, plt *plot.Plot) {
+	colors := hb.Palette.Colors()
+	if len(colors) == 0 {
+		return
+	}
+
+	vmin, vmax := hb.Min, hb.Max
+	if math.IsNaN(vmin) || math.IsNaN(vmax) {
+		lo, hi := math.Inf(+1), math.Inf(-1)
+		for _, v := range hb.bins {
+			if v == 0 {
+				continue
+			}
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+		if math.IsNaN(vmin) {
+			vmin = lo
+		}
+		if math.IsNaN(vmax) {
+			vmax = hi
+		}
+	}
+	norm := func(v float64) float64 { return v }
+	if hb.Log {
+		norm = func(v float64) float64 {
+			if v <= 0 {
+				return math.NaN()
+			}
+			return math.Log(v)
+		}
+	}
+	lo, hi := norm(vmin), norm(vmax)
+
+	trX, trY := plt.Transforms(&c)
+	pts := make([]vg.Point, len(hexagon))
+	for i, v := range hb.bins {
+		if v == 0 {
+			continue
+		}
+		nv := norm(v)
+		if math.IsNaN(nv) {
+			continue
+		}
+		idx := 0
+		if hi > lo {
+			f := (nv - lo) / (hi - lo)
+			f = math.Max(0, math.Min(1, f))
+			idx = int(f*float64(len(colors)-1) + 0.5)
+		}
+		x, y := hb.center(i)
+		for j, vtx := range hexagon {
+			pts[j] = vg.Point{
+				X: trX(x + vtx[0]*hb.sx),
+				Y: trY(y + vtx[1]*hb.sy),
+			}
+		}
+		c.FillPolygon(colors[idx], c.ClipPolygonXY(pts))
+		if hb.LineStyle.Width > 0 {
+			c.StrokeLines(hb.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+		}
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values, implementing
+// the plot.DataRanger interface.
+func (hb *HexBin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return hb.xmin, hb.xmax, hb.ymin, hb.ymax
+}
+
+var (
+	_ plot.Plotter    = (*HexBin)(nil)
+	_ plot.DataRanger = (*HexBin)(nil)
+)
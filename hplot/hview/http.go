@@ -0,0 +1,219 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hview
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-hep.org/x/hep/hplot"
+)
+
+// Handler returns an HTTP handler serving the view as an SVG image,
+// embedded in an HTML page handling user interactions.
+//
+// The handler serves the following endpoints:
+//  - /: the HTML page displaying the view,
+//  - /plot.svg: the SVG image of the view,
+//  - /cmd: the commands modifying the view.
+//
+// Commands are sent as POST requests with the name of the command as the
+// "op" form value.
+// Positions are given as fractions of the displayed image size,
+// (0,0) being the top-left corner of the image.
+//  - op=zoom&x1=...&y1=...&x2=...&y2=...: zoom on a rectangle,
+//  - op=zoomat&x=...&y=...&f=...: zoom around a point by a factor f,
+//  - op=pan&x1=...&y1=...&x2=...&y2=...: pan from (x1,y1) to (x2,y2),
+//  - op=unzoom: undo the last zoom,
+//  - op=reset: reset the axes ranges,
+//  - op=logx, op=logy: toggle the log-scale of an axis,
+//  - op=refresh: refresh the view.
+//
+// The view is refreshed when the SVG image is requested and the refresh
+// period of the view has elapsed.
+func Handler(v *View) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err := pageTmpl.Execute(w, struct {
+			Refresh int64
+		}{
+			Refresh: int64(v.Period() / time.Millisecond),
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/plot.svg", func(w http.ResponseWriter, r *http.Request) {
+		err := v.refreshIfDue(time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		wt, err := hplot.WriterTo(v, v.w, v.h, "svg")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		w.Header().Set("Cache-Control", "no-cache")
+		_, _ = wt.WriteTo(w)
+	})
+	mux.HandleFunc("/cmd", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "hview: invalid method "+r.Method, http.StatusMethodNotAllowed)
+			return
+		}
+		err := handleCmd(v, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+func handleCmd(v *View, r *http.Request) error {
+	err := r.ParseForm()
+	if err != nil {
+		return fmt.Errorf("hview: could not parse command: %w", err)
+	}
+
+	floats := func(names ...string) ([]float64, error) {
+		vs := make([]float64, len(names))
+		for i, name := range names {
+			v, err := strconv.ParseFloat(r.Form.Get(name), 64)
+			if err != nil {
+				return nil, fmt.Errorf("hview: invalid %q value: %w", name, err)
+			}
+			vs[i] = v
+		}
+		return vs, nil
+	}
+
+	switch op := r.Form.Get("op"); op {
+	case "zoom", "pan":
+		vs, err := floats("x1", "y1", "x2", "y2")
+		if err != nil {
+			return err
+		}
+		p1, p2 := v.Point(vs[0], vs[1]), v.Point(vs[2], vs[3])
+		switch op {
+		case "zoom":
+			v.Zoom(p1, p2)
+		case "pan":
+			v.Pan(p1, p2)
+		}
+	case "zoomat":
+		vs, err := floats("x", "y", "f")
+		if err != nil {
+			return err
+		}
+		v.ZoomAt(v.Point(vs[0], vs[1]), vs[2])
+	case "unzoom":
+		v.Unzoom()
+	case "reset":
+		v.Reset()
+	case "logx":
+		v.ToggleLogX()
+	case "logy":
+		v.ToggleLogY()
+	case "refresh":
+		return v.Refresh()
+	default:
+		return fmt.Errorf("hview: unknown command %q", op)
+	}
+	return nil
+}
+
+var pageTmpl = template.Must(template.New("hview").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>hview</title>
+<style>
+body { font-family: sans-serif; }
+#plot { border: 1px solid #ccc; cursor: crosshair; user-select: none; }
+</style>
+</head>
+<body>
+<div>
+<button onclick="cmd({op: 'unzoom'})">Unzoom</button>
+<button onclick="cmd({op: 'reset'})">Reset</button>
+<button onclick="cmd({op: 'logx'})">Log X</button>
+<button onclick="cmd({op: 'logy'})">Log Y</button>
+<button onclick="cmd({op: 'refresh'})">Refresh</button>
+</div>
+<img id="plot" src="plot.svg" draggable="false">
+<script>
+var img = document.getElementById("plot");
+var beg = null;
+
+function reload() {
+	img.src = "plot.svg?t=" + Date.now();
+}
+
+function cmd(args) {
+	var form = new URLSearchParams();
+	for (var k in args) {
+		form.append(k, args[k]);
+	}
+	fetch("cmd", {method: "POST", body: form}).then(function(resp) {
+		if (!resp.ok) {
+			resp.text().then(function(txt) { console.log(txt); });
+		}
+		reload();
+	});
+}
+
+function pos(e) {
+	var r = img.getBoundingClientRect();
+	return {x: (e.clientX - r.left) / r.width, y: (e.clientY - r.top) / r.height};
+}
+
+img.addEventListener("contextmenu", function(e) { e.preventDefault(); });
+img.addEventListener("mousedown", function(e) {
+	beg = {p: pos(e), btn: e.button, shift: e.shiftKey};
+	e.preventDefault();
+});
+img.addEventListener("mouseup", function(e) {
+	if (beg === null) {
+		return;
+	}
+	var end = pos(e);
+	var op = (beg.btn === 0 && !beg.shift) ? "zoom" : "pan";
+	cmd({op: op, x1: beg.p.x, y1: beg.p.y, x2: end.x, y2: end.y});
+	beg = null;
+});
+img.addEventListener("wheel", function(e) {
+	var p = pos(e);
+	cmd({op: "zoomat", x: p.x, y: p.y, f: e.deltaY < 0 ? 0.8 : 1.25});
+	e.preventDefault();
+});
+document.addEventListener("keydown", function(e) {
+	switch (e.key) {
+	case "l": cmd({op: "logy"}); break;
+	case "k": cmd({op: "logx"}); break;
+	case "u": case "Backspace": cmd({op: "unzoom"}); break;
+	case "r": case "Home": cmd({op: "reset"}); break;
+	case "f": cmd({op: "refresh"}); break;
+	}
+});
+
+if ({{.Refresh}} > 0) {
+	setInterval(reload, {{.Refresh}});
+}
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,535 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hview provides an interactive viewer for hplot drawers.
+//
+// A View wraps any hplot.Drawer and keeps track of the interactive state
+// of the display: zoomed ranges, log-scale axes and the periodic refresh
+// of the displayed data.
+// Zooming, panning and log-scale axes are only available for drawers that
+// are (or wrap, via hplot.Fig) an *hplot.Plot.
+//
+// A View can be displayed in a window on a desktop (see Run and Show) or
+// served over HTTP as an SVG image (see Handler) for machines without a
+// display.
+package hview // import "go-hep.org/x/hep/hplot/hview"
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sync"
+	"time"
+
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// View is an interactive view of a hplot.Drawer.
+//
+// View is safe for concurrent use.
+type View struct {
+	mu sync.Mutex
+
+	p    hplot.Drawer
+	w, h vg.Length
+	lock sync.Locker // lock held while reading the data of the drawer
+
+	period  time.Duration
+	refresh func() (hplot.Drawer, error)
+	last    time.Time // time of the last refresh
+
+	home  ranges   // ranges of the plot, as provided by the user
+	auto  autos    // home ranges following the data of the plot
+	zooms []ranges // stack of zoomed ranges
+	cur   ranges   // ranges used for the last drawing
+	area  vg.Rectangle
+
+	logx, logy bool
+	xscale     plot.Normalizer
+	xticks     plot.Ticker
+	yscale     plot.Normalizer
+	yticks     plot.Ticker
+}
+
+type ranges struct {
+	xmin, xmax float64
+	ymin, ymax float64
+}
+
+// autos describes which bounds of the home ranges of a view follow the
+// data of the plot.
+type autos struct {
+	xmin, xmax bool
+	ymin, ymax bool
+}
+
+// Option configures a View.
+type Option func(v *View)
+
+// WithSize sets the size of the view.
+// If w or h are <= 0, the value is chosen such that it follows the Golden Ratio.
+func WithSize(w, h vg.Length) Option {
+	return func(v *View) {
+		v.w, v.h = hplot.Dims(w, h)
+	}
+}
+
+// WithRefresh sets the function called periodically by the view to
+// refresh its content.
+//
+// If f returns a non-nil drawer, it replaces the currently displayed one.
+// Otherwise, the current drawer is redrawn, after the ranges of its axes
+// that follow the data (i.e. that were not modified by the user) have been
+// recomputed, which is enough to display histograms being filled in the
+// background.
+// As the view reads the displayed data after f has returned, data filled
+// concurrently must either be returned by f as a snapshot (e.g. a new
+// drawer with cloned histograms) or be protected by the lock given to
+// WithLocker.
+func WithRefresh(period time.Duration, f func() (hplot.Drawer, error)) Option {
+	return func(v *View) {
+		v.period = period
+		v.refresh = f
+	}
+}
+
+// WithLocker sets the lock held by the view while it reads the data of the
+// displayed drawer, i.e. while drawing it or computing its data ranges.
+// Users filling the displayed data concurrently should hold the same lock
+// while filling.
+//
+// The lock is not held while the refresh function is called.
+func WithLocker(l sync.Locker) Option {
+	return func(v *View) {
+		v.lock = l
+	}
+}
+
+// New creates a new interactive view of the provided drawer.
+func New(p hplot.Drawer, opts ...Option) *View {
+	v := &View{lock: nolock{}}
+	v.w, v.h = hplot.Dims(-1, -1)
+	for _, opt := range opts {
+		opt(v)
+	}
+	v.set(p)
+	return v
+}
+
+func (v *View) set(p hplot.Drawer) {
+	v.p = p
+	pl := plotOf(p)
+	if pl == nil {
+		return
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.home = ranges{
+		xmin: pl.X.Min, xmax: pl.X.Max,
+		ymin: pl.Y.Min, ymax: pl.Y.Max,
+	}
+	data := dataRanges(pl)
+	v.auto = autos{
+		xmin: v.home.xmin == data.xmin, xmax: v.home.xmax == data.xmax,
+		ymin: v.home.ymin == data.ymin, ymax: v.home.ymax == data.ymax,
+	}
+	v.xscale, v.xticks = pl.X.Scale, pl.X.Tick.Marker
+	v.yscale, v.yticks = pl.Y.Scale, pl.Y.Tick.Marker
+	switch pl.X.Scale.(type) {
+	case plot.LogScale, logScale:
+		v.logx = true
+	}
+	switch pl.Y.Scale.(type) {
+	case plot.LogScale, logScale:
+		v.logy = true
+	}
+}
+
+// rehome updates the bounds of the home ranges of the view that follow
+// the data of the plot.
+func (v *View) rehome() {
+	pl := plotOf(v.p)
+	if pl == nil {
+		return
+	}
+	v.lock.Lock()
+	data := dataRanges(pl)
+	v.lock.Unlock()
+
+	if v.auto.xmin {
+		v.home.xmin = data.xmin
+	}
+	if v.auto.xmax {
+		v.home.xmax = data.xmax
+	}
+	if v.auto.ymin {
+		v.home.ymin = data.ymin
+	}
+	if v.auto.ymax {
+		v.home.ymax = data.ymax
+	}
+}
+
+// dataRanges returns the current ranges of the data of the provided plot.
+func dataRanges(pl *hplot.Plot) ranges {
+	xmin, xmax, ymin, ymax := pl.DataRange()
+	return ranges{xmin: xmin, xmax: xmax, ymin: ymin, ymax: ymax}
+}
+
+// plotOf returns the hplot.Plot underlying the provided drawer, if any.
+func plotOf(p hplot.Drawer) *hplot.Plot {
+	switch p := p.(type) {
+	case *hplot.Plot:
+		return p
+	case *hplot.Fig:
+		return plotOf(p.Plot)
+	}
+	return nil
+}
+
+// dataArea returns the area of the canvas where the data of the plot
+// underlying the provided drawer is drawn.
+func dataArea(p hplot.Drawer, c draw.Canvas) (vg.Rectangle, bool) {
+	switch p := p.(type) {
+	case *hplot.Plot:
+		return p.Plot.DataCanvas(c).Rectangle, true
+	case *hplot.Fig:
+		c = draw.Crop(c,
+			p.Border.Left, -p.Border.Right,
+			p.Border.Bottom, -p.Border.Top,
+		)
+		return dataArea(p.Plot, c)
+	}
+	return vg.Rectangle{}, false
+}
+
+// Size returns the size of the view.
+func (v *View) Size() (w, h vg.Length) {
+	return v.w, v.h
+}
+
+// Drawer returns the currently displayed drawer.
+func (v *View) Drawer() hplot.Drawer {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.p
+}
+
+// Draw draws the view to the provided canvas, implementing the hplot.Drawer
+// interface.
+func (v *View) Draw(c draw.Canvas) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	c.FillPolygon(color.White, []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+	})
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	pl := plotOf(v.p)
+	if pl == nil {
+		v.p.Draw(c)
+		return
+	}
+
+	v.cur = v.ranges()
+	pl.X.Min, pl.X.Max = v.cur.xmin, v.cur.xmax
+	pl.Y.Min, pl.Y.Max = v.cur.ymin, v.cur.ymax
+
+	pl.X.Scale, pl.X.Tick.Marker = v.xscale, v.xticks
+	if v.logx {
+		pl.X.Scale, pl.X.Tick.Marker = logScale{}, plot.LogTicks{}
+	}
+	pl.Y.Scale, pl.Y.Tick.Marker = v.yscale, v.yticks
+	if v.logy {
+		pl.Y.Scale, pl.Y.Tick.Marker = logScale{}, plot.LogTicks{}
+	}
+
+	v.p.Draw(c)
+	v.area, _ = dataArea(v.p, c)
+}
+
+// ranges returns the current ranges of the view, making sure they are
+// valid for log-scale axes.
+func (v *View) ranges() ranges {
+	r := v.home
+	if n := len(v.zooms); n > 0 {
+		r = v.zooms[n-1]
+	}
+	if v.logx {
+		r.xmin, r.xmax = logRange(r.xmin, r.xmax)
+	}
+	if v.logy {
+		r.ymin, r.ymax = logRange(r.ymin, r.ymax)
+	}
+	return r
+}
+
+func logRange(min, max float64) (float64, float64) {
+	if max <= 0 {
+		max = 1
+	}
+	if min <= 0 {
+		min = max * 1e-3
+	}
+	return min, max
+}
+
+// Ranges returns the current ranges of the view axes.
+func (v *View) Ranges() (xmin, xmax, ymin, ymax float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	r := v.ranges()
+	return r.xmin, r.xmax, r.ymin, r.ymax
+}
+
+// Point returns the point on the view canvas corresponding to the provided
+// fractional position (fx,fy) on the displayed image.
+// (0,0) is the top-left corner of the image and (1,1) its bottom-right corner.
+func (v *View) Point(fx, fy float64) vg.Point {
+	return vg.Point{
+		X: vg.Length(fx) * v.w,
+		Y: vg.Length(1-fy) * v.h,
+	}
+}
+
+// dataXY returns the data coordinates corresponding to the provided point
+// on the canvas.
+func (v *View) dataXY(pt vg.Point) (x, y float64) {
+	var (
+		tx = float64((pt.X - v.area.Min.X) / (v.area.Max.X - v.area.Min.X))
+		ty = float64((pt.Y - v.area.Min.Y) / (v.area.Max.Y - v.area.Min.Y))
+	)
+	x = unnorm(v.cur.xmin, v.cur.xmax, tx, v.logx)
+	y = unnorm(v.cur.ymin, v.cur.ymax, ty, v.logy)
+	return x, y
+}
+
+// unnorm is the inverse of the Normalize method of linear and log scales.
+func unnorm(min, max, t float64, log bool) float64 {
+	if log {
+		lmin := math.Log(min)
+		return math.Exp(lmin + t*(math.Log(max)-lmin))
+	}
+	return min + t*(max-min)
+}
+
+// norm is the Normalize method of linear and log scales.
+func norm(min, max, x float64, log bool) float64 {
+	if log {
+		return logScale{}.Normalize(min, max, x)
+	}
+	return plot.LinearScale{}.Normalize(min, max, x)
+}
+
+// logScale is a log scale that does not panic on non-positive values.
+// Non-positive values are displayed at the lower edge of the axis.
+type logScale struct{}
+
+func (logScale) Normalize(min, max, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return plot.LogScale{}.Normalize(min, max, x)
+}
+
+func (v *View) interactive() bool {
+	return plotOf(v.p) != nil && v.area.Size().X > 0 && v.area.Size().Y > 0
+}
+
+// Zoom zooms on the rectangle defined by the two provided points on the
+// view canvas.
+// Zoom is a no-op if the view can not be zoomed or if the rectangle is
+// degenerate.
+func (v *View) Zoom(p1, p2 vg.Point) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.interactive() {
+		return
+	}
+
+	// minSize is the minimal size of a zoom rectangle, to prevent
+	// zooming on simple clicks.
+	const minSize = 2 * vg.Millimeter
+	if dx, dy := p1.X-p2.X, p1.Y-p2.Y; -minSize < dx && dx < minSize || -minSize < dy && dy < minSize {
+		return
+	}
+
+	x1, y1 := v.dataXY(p1)
+	x2, y2 := v.dataXY(p2)
+	if x1 == x2 || y1 == y2 {
+		return
+	}
+	v.zooms = append(v.zooms, ranges{
+		xmin: math.Min(x1, x2), xmax: math.Max(x1, x2),
+		ymin: math.Min(y1, y2), ymax: math.Max(y1, y2),
+	})
+}
+
+// ZoomAt zooms in (factor < 1) or out (factor > 1) around the provided
+// point on the view canvas.
+func (v *View) ZoomAt(pt vg.Point, factor float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.interactive() || factor <= 0 {
+		return
+	}
+
+	x, y := v.dataXY(pt)
+	scale := func(min, max, v float64, log bool) (float64, float64) {
+		t := norm(min, max, v, log)
+		return unnorm(min, max, t-t*factor, log), unnorm(min, max, t+(1-t)*factor, log)
+	}
+
+	r := v.cur
+	r.xmin, r.xmax = scale(r.xmin, r.xmax, x, v.logx)
+	r.ymin, r.ymax = scale(r.ymin, r.ymax, y, v.logy)
+	v.zooms = append(v.zooms, r)
+}
+
+// Pan translates the view ranges such that the data displayed at the
+// point from is displayed at the point to.
+func (v *View) Pan(from, to vg.Point) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if !v.interactive() {
+		return
+	}
+
+	var (
+		dx = float64((to.X - from.X) / (v.area.Max.X - v.area.Min.X))
+		dy = float64((to.Y - from.Y) / (v.area.Max.Y - v.area.Min.Y))
+		r  = v.cur
+	)
+	r.xmin, r.xmax = unnorm(r.xmin, r.xmax, -dx, v.logx), unnorm(r.xmin, r.xmax, 1-dx, v.logx)
+	r.ymin, r.ymax = unnorm(r.ymin, r.ymax, -dy, v.logy), unnorm(r.ymin, r.ymax, 1-dy, v.logy)
+
+	switch n := len(v.zooms); n {
+	case 0:
+		v.zooms = append(v.zooms, r)
+	default:
+		v.zooms[n-1] = r
+	}
+}
+
+// Unzoom restores the ranges that were displayed before the last zoom.
+func (v *View) Unzoom() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if n := len(v.zooms); n > 0 {
+		v.zooms = v.zooms[:n-1]
+	}
+}
+
+// Reset restores the original ranges of the view, updated with the
+// current ranges of the displayed data.
+func (v *View) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.zooms = v.zooms[:0]
+	v.rehome()
+}
+
+// LogX returns whether the x-axis is displayed with a log-scale.
+func (v *View) LogX() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.logx
+}
+
+// LogY returns whether the y-axis is displayed with a log-scale.
+func (v *View) LogY() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.logy
+}
+
+// ToggleLogX toggles the log-scale of the x-axis.
+func (v *View) ToggleLogX() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if plotOf(v.p) == nil {
+		return
+	}
+	v.logx = !v.logx
+}
+
+// ToggleLogY toggles the log-scale of the y-axis.
+func (v *View) ToggleLogY() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if plotOf(v.p) == nil {
+		return
+	}
+	v.logy = !v.logy
+}
+
+// Period returns the refresh period of the view.
+// A zero period means no periodic refresh.
+func (v *View) Period() time.Duration {
+	if v.refresh == nil {
+		return 0
+	}
+	return v.period
+}
+
+// Refresh calls the refresh function of the view, if any.
+func (v *View) Refresh() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.doRefresh(time.Now())
+}
+
+// refreshIfDue refreshes the view if its refresh period has elapsed.
+func (v *View) refreshIfDue(now time.Time) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.refresh == nil || now.Sub(v.last) < v.period {
+		return nil
+	}
+	return v.doRefresh(now)
+}
+
+func (v *View) doRefresh(now time.Time) error {
+	v.last = now
+	if v.refresh == nil {
+		return nil
+	}
+	p, err := v.refresh()
+	if err != nil {
+		return fmt.Errorf("hview: could not refresh view: %w", err)
+	}
+	switch {
+	case p != nil && p != v.p:
+		v.set(p)
+	default:
+		v.rehome()
+	}
+	return nil
+}
+
+// nolock is a no-op sync.Locker.
+type nolock struct{}
+
+func (nolock) Lock()   {}
+func (nolock) Unlock() {}
+
+var (
+	_ hplot.Drawer = (*View)(nil)
+	_ sync.Locker  = nolock{}
+)
@@ -0,0 +1,350 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hview
+
+import (
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-hep.org/x/hep/hbook"
+	"go-hep.org/x/hep/hplot"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+func newPlot() (*hplot.Plot, *hbook.H1D) {
+	h := hbook.NewH1D(10, 0, 10)
+	for i := 0; i < 10; i++ {
+		h.Fill(float64(i)+0.5, float64(i))
+	}
+	p := hplot.New()
+	p.Add(hplot.NewH1D(h))
+	p.X.Min = 0
+	p.X.Max = 10
+	p.Y.Min = 0
+	p.Y.Max = 10
+	return p, h
+}
+
+func render(v *View) {
+	w, h := v.Size()
+	v.Draw(draw.New(vgimg.New(w, h)))
+}
+
+// at returns the canvas point at the fractional position (tx,ty) of the
+// data area of the view.
+func at(v *View, tx, ty float64) vg.Point {
+	return vg.Point{
+		X: v.area.Min.X + vg.Length(tx)*(v.area.Max.X-v.area.Min.X),
+		Y: v.area.Min.Y + vg.Length(ty)*(v.area.Max.Y-v.area.Min.Y),
+	}
+}
+
+func cmpRanges(t *testing.T, v *View, want [4]float64) {
+	t.Helper()
+	xmin, xmax, ymin, ymax := v.Ranges()
+	got := [4]float64{xmin, xmax, ymin, ymax}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("invalid ranges:\ngot= %v\nwant=%v", got, want)
+		}
+	}
+}
+
+func TestViewZoomPan(t *testing.T) {
+	p, _ := newPlot()
+	v := New(p, WithSize(10*vg.Centimeter, 8*vg.Centimeter))
+	render(v)
+
+	cmpRanges(t, v, [4]float64{0, 10, 0, 10})
+
+	v.Zoom(at(v, 0.2, 0.1), at(v, 0.6, 0.5))
+	render(v)
+	cmpRanges(t, v, [4]float64{2, 6, 1, 5})
+
+	// simple clicks do not zoom.
+	v.Zoom(at(v, 0.2, 0.1), at(v, 0.2, 0.1))
+	render(v)
+	cmpRanges(t, v, [4]float64{2, 6, 1, 5})
+
+	v.Pan(at(v, 0.5, 0.5), at(v, 0.25, 0.75))
+	render(v)
+	cmpRanges(t, v, [4]float64{3, 7, 0, 4})
+
+	v.ZoomAt(at(v, 0.5, 0.5), 0.5)
+	render(v)
+	cmpRanges(t, v, [4]float64{4, 6, 1, 3})
+
+	v.Unzoom()
+	render(v)
+	cmpRanges(t, v, [4]float64{3, 7, 0, 4})
+
+	v.Reset()
+	render(v)
+	cmpRanges(t, v, [4]float64{0, 10, 0, 10})
+}
+
+func TestViewLog(t *testing.T) {
+	p, _ := newPlot()
+	v := New(p)
+	render(v)
+
+	v.ToggleLogY()
+	if !v.LogY() {
+		t.Fatalf("expected a log-scale y-axis")
+	}
+	render(v) // empty bins should not panic.
+	cmpRanges(t, v, [4]float64{0, 10, 0.01, 10})
+
+	v.Zoom(at(v, 0, 0.5), at(v, 1, 1))
+	render(v)
+	cmpRanges(t, v, [4]float64{0, 10, math.Sqrt(0.1), 10})
+
+	v.ToggleLogY()
+	render(v)
+	if v.LogY() {
+		t.Fatalf("expected a linear y-axis")
+	}
+	if _, ok := p.Y.Scale.(logScale); ok {
+		t.Fatalf("expected a linear y-axis scale")
+	}
+
+	v.ToggleLogX()
+	render(v)
+	if !v.LogX() {
+		t.Fatalf("expected a log-scale x-axis")
+	}
+}
+
+func TestViewRefresh(t *testing.T) {
+	p, h := newPlot()
+
+	var (
+		n    = 0
+		next *hplot.Plot
+	)
+	v := New(p, WithRefresh(time.Hour, func() (hplot.Drawer, error) {
+		n++
+		h.Fill(5, 1)
+		if next != nil {
+			return next, nil
+		}
+		return nil, nil
+	}))
+	render(v)
+
+	if got, want := v.Period(), time.Hour; got != want {
+		t.Fatalf("invalid period: got=%v, want=%v", got, want)
+	}
+
+	now := time.Now()
+	err := v.refreshIfDue(now)
+	if err != nil {
+		t.Fatalf("could not refresh: %+v", err)
+	}
+	err = v.refreshIfDue(now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("could not refresh: %+v", err)
+	}
+	if n != 1 {
+		t.Fatalf("invalid number of refreshes: got=%d, want=1", n)
+	}
+	if v.Drawer() != p {
+		t.Fatalf("invalid drawer")
+	}
+
+	next, _ = newPlot()
+	next.X.Max = 20
+	err = v.Refresh()
+	if err != nil {
+		t.Fatalf("could not refresh: %+v", err)
+	}
+	if v.Drawer() != next {
+		t.Fatalf("drawer was not replaced")
+	}
+	render(v)
+	cmpRanges(t, v, [4]float64{0, 20, 0, 10})
+	if got, want := h.Entries(), int64(12); got != want {
+		t.Fatalf("invalid entries: got=%d, want=%d", got, want)
+	}
+}
+
+func TestViewRefreshFill(t *testing.T) {
+	h := hbook.NewH1D(10, 0, 10)
+	h.Fill(5, 1)
+	p := hplot.New()
+	p.Add(hplot.NewH1D(h))
+	p.X.Min = -5 // fixed by the user.
+
+	v := New(p, WithRefresh(time.Hour, func() (hplot.Drawer, error) {
+		h.Fill(2, 3)
+		return nil, nil
+	}))
+	render(v)
+	cmpRanges(t, v, [4]float64{-5, 10, 0, 1})
+
+	err := v.Refresh()
+	if err != nil {
+		t.Fatalf("could not refresh: %+v", err)
+	}
+	render(v)
+	cmpRanges(t, v, [4]float64{-5, 10, 0, 3})
+
+	v.Zoom(at(v, 0.2, 0.1), at(v, 0.6, 0.5))
+	render(v)
+	h.Fill(7, 5)
+	v.Reset()
+	render(v)
+	cmpRanges(t, v, [4]float64{-5, 10, 0, 5})
+}
+
+func TestViewRefreshConcurrentFill(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		h    = hbook.NewH1D(10, 0, 10)
+		done = make(chan int)
+	)
+	h.Fill(5, 1)
+	p := hplot.New()
+	p.Add(hplot.NewH1D(h))
+
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			mu.Lock()
+			h.Fill(float64(i%10)+0.5, 1)
+			mu.Unlock()
+		}
+	}()
+
+	v := New(p, WithLocker(&mu), WithRefresh(time.Hour, func() (hplot.Drawer, error) {
+		return nil, nil
+	}))
+	for i := 0; i < 10; i++ {
+		err := v.Refresh()
+		if err != nil {
+			t.Fatalf("could not refresh: %+v", err)
+		}
+		render(v)
+		v.Reset()
+	}
+	<-done
+
+	err := v.Refresh()
+	if err != nil {
+		t.Fatalf("could not refresh: %+v", err)
+	}
+	render(v)
+	cmpRanges(t, v, [4]float64{0, 10, 100, 101})
+}
+
+func TestViewNoPlot(t *testing.T) {
+	tp := hplot.NewTiledPlot(draw.Tiles{Cols: 2, Rows: 1})
+	v := New(tp)
+	render(v)
+
+	v.Zoom(vg.Point{X: 0, Y: 0}, vg.Point{X: 10 * vg.Centimeter, Y: 10 * vg.Centimeter})
+	v.ToggleLogY()
+	if v.LogY() {
+		t.Fatalf("tiled plots can not be displayed with a log-scale")
+	}
+	render(v)
+}
+
+func TestHandler(t *testing.T) {
+	p, _ := newPlot()
+	v := New(p, WithSize(10*vg.Centimeter, 10*vg.Centimeter))
+	srv := httptest.NewServer(Handler(v))
+	defer srv.Close()
+
+	get := func(path, ctype string) string {
+		t.Helper()
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("could not get %q: %+v", path, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("invalid status code for %q: %v", path, resp.Status)
+		}
+		if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, ctype) {
+			t.Fatalf("invalid content-type for %q: got=%q, want=%q", path, got, ctype)
+		}
+		raw, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("could not read response body: %+v", err)
+		}
+		return string(raw)
+	}
+
+	post := func(form url.Values, code int) {
+		t.Helper()
+		resp, err := http.PostForm(srv.URL+"/cmd", form)
+		if err != nil {
+			t.Fatalf("could not post command: %+v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != code {
+			t.Fatalf("invalid status code: got=%v, want=%v", resp.StatusCode, code)
+		}
+	}
+
+	if page := get("/", "text/html"); !strings.Contains(page, "plot.svg") {
+		t.Fatalf("invalid HTML page:\n%s", page)
+	}
+
+	if svg := get("/plot.svg", "image/svg+xml"); !strings.Contains(svg, "<svg") {
+		t.Fatalf("invalid SVG image")
+	}
+
+	var (
+		w, h   = v.Size()
+		p1, p2 = at(v, 0.2, 0.1), at(v, 0.6, 0.5)
+		frac   = func(p vg.Point) (string, string) {
+			return fmtFloat(float64(p.X / w)), fmtFloat(float64(1 - p.Y/h))
+		}
+		x1, y1 = frac(p1)
+		x2, y2 = frac(p2)
+	)
+	post(url.Values{"op": {"zoom"}, "x1": {x1}, "y1": {y1}, "x2": {x2}, "y2": {y2}}, http.StatusOK)
+	_ = get("/plot.svg", "image/svg+xml")
+	cmpRanges(t, v, [4]float64{2, 6, 1, 5})
+
+	post(url.Values{"op": {"logy"}}, http.StatusOK)
+	if !v.LogY() {
+		t.Fatalf("expected a log-scale y-axis")
+	}
+	_ = get("/plot.svg", "image/svg+xml")
+
+	post(url.Values{"op": {"reset"}}, http.StatusOK)
+	post(url.Values{"op": {"logy"}}, http.StatusOK)
+	_ = get("/plot.svg", "image/svg+xml")
+	cmpRanges(t, v, [4]float64{0, 10, 0, 10})
+
+	post(url.Values{"op": {"zoom"}, "x1": {"NaN-ish"}}, http.StatusBadRequest)
+	post(url.Values{"op": {"not-there"}}, http.StatusBadRequest)
+
+	resp, err := http.Get(srv.URL + "/cmd")
+	if err != nil {
+		t.Fatalf("could not get /cmd: %+v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("invalid status code: got=%v, want=%v", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
+
+func fmtFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,178 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hview
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"go-hep.org/x/exp/vgshiny"
+	"golang.org/x/exp/shiny/driver"
+	"golang.org/x/exp/shiny/screen"
+	"golang.org/x/mobile/event/key"
+	"golang.org/x/mobile/event/lifecycle"
+	"golang.org/x/mobile/event/mouse"
+	"golang.org/x/mobile/event/paint"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// wheelFactor is the zoom factor applied for each step of the mouse wheel.
+const wheelFactor = 0.8
+
+// refreshEvent is sent to the window to request a refresh of the view.
+type refreshEvent struct{}
+
+// Run displays the view in a new window and handles user interactions
+// until the window is closed.
+//
+// Run must be called from the main goroutine, as it calls
+// golang.org/x/exp/shiny/driver.Main.
+//
+// Keyboard and mouse bindings:
+//  - left button drag: zoom on the selected rectangle,
+//  - right or middle button drag: pan,
+//  - mouse wheel: zoom in/out around the mouse position,
+//  - 'l': toggle log-scale on the y-axis,
+//  - 'k': toggle log-scale on the x-axis,
+//  - 'u' or backspace: undo the last zoom,
+//  - 'r' or home: reset the axes ranges,
+//  - 'f' or space: refresh the view,
+//  - 'q' or escape: quit.
+func Run(v *View) error {
+	var err error
+	driver.Main(func(s screen.Screen) {
+		err = Show(s, v)
+	})
+	return err
+}
+
+// Show displays the view in a new window of the provided screen and
+// handles user interactions until the window is closed.
+func Show(s screen.Screen, v *View) error {
+	c, err := vgshiny.New(s, v.w, v.h)
+	if err != nil {
+		return fmt.Errorf("hview: could not create shiny canvas: %w", err)
+	}
+	defer c.Release()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	if period := v.Period(); period > 0 {
+		go func() {
+			tick := time.NewTicker(period)
+			defer tick.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-tick.C:
+					c.Send(refreshEvent{})
+				}
+			}
+		}()
+	}
+
+	var (
+		dpi    = vg.Length(vgimg.DefaultDPI)
+		beg    vg.Point
+		btn    mouse.Button
+		redraw = func() {
+			v.Draw(draw.New(c))
+			c.Paint()
+		}
+	)
+
+	// pt converts a position in pixels to a point on the canvas.
+	pt := func(e mouse.Event) vg.Point {
+		return vg.Point{
+			X: vg.Length(e.X) / dpi * vg.Inch,
+			Y: v.h - vg.Length(e.Y)/dpi*vg.Inch,
+		}
+	}
+
+	redraw()
+	c.Run(func(e interface{}) bool {
+		switch e := e.(type) {
+		case lifecycle.Event:
+			if e.To == lifecycle.StageDead {
+				return false
+			}
+
+		case paint.Event:
+			c.Paint()
+
+		case refreshEvent:
+			err := v.Refresh()
+			if err != nil {
+				log.Printf("%+v", err)
+			}
+			redraw()
+
+		case key.Event:
+			if e.Direction != key.DirPress {
+				break
+			}
+			switch e.Code {
+			case key.CodeEscape, key.CodeQ:
+				return false
+			case key.CodeL:
+				v.ToggleLogY()
+			case key.CodeK:
+				v.ToggleLogX()
+			case key.CodeU, key.CodeDeleteBackspace:
+				v.Unzoom()
+			case key.CodeR, key.CodeHome:
+				v.Reset()
+			case key.CodeF, key.CodeSpacebar:
+				err := v.Refresh()
+				if err != nil {
+					log.Printf("%+v", err)
+				}
+			default:
+				return true
+			}
+			redraw()
+
+		case mouse.Event:
+			switch {
+			case e.Button.IsWheel():
+				if e.Direction != mouse.DirStep && e.Direction != mouse.DirPress {
+					break
+				}
+				switch e.Button {
+				case mouse.ButtonWheelUp:
+					v.ZoomAt(pt(e), wheelFactor)
+				case mouse.ButtonWheelDown:
+					v.ZoomAt(pt(e), 1/wheelFactor)
+				default:
+					return true
+				}
+				redraw()
+
+			case e.Direction == mouse.DirPress:
+				beg = pt(e)
+				btn = e.Button
+
+			case e.Direction == mouse.DirRelease && e.Button == btn:
+				end := pt(e)
+				switch btn {
+				case mouse.ButtonLeft:
+					v.Zoom(beg, end)
+				case mouse.ButtonMiddle, mouse.ButtonRight:
+					v.Pan(beg, end)
+				}
+				btn = mouse.ButtonNone
+				redraw()
+			}
+		}
+		return true
+	})
+
+	return nil
+}
@@ -17,6 +17,8 @@ import (
 type Plot struct {
 	*plot.Plot
 	Style Style
+
+	rangers []plot.DataRanger // data rangers added to the plot
 }
 
 // New returns a new plot with some reasonable
@@ -51,6 +53,7 @@ func New() *Plot {
 func (p *Plot) Add(ps ...plot.Plotter) {
 	for _, d := range ps {
 		if x, ok := d.(plot.DataRanger); ok {
+			p.rangers = append(p.rangers, x)
 			xmin, xmax, ymin, ymax := x.DataRange()
 			p.Plot.X.Min = math.Min(p.Plot.X.Min, xmin)
 			p.Plot.X.Max = math.Max(p.Plot.X.Max, xmax)
@@ -62,6 +65,27 @@ func (p *Plot) Add(ps ...plot.Plotter) {
 	p.Plot.Add(ps...)
 }
 
+// DataRange returns the minimum and maximum x and y values of the
+// plotters added to the plot that implement DataRanger.
+//
+// Contrary to the ranges of the X and Y axes of the plot, which are
+// updated when plotters are added, DataRange reflects the current
+// state of the data (e.g. histograms still being filled.)
+func (p *Plot) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin = math.Inf(+1)
+	xmax = math.Inf(-1)
+	ymin = math.Inf(+1)
+	ymax = math.Inf(-1)
+	for _, x := range p.rangers {
+		xmin1, xmax1, ymin1, ymax1 := x.DataRange()
+		xmin = math.Min(xmin, xmin1)
+		xmax = math.Max(xmax, xmax1)
+		ymin = math.Min(ymin, ymin1)
+		ymax = math.Max(ymax, ymax1)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
 // Save saves the plot to an image file.  The file format is determined
 // by the extension.
 //
@@ -98,5 +122,6 @@ func (p *Plot) Draw(dc draw.Canvas) {
 }
 
 var (
-	_ Drawer = (*Plot)(nil)
+	_ Drawer          = (*Plot)(nil)
+	_ plot.DataRanger = (*Plot)(nil)
 )
@@ -0,0 +1,160 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot
+
+import (
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Violin implements the plot.Plotter interface, drawing violin plots of
+// a set of distributions.
+//
+// A violin is the mirrored density of a distribution, scaled so its
+// widest point spans the drawing width of the distribution.
+// The density of binned distributions is drawn from their bins contents.
+// The density of unbinned distributions is estimated with a gaussian
+// kernel.
+type Violin struct {
+	// Dists are the distributions to display.
+	Dists []Distribution
+
+	// Width is the fraction of the width of each distribution used to
+	// draw its violin.
+	Width float64
+
+	// FillColor is the color used to fill the violins.
+	// Use nil to disable the filling.
+	FillColor color.Color
+
+	// LineStyle is the style of the outline of the violins.
+	draw.LineStyle
+
+	// Points is the number of points used to sample the density of
+	// unbinned distributions.
+	Points int
+
+	// QuartileStyle is the style of the line drawn inside the violins,
+	// spanning the inter-quartile range of the distributions.
+	// Use a zero width to disable the line.
+	QuartileStyle draw.LineStyle
+
+	// MedianStyle is the style of the glyph marking the median of the
+	// distributions.
+	// Use a zero radius to disable the glyph.
+	MedianStyle draw.GlyphStyle
+}
+
+// NewViolin returns a violin plot of the provided distributions.
+func NewViolin(dists []Distribution) *Violin {
+	return &Violin{
+		Dists:     dists,
+		Width:     0.9,
+		FillColor: color.NRGBA{R: 0x99, G: 0xbb, B: 0xdd, A: 0xff},
+		LineStyle: plotter.DefaultLineStyle,
+		Points:    100,
+		QuartileStyle: draw.LineStyle{
+			Color: color.Black,
+			Width: vg.Points(3),
+		},
+		MedianStyle: draw.GlyphStyle{
+			Color:  color.White,
+			Radius: vg.Points(1.5),
+			Shape:  draw.CircleGlyph{},
+		},
+	}
+}
+
+// Plot implements the Plotter interface, drawing the density of each
+// distribution.
+func (v *Violin) Plot(c draw.Canvas, plt *plot.Plot) {
+	var (
+		trX, trY = plt.Transforms(&c)
+		dw       = distWidth(v.Dists)
+	)
+
+	for i := range v.Dists {
+		d := &v.Dists[i]
+		ys, ds := d.density(v.Points)
+		if len(ys) == 0 {
+			continue
+		}
+		dmax := 0.0
+		for _, dv := range ds {
+			if dv > dmax {
+				dmax = dv
+			}
+		}
+		if dmax <= 0 {
+			continue
+		}
+
+		w := d.Width
+		if w <= 0 {
+			w = dw
+		}
+		var (
+			half = 0.5 * w * v.Width
+			pts  = make([]vg.Point, 0, 2*len(ys)+1)
+		)
+		for j, y := range ys {
+			pts = append(pts, vg.Point{X: trX(d.X + ds[j]/dmax*half), Y: trY(y)})
+		}
+		for j := len(ys) - 1; j >= 0; j-- {
+			pts = append(pts, vg.Point{X: trX(d.X - ds[j]/dmax*half), Y: trY(ys[j])})
+		}
+
+		if v.FillColor != nil {
+			c.FillPolygon(v.FillColor, c.ClipPolygonXY(pts))
+		}
+		pts = append(pts, pts[0])
+		c.StrokeLines(v.LineStyle, c.ClipLinesXY(pts)...)
+
+		xm := trX(d.X)
+		if v.QuartileStyle.Width > 0 {
+			c.StrokeLines(v.QuartileStyle, c.ClipLinesXY(
+				[]vg.Point{{X: xm, Y: trY(d.Q1)}, {X: xm, Y: trY(d.Q3)}},
+			)...)
+		}
+		if v.MedianStyle.Radius > 0 {
+			pt := vg.Point{X: xm, Y: trY(d.Median)}
+			if c.Contains(pt) {
+				c.DrawGlyph(v.MedianStyle, pt)
+			}
+		}
+	}
+}
+
+// DataRange returns the minimum and maximum x and y values, implementing
+// the plot.DataRanger interface.
+func (v *Violin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return distRange(v.Dists, v.Width)
+}
+
+// Thumbnail draws a box in the given style, implementing the
+// plot.Thumbnailer interface.
+func (v *Violin) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{X: c.Min.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Min.Y},
+		{X: c.Max.X, Y: c.Max.Y},
+		{X: c.Min.X, Y: c.Max.Y},
+	}
+	if v.FillColor != nil {
+		c.FillPolygon(v.FillColor, c.ClipPolygonXY(pts))
+	}
+	pts = append(pts, pts[0])
+	c.StrokeLines(v.LineStyle, c.ClipLinesXY(pts)...)
+}
+
+var (
+	_ plot.Plotter     = (*Violin)(nil)
+	_ plot.DataRanger  = (*Violin)(nil)
+	_ plot.Thumbnailer = (*Violin)(nil)
+)
@@ -0,0 +1,19 @@
+// Copyright ©2020 The go-hep Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hplot_test
+
+import (
+	"testing"
+
+	"gonum.org/v1/plot/cmpimg"
+)
+
+func TestViolin(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleViolin, t, "violin.png")
+}
+
+func TestViolinH2D(t *testing.T) {
+	checkPlot(cmpimg.CheckPlot)(ExampleViolin_h2d, t, "violin_h2d.png")
+}